@@ -4,15 +4,20 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"strings"
 
 	"connectrpc.com/connect"
 	"github.com/samber/do"
 	"github.com/spf13/cobra"
+	"go.uber.org/zap"
 
 	"github.com/sweetloveinyourheart/sweet-reel/pkg/cmdutil"
 	"github.com/sweetloveinyourheart/sweet-reel/pkg/config"
 	"github.com/sweetloveinyourheart/sweet-reel/pkg/interceptors"
+	"github.com/sweetloveinyourheart/sweet-reel/pkg/kafka"
 	"github.com/sweetloveinyourheart/sweet-reel/pkg/logger"
+	"github.com/sweetloveinyourheart/sweet-reel/pkg/messages"
+	"github.com/sweetloveinyourheart/sweet-reel/pkg/s3"
 	authConnect "github.com/sweetloveinyourheart/sweet-reel/proto/code/auth/go/grpcconnect"
 	userConnect "github.com/sweetloveinyourheart/sweet-reel/proto/code/user/go/grpcconnect"
 	videoManagementConnect "github.com/sweetloveinyourheart/sweet-reel/proto/code/video_management/go/grpcconnect"
@@ -71,7 +76,12 @@ func Command(rootCmd *cobra.Command) *cobra.Command {
 	config.StringDefault(apiGatewayCommand, fmt.Sprintf("%s.user_server.url", serviceType), "user-server-url", "http://user:50065", "User server connection URL", "API_GATEWAY_USER_SERVER_URL")
 	config.StringDefault(apiGatewayCommand, fmt.Sprintf("%s.video_management.url", serviceType), "video-management-url", "http://video_management:50060", "Video Management server connection URL", "API_GATEWAY_VIDEO_MANAGEMENT_SERVER_URL")
 
+	config.StringDefault(apiGatewayCommand, fmt.Sprintf("%s.http.trusted_proxies", serviceType), "http-trusted-proxies", "", "CIDRs (comma-separated) of the proxies whose X-Forwarded-For and X-Real-IP headers are honored", "API_GATEWAY_HTTP_TRUSTED_PROXIES")
+
+	config.StringDefault(apiGatewayCommand, fmt.Sprintf("%s.storage.local.notify_kafka_brokers", serviceType), "storage-local-notify-kafka-brokers", "", "Kafka brokers (comma-separated) notified of uploaded videos when the local storage backend is used", "API_GATEWAY_STORAGE_LOCAL_NOTIFY_KAFKA_BROKERS")
+
 	cmdutil.BoilerplateFlagsCore(apiGatewayCommand, serviceType, envPrefix)
+	cmdutil.BoilerplateFlagsStorage(apiGatewayCommand, serviceType, envPrefix)
 	cmdutil.BoilerplateSecureFlags(apiGatewayCommand, serviceType)
 
 	return apiGatewayCommand
@@ -81,7 +91,12 @@ func setupHTTPServer(ctx context.Context) error {
 	port := config.Instance().GetUint64(fmt.Sprintf("%s.http.port", serviceType))
 	signingKey := config.Instance().GetString(fmt.Sprintf("%s.secrets.token_signing_key", serviceType))
 
-	server := apigateway.NewServer(ctx, port, signingKey)
+	var trustedProxies []string
+	if proxies := config.Instance().GetString(fmt.Sprintf("%s.http.trusted_proxies", serviceType)); proxies != "" {
+		trustedProxies = strings.Split(proxies, ",")
+	}
+
+	server := apigateway.NewServer(ctx, port, signingKey, trustedProxies)
 
 	go server.Start(port)
 
@@ -128,5 +143,54 @@ func setupDependencies(ctx context.Context) error {
 		return videoManagementClient, nil
 	})
 
+	if err := setupLocalStorage(ctx); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// setupLocalStorage provides the storage the gateway serves presigned URLs of when the local
+// storage backend is used. Like MinIO, it notifies Kafka of every uploaded video.
+func setupLocalStorage(ctx context.Context) error {
+	cfg := s3.ServiceConfig(serviceType)
+	if cfg.Backend != s3.StorageBackendLocal {
+		return nil
+	}
+
+	storage, err := s3.NewLocalStorage(cfg.Local)
+	if err != nil {
+		return err
+	}
+
+	if brokers := config.Instance().GetString(fmt.Sprintf("%s.storage.local.notify_kafka_brokers", serviceType)); brokers != "" {
+		kafkaConfig := kafka.DefaultConfig()
+		kafkaConfig.Brokers = strings.Split(brokers, ",")
+		for i, broker := range kafkaConfig.Brokers {
+			kafkaConfig.Brokers[i] = strings.TrimSpace(broker)
+		}
+
+		kafkaClient, err := kafka.NewClient(kafkaConfig)
+		if err != nil {
+			return err
+		}
+
+		// Gracefull shutdown
+		go func() {
+			<-ctx.Done()
+			kafkaClient.Close()
+		}()
+
+		storage.OnObjectCreated(s3.S3VideoUploadedBucket, func(event messages.S3EventMessage) {
+			if _, _, err := kafkaClient.SendJSON(ctx, kafka.KafkaVideoUploadedTopic, event.Key, event); err != nil {
+				logger.Global().Error("failed to publish upload notification", zap.String("key", event.Key), zap.Error(err))
+			}
+		})
+	}
+
+	do.Provide(nil, func(i *do.Injector) (*s3.LocalStorage, error) {
+		return storage, nil
+	})
+
 	return nil
 }
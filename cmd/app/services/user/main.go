@@ -15,6 +15,7 @@ import (
 	"github.com/sweetloveinyourheart/sweet-reel/pkg/grpc"
 	"github.com/sweetloveinyourheart/sweet-reel/pkg/interceptors"
 	authInterceptor "github.com/sweetloveinyourheart/sweet-reel/pkg/interceptors/auth"
+	"github.com/sweetloveinyourheart/sweet-reel/pkg/kafka"
 	"github.com/sweetloveinyourheart/sweet-reel/pkg/logger"
 	"github.com/sweetloveinyourheart/sweet-reel/proto/code/user/go/grpcconnect"
 	user "github.com/sweetloveinyourheart/sweet-reel/services/user"
@@ -75,6 +76,7 @@ func Command(rootCmd *cobra.Command) *cobra.Command {
 	config.Int64Default(userManagementCommand, fmt.Sprintf("%s.grpc.port", serviceType), "grpc-port", DEFAULT_USER_GRPC_PORT, "GRPC Port to listen on", "USER_GRPC_PORT")
 
 	cmdutil.BoilerplateFlagsCore(userManagementCommand, serviceType, envPrefix)
+	cmdutil.BoilerplateFlagsKafka(userManagementCommand, serviceType, envPrefix)
 	cmdutil.BoilerplateFlagsDB(userManagementCommand, serviceType, envPrefix)
 	cmdutil.BoilerplateSecureFlags(userManagementCommand, serviceType)
 
@@ -102,6 +104,11 @@ func setupGrpcServer(ctx context.Context) error {
 }
 
 func setupDependencies(ctx context.Context) error {
+	kafkaClient, err := initKafkaClient(ctx)
+	if err != nil {
+		return err
+	}
+
 	dbConn, err := initDBConnection()
 	if err != nil {
 		return err
@@ -122,9 +129,31 @@ func setupDependencies(ctx context.Context) error {
 		return channelRepo, nil
 	})
 
+	do.Provide(nil, func(i *do.Injector) (*kafka.Client, error) {
+		return kafkaClient, nil
+	})
+
 	return nil
 }
 
+func initKafkaClient(ctx context.Context) (*kafka.Client, error) {
+	cfg := kafka.ServiceConfig(serviceType)
+
+	// Init client
+	client, err := kafka.NewClient(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	// Gracefull shutdown
+	go func() {
+		<-ctx.Done()
+		client.Close()
+	}()
+
+	return client, nil
+}
+
 func initDBConnection() (*pgxpool.Pool, error) {
 	dbConn, err := db.NewDbWithWait(config.Instance().GetString(fmt.Sprintf("%s.db.url", serviceType)), db.DBOptions{
 		TimeoutSec:      config.Instance().GetInt(fmt.Sprintf("%s.db.postgres.timeout", serviceType)),
@@ -3,6 +3,7 @@ package videomanagement
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"connectrpc.com/connect"
 	"github.com/jackc/pgx/v5/pgxpool"
@@ -20,6 +21,10 @@ import (
 	"github.com/sweetloveinyourheart/sweet-reel/proto/code/video_management/go/grpcconnect"
 	videomanagement "github.com/sweetloveinyourheart/sweet-reel/services/video_management"
 	"github.com/sweetloveinyourheart/sweet-reel/services/video_management/actions"
+	"github.com/sweetloveinyourheart/sweet-reel/services/video_management/domains/channelstats"
+	"github.com/sweetloveinyourheart/sweet-reel/services/video_management/domains/contentkeys"
+	"github.com/sweetloveinyourheart/sweet-reel/services/video_management/domains/publishing"
+	"github.com/sweetloveinyourheart/sweet-reel/services/video_management/domains/uploads"
 	"github.com/sweetloveinyourheart/sweet-reel/services/video_management/repos"
 )
 
@@ -45,7 +50,12 @@ func Command(rootCmd *cobra.Command) *cobra.Command {
 				logger.GlobalSugared().Fatal(err)
 			}
 
-			if err := videomanagement.InitializeRepos(app.Ctx()); err != nil {
+			publishInterval := time.Duration(config.Instance().GetInt64(fmt.Sprintf("%s.publish_scheduler.interval_seconds", serviceType))) * time.Second
+			reconcileInterval := time.Duration(config.Instance().GetInt64(fmt.Sprintf("%s.channel_stats.reconcile_interval_seconds", serviceType))) * time.Second
+			viewsFlushInterval := time.Duration(config.Instance().GetInt64(fmt.Sprintf("%s.channel_stats.views_flush_interval_seconds", serviceType))) * time.Second
+			uploadCleanupInterval := time.Duration(config.Instance().GetInt64(fmt.Sprintf("%s.uploads.cleanup_interval_seconds", serviceType))) * time.Second
+			uploadTTL := time.Duration(config.Instance().GetInt64(fmt.Sprintf("%s.uploads.multipart_ttl_seconds", serviceType))) * time.Second
+			if err := videomanagement.InitializeRepos(app.Ctx(), publishInterval, reconcileInterval, viewsFlushInterval, uploadCleanupInterval, uploadTTL); err != nil {
 				logger.GlobalSugared().Fatal(err)
 			}
 
@@ -79,11 +89,20 @@ func Command(rootCmd *cobra.Command) *cobra.Command {
 	config.String(videoManagementCommand, fmt.Sprintf("%s.aws.s3.secret", serviceType), "aws_s3_secret", "s3 secret", "VIDEO_MANAGEMENT_AWS_S3_SECRET")
 	config.String(videoManagementCommand, fmt.Sprintf("%s.aws.s3.bucket", serviceType), "s3_bucket", "s3 bucket", "VIDEO_MANAGEMENT_AWS_S3_BUCKET")
 	config.StringDefault(videoManagementCommand, fmt.Sprintf("%s.minio.url", serviceType), "minio-url", "", "MINIO URL", "VIDEO_MANAGEMENT_MINIO_URL")
+	config.Int64Default(videoManagementCommand, fmt.Sprintf("%s.publish_scheduler.interval_seconds", serviceType), "publish-scheduler-interval-seconds", int64(publishing.DefaultPublishInterval.Seconds()), "Seconds between checks for scheduled videos that are due to be published", "VIDEO_MANAGEMENT_PUBLISH_SCHEDULER_INTERVAL_SECONDS")
+	config.Int64Default(videoManagementCommand, fmt.Sprintf("%s.channel_stats.reconcile_interval_seconds", serviceType), "channel-stats-reconcile-interval-seconds", int64(channelstats.DefaultReconcileInterval.Seconds()), "Seconds between republishing the view and video totals of every channel", "VIDEO_MANAGEMENT_CHANNEL_STATS_RECONCILE_INTERVAL_SECONDS")
+	config.Int64Default(videoManagementCommand, fmt.Sprintf("%s.channel_stats.views_flush_interval_seconds", serviceType), "channel-stats-views-flush-interval-seconds", int64(channelstats.DefaultViewsFlushInterval.Seconds()), "Seconds between publishing the view totals of the channels viewed lately", "VIDEO_MANAGEMENT_CHANNEL_STATS_VIEWS_FLUSH_INTERVAL_SECONDS")
+	config.Int64Default(videoManagementCommand, fmt.Sprintf("%s.uploads.cleanup_interval_seconds", serviceType), "upload-cleanup-interval-seconds", int64(uploads.DefaultUploadCleanupInterval.Seconds()), "Seconds between checks for abandoned multipart uploads", "VIDEO_MANAGEMENT_UPLOAD_CLEANUP_INTERVAL_SECONDS")
+	config.Int64Default(videoManagementCommand, fmt.Sprintf("%s.uploads.multipart_ttl_seconds", serviceType), "multipart-upload-ttl-seconds", int64(uploads.DefaultUploadTTL.Seconds()), "Seconds a multipart upload may stay in progress before it is aborted and its video deleted", "VIDEO_MANAGEMENT_MULTIPART_UPLOAD_TTL_SECONDS")
+
+	config.String(videoManagementCommand, fmt.Sprintf("%s.secrets.content_key_encryption_key", serviceType), "content-key-encryption-key", "Key sealing the content keys of encrypted videos before they are stored, encrypted videos are refused without it", "VIDEO_MANAGEMENT_SECRETS_CONTENT_KEY_ENCRYPTION_KEY")
 
 	cmdutil.BoilerplateFlagsCore(videoManagementCommand, serviceType, envPrefix)
 	cmdutil.BoilerplateFlagsKafka(videoManagementCommand, serviceType, envPrefix)
 	cmdutil.BoilerplateFlagsDB(videoManagementCommand, serviceType, envPrefix)
+	cmdutil.BoilerplateFlagsStorage(videoManagementCommand, serviceType, envPrefix)
 	cmdutil.BoilerplateSecureFlags(videoManagementCommand, serviceType)
+	config.SecureFields(fmt.Sprintf("%s.secrets.content_key_encryption_key", serviceType))
 
 	return videoManagementCommand
 }
@@ -123,6 +142,23 @@ func setupDependencies(ctx context.Context) error {
 		return err
 	}
 
+	// Events are written to the outbox together with the rows they describe, the relay publishes them
+	kafka.NewOutboxRelay(kafka.NewPostgresOutboxStore(dbConn), kafkaClient, kafka.DefaultOutboxRelayConfig()).Start(ctx)
+
+	// Consumed messages are deduplicated in the same database, old records are purged
+	kafka.NewPostgresProcessedMessageStore(dbConn).StartPurge(ctx, kafka.DefaultProcessedMessageRetention)
+
+	// Without a secret content keys can't be sealed, so encrypted videos are refused
+	var contentKeyCipher *contentkeys.Cipher
+	if secret := config.Instance().GetString(fmt.Sprintf("%s.secrets.content_key_encryption_key", serviceType)); secret != "" {
+		contentKeyCipher, err = contentkeys.NewCipher(secret)
+		if err != nil {
+			return err
+		}
+	} else {
+		logger.Global().Warn("No content key encryption key configured, encrypted videos are not supported")
+	}
+
 	videoAggregateRepo := repos.NewVideoAggregateRepository(dbConn)
 
 	do.Provide(nil, func(i *do.Injector) (repos.IVideoAggregateRepository, error) {
@@ -137,6 +173,10 @@ func setupDependencies(ctx context.Context) error {
 		return s3Client, nil
 	})
 
+	do.Provide(nil, func(i *do.Injector) (*contentkeys.Cipher, error) {
+		return contentKeyCipher, nil
+	})
+
 	return nil
 }
 
@@ -160,7 +200,7 @@ func initKafkaClient(ctx context.Context) (*kafka.Client, error) {
 
 func initS3Client(ctx context.Context) (s3.S3Storage, error) {
 	cfg := s3.ServiceConfig(serviceType)
-	s3Client, err := s3.CreateS3Client(ctx, cfg)
+	s3Client, err := s3.NewStorage(ctx, cfg)
 	if err != nil {
 		return nil, err
 	}
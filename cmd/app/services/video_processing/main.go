@@ -3,22 +3,32 @@ package appvideoprocessing
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"time"
 
+	"connectrpc.com/connect"
+	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/samber/do"
 	"github.com/spf13/cobra"
 
 	"github.com/sweetloveinyourheart/sweet-reel/pkg/cmdutil"
 	"github.com/sweetloveinyourheart/sweet-reel/pkg/config"
+	"github.com/sweetloveinyourheart/sweet-reel/pkg/db"
+	"github.com/sweetloveinyourheart/sweet-reel/pkg/ffmpeg"
+	"github.com/sweetloveinyourheart/sweet-reel/pkg/interceptors"
 	"github.com/sweetloveinyourheart/sweet-reel/pkg/kafka"
 	"github.com/sweetloveinyourheart/sweet-reel/pkg/logger"
 	"github.com/sweetloveinyourheart/sweet-reel/pkg/s3"
+	videoManagementConnect "github.com/sweetloveinyourheart/sweet-reel/proto/code/video_management/go/grpcconnect"
 	videoprocessing "github.com/sweetloveinyourheart/sweet-reel/services/video_processing"
+	"github.com/sweetloveinyourheart/sweet-reel/services/video_processing/domains/processing"
 )
 
 const DEFAULT_VIDEO_PROCESSING_GRPC_PORT = 50055
 
 const serviceType = "video_processing"
 const envPrefix = "VIDEO_PROCESSING"
+const dbTablePrefix = "video-processing"
 
 func Command(rootCmd *cobra.Command) *cobra.Command {
 	var videoProcessingCommand = &cobra.Command{
@@ -30,15 +40,33 @@ func Command(rootCmd *cobra.Command) *cobra.Command {
 				logger.GlobalSugared().Fatal(err)
 			}
 
+			app.Migrations(videoprocessing.FS, dbTablePrefix)
+
 			if err := setupDependencies(app.Ctx()); err != nil {
 				logger.GlobalSugared().Fatal(err)
 			}
 
-			if err := videoprocessing.InitializeRepos(app.Ctx()); err != nil {
+			workerConfig := &processing.WorkerConfig{
+				Concurrency:      config.Instance().GetInt(fmt.Sprintf("%s.worker.concurrency", serviceType)),
+				DrainTimeout:     time.Duration(config.Instance().GetInt64(fmt.Sprintf("%s.worker.drain_timeout_seconds", serviceType))) * time.Second,
+				Validation:       processing.DefaultValidationConfig(),
+				ProgressInterval: time.Duration(config.Instance().GetInt64(fmt.Sprintf("%s.worker.progress_interval_ms", serviceType))) * time.Millisecond,
+				Packaging:        config.Instance().GetString(fmt.Sprintf("%s.worker.packaging", serviceType)),
+				Encryption:       config.Instance().GetBool(fmt.Sprintf("%s.worker.encryption", serviceType)),
+			}
+			workerConfig.Validation.MaxFileSize = config.Instance().GetInt64(fmt.Sprintf("%s.validation.max_file_size_bytes", serviceType))
+			workerConfig.Validation.MinDurationSeconds = config.Instance().GetFloat64(fmt.Sprintf("%s.validation.min_duration_seconds", serviceType))
+			workerConfig.Validation.MaxDurationSeconds = config.Instance().GetFloat64(fmt.Sprintf("%s.validation.max_duration_seconds", serviceType))
+
+			manager, err := videoprocessing.InitializeRepos(app.Ctx(), workerConfig)
+			if err != nil {
 				logger.GlobalSugared().Fatal(err)
 			}
 
 			app.Run()
+
+			// Let running transcodes finish (or be handed back) before exiting
+			manager.Wait()
 		},
 		Args: func(cmd *cobra.Command, args []string) error {
 			return nil
@@ -64,9 +92,21 @@ func Command(rootCmd *cobra.Command) *cobra.Command {
 	config.String(videoProcessingCommand, fmt.Sprintf("%s.aws.s3.secret", serviceType), "aws_s3_secret", "s3 secret", "VIDEO_PROCESSING_AWS_S3_SECRET")
 	config.String(videoProcessingCommand, fmt.Sprintf("%s.aws.s3.bucket", serviceType), "s3_bucket", "s3 bucket", "VIDEO_PROCESSING_AWS_S3_BUCKET")
 	config.StringDefault(videoProcessingCommand, fmt.Sprintf("%s.minio.url", serviceType), "minio-url", "", "MINIO URL", "VIDEO_PROCESSING_MINIO_URL")
+	config.Int64Default(videoProcessingCommand, fmt.Sprintf("%s.worker.concurrency", serviceType), "worker-concurrency", processing.DefaultWorkerConcurrency, "Maximum number of videos transcoded concurrently on this node", "VIDEO_PROCESSING_WORKER_CONCURRENCY")
+	config.Int64Default(videoProcessingCommand, fmt.Sprintf("%s.worker.drain_timeout_seconds", serviceType), "worker-drain-timeout-seconds", int64(processing.DefaultDrainTimeout.Seconds()), "Seconds running transcodes may take to finish on shutdown before they are handed back", "VIDEO_PROCESSING_WORKER_DRAIN_TIMEOUT_SECONDS")
+
+	config.Int64Default(videoProcessingCommand, fmt.Sprintf("%s.worker.progress_interval_ms", serviceType), "worker-progress-interval-ms", processing.DefaultProgressInterval.Milliseconds(), "Milliseconds between two published transcoding progress updates of a video", "VIDEO_PROCESSING_WORKER_PROGRESS_INTERVAL_MS")
+	config.StringDefault(videoProcessingCommand, fmt.Sprintf("%s.worker.packaging", serviceType), "worker-packaging", processing.DefaultPackaging, "Packaging of processed segments: ts for HLS only, cmaf for fMP4 segments shared by HLS and DASH", "VIDEO_PROCESSING_WORKER_PACKAGING")
+	config.BoolDefault(videoProcessingCommand, fmt.Sprintf("%s.worker.encryption", serviceType), "worker-encryption", false, "Encrypt the segments of every video with its own AES-128 key, handed out by video management to entitled viewers. Requires ts packaging", "VIDEO_PROCESSING_WORKER_ENCRYPTION")
+	config.Int64Default(videoProcessingCommand, fmt.Sprintf("%s.validation.max_file_size_bytes", serviceType), "validation-max-file-size-bytes", processing.DefaultMaxFileSize, "Largest upload transcoded, in bytes", "VIDEO_PROCESSING_VALIDATION_MAX_FILE_SIZE_BYTES")
+	config.Int64Default(videoProcessingCommand, fmt.Sprintf("%s.validation.min_duration_seconds", serviceType), "validation-min-duration-seconds", processing.DefaultMinDurationSeconds, "Shortest video transcoded, in seconds", "VIDEO_PROCESSING_VALIDATION_MIN_DURATION_SECONDS")
+	config.Int64Default(videoProcessingCommand, fmt.Sprintf("%s.validation.max_duration_seconds", serviceType), "validation-max-duration-seconds", processing.DefaultMaxDurationSeconds, "Longest video transcoded, in seconds", "VIDEO_PROCESSING_VALIDATION_MAX_DURATION_SECONDS")
+	config.StringDefault(videoProcessingCommand, fmt.Sprintf("%s.video_management.url", serviceType), "video-management-url", "http://video_management:50060", "Video Management server connection URL", "VIDEO_PROCESSING_VIDEO_MANAGEMENT_SERVER_URL")
 
 	cmdutil.BoilerplateFlagsCore(videoProcessingCommand, serviceType, envPrefix)
 	cmdutil.BoilerplateFlagsKafka(videoProcessingCommand, serviceType, envPrefix)
+	cmdutil.BoilerplateFlagsDB(videoProcessingCommand, serviceType, envPrefix)
+	cmdutil.BoilerplateFlagsStorage(videoProcessingCommand, serviceType, envPrefix)
 	cmdutil.BoilerplateSecureFlags(videoProcessingCommand, serviceType)
 
 	return videoProcessingCommand
@@ -83,14 +123,45 @@ func setupDependencies(ctx context.Context) error {
 		return err
 	}
 
+	dbConn, err := initDBConnection()
+	if err != nil {
+		return err
+	}
+
+	// Remembers the uploads already transcoded, so that redelivered messages are skipped
+	processedMessageStore := kafka.NewPostgresProcessedMessageStore(dbConn)
+	processedMessageStore.StartPurge(ctx, kafka.DefaultProcessedMessageRetention)
+
+	do.Provide(nil, func(i *do.Injector) (kafka.ProcessedMessageStore, error) {
+		return processedMessageStore, nil
+	})
+
 	do.Provide(nil, func(i *do.Injector) (*kafka.Client, error) {
 		return kafkaClient, nil
 	})
 
-	do.Provide(nil, func(i *do.Injector) (s3.S3Storage, error) {
+	do.Provide(nil, func(i *do.Injector) (s3.S3StreamStorage, error) {
 		return s3Client, nil
 	})
 
+	do.Provide(nil, func(i *do.Injector) (ffmpeg.FFmpegInterface, error) {
+		return ffmpeg.New(), nil
+	})
+
+	// Uploads are only processed while their video waits for them
+	videoManagementClient := videoManagementConnect.NewVideoManagementClient(
+		http.DefaultClient,
+		config.Instance().GetString(fmt.Sprintf("%s.video_management.url", serviceType)),
+		connect.WithInterceptors(interceptors.CommonConnectClientInterceptors(
+			serviceType,
+			config.Instance().GetString(fmt.Sprintf("%s.secrets.token_signing_key", serviceType)),
+		)...),
+	)
+
+	do.Provide(nil, func(i *do.Injector) (videoManagementConnect.VideoManagementClient, error) {
+		return videoManagementClient, nil
+	})
+
 	return nil
 }
 
@@ -112,12 +183,28 @@ func initKafkaClient(ctx context.Context) (*kafka.Client, error) {
 	return client, nil
 }
 
-func initS3Client(ctx context.Context) (s3.S3Storage, error) {
+func initS3Client(ctx context.Context) (s3.S3StreamStorage, error) {
 	cfg := s3.ServiceConfig(serviceType)
-	s3Client, err := s3.CreateS3Client(ctx, cfg)
+	s3Client, err := s3.NewStorage(ctx, cfg)
 	if err != nil {
 		return nil, err
 	}
 
 	return s3Client, nil
 }
+
+func initDBConnection() (*pgxpool.Pool, error) {
+	dbConn, err := db.NewDbWithWait(config.Instance().GetString(fmt.Sprintf("%s.db.url", serviceType)), db.DBOptions{
+		TimeoutSec:      config.Instance().GetInt(fmt.Sprintf("%s.db.postgres.timeout", serviceType)),
+		MaxOpenConns:    config.Instance().GetInt(fmt.Sprintf("%s.db.postgres.max_open_connections", serviceType)),
+		MaxIdleConns:    config.Instance().GetInt(fmt.Sprintf("%s.db.postgres.max_idle_connections", serviceType)),
+		ConnMaxLifetime: config.Instance().GetInt(fmt.Sprintf("%s.db.postgres.max_lifetime", serviceType)),
+		ConnMaxIdleTime: config.Instance().GetInt(fmt.Sprintf("%s.db.postgres.max_idletime", serviceType)),
+		EnableTracing:   config.Instance().GetBool(fmt.Sprintf("%s.db.tracing", serviceType)),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return dbConn, nil
+}
@@ -0,0 +1,80 @@
+package apputils
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	"github.com/sweetloveinyourheart/sweet-reel/pkg/kafka"
+	"github.com/sweetloveinyourheart/sweet-reel/pkg/logger"
+)
+
+var (
+	dlqBrokers     string
+	dlqReplayGroup string
+	dlqMaxMessages int
+)
+
+var dlqCmd = &cobra.Command{
+	Use:   `dlq`,
+	Short: "Dead-letter topic commands",
+	Long:  "Commands for inspecting and recovering messages on <topic>.dlq topics",
+}
+
+var dlqReplayCmd = &cobra.Command{
+	Use:     `replay [topic]`,
+	Short:   "Move dead-lettered messages back onto their original topic",
+	Example: `  dlq replay video-uploaded --brokers=localhost:9092 --max=100`,
+	RunE:    runDLQReplay,
+}
+
+func DLQCommand() *cobra.Command {
+	dlqReplayCmd.Flags().StringVar(&dlqBrokers, "brokers", "localhost:9092", "Kafka broker addresses (comma-separated)")
+	dlqReplayCmd.Flags().StringVar(&dlqReplayGroup, "group", kafka.DefaultReplayGroup, "consumer group that tracks replay progress")
+	dlqReplayCmd.Flags().IntVar(&dlqMaxMessages, "max", 0, "maximum number of messages to replay, 0 replays everything")
+	dlqCmd.AddCommand(dlqReplayCmd)
+
+	return dlqCmd
+}
+
+func runDLQReplay(cmd *cobra.Command, args []string) error {
+	if len(args) < 1 {
+		return fmt.Errorf("topic is required! example: dlq replay video-uploaded")
+	}
+
+	// Accept both the original topic and its dead-letter topic
+	dlqTopic := args[0]
+	if !strings.HasSuffix(dlqTopic, kafka.DeadLetterSuffix) {
+		dlqTopic = kafka.DeadLetterTopic(dlqTopic)
+	}
+
+	cfg := kafka.DefaultConfig()
+	cfg.Brokers = strings.Split(dlqBrokers, ",")
+	for i, broker := range cfg.Brokers {
+		cfg.Brokers[i] = strings.TrimSpace(broker)
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	replayed, err := kafka.ReplayDeadLetters(ctx, cfg, dlqTopic, dlqReplayGroup, dlqMaxMessages)
+	if err != nil {
+		logger.Global().Error("dlq replay failed",
+			zap.String("topic", dlqTopic),
+			zap.Int("replayed", replayed),
+			zap.Error(err))
+		return err
+	}
+
+	logger.Global().Info("dlq replay finished",
+		zap.String("topic", dlqTopic),
+		zap.Int("replayed", replayed))
+
+	return nil
+}
@@ -26,6 +26,7 @@ func main() {
 	commands = append(commands, appvideoprocessing.Command(cmdutil.ServiceRootCmd))
 
 	commands = append(commands, apputils.CheckCommand())
+	commands = append(commands, apputils.DLQCommand())
 
 	cmdutil.InitializeService(commands...)
 }
@@ -9,6 +9,7 @@ import (
 	"strings"
 	"sync"
 	"syscall"
+	"time"
 
 	"github.com/spf13/cobra"
 	"go.uber.org/zap"
@@ -17,15 +18,17 @@ import (
 	"github.com/sweetloveinyourheart/sweet-reel/pkg/db"
 	"github.com/sweetloveinyourheart/sweet-reel/pkg/logger"
 	"github.com/sweetloveinyourheart/sweet-reel/pkg/stringsutil"
+	"github.com/sweetloveinyourheart/sweet-reel/pkg/tracing"
 )
 
 type AppRun struct {
-	serviceType string
-	serviceKey  string
-	wg          sync.WaitGroup
-	ctx         context.Context
-	cancel      context.CancelFunc
-	readyChan   chan bool
+	serviceType     string
+	serviceKey      string
+	wg              sync.WaitGroup
+	ctx             context.Context
+	cancel          context.CancelFunc
+	readyChan       chan bool
+	shutdownTracing func(context.Context) error
 }
 
 func st(s string) (serviceType string, serviceKey string) {
@@ -53,17 +56,24 @@ func BoilerplateRun(serviceType string) (*AppRun, error) {
 		"service", serviceName,
 	)
 
+	shutdownTracing, err := tracing.Init(ctx, serviceType, config.Instance().GetString("tracing.otlp_endpoint"))
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
 	healthCheckPort := config.Instance().GetInt("healthcheck.port")
 	healthCheckWebPort := config.Instance().GetInt("healthcheck.web.port")
 	readyChan := StartHealthServices(ctx, serviceName, healthCheckPort, healthCheckWebPort)
 
 	return &AppRun{
-		serviceType: serviceType,
-		serviceKey:  serviceKey,
-		wg:          sync.WaitGroup{},
-		ctx:         ctx,
-		cancel:      cancel,
-		readyChan:   readyChan,
+		serviceType:     serviceType,
+		serviceKey:      serviceKey,
+		wg:              sync.WaitGroup{},
+		ctx:             ctx,
+		cancel:          cancel,
+		readyChan:       readyChan,
+		shutdownTracing: shutdownTracing,
 	}, nil
 }
 
@@ -135,9 +145,29 @@ func BoilerplateFlagsKafka(command *cobra.Command, serviceType string, envPrefix
 	config.BoolDefault(command, fmt.Sprintf("%s.kafka.tls_enabled", serviceKey), "kafka-tls-enabled", false, "Enable TLS encryption", fmt.Sprintf("%s_KAFKA_TLS_ENABLED", envPrefix))
 	config.Int32Default(command, fmt.Sprintf("%s.kafka.max_open_requests", serviceKey), "kafka-max-open-requests", 1, "Max open requests", fmt.Sprintf("%s_KAFKA_MAX_OPEN_REQUESTS", envPrefix))
 
+	// Consumer retry policy
+	config.Int32Default(command, fmt.Sprintf("%s.kafka.consumer_retry.max_attempts", serviceKey), "kafka-consumer-retry-max-attempts", 3, "Attempts to handle a consumed message before it is sent to the <topic>.dlq topic", fmt.Sprintf("%s_KAFKA_CONSUMER_RETRY_MAX_ATTEMPTS", envPrefix))
+	config.Int64Default(command, fmt.Sprintf("%s.kafka.consumer_retry.backoff_ms", serviceKey), "kafka-consumer-retry-backoff-ms", 1000, "Initial backoff between consumer retries in milliseconds, doubled on every attempt", fmt.Sprintf("%s_KAFKA_CONSUMER_RETRY_BACKOFF_MS", envPrefix))
+	config.Int64Default(command, fmt.Sprintf("%s.kafka.consumer_retry.max_backoff_ms", serviceKey), "kafka-consumer-retry-max-backoff-ms", 30000, "Maximum backoff between consumer retries in milliseconds", fmt.Sprintf("%s_KAFKA_CONSUMER_RETRY_MAX_BACKOFF_MS", envPrefix))
+	config.StringDefault(command, fmt.Sprintf("%s.kafka.consumer_retry.topic", serviceKey), "kafka-consumer-retry-topic", "", "Topic failed messages are retried through; retries happen in place when empty. Must be unique per consumer group", fmt.Sprintf("%s_KAFKA_CONSUMER_RETRY_TOPIC", envPrefix))
+
+	// Events
+	config.StringDefault(command, fmt.Sprintf("%s.kafka.event_encoding", serviceKey), "kafka-event-encoding", "json", "Encoding of published event envelopes (json, protobuf)", fmt.Sprintf("%s_KAFKA_EVENT_ENCODING", envPrefix))
+
 	_ = command.MarkPersistentFlagRequired("kafka-brokers")
 }
 
+func BoilerplateFlagsStorage(command *cobra.Command, serviceType string, envPrefix string) {
+	_, serviceKey := st(serviceType)
+
+	config.StringDefault(command, fmt.Sprintf("%s.storage.backend", serviceKey), "storage-backend", "s3", "Where objects are stored (s3, local). s3 also covers MinIO", fmt.Sprintf("%s_STORAGE_BACKEND", envPrefix))
+
+	// Local backend, whose files are served by the api gateway
+	config.StringDefault(command, fmt.Sprintf("%s.storage.local.dir", serviceKey), "storage-local-dir", "/var/lib/sweet-reel/storage", "Directory objects are stored in by the local backend. Must be shared with the api gateway", fmt.Sprintf("%s_STORAGE_LOCAL_DIR", envPrefix))
+	config.StringDefault(command, fmt.Sprintf("%s.storage.local.url", serviceKey), "storage-local-url", "http://localhost:8080/storage", "Public URL of the api gateway storage endpoint, presigned URLs of the local backend start with it", fmt.Sprintf("%s_STORAGE_LOCAL_URL", envPrefix))
+	config.StringDefault(command, fmt.Sprintf("%s.storage.local.signing_key", serviceKey), "storage-local-signing-key", "", "Key signing the presigned URLs of the local backend. Must be the same for every service", fmt.Sprintf("%s_STORAGE_LOCAL_SIGNING_KEY", envPrefix))
+}
+
 func BoilerplateSecureFlags(command *cobra.Command, serviceType string) {
 	_, serviceKey := st(serviceType)
 
@@ -149,6 +179,7 @@ func BoilerplateSecureFlags(command *cobra.Command, serviceType string) {
 		fmt.Sprintf("%s.kafka.brokers", serviceKey),
 		fmt.Sprintf("%s.kafka.sasl_username", serviceKey),
 		fmt.Sprintf("%s.kafka.sasl_password", serviceKey),
+		fmt.Sprintf("%s.storage.local.signing_key", serviceKey),
 	)
 }
 
@@ -192,4 +223,11 @@ func (a *AppRun) Run() {
 
 	// Wait for a signal or other termination event
 	a.wg.Wait()
+
+	// Flush the spans of the last requests
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := a.shutdownTracing(ctx); err != nil {
+		logger.Global().Error("failed to flush traces", zap.Error(err))
+	}
 }
@@ -74,6 +74,11 @@ func InitializeService(command ...*cobra.Command) {
 	fields.BindWithDefault(ServiceRootCmd.PersistentFlags().Lookup("service"), "service", "", "SWEET_REEL_SERVICE")
 	fields.BindWithDefault(ServiceRootCmd.PersistentFlags().Lookup("log-level"), "log.level", "info", "LOG_LEVEL")
 
+	// Tracing
+	ServiceRootCmd.PersistentFlags().String("tracing-otlp-endpoint", "", "OTLP/HTTP endpoint traces are exported to, e.g. http://localhost:4318/v1/traces; traces are not exported when empty")
+
+	fields.BindWithDefault(ServiceRootCmd.PersistentFlags().Lookup("tracing-otlp-endpoint"), "tracing.otlp_endpoint", "", "SWEET_REEL_TRACING_OTLP_ENDPOINT")
+
 	// Health check
 	ServiceRootCmd.PersistentFlags().Int64("healthcheck-port", HealthCheckPortGRPC, "Port to listen on for services that support a health check")
 	ServiceRootCmd.PersistentFlags().Int64("healthcheck-web-port", HealthCheckPortHTTP, "Port to listen on for services that support a health check")
@@ -0,0 +1,146 @@
+package db
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// outboxRelayLockKey is the advisory lock held by the relay that is publishing the outbox,
+// so that a single relay per database publishes at a time and messages go out in order
+const outboxRelayLockKey = 7_310_562_104
+
+// OutboxMessage is an event written to the outbox table, waiting to be published.
+//
+// Services that publish through the outbox create the table in their own schema:
+//
+//	CREATE TABLE outbox (
+//	    id          BIGSERIAL       NOT NULL,
+//	    message_id  UUID,
+//	    topic       VARCHAR(255)    NOT NULL,
+//	    message_key VARCHAR(255)    NOT NULL,
+//	    payload     BYTEA           NOT NULL,
+//	    headers     JSONB,
+//	    created_at  TIMESTAMP       DEFAULT NOW(),
+//	    sent_at     TIMESTAMP,
+//	    attempts    INT             NOT NULL DEFAULT 0,
+//	    last_error  TEXT,
+//	    parked_at   TIMESTAMP,
+//	    PRIMARY KEY (id)
+//	);
+//
+// A message that keeps failing to publish is parked and left out of the relay until it is
+// released with UPDATE outbox SET parked_at = NULL, attempts = 0 WHERE id = ...
+type OutboxMessage struct {
+	ID        int64
+	MessageID string
+	Topic     string
+	Key       string
+	Payload   []byte
+	Headers   map[string]string
+	CreatedAt time.Time
+	// Attempts is how many times publishing the message has failed
+	Attempts int
+}
+
+// EnqueueOutbox writes a message to the outbox. Pass the transaction of the write the
+// message describes, so that the message is stored if and only if that write commits.
+// The message gets an id here, so that publishing it again after a crash keeps its id.
+func EnqueueOutbox(ctx context.Context, tx DbOrTx, topic string, key string, payload []byte, headers map[string]string) error {
+	var headersJSON []byte
+	if len(headers) > 0 {
+		var err error
+		headersJSON, err = json.Marshal(headers)
+		if err != nil {
+			return fmt.Errorf("failed to marshal outbox headers: %w", err)
+		}
+	}
+
+	query := `
+		INSERT INTO outbox (message_id, topic, message_key, payload, headers)
+		VALUES ($1, $2, $3, $4, $5)`
+
+	_, err := tx.Exec(ctx, query, uuid.Must(uuid.NewV7()), topic, key, payload, headersJSON)
+	return err
+}
+
+// ClaimOutbox returns up to limit unsent messages that are not parked, in the order they were written.
+// It must run inside tx, which holds the relay lock until it ends. When another relay
+// holds the lock, no messages are returned.
+func ClaimOutbox(ctx context.Context, tx pgx.Tx, limit int) ([]*OutboxMessage, error) {
+	var locked bool
+	if err := tx.QueryRow(ctx, `SELECT pg_try_advisory_xact_lock($1)`, outboxRelayLockKey).Scan(&locked); err != nil {
+		return nil, err
+	}
+
+	if !locked {
+		return nil, nil
+	}
+
+	query := `
+		SELECT id, COALESCE(message_id::TEXT, ''), topic, message_key, payload, headers, created_at, attempts
+		FROM outbox
+		WHERE sent_at IS NULL AND parked_at IS NULL
+		ORDER BY id
+		LIMIT $1`
+
+	rows, err := tx.Query(ctx, query, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var messages []*OutboxMessage
+	for rows.Next() {
+		message := &OutboxMessage{}
+		var headersJSON []byte
+		if err := rows.Scan(&message.ID, &message.MessageID, &message.Topic, &message.Key, &message.Payload, &headersJSON, &message.CreatedAt, &message.Attempts); err != nil {
+			return nil, err
+		}
+
+		if len(headersJSON) > 0 {
+			if err := json.Unmarshal(headersJSON, &message.Headers); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal headers of outbox message %d: %w", message.ID, err)
+			}
+		}
+
+		messages = append(messages, message)
+	}
+	return messages, rows.Err()
+}
+
+// MarkOutboxSent records that the given messages have been published
+func MarkOutboxSent(ctx context.Context, tx DbOrTx, ids []int64) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	_, err := tx.Exec(ctx, `UPDATE outbox SET sent_at = NOW() WHERE id = ANY($1)`, ids)
+	return err
+}
+
+// RecordOutboxFailure counts a failed attempt at publishing a message, parking it when park is set
+func RecordOutboxFailure(ctx context.Context, tx DbOrTx, id int64, cause string, park bool) error {
+	query := `
+		UPDATE outbox
+		SET attempts = attempts + 1,
+			last_error = $2,
+			parked_at = CASE WHEN $3 THEN NOW() END
+		WHERE id = $1`
+
+	_, err := tx.Exec(ctx, query, id, cause, park)
+	return err
+}
+
+// PurgeOutbox deletes the messages that were published before sentBefore and returns how many were deleted
+func PurgeOutbox(ctx context.Context, tx DbOrTx, sentBefore time.Time) (int64, error) {
+	result, err := tx.Exec(ctx, `DELETE FROM outbox WHERE sent_at IS NOT NULL AND sent_at < $1`, sentBefore)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
@@ -0,0 +1,54 @@
+package db
+
+import (
+	"context"
+	"time"
+)
+
+// Services that deduplicate consumed messages create the table in their own schema:
+//
+//	CREATE TABLE processed_messages (
+//	    consumer_group  VARCHAR(255)    NOT NULL,
+//	    message_id      VARCHAR(255)    NOT NULL,
+//	    processed_at    TIMESTAMP       DEFAULT NOW(),
+//	    PRIMARY KEY (consumer_group, message_id)
+//	);
+
+// IsMessageProcessed reports whether group has already recorded the message with the given id
+func IsMessageProcessed(ctx context.Context, tx DbOrTx, group string, messageID string) (bool, error) {
+	query := `
+		SELECT EXISTS (
+			SELECT 1 FROM processed_messages
+			WHERE consumer_group = $1 AND message_id = $2
+		)`
+
+	var processed bool
+	err := tx.QueryRow(ctx, query, group, messageID).Scan(&processed)
+	return processed, err
+}
+
+// MarkMessageProcessed records that group has handled the message with the given id.
+// It returns false when the message was already recorded. Pass the transaction of the
+// writes the message caused, so that the record and the writes commit together.
+func MarkMessageProcessed(ctx context.Context, tx DbOrTx, group string, messageID string) (bool, error) {
+	query := `
+		INSERT INTO processed_messages (consumer_group, message_id)
+		VALUES ($1, $2)
+		ON CONFLICT (consumer_group, message_id) DO NOTHING`
+
+	result, err := tx.Exec(ctx, query, group, messageID)
+	if err != nil {
+		return false, err
+	}
+	return result.RowsAffected() == 1, nil
+}
+
+// PurgeProcessedMessages deletes the records of messages processed before processedBefore
+// and returns how many were deleted
+func PurgeProcessedMessages(ctx context.Context, tx DbOrTx, processedBefore time.Time) (int64, error) {
+	result, err := tx.Exec(ctx, `DELETE FROM processed_messages WHERE processed_at < $1`, processedBefore)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
@@ -0,0 +1,135 @@
+package ffmpeg
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// h264Profiles maps the H.264 profiles reported by ffprobe to their profile_idc and
+// constraint flags, the first two bytes of an "avc1" codecs string
+var h264Profiles = map[string]string{
+	"Baseline":              "42E0",
+	"Constrained Baseline":  "42E0",
+	"Main":                  "4D40",
+	"Extended":              "5800",
+	"High":                  "6400",
+	"High 10":               "6E00",
+	"High 4:2:2":            "7A00",
+	"High 4:4:4 Predictive": "F400",
+}
+
+// aacObjectTypes maps the AAC profiles reported by ffprobe to their MPEG-4 audio object type
+var aacObjectTypes = map[string]int{
+	"LC":       2,
+	"HE-AAC":   5,
+	"HE-AACv2": 29,
+	"Main":     1,
+	"SSR":      3,
+	"LTP":      4,
+}
+
+// CodecString returns the codecs parameter of the stream as used by HLS and DASH manifests
+// (RFC 6381), e.g. "avc1.64001f" or "mp4a.40.2". It is empty for codecs that can't be
+// described from probe data.
+func (s *StreamInfo) CodecString() string {
+	switch s.CodecName {
+	case CodecH264:
+		prefix, ok := h264Profiles[s.Profile]
+		if !ok || s.Level <= 0 {
+			return ""
+		}
+		return strings.ToLower(fmt.Sprintf("avc1.%s%02X", prefix, s.Level))
+	case "hevc":
+		// ffprobe reports the general_level_idc, 30 times the level
+		if s.Level <= 0 {
+			return ""
+		}
+		switch s.Profile {
+		case "Main":
+			return fmt.Sprintf("hvc1.1.6.L%d.B0", s.Level)
+		case "Main 10":
+			return fmt.Sprintf("hvc1.2.4.L%d.B0", s.Level)
+		default:
+			return ""
+		}
+	case CodecAAC:
+		objectType, ok := aacObjectTypes[s.Profile]
+		if !ok {
+			objectType = aacObjectTypes["LC"]
+		}
+		return fmt.Sprintf("mp4a.40.%d", objectType)
+	case CodecMP3:
+		return "mp4a.40.34"
+	case CodecOpus:
+		return "opus"
+	case "ac3":
+		return "ac-3"
+	case "eac3":
+		return "ec-3"
+	default:
+		return ""
+	}
+}
+
+// FrameRate returns the average frame rate of the stream in frames per second, falling back to
+// the real base frame rate, or zero when neither is known
+func (s *StreamInfo) FrameRate() float64 {
+	for _, rate := range []string{s.AvgFrameRate, s.RFrameRate} {
+		if value := parseFrameRate(rate); value > 0 {
+			return value
+		}
+	}
+
+	return 0
+}
+
+// AudioStream returns the first audio stream of the probed file, or nil when there is none
+func (p *ProbeInfo) AudioStream() *StreamInfo {
+	for i := range p.Streams {
+		if p.Streams[i].CodecType == "audio" {
+			return &p.Streams[i]
+		}
+	}
+
+	return nil
+}
+
+// Codecs returns the codecs parameter of the first video and audio streams, separated by a
+// comma. It is empty unless every one of those streams can be described, since players skip
+// variants listing codecs they don't support.
+func (p *ProbeInfo) Codecs() string {
+	var codecs []string
+	for _, stream := range []*StreamInfo{p.VideoStream(), p.AudioStream()} {
+		if stream == nil {
+			continue
+		}
+
+		codec := stream.CodecString()
+		if codec == "" {
+			return ""
+		}
+		codecs = append(codecs, codec)
+	}
+
+	return strings.Join(codecs, ",")
+}
+
+// parseFrameRate parses frame rates written by ffprobe as a fraction ("30000/1001") or a number
+func parseFrameRate(rate string) float64 {
+	numerator, denominator, isFraction := strings.Cut(rate, "/")
+	value, err := strconv.ParseFloat(numerator, 64)
+	if err != nil {
+		return 0
+	}
+
+	if isFraction {
+		divisor, err := strconv.ParseFloat(denominator, 64)
+		if err != nil || divisor == 0 {
+			return 0
+		}
+		value /= divisor
+	}
+
+	return value
+}
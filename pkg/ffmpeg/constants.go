@@ -6,9 +6,18 @@ const (
 	Quality480p    = "480p"
 	Quality720p    = "720p"
 	Quality1080p   = "1080p"
+	Quality1440p   = "1440p"
+	Quality2160p   = "2160p"
 	QualityUnknown = "unknown"
 )
 
+// Video orientations
+const (
+	OrientationLandscape = "landscape"
+	OrientationPortrait  = "portrait"
+	OrientationSquare    = "square"
+)
+
 // Video codecs
 const (
 	CodecLibX264 = "libx264"
@@ -50,7 +59,10 @@ const (
 	ExtMOV  = ".mov"
 	ExtFLV  = ".flv"
 	ExtTS   = ".ts"
+	ExtM4S  = ".m4s"
 	ExtM3U8 = ".m3u8"
+	ExtMPD  = ".mpd"
+	ExtVTT  = ".vtt"
 	ExtJPG  = ".jpg"
 	ExtJPEG = ".jpeg"
 	ExtPNG  = ".png"
@@ -65,7 +77,10 @@ const (
 	MimeTypeMOV         = "video/quicktime"
 	MimeTypeFLV         = "video/x-flv"
 	MimeTypeTS          = "video/mp2t"
+	MimeTypeM4S         = "video/iso.segment"
 	MimeTypeM3U8        = "application/vnd.apple.mpegurl"
+	MimeTypeMPD         = "application/dash+xml"
+	MimeTypeVTT         = "text/vtt"
 	MimeTypeJPEG        = "image/jpeg"
 	MimeTypePNG         = "image/png"
 	MimeTypeOctetStream = "application/octet-stream"
@@ -82,6 +97,23 @@ const (
 	DefaultSegmentPrefix   = "segment"
 	DefaultSegmentFormat   = "ts"
 	DefaultSegmentDuration = "10"
+
+	// Segment containers, fMP4 segments can be shared with DASH
+	SegmentTypeMPEGTS       = "mpegts"
+	SegmentTypeFMP4         = "fmp4"
+	SegmentFormatFMP4       = "m4s"
+	DefaultInitSegmentName  = "init.mp4"
+	DefaultDASHManifestName = "manifest.mpd"
+)
+
+// Storyboard settings
+const (
+	DefaultStoryboardTrackName    = "storyboard.vtt"
+	DefaultStoryboardSpritePrefix = "storyboard"
+	DefaultStoryboardInterval     = 5 // Seconds between two sampled frames
+	DefaultStoryboardTileSize     = 160
+	DefaultStoryboardColumns      = 10
+	DefaultStoryboardRows         = 10
 )
 
 // Quality presets
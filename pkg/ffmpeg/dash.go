@@ -0,0 +1,218 @@
+package ffmpeg
+
+import (
+	"encoding/xml"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+const (
+	// DASHProfileMain allows the explicit segment lists written for fMP4 segments shared with HLS
+	DASHProfileMain = "urn:mpeg:dash:profile:isoff-main:2011"
+
+	// dashTimescale is the number of ticks per second of segment timelines
+	dashTimescale = 1000
+)
+
+// MPD is a static DASH manifest whose representations list their segments explicitly
+type MPD struct {
+	XMLName                   xml.Name    `xml:"urn:mpeg:dash:schema:mpd:2011 MPD"`
+	Profiles                  string      `xml:"profiles,attr"`
+	Type                      string      `xml:"type,attr"`
+	MediaPresentationDuration string      `xml:"mediaPresentationDuration,attr"`
+	MinBufferTime             string      `xml:"minBufferTime,attr"`
+	Periods                   []MPDPeriod `xml:"Period"`
+}
+
+type MPDPeriod struct {
+	ID             string             `xml:"id,attr,omitempty"`
+	AdaptationSets []MPDAdaptationSet `xml:"AdaptationSet"`
+}
+
+type MPDAdaptationSet struct {
+	MimeType         string              `xml:"mimeType,attr"`
+	SegmentAlignment bool                `xml:"segmentAlignment,attr"`
+	StartWithSAP     int                 `xml:"startWithSAP,attr,omitempty"`
+	Representations  []MPDRepresentation `xml:"Representation"`
+}
+
+type MPDRepresentation struct {
+	ID          string         `xml:"id,attr"`
+	Bandwidth   int            `xml:"bandwidth,attr"`
+	Width       int            `xml:"width,attr,omitempty"`
+	Height      int            `xml:"height,attr,omitempty"`
+	FrameRate   string         `xml:"frameRate,attr,omitempty"`
+	Codecs      string         `xml:"codecs,attr,omitempty"`
+	SegmentList MPDSegmentList `xml:"SegmentList"`
+}
+
+type MPDSegmentList struct {
+	Timescale       int                `xml:"timescale,attr"`
+	Initialization  *MPDURL            `xml:"Initialization,omitempty"`
+	SegmentTimeline MPDSegmentTimeline `xml:"SegmentTimeline"`
+	SegmentURLs     []MPDSegmentURL    `xml:"SegmentURL"`
+}
+
+type MPDURL struct {
+	SourceURL string `xml:"sourceURL,attr"`
+}
+
+type MPDSegmentTimeline struct {
+	Segments []MPDTimelineSegment `xml:"S"`
+}
+
+type MPDTimelineSegment struct {
+	Start    int64 `xml:"t,attr"`
+	Duration int64 `xml:"d,attr"`
+}
+
+type MPDSegmentURL struct {
+	Media string `xml:"media,attr"`
+}
+
+// DASHRepresentation is one quality of a video packaged as fMP4 segments
+type DASHRepresentation struct {
+	ID          string
+	Bandwidth   int
+	Width       int
+	Height      int
+	FrameRate   float64
+	Codecs      string
+	InitSegment string // URI of the initialization segment
+	Segments    []MediaSegment
+}
+
+// NewDASHManifest describes representations, whose segments hold both audio and video, as a
+// static DASH presentation of durationSeconds
+func NewDASHManifest(durationSeconds float64, representations []DASHRepresentation) *MPD {
+	adaptationSet := MPDAdaptationSet{
+		MimeType:         MimeTypeMP4,
+		SegmentAlignment: true,
+		StartWithSAP:     1,
+	}
+
+	for _, representation := range representations {
+		segmentList := MPDSegmentList{Timescale: dashTimescale}
+		if representation.InitSegment != "" {
+			segmentList.Initialization = &MPDURL{SourceURL: representation.InitSegment}
+		}
+
+		var start int64
+		for _, segment := range representation.Segments {
+			duration := int64(math.Round(segment.Duration * dashTimescale))
+			segmentList.SegmentTimeline.Segments = append(segmentList.SegmentTimeline.Segments, MPDTimelineSegment{Start: start, Duration: duration})
+			segmentList.SegmentURLs = append(segmentList.SegmentURLs, MPDSegmentURL{Media: segment.URI})
+			start += duration
+		}
+
+		adaptationSet.Representations = append(adaptationSet.Representations, MPDRepresentation{
+			ID:          representation.ID,
+			Bandwidth:   representation.Bandwidth,
+			Width:       representation.Width,
+			Height:      representation.Height,
+			FrameRate:   dashFrameRate(representation.FrameRate),
+			Codecs:      representation.Codecs,
+			SegmentList: segmentList,
+		})
+	}
+
+	return &MPD{
+		Profiles:                  DASHProfileMain,
+		Type:                      "static",
+		MediaPresentationDuration: dashDuration(durationSeconds),
+		MinBufferTime:             dashDuration(2),
+		Periods: []MPDPeriod{{
+			ID:             "0",
+			AdaptationSets: []MPDAdaptationSet{adaptationSet},
+		}},
+	}
+}
+
+// ParseMPD reads a DASH manifest written by NewDASHManifest
+func ParseMPD(data []byte) (*MPD, error) {
+	var mpd MPD
+	if err := xml.Unmarshal(data, &mpd); err != nil {
+		return nil, err
+	}
+	return &mpd, nil
+}
+
+// Marshal writes the manifest as an XML document
+func (m *MPD) Marshal() ([]byte, error) {
+	data, err := xml.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), append(data, '\n')...), nil
+}
+
+// DurationSeconds returns the duration of the presentation, or zero when it is not written in
+// seconds like NewDASHManifest does
+func (m *MPD) DurationSeconds() float64 {
+	seconds, ok := strings.CutPrefix(m.MediaPresentationDuration, "PT")
+	if !ok {
+		return 0
+	}
+
+	duration, err := strconv.ParseFloat(strings.TrimSuffix(seconds, "S"), 64)
+	if err != nil || duration < 0 {
+		return 0
+	}
+	return duration
+}
+
+// RewriteURLs replaces the URL of every initialization and media segment with the one
+// returned by rewrite
+func (m *MPD) RewriteURLs(rewrite func(uri string) (string, error)) error {
+	for p := range m.Periods {
+		for a := range m.Periods[p].AdaptationSets {
+			representations := m.Periods[p].AdaptationSets[a].Representations
+			for r := range representations {
+				segmentList := &representations[r].SegmentList
+				if segmentList.Initialization != nil {
+					uri, err := rewrite(segmentList.Initialization.SourceURL)
+					if err != nil {
+						return err
+					}
+					segmentList.Initialization.SourceURL = uri
+				}
+
+				for s := range segmentList.SegmentURLs {
+					uri, err := rewrite(segmentList.SegmentURLs[s].Media)
+					if err != nil {
+						return err
+					}
+					segmentList.SegmentURLs[s].Media = uri
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// dashDuration writes seconds as an xs:duration, e.g. "PT60.000S"
+func dashDuration(seconds float64) string {
+	return fmt.Sprintf("PT%.3fS", seconds)
+}
+
+// dashFrameRate writes a frame rate as a whole number or a fraction, the only forms DASH
+// accepts, e.g. "30" or "30000/1001". It is empty when the frame rate is unknown.
+func dashFrameRate(fps float64) string {
+	if fps <= 0 {
+		return ""
+	}
+
+	if whole := math.Round(fps); math.Abs(fps-whole) < 0.01 {
+		return strconv.Itoa(int(whole))
+	}
+
+	// NTSC rates such as 29.97 are N*1000/1001
+	if ntsc := math.Round(fps * 1.001); math.Abs(fps-ntsc*1000/1001) < 0.01 {
+		return fmt.Sprintf("%d/1001", int(ntsc)*1000)
+	}
+
+	return fmt.Sprintf("%d/1000", int(math.Round(fps*1000)))
+}
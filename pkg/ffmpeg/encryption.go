@@ -0,0 +1,32 @@
+package ffmpeg
+
+import (
+	"encoding/hex"
+	"fmt"
+	"os"
+
+	"github.com/cockroachdb/errors"
+)
+
+// AES128KeySize is the size in bytes of the keys HLS segments are encrypted with
+const AES128KeySize = 16
+
+// WriteKeyInfoFile writes the key info file FFmpeg encrypts HLS segments from. keyURI is written
+// to the playlists for players to fetch the key, keyPath is where FFmpeg reads it from. The IV is
+// written to the playlists too, FFmpeg falls back to the segment sequence number without one.
+func WriteKeyInfoFile(path, keyURI, keyPath string, iv []byte) error {
+	if len(iv) != 0 && len(iv) != AES128KeySize {
+		return errors.Errorf("IV should be %d bytes, got %d", AES128KeySize, len(iv))
+	}
+
+	content := fmt.Sprintf("%s\n%s\n", keyURI, keyPath)
+	if len(iv) != 0 {
+		content += hex.EncodeToString(iv) + "\n"
+	}
+
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		return errors.Wrap(err, "failed to write key info file")
+	}
+
+	return nil
+}
@@ -63,6 +63,50 @@ func (f *FFmpeg) GetVersion(ctx context.Context) (string, error) {
 	return "", errors.New("unable to parse FFmpeg version")
 }
 
+// StderrExcerptLines is how many of the last stderr lines of a failed process are kept
+const StderrExcerptLines = 20
+
+// CommandError is returned when an FFmpeg process fails. Stderr holds the end of what
+// the process wrote there, which usually tells why it failed.
+type CommandError struct {
+	Err    error
+	Stderr string
+}
+
+func (e *CommandError) Error() string {
+	return fmt.Sprintf("FFmpeg process failed: %v", e.Err)
+}
+
+func (e *CommandError) Unwrap() error {
+	return e.Err
+}
+
+// StderrExcerpt returns the end of the stderr of the FFmpeg process err comes from,
+// or an empty string when err was not returned by a process
+func StderrExcerpt(err error) string {
+	var cmdErr *CommandError
+	if errors.As(err, &cmdErr) {
+		return cmdErr.Stderr
+	}
+	return ""
+}
+
+// stderrTail keeps the last lines written to stderr
+type stderrTail struct {
+	lines []string
+}
+
+func (t *stderrTail) add(line string) {
+	if len(t.lines) == StderrExcerptLines {
+		t.lines = t.lines[1:]
+	}
+	t.lines = append(t.lines, line)
+}
+
+func (t *stderrTail) String() string {
+	return strings.Join(t.lines, "\n")
+}
+
 // runCommand executes an FFmpeg command with the given arguments
 func (f *FFmpeg) runCommand(ctx context.Context, args []string, progressCallback ProgressCallback) error {
 	cmd := exec.CommandContext(ctx, f.binaryPath, args...)
@@ -82,80 +126,78 @@ func (f *FFmpeg) runCommand(ctx context.Context, args []string, progressCallback
 		return errors.Wrap(err, "failed to start FFmpeg process")
 	}
 
-	// Channel to collect stderr output
-	stderrLines := make(chan string, 100)
-	done := make(chan bool)
-
-	// Monitor stderr output
+	// Lines are handled as they are read, so the process never blocks on a full pipe
+	var tail stderrTail
+	done := make(chan struct{})
 	go func() {
-		defer close(stderrLines)
+		defer close(done)
+
+		var duration time.Duration
 		scanner := bufio.NewScanner(stderr)
 		for scanner.Scan() {
 			line := scanner.Text()
-			stderrLines <- line
+			tail.add(line)
 			logger.Global().Debug("FFmpeg output", zap.String("line", line))
-		}
-		done <- true
-	}()
 
-	// Monitor progress if callback is provided
-	if progressCallback != nil {
-		go func() {
-			var duration time.Duration
-			for line := range stderrLines {
-				// Parse duration from the beginning
-				if strings.Contains(line, "Duration:") && duration == 0 {
-					if d := parseDuration(line); d > 0 {
-						duration = d
-					}
+			if progressCallback == nil {
+				continue
+			}
+
+			// Parse duration from the beginning
+			if strings.Contains(line, "Duration:") && duration == 0 {
+				if d := parseDuration(line); d > 0 {
+					duration = d
 				}
+			}
 
-				// Parse current time progress
-				if strings.Contains(line, "time=") && duration > 0 {
-					if current := parseCurrentTime(line); current > 0 {
-						progress := float64(current) / float64(duration) * 100
-						if progress > 100 {
-							progress = 100
-						}
-
-						progressCallback(ProgressInfo{
-							Percentage: progress,
-							Duration:   duration,
-							Current:    current,
-							Speed:      parseSpeed(line),
-							Bitrate:    parseBitrate(line),
-						})
+			// Parse current time progress
+			if strings.Contains(line, "time=") && duration > 0 {
+				if current := parseCurrentTime(line); current > 0 {
+					progress := float64(current) / float64(duration) * 100
+					if progress > 100 {
+						progress = 100
 					}
+
+					progressCallback(ProgressInfo{
+						Percentage: progress,
+						Duration:   duration,
+						Current:    current,
+						Speed:      parseSpeed(line),
+						Bitrate:    parseBitrate(line),
+					})
 				}
 			}
-		}()
-	}
-
-	// Wait for command to complete
-	cmdErr := cmd.Wait()
+		}
+	}()
 
-	// Wait for stderr collection to complete
+	// Stderr is read to the end before waiting, Wait closes the pipe
 	<-done
-
-	// Collect any remaining stderr output for error reporting
-	var stderrOutput []string
-	for line := range stderrLines {
-		stderrOutput = append(stderrOutput, line)
-	}
-
-	if cmdErr != nil {
+	if err := cmd.Wait(); err != nil {
 		// Log stderr output for debugging
-		if len(stderrOutput) > 0 {
-			logger.Global().Error("FFmpeg stderr output",
-				zap.Strings("stderr", stderrOutput),
-				zap.Error(cmdErr))
-		}
-		return errors.Wrapf(cmdErr, "FFmpeg process failed")
+		logger.Global().Error("FFmpeg stderr output",
+			zap.Strings("stderr", tail.lines),
+			zap.Error(err))
+		return &CommandError{Err: err, Stderr: tail.String()}
 	}
 
 	return nil
 }
 
+// overallProgress turns the progress of the run at index out of total runs over the same
+// input into the progress of all of them, so that callers see a single 0-100 percentage
+func overallProgress(progressCallback ProgressCallback, index, total int) ProgressCallback {
+	if progressCallback == nil || total <= 1 {
+		return progressCallback
+	}
+
+	return func(progress ProgressInfo) {
+		progress.Percentage = (float64(index)*100 + progress.Percentage) / float64(total)
+		progress.Current += time.Duration(index) * progress.Duration
+		progress.Duration *= time.Duration(total)
+		progressCallback(progress)
+	}
+}
+
 // parseDuration extracts duration from FFmpeg output line
 func parseDuration(line string) time.Duration {
 	// Look for pattern: Duration: 00:01:30.45
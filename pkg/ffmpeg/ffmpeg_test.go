@@ -2,8 +2,14 @@ package ffmpeg
 
 import (
 	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
+
+	"github.com/cockroachdb/errors"
 )
 
 func TestFFmpegAvailability(t *testing.T) {
@@ -31,6 +37,51 @@ func TestFFmpegAvailability(t *testing.T) {
 	t.Logf("FFmpeg version: %s", version)
 }
 
+func TestRunCommandStderrExcerpt(t *testing.T) {
+	// A shell stands in for FFmpeg, writing more lines than are kept before failing
+	ff := NewWithBinaryPath("sh")
+	script := fmt.Sprintf("for i in $(seq 1 %d); do echo line $i >&2; done; exit 1", StderrExcerptLines+5)
+
+	err := ff.runCommand(context.Background(), []string{"-c", script}, nil)
+	if err == nil {
+		t.Fatal("Expected the command to fail")
+	}
+
+	excerpt := StderrExcerpt(errors.Wrap(err, "failed to segment video"))
+	lines := strings.Split(excerpt, "\n")
+	if len(lines) != StderrExcerptLines {
+		t.Fatalf("Expected %d stderr lines, got %d", StderrExcerptLines, len(lines))
+	}
+	if lines[0] != "line 6" || lines[len(lines)-1] != fmt.Sprintf("line %d", StderrExcerptLines+5) {
+		t.Errorf("Expected the last stderr lines, got %q", excerpt)
+	}
+
+	if StderrExcerpt(errors.New("not a process error")) != "" {
+		t.Error("Expected no excerpt for an error not returned by a process")
+	}
+}
+
+func TestOverallProgress(t *testing.T) {
+	var reported ProgressInfo
+	callback := overallProgress(func(progress ProgressInfo) {
+		reported = progress
+	}, 1, 4)
+
+	// Halfway through the second of four runs over a 10 seconds input
+	callback(ProgressInfo{Percentage: 50, Duration: 10 * time.Second, Current: 5 * time.Second})
+
+	if reported.Percentage != 37.5 {
+		t.Errorf("Expected 37.5%% overall, got %v", reported.Percentage)
+	}
+	if reported.Current != 15*time.Second || reported.Duration != 40*time.Second {
+		t.Errorf("Expected 15s of 40s overall, got %v of %v", reported.Current, reported.Duration)
+	}
+
+	if overallProgress(nil, 0, 4) != nil {
+		t.Error("Expected no callback when none is given")
+	}
+}
+
 func TestTimeStringParsing(t *testing.T) {
 	tests := []struct {
 		input    string
@@ -75,11 +126,11 @@ func TestBitrateStringParsing(t *testing.T) {
 	}
 }
 
-func TestExtractBandwidth(t *testing.T) {
+func TestNominalBandwidth(t *testing.T) {
 	videoBitrate := "2000k"
 	audioBitrate := "128k"
 
-	bandwidth := extractBandwidth(videoBitrate, audioBitrate)
+	bandwidth := NominalBandwidth(videoBitrate, audioBitrate)
 	expected := 2000000 + 128000 // 2M + 128k
 
 	if bandwidth != expected {
@@ -100,3 +151,362 @@ func TestValidateInputFile(t *testing.T) {
 		t.Error("Expected error for non-existent file")
 	}
 }
+
+func TestSelectLadder(t *testing.T) {
+	ladder := []Rendition{
+		{QualityName: Quality480p, Height: 480, VideoBitrate: VideoBitrate480p, AudioBitrate: AudioBitrate96k},
+		{QualityName: Quality720p, Height: 720, VideoBitrate: VideoBitrate720p, AudioBitrate: AudioBitrate128k},
+		{QualityName: Quality1080p, Height: 1080, VideoBitrate: VideoBitrate1080p, AudioBitrate: AudioBitrate192k},
+		{QualityName: Quality1440p, Height: 1440, VideoBitrate: VideoBitrate1440p, AudioBitrate: AudioBitrate192k},
+		{QualityName: Quality2160p, Height: 2160, VideoBitrate: VideoBitrate2160p, AudioBitrate: AudioBitrate192k},
+	}
+
+	tests := []struct {
+		name        string
+		width       int
+		height      int
+		resolutions []string
+	}{
+		{"4K source keeps every rung", 3840, 2160, []string{"854x480", "1280x720", "1920x1080", "2560x1440", "3840x2160"}},
+		{"1080p source is not upscaled", 1920, 1080, []string{"854x480", "1280x720", "1920x1080"}},
+		{"Ultrawide keeps its aspect ratio", 2560, 1080, []string{"1138x480", "1706x720", "2560x1080"}},
+		{"4:3 source keeps its aspect ratio", 1440, 1080, []string{"640x480", "960x720", "1440x1080"}},
+		{"Small source gets a native rendition", 640, 360, []string{"640x360"}},
+		{"Portrait source is matched on its width", 1080, 1920, []string{"480x854", "720x1280", "1080x1920"}},
+		{"Small portrait source gets a native rendition", 360, 640, []string{"360x640"}},
+		{"Unknown size", 0, 0, nil},
+	}
+
+	for _, test := range tests {
+		selected := SelectLadder(test.width, test.height, ladder)
+		if len(selected) != len(test.resolutions) {
+			t.Errorf("%s: expected %d renditions, got %d", test.name, len(test.resolutions), len(selected))
+			continue
+		}
+
+		for i, rendition := range selected {
+			if rendition.Resolution() != test.resolutions[i] {
+				t.Errorf("%s: expected rendition %d to be %s, got %s", test.name, i, test.resolutions[i], rendition.Resolution())
+			}
+		}
+	}
+
+	native := SelectLadder(640, 360, ladder)[0]
+	if native.QualityName != "360p" || native.VideoBitrate != VideoBitrate480p {
+		t.Errorf("Expected native 360p rendition with the lowest rung bitrate, got %s at %s", native.QualityName, native.VideoBitrate)
+	}
+}
+
+func TestRenditionSegmentationOptions(t *testing.T) {
+	rendition := Rendition{QualityName: Quality720p, Width: 1280, Height: 720, VideoBitrate: VideoBitrate720p, AudioBitrate: AudioBitrate128k}
+	base := SegmentationOptions{SegmentDuration: "6", VideoCodec: CodecLibX264}
+
+	options := rendition.SegmentationOptions(base)
+	if options.QualityName != Quality720p || options.Resolution != "1280x720" || options.ScaleHeight != 720 {
+		t.Errorf("Unexpected segmentation options: %+v", options)
+	}
+	if options.SegmentDuration != "6" || options.VideoCodec != CodecLibX264 {
+		t.Error("Expected base options to be kept")
+	}
+
+	portrait := Rendition{QualityName: Quality720p, Width: 720, Height: 1280}.SegmentationOptions(base)
+	if portrait.ScaleWidth != 720 || portrait.ScaleHeight != 0 {
+		t.Errorf("Expected portrait rendition to scale by width, got %+v", portrait)
+	}
+}
+
+func TestStreamOrientation(t *testing.T) {
+	tests := []struct {
+		name        string
+		stream      StreamInfo
+		rotation    int
+		orientation string
+	}{
+		{"Landscape", StreamInfo{Width: 1920, Height: 1080}, 0, OrientationLandscape},
+		{"Portrait", StreamInfo{Width: 1080, Height: 1920}, 0, OrientationPortrait},
+		{"Square", StreamInfo{Width: 1080, Height: 1080}, 0, OrientationSquare},
+		{"Rotate tag", StreamInfo{Width: 1920, Height: 1080, Tags: map[string]string{"rotate": "90"}}, 90, OrientationPortrait},
+		{"Display matrix", StreamInfo{Width: 1920, Height: 1080, SideDataList: []SideData{{SideDataType: "Display Matrix", Rotation: -90}}}, 90, OrientationPortrait},
+		{"Upside down", StreamInfo{Width: 1920, Height: 1080, SideDataList: []SideData{{SideDataType: "Display Matrix", Rotation: 180}}}, 180, OrientationLandscape},
+	}
+
+	for _, test := range tests {
+		if rotation := test.stream.Rotation(); rotation != test.rotation {
+			t.Errorf("%s: expected rotation %d, got %d", test.name, test.rotation, rotation)
+		}
+		if orientation := test.stream.Orientation(); orientation != test.orientation {
+			t.Errorf("%s: expected %s, got %s", test.name, test.orientation, orientation)
+		}
+	}
+
+	probe := ProbeInfo{Format: FormatInfo{Duration: "42.5"}}
+	if probe.DurationSeconds() != 42.5 {
+		t.Errorf("Expected duration of 42.5s, got %f", probe.DurationSeconds())
+	}
+}
+
+func TestStreamCodecs(t *testing.T) {
+	probe := ProbeInfo{Streams: []StreamInfo{
+		{CodecType: "video", CodecName: CodecH264, Profile: "High", Level: 31, AvgFrameRate: "30000/1001"},
+		{CodecType: "audio", CodecName: CodecAAC, Profile: "LC"},
+	}}
+
+	if codecs := probe.Codecs(); codecs != "avc1.64001f,mp4a.40.2" {
+		t.Errorf("Expected codecs avc1.64001f,mp4a.40.2, got %s", codecs)
+	}
+
+	if frameRate := probe.VideoStream().FrameRate(); frameRate < 29.97 || frameRate > 29.98 {
+		t.Errorf("Expected frame rate of 29.97, got %f", frameRate)
+	}
+
+	// A stream that can't be described leaves the codecs out
+	probe.Streams[0].Profile = "unknown"
+	if codecs := probe.Codecs(); codecs != "" {
+		t.Errorf("Expected no codecs, got %s", codecs)
+	}
+}
+
+func TestMasterPlaylist(t *testing.T) {
+	playlist := MasterPlaylist([]StreamVariant{
+		{URI: "480p/playlist.m3u8", Bandwidth: 1500000, AverageBandwidth: 1200000, Resolution: "854x480", FrameRate: 30, Codecs: "avc1.64001e,mp4a.40.2"},
+		{URI: "720p/playlist.m3u8", Bandwidth: 3000000, Resolution: "1280x720"},
+		{URI: "1080p/playlist.m3u8"},
+	})
+
+	expected := `#EXTM3U
+#EXT-X-VERSION:3
+#EXT-X-STREAM-INF:BANDWIDTH=1500000,AVERAGE-BANDWIDTH=1200000,RESOLUTION=854x480,FRAME-RATE=30.000,CODECS="avc1.64001e,mp4a.40.2"
+480p/playlist.m3u8
+#EXT-X-STREAM-INF:BANDWIDTH=3000000,RESOLUTION=1280x720
+720p/playlist.m3u8
+`
+	if playlist != expected {
+		t.Errorf("Unexpected master playlist:\n%s", playlist)
+	}
+}
+
+func TestParseMediaPlaylist(t *testing.T) {
+	playlist := ParseMediaPlaylist(`#EXTM3U
+#EXT-X-VERSION:7
+#EXT-X-TARGETDURATION:6
+#EXT-X-MAP:URI="init.mp4"
+#EXTINF:6.000000,
+segment_000.m4s
+#EXTINF:2.500000,
+segment_001.m4s
+#EXT-X-ENDLIST
+`)
+
+	if playlist.InitSegment != "init.mp4" {
+		t.Errorf("Expected init segment init.mp4, got %q", playlist.InitSegment)
+	}
+
+	expected := []MediaSegment{{URI: "segment_000.m4s", Duration: 6}, {URI: "segment_001.m4s", Duration: 2.5}}
+	if fmt.Sprint(playlist.Segments) != fmt.Sprint(expected) {
+		t.Errorf("Expected segments %v, got %v", expected, playlist.Segments)
+	}
+
+	if playlist.Duration() != 8.5 {
+		t.Errorf("Expected duration 8.5, got %f", playlist.Duration())
+	}
+}
+
+func TestPlaylistAttribute(t *testing.T) {
+	line := `#EXT-X-STREAM-INF:BANDWIDTH=1500000,CODECS="avc1.64001e,mp4a.40.2",RESOLUTION=854x480`
+
+	tests := map[string]string{
+		"BANDWIDTH":  "1500000",
+		"CODECS":     "avc1.64001e,mp4a.40.2",
+		"RESOLUTION": "854x480",
+		"FRAME-RATE": "",
+	}
+	for attribute, expected := range tests {
+		if value := PlaylistAttribute(line, attribute); value != expected {
+			t.Errorf("PlaylistAttribute(%s) = %q, expected %q", attribute, value, expected)
+		}
+	}
+}
+
+func TestDASHManifest(t *testing.T) {
+	mpd := NewDASHManifest(8.5, []DASHRepresentation{
+		{
+			ID:          "480p",
+			Bandwidth:   1500000,
+			Width:       854,
+			Height:      480,
+			FrameRate:   29.97,
+			Codecs:      "avc1.64001e,mp4a.40.2",
+			InitSegment: "480p/init.mp4",
+			Segments:    []MediaSegment{{URI: "480p/segment_000.m4s", Duration: 6}, {URI: "480p/segment_001.m4s", Duration: 2.5}},
+		},
+	})
+
+	data, err := mpd.Marshal()
+	if err != nil {
+		t.Fatalf("Failed to marshal manifest: %v", err)
+	}
+
+	for _, expected := range []string{
+		`<MPD xmlns="urn:mpeg:dash:schema:mpd:2011" profiles="urn:mpeg:dash:profile:isoff-main:2011" type="static" mediaPresentationDuration="PT8.500S"`,
+		`<Representation id="480p" bandwidth="1500000" width="854" height="480" frameRate="30000/1001" codecs="avc1.64001e,mp4a.40.2">`,
+		`<Initialization sourceURL="480p/init.mp4"></Initialization>`,
+		`<S t="6000" d="2500"></S>`,
+		`<SegmentURL media="480p/segment_001.m4s"></SegmentURL>`,
+	} {
+		if !strings.Contains(string(data), expected) {
+			t.Errorf("Expected manifest to contain %s:\n%s", expected, data)
+		}
+	}
+
+	parsed, err := ParseMPD(data)
+	if err != nil {
+		t.Fatalf("Failed to parse manifest: %v", err)
+	}
+	if parsed.DurationSeconds() != 8.5 {
+		t.Errorf("Expected duration 8.5, got %f", parsed.DurationSeconds())
+	}
+
+	err = parsed.RewriteURLs(func(uri string) (string, error) {
+		return "https://cdn.example.com/" + uri, nil
+	})
+	if err != nil {
+		t.Fatalf("Failed to rewrite urls: %v", err)
+	}
+
+	segmentList := parsed.Periods[0].AdaptationSets[0].Representations[0].SegmentList
+	if segmentList.Initialization.SourceURL != "https://cdn.example.com/480p/init.mp4" {
+		t.Errorf("Unexpected initialization url: %s", segmentList.Initialization.SourceURL)
+	}
+	if segmentList.SegmentURLs[0].Media != "https://cdn.example.com/480p/segment_000.m4s" {
+		t.Errorf("Unexpected segment url: %s", segmentList.SegmentURLs[0].Media)
+	}
+}
+
+func TestDASHFrameRate(t *testing.T) {
+	tests := map[float64]string{
+		0:      "",
+		30:     "30",
+		29.97:  "30000/1001",
+		59.94:  "60000/1001",
+		12.5:   "12500/1000",
+		23.976: "24000/1001",
+	}
+	for fps, expected := range tests {
+		if rate := dashFrameRate(fps); rate != expected {
+			t.Errorf("dashFrameRate(%f) = %q, expected %q", fps, rate, expected)
+		}
+	}
+}
+
+func TestWriteKeyInfoFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "key.info")
+	iv := []byte{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15}
+
+	if err := WriteKeyInfoFile(path, "key", "/tmp/content.key", iv); err != nil {
+		t.Fatalf("Failed to write key info file: %v", err)
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read key info file: %v", err)
+	}
+
+	expected := "key\n/tmp/content.key\n000102030405060708090a0b0c0d0e0f\n"
+	if string(content) != expected {
+		t.Errorf("Expected key info %q, got %q", expected, content)
+	}
+
+	if err := WriteKeyInfoFile(path, "key", "/tmp/content.key", iv[:8]); err == nil {
+		t.Error("Expected an error for a short IV")
+	}
+}
+
+func TestDefaultStoryboardOptions(t *testing.T) {
+	tests := []struct {
+		width, height         int
+		tileWidth, tileHeight int
+	}{
+		{1920, 1080, 160, 90},
+		{1080, 1920, 90, 160},
+		{640, 480, 160, 120},
+		{0, 0, 160, 160},
+	}
+	for _, test := range tests {
+		options := DefaultStoryboardOptions(test.width, test.height)
+		if options.TileWidth != test.tileWidth || options.TileHeight != test.tileHeight {
+			t.Errorf("DefaultStoryboardOptions(%d, %d) tile = %dx%d, expected %dx%d",
+				test.width, test.height, options.TileWidth, options.TileHeight, test.tileWidth, test.tileHeight)
+		}
+		if err := options.Validate(); err != nil {
+			t.Errorf("DefaultStoryboardOptions(%d, %d) is invalid: %v", test.width, test.height, err)
+		}
+	}
+
+	if err := (StoryboardOptions{}).Validate(); err == nil {
+		t.Error("Expected an error for empty storyboard options")
+	}
+}
+
+func TestStoryboardTrack(t *testing.T) {
+	options := StoryboardOptions{
+		IntervalSeconds: 5,
+		TileWidth:       160,
+		TileHeight:      90,
+		Columns:         2,
+		Rows:            2,
+		SpritePrefix:    "storyboard",
+	}
+
+	track := StoryboardTrack(22.5, 2, options)
+	expected := strings.Join([]string{
+		"WEBVTT",
+		"",
+		"00:00:00.000 --> 00:00:05.000",
+		"storyboard_000.jpg#xywh=0,0,160,90",
+		"",
+		"00:00:05.000 --> 00:00:10.000",
+		"storyboard_000.jpg#xywh=160,0,160,90",
+		"",
+		"00:00:10.000 --> 00:00:15.000",
+		"storyboard_000.jpg#xywh=0,90,160,90",
+		"",
+		"00:00:15.000 --> 00:00:20.000",
+		"storyboard_000.jpg#xywh=160,90,160,90",
+		"",
+		"00:00:20.000 --> 00:00:22.500",
+		"storyboard_001.jpg#xywh=0,0,160,90",
+		"",
+	}, "\n")
+	if track != expected {
+		t.Errorf("Unexpected storyboard track:\n%s", track)
+	}
+
+	// Frames are capped to the tiles of the sprites
+	if cues := strings.Count(StoryboardTrack(3600, 1, options), "-->"); cues != 4 {
+		t.Errorf("Expected 4 cues, got %d", cues)
+	}
+}
+
+func TestVTTTimestamp(t *testing.T) {
+	if timestamp := vttTimestamp(3725.5); timestamp != "01:02:05.500" {
+		t.Errorf("Expected 01:02:05.500, got %s", timestamp)
+	}
+
+	tests := map[string]float64{
+		"01:02:05.500": 3725.5,
+		"02:05.250":    125.25,
+		"00:00:00.000": 0,
+	}
+	for timestamp, expected := range tests {
+		seconds, err := ParseVTTTimestamp(timestamp)
+		if err != nil || seconds != expected {
+			t.Errorf("ParseVTTTimestamp(%q) = %f, %v, expected %f", timestamp, seconds, err, expected)
+		}
+	}
+
+	for _, timestamp := range []string{"", "5.000", "aa:bb.ccc", "1:2:3:4"} {
+		if _, err := ParseVTTTimestamp(timestamp); err == nil {
+			t.Errorf("Expected an error for %q", timestamp)
+		}
+	}
+}
@@ -27,6 +27,7 @@ type FFmpegInterface interface {
 	TranscodeToMultipleQualities(ctx context.Context, inputPath, outputDir string, qualities []TranscodeOptions, progressCallback ProgressCallback) error
 	ExtractAudio(ctx context.Context, inputPath, outputPath string, options TranscodeOptions, progressCallback ProgressCallback) error
 	CreateThumbnail(ctx context.Context, inputPath, outputPath, timeOffset string, width, height int) error
+	CreateStoryboard(ctx context.Context, inputPath, outputDir string, options StoryboardOptions) error
 
 	// Segmentation methods
 	SegmentVideo(ctx context.Context, inputPath, outputDir string, options SegmentationOptions, progressCallback ProgressCallback) error
@@ -0,0 +1,105 @@
+package ffmpeg
+
+import (
+	"fmt"
+	"slices"
+)
+
+// Rendition is one rung of an adaptive bitrate ladder
+type Rendition struct {
+	QualityName  string
+	Width        int
+	Height       int
+	VideoBitrate string
+	AudioBitrate string
+}
+
+// Resolution returns the rendition size as "WxH"
+func (r Rendition) Resolution() string {
+	return fmt.Sprintf("%dx%d", r.Width, r.Height)
+}
+
+// SelectLadder picks the renditions of ladder that fit a sourceWidth x sourceHeight video.
+// Rungs are matched against the short side of the source, so the Height of a rung is the
+// height of landscape renditions and the width of portrait ones; the other side follows the
+// source aspect ratio. Renditions larger than the source are dropped so nothing is upscaled.
+// A source smaller than every rung gets a single rendition at its own size, using the
+// bitrates of the lowest rung.
+func SelectLadder(sourceWidth, sourceHeight int, ladder []Rendition) []Rendition {
+	if sourceWidth <= 0 || sourceHeight <= 0 || len(ladder) == 0 {
+		return nil
+	}
+
+	rungs := slices.Clone(ladder)
+	slices.SortFunc(rungs, func(a, b Rendition) int {
+		return a.Height - b.Height
+	})
+
+	portrait := sourceHeight > sourceWidth
+	shortSide := min(sourceWidth, sourceHeight)
+
+	// fit sizes a rung to the source aspect ratio
+	fit := func(rung Rendition, size int) Rendition {
+		if portrait {
+			rung.Width = size
+			rung.Height = ScaledHeight(sourceWidth, sourceHeight, size)
+		} else {
+			rung.Height = size
+			rung.Width = ScaledWidth(sourceWidth, sourceHeight, size)
+		}
+		return rung
+	}
+
+	selected := make([]Rendition, 0, len(rungs))
+	for _, rung := range rungs {
+		if rung.Height > shortSide {
+			break
+		}
+
+		selected = append(selected, fit(rung, rung.Height))
+	}
+
+	if len(selected) == 0 {
+		size := shortSide - shortSide%2
+		native := fit(rungs[0], size)
+		native.QualityName = fmt.Sprintf("%dp", size)
+		selected = append(selected, native)
+	}
+
+	return selected
+}
+
+// ScaledWidth returns the width that keeps the source aspect ratio at the given height,
+// rounded to an even number the same way FFmpeg resolves "scale=-2:height"
+func ScaledWidth(sourceWidth, sourceHeight, height int) int {
+	if sourceHeight <= 0 {
+		return 0
+	}
+
+	// Round half up of height*sourceWidth/(sourceHeight*2), then back to an even width
+	denominator := int64(sourceHeight) * 2
+	half := (int64(height)*int64(sourceWidth) + denominator/2) / denominator
+
+	return int(half * 2)
+}
+
+// ScaledHeight returns the height that keeps the source aspect ratio at the given width,
+// rounded to an even number the same way FFmpeg resolves "scale=width:-2"
+func ScaledHeight(sourceWidth, sourceHeight, width int) int {
+	return ScaledWidth(sourceHeight, sourceWidth, width)
+}
+
+// SegmentationOptions returns segmentation options for the rendition based on base
+func (r Rendition) SegmentationOptions(base SegmentationOptions) SegmentationOptions {
+	options := base
+	options.QualityName = r.QualityName
+	options.VideoBitrate = r.VideoBitrate
+	options.AudioBitrate = r.AudioBitrate
+	options.Resolution = r.Resolution()
+	if r.Height > r.Width {
+		options.ScaleWidth = r.Width
+	} else {
+		options.ScaleHeight = r.Height
+	}
+	return options
+}
@@ -0,0 +1,71 @@
+package ffmpeg
+
+import (
+	"strconv"
+)
+
+// Rotation returns the clockwise rotation, in degrees within [0, 360), that players apply
+// when displaying the stream. It reads the display matrix side data written by newer
+// ffprobe versions and falls back to the legacy "rotate" tag.
+func (s *StreamInfo) Rotation() int {
+	rotation := 0
+	found := false
+	for _, sideData := range s.SideDataList {
+		if sideData.SideDataType == "Display Matrix" {
+			// The display matrix stores the counter-clockwise angle
+			rotation = -sideData.Rotation
+			found = true
+			break
+		}
+	}
+
+	if !found {
+		if tag, ok := s.Tags["rotate"]; ok {
+			if value, err := strconv.Atoi(tag); err == nil {
+				rotation = value
+			}
+		}
+	}
+
+	rotation %= 360
+	if rotation < 0 {
+		rotation += 360
+	}
+
+	return rotation
+}
+
+// DisplaySize returns the width and height of the stream as it is displayed, with the
+// coded dimensions swapped for videos rotated by 90 or 270 degrees. FFmpeg applies the
+// same rotation while decoding, so filters see frames of this size.
+func (s *StreamInfo) DisplaySize() (int, int) {
+	switch s.Rotation() {
+	case 90, 270:
+		return s.Height, s.Width
+	default:
+		return s.Width, s.Height
+	}
+}
+
+// Orientation returns whether the stream is displayed as landscape, portrait or square
+func (s *StreamInfo) Orientation() string {
+	width, height := s.DisplaySize()
+	switch {
+	case height > width:
+		return OrientationPortrait
+	case width > height:
+		return OrientationLandscape
+	default:
+		return OrientationSquare
+	}
+}
+
+// DurationSeconds returns the container duration in seconds, or zero when it is unknown
+func (p *ProbeInfo) DurationSeconds() float64 {
+	duration, err := strconv.ParseFloat(p.Format.Duration, 64)
+	if err != nil || duration < 0 {
+		return 0
+	}
+
+	return duration
+}
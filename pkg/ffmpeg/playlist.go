@@ -0,0 +1,120 @@
+package ffmpeg
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// StreamVariant is one variant stream of an HLS master playlist
+type StreamVariant struct {
+	URI              string
+	Bandwidth        int     // Peak bitrate in bits per second
+	AverageBandwidth int     // Average bitrate in bits per second, zero when unknown
+	Resolution       string  // "WxH", empty when unknown
+	FrameRate        float64 // Zero when unknown
+	Codecs           string  // RFC 6381 codecs, empty when unknown
+}
+
+// MasterPlaylist writes an HLS master playlist listing variants in the given order.
+// BANDWIDTH is the only required attribute, so variants without one are left out.
+func MasterPlaylist(variants []StreamVariant) string {
+	lines := []string{"#EXTM3U", "#EXT-X-VERSION:3"}
+
+	for _, variant := range variants {
+		if variant.Bandwidth <= 0 {
+			continue
+		}
+
+		attributes := []string{"BANDWIDTH=" + strconv.Itoa(variant.Bandwidth)}
+		if variant.AverageBandwidth > 0 {
+			attributes = append(attributes, "AVERAGE-BANDWIDTH="+strconv.Itoa(variant.AverageBandwidth))
+		}
+		if variant.Resolution != "" {
+			attributes = append(attributes, "RESOLUTION="+variant.Resolution)
+		}
+		if variant.FrameRate > 0 {
+			attributes = append(attributes, fmt.Sprintf("FRAME-RATE=%.3f", variant.FrameRate))
+		}
+		if variant.Codecs != "" {
+			attributes = append(attributes, fmt.Sprintf("CODECS=%q", variant.Codecs))
+		}
+
+		lines = append(lines, "#EXT-X-STREAM-INF:"+strings.Join(attributes, ","), variant.URI)
+	}
+
+	return strings.Join(lines, "\n") + "\n"
+}
+
+// MediaSegment is one segment listed in an HLS media playlist
+type MediaSegment struct {
+	URI      string
+	Duration float64 // Seconds
+}
+
+// MediaPlaylist holds the segments listed in an HLS media playlist
+type MediaPlaylist struct {
+	InitSegment string // URI of the EXT-X-MAP initialization segment, empty for MPEG-TS segments
+	Segments    []MediaSegment
+}
+
+// ParseMediaPlaylist reads the segments of an HLS media playlist. Segments without a duration
+// are listed with a zero duration.
+func ParseMediaPlaylist(content string) MediaPlaylist {
+	var playlist MediaPlaylist
+	var segmentDuration float64
+
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(line, "#EXT-X-MAP:"):
+			playlist.InitSegment = PlaylistAttribute(line, "URI")
+		case strings.HasPrefix(line, "#EXTINF:"):
+			// #EXTINF:6.000000, holds the duration of the segment on the next line
+			value, _, _ := strings.Cut(strings.TrimPrefix(line, "#EXTINF:"), ",")
+			segmentDuration, _ = strconv.ParseFloat(value, 64)
+		case line == "" || strings.HasPrefix(line, "#"):
+			continue
+		default:
+			playlist.Segments = append(playlist.Segments, MediaSegment{URI: line, Duration: segmentDuration})
+			segmentDuration = 0
+		}
+	}
+
+	return playlist
+}
+
+// Duration returns the total duration of the segments of the playlist in seconds
+func (p MediaPlaylist) Duration() float64 {
+	var duration float64
+	for _, segment := range p.Segments {
+		duration += segment.Duration
+	}
+	return duration
+}
+
+// PlaylistAttribute returns the value of attribute in an HLS tag line, unquoted, or an empty
+// string when the tag does not have it
+func PlaylistAttribute(line, attribute string) string {
+	_, attributes, ok := strings.Cut(line, ":")
+	if !ok {
+		return ""
+	}
+
+	for attributes != "" {
+		var name, value string
+		name, attributes, _ = strings.Cut(attributes, "=")
+		if strings.HasPrefix(attributes, `"`) {
+			value, attributes, _ = strings.Cut(attributes[1:], `"`)
+			attributes = strings.TrimPrefix(attributes, ",")
+		} else {
+			value, attributes, _ = strings.Cut(attributes, ",")
+		}
+
+		if strings.TrimSpace(name) == attribute {
+			return value
+		}
+	}
+
+	return ""
+}
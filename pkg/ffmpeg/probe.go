@@ -21,8 +21,9 @@ func (f *FFmpeg) ProbeFile(ctx context.Context, inputPath string) (*ProbeInfo, e
 	// Use ffprobe instead of ffmpeg for probing
 	probePath := strings.Replace(f.binaryPath, "ffmpeg", "ffprobe", 1)
 
+	// Only errors are written to stderr, the probe result goes to stdout
 	args := []string{
-		"-v", "quiet",
+		"-v", "error",
 		"-print_format", "json",
 		"-show_format",
 		"-show_streams",
@@ -32,6 +33,14 @@ func (f *FFmpeg) ProbeFile(ctx context.Context, inputPath string) (*ProbeInfo, e
 	cmd := exec.CommandContext(ctx, probePath, args...)
 	output, err := cmd.Output()
 	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			var tail stderrTail
+			for _, line := range strings.Split(strings.TrimSpace(string(exitErr.Stderr)), "\n") {
+				tail.add(line)
+			}
+			err = &CommandError{Err: err, Stderr: tail.String()}
+		}
 		return nil, errors.Wrap(err, "failed to probe file")
 	}
 
@@ -49,6 +58,17 @@ func (f *FFmpeg) ProbeFile(ctx context.Context, inputPath string) (*ProbeInfo, e
 	return &probeInfo, nil
 }
 
+// VideoStream returns the first video stream of the probed file, or nil when there is none
+func (p *ProbeInfo) VideoStream() *StreamInfo {
+	for i := range p.Streams {
+		if p.Streams[i].CodecType == "video" {
+			return &p.Streams[i]
+		}
+	}
+
+	return nil
+}
+
 // GetVideoInfo extracts video-specific information from probe data
 func (f *FFmpeg) GetVideoInfo(ctx context.Context, inputPath string) (*StreamInfo, error) {
 	probeInfo, err := f.ProbeFile(ctx, inputPath)
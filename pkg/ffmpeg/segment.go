@@ -45,7 +45,13 @@ func (f *FFmpeg) SegmentVideo(ctx context.Context, inputPath, outputDir string,
 	}
 
 	// Resolution - use scale filter instead of -s to handle aspect ratio properly
-	if options.Resolution != "" {
+	if options.ScaleHeight > 0 {
+		// Let FFmpeg derive an even width from the source aspect ratio
+		args = append(args, "-vf", fmt.Sprintf("scale=-2:%d", options.ScaleHeight))
+	} else if options.ScaleWidth > 0 {
+		// Portrait renditions are sized by width, the height follows the aspect ratio
+		args = append(args, "-vf", fmt.Sprintf("scale=%d:-2", options.ScaleWidth))
+	} else if options.Resolution != "" {
 		// Parse resolution and use scale filter
 		if strings.Contains(options.Resolution, "x") {
 			parts := strings.Split(options.Resolution, "x")
@@ -87,6 +93,16 @@ func (f *FFmpeg) SegmentVideo(ctx context.Context, inputPath, outputDir string,
 	args = append(args, "-hls_time", options.SegmentDuration)
 	args = append(args, "-hls_playlist_type", options.PlaylistType)
 
+	// fMP4 segments share one initialization segment, written next to the playlist
+	if options.SegmentType == SegmentTypeFMP4 {
+		initSegmentName := options.InitSegmentName
+		if initSegmentName == "" {
+			initSegmentName = DefaultInitSegmentName
+		}
+		args = append(args, "-hls_segment_type", SegmentTypeFMP4)
+		args = append(args, "-hls_fmp4_init_filename", initSegmentName)
+	}
+
 	// Segment file format
 	segmentFilename := fmt.Sprintf("%s_%%03d.%s", options.SegmentPrefix, options.SegmentFormat)
 	args = append(args, "-hls_segment_filename", filepath.Join(outputDir, segmentFilename))
@@ -112,7 +128,8 @@ func (f *FFmpeg) SegmentVideo(ctx context.Context, inputPath, outputDir string,
 	return f.runCommand(ctx, args, progressCallback)
 }
 
-// SegmentVideoMultiQuality segments a video into HLS format with multiple quality levels
+// SegmentVideoMultiQuality segments a video into HLS format with multiple quality levels.
+// Progress is reported over all quality levels, which are segmented one after another.
 func (f *FFmpeg) SegmentVideoMultiQuality(ctx context.Context, inputPath, outputDir string, qualities []SegmentationOptions, progressCallback ProgressCallback) error {
 	if err := validateInputFile(inputPath); err != nil {
 		return errors.Wrap(err, "invalid input file")
@@ -124,11 +141,9 @@ func (f *FFmpeg) SegmentVideoMultiQuality(ctx context.Context, inputPath, output
 
 	// Create master playlist
 	masterPlaylistPath := filepath.Join(outputDir, MasterPlaylistName)
-	var masterPlaylistLines []string
-	masterPlaylistLines = append(masterPlaylistLines, "#EXTM3U")
-	masterPlaylistLines = append(masterPlaylistLines, "#EXT-X-VERSION:3")
+	variants := make([]StreamVariant, 0, len(qualities))
 
-	for _, quality := range qualities {
+	for i, quality := range qualities {
 		// Create quality-specific directory
 		qualityDir := filepath.Join(outputDir, quality.QualityName)
 		if err := ensureOutputDir(qualityDir); err != nil {
@@ -142,29 +157,20 @@ func (f *FFmpeg) SegmentVideoMultiQuality(ctx context.Context, inputPath, output
 			zap.String("resolution", quality.Resolution),
 			zap.String("bitrate", quality.VideoBitrate))
 
-		if err := f.SegmentVideo(ctx, inputPath, qualityDir, quality, progressCallback); err != nil {
+		if err := f.SegmentVideo(ctx, inputPath, qualityDir, quality, overallProgress(progressCallback, i, len(qualities))); err != nil {
 			return errors.Wrapf(err, "failed to segment quality level %s", quality.QualityName)
 		}
 
 		// Add to master playlist
-		bandwidth := extractBandwidth(quality.VideoBitrate, quality.AudioBitrate)
-		resolution := quality.Resolution
-
-		if bandwidth > 0 {
-			streamInfo := fmt.Sprintf("#EXT-X-STREAM-INF:BANDWIDTH=%d", bandwidth)
-			if resolution != "" {
-				streamInfo += fmt.Sprintf(",RESOLUTION=%s", resolution)
-			}
-			masterPlaylistLines = append(masterPlaylistLines, streamInfo)
-		}
-
-		relativePath := fmt.Sprintf("%s/%s", quality.QualityName, DefaultPlaylistName)
-		masterPlaylistLines = append(masterPlaylistLines, relativePath)
+		variants = append(variants, StreamVariant{
+			URI:        fmt.Sprintf("%s/%s", quality.QualityName, DefaultPlaylistName),
+			Bandwidth:  NominalBandwidth(quality.VideoBitrate, quality.AudioBitrate),
+			Resolution: quality.Resolution,
+		})
 	}
 
 	// Write master playlist
-	masterPlaylistContent := strings.Join(masterPlaylistLines, "\n")
-	if err := os.WriteFile(masterPlaylistPath, []byte(masterPlaylistContent), 0644); err != nil {
+	if err := os.WriteFile(masterPlaylistPath, []byte(MasterPlaylist(variants)), 0644); err != nil {
 		return errors.Wrap(err, "failed to write master playlist")
 	}
 
@@ -267,8 +273,9 @@ func (f *FFmpeg) CreatePreviewClips(ctx context.Context, inputPath, outputDir st
 	return nil
 }
 
-// extractBandwidth extracts bandwidth from bitrate strings
-func extractBandwidth(videoBitrate, audioBitrate string) int {
+// NominalBandwidth returns the bandwidth, in bits per second, targeted by the given encoder
+// bitrates. The bitrate actually produced by the encoder can peak above it.
+func NominalBandwidth(videoBitrate, audioBitrate string) int {
 	totalBandwidth := 0
 
 	if videoBitrate != "" {
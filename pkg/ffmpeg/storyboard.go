@@ -0,0 +1,179 @@
+package ffmpeg
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+	"go.uber.org/zap"
+
+	"github.com/sweetloveinyourheart/sweet-reel/pkg/logger"
+)
+
+// StoryboardOptions describes the sprite sheets of a storyboard. Frames are sampled every
+// IntervalSeconds, scaled to tiles and laid out left to right, top to bottom, in sprites of
+// Columns by Rows tiles.
+type StoryboardOptions struct {
+	IntervalSeconds int
+	TileWidth       int
+	TileHeight      int
+	Columns         int
+	Rows            int
+	SpritePrefix    string // Sprites are named {prefix}_000.jpg, {prefix}_001.jpg...
+}
+
+// DefaultStoryboardOptions returns the storyboard settings for a video of width x height,
+// whose longest side is scaled to DefaultStoryboardTileSize
+func DefaultStoryboardOptions(width, height int) StoryboardOptions {
+	tileWidth, tileHeight := DefaultStoryboardTileSize, DefaultStoryboardTileSize
+	if width > 0 && height > 0 {
+		if width >= height {
+			tileHeight = evenDimension(float64(DefaultStoryboardTileSize*height) / float64(width))
+		} else {
+			tileWidth = evenDimension(float64(DefaultStoryboardTileSize*width) / float64(height))
+		}
+	}
+
+	return StoryboardOptions{
+		IntervalSeconds: DefaultStoryboardInterval,
+		TileWidth:       tileWidth,
+		TileHeight:      tileHeight,
+		Columns:         DefaultStoryboardColumns,
+		Rows:            DefaultStoryboardRows,
+		SpritePrefix:    DefaultStoryboardSpritePrefix,
+	}
+}
+
+// TilesPerSprite returns how many frames a sprite holds
+func (o StoryboardOptions) TilesPerSprite() int {
+	return o.Columns * o.Rows
+}
+
+// SpriteName returns the file name of the sprite at index, counted from zero
+func (o StoryboardOptions) SpriteName(index int) string {
+	return fmt.Sprintf("%s_%03d%s", o.SpritePrefix, index, ExtJPG)
+}
+
+// Validate checks that the options describe a storyboard FFmpeg can write
+func (o StoryboardOptions) Validate() error {
+	if o.IntervalSeconds <= 0 {
+		return errors.New("storyboard interval must be positive")
+	}
+
+	if o.TileWidth <= 0 || o.TileHeight <= 0 {
+		return errors.New("storyboard tile size must be positive")
+	}
+
+	if o.Columns <= 0 || o.Rows <= 0 {
+		return errors.New("storyboard columns and rows must be positive")
+	}
+
+	if strings.TrimSpace(o.SpritePrefix) == "" {
+		return errors.New("storyboard sprite prefix is required")
+	}
+
+	return nil
+}
+
+// CreateStoryboard samples a frame of the video every options.IntervalSeconds and tiles them
+// into JPEG sprites written to outputDir, see StoryboardOptions.SpriteName. The last sprite is
+// only partly filled when the frames run out.
+func (f *FFmpeg) CreateStoryboard(ctx context.Context, inputPath, outputDir string, options StoryboardOptions) error {
+	if err := validateInputFile(inputPath); err != nil {
+		return errors.Wrap(err, "invalid input file")
+	}
+
+	if err := options.Validate(); err != nil {
+		return errors.Wrap(err, "invalid storyboard options")
+	}
+
+	if err := ensureOutputDir(outputDir); err != nil {
+		return errors.Wrap(err, "failed to create output directory")
+	}
+
+	filter := fmt.Sprintf("fps=1/%d,scale=%d:%d,tile=%dx%d",
+		options.IntervalSeconds, options.TileWidth, options.TileHeight, options.Columns, options.Rows)
+	outputPattern := filepath.Join(outputDir, options.SpritePrefix+"_%03d"+ExtJPG)
+
+	args := []string{
+		"-y",            // Overwrite output files
+		"-i", inputPath, // Input file
+		"-an",         // Disable audio
+		"-vf", filter, // Sample, scale and tile frames
+		"-q:v", "5", // Sprites are small previews, trade quality for size
+		"-start_number", "0", // Sprites are numbered from zero, like segments
+		outputPattern,
+	}
+
+	logger.Global().Info("Creating storyboard",
+		zap.String("input", inputPath),
+		zap.String("outputDir", outputDir),
+		zap.String("filter", filter))
+
+	return f.runCommand(ctx, args, nil)
+}
+
+// StoryboardTrack writes the WebVTT track of a storyboard of durationSeconds made of sprites,
+// mapping the time range of every frame to its tile, e.g. "storyboard_000.jpg#xywh=160,0,160,90".
+// Sprite urls are relative to the track.
+func StoryboardTrack(durationSeconds float64, sprites int, options StoryboardOptions) string {
+	frames := sprites * options.TilesPerSprite()
+	if durationSeconds > 0 {
+		frames = min(frames, int(math.Ceil(durationSeconds/float64(options.IntervalSeconds))))
+	}
+
+	lines := []string{"WEBVTT", ""}
+	for frame := range frames {
+		start := float64(frame * options.IntervalSeconds)
+		end := float64((frame + 1) * options.IntervalSeconds)
+		if durationSeconds > 0 {
+			end = min(end, durationSeconds)
+		}
+
+		tile := frame % options.TilesPerSprite()
+		x := (tile % options.Columns) * options.TileWidth
+		y := (tile / options.Columns) * options.TileHeight
+
+		lines = append(lines,
+			vttTimestamp(start)+" --> "+vttTimestamp(end),
+			fmt.Sprintf("%s#xywh=%d,%d,%d,%d", options.SpriteName(frame/options.TilesPerSprite()), x, y, options.TileWidth, options.TileHeight),
+			"")
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// ParseVTTTimestamp parses a WebVTT timestamp, "mm:ss.ttt" or "hh:mm:ss.ttt", into seconds
+func ParseVTTTimestamp(timestamp string) (float64, error) {
+	parts := strings.Split(strings.TrimSpace(timestamp), ":")
+	if len(parts) < 2 || len(parts) > 3 {
+		return 0, errors.Errorf("invalid WebVTT timestamp: %s", timestamp)
+	}
+
+	var seconds float64
+	for _, part := range parts {
+		value, err := strconv.ParseFloat(part, 64)
+		if err != nil || value < 0 {
+			return 0, errors.Errorf("invalid WebVTT timestamp: %s", timestamp)
+		}
+		seconds = seconds*60 + value
+	}
+
+	return seconds, nil
+}
+
+// vttTimestamp writes seconds as a WebVTT timestamp, e.g. "00:01:05.500"
+func vttTimestamp(seconds float64) string {
+	milliseconds := int64(math.Round(seconds * 1000))
+	return fmt.Sprintf("%02d:%02d:%02d.%03d",
+		milliseconds/3_600_000, milliseconds/60_000%60, milliseconds/1000%60, milliseconds%1000)
+}
+
+// evenDimension rounds a scaled dimension to the nearest even number, at least 2
+func evenDimension(value float64) int {
+	return max(2, int(math.Round(value/2))*2)
+}
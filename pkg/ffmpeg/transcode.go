@@ -105,7 +105,8 @@ func (f *FFmpeg) Transcode(ctx context.Context, inputPath, outputPath string, op
 	return f.runCommand(ctx, args, progressCallback)
 }
 
-// TranscodeToMultipleQualities transcodes a video to multiple quality levels
+// TranscodeToMultipleQualities transcodes a video to multiple quality levels, reporting
+// progress over all of them
 func (f *FFmpeg) TranscodeToMultipleQualities(ctx context.Context, inputPath string, outputDir string, qualities []TranscodeOptions, progressCallback ProgressCallback) error {
 	if err := validateInputFile(inputPath); err != nil {
 		return errors.Wrap(err, "invalid input file")
@@ -125,7 +126,7 @@ func (f *FFmpeg) TranscodeToMultipleQualities(ctx context.Context, inputPath str
 			zap.Int("total", len(qualities)),
 			zap.String("output", outputFilename))
 
-		if err := f.Transcode(ctx, inputPath, outputPath, quality, progressCallback); err != nil {
+		if err := f.Transcode(ctx, inputPath, outputPath, quality, overallProgress(progressCallback, i, len(qualities))); err != nil {
 			return errors.Wrapf(err, "failed to transcode quality level %d", i+1)
 		}
 	}
@@ -58,11 +58,17 @@ type SegmentationOptions struct {
 	SegmentPrefix string // Prefix for segment files (default: "segment")
 	SegmentFormat string // Segment file format (default: "ts")
 
+	// Segment container
+	SegmentType     string // "mpegts" (default) or "fmp4"
+	InitSegmentName string // Name of the fMP4 initialization segment (default: "init.mp4")
+
 	// Video options (inherited from transcoding)
 	VideoCodec   string
 	VideoBitrate string
 	VideoQuality string
 	Resolution   string
+	ScaleHeight  int // Scale to this height keeping the source aspect ratio, takes precedence over Resolution
+	ScaleWidth   int // Scale to this width keeping the source aspect ratio, used for portrait renditions
 	FrameRate    string
 
 	// Audio options (inherited from transcoding)
@@ -136,4 +142,11 @@ type StreamInfo struct {
 	ChannelLayout      string            `json:"channel_layout,omitempty"`
 	BitsPerSample      int               `json:"bits_per_sample,omitempty"`
 	Tags               map[string]string `json:"tags,omitempty"`
+	SideDataList       []SideData        `json:"side_data_list,omitempty"`
+}
+
+// SideData contains stream side data, such as the display matrix of rotated videos
+type SideData struct {
+	SideDataType string `json:"side_data_type"`
+	Rotation     int    `json:"rotation,omitempty"`
 }
@@ -274,6 +274,12 @@ func GetMimeType(filePath string) string {
 		return MimeTypeM3U8
 	case ExtTS:
 		return MimeTypeTS
+	case ExtM4S:
+		return MimeTypeM4S
+	case ExtMPD:
+		return MimeTypeMPD
+	case ExtVTT:
+		return MimeTypeVTT
 	case ExtMP4:
 		return MimeTypeMP4
 	case ExtJPG, ExtJPEG:
@@ -59,6 +59,13 @@ func UnauthenticatedError(err error) error {
 	return connect.NewError(connect.CodeUnauthenticated, errors.Newf("unauthenticated: %s", err))
 }
 
+func PermissionDeniedError(err error) error {
+	if connectErr := new(connect.Error); errors.As(err, &connectErr) {
+		return err
+	}
+	return connect.NewError(connect.CodePermissionDenied, errors.Newf("permission denied: %s", err))
+}
+
 func PreconditionFailure(typ string, subject string, desc string) *errdetails.PreconditionFailure_Violation {
 	return &errdetails.PreconditionFailure_Violation{
 		Type:        typ,
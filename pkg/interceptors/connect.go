@@ -2,6 +2,7 @@ package interceptors
 
 import (
 	"connectrpc.com/connect"
+	"connectrpc.com/otelconnect"
 
 	auth_interceptors "github.com/sweetloveinyourheart/sweet-reel/pkg/interceptors/auth"
 	client_interceptors "github.com/sweetloveinyourheart/sweet-reel/pkg/interceptors/client"
@@ -13,12 +14,25 @@ func CommonConnectInterceptors(serviceName string, signingKey string, authFunc a
 	}
 
 	return []connect.Interceptor{
+		// Calls come from the other services, whose trace is continued
+		tracingInterceptor(otelconnect.WithTrustRemote()),
 		auth_interceptors.NewAuthInterceptor(authFunc, authOpts...),
 	}
 }
 
 func CommonConnectClientInterceptors(serviceName string, signingKey string) []connect.Interceptor {
 	return []connect.Interceptor{
+		tracingInterceptor(),
 		client_interceptors.NewServiceClientInterceptor(serviceName, signingKey),
 	}
 }
+
+// tracingInterceptor records a span for every call and propagates its trace context
+func tracingInterceptor(opts ...otelconnect.Option) connect.Interceptor {
+	interceptor, err := otelconnect.NewInterceptor(opts...)
+	if err != nil {
+		// Only invalid options fail
+		panic(err)
+	}
+	return interceptor
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/IBM/sarama"
 
 	"github.com/sweetloveinyourheart/sweet-reel/pkg/config"
+	"github.com/sweetloveinyourheart/sweet-reel/pkg/messages"
 )
 
 // Config holds the configuration for Kafka client
@@ -27,6 +28,16 @@ type Config struct {
 	SASLPassword     string
 	TLSEnabled       bool
 	MaxOpenRequests  int
+	ConsumerRetry    RetryPolicy
+	// ConsumerInitialOffset is where a consumer group without a committed offset starts,
+	// sarama.OffsetOldest or by default sarama.OffsetNewest
+	ConsumerInitialOffset int64
+	// Producer names the service in the envelope of the events it publishes
+	Producer string
+	// EventEncoding is how event envelopes are encoded
+	EventEncoding messages.Encoding
+	// Transport replaces the connection to Brokers, e.g. with an in-memory broker in tests
+	Transport Transport
 }
 
 // DefaultConfig returns a default configuration for Kafka
@@ -43,6 +54,8 @@ func DefaultConfig() *Config {
 		IdempotentWrites: true,
 		SecurityProtocol: "PLAINTEXT",
 		MaxOpenRequests:  1,
+		ConsumerRetry:    DefaultRetryPolicy(),
+		EventEncoding:    messages.EncodingJSON,
 	}
 }
 
@@ -100,6 +113,21 @@ func ServiceConfig(serviceType string) *Config {
 	cfg.TLSEnabled = config.Instance().GetBool(fmt.Sprintf("%s.kafka.tls_enabled", serviceType))
 	cfg.MaxOpenRequests = int(config.Instance().GetInt32(fmt.Sprintf("%s.kafka.max_open_requests", serviceType)))
 
+	// Consumer retry policy
+	cfg.ConsumerRetry.MaxAttempts = int(config.Instance().GetInt32(fmt.Sprintf("%s.kafka.consumer_retry.max_attempts", serviceType)))
+	cfg.ConsumerRetry.InitialBackoff = time.Duration(config.Instance().GetInt64(fmt.Sprintf("%s.kafka.consumer_retry.backoff_ms", serviceType))) * time.Millisecond
+	cfg.ConsumerRetry.MaxBackoff = time.Duration(config.Instance().GetInt64(fmt.Sprintf("%s.kafka.consumer_retry.max_backoff_ms", serviceType))) * time.Millisecond
+	cfg.ConsumerRetry.RetryTopic = config.Instance().GetString(fmt.Sprintf("%s.kafka.consumer_retry.topic", serviceType))
+
+	// Events
+	cfg.Producer = serviceType
+	switch strings.ToLower(config.Instance().GetString(fmt.Sprintf("%s.kafka.event_encoding", serviceType))) {
+	case "protobuf":
+		cfg.EventEncoding = messages.EncodingProtobuf
+	default:
+		cfg.EventEncoding = messages.EncodingJSON
+	}
+
 	return cfg
 }
 
@@ -122,6 +150,9 @@ func (c *Config) ToSaramaConfig() *sarama.Config {
 	// Consumer settings
 	config.Consumer.Return.Errors = true
 	config.Consumer.Offsets.Initial = sarama.OffsetNewest
+	if c.ConsumerInitialOffset == sarama.OffsetOldest {
+		config.Consumer.Offsets.Initial = sarama.OffsetOldest
+	}
 	config.Consumer.Group.Rebalance.Strategy = sarama.NewBalanceStrategyRoundRobin()
 
 	// Version
@@ -4,6 +4,8 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"slices"
+	"strconv"
 	"sync"
 	"time"
 
@@ -41,6 +43,8 @@ func (m *ConsumedMessage) ValueAsJSON(v any) error {
 type Consumer struct {
 	consumerGroup sarama.ConsumerGroup
 	config        *Config
+	producer      *Producer
+	producerMu    sync.Mutex
 	handler       MessageHandler
 	topics        []string
 	ctx           context.Context
@@ -90,30 +94,141 @@ func (h *ConsumerGroupHandler) ConsumeClaim(session sarama.ConsumerGroupSession,
 				Timestamp: message.Timestamp,
 			}
 
-			// Call the message handler
-			if err := h.consumer.handler(h.consumer.ctx, msg); err != nil {
-				logger.Global().ErrorContext(h.consumer.ctx, "Message handler failed",
+			// Messages from producers that set no id are identified by where they were first read,
+			// which is then carried along when the message is retried or dead-lettered
+			if headers[HeaderMessageID] == "" {
+				headers[HeaderMessageID] = msg.MessageID()
+			}
+
+			if err := h.consumer.process(msg); err != nil {
+				if h.consumer.ctx.Err() != nil {
+					// Shutting down, the message is picked up again by the next session
+					return nil
+				}
+
+				// The message could not be parked on the retry or dead-letter topic.
+				// Ending the claim without marking it makes it redelivered after the rebalance.
+				logger.Global().ErrorContext(h.consumer.ctx, "Failed to hand off failed message",
 					zap.String("topic", msg.Topic),
 					zap.Int32("partition", msg.Partition),
 					zap.Int64("offset", msg.Offset),
 					zap.String("key", msg.Key),
 					zap.Error(err),
 				)
-				// Depending on your error handling strategy, you might want to:
-				// 1. Continue processing (current behavior)
-				// 2. Return the error to stop processing
-				// 3. Send to a dead letter queue
-			} else {
-				// Mark message as processed
-				session.MarkMessage(message, "")
+				return err
 			}
 
+			// Mark message as processed
+			session.MarkMessage(message, "")
+
 		case <-h.consumer.ctx.Done():
 			return nil
 		}
 	}
 }
 
+// process runs the handler on msg following the retry policy. A nil error means the
+// message is done with: handled, moved to the retry topic or dead-lettered.
+// The handler runs in a span continuing the trace of the producer of msg.
+func (c *Consumer) process(msg *ConsumedMessage) (err error) {
+	policy := c.config.ConsumerRetry
+
+	// Messages coming back from the retry topic are handled under their original topic
+	if policy.RetryTopic != "" && msg.Topic == policy.RetryTopic {
+		if origin := msg.Headers[HeaderOriginalTopic]; origin != "" {
+			msg.Topic = origin
+		}
+	}
+
+	// Retried messages, whether from the retry topic or put back on their own topic,
+	// are handled once their backoff has elapsed
+	if retryAfter, err := strconv.ParseInt(msg.Headers[HeaderRetryAfter], 10, 64); err == nil {
+		if err := sleepContext(c.ctx, time.Until(time.UnixMilli(retryAfter))); err != nil {
+			return err
+		}
+	}
+
+	ctx, span := startProcessSpan(c.ctx, msg)
+	defer func() { endSpan(span, err) }()
+
+	attempts := msg.Attempts()
+	for {
+		err := c.handler(ctx, msg)
+		if err == nil {
+			return nil
+		}
+
+		if c.ctx.Err() != nil {
+			return c.ctx.Err()
+		}
+
+		attempts++
+		span.RecordError(err)
+		logger.Global().ErrorContext(ctx, "Message handler failed",
+			zap.String("topic", msg.Topic),
+			zap.Int32("partition", msg.Partition),
+			zap.Int64("offset", msg.Offset),
+			zap.String("key", msg.Key),
+			zap.Int("attempt", attempts),
+			zap.Int("max_attempts", policy.Attempts()),
+			zap.Error(err),
+		)
+
+		if attempts >= policy.Attempts() {
+			producer, perr := c.getProducer()
+			if perr != nil {
+				return perr
+			}
+			return publishDeadLetter(ctx, producer, msg, attempts, err)
+		}
+
+		if policy.RetryTopic != "" {
+			producer, perr := c.getProducer()
+			if perr != nil {
+				return perr
+			}
+			return publishRetry(ctx, producer, policy, msg, attempts, err)
+		}
+
+		if err := sleepContext(ctx, policy.Backoff(attempts)); err != nil {
+			return err
+		}
+	}
+}
+
+// getProducer returns the producer used for retries and dead letters (creates if not exists)
+func (c *Consumer) getProducer() (*Producer, error) {
+	c.producerMu.Lock()
+	defer c.producerMu.Unlock()
+
+	if c.producer == nil {
+		producer, err := NewProducer(c.config)
+		if err != nil {
+			return nil, err
+		}
+		c.producer = producer
+	}
+
+	return c.producer, nil
+}
+
+// sleepContext waits for d or until ctx is done
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
 // NewConsumer creates a new Kafka consumer
 func NewConsumer(config *Config, groupID string, topics []string, handler MessageHandler) (*Consumer, error) {
 	if config == nil {
@@ -124,8 +239,12 @@ func NewConsumer(config *Config, groupID string, topics []string, handler Messag
 		return nil, fmt.Errorf("message handler cannot be nil")
 	}
 
-	saramaConfig := config.ToSaramaConfig()
-	consumerGroup, err := sarama.NewConsumerGroup(config.Brokers, groupID, saramaConfig)
+	// Retried messages come back through the retry topic, so consume it as well
+	if retryTopic := config.ConsumerRetry.RetryTopic; retryTopic != "" && !slices.Contains(topics, retryTopic) {
+		topics = append(slices.Clone(topics), retryTopic)
+	}
+
+	consumerGroup, err := config.transport().NewConsumerGroup(config, groupID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create consumer group: %w", err)
 	}
@@ -199,9 +318,18 @@ func (c *Consumer) Stop() error {
 
 	c.closed = true
 	c.cancel()
+
+	// Closing the group ends its errors channel, which the error logging goroutine waits on
+	err := c.consumerGroup.Close()
 	c.wg.Wait()
 
-	return c.consumerGroup.Close()
+	if c.producer != nil {
+		if err := c.producer.Close(); err != nil {
+			logger.Global().Error("Failed to close consumer producer", zap.Error(err))
+		}
+	}
+
+	return err
 }
 
 // IsRunning checks if the consumer is running
@@ -0,0 +1,257 @@
+package kafka_test
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/IBM/sarama"
+
+	"github.com/sweetloveinyourheart/sweet-reel/pkg/kafka"
+	"github.com/sweetloveinyourheart/sweet-reel/pkg/testing/fake"
+)
+
+const testTopic = "orders"
+
+// waitFor fails the test when cond does not hold within a few seconds
+func waitFor(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("Timed out waiting for %s", what)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+// headerValue returns the value of the header key of msg
+func headerValue(msg *sarama.ConsumerMessage, key string) string {
+	for _, header := range msg.Headers {
+		if string(header.Key) == key {
+			return string(header.Value)
+		}
+	}
+	return ""
+}
+
+// recordingHandler fails the first failures deliveries and records every delivery
+type recordingHandler struct {
+	mu         sync.Mutex
+	failures   int
+	deliveries []kafka.ConsumedMessage
+}
+
+func (h *recordingHandler) handle(ctx context.Context, msg *kafka.ConsumedMessage) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.deliveries = append(h.deliveries, *msg)
+	if len(h.deliveries) <= h.failures {
+		return errors.New("handler failed")
+	}
+	return nil
+}
+
+func (h *recordingHandler) count() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return len(h.deliveries)
+}
+
+func (h *recordingHandler) delivery(i int) kafka.ConsumedMessage {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return h.deliveries[i]
+}
+
+// startConsumer consumes the test topic under config until the test ends
+func startConsumer(t *testing.T, config *kafka.Config, handler kafka.MessageHandler) {
+	t.Helper()
+
+	consumer, err := kafka.NewConsumer(config, "test-group", []string{testTopic}, handler)
+	if err != nil {
+		t.Fatalf("Failed to create consumer: %v", err)
+	}
+	if err := consumer.Start(context.Background()); err != nil {
+		t.Fatalf("Failed to start consumer: %v", err)
+	}
+	t.Cleanup(func() { _ = consumer.Stop() })
+}
+
+// publish sends a message with the given key to the test topic
+func publish(t *testing.T, config *kafka.Config, key string) {
+	t.Helper()
+
+	producer, err := kafka.NewProducer(config)
+	if err != nil {
+		t.Fatalf("Failed to create producer: %v", err)
+	}
+	defer producer.Close()
+
+	if _, _, err := producer.SendString(context.Background(), testTopic, key, "payload-"+key); err != nil {
+		t.Fatalf("Failed to publish: %v", err)
+	}
+}
+
+func TestConsumerRetriesInPlace(t *testing.T) {
+	broker := fake.NewKafkaBroker()
+	config := broker.Config()
+
+	handler := &recordingHandler{failures: 2}
+	startConsumer(t, config, handler.handle)
+	publish(t, config, "order-1")
+
+	waitFor(t, "the message to be committed", func() bool {
+		return broker.Committed("test-group", testTopic) == 1
+	})
+
+	if got := handler.count(); got != 3 {
+		t.Errorf("Expected 3 deliveries, got %d", got)
+	}
+	if got := len(broker.Messages(kafka.DeadLetterTopic(testTopic))); got != 0 {
+		t.Errorf("Expected no dead letters, got %d", got)
+	}
+	if got := len(broker.Messages(testTopic)); got != 1 {
+		t.Errorf("Expected in place retries not to re-publish, got %d messages", got)
+	}
+}
+
+func TestConsumerDeadLettersAfterMaxAttempts(t *testing.T) {
+	broker := fake.NewKafkaBroker()
+	config := broker.Config()
+
+	handler := &recordingHandler{failures: 100}
+	startConsumer(t, config, handler.handle)
+	publish(t, config, "order-1")
+
+	dlqTopic := kafka.DeadLetterTopic(testTopic)
+	waitFor(t, "the message to be dead-lettered", func() bool {
+		return len(broker.Messages(dlqTopic)) == 1
+	})
+	waitFor(t, "the message to be committed", func() bool {
+		return broker.Committed("test-group", testTopic) == 1
+	})
+
+	if got := handler.count(); got != config.ConsumerRetry.MaxAttempts {
+		t.Errorf("Expected %d deliveries, got %d", config.ConsumerRetry.MaxAttempts, got)
+	}
+
+	deadLetter := broker.Messages(dlqTopic)[0]
+	if got := string(deadLetter.Key); got != "order-1" {
+		t.Errorf("Expected key order-1, got %s", got)
+	}
+	if got := headerValue(deadLetter, kafka.HeaderAttempts); got != strconv.Itoa(config.ConsumerRetry.MaxAttempts) {
+		t.Errorf("Expected %d attempts, got %s", config.ConsumerRetry.MaxAttempts, got)
+	}
+	if got := headerValue(deadLetter, kafka.HeaderOriginalTopic); got != testTopic {
+		t.Errorf("Expected original topic %s, got %s", testTopic, got)
+	}
+	if got := headerValue(deadLetter, kafka.HeaderError); got != "handler failed" {
+		t.Errorf("Expected the handler error, got %s", got)
+	}
+}
+
+func TestConsumerRetryTopic(t *testing.T) {
+	broker := fake.NewKafkaBroker()
+	config := broker.Config()
+	config.ConsumerRetry.RetryTopic = "orders-retry"
+
+	handler := &recordingHandler{failures: 1}
+	startConsumer(t, config, handler.handle)
+	publish(t, config, "order-1")
+
+	waitFor(t, "the retried message to be handled", func() bool {
+		return handler.count() == 2
+	})
+	waitFor(t, "the retried message to be committed", func() bool {
+		return broker.Committed("test-group", "orders-retry") == 1
+	})
+
+	retried := broker.Messages("orders-retry")
+	if len(retried) != 1 {
+		t.Fatalf("Expected 1 message on the retry topic, got %d", len(retried))
+	}
+	if got := headerValue(retried[0], kafka.HeaderAttempts); got != "1" {
+		t.Errorf("Expected 1 attempt, got %s", got)
+	}
+	if headerValue(retried[0], kafka.HeaderRetryAfter) == "" {
+		t.Error("Expected the retry to carry when it is due")
+	}
+
+	// The retry is handled under the topic the message was published on
+	second := handler.delivery(1)
+	if second.Topic != testTopic {
+		t.Errorf("Expected the retry to be handled as %s, got %s", testTopic, second.Topic)
+	}
+	if got := second.Attempts(); got != 1 {
+		t.Errorf("Expected the retry to know of 1 attempt, got %d", got)
+	}
+}
+
+func TestRejectRetriesOnOriginalTopic(t *testing.T) {
+	broker := fake.NewKafkaBroker()
+	config := broker.Config()
+	config.ConsumerRetry.InitialBackoff = 50 * time.Millisecond
+	config.ConsumerRetry.MaxBackoff = time.Second
+
+	client, err := kafka.NewClient(config)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	var mu sync.Mutex
+	var handledAt []time.Time
+	startConsumer(t, config, func(ctx context.Context, msg *kafka.ConsumedMessage) error {
+		mu.Lock()
+		handledAt = append(handledAt, time.Now())
+		mu.Unlock()
+
+		// Fails outside of the handler, e.g. in a job the handler started
+		return client.Reject(ctx, msg, errors.New("job failed"))
+	})
+	publish(t, config, "order-1")
+
+	dlqTopic := kafka.DeadLetterTopic(testTopic)
+	waitFor(t, "the message to be dead-lettered", func() bool {
+		return len(broker.Messages(dlqTopic)) == 1
+	})
+
+	// Every attempt but the last is put back on the topic the message came from
+	messages := broker.Messages(testTopic)
+	if len(messages) != config.ConsumerRetry.MaxAttempts {
+		t.Fatalf("Expected %d messages on %s, got %d", config.ConsumerRetry.MaxAttempts, testTopic, len(messages))
+	}
+	for i, msg := range messages[1:] {
+		if got := headerValue(msg, kafka.HeaderAttempts); got != strconv.Itoa(i+1) {
+			t.Errorf("Expected retry %d to carry %d attempts, got %s", i, i+1, got)
+		}
+		if headerValue(msg, kafka.HeaderRetryAfter) == "" {
+			t.Errorf("Expected retry %d to carry when it is due", i)
+		}
+		if got := headerValue(msg, kafka.HeaderOriginalOffset); got != "0" {
+			t.Errorf("Expected retry %d to keep the first origin, got offset %s", i, got)
+		}
+	}
+
+	if got := headerValue(broker.Messages(dlqTopic)[0], kafka.HeaderAttempts); got != strconv.Itoa(config.ConsumerRetry.MaxAttempts) {
+		t.Errorf("Expected the dead letter to carry %d attempts, got %s", config.ConsumerRetry.MaxAttempts, got)
+	}
+
+	// The consumer waits out the backoff before handling a retry, which is due to the millisecond
+	mu.Lock()
+	defer mu.Unlock()
+	if len(handledAt) != config.ConsumerRetry.MaxAttempts {
+		t.Fatalf("Expected %d deliveries, got %d", config.ConsumerRetry.MaxAttempts, len(handledAt))
+	}
+	if waited := handledAt[1].Sub(handledAt[0]); waited < config.ConsumerRetry.Backoff(1)-time.Millisecond {
+		t.Errorf("Expected the first retry to wait %s, waited %s", config.ConsumerRetry.Backoff(1), waited)
+	}
+}
@@ -0,0 +1,301 @@
+package kafka
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/IBM/sarama"
+	"go.uber.org/zap"
+
+	"github.com/sweetloveinyourheart/sweet-reel/pkg/logger"
+)
+
+// DefaultReplayGroup is the consumer group used to track replay progress on dead-letter topics
+const DefaultReplayGroup = "dlq-replay"
+
+// ReplayDeadLetters moves the messages currently on dlqTopic back onto the topic they
+// originally came from, with their retry state cleared. Progress is committed under
+// groupID, so each dead letter is replayed once. A maxMessages of zero replays everything.
+// It returns the number of messages replayed.
+func ReplayDeadLetters(ctx context.Context, config *Config, dlqTopic string, groupID string, maxMessages int) (int, error) {
+	if config == nil {
+		config = DefaultConfig()
+	}
+
+	if !strings.HasSuffix(dlqTopic, DeadLetterSuffix) {
+		return 0, fmt.Errorf("%s is not a dead-letter topic", dlqTopic)
+	}
+
+	if groupID == "" {
+		groupID = DefaultReplayGroup
+	}
+
+	// Only replay what is there now, so messages that fail again are not looped over
+	ends, err := config.transport().NewestOffsets(config, dlqTopic)
+	if err != nil {
+		return 0, err
+	}
+
+	producer, err := NewProducer(config)
+	if err != nil {
+		return 0, err
+	}
+	defer producer.Close()
+
+	// A group that never replayed anything starts at the first dead letter
+	replayConfig := *config
+	replayConfig.ConsumerInitialOffset = sarama.OffsetOldest
+
+	group, err := replayConfig.transport().NewConsumerGroup(&replayConfig, groupID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create consumer group: %w", err)
+	}
+	defer group.Close()
+
+	go func() {
+		for err := range group.Errors() {
+			logger.Global().ErrorContext(ctx, "Dead letter replay consumer error", zap.Error(err))
+		}
+	}()
+
+	handler := &replayHandler{
+		producer: producer,
+		dlqTopic: dlqTopic,
+		ends:     ends,
+		limit:    maxMessages,
+		finished: make(map[int32]bool, len(ends)),
+	}
+
+	// A session ends once every claimed partition is replayed up to its end, which may
+	// leave partitions to a following session after a rebalance
+	for !handler.complete() {
+		if err := group.Consume(ctx, []string{dlqTopic}, handler); err != nil {
+			return handler.count(), fmt.Errorf("failed to consume %s: %w", dlqTopic, err)
+		}
+		if err := handler.failure(); err != nil {
+			return handler.count(), err
+		}
+		if err := ctx.Err(); err != nil {
+			return handler.count(), err
+		}
+	}
+
+	logger.Global().InfoContext(ctx, "Dead letters replayed",
+		zap.String("topic", dlqTopic),
+		zap.Int("count", handler.count()))
+
+	return handler.count(), nil
+}
+
+// replayHandler replays the partitions of a dead-letter topic claimed by a consumer group
+// session up to the offsets they ended at when the replay started
+type replayHandler struct {
+	producer *Producer
+	dlqTopic string
+	ends     map[int32]int64
+	limit    int
+
+	mu       sync.Mutex
+	reserved int
+	replayed int
+	err      error
+	finished map[int32]bool
+	// pending counts the claims of the session still replaying, the session is ended
+	// by closing done once there are none left
+	pending int
+	done    chan struct{}
+	stopped bool
+}
+
+// Setup is called at the beginning of a new session, before ConsumeClaim
+func (h *replayHandler) Setup(session sarama.ConsumerGroupSession) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.pending = 0
+	for _, partition := range session.Claims()[h.dlqTopic] {
+		if !h.finished[partition] {
+			h.pending++
+		}
+	}
+	h.done = make(chan struct{})
+	h.stopped = false
+	if h.pending == 0 {
+		h.stopLocked()
+	}
+
+	return nil
+}
+
+// Cleanup is called at the end of a session, once all ConsumeClaim goroutines have exited
+func (h *replayHandler) Cleanup(sarama.ConsumerGroupSession) error {
+	return nil
+}
+
+// ConsumeClaim replays one partition. A claim returning ends the session, so a replayed
+// partition waits for the others before returning.
+func (h *replayHandler) ConsumeClaim(session sarama.ConsumerGroupSession, claim sarama.ConsumerGroupClaim) error {
+	h.mu.Lock()
+	done := h.done
+	h.mu.Unlock()
+
+	end := h.ends[claim.Partition()]
+	if claim.InitialOffset() >= end {
+		h.finishClaim(claim.Partition())
+	}
+
+	for {
+		select {
+		case <-done:
+			// Commit before the partition is released so replay progress is kept
+			session.Commit()
+			return nil
+		case <-session.Context().Done():
+			session.Commit()
+			return nil
+		case message := <-claim.Messages():
+			if message == nil {
+				return nil
+			}
+
+			if message.Offset >= end {
+				// Arrived after the replay started, left for the next replay
+				h.finishClaim(claim.Partition())
+				continue
+			}
+
+			if !h.reserve() {
+				continue
+			}
+
+			if err := replayMessage(session.Context(), h.producer, h.dlqTopic, message); err != nil {
+				h.fail(err)
+				continue
+			}
+
+			session.MarkMessage(message, "")
+			h.markReplayed()
+
+			if message.Offset+1 >= end {
+				h.finishClaim(claim.Partition())
+			}
+		}
+	}
+}
+
+// reserve takes one message from the limit, ending the session once it is used up
+func (h *replayHandler) reserve() bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.stopped || (h.limit > 0 && h.reserved >= h.limit) {
+		h.stopLocked()
+		return false
+	}
+
+	h.reserved++
+	return true
+}
+
+func (h *replayHandler) markReplayed() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.replayed++
+	if h.limit > 0 && h.replayed >= h.limit {
+		h.stopLocked()
+	}
+}
+
+// finishClaim records partition as replayed, ending the session after its last claim
+func (h *replayHandler) finishClaim(partition int32) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.finished[partition] {
+		return
+	}
+
+	h.finished[partition] = true
+	h.pending--
+	if h.pending <= 0 {
+		h.stopLocked()
+	}
+}
+
+// fail ends the replay with err
+func (h *replayHandler) fail(err error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.err == nil {
+		h.err = err
+	}
+	h.stopLocked()
+}
+
+func (h *replayHandler) stopLocked() {
+	if !h.stopped {
+		h.stopped = true
+		close(h.done)
+	}
+}
+
+// complete reports whether every partition is replayed or the limit is reached
+func (h *replayHandler) complete() bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.limit > 0 && h.replayed >= h.limit {
+		return true
+	}
+	return len(h.finished) >= len(h.ends)
+}
+
+func (h *replayHandler) count() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return h.replayed
+}
+
+func (h *replayHandler) failure() error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return h.err
+}
+
+// replayMessage publishes a dead letter back onto its original topic
+func replayMessage(ctx context.Context, producer *Producer, dlqTopic string, message *sarama.ConsumerMessage) error {
+	headers := make(map[string]string, len(message.Headers))
+	for _, header := range message.Headers {
+		headers[string(header.Key)] = string(header.Value)
+	}
+
+	topic := headers[HeaderOriginalTopic]
+	if topic == "" {
+		topic = strings.TrimSuffix(dlqTopic, DeadLetterSuffix)
+	}
+
+	// Give the message a fresh set of attempts
+	for _, key := range []string{HeaderAttempts, HeaderError, HeaderRetryAfter, HeaderDeadLetteredAt,
+		HeaderOriginalTopic, HeaderOriginalPartition, HeaderOriginalOffset} {
+		delete(headers, key)
+	}
+
+	_, _, err := producer.Send(ctx, &Message{
+		Topic:     topic,
+		Key:       string(message.Key),
+		Value:     message.Value,
+		Headers:   headers,
+		Partition: -1,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to replay %s/%d@%d to %s: %w", dlqTopic, message.Partition, message.Offset, topic, err)
+	}
+
+	return nil
+}
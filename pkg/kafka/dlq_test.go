@@ -0,0 +1,125 @@
+package kafka_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sweetloveinyourheart/sweet-reel/pkg/kafka"
+	"github.com/sweetloveinyourheart/sweet-reel/pkg/testing/fake"
+)
+
+// publishDeadLetter puts a dead letter of the test topic with the given key on the broker
+func publishDeadLetter(t *testing.T, config *kafka.Config, key string) {
+	t.Helper()
+
+	producer, err := kafka.NewProducer(config)
+	if err != nil {
+		t.Fatalf("Failed to create producer: %v", err)
+	}
+	defer producer.Close()
+
+	_, _, err = producer.Send(context.Background(), &kafka.Message{
+		Topic: kafka.DeadLetterTopic(testTopic),
+		Key:   key,
+		Value: "payload-" + key,
+		Headers: map[string]string{
+			kafka.HeaderAttempts:       "3",
+			kafka.HeaderError:          "handler failed",
+			kafka.HeaderOriginalTopic:  testTopic,
+			kafka.HeaderOriginalOffset: "7",
+			kafka.HeaderDeadLetteredAt: "2026-01-01T00:00:00Z",
+			"x-tenant":                 "acme",
+		},
+		Partition: -1,
+	})
+	if err != nil {
+		t.Fatalf("Failed to publish dead letter: %v", err)
+	}
+}
+
+func TestReplayDeadLetters(t *testing.T) {
+	broker := fake.NewKafkaBroker()
+	config := broker.Config()
+	dlqTopic := kafka.DeadLetterTopic(testTopic)
+
+	publishDeadLetter(t, config, "order-1")
+	publishDeadLetter(t, config, "order-2")
+
+	replayed, err := kafka.ReplayDeadLetters(context.Background(), config, dlqTopic, "", 0)
+	if err != nil {
+		t.Fatalf("Replay failed: %v", err)
+	}
+	if replayed != 2 {
+		t.Fatalf("Expected 2 replayed messages, got %d", replayed)
+	}
+
+	messages := broker.Messages(testTopic)
+	if len(messages) != 2 {
+		t.Fatalf("Expected 2 messages back on %s, got %d", testTopic, len(messages))
+	}
+	for i, msg := range messages {
+		if got, want := string(msg.Key), []string{"order-1", "order-2"}[i]; got != want {
+			t.Errorf("Expected message %d to be %s, got %s", i, want, got)
+		}
+		for _, key := range []string{kafka.HeaderAttempts, kafka.HeaderError, kafka.HeaderOriginalTopic,
+			kafka.HeaderOriginalOffset, kafka.HeaderDeadLetteredAt} {
+			if got := headerValue(msg, key); got != "" {
+				t.Errorf("Expected %s to be cleared, got %s", key, got)
+			}
+		}
+		if got := headerValue(msg, "x-tenant"); got != "acme" {
+			t.Errorf("Expected other headers to be kept, got x-tenant %s", got)
+		}
+	}
+
+	if got := broker.Committed(kafka.DefaultReplayGroup, dlqTopic); got != 2 {
+		t.Errorf("Expected replay progress at 2, got %d", got)
+	}
+
+	// Replayed dead letters are not replayed again
+	replayed, err = kafka.ReplayDeadLetters(context.Background(), config, dlqTopic, "", 0)
+	if err != nil {
+		t.Fatalf("Second replay failed: %v", err)
+	}
+	if replayed != 0 {
+		t.Errorf("Expected nothing left to replay, got %d", replayed)
+	}
+}
+
+func TestReplayDeadLettersLimit(t *testing.T) {
+	broker := fake.NewKafkaBroker()
+	config := broker.Config()
+	dlqTopic := kafka.DeadLetterTopic(testTopic)
+
+	for _, key := range []string{"order-1", "order-2", "order-3"} {
+		publishDeadLetter(t, config, key)
+	}
+
+	replayed, err := kafka.ReplayDeadLetters(context.Background(), config, dlqTopic, "replay", 2)
+	if err != nil {
+		t.Fatalf("Replay failed: %v", err)
+	}
+	if replayed != 2 {
+		t.Errorf("Expected 2 replayed messages, got %d", replayed)
+	}
+
+	replayed, err = kafka.ReplayDeadLetters(context.Background(), config, dlqTopic, "replay", 2)
+	if err != nil {
+		t.Fatalf("Second replay failed: %v", err)
+	}
+	if replayed != 1 {
+		t.Errorf("Expected the remaining message to be replayed, got %d", replayed)
+	}
+
+	if got := len(broker.Messages(testTopic)); got != 3 {
+		t.Errorf("Expected 3 messages back on %s, got %d", testTopic, got)
+	}
+}
+
+func TestReplayDeadLettersRejectsOtherTopics(t *testing.T) {
+	broker := fake.NewKafkaBroker()
+
+	if _, err := kafka.ReplayDeadLetters(context.Background(), broker.Config(), testTopic, "", 0); err == nil {
+		t.Error("Expected replaying a topic that is not a dead-letter topic to fail")
+	}
+}
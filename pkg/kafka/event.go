@@ -0,0 +1,101 @@
+package kafka
+
+import (
+	"context"
+
+	"github.com/sweetloveinyourheart/sweet-reel/pkg/db"
+	"github.com/sweetloveinyourheart/sweet-reel/pkg/messages"
+)
+
+// HeaderContentType tells consumers how the message value is encoded
+const HeaderContentType = "content-type"
+
+// encodeEvent wraps event in an envelope from producer and returns the value and headers to publish.
+// The envelope and the headers both carry the trace context of ctx.
+func encodeEvent(ctx context.Context, event messages.Event, producer string, encoding messages.Encoding) ([]byte, map[string]string, error) {
+	envelope, err := messages.NewEnvelope(event)
+	if err != nil {
+		return nil, nil, err
+	}
+	envelope.Producer = producer
+
+	envelope.Trace = envelopeTrace(ctx)
+
+	value, contentType, err := envelope.Marshal(encoding)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	headers := map[string]string{
+		HeaderContentType: contentType,
+		HeaderMessageID:   envelope.EventID,
+	}
+	InjectTrace(ctx, headers)
+	return value, headers, nil
+}
+
+// SendEvent publishes event wrapped in an envelope, encoded as configured for the client
+func (c *Client) SendEvent(ctx context.Context, topic, key string, event messages.Event) (partition int32, offset int64, err error) {
+	producer, err := c.GetProducer()
+	if err != nil {
+		return 0, 0, err
+	}
+
+	producerName, encoding := c.eventConfig()
+	value, headers, err := encodeEvent(ctx, event, producerName, encoding)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return producer.Send(ctx, &Message{
+		Topic:     topic,
+		Key:       key,
+		Value:     value,
+		Headers:   headers,
+		Partition: -1,
+	})
+}
+
+// DecodeEvent unmarshals the event carried by the message into event.
+// Bare JSON messages from producers that predate envelopes are decoded as well.
+func (m *ConsumedMessage) DecodeEvent(event messages.Event) error {
+	return messages.Unmarshal(m.Value, m.Headers[HeaderContentType], event)
+}
+
+// EnqueueEvent writes event to the outbox of tx, to be published by an OutboxRelay.
+// The envelope is stored as JSON; the relay re-encodes it if the client publishes protobuf.
+func EnqueueEvent(ctx context.Context, tx db.DbOrTx, topic string, key string, event messages.Event) error {
+	value, headers, err := encodeEvent(ctx, event, "", messages.EncodingJSON)
+	if err != nil {
+		return err
+	}
+
+	return db.EnqueueOutbox(ctx, tx, topic, key, value, headers)
+}
+
+// prepareOutboxEvent stamps an enveloped outbox message with the producer of the client
+// and encodes it as the client is configured to
+func (c *Client) prepareOutboxEvent(value []byte, headers map[string]string) ([]byte, error) {
+	envelope, err := messages.UnmarshalEnvelope(value, headers[HeaderContentType])
+	if err != nil {
+		return nil, err
+	}
+	producerName, encoding := c.eventConfig()
+	envelope.Producer = producerName
+
+	value, contentType, err := envelope.Marshal(encoding)
+	if err != nil {
+		return nil, err
+	}
+
+	headers[HeaderContentType] = contentType
+	return value, nil
+}
+
+// eventConfig returns the producer name and encoding of the events the client publishes
+func (c *Client) eventConfig() (string, messages.Encoding) {
+	if c.config == nil {
+		return "", messages.EncodingJSON
+	}
+	return c.config.Producer, c.config.EventEncoding
+}
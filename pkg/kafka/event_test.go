@@ -0,0 +1,60 @@
+package kafka
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/sweetloveinyourheart/sweet-reel/pkg/messages"
+)
+
+func TestEncodeEventRoundTrip(t *testing.T) {
+	event := messages.VideoDeleted{VideoID: uuid.Must(uuid.NewV7())}
+
+	for _, encoding := range []messages.Encoding{messages.EncodingJSON, messages.EncodingProtobuf} {
+		value, headers, err := encodeEvent(context.Background(), event, "video_management", encoding)
+		if err != nil {
+			t.Fatalf("Failed to encode event as %s: %v", encoding, err)
+		}
+
+		if headers[HeaderMessageID] == "" {
+			t.Errorf("Expected the event id as message id for %s", encoding)
+		}
+
+		msg := &ConsumedMessage{Value: value, Headers: headers}
+		var decoded messages.VideoDeleted
+		if err := msg.DecodeEvent(&decoded); err != nil {
+			t.Fatalf("Failed to decode event encoded as %s: %v", encoding, err)
+		}
+		if decoded.VideoID != event.VideoID {
+			t.Errorf("Expected video id %s, got %s", event.VideoID, decoded.VideoID)
+		}
+	}
+}
+
+func TestPrepareOutboxEvent(t *testing.T) {
+	event := messages.VideoDeleted{VideoID: uuid.Must(uuid.NewV7())}
+	value, headers, err := encodeEvent(context.Background(), event, "", messages.EncodingJSON)
+	if err != nil {
+		t.Fatalf("Failed to encode event: %v", err)
+	}
+
+	client := &Client{config: &Config{Producer: "video_management", EventEncoding: messages.EncodingProtobuf}}
+	value, err = client.prepareOutboxEvent(value, headers)
+	if err != nil {
+		t.Fatalf("Failed to prepare outbox event: %v", err)
+	}
+
+	if headers[HeaderContentType] != messages.ContentTypeEventProtobuf {
+		t.Errorf("Expected protobuf content type, got %s", headers[HeaderContentType])
+	}
+
+	envelope, err := messages.UnmarshalEnvelope(value, headers[HeaderContentType])
+	if err != nil {
+		t.Fatalf("Failed to unmarshal envelope: %v", err)
+	}
+	if envelope.Producer != "video_management" {
+		t.Errorf("Expected producer video_management, got %s", envelope.Producer)
+	}
+}
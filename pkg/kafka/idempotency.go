@@ -0,0 +1,116 @@
+package kafka
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/sweetloveinyourheart/sweet-reel/pkg/db"
+	"github.com/sweetloveinyourheart/sweet-reel/pkg/logger"
+)
+
+// HeaderMessageID identifies a message across redeliveries, retries and replays
+const HeaderMessageID = "x-message-id"
+
+const (
+	// DefaultProcessedMessageRetention is how long processed message records are kept.
+	// Redeliveries older than this are handled again.
+	DefaultProcessedMessageRetention = 7 * 24 * time.Hour
+
+	// processedMessagePurgeInterval is how often expired processed message records are deleted
+	processedMessagePurgeInterval = time.Hour
+)
+
+// MessageID returns the id the message is deduplicated on: its x-message-id header when
+// the producer set one, otherwise its topic, partition and offset
+func (m *ConsumedMessage) MessageID() string {
+	if id := m.Headers[HeaderMessageID]; id != "" {
+		return id
+	}
+	return fmt.Sprintf("%s/%d/%d", m.Topic, m.Partition, m.Offset)
+}
+
+// ProcessedMessageStore records which messages a consumer group has handled
+type ProcessedMessageStore interface {
+	// IsProcessed reports whether group has already handled the message with the given id
+	IsProcessed(ctx context.Context, group string, messageID string) (bool, error)
+	// MarkProcessed records that group has handled the message with the given id.
+	// It returns false when the message was already recorded.
+	MarkProcessed(ctx context.Context, group string, messageID string) (bool, error)
+}
+
+// PostgresProcessedMessageStore keeps processed messages in the processed_messages table
+type PostgresProcessedMessageStore struct {
+	tx db.DbOrTx
+}
+
+// NewPostgresProcessedMessageStore creates a store on the processed_messages table of tx
+func NewPostgresProcessedMessageStore(tx db.DbOrTx) *PostgresProcessedMessageStore {
+	return &PostgresProcessedMessageStore{tx: tx}
+}
+
+func (s *PostgresProcessedMessageStore) IsProcessed(ctx context.Context, group string, messageID string) (bool, error) {
+	return db.IsMessageProcessed(ctx, s.tx, group, messageID)
+}
+
+func (s *PostgresProcessedMessageStore) MarkProcessed(ctx context.Context, group string, messageID string) (bool, error) {
+	return db.MarkMessageProcessed(ctx, s.tx, group, messageID)
+}
+
+// StartPurge deletes the records older than retention every hour until ctx is done
+func (s *PostgresProcessedMessageStore) StartPurge(ctx context.Context, retention time.Duration) {
+	go func() {
+		ticker := time.NewTicker(processedMessagePurgeInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				purged, err := db.PurgeProcessedMessages(ctx, s.tx, time.Now().Add(-retention))
+				if err != nil {
+					logger.Global().ErrorContext(ctx, "failed to purge processed messages", zap.Error(err))
+				} else if purged > 0 {
+					logger.Global().InfoContext(ctx, "processed messages purged", zap.Int64("count", purged))
+				}
+			}
+		}
+	}()
+}
+
+// IdempotentHandler wraps handler so that a message group has already handled is skipped.
+// A message is only recorded once handler succeeds, so a failed message is still retried.
+// A crash in between redelivers the message, so handler writes must tolerate a repeat.
+func IdempotentHandler(store ProcessedMessageStore, group string, handler MessageHandler) MessageHandler {
+	return func(ctx context.Context, msg *ConsumedMessage) error {
+		messageID := msg.MessageID()
+
+		processed, err := store.IsProcessed(ctx, group, messageID)
+		if err != nil {
+			return fmt.Errorf("failed to check message %s: %w", messageID, err)
+		}
+
+		if processed {
+			logger.Global().InfoContext(ctx, "skipping already processed message",
+				zap.String("topic", msg.Topic),
+				zap.String("message_id", messageID))
+			return nil
+		}
+
+		if err := handler(ctx, msg); err != nil {
+			return err
+		}
+
+		// The work is done, failing here would only run it again
+		if _, err := store.MarkProcessed(ctx, group, messageID); err != nil {
+			logger.Global().ErrorContext(ctx, "failed to mark message processed",
+				zap.String("message_id", messageID),
+				zap.Error(err))
+		}
+
+		return nil
+	}
+}
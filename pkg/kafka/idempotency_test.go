@@ -0,0 +1,94 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+// memoryProcessedMessageStore keeps processed messages in a map
+type memoryProcessedMessageStore map[string]bool
+
+func (s memoryProcessedMessageStore) IsProcessed(ctx context.Context, group string, messageID string) (bool, error) {
+	return s[group+"/"+messageID], nil
+}
+
+func (s memoryProcessedMessageStore) MarkProcessed(ctx context.Context, group string, messageID string) (bool, error) {
+	key := group + "/" + messageID
+	if s[key] {
+		return false, nil
+	}
+	s[key] = true
+	return true, nil
+}
+
+func TestConsumedMessageID(t *testing.T) {
+	msg := &ConsumedMessage{Topic: "video-uploaded", Partition: 2, Offset: 42}
+
+	if id := msg.MessageID(); id != "video-uploaded/2/42" {
+		t.Errorf("Expected the position as message id, got %s", id)
+	}
+
+	msg.Headers = map[string]string{HeaderMessageID: "0192f1d4-7c1e-7000-8000-000000000001"}
+	if id := msg.MessageID(); id != "0192f1d4-7c1e-7000-8000-000000000001" {
+		t.Errorf("Expected the header as message id, got %s", id)
+	}
+}
+
+func TestIdempotentHandler(t *testing.T) {
+	store := memoryProcessedMessageStore{}
+	calls := 0
+	handler := IdempotentHandler(store, "group", func(ctx context.Context, msg *ConsumedMessage) error {
+		calls++
+		return nil
+	})
+
+	msg := &ConsumedMessage{Topic: "video-uploaded", Headers: map[string]string{HeaderMessageID: "message-1"}}
+	for i := 0; i < 3; i++ {
+		if err := handler(context.Background(), msg); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+
+	if calls != 1 {
+		t.Errorf("Expected a redelivered message to be handled once, got %d calls", calls)
+	}
+
+	// Another consumer group handles the same message on its own
+	other := IdempotentHandler(store, "other-group", func(ctx context.Context, msg *ConsumedMessage) error {
+		calls++
+		return nil
+	})
+	if err := other(context.Background(), msg); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if calls != 2 {
+		t.Errorf("Expected the other group to handle the message, got %d calls", calls)
+	}
+}
+
+func TestIdempotentHandlerRetriesFailures(t *testing.T) {
+	store := memoryProcessedMessageStore{}
+	calls := 0
+	handler := IdempotentHandler(store, "group", func(ctx context.Context, msg *ConsumedMessage) error {
+		calls++
+		if calls == 1 {
+			return errors.New("transcode failed")
+		}
+		return nil
+	})
+
+	msg := &ConsumedMessage{Topic: "video-uploaded", Headers: map[string]string{HeaderMessageID: "message-1"}}
+	if err := handler(context.Background(), msg); err == nil {
+		t.Fatal("Expected the handler error to be returned")
+	}
+
+	if err := handler(context.Background(), msg); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if calls != 2 {
+		t.Errorf("Expected a failed message to be handled again, got %d calls", calls)
+	}
+}
@@ -0,0 +1,247 @@
+package kafka
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.uber.org/zap"
+
+	"github.com/sweetloveinyourheart/sweet-reel/pkg/db"
+	"github.com/sweetloveinyourheart/sweet-reel/pkg/logger"
+	"github.com/sweetloveinyourheart/sweet-reel/pkg/messages"
+)
+
+// outboxPurgeInterval is how often published messages past their retention are deleted
+const outboxPurgeInterval = time.Hour
+
+// outboxMaxBackoff caps the wait between polls while relaying keeps failing
+const outboxMaxBackoff = time.Minute
+
+// OutboxFailure is the message that failed to publish, ending its batch
+type OutboxFailure struct {
+	ID  int64
+	Err error
+	// Park leaves the message out of the following batches
+	Park bool
+}
+
+// OutboxStore is the outbox an OutboxRelay publishes
+type OutboxStore interface {
+	// RelayOutbox runs publish on up to limit unsent messages, in the order they were written,
+	// while no other relay can claim them. The messages publish returns as sent are marked
+	// sent and its failure is recorded on the failed message. publish is not called when
+	// another relay holds the outbox.
+	RelayOutbox(ctx context.Context, limit int, publish func(batch []*db.OutboxMessage) (sent []int64, failure *OutboxFailure)) error
+	// PurgeOutbox deletes the messages that were published before sentBefore and returns how many were deleted
+	PurgeOutbox(ctx context.Context, sentBefore time.Time) (int64, error)
+}
+
+// PostgresOutboxStore relays the outbox table of a database
+type PostgresOutboxStore struct {
+	pool db.ConnPool
+}
+
+// NewPostgresOutboxStore creates a store on the outbox table of pool
+func NewPostgresOutboxStore(pool db.ConnPool) *PostgresOutboxStore {
+	return &PostgresOutboxStore{pool: pool}
+}
+
+func (s *PostgresOutboxStore) RelayOutbox(ctx context.Context, limit int, publish func(batch []*db.OutboxMessage) (sent []int64, failure *OutboxFailure)) error {
+	return s.pool.AcquireFunc(ctx, func(conn *pgxpool.Conn) error {
+		return db.TransactionC(ctx, conn.Conn(), "RelayOutbox", func(tx pgx.Tx) error {
+			batch, err := db.ClaimOutbox(ctx, tx, limit)
+			if err != nil || len(batch) == 0 {
+				return err
+			}
+
+			sent, failure := publish(batch)
+
+			// What was published is marked even when a later message failed
+			if err := db.MarkOutboxSent(ctx, tx, sent); err != nil {
+				return err
+			}
+
+			if failure != nil {
+				return db.RecordOutboxFailure(ctx, tx, failure.ID, failure.Err.Error(), failure.Park)
+			}
+
+			return nil
+		})
+	})
+}
+
+func (s *PostgresOutboxStore) PurgeOutbox(ctx context.Context, sentBefore time.Time) (int64, error) {
+	return db.PurgeOutbox(ctx, s.pool, sentBefore)
+}
+
+// OutboxRelayConfig controls how the outbox relay polls and cleans up the outbox table
+type OutboxRelayConfig struct {
+	// PollInterval is the wait between two polls when the outbox was found empty
+	PollInterval time.Duration
+	// BatchSize is the maximum number of messages published per poll
+	BatchSize int
+	// Retention is how long published messages are kept before they are deleted
+	Retention time.Duration
+	// MaxAttempts is how many times a message fails to publish before it is parked,
+	// zero never parks a message
+	MaxAttempts int
+}
+
+// DefaultOutboxRelayConfig returns the default outbox relay configuration
+func DefaultOutboxRelayConfig() *OutboxRelayConfig {
+	return &OutboxRelayConfig{
+		PollInterval: 500 * time.Millisecond,
+		BatchSize:    100,
+		Retention:    24 * time.Hour,
+		MaxAttempts:  10,
+	}
+}
+
+// OutboxRelay publishes the messages written to a database outbox with db.EnqueueOutbox.
+// Messages are published in the order they were written and are only marked sent once
+// Kafka has acknowledged them, so a crash at any point re-publishes at most the last batch.
+// A message that fails MaxAttempts times is parked so it no longer holds back the others.
+type OutboxRelay struct {
+	store  OutboxStore
+	client *Client
+	config *OutboxRelayConfig
+}
+
+// NewOutboxRelay creates a relay publishing the outbox of store through client
+func NewOutboxRelay(store OutboxStore, client *Client, config *OutboxRelayConfig) *OutboxRelay {
+	if config == nil {
+		config = DefaultOutboxRelayConfig()
+	}
+
+	return &OutboxRelay{
+		store:  store,
+		client: client,
+		config: config,
+	}
+}
+
+// Start runs the relay in the background until ctx is done
+func (r *OutboxRelay) Start(ctx context.Context) {
+	go func() {
+		lastPurge := time.Now()
+		backoff := r.config.PollInterval
+		for {
+			relayed, err := r.RelayOnce(ctx)
+			if err != nil && ctx.Err() == nil {
+				logger.Global().ErrorContext(ctx, "failed to relay outbox messages", zap.Error(err))
+			}
+
+			if r.config.Retention > 0 && time.Since(lastPurge) >= outboxPurgeInterval {
+				if purged, err := r.store.PurgeOutbox(ctx, time.Now().Add(-r.config.Retention)); err != nil {
+					logger.Global().ErrorContext(ctx, "failed to purge outbox", zap.Error(err))
+				} else if purged > 0 {
+					logger.Global().InfoContext(ctx, "outbox purged", zap.Int64("count", purged))
+				}
+				lastPurge = time.Now()
+			}
+
+			wait := r.config.PollInterval
+			if err != nil {
+				// Back off while failing, so that an unreachable broker does not use up the
+				// attempts of the message at the head of the outbox within seconds
+				wait = backoff
+				backoff = min(2*backoff, outboxMaxBackoff)
+			} else {
+				backoff = r.config.PollInterval
+
+				// Keep going straight away while there is a backlog
+				if relayed >= r.config.BatchSize {
+					continue
+				}
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(wait):
+			}
+		}
+	}()
+}
+
+// RelayOnce publishes the next batch of unsent messages and returns how many were published.
+// Publishing stops at the first failure so that later messages never overtake it, unless
+// the failed message is parked.
+func (r *OutboxRelay) RelayOnce(ctx context.Context) (int, error) {
+	producer, err := r.client.GetProducer()
+	if err != nil {
+		return 0, err
+	}
+
+	relayed := 0
+	var sendErr error
+	err = r.store.RelayOutbox(ctx, r.config.BatchSize, func(batch []*db.OutboxMessage) ([]int64, *OutboxFailure) {
+		sent := make([]int64, 0, len(batch))
+		for _, message := range batch {
+			if err := r.publish(ctx, producer, message); err != nil {
+				sendErr = err
+				failure := &OutboxFailure{
+					ID:   message.ID,
+					Err:  err,
+					Park: r.config.MaxAttempts > 0 && message.Attempts+1 >= r.config.MaxAttempts,
+				}
+				if failure.Park {
+					logger.Global().ErrorContext(ctx, "parking outbox message that keeps failing to publish",
+						zap.Int64("id", message.ID),
+						zap.String("topic", message.Topic),
+						zap.String("key", message.Key),
+						zap.Int("attempts", message.Attempts+1),
+						zap.Error(err))
+				}
+
+				relayed = len(sent)
+				return sent, failure
+			}
+			sent = append(sent, message.ID)
+		}
+
+		relayed = len(sent)
+		return sent, nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return relayed, sendErr
+}
+
+// publish sends one outbox message
+func (r *OutboxRelay) publish(ctx context.Context, producer *Producer, message *db.OutboxMessage) error {
+	headers := make(map[string]string, len(message.Headers)+1)
+	for k, v := range message.Headers {
+		headers[k] = v
+	}
+	if headers[HeaderMessageID] == "" && message.MessageID != "" {
+		headers[HeaderMessageID] = message.MessageID
+	}
+
+	value := message.Payload
+	if headers[HeaderContentType] == messages.ContentTypeEventJSON {
+		var err error
+		if value, err = r.client.prepareOutboxEvent(value, headers); err != nil {
+			return fmt.Errorf("failed to encode outbox message %d: %w", message.ID, err)
+		}
+	}
+
+	_, _, err := producer.Send(ctx, &Message{
+		Topic:     message.Topic,
+		Key:       message.Key,
+		Value:     value,
+		Headers:   headers,
+		Partition: -1,
+		Timestamp: message.CreatedAt,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to publish outbox message %d: %w", message.ID, err)
+	}
+
+	return nil
+}
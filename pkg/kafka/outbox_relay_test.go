@@ -0,0 +1,165 @@
+package kafka_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sweetloveinyourheart/sweet-reel/pkg/kafka"
+	"github.com/sweetloveinyourheart/sweet-reel/pkg/messages"
+	"github.com/sweetloveinyourheart/sweet-reel/pkg/testing/fake"
+)
+
+// poisonHeaders mark a payload as an event envelope it is not, so it never encodes
+var poisonHeaders = map[string]string{kafka.HeaderContentType: messages.ContentTypeEventJSON}
+
+// newOutboxRelay creates a relay publishing store to broker
+func newOutboxRelay(t *testing.T, broker *fake.KafkaBroker, store *fake.OutboxStore, config *kafka.OutboxRelayConfig) *kafka.OutboxRelay {
+	t.Helper()
+
+	client, err := kafka.NewClient(broker.Config())
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	t.Cleanup(func() { _ = client.Close() })
+
+	return kafka.NewOutboxRelay(store, client, config)
+}
+
+// publishedKeys returns the keys of the messages on the test topic
+func publishedKeys(broker *fake.KafkaBroker) []string {
+	var keys []string
+	for _, msg := range broker.Messages(testTopic) {
+		keys = append(keys, string(msg.Key))
+	}
+	return keys
+}
+
+func TestOutboxRelayPublishesInOrder(t *testing.T) {
+	broker := fake.NewKafkaBroker()
+	store := fake.NewOutboxStore()
+	relay := newOutboxRelay(t, broker, store, nil)
+
+	for _, key := range []string{"order-1", "order-2", "order-3"} {
+		store.Enqueue(testTopic, key, []byte("payload-"+key), nil)
+	}
+
+	relayed, err := relay.RelayOnce(context.Background())
+	if err != nil {
+		t.Fatalf("Relay failed: %v", err)
+	}
+	if relayed != 3 {
+		t.Errorf("Expected 3 relayed messages, got %d", relayed)
+	}
+
+	keys := publishedKeys(broker)
+	if len(keys) != 3 || keys[0] != "order-1" || keys[1] != "order-2" || keys[2] != "order-3" {
+		t.Errorf("Expected the messages in the order they were written, got %v", keys)
+	}
+
+	// Sent messages are not published again
+	relayed, err = relay.RelayOnce(context.Background())
+	if err != nil {
+		t.Fatalf("Second relay failed: %v", err)
+	}
+	if relayed != 0 {
+		t.Errorf("Expected nothing left to relay, got %d", relayed)
+	}
+}
+
+func TestOutboxRelayStopsAtFailure(t *testing.T) {
+	broker := fake.NewKafkaBroker()
+	store := fake.NewOutboxStore()
+	relay := newOutboxRelay(t, broker, store, nil)
+
+	first := store.Enqueue(testTopic, "order-1", []byte("payload-order-1"), nil)
+	poison := store.Enqueue(testTopic, "order-2", []byte("not an envelope"), poisonHeaders)
+	last := store.Enqueue(testTopic, "order-3", []byte("payload-order-3"), nil)
+
+	relayed, err := relay.RelayOnce(context.Background())
+	if err == nil {
+		t.Fatal("Expected the failed message to be reported")
+	}
+	if relayed != 1 {
+		t.Errorf("Expected 1 relayed message, got %d", relayed)
+	}
+
+	// What was published before the failure is marked sent, nothing overtakes the failure
+	if store.Record(first).SentAt.IsZero() {
+		t.Error("Expected the message published before the failure to be marked sent")
+	}
+	if record := store.Record(poison); record.Message.Attempts != 1 || record.LastError == "" || record.Parked {
+		t.Errorf("Expected the failure to be counted against its message, got %+v", record)
+	}
+	if !store.Record(last).SentAt.IsZero() {
+		t.Error("Expected the message after the failure to wait")
+	}
+	if keys := publishedKeys(broker); len(keys) != 1 {
+		t.Errorf("Expected only the first message to be published, got %v", keys)
+	}
+}
+
+func TestOutboxRelayParksPoisonMessage(t *testing.T) {
+	broker := fake.NewKafkaBroker()
+	store := fake.NewOutboxStore()
+	config := kafka.DefaultOutboxRelayConfig()
+	config.MaxAttempts = 2
+	relay := newOutboxRelay(t, broker, store, config)
+
+	poison := store.Enqueue(testTopic, "order-1", []byte("not an envelope"), poisonHeaders)
+	next := store.Enqueue(testTopic, "order-2", []byte("payload-order-2"), nil)
+
+	for i := 0; i < config.MaxAttempts; i++ {
+		if _, err := relay.RelayOnce(context.Background()); err == nil {
+			t.Fatalf("Expected attempt %d to fail", i+1)
+		}
+	}
+
+	if record := store.Record(poison); !record.Parked || record.Message.Attempts != config.MaxAttempts {
+		t.Errorf("Expected the message to be parked after %d attempts, got %+v", config.MaxAttempts, record)
+	}
+
+	// The parked message no longer holds back the outbox
+	relayed, err := relay.RelayOnce(context.Background())
+	if err != nil {
+		t.Fatalf("Relay failed: %v", err)
+	}
+	if relayed != 1 {
+		t.Errorf("Expected the next message to be relayed, got %d", relayed)
+	}
+	if store.Record(next).SentAt.IsZero() {
+		t.Error("Expected the next message to be marked sent")
+	}
+	if keys := publishedKeys(broker); len(keys) != 1 || keys[0] != "order-2" {
+		t.Errorf("Expected only the next message to be published, got %v", keys)
+	}
+}
+
+func TestOutboxRelaySkipsOutboxHeldByAnotherRelay(t *testing.T) {
+	broker := fake.NewKafkaBroker()
+	store := fake.NewOutboxStore()
+	relay := newOutboxRelay(t, broker, store, nil)
+
+	id := store.Enqueue(testTopic, "order-1", []byte("payload-order-1"), nil)
+
+	release := store.Hold()
+	relayed, err := relay.RelayOnce(context.Background())
+	release()
+
+	if err != nil {
+		t.Fatalf("Expected a held outbox not to be an error, got %v", err)
+	}
+	if relayed != 0 || len(broker.Messages(testTopic)) != 0 {
+		t.Errorf("Expected nothing to be relayed while another relay holds the outbox, got %d", relayed)
+	}
+	if !store.Record(id).SentAt.IsZero() {
+		t.Error("Expected the message to stay unsent")
+	}
+
+	relayed, err = relay.RelayOnce(context.Background())
+	if err != nil {
+		t.Fatalf("Relay failed: %v", err)
+	}
+	if relayed != 1 {
+		t.Errorf("Expected the message to be relayed once the outbox is free, got %d", relayed)
+	}
+}
@@ -0,0 +1,34 @@
+package kafka
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDefaultOutboxRelayConfig(t *testing.T) {
+	config := DefaultOutboxRelayConfig()
+
+	if config.PollInterval != 500*time.Millisecond {
+		t.Errorf("Expected 500ms poll interval, got %s", config.PollInterval)
+	}
+
+	if config.BatchSize != 100 {
+		t.Errorf("Expected batch size 100, got %d", config.BatchSize)
+	}
+
+	if config.Retention != 24*time.Hour {
+		t.Errorf("Expected 24h retention, got %s", config.Retention)
+	}
+}
+
+func TestNewOutboxRelayDefaults(t *testing.T) {
+	relay := NewOutboxRelay(nil, &Client{}, nil)
+
+	if relay.config == nil {
+		t.Fatal("Expected the default config when none is given")
+	}
+
+	if relay.config.BatchSize != DefaultOutboxRelayConfig().BatchSize {
+		t.Errorf("Expected default batch size, got %d", relay.config.BatchSize)
+	}
+}
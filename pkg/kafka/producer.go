@@ -4,10 +4,12 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"maps"
 	"sync"
 	"time"
 
 	"github.com/IBM/sarama"
+	"github.com/gofrs/uuid"
 	"go.uber.org/zap"
 
 	"github.com/sweetloveinyourheart/sweet-reel/pkg/logger"
@@ -37,8 +39,7 @@ func NewProducer(config *Config) (*Producer, error) {
 		config = DefaultConfig()
 	}
 
-	saramaConfig := config.ToSaramaConfig()
-	producer, err := sarama.NewSyncProducer(config.Brokers, saramaConfig)
+	producer, err := config.transport().NewSyncProducer(config)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create Kafka producer: %w", err)
 	}
@@ -58,6 +59,9 @@ func (p *Producer) Send(ctx context.Context, msg *Message) (partition int32, off
 	}
 	p.mu.RUnlock()
 
+	ctx, span := startPublishSpan(ctx, msg)
+	defer func() { endSpan(span, err) }()
+
 	// Convert value to bytes
 	var valueBytes []byte
 	switch v := msg.Value.(type) {
@@ -89,17 +93,23 @@ func (p *Producer) Send(ctx context.Context, msg *Message) (partition int32, off
 		saramaMsg.Partition = msg.Partition
 	}
 
-	// Set headers
-	if len(msg.Headers) > 0 {
-		headers := make([]sarama.RecordHeader, 0, len(msg.Headers))
-		for k, v := range msg.Headers {
-			headers = append(headers, sarama.RecordHeader{
-				Key:   []byte(k),
-				Value: []byte(v),
-			})
-		}
-		saramaMsg.Headers = headers
+	// Set headers, every message gets an id consumers can deduplicate on
+	// and the trace context consumers continue the trace in
+	msgHeaders := make(map[string]string, len(msg.Headers)+3)
+	maps.Copy(msgHeaders, msg.Headers)
+	if msgHeaders[HeaderMessageID] == "" {
+		msgHeaders[HeaderMessageID] = uuid.Must(uuid.NewV7()).String()
+	}
+	InjectTrace(ctx, msgHeaders)
+
+	headers := make([]sarama.RecordHeader, 0, len(msgHeaders))
+	for k, v := range msgHeaders {
+		headers = append(headers, sarama.RecordHeader{
+			Key:   []byte(k),
+			Value: []byte(v),
+		})
 	}
+	saramaMsg.Headers = headers
 
 	// Send message
 	partition, offset, err = p.producer.SendMessage(saramaMsg)
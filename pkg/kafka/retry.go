@@ -0,0 +1,177 @@
+package kafka
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// Headers attached to messages that are retried or dead-lettered
+const (
+	HeaderAttempts          = "x-attempts"
+	HeaderError             = "x-error"
+	HeaderOriginalTopic     = "x-original-topic"
+	HeaderOriginalPartition = "x-original-partition"
+	HeaderOriginalOffset    = "x-original-offset"
+	HeaderRetryAfter        = "x-retry-after"
+	HeaderDeadLetteredAt    = "x-dead-lettered-at"
+)
+
+// DeadLetterSuffix is appended to a topic name to get its dead-letter topic
+const DeadLetterSuffix = ".dlq"
+
+// RetryPolicy controls what the consumer does when a message handler returns an error
+type RetryPolicy struct {
+	// MaxAttempts is the total number of times a message is handled before it is dead-lettered
+	MaxAttempts int
+	// InitialBackoff is the wait before the first retry, doubled on every following attempt
+	InitialBackoff time.Duration
+	// MaxBackoff caps the exponential backoff
+	MaxBackoff time.Duration
+	// RetryTopic, when set, receives failed messages so they are retried without blocking
+	// the partition they came from. When empty, retries happen in place and rejected
+	// messages are put back on the topic they came from.
+	RetryTopic string
+}
+
+// DefaultRetryPolicy returns the default consumer retry policy
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:    3,
+		InitialBackoff: time.Second,
+		MaxBackoff:     30 * time.Second,
+	}
+}
+
+// Attempts returns the maximum number of attempts, never less than one
+func (p RetryPolicy) Attempts() int {
+	if p.MaxAttempts < 1 {
+		return 1
+	}
+	return p.MaxAttempts
+}
+
+// Backoff returns how long to wait after the given (1-based) failed attempt
+func (p RetryPolicy) Backoff(attempt int) time.Duration {
+	if attempt < 1 || p.InitialBackoff <= 0 {
+		return 0
+	}
+
+	backoff := p.InitialBackoff
+	for i := 1; i < attempt; i++ {
+		backoff *= 2
+		if p.MaxBackoff > 0 && backoff >= p.MaxBackoff {
+			return p.MaxBackoff
+		}
+	}
+
+	if p.MaxBackoff > 0 && backoff > p.MaxBackoff {
+		return p.MaxBackoff
+	}
+
+	return backoff
+}
+
+// DeadLetterTopic returns the dead-letter topic for the given topic
+func DeadLetterTopic(topic string) string {
+	return topic + DeadLetterSuffix
+}
+
+// Attempts returns how many times the message has already been handled and failed
+func (m *ConsumedMessage) Attempts() int {
+	attempts, err := strconv.Atoi(m.Headers[HeaderAttempts])
+	if err != nil || attempts < 0 {
+		return 0
+	}
+	return attempts
+}
+
+// Reject hands a message whose processing failed outside of the consumer handler back to
+// the retry policy. While attempts remain the message goes to the retry topic, or back onto
+// its original topic when no retry topic is configured, otherwise it is dead-lettered.
+func (c *Client) Reject(ctx context.Context, msg *ConsumedMessage, cause error) error {
+	producer, err := c.GetProducer()
+	if err != nil {
+		return err
+	}
+
+	policy := DefaultRetryPolicy()
+	if c.config != nil {
+		policy = c.config.ConsumerRetry
+	}
+
+	attempts := msg.Attempts() + 1
+	if attempts < policy.Attempts() {
+		return publishRetry(ctx, producer, policy, msg, attempts, cause)
+	}
+
+	return publishDeadLetter(ctx, producer, msg, attempts, cause)
+}
+
+// publishRetry puts msg on the retry topic, or back onto its original topic when the policy
+// has none, to be handled again once its backoff has elapsed
+func publishRetry(ctx context.Context, producer *Producer, policy RetryPolicy, msg *ConsumedMessage, attempts int, cause error) error {
+	headers := failureHeaders(msg, attempts, cause)
+	headers[HeaderRetryAfter] = strconv.FormatInt(time.Now().Add(policy.Backoff(attempts)).UnixMilli(), 10)
+
+	topic := policy.RetryTopic
+	if topic == "" {
+		topic = headers[HeaderOriginalTopic]
+	}
+
+	_, _, err := producer.Send(ctx, &Message{
+		Topic:     topic,
+		Key:       msg.Key,
+		Value:     msg.Value,
+		Headers:   headers,
+		Partition: -1,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to publish message for retry to %s: %w", topic, err)
+	}
+
+	return nil
+}
+
+// publishDeadLetter puts msg on the dead-letter topic of its original topic
+func publishDeadLetter(ctx context.Context, producer *Producer, msg *ConsumedMessage, attempts int, cause error) error {
+	headers := failureHeaders(msg, attempts, cause)
+	headers[HeaderDeadLetteredAt] = time.Now().UTC().Format(time.RFC3339Nano)
+
+	topic := DeadLetterTopic(headers[HeaderOriginalTopic])
+	_, _, err := producer.Send(ctx, &Message{
+		Topic:     topic,
+		Key:       msg.Key,
+		Value:     msg.Value,
+		Headers:   headers,
+		Partition: -1,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to publish message to dead-letter topic %s: %w", topic, err)
+	}
+
+	return nil
+}
+
+// failureHeaders copies the headers of msg and records the failure on them
+func failureHeaders(msg *ConsumedMessage, attempts int, cause error) map[string]string {
+	headers := make(map[string]string, len(msg.Headers)+5)
+	for k, v := range msg.Headers {
+		headers[k] = v
+	}
+
+	// Keep the first origin when a message has already been through the retry topic
+	if _, ok := headers[HeaderOriginalTopic]; !ok {
+		headers[HeaderOriginalTopic] = msg.Topic
+		headers[HeaderOriginalPartition] = strconv.FormatInt(int64(msg.Partition), 10)
+		headers[HeaderOriginalOffset] = strconv.FormatInt(msg.Offset, 10)
+	}
+
+	headers[HeaderAttempts] = strconv.Itoa(attempts)
+	if cause != nil {
+		headers[HeaderError] = cause.Error()
+	}
+
+	return headers
+}
@@ -0,0 +1,116 @@
+package kafka
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestDefaultRetryPolicy(t *testing.T) {
+	config := DefaultConfig()
+
+	if config.ConsumerRetry.MaxAttempts != 3 {
+		t.Errorf("Expected 3 max attempts, got %d", config.ConsumerRetry.MaxAttempts)
+	}
+
+	if config.ConsumerRetry.RetryTopic != "" {
+		t.Errorf("Expected no retry topic by default, got %s", config.ConsumerRetry.RetryTopic)
+	}
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	policy := RetryPolicy{
+		MaxAttempts:    5,
+		InitialBackoff: 100 * time.Millisecond,
+		MaxBackoff:     time.Second,
+	}
+
+	expected := map[int]time.Duration{
+		0: 0,
+		1: 100 * time.Millisecond,
+		2: 200 * time.Millisecond,
+		3: 400 * time.Millisecond,
+		4: 800 * time.Millisecond,
+		5: time.Second,
+		9: time.Second,
+	}
+
+	for attempt, want := range expected {
+		if got := policy.Backoff(attempt); got != want {
+			t.Errorf("Backoff(%d): expected %s, got %s", attempt, want, got)
+		}
+	}
+}
+
+func TestRetryPolicyAttempts(t *testing.T) {
+	if got := (RetryPolicy{}).Attempts(); got != 1 {
+		t.Errorf("Expected at least one attempt, got %d", got)
+	}
+
+	if got := (RetryPolicy{MaxAttempts: 4}).Attempts(); got != 4 {
+		t.Errorf("Expected 4 attempts, got %d", got)
+	}
+}
+
+func TestDeadLetterTopic(t *testing.T) {
+	if got := DeadLetterTopic(KafkaVideoUploadedTopic); got != "video-uploaded.dlq" {
+		t.Errorf("Expected video-uploaded.dlq, got %s", got)
+	}
+}
+
+func TestConsumedMessageAttempts(t *testing.T) {
+	msg := &ConsumedMessage{Headers: map[string]string{}}
+	if msg.Attempts() != 0 {
+		t.Errorf("Expected 0 attempts without header, got %d", msg.Attempts())
+	}
+
+	msg.Headers[HeaderAttempts] = "2"
+	if msg.Attempts() != 2 {
+		t.Errorf("Expected 2 attempts, got %d", msg.Attempts())
+	}
+
+	msg.Headers[HeaderAttempts] = "invalid"
+	if msg.Attempts() != 0 {
+		t.Errorf("Expected 0 attempts for invalid header, got %d", msg.Attempts())
+	}
+}
+
+func TestFailureHeaders(t *testing.T) {
+	msg := &ConsumedMessage{
+		Topic:     KafkaVideoUploadedTopic,
+		Partition: 1,
+		Offset:    42,
+		Headers:   map[string]string{"content-type": "application/json"},
+	}
+
+	headers := failureHeaders(msg, 3, errors.New("boom"))
+
+	if headers[HeaderAttempts] != "3" {
+		t.Errorf("Expected attempts header 3, got %s", headers[HeaderAttempts])
+	}
+	if headers[HeaderError] != "boom" {
+		t.Errorf("Expected error header boom, got %s", headers[HeaderError])
+	}
+	if headers[HeaderOriginalTopic] != KafkaVideoUploadedTopic {
+		t.Errorf("Expected original topic %s, got %s", KafkaVideoUploadedTopic, headers[HeaderOriginalTopic])
+	}
+	if headers[HeaderOriginalPartition] != "1" || headers[HeaderOriginalOffset] != "42" {
+		t.Errorf("Expected original position 1@42, got %s@%s", headers[HeaderOriginalPartition], headers[HeaderOriginalOffset])
+	}
+	if headers["content-type"] != "application/json" {
+		t.Error("Expected existing headers to be kept")
+	}
+	if _, ok := msg.Headers[HeaderAttempts]; ok {
+		t.Error("Expected the consumed message headers to be left untouched")
+	}
+
+	// A message coming from the retry topic keeps its first origin
+	retried := &ConsumedMessage{
+		Topic:   "video-processing.retry",
+		Headers: headers,
+	}
+	headers = failureHeaders(retried, 4, errors.New("boom again"))
+	if headers[HeaderOriginalTopic] != KafkaVideoUploadedTopic {
+		t.Errorf("Expected original topic to be kept, got %s", headers[HeaderOriginalTopic])
+	}
+}
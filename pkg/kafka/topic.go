@@ -1,11 +1,17 @@
 package kafka
 
 const (
-	KafkaVideoProcessingGroup = "video-processing"
+	KafkaVideoProcessingGroup  = "video-processing"
+	KafkaUserChannelStatsGroup = "user-channel-stats"
 )
 
 const (
 	KafkaVideoUploadedTopic  = "video-uploaded"
 	KafkaVideoProgressTopic  = "video-progress-update"
 	KafkaVideoProcessedTopic = "video-processed"
+	KafkaVideoDeletedTopic   = "video-deleted"
+	KafkaVideoPublishedTopic = "video-published"
+
+	KafkaChannelViewsChangedTopic  = "channel-views-changed"
+	KafkaChannelVideosChangedTopic = "channel-videos-changed"
 )
@@ -0,0 +1,85 @@
+package kafka
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.23.1"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/sweetloveinyourheart/sweet-reel/pkg/messages"
+)
+
+var tracer = otel.Tracer("com.sweetloveinyourheart.srl.kafka")
+
+// ContextWithTrace returns ctx carrying the W3C trace context found in headers.
+// ctx is returned unchanged when it already belongs to a trace or headers carry none.
+func ContextWithTrace(ctx context.Context, headers map[string]string) context.Context {
+	if trace.SpanContextFromContext(ctx).IsValid() {
+		return ctx
+	}
+	return otel.GetTextMapPropagator().Extract(ctx, propagation.MapCarrier(headers))
+}
+
+// InjectTrace writes the W3C trace context of ctx into headers
+func InjectTrace(ctx context.Context, headers map[string]string) {
+	otel.GetTextMapPropagator().Inject(ctx, propagation.MapCarrier(headers))
+}
+
+// envelopeTrace returns the trace context of ctx as recorded in event envelopes,
+// or nil when ctx is not part of a trace
+func envelopeTrace(ctx context.Context) *messages.TraceContext {
+	carrier := propagation.MapCarrier{}
+	InjectTrace(ctx, carrier)
+	if carrier["traceparent"] == "" {
+		return nil
+	}
+
+	return &messages.TraceContext{
+		TraceParent: carrier["traceparent"],
+		TraceState:  carrier["tracestate"],
+	}
+}
+
+// startPublishSpan starts the span of sending msg. Messages sent outside of a trace, like
+// the ones relayed from an outbox, continue the trace recorded in their headers.
+func startPublishSpan(ctx context.Context, msg *Message) (context.Context, trace.Span) {
+	ctx = ContextWithTrace(ctx, msg.Headers)
+	return tracer.Start(ctx, msg.Topic+" publish",
+		trace.WithSpanKind(trace.SpanKindProducer),
+		trace.WithAttributes(
+			semconv.MessagingSystemKey.String("kafka"),
+			semconv.MessagingOperationPublish,
+			semconv.MessagingDestinationName(msg.Topic),
+			semconv.MessagingKafkaMessageKey(msg.Key),
+		),
+	)
+}
+
+// startProcessSpan starts the span of handling msg as a child of the span that sent it
+func startProcessSpan(ctx context.Context, msg *ConsumedMessage) (context.Context, trace.Span) {
+	ctx = otel.GetTextMapPropagator().Extract(ctx, propagation.MapCarrier(msg.Headers))
+	return tracer.Start(ctx, msg.Topic+" process",
+		trace.WithSpanKind(trace.SpanKindConsumer),
+		trace.WithAttributes(
+			semconv.MessagingSystemKey.String("kafka"),
+			semconv.MessagingOperationDeliver,
+			semconv.MessagingDestinationName(msg.Topic),
+			semconv.MessagingKafkaMessageKey(msg.Key),
+			semconv.MessagingKafkaDestinationPartition(int(msg.Partition)),
+			semconv.MessagingKafkaMessageOffset(int(msg.Offset)),
+			semconv.MessagingMessageID(msg.MessageID()),
+		),
+	)
+}
+
+// endSpan ends span, recording err when there is one
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
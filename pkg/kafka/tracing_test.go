@@ -0,0 +1,78 @@
+package kafka
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/sweetloveinyourheart/sweet-reel/pkg/messages"
+)
+
+const testTraceParent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+
+func tracedContext(t *testing.T) context.Context {
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	ctx := otel.GetTextMapPropagator().Extract(context.Background(), propagation.MapCarrier{"traceparent": testTraceParent})
+	if !trace.SpanContextFromContext(ctx).IsValid() {
+		t.Fatal("Expected a valid span context")
+	}
+	return ctx
+}
+
+func TestEncodeEventCarriesTrace(t *testing.T) {
+	ctx := tracedContext(t)
+
+	value, headers, err := encodeEvent(ctx, messages.VideoDeleted{VideoID: uuid.Must(uuid.NewV7())}, "", messages.EncodingJSON)
+	if err != nil {
+		t.Fatalf("Failed to encode event: %v", err)
+	}
+
+	if headers["traceparent"] != testTraceParent {
+		t.Errorf("Expected traceparent header %s, got %s", testTraceParent, headers["traceparent"])
+	}
+
+	envelope, err := messages.UnmarshalEnvelope(value, headers[HeaderContentType])
+	if err != nil {
+		t.Fatalf("Failed to unmarshal envelope: %v", err)
+	}
+	if envelope.Trace == nil || envelope.Trace.TraceParent != testTraceParent {
+		t.Errorf("Expected envelope trace %s, got %+v", testTraceParent, envelope.Trace)
+	}
+}
+
+func TestContextWithTrace(t *testing.T) {
+	traced := tracedContext(t)
+	headers := map[string]string{"traceparent": testTraceParent}
+
+	// Outside of a trace, the trace of the headers is continued
+	ctx := ContextWithTrace(context.Background(), headers)
+	if got := trace.SpanContextFromContext(ctx).TraceID(); got != trace.SpanContextFromContext(traced).TraceID() {
+		t.Errorf("Expected the trace of the headers, got %s", got)
+	}
+
+	// Within a trace, the headers are ignored
+	other := trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: trace.TraceID{1},
+		SpanID:  trace.SpanID{1},
+	}))
+	if got := trace.SpanContextFromContext(ContextWithTrace(other, headers)).TraceID(); got != (trace.TraceID{1}) {
+		t.Errorf("Expected the trace of the context to be kept, got %s", got)
+	}
+}
+
+func TestStartProcessSpanContinuesProducerTrace(t *testing.T) {
+	traced := tracedContext(t)
+
+	msg := &ConsumedMessage{Topic: KafkaVideoProcessedTopic, Headers: map[string]string{"traceparent": testTraceParent}}
+	ctx, span := startProcessSpan(context.Background(), msg)
+	defer span.End()
+
+	if got := trace.SpanContextFromContext(ctx).TraceID(); got != trace.SpanContextFromContext(traced).TraceID() {
+		t.Errorf("Expected the handler to run in the producer trace, got %s", got)
+	}
+}
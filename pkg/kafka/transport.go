@@ -0,0 +1,58 @@
+package kafka
+
+import (
+	"fmt"
+
+	"github.com/IBM/sarama"
+)
+
+// Transport opens the producers and consumer groups a client exchanges messages through
+type Transport interface {
+	NewSyncProducer(config *Config) (sarama.SyncProducer, error)
+	NewConsumerGroup(config *Config, groupID string) (sarama.ConsumerGroup, error)
+	// NewestOffsets returns, for each partition of topic, the offset its next message gets
+	NewestOffsets(config *Config, topic string) (map[int32]int64, error)
+}
+
+// saramaTransport connects to the brokers of the config
+type saramaTransport struct{}
+
+func (saramaTransport) NewSyncProducer(config *Config) (sarama.SyncProducer, error) {
+	return sarama.NewSyncProducer(config.Brokers, config.ToSaramaConfig())
+}
+
+func (saramaTransport) NewConsumerGroup(config *Config, groupID string) (sarama.ConsumerGroup, error) {
+	return sarama.NewConsumerGroup(config.Brokers, groupID, config.ToSaramaConfig())
+}
+
+func (saramaTransport) NewestOffsets(config *Config, topic string) (map[int32]int64, error) {
+	client, err := sarama.NewClient(config.Brokers, config.ToSaramaConfig())
+	if err != nil {
+		return nil, fmt.Errorf("failed to create kafka client: %w", err)
+	}
+	defer client.Close()
+
+	partitions, err := client.Partitions(topic)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list partitions of %s: %w", topic, err)
+	}
+
+	offsets := make(map[int32]int64, len(partitions))
+	for _, partition := range partitions {
+		offset, err := client.GetOffset(topic, partition, sarama.OffsetNewest)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get the newest offset of %s/%d: %w", topic, partition, err)
+		}
+		offsets[partition] = offset
+	}
+
+	return offsets, nil
+}
+
+// transport returns the transport of the config, the Kafka brokers unless one is set
+func (c *Config) transport() Transport {
+	if c.Transport != nil {
+		return c.Transport
+	}
+	return saramaTransport{}
+}
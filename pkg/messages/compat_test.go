@@ -0,0 +1,96 @@
+package messages
+
+import (
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+var updateSchemas = flag.Bool("update-schemas", false, "record the current event schemas in testdata")
+
+var schemasFile = filepath.Join("testdata", "event_schemas.json")
+
+// TestEventCompatibility fails when an event changes in a way consumers of the recorded
+// schema cannot decode. Breaking changes need a new EventVersion; record new or extended
+// schemas with: go test ./pkg/messages -run TestEventCompatibility -update-schemas
+func TestEventCompatibility(t *testing.T) {
+	recorded := map[string]Schema{}
+	if data, err := os.ReadFile(schemasFile); err == nil {
+		if err := json.Unmarshal(data, &recorded); err != nil {
+			t.Fatalf("Failed to read %s: %v", schemasFile, err)
+		}
+	}
+
+	schemas := map[string]Schema{}
+	for _, event := range Events {
+		schemas[SchemaKey(event)] = SchemaOf(event)
+	}
+	for processedType, data := range VideoProcessedData {
+		schemas[SchemaKey(VideoProcessed{})+"/"+string(processedType)] = SchemaOf(data)
+	}
+
+	for key, current := range schemas {
+		previous, ok := recorded[key]
+		if !ok {
+			if !*updateSchemas {
+				t.Errorf("No schema recorded for %s, run the test with -update-schemas", key)
+			}
+			recorded[key] = current
+			continue
+		}
+
+		for _, problem := range CheckCompatible(previous, current) {
+			t.Errorf("%s: %s, bump its EventVersion instead", key, problem)
+		}
+		recorded[key] = current
+	}
+
+	if *updateSchemas && !t.Failed() {
+		data, err := json.MarshalIndent(recorded, "", "  ")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(schemasFile, append(data, '\n'), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestCheckCompatible(t *testing.T) {
+	previous := Schema{"video_id": "string", "status": "string", "size": "integer"}
+	current := Schema{"video_id": "string", "size": "string", "width": "integer"}
+
+	problems := CheckCompatible(previous, current)
+	expected := []string{
+		"field size changed from integer to string",
+		"field status was removed",
+	}
+
+	if len(problems) != len(expected) {
+		t.Fatalf("Expected %d problems, got %v", len(expected), problems)
+	}
+	for i := range expected {
+		if problems[i] != expected[i] {
+			t.Errorf("Expected %q, got %q", expected[i], problems[i])
+		}
+	}
+}
+
+func TestSchemaOf(t *testing.T) {
+	schema := SchemaOf(VideoProcessedVariantData{})
+
+	expected := map[string]string{
+		"quality":         "string",
+		"total_segments":  "integer",
+		"ladder":          "array",
+		"ladder[].width":  "integer",
+		"ladder[].height": "integer",
+	}
+	for path, kind := range expected {
+		if schema[path] != kind {
+			t.Errorf("Expected %s to be %s, got %q", path, kind, schema[path])
+		}
+	}
+}
@@ -0,0 +1,190 @@
+package messages
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	events "github.com/sweetloveinyourheart/sweet-reel/proto/code/events/go"
+)
+
+// Content types of the values published on Kafka
+const (
+	// ContentTypeJSON is a bare JSON message, as published before events were enveloped
+	ContentTypeJSON = "application/json"
+	// ContentTypeEventJSON is an Envelope encoded as JSON
+	ContentTypeEventJSON = "application/vnd.sweetreel.event+json"
+	// ContentTypeEventProtobuf is an Envelope encoded as protobuf. Its event data stays JSON,
+	// only the envelope around it is protobuf.
+	ContentTypeEventProtobuf = "application/vnd.sweetreel.event+protobuf"
+)
+
+// ErrUnsupportedEventVersion is returned when decoding an event of a newer version than the consumer knows
+var ErrUnsupportedEventVersion = errors.New("unsupported event version")
+
+// Encoding is how an Envelope is encoded on the wire
+type Encoding string
+
+const (
+	EncodingJSON     Encoding = "json"
+	EncodingProtobuf Encoding = "protobuf"
+)
+
+// Event is a message published on Kafka.
+// Adding fields to an event is backward compatible; removing or retyping a field is not and
+// needs a new version, which consumers of the old version must be able to tell apart.
+type Event interface {
+	// EventType names the event, independently of the topic it is published on
+	EventType() string
+	// EventVersion is the version of the event schema, starting at 1
+	EventVersion() int
+}
+
+// TraceContext is the W3C trace context of the operation that produced an event
+type TraceContext struct {
+	TraceParent string `json:"traceparent,omitempty"`
+	TraceState  string `json:"tracestate,omitempty"`
+}
+
+// Envelope wraps an event with what consumers need to route, deduplicate and trace it.
+// Data always holds the event as JSON, also in envelopes encoded as protobuf.
+type Envelope struct {
+	EventID    string          `json:"event_id"`
+	Type       string          `json:"type"`
+	Version    int             `json:"version"`
+	OccurredAt time.Time       `json:"occurred_at"`
+	Producer   string          `json:"producer,omitempty"`
+	Trace      *TraceContext   `json:"trace,omitempty"`
+	Data       json.RawMessage `json:"data"`
+}
+
+// NewEnvelope wraps event in an envelope with a new event id
+func NewEnvelope(event Event) (*Envelope, error) {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal %s event: %w", event.EventType(), err)
+	}
+
+	return &Envelope{
+		EventID:    uuid.Must(uuid.NewV7()).String(),
+		Type:       event.EventType(),
+		Version:    event.EventVersion(),
+		OccurredAt: time.Now().UTC(),
+		Data:       data,
+	}, nil
+}
+
+// Decode unmarshals the event carried by the envelope into event.
+// Added fields do not change the version of an event, so an event of a newer version than
+// event removed or retyped fields and is refused with ErrUnsupportedEventVersion.
+func (e *Envelope) Decode(event Event) error {
+	if e.Type != event.EventType() {
+		return fmt.Errorf("cannot decode %s event into %s", e.Type, event.EventType())
+	}
+
+	if e.Version > event.EventVersion() {
+		return fmt.Errorf("%w: %s event version %d, up to %d is known", ErrUnsupportedEventVersion, e.Type, e.Version, event.EventVersion())
+	}
+
+	return json.Unmarshal(e.Data, event)
+}
+
+// Marshal encodes the envelope and returns it with its content type
+func (e *Envelope) Marshal(encoding Encoding) ([]byte, string, error) {
+	switch encoding {
+	case EncodingProtobuf:
+		value, err := proto.Marshal(e.toProto())
+		if err != nil {
+			return nil, "", err
+		}
+		return value, ContentTypeEventProtobuf, nil
+	case EncodingJSON, "":
+		value, err := json.Marshal(e)
+		if err != nil {
+			return nil, "", err
+		}
+		return value, ContentTypeEventJSON, nil
+	default:
+		return nil, "", fmt.Errorf("unknown event encoding %s", encoding)
+	}
+}
+
+// UnmarshalEnvelope decodes an envelope encoded with the given content type
+func UnmarshalEnvelope(value []byte, contentType string) (*Envelope, error) {
+	switch contentType {
+	case ContentTypeEventProtobuf:
+		var pb events.Envelope
+		if err := proto.Unmarshal(value, &pb); err != nil {
+			return nil, err
+		}
+		return envelopeFromProto(&pb), nil
+	case ContentTypeEventJSON:
+		var envelope Envelope
+		if err := json.Unmarshal(value, &envelope); err != nil {
+			return nil, err
+		}
+		return &envelope, nil
+	default:
+		return nil, fmt.Errorf("%s is not an event content type", contentType)
+	}
+}
+
+// Unmarshal decodes a value published with the given content type into event. Values
+// without an event content type are bare JSON messages from producers that predate envelopes.
+func Unmarshal(value []byte, contentType string, event Event) error {
+	if contentType != ContentTypeEventJSON && contentType != ContentTypeEventProtobuf {
+		return json.Unmarshal(value, event)
+	}
+
+	envelope, err := UnmarshalEnvelope(value, contentType)
+	if err != nil {
+		return err
+	}
+
+	return envelope.Decode(event)
+}
+
+func (e *Envelope) toProto() *events.Envelope {
+	pb := &events.Envelope{
+		EventId:    e.EventID,
+		Type:       e.Type,
+		Version:    int32(e.Version),
+		OccurredAt: timestamppb.New(e.OccurredAt),
+		Producer:   e.Producer,
+		Data:       e.Data,
+	}
+
+	if e.Trace != nil {
+		pb.Trace = &events.TraceContext{
+			Traceparent: e.Trace.TraceParent,
+			Tracestate:  e.Trace.TraceState,
+		}
+	}
+
+	return pb
+}
+
+func envelopeFromProto(pb *events.Envelope) *Envelope {
+	envelope := &Envelope{
+		EventID:    pb.GetEventId(),
+		Type:       pb.GetType(),
+		Version:    int(pb.GetVersion()),
+		OccurredAt: pb.GetOccurredAt().AsTime(),
+		Producer:   pb.GetProducer(),
+		Data:       pb.GetData(),
+	}
+
+	if pb.GetTrace() != nil {
+		envelope.Trace = &TraceContext{
+			TraceParent: pb.GetTrace().GetTraceparent(),
+			TraceState:  pb.GetTrace().GetTracestate(),
+		}
+	}
+
+	return envelope
+}
@@ -0,0 +1,142 @@
+package messages
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+func TestEnvelopeRoundTrip(t *testing.T) {
+	event := VideoProcessingProgress{
+		VideoID:     uuid.Must(uuid.NewV7()),
+		Status:      VideoStatusReady,
+		ObjectKey:   "2025-11-03/video.mp4",
+		ProcessedAt: time.Now().UTC().Truncate(time.Millisecond),
+	}
+
+	for _, encoding := range []Encoding{EncodingJSON, EncodingProtobuf} {
+		envelope, err := NewEnvelope(event)
+		if err != nil {
+			t.Fatal(err)
+		}
+		envelope.Producer = "video_processing"
+		envelope.Trace = &TraceContext{TraceParent: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"}
+
+		value, contentType, err := envelope.Marshal(encoding)
+		if err != nil {
+			t.Fatalf("%s: %v", encoding, err)
+		}
+
+		decoded, err := UnmarshalEnvelope(value, contentType)
+		if err != nil {
+			t.Fatalf("%s: %v", encoding, err)
+		}
+
+		if decoded.EventID != envelope.EventID || decoded.Type != "video.processing_progress" || decoded.Version != 1 {
+			t.Errorf("%s: envelope fields not preserved: %+v", encoding, decoded)
+		}
+		if decoded.Producer != "video_processing" || decoded.Trace == nil || decoded.Trace.TraceParent != envelope.Trace.TraceParent {
+			t.Errorf("%s: producer or trace not preserved: %+v", encoding, decoded)
+		}
+
+		var got VideoProcessingProgress
+		if err := Unmarshal(value, contentType, &got); err != nil {
+			t.Fatalf("%s: %v", encoding, err)
+		}
+		if got.VideoID != event.VideoID || got.Status != event.Status || !got.ProcessedAt.Equal(event.ProcessedAt) {
+			t.Errorf("%s: expected %+v, got %+v", encoding, event, got)
+		}
+	}
+}
+
+func TestUnmarshalBareJSON(t *testing.T) {
+	event := VideoDeleted{VideoID: uuid.Must(uuid.NewV7())}
+	value, err := json.Marshal(event)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Messages from producers that predate envelopes carry no content type
+	var got VideoDeleted
+	if err := Unmarshal(value, "", &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.VideoID != event.VideoID {
+		t.Errorf("Expected video %s, got %s", event.VideoID, got.VideoID)
+	}
+}
+
+func TestEnvelopeDecodeWrongType(t *testing.T) {
+	envelope, err := NewEnvelope(VideoDeleted{VideoID: uuid.Must(uuid.NewV7())})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := envelope.Decode(&VideoPublished{}); err == nil {
+		t.Error("Expected decoding into another event type to fail")
+	}
+}
+
+func TestEnvelopeDecodeNewerVersion(t *testing.T) {
+	event := VideoDeleted{VideoID: uuid.Must(uuid.NewV7())}
+	envelope, err := NewEnvelope(event)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A newer version removed or retyped fields the consumer relies on
+	envelope.Version = event.EventVersion() + 1
+	if err := envelope.Decode(&VideoDeleted{}); !errors.Is(err, ErrUnsupportedEventVersion) {
+		t.Errorf("Expected ErrUnsupportedEventVersion, got %v", err)
+	}
+
+	envelope.Version = event.EventVersion()
+	if err := envelope.Decode(&VideoDeleted{}); err != nil {
+		t.Errorf("Expected the known version to decode, got %v", err)
+	}
+}
+
+func TestProtobufEnvelopeCarriesJSONData(t *testing.T) {
+	event := VideoDeleted{VideoID: uuid.Must(uuid.NewV7())}
+	envelope, err := NewEnvelope(event)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	value, contentType, err := envelope.Marshal(EncodingProtobuf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	decoded, err := UnmarshalEnvelope(value, contentType)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got VideoDeleted
+	if err := json.Unmarshal(decoded.Data, &got); err != nil {
+		t.Fatalf("Expected the event data to stay JSON, got %v", err)
+	}
+	if got.VideoID != event.VideoID {
+		t.Errorf("Expected video %s, got %s", event.VideoID, got.VideoID)
+	}
+}
+
+func TestVideoProcessedData(t *testing.T) {
+	msg, err := NewVideoProcessed(uuid.Must(uuid.NewV7()), "video/hls/720p/index.m3u8", VideoProcessedTypeVariant,
+		VideoProcessedVariantData{Quality: "720p", TotalSegments: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var data VideoProcessedVariantData
+	if err := msg.DecodeData(&data); err != nil {
+		t.Fatal(err)
+	}
+	if data.Quality != "720p" || data.TotalSegments != 10 {
+		t.Errorf("Unexpected variant data %+v", data)
+	}
+}
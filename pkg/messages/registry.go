@@ -0,0 +1,20 @@
+package messages
+
+// Events lists every event published on Kafka, the compatibility check covers each of them
+var Events = []Event{
+	VideoProcessingProgress{},
+	VideoProcessed{},
+	VideoDeleted{},
+	VideoPublished{},
+	ChannelViewsChanged{},
+	ChannelVideosChanged{},
+}
+
+// VideoProcessedData lists the data struct carried by VideoProcessed for each type
+var VideoProcessedData = map[VideoProcessedType]any{
+	VideoProcessedTypeSource:     VideoProcessedSourceData{},
+	VideoProcessedTypeManifest:   VideoProcessedManifestData{},
+	VideoProcessedTypeThumbnail:  VideoProcessedThumbnailData{},
+	VideoProcessedTypeStoryboard: VideoProcessedStoryboardData{},
+	VideoProcessedTypeVariant:    VideoProcessedVariantData{},
+}
@@ -0,0 +1,115 @@
+package messages
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+// Schema maps the JSON path of every field of an event to its JSON kind.
+// Nested objects are joined with dots and array elements are marked with [].
+type Schema map[string]string
+
+var (
+	timeType       = reflect.TypeOf(time.Time{})
+	uuidType       = reflect.TypeOf(uuid.UUID{})
+	rawMessageType = reflect.TypeOf(json.RawMessage{})
+)
+
+// SchemaOf returns the schema of the JSON encoding of v
+func SchemaOf(v any) Schema {
+	schema := Schema{}
+	addFields(schema, "", reflect.TypeOf(v))
+	return schema
+}
+
+// SchemaKey identifies a version of an event in a set of schemas
+func SchemaKey(event Event) string {
+	return fmt.Sprintf("%s@v%d", event.EventType(), event.EventVersion())
+}
+
+// CheckCompatible returns the changes from previous to current that break consumers still
+// decoding previous: fields that were removed or changed kind. Added fields are compatible.
+func CheckCompatible(previous Schema, current Schema) []string {
+	var problems []string
+	for path, kind := range previous {
+		currentKind, ok := current[path]
+		switch {
+		case !ok:
+			problems = append(problems, fmt.Sprintf("field %s was removed", path))
+		case currentKind != kind:
+			problems = append(problems, fmt.Sprintf("field %s changed from %s to %s", path, kind, currentKind))
+		}
+	}
+
+	sort.Strings(problems)
+	return problems
+}
+
+func addFields(schema Schema, prefix string, t reflect.Type) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name := field.Name
+		if tag := field.Tag.Get("json"); tag != "" {
+			tagName, _, _ := strings.Cut(tag, ",")
+			if tagName == "-" {
+				continue
+			}
+			if tagName != "" {
+				name = tagName
+			}
+		}
+
+		addType(schema, prefix+name, field.Type)
+	}
+}
+
+func addType(schema Schema, path string, t reflect.Type) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	switch {
+	case t == timeType || t == uuidType:
+		schema[path] = "string"
+		return
+	case t == rawMessageType:
+		schema[path] = "any"
+		return
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		schema[path] = "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		schema[path] = "integer"
+	case reflect.Float32, reflect.Float64:
+		schema[path] = "number"
+	case reflect.String:
+		schema[path] = "string"
+	case reflect.Slice, reflect.Array:
+		schema[path] = "array"
+		addType(schema, path+"[]", t.Elem())
+	case reflect.Map:
+		schema[path] = "object"
+	case reflect.Struct:
+		schema[path] = "object"
+		addFields(schema, path+".", t)
+	default:
+		schema[path] = "any"
+	}
+}
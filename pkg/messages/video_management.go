@@ -0,0 +1,50 @@
+package messages
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+// VideoDeleted is published once a video and all of its stored files have been removed
+type VideoDeleted struct {
+	VideoID    uuid.UUID `json:"video_id"`
+	ChannelID  uuid.UUID `json:"channel_id"`
+	UploaderID uuid.UUID `json:"uploader_id"`
+	DeletedAt  time.Time `json:"deleted_at"`
+}
+
+func (VideoDeleted) EventType() string { return "video.deleted" }
+func (VideoDeleted) EventVersion() int { return 1 }
+
+// VideoPublished is published when a scheduled video becomes public
+type VideoPublished struct {
+	VideoID     uuid.UUID `json:"video_id"`
+	ChannelID   uuid.UUID `json:"channel_id"`
+	UploaderID  uuid.UUID `json:"uploader_id"`
+	PublishedAt time.Time `json:"published_at"`
+}
+
+func (VideoPublished) EventType() string { return "video.published" }
+func (VideoPublished) EventVersion() int { return 1 }
+
+// ChannelViewsChanged carries the total views of a channel as counted at ObservedAt.
+// It holds the whole total rather than a delta so consumers can apply it any number of times.
+type ChannelViewsChanged struct {
+	ChannelID  uuid.UUID `json:"channel_id"`
+	TotalViews int64     `json:"total_views"`
+	ObservedAt time.Time `json:"observed_at"`
+}
+
+func (ChannelViewsChanged) EventType() string { return "channel.views_changed" }
+func (ChannelViewsChanged) EventVersion() int { return 1 }
+
+// ChannelVideosChanged carries the number of videos of a channel as counted at ObservedAt
+type ChannelVideosChanged struct {
+	ChannelID   uuid.UUID `json:"channel_id"`
+	TotalVideos int64     `json:"total_videos"`
+	ObservedAt  time.Time `json:"observed_at"`
+}
+
+func (ChannelVideosChanged) EventType() string { return "channel.videos_changed" }
+func (ChannelVideosChanged) EventVersion() int { return 1 }
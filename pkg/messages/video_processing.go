@@ -1,6 +1,7 @@
 package messages
 
 import (
+	"encoding/json"
 	"time"
 
 	"github.com/gofrs/uuid"
@@ -15,31 +16,163 @@ const (
 	VideoStatusFailed     VideoStatus = "failed"
 )
 
+// VideoFormat represents how a video is presented to viewers
+type VideoFormat string
+
+const (
+	VideoFormatLongForm VideoFormat = "long_form"
+	VideoFormatReel     VideoFormat = "reel"
+)
+
+// VideoFailureCode tells why a video could not be processed
+type VideoFailureCode string
+
+const (
+	VideoFailureFileTooLarge          VideoFailureCode = "file_too_large"
+	VideoFailureEmptyFile             VideoFailureCode = "empty_file"
+	VideoFailureUnreadableFile        VideoFailureCode = "unreadable_file"
+	VideoFailureNoVideoStream         VideoFailureCode = "no_video_stream"
+	VideoFailureUnsupportedContainer  VideoFailureCode = "unsupported_container"
+	VideoFailureUnsupportedCodec      VideoFailureCode = "unsupported_codec"
+	VideoFailureDurationTooShort      VideoFailureCode = "duration_too_short"
+	VideoFailureDurationTooLong       VideoFailureCode = "duration_too_long"
+	VideoFailureUnsupportedResolution VideoFailureCode = "unsupported_resolution"
+	VideoFailureProcessingError       VideoFailureCode = "processing_error" // Transcoding failed, the upload itself was valid
+)
+
+// VideoFailure is the reason a video ended up failed
+type VideoFailure struct {
+	Code    VideoFailureCode `json:"code"`
+	Message string           `json:"message"`
+}
+
+// VideoProcessingStage is a step of processing a video
+type VideoProcessingStage string
+
+const (
+	VideoProcessingStageDownload   VideoProcessingStage = "download"
+	VideoProcessingStageProbe      VideoProcessingStage = "probe"
+	VideoProcessingStageSegment    VideoProcessingStage = "segment"
+	VideoProcessingStageThumbnail  VideoProcessingStage = "thumbnail"
+	VideoProcessingStageStoryboard VideoProcessingStage = "storyboard"
+	VideoProcessingStageUpload     VideoProcessingStage = "upload"
+)
+
+// VideoProcessingJobStatus is the state of one attempt at processing a video
+type VideoProcessingJobStatus string
+
+const (
+	VideoProcessingJobRunning     VideoProcessingJobStatus = "running"
+	VideoProcessingJobSucceeded   VideoProcessingJobStatus = "succeeded"
+	VideoProcessingJobFailed      VideoProcessingJobStatus = "failed"
+	VideoProcessingJobInterrupted VideoProcessingJobStatus = "interrupted" // Handed back on shutdown, another attempt follows
+)
+
+// VideoProcessingStageReport tells how a stage of a processing attempt went
+type VideoProcessingStageReport struct {
+	Stage        VideoProcessingStage `json:"stage"`
+	StartedAt    time.Time            `json:"started_at"`
+	DurationMs   int64                `json:"duration_ms"`
+	ErrorCode    VideoFailureCode     `json:"error_code,omitempty"` // Empty when the stage succeeded
+	ErrorMessage string               `json:"error_message,omitempty"`
+	FFmpegStderr string               `json:"ffmpeg_stderr,omitempty"` // End of the stderr of a failed ffmpeg run
+}
+
+// VideoProcessingJob is the attempt at processing a video a progress update comes from
+type VideoProcessingJob struct {
+	ID        uuid.UUID                    `json:"id"`
+	Attempt   int                          `json:"attempt"` // Starts at 1, retries of the upload count up
+	Status    VideoProcessingJobStatus     `json:"status"`
+	StartedAt time.Time                    `json:"started_at"`
+	Stages    []VideoProcessingStageReport `json:"stages,omitempty"` // In the order they ran
+}
+
+// VideoTranscodeProgress is how far the transcoding of a video got, over all its renditions
+type VideoTranscodeProgress struct {
+	Percentage     float64 `json:"percentage"`      // 0-100
+	Speed          string  `json:"speed,omitempty"` // Relative to playback, e.g. "2.34x"
+	CurrentSeconds float64 `json:"current_seconds"`
+	TotalSeconds   float64 `json:"total_seconds"`
+}
+
+// VideoProcessingProgress reports the status of a video. Updates with the processing status
+// only describe the job, the video keeps its status until the job is done.
 type VideoProcessingProgress struct {
-	VideoID     uuid.UUID   `json:"video_id"`
-	Status      VideoStatus `json:"status"`
-	ObjectKey   string      `json:"object_key"`
-	ProcessedAt time.Time   `json:"processed_at"`
+	VideoID     uuid.UUID               `json:"video_id"`
+	Status      VideoStatus             `json:"status"`
+	ObjectKey   string                  `json:"object_key"`
+	ProcessedAt time.Time               `json:"processed_at"`
+	Failure     *VideoFailure           `json:"failure,omitempty"` // Set when Status is failed
+	Job         *VideoProcessingJob     `json:"job,omitempty"`
+	Progress    *VideoTranscodeProgress `json:"progress,omitempty"` // Set while the video is transcoded
 }
 
+func (VideoProcessingProgress) EventType() string { return "video.processing_progress" }
+func (VideoProcessingProgress) EventVersion() int { return 1 }
+
 type VideoProcessedType string
 
 const (
-	VideoProcessedTypeManifest  VideoProcessedType = "manifest"
-	VideoProcessedTypeThumbnail VideoProcessedType = "thumbnail"
-	VideoProcessedTypeVariant   VideoProcessedType = "variant"
+	VideoProcessedTypeManifest   VideoProcessedType = "manifest"
+	VideoProcessedTypeThumbnail  VideoProcessedType = "thumbnail"
+	VideoProcessedTypeStoryboard VideoProcessedType = "storyboard"
+	VideoProcessedTypeVariant    VideoProcessedType = "variant"
+	VideoProcessedTypeSource     VideoProcessedType = "source"
 )
 
+// VideoProcessed announces a file produced for a video. Data holds the
+// VideoProcessed*Data struct matching Type.
 type VideoProcessed struct {
 	VideoID   uuid.UUID          `json:"video_id"`
 	ObjectKey string             `json:"object_key"`
 	Type      VideoProcessedType `json:"type"`
-	Data      any                `json:"data"`
+	Data      json.RawMessage    `json:"data"`
 }
 
+func (VideoProcessed) EventType() string { return "video.processed" }
+func (VideoProcessed) EventVersion() int { return 1 }
+
+// NewVideoProcessed creates a VideoProcessed message carrying data
+func NewVideoProcessed(videoID uuid.UUID, objectKey string, processedType VideoProcessedType, data any) (VideoProcessed, error) {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return VideoProcessed{}, err
+	}
+
+	return VideoProcessed{
+		VideoID:   videoID,
+		ObjectKey: objectKey,
+		Type:      processedType,
+		Data:      raw,
+	}, nil
+}
+
+// DecodeData unmarshals the data of the message into the VideoProcessed*Data struct matching its type
+func (m VideoProcessed) DecodeData(data any) error {
+	return json.Unmarshal(m.Data, data)
+}
+
+// VideoProcessedSourceData describes the uploaded source as detected when it was probed
+type VideoProcessedSourceData struct {
+	Width           int         `json:"width"`  // Display width, after rotation
+	Height          int         `json:"height"` // Display height, after rotation
+	Orientation     string      `json:"orientation"`
+	DurationSeconds float64     `json:"duration_seconds"`
+	Format          VideoFormat `json:"format"`
+}
+
+// ManifestFormat is the streaming protocol a manifest is written for
+type ManifestFormat string
+
+const (
+	ManifestFormatHLS  ManifestFormat = "hls"
+	ManifestFormatDASH ManifestFormat = "dash"
+)
+
 type VideoProcessedManifestData struct {
-	Quality   string `json:"quality"`
-	SizeBytes int64  `json:"size_bytes"`
+	Quality   string         `json:"quality"`
+	SizeBytes int64          `json:"size_bytes"`
+	Format    ManifestFormat `json:"format,omitempty"` // Empty for HLS playlists published before DASH was supported
 }
 
 type VideoProcessedThumbnailData struct {
@@ -47,8 +180,39 @@ type VideoProcessedThumbnailData struct {
 	Height int `json:"height"`
 }
 
+// VideoProcessedStoryboardData describes the sprites of a storyboard, the object key of the
+// message is its WebVTT track
+type VideoProcessedStoryboardData struct {
+	IntervalSeconds int `json:"interval_seconds"` // Time between two frames
+	TileWidth       int `json:"tile_width"`
+	TileHeight      int `json:"tile_height"`
+	Columns         int `json:"columns"`
+	Rows            int `json:"rows"`
+	Sprites         int `json:"sprites"` // Number of sprite sheets, stored next to the track
+}
+
 type VideoProcessedVariantData struct {
-	Quality       string `json:"quality"`
-	TotalSegments int    `json:"total_segments"`
-	TotalDuration int    `json:"total_duration"`
+	Quality       string           `json:"quality"`
+	TotalSegments int              `json:"total_segments"`
+	TotalDuration int              `json:"total_duration"`
+	Width         int              `json:"width,omitempty"`
+	Height        int              `json:"height,omitempty"`
+	VideoBitrate  string           `json:"video_bitrate,omitempty"`
+	AudioBitrate  string           `json:"audio_bitrate,omitempty"`
+	Ladder        []VideoRendition `json:"ladder,omitempty"` // Every rendition chosen for the video
+
+	// Stream info of the variant as written to HLS master playlists
+	Bandwidth        int     `json:"bandwidth,omitempty"`         // Peak segment bitrate in bits per second
+	AverageBandwidth int     `json:"average_bandwidth,omitempty"` // Average bitrate in bits per second
+	FrameRate        float64 `json:"frame_rate,omitempty"`
+	Codecs           string  `json:"codecs,omitempty"` // RFC 6381 codecs, e.g. "avc1.64001f,mp4a.40.2"
+}
+
+// VideoRendition is one rung of the adaptive bitrate ladder chosen for a video
+type VideoRendition struct {
+	Quality      string `json:"quality"`
+	Width        int    `json:"width"`
+	Height       int    `json:"height"`
+	VideoBitrate string `json:"video_bitrate"`
+	AudioBitrate string `json:"audio_bitrate"`
 }
@@ -13,14 +13,34 @@ import (
 	"github.com/sweetloveinyourheart/sweet-reel/pkg/logger"
 )
 
+const (
+	// StorageBackendS3 stores objects in AWS S3, or MinIO when its URL is configured
+	StorageBackendS3 = "s3"
+	// StorageBackendLocal stores objects in a local directory, see LocalStorage
+	StorageBackendLocal = "local"
+)
+
 type Config struct {
 	AccessID string
 	Region   string
 	Secret   string
 
+	// Backend selects where objects are stored, StorageBackendS3 by default
+	Backend string
+	Local   LocalConfig
+
 	minioURL string
 }
 
+type LocalConfig struct {
+	// Dir is the directory objects are stored in
+	Dir string
+	// BaseURL is the public URL of the handler serving Dir, presigned URLs are built from it
+	BaseURL string
+	// SigningKey signs presigned URLs
+	SigningKey string
+}
+
 func ServiceConfig(serviceType string) *Config {
 	id := config.Instance().GetString(fmt.Sprintf("%s.aws.s3.access.id", serviceType))
 	s3Region := config.Instance().GetString(fmt.Sprintf("%s.aws.s3.region", serviceType))
@@ -32,6 +52,13 @@ func ServiceConfig(serviceType string) *Config {
 		AccessID: id,
 		Secret:   secret,
 
+		Backend: config.Instance().GetString(fmt.Sprintf("%s.storage.backend", serviceType)),
+		Local: LocalConfig{
+			Dir:        config.Instance().GetString(fmt.Sprintf("%s.storage.local.dir", serviceType)),
+			BaseURL:    config.Instance().GetString(fmt.Sprintf("%s.storage.local.url", serviceType)),
+			SigningKey: config.Instance().GetString(fmt.Sprintf("%s.storage.local.signing_key", serviceType)),
+		},
+
 		minioURL: minioURL,
 	}
 }
@@ -60,3 +87,15 @@ func (c *Config) ToS3Config(ctx context.Context) (aws.Config, error) {
 
 	return cfg, nil
 }
+
+// NewStorage creates the storage selected by the backend of config
+func NewStorage(ctx context.Context, config *Config) (S3StreamStorage, error) {
+	switch config.Backend {
+	case StorageBackendS3, "":
+		return CreateS3Client(ctx, config)
+	case StorageBackendLocal:
+		return NewLocalStorage(config.Local)
+	default:
+		return nil, fmt.Errorf("unknown storage backend: %q", config.Backend)
+	}
+}
@@ -0,0 +1,413 @@
+package s3
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sweetloveinyourheart/sweet-reel/pkg/logger"
+	"github.com/sweetloveinyourheart/sweet-reel/pkg/messages"
+)
+
+var _ S3StreamStorage = (*LocalStorage)(nil)
+
+const (
+	// localMetadataDir holds the content type and user metadata of every object, next to the
+	// bucket directories. Bucket names cannot start with a dot, so it never clashes with one.
+	localMetadataDir = ".metadata"
+
+	// localTempPattern names the files objects are written to before they are complete
+	localTempPattern = ".upload-*"
+)
+
+var (
+	ErrObjectNotFound   = errors.New("object not found")
+	ErrInvalidSignature = errors.New("invalid signature")
+	ErrURLExpired       = errors.New("presigned url expired")
+)
+
+// LocalStorage stores objects as files under a directory, one subdirectory per bucket, for
+// single-node installs without S3 or MinIO. Its presigned URLs point to a handler serving
+// the same directory, which checks them with AuthorizeUpload and AuthorizeDownload.
+//
+// Presigned URLs carry their expiry and HMAC signature as the first path segments:
+// <base URL>/<expires>/<signature>/<bucket>/<key>. A download URL grants access to the
+// object of its key only, playlists and manifests are handed out with every segment signed.
+type LocalStorage struct {
+	dir        string
+	baseURL    string
+	signingKey []byte
+
+	mu       sync.RWMutex
+	watchers map[string][]func(messages.S3EventMessage)
+}
+
+// localObjectInfo is stored in the metadata directory for every object
+type localObjectInfo struct {
+	ContentType string            `json:"content_type"`
+	Metadata    map[string]string `json:"metadata,omitempty"`
+}
+
+func NewLocalStorage(config LocalConfig) (*LocalStorage, error) {
+	if config.Dir == "" {
+		return nil, errors.New("local storage directory is required")
+	}
+	if config.BaseURL == "" {
+		return nil, errors.New("local storage url is required")
+	}
+	if config.SigningKey == "" {
+		return nil, errors.New("local storage signing key is required")
+	}
+
+	if err := os.MkdirAll(config.Dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create local storage directory: %w", err)
+	}
+
+	return &LocalStorage{
+		dir:        config.Dir,
+		baseURL:    strings.TrimSuffix(config.BaseURL, "/"),
+		signingKey: []byte(config.SigningKey),
+		watchers:   make(map[string][]func(messages.S3EventMessage)),
+	}, nil
+}
+
+// OnObjectCreated calls fn with a MinIO-style notification of every object stored in bucket
+func (s *LocalStorage) OnObjectCreated(bucket string, fn func(messages.S3EventMessage)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.watchers[bucket] = append(s.watchers[bucket], fn)
+}
+
+func (s *LocalStorage) Upload(key string, bucket string, file io.Reader, mimeType string) error {
+	return s.PutObject(key, bucket, file, mimeType, nil)
+}
+
+func (s *LocalStorage) UploadFile(key string, bucket string, filePath string, mimeType string) error {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	return s.PutObject(key, bucket, file, mimeType, nil)
+}
+
+// PutObject stores the content of file as key in bucket along with its user metadata, and
+// notifies the watchers of bucket
+func (s *LocalStorage) PutObject(key string, bucket string, file io.Reader, mimeType string, metadata map[string]string) error {
+	objectPath, metadataPath, err := s.paths(key, bucket)
+	if err != nil {
+		return err
+	}
+
+	info, err := json.Marshal(localObjectInfo{ContentType: mimeType, Metadata: metadata})
+	if err != nil {
+		return err
+	}
+	if _, err := writeFileAtomic(metadataPath, bytes.NewReader(info)); err != nil {
+		return err
+	}
+
+	size, err := writeFileAtomic(objectPath, file)
+	if err != nil {
+		logger.GlobalSugared().Errorf("Failed to store object %s in bucket %s: %v", key, bucket, err)
+		return err
+	}
+
+	logger.GlobalSugared().Infof("Successfully stored %s in bucket %s", key, bucket)
+
+	s.mu.RLock()
+	watchers := slices.Clone(s.watchers[bucket])
+	s.mu.RUnlock()
+
+	if len(watchers) > 0 {
+		event := NewObjectCreatedEvent(key, bucket, size, mimeType, metadata)
+		for _, fn := range watchers {
+			fn(event)
+		}
+	}
+
+	return nil
+}
+
+func (s *LocalStorage) Download(key string, bucket string) ([]byte, error) {
+	body, err := s.DownloadStream(key, bucket)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	return io.ReadAll(body)
+}
+
+func (s *LocalStorage) DownloadStream(key string, bucket string) (io.ReadCloser, error) {
+	file, _, err := s.OpenObject(key, bucket)
+	if err != nil {
+		return nil, err
+	}
+	return file, nil
+}
+
+func (s *LocalStorage) DownloadToFile(key string, bucket string, filePath string) (int64, error) {
+	body, err := s.DownloadStream(key, bucket)
+	if err != nil {
+		return 0, err
+	}
+	defer body.Close()
+
+	if err := os.MkdirAll(filepath.Dir(filePath), 0755); err != nil {
+		return 0, err
+	}
+
+	file, err := os.Create(filePath)
+	if err != nil {
+		return 0, err
+	}
+	defer file.Close()
+
+	written, err := io.Copy(file, body)
+	if err != nil {
+		return written, err
+	}
+
+	return written, file.Sync()
+}
+
+// OpenObject opens key in bucket for reading and returns its content type.
+// The caller owns the file and must close it.
+func (s *LocalStorage) OpenObject(key string, bucket string) (*os.File, string, error) {
+	objectPath, metadataPath, err := s.paths(key, bucket)
+	if err != nil {
+		return nil, "", err
+	}
+
+	file, err := os.Open(objectPath)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, "", fmt.Errorf("%w: %s in bucket %s", ErrObjectNotFound, key, bucket)
+	}
+	if err != nil {
+		return nil, "", err
+	}
+
+	var info localObjectInfo
+	if data, err := os.ReadFile(metadataPath); err == nil {
+		_ = json.Unmarshal(data, &info)
+	}
+
+	return file, info.ContentType, nil
+}
+
+func (s *LocalStorage) Delete(key string, bucket string) error {
+	objectPath, metadataPath, err := s.paths(key, bucket)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(objectPath); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		logger.GlobalSugared().Errorf("Failed to delete object %s from bucket %s: %v", key, bucket, err)
+		return err
+	}
+	if err := os.Remove(metadataPath); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+
+	logger.GlobalSugared().Infof("Successfully deleted %s from bucket %s", key, bucket)
+	return nil
+}
+
+// DeletePrefix deletes every object whose key starts with prefix and returns how many were deleted
+func (s *LocalStorage) DeletePrefix(prefix string, bucket string) (int, error) {
+	bucketDir, _, err := s.paths("", bucket)
+	if err != nil {
+		return 0, err
+	}
+
+	var keys []string
+	err = filepath.WalkDir(bucketDir, func(p string, d fs.DirEntry, err error) error {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		// Skip the objects still being written
+		if d.IsDir() || strings.HasPrefix(d.Name(), strings.TrimSuffix(localTempPattern, "*")) {
+			return nil
+		}
+
+		rel, err := filepath.Rel(bucketDir, p)
+		if err != nil {
+			return err
+		}
+		if key := filepath.ToSlash(rel); strings.HasPrefix(key, prefix) {
+			keys = append(keys, key)
+		}
+		return nil
+	})
+	if err != nil {
+		logger.GlobalSugared().Errorf("Failed to list objects under %s in bucket %s: %v", prefix, bucket, err)
+		return 0, err
+	}
+
+	for i, key := range keys {
+		if err := s.Delete(key, bucket); err != nil {
+			return i, err
+		}
+	}
+
+	logger.GlobalSugared().Infof("Successfully deleted %d objects under %s from bucket %s", len(keys), prefix, bucket)
+	return len(keys), nil
+}
+
+func (s *LocalStorage) GenerateUploadPublicUri(key string, bucket string, expirationSeconds uint32, metadata map[string]string) (string, error) {
+	if _, _, err := s.paths(key, bucket); err != nil {
+		return "", err
+	}
+
+	expires := time.Now().Add(time.Duration(expirationSeconds) * time.Second).Unix()
+	signature := s.sign("PUT", bucket, key, expires, metadata)
+
+	logger.GlobalSugared().Infof("Generated upload URL for %s in bucket %s, expires in %d seconds", key, bucket, expirationSeconds)
+	return s.presignedURL(expires, signature, bucket, key), nil
+}
+
+func (s *LocalStorage) GenerateDownloadPublicUri(key string, bucket string, expirationSeconds uint32) (string, error) {
+	if _, _, err := s.paths(key, bucket); err != nil {
+		return "", err
+	}
+
+	expires := time.Now().Add(time.Duration(expirationSeconds) * time.Second).Unix()
+	signature := s.sign("GET", bucket, key, expires, nil)
+
+	return s.presignedURL(expires, signature, bucket, key), nil
+}
+
+// AuthorizeUpload checks that an upload of key to bucket with metadata was presigned
+func (s *LocalStorage) AuthorizeUpload(bucket string, key string, expires string, signature string, metadata map[string]string) error {
+	return s.authorize("PUT", bucket, key, expires, signature, metadata)
+}
+
+// AuthorizeDownload checks that a download of key from bucket was presigned
+func (s *LocalStorage) AuthorizeDownload(bucket string, key string, expires string, signature string) error {
+	// A bucket is never downloaded as a whole
+	if key == "" {
+		return ErrInvalidSignature
+	}
+	return s.authorize("GET", bucket, key, expires, signature, nil)
+}
+
+func (s *LocalStorage) authorize(method string, bucket string, scope string, expires string, signature string, metadata map[string]string) error {
+	expiresAt, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil {
+		return ErrInvalidSignature
+	}
+
+	expected := s.sign(method, bucket, scope, expiresAt, metadata)
+	if !hmac.Equal([]byte(signature), []byte(expected)) {
+		return ErrInvalidSignature
+	}
+	if time.Now().Unix() > expiresAt {
+		return ErrURLExpired
+	}
+	return nil
+}
+
+// sign returns the signature of a request for scope in bucket, valid until expires
+func (s *LocalStorage) sign(method string, bucket string, scope string, expires int64, metadata map[string]string) string {
+	mac := hmac.New(sha256.New, s.signingKey)
+	fmt.Fprintf(mac, "%s\n%s\n%s\n%d\n", method, bucket, scope, expires)
+
+	names := make([]string, 0, len(metadata))
+	for name := range metadata {
+		names = append(names, strings.ToLower(name))
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		fmt.Fprintf(mac, "%s:%s\n", name, metadataValue(metadata, name))
+	}
+
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func (s *LocalStorage) presignedURL(expires int64, signature string, bucket string, key string) string {
+	return fmt.Sprintf("%s/%d/%s/%s/%s", s.baseURL, expires, signature, url.PathEscape(bucket), escapeKey(key))
+}
+
+// paths returns where key in bucket and its metadata are stored, making sure both stay
+// inside the storage directory
+func (s *LocalStorage) paths(key string, bucket string) (string, string, error) {
+	if bucket == "" || strings.HasPrefix(bucket, ".") || strings.ContainsAny(bucket, `/\`) {
+		return "", "", fmt.Errorf("invalid bucket name: %q", bucket)
+	}
+
+	cleaned := path.Clean("/" + key)
+	if key != "" && (cleaned == "/" || cleaned[1:] != key || strings.Contains(key, `\`)) {
+		return "", "", fmt.Errorf("invalid object key: %q", key)
+	}
+
+	objectPath := filepath.Join(s.dir, bucket, filepath.FromSlash(key))
+	metadataPath := filepath.Join(s.dir, localMetadataDir, bucket, filepath.FromSlash(key)+".json")
+	return objectPath, metadataPath, nil
+}
+
+// metadataValue returns the value of the metadata named name, whatever its case
+func metadataValue(metadata map[string]string, name string) string {
+	for k, v := range metadata {
+		if strings.EqualFold(k, name) {
+			return v
+		}
+	}
+	return ""
+}
+
+// escapeKey escapes every segment of key for use in a URL path
+func escapeKey(key string) string {
+	segments := strings.Split(key, "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	return strings.Join(segments, "/")
+}
+
+// writeFileAtomic writes the content of r to name through a temporary file, so that the
+// file is never seen partially written. It returns the number of bytes written.
+func writeFileAtomic(name string, r io.Reader) (int64, error) {
+	if err := os.MkdirAll(filepath.Dir(name), 0755); err != nil {
+		return 0, err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(name), localTempPattern)
+	if err != nil {
+		return 0, err
+	}
+	defer os.Remove(tmp.Name())
+
+	written, err := io.Copy(tmp, r)
+	if err == nil {
+		err = tmp.Sync()
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return written, err
+	}
+
+	return written, os.Rename(tmp.Name(), name)
+}
@@ -0,0 +1,277 @@
+package s3
+
+import (
+	"bytes"
+	"crypto/md5"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sweetloveinyourheart/sweet-reel/pkg/logger"
+)
+
+const (
+	// localMultipartDir holds the parts of the multipart uploads in progress, one directory per upload
+	localMultipartDir = ".multipart"
+
+	// localUploadInfoFile describes the upload in its directory, next to the parts
+	localUploadInfoFile = "upload.json"
+
+	// localETagSuffix names the file holding the ETag of a part
+	localETagSuffix = ".etag"
+)
+
+// localUploadInfo is stored in the directory of every multipart upload
+type localUploadInfo struct {
+	Key         string            `json:"key"`
+	ContentType string            `json:"content_type"`
+	Metadata    map[string]string `json:"metadata,omitempty"`
+	Initiated   time.Time         `json:"initiated"`
+}
+
+func (s *LocalStorage) CreateMultipartUpload(key string, bucket string, mimeType string, metadata map[string]string) (string, error) {
+	if _, _, err := s.paths(key, bucket); err != nil {
+		return "", err
+	}
+
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return "", err
+	}
+	uploadID := hex.EncodeToString(id)
+
+	info, err := json.Marshal(localUploadInfo{
+		Key:         key,
+		ContentType: mimeType,
+		Metadata:    metadata,
+		Initiated:   time.Now().UTC(),
+	})
+	if err != nil {
+		return "", err
+	}
+	if _, err := writeFileAtomic(filepath.Join(s.uploadDir(bucket, uploadID), localUploadInfoFile), bytes.NewReader(info)); err != nil {
+		logger.GlobalSugared().Errorf("Failed to create multipart upload of %s to bucket %s: %v", key, bucket, err)
+		return "", err
+	}
+
+	logger.GlobalSugared().Infof("Created multipart upload %s of %s to bucket %s", uploadID, key, bucket)
+	return uploadID, nil
+}
+
+func (s *LocalStorage) GenerateUploadPartPublicUri(key string, bucket string, uploadID string, partNumber int32, expirationSeconds uint32) (string, error) {
+	if _, err := s.uploadInfo(key, bucket, uploadID); err != nil {
+		return "", err
+	}
+
+	expires := time.Now().Add(time.Duration(expirationSeconds) * time.Second).Unix()
+	partParam := strconv.Itoa(int(partNumber))
+	signature := s.sign("UPLOAD_PART", bucket, key, expires, partParams(uploadID, partParam))
+
+	query := url.Values{"uploadId": {uploadID}, "partNumber": {partParam}}
+	return s.presignedURL(expires, signature, bucket, key) + "?" + query.Encode(), nil
+}
+
+// AuthorizeUploadPart checks that an upload of a part of the multipart upload uploadID was presigned
+func (s *LocalStorage) AuthorizeUploadPart(bucket string, key string, expires string, signature string, uploadID string, partNumber string) error {
+	return s.authorize("UPLOAD_PART", bucket, key, expires, signature, partParams(uploadID, partNumber))
+}
+
+// PutPart stores the content of file as a part of the multipart upload uploadID and returns its ETag.
+// A part uploaded again replaces the previous one.
+func (s *LocalStorage) PutPart(key string, bucket string, uploadID string, partNumber int32, file io.Reader) (string, error) {
+	if partNumber < 1 || partNumber > MaxMultipartParts {
+		return "", fmt.Errorf("invalid part number: %d", partNumber)
+	}
+	if _, err := s.uploadInfo(key, bucket, uploadID); err != nil {
+		return "", err
+	}
+
+	partPath := filepath.Join(s.uploadDir(bucket, uploadID), strconv.Itoa(int(partNumber)))
+
+	digest := md5.New()
+	if _, err := writeFileAtomic(partPath, io.TeeReader(file, digest)); err != nil {
+		logger.GlobalSugared().Errorf("Failed to store part %d of upload %s in bucket %s: %v", partNumber, uploadID, bucket, err)
+		return "", err
+	}
+
+	etag := fmt.Sprintf("%q", hex.EncodeToString(digest.Sum(nil)))
+	if _, err := writeFileAtomic(partPath+localETagSuffix, strings.NewReader(etag)); err != nil {
+		return "", err
+	}
+
+	return etag, nil
+}
+
+func (s *LocalStorage) ListUploadedParts(key string, bucket string, uploadID string) ([]UploadedPart, error) {
+	if _, err := s.uploadInfo(key, bucket, uploadID); err != nil {
+		return nil, err
+	}
+
+	dir := s.uploadDir(bucket, uploadID)
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var parts []UploadedPart
+	for _, entry := range entries {
+		partNumber, err := strconv.ParseInt(entry.Name(), 10, 32)
+		if err != nil || entry.IsDir() {
+			continue
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			return nil, err
+		}
+
+		// The ETag is written right after the part, it may be missing while the part is stored
+		etag, err := os.ReadFile(filepath.Join(dir, entry.Name()+localETagSuffix))
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return nil, err
+		}
+
+		parts = append(parts, UploadedPart{
+			PartNumber: int32(partNumber),
+			Size:       info.Size(),
+			ETag:       string(etag),
+		})
+	}
+
+	slices.SortFunc(parts, func(a, b UploadedPart) int {
+		return int(a.PartNumber - b.PartNumber)
+	})
+	return parts, nil
+}
+
+func (s *LocalStorage) CompleteMultipartUpload(key string, bucket string, uploadID string) error {
+	info, err := s.uploadInfo(key, bucket, uploadID)
+	if err != nil {
+		return err
+	}
+
+	parts, err := s.ListUploadedParts(key, bucket, uploadID)
+	if err != nil {
+		return err
+	}
+	if len(parts) == 0 {
+		return fmt.Errorf("multipart upload %s has no part", uploadID)
+	}
+
+	dir := s.uploadDir(bucket, uploadID)
+	readers := make([]io.Reader, 0, len(parts))
+	for _, part := range parts {
+		file, err := os.Open(filepath.Join(dir, strconv.Itoa(int(part.PartNumber))))
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		readers = append(readers, file)
+	}
+
+	if err := s.PutObject(key, bucket, io.MultiReader(readers...), info.ContentType, info.Metadata); err != nil {
+		return err
+	}
+
+	logger.GlobalSugared().Infof("Completed upload %s of %s to bucket %s (%d parts)", uploadID, key, bucket, len(parts))
+	return os.RemoveAll(dir)
+}
+
+func (s *LocalStorage) AbortMultipartUpload(key string, bucket string, uploadID string) error {
+	if _, err := s.uploadInfo(key, bucket, uploadID); err != nil {
+		if errors.Is(err, ErrUploadNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	if err := os.RemoveAll(s.uploadDir(bucket, uploadID)); err != nil {
+		logger.GlobalSugared().Errorf("Failed to abort upload %s of %s to bucket %s: %v", uploadID, key, bucket, err)
+		return err
+	}
+
+	logger.GlobalSugared().Infof("Aborted upload %s of %s to bucket %s", uploadID, key, bucket)
+	return nil
+}
+
+func (s *LocalStorage) AbortMultipartUploadsBefore(bucket string, before time.Time) (int, error) {
+	if _, _, err := s.paths("", bucket); err != nil {
+		return 0, err
+	}
+
+	entries, err := os.ReadDir(filepath.Join(s.dir, localMultipartDir, bucket))
+	if errors.Is(err, fs.ErrNotExist) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	aborted := 0
+	for _, entry := range entries {
+		data, err := os.ReadFile(filepath.Join(s.uploadDir(bucket, entry.Name()), localUploadInfoFile))
+		if err != nil {
+			continue
+		}
+
+		var info localUploadInfo
+		if err := json.Unmarshal(data, &info); err != nil || !info.Initiated.Before(before) {
+			continue
+		}
+
+		if err := s.AbortMultipartUpload(info.Key, bucket, entry.Name()); err != nil {
+			return aborted, err
+		}
+		aborted++
+	}
+
+	return aborted, nil
+}
+
+// uploadInfo returns the description of the multipart upload uploadID of key
+func (s *LocalStorage) uploadInfo(key string, bucket string, uploadID string) (*localUploadInfo, error) {
+	if _, _, err := s.paths(key, bucket); err != nil {
+		return nil, err
+	}
+	if _, err := hex.DecodeString(uploadID); err != nil || uploadID == "" {
+		return nil, fmt.Errorf("%w: %s", ErrUploadNotFound, uploadID)
+	}
+
+	data, err := os.ReadFile(filepath.Join(s.uploadDir(bucket, uploadID), localUploadInfoFile))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("%w: %s", ErrUploadNotFound, uploadID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var info localUploadInfo
+	if err := json.Unmarshal(data, &info); err != nil {
+		return nil, err
+	}
+	if info.Key != key {
+		return nil, fmt.Errorf("%w: %s", ErrUploadNotFound, uploadID)
+	}
+
+	return &info, nil
+}
+
+func (s *LocalStorage) uploadDir(bucket string, uploadID string) string {
+	return filepath.Join(s.dir, localMultipartDir, bucket, uploadID)
+}
+
+// partParams returns the request parameters the signature of a part upload covers
+func partParams(uploadID string, partNumber string) map[string]string {
+	return map[string]string{"uploadid": uploadID, "partnumber": partNumber}
+}
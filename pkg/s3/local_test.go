@@ -0,0 +1,217 @@
+package s3
+
+import (
+	"bytes"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sweetloveinyourheart/sweet-reel/pkg/messages"
+)
+
+func newTestLocalStorage(t *testing.T) *LocalStorage {
+	storage, err := NewLocalStorage(LocalConfig{
+		Dir:        t.TempDir(),
+		BaseURL:    "http://localhost:8080/storage/",
+		SigningKey: "signing-key",
+	})
+	require.NoError(t, err)
+	return storage
+}
+
+// parsePresignedURL splits a presigned URL into its expiry, signature, bucket and key
+func parsePresignedURL(t *testing.T, uri string) (string, string, string, string) {
+	u, err := url.Parse(uri)
+	require.NoError(t, err)
+
+	path, ok := strings.CutPrefix(u.Path, "/storage/")
+	require.True(t, ok, uri)
+
+	parts := strings.SplitN(path, "/", 4)
+	require.Len(t, parts, 4, uri)
+	return parts[0], parts[1], parts[2], parts[3]
+}
+
+func TestLocalStoragePresignedUpload(t *testing.T) {
+	storage := newTestLocalStorage(t)
+	metadata := map[string]string{"traceparent": "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"}
+
+	uri, err := storage.GenerateUploadPublicUri("channel/video.mp4", S3VideoUploadedBucket, UrlExpirationSeconds, metadata)
+	require.NoError(t, err)
+
+	expires, signature, bucket, key := parsePresignedURL(t, uri)
+	require.Equal(t, S3VideoUploadedBucket, bucket)
+	require.Equal(t, "channel/video.mp4", key)
+
+	require.NoError(t, storage.AuthorizeUpload(bucket, key, expires, signature, metadata))
+
+	// The signature covers the key, the metadata and the expiry
+	require.ErrorIs(t, storage.AuthorizeUpload(bucket, "channel/other.mp4", expires, signature, metadata), ErrInvalidSignature)
+	require.ErrorIs(t, storage.AuthorizeUpload(bucket, key, expires, signature, nil), ErrInvalidSignature)
+	require.ErrorIs(t, storage.AuthorizeUpload(bucket, key, expires+"0", signature, metadata), ErrInvalidSignature)
+
+	// An upload URL does not allow downloads
+	require.ErrorIs(t, storage.AuthorizeDownload(bucket, key, expires, signature), ErrInvalidSignature)
+}
+
+func TestLocalStoragePresignedDownload(t *testing.T) {
+	storage := newTestLocalStorage(t)
+
+	uri, err := storage.GenerateDownloadPublicUri("video/master.m3u8", S3VideoProcessedBucket, UrlExpirationSeconds)
+	require.NoError(t, err)
+
+	expires, signature, bucket, key := parsePresignedURL(t, uri)
+	require.NoError(t, storage.AuthorizeDownload(bucket, key, expires, signature))
+
+	// The signature covers the object only, not the other objects of its directory
+	require.ErrorIs(t, storage.AuthorizeDownload(bucket, "video/720p/segment_000.ts", expires, signature), ErrInvalidSignature)
+	require.ErrorIs(t, storage.AuthorizeDownload(bucket, "video/other.m3u8", expires, signature), ErrInvalidSignature)
+	require.ErrorIs(t, storage.AuthorizeDownload(bucket, "other/master.m3u8", expires, signature), ErrInvalidSignature)
+	require.ErrorIs(t, storage.AuthorizeDownload(S3VideoUploadedBucket, key, expires, signature), ErrInvalidSignature)
+}
+
+func TestLocalStorageBucketDownload(t *testing.T) {
+	storage := newTestLocalStorage(t)
+
+	expires := time.Now().Add(time.Hour).Unix()
+	signature := storage.sign("GET", S3VideoProcessedBucket, "", expires, nil)
+	require.ErrorIs(t, storage.AuthorizeDownload(S3VideoProcessedBucket, "", strconv.FormatInt(expires, 10), signature), ErrInvalidSignature)
+}
+
+func TestLocalStorageExpiredURL(t *testing.T) {
+	storage := newTestLocalStorage(t)
+
+	signature := storage.sign("GET", S3VideoProcessedBucket, "video/master.m3u8", 1, nil)
+	require.ErrorIs(t, storage.AuthorizeDownload(S3VideoProcessedBucket, "video/master.m3u8", "1", signature), ErrURLExpired)
+}
+
+func TestLocalStorageObjects(t *testing.T) {
+	storage := newTestLocalStorage(t)
+
+	var events []messages.S3EventMessage
+	storage.OnObjectCreated(S3VideoUploadedBucket, func(event messages.S3EventMessage) {
+		events = append(events, event)
+	})
+
+	metadata := map[string]string{"traceparent": "trace"}
+	require.NoError(t, storage.PutObject("channel/video.mp4", S3VideoUploadedBucket, bytes.NewReader([]byte("video")), "video/mp4", metadata))
+	require.NoError(t, storage.Upload("video/master.m3u8", S3VideoProcessedBucket, bytes.NewReader([]byte("#EXTM3U")), "application/vnd.apple.mpegurl"))
+
+	// Only uploads to the watched bucket are notified, the way MinIO reports them
+	require.Len(t, events, 1)
+	require.Equal(t, S3VideoUploadedBucket+"/channel/video.mp4", events[0].Key)
+	record := events[0].Records[0].S3
+	require.Equal(t, "channel%2Fvideo.mp4", record.Object.Key)
+	require.Equal(t, int64(5), record.Object.Size)
+	require.Equal(t, "trace", record.Object.UserMetadata["X-Amz-Meta-Traceparent"])
+
+	file, contentType, err := storage.OpenObject("channel/video.mp4", S3VideoUploadedBucket)
+	require.NoError(t, err)
+	require.NoError(t, file.Close())
+	require.Equal(t, "video/mp4", contentType)
+
+	data, err := storage.Download("video/master.m3u8", S3VideoProcessedBucket)
+	require.NoError(t, err)
+	require.Equal(t, "#EXTM3U", string(data))
+
+	_, err = storage.Download("video/missing.m3u8", S3VideoProcessedBucket)
+	require.ErrorIs(t, err, ErrObjectNotFound)
+
+	deleted, err := storage.DeletePrefix("channel/", S3VideoUploadedBucket)
+	require.NoError(t, err)
+	require.Equal(t, 1, deleted)
+
+	_, err = storage.Download("channel/video.mp4", S3VideoUploadedBucket)
+	require.ErrorIs(t, err, ErrObjectNotFound)
+}
+
+func TestLocalStorageRejectsEscapingKeys(t *testing.T) {
+	storage := newTestLocalStorage(t)
+
+	for _, key := range []string{"../video.mp4", "video/../../video.mp4", "/video.mp4", "video//master.m3u8", `video\master.m3u8`} {
+		require.Error(t, storage.Upload(key, S3VideoUploadedBucket, bytes.NewReader(nil), "video/mp4"), key)
+	}
+	require.Error(t, storage.Upload("video.mp4", "../bucket", bytes.NewReader(nil), "video/mp4"))
+	require.Error(t, storage.Upload("video.mp4", localMetadataDir, bytes.NewReader(nil), "video/mp4"))
+}
+
+func TestLocalStorageMultipartUpload(t *testing.T) {
+	storage := newTestLocalStorage(t)
+
+	var events []messages.S3EventMessage
+	storage.OnObjectCreated(S3VideoUploadedBucket, func(event messages.S3EventMessage) {
+		events = append(events, event)
+	})
+
+	metadata := map[string]string{"traceparent": "trace"}
+	uploadID, err := storage.CreateMultipartUpload("channel/video.mp4", S3VideoUploadedBucket, "video/mp4", metadata)
+	require.NoError(t, err)
+
+	uri, err := storage.GenerateUploadPartPublicUri("channel/video.mp4", S3VideoUploadedBucket, uploadID, 2, UrlExpirationSeconds)
+	require.NoError(t, err)
+
+	u, err := url.Parse(uri)
+	require.NoError(t, err)
+	require.Equal(t, uploadID, u.Query().Get("uploadId"))
+	require.Equal(t, "2", u.Query().Get("partNumber"))
+
+	expires, signature, bucket, key := parsePresignedURL(t, uri)
+	require.NoError(t, storage.AuthorizeUploadPart(bucket, key, expires, signature, uploadID, "2"))
+
+	// A part URL is valid for its part only, and does not allow whole uploads
+	require.ErrorIs(t, storage.AuthorizeUploadPart(bucket, key, expires, signature, uploadID, "1"), ErrInvalidSignature)
+	require.ErrorIs(t, storage.AuthorizeUpload(bucket, key, expires, signature, nil), ErrInvalidSignature)
+
+	// Parts are uploaded in any order, and again after a failure
+	_, err = storage.PutPart(key, bucket, uploadID, 2, strings.NewReader("lost"))
+	require.NoError(t, err)
+	_, err = storage.PutPart(key, bucket, uploadID, 2, strings.NewReader("world"))
+	require.NoError(t, err)
+	etag, err := storage.PutPart(key, bucket, uploadID, 1, strings.NewReader("hello "))
+	require.NoError(t, err)
+
+	parts, err := storage.ListUploadedParts(key, bucket, uploadID)
+	require.NoError(t, err)
+	require.Equal(t, []UploadedPart{
+		{PartNumber: 1, Size: 6, ETag: etag},
+		{PartNumber: 2, Size: 5, ETag: parts[1].ETag},
+	}, parts)
+
+	require.NoError(t, storage.CompleteMultipartUpload(key, bucket, uploadID))
+
+	data, err := storage.Download(key, bucket)
+	require.NoError(t, err)
+	require.Equal(t, "hello world", string(data))
+
+	require.Len(t, events, 1)
+	require.Equal(t, "trace", events[0].Records[0].S3.Object.UserMetadata["X-Amz-Meta-Traceparent"])
+
+	// The upload is gone once completed
+	_, err = storage.ListUploadedParts(key, bucket, uploadID)
+	require.ErrorIs(t, err, ErrUploadNotFound)
+	require.NoError(t, storage.AbortMultipartUpload(key, bucket, uploadID))
+}
+
+func TestLocalStorageAbortMultipartUploads(t *testing.T) {
+	storage := newTestLocalStorage(t)
+
+	uploadID, err := storage.CreateMultipartUpload("channel/video.mp4", S3VideoUploadedBucket, "video/mp4", nil)
+	require.NoError(t, err)
+	_, err = storage.PutPart("channel/video.mp4", S3VideoUploadedBucket, uploadID, 1, strings.NewReader("part"))
+	require.NoError(t, err)
+
+	aborted, err := storage.AbortMultipartUploadsBefore(S3VideoUploadedBucket, time.Now().Add(-time.Hour))
+	require.NoError(t, err)
+	require.Zero(t, aborted)
+
+	aborted, err = storage.AbortMultipartUploadsBefore(S3VideoUploadedBucket, time.Now().Add(time.Minute))
+	require.NoError(t, err)
+	require.Equal(t, 1, aborted)
+
+	_, err = storage.ListUploadedParts("channel/video.mp4", S3VideoUploadedBucket, uploadID)
+	require.ErrorIs(t, err, ErrUploadNotFound)
+}
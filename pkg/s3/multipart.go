@@ -0,0 +1,63 @@
+package s3
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	// MinMultipartPartSize is the smallest size of every part of a multipart upload but the last one
+	MinMultipartPartSize int64 = 5 << 20
+	// DefaultMultipartPartSize is the size of the parts of uploads small enough to need fewer than MaxMultipartParts
+	DefaultMultipartPartSize int64 = 16 << 20
+	// MaxMultipartParts is the number of parts a multipart upload can have at most
+	MaxMultipartParts = 10000
+	// MaxMultipartObjectSize is the size of the largest object a multipart upload can create
+	MaxMultipartObjectSize int64 = 5 << 40
+)
+
+var ErrUploadNotFound = errors.New("multipart upload not found")
+
+// UploadedPart is a part of a multipart upload that has been uploaded
+type UploadedPart struct {
+	PartNumber int32
+	Size       int64
+	ETag       string
+}
+
+// S3MultipartStorage uploads objects in parts, each sent to its own presigned URL. Failed
+// parts are sent again on their own, so large uploads resume where they stopped.
+type S3MultipartStorage interface {
+	// CreateMultipartUpload starts an upload of key and returns its id. The object is stored
+	// with mimeType and metadata once the upload completes.
+	CreateMultipartUpload(key string, bucket string, mimeType string, metadata map[string]string) (string, error)
+	GenerateUploadPartPublicUri(key string, bucket string, uploadID string, partNumber int32, expirationSeconds uint32) (string, error)
+	// ListUploadedParts returns the parts uploaded so far, ordered by part number
+	ListUploadedParts(key string, bucket string, uploadID string) ([]UploadedPart, error)
+	// CompleteMultipartUpload assembles every uploaded part into the object
+	CompleteMultipartUpload(key string, bucket string, uploadID string) error
+	// AbortMultipartUpload discards the uploaded parts. Aborting an upload that no longer
+	// exists succeeds.
+	AbortMultipartUpload(key string, bucket string, uploadID string) error
+	// AbortMultipartUploadsBefore aborts every upload to bucket started before the given time
+	// and returns how many were aborted
+	AbortMultipartUploadsBefore(bucket string, before time.Time) (int, error)
+}
+
+// MultipartPartSize returns the size of the parts a file of fileSize bytes is uploaded in,
+// in whole MiB, so that it takes at most MaxMultipartParts parts
+func MultipartPartSize(fileSize int64) int64 {
+	partSize := DefaultMultipartPartSize
+	if minPartSize := (fileSize + MaxMultipartParts - 1) / MaxMultipartParts; minPartSize > partSize {
+		partSize = (minPartSize + 1<<20 - 1) / (1 << 20) * (1 << 20)
+	}
+	return partSize
+}
+
+// MultipartPartCount returns how many parts of partSize bytes a file of fileSize bytes is uploaded in
+func MultipartPartCount(fileSize int64, partSize int64) int32 {
+	if fileSize <= 0 {
+		return 1
+	}
+	return int32((fileSize + partSize - 1) / partSize)
+}
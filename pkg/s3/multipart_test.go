@@ -0,0 +1,23 @@
+package s3
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMultipartPartSize(t *testing.T) {
+	require.Equal(t, DefaultMultipartPartSize, MultipartPartSize(1))
+	require.Equal(t, DefaultMultipartPartSize, MultipartPartSize(DefaultMultipartPartSize*MaxMultipartParts))
+
+	// Larger files take larger parts, rounded up to whole MiB, to stay within MaxMultipartParts
+	partSize := MultipartPartSize(MaxMultipartObjectSize)
+	require.Zero(t, partSize%(1<<20))
+	require.LessOrEqual(t, MultipartPartCount(MaxMultipartObjectSize, partSize), int32(MaxMultipartParts))
+}
+
+func TestMultipartPartCount(t *testing.T) {
+	require.Equal(t, int32(1), MultipartPartCount(0, DefaultMultipartPartSize))
+	require.Equal(t, int32(1), MultipartPartCount(DefaultMultipartPartSize, DefaultMultipartPartSize))
+	require.Equal(t, int32(2), MultipartPartCount(DefaultMultipartPartSize+1, DefaultMultipartPartSize))
+}
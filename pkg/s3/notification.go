@@ -0,0 +1,48 @@
+package s3
+
+import (
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/sweetloveinyourheart/sweet-reel/pkg/messages"
+)
+
+// ObjectCreatedEventName is the MinIO event name of an object being uploaded
+const ObjectCreatedEventName = "s3:ObjectCreated:Put"
+
+// NewObjectCreatedEvent builds the notification MinIO sends when key is uploaded to bucket,
+// for storages that notify uploads themselves
+func NewObjectCreatedEvent(key string, bucket string, size int64, mimeType string, metadata map[string]string) messages.S3EventMessage {
+	// MinIO reports the user metadata under its header name, next to the content type
+	userMetadata := map[string]string{"content-type": mimeType}
+	for name, value := range MetadataHeaders(metadata) {
+		userMetadata[http.CanonicalHeaderKey(name)] = value
+	}
+
+	return messages.S3EventMessage{
+		EventName: ObjectCreatedEventName,
+		Key:       bucket + "/" + key,
+		Records: []messages.S3Record{
+			{
+				EventVersion: "2.0",
+				EventSource:  "minio:s3",
+				EventTime:    time.Now().UTC(),
+				EventName:    ObjectCreatedEventName,
+				S3: messages.S3Details{
+					S3SchemaVersion: "1.0",
+					Bucket: messages.S3Bucket{
+						Name: bucket,
+						ARN:  "arn:aws:s3:::" + bucket,
+					},
+					Object: messages.S3Object{
+						Key:          url.PathEscape(key),
+						Size:         size,
+						ContentType:  mimeType,
+						UserMetadata: userMetadata,
+					},
+				},
+			},
+		},
+	}
+}
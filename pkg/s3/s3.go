@@ -3,12 +3,17 @@ package s3
 import (
 	"bytes"
 	"context"
+	"errors"
+	"fmt"
 	"io"
+	"os"
+	"path/filepath"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
 	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/aws/smithy-go"
 
 	"github.com/sweetloveinyourheart/sweet-reel/pkg/logger"
 )
@@ -18,11 +23,27 @@ const UrlExpirationSeconds = 600
 type S3Storage interface {
 	Download(key string, bucket string) ([]byte, error)
 	Upload(key string, bucket string, file io.Reader, mimeType string) error
-	GenerateUploadPublicUri(key string, bucket string, expirationSeconds uint32) (string, error)
+	// GenerateUploadPublicUri presigns an upload of key. The object is stored with metadata,
+	// which the upload must send as the headers returned by MetadataHeaders.
+	GenerateUploadPublicUri(key string, bucket string, expirationSeconds uint32, metadata map[string]string) (string, error)
 	GenerateDownloadPublicUri(key string, bucket string, expirationSeconds uint32) (string, error)
 	Delete(key string, bucket string) error
+	DeletePrefix(prefix string, bucket string) (int, error)
+	S3MultipartStorage
 }
 
+// S3StreamStorage is the streaming variant of S3Storage. Objects are moved between
+// storage and local files through readers and writers, so memory usage stays bounded
+// regardless of the object size.
+type S3StreamStorage interface {
+	S3Storage
+	DownloadStream(key string, bucket string) (io.ReadCloser, error)
+	DownloadToFile(key string, bucket string, filePath string) (int64, error)
+	UploadFile(key string, bucket string, filePath string, mimeType string) error
+}
+
+var _ S3StreamStorage = (*s3Client)(nil)
+
 type s3Client struct {
 	client *s3.Client
 }
@@ -52,12 +73,12 @@ func CreateS3Client(ctx context.Context, config *Config) (*s3Client, error) {
 func (s s3Client) Upload(key string, bucket string, file io.Reader, mimeType string) error {
 	ctx := context.Background()
 
+	// Objects are private, they are read through presigned urls
 	input := &s3.PutObjectInput{
 		Bucket:      aws.String(bucket),
 		Key:         aws.String(key),
 		Body:        file,
 		ContentType: aws.String(mimeType),
-		ACL:         types.ObjectCannedACLPublicRead, // Make object publicly readable
 	}
 
 	_, err := s.client.PutObject(ctx, input)
@@ -97,6 +118,89 @@ func (s s3Client) Download(key string, bucket string) ([]byte, error) {
 	return buf.Bytes(), nil
 }
 
+func (s s3Client) DownloadStream(key string, bucket string) (io.ReadCloser, error) {
+	ctx := context.Background()
+
+	input := &s3.GetObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+	}
+
+	result, err := s.client.GetObject(ctx, input)
+	if err != nil {
+		logger.GlobalSugared().Errorf("Failed to download object %s from bucket %s: %v", key, bucket, err)
+		return nil, err
+	}
+
+	// The caller owns the body and must close it
+	return result.Body, nil
+}
+
+func (s s3Client) DownloadToFile(key string, bucket string, filePath string) (int64, error) {
+	body, err := s.DownloadStream(key, bucket)
+	if err != nil {
+		return 0, err
+	}
+	defer body.Close()
+
+	if err := os.MkdirAll(filepath.Dir(filePath), 0755); err != nil {
+		return 0, err
+	}
+
+	file, err := os.Create(filePath)
+	if err != nil {
+		return 0, err
+	}
+	defer file.Close()
+
+	// Copy in fixed-size chunks straight to disk
+	written, err := io.Copy(file, body)
+	if err != nil {
+		logger.GlobalSugared().Errorf("Failed to write object %s from bucket %s to %s: %v", key, bucket, filePath, err)
+		return written, err
+	}
+
+	if err := file.Sync(); err != nil {
+		return written, err
+	}
+
+	logger.GlobalSugared().Infof("Successfully downloaded %s from bucket %s to %s (%d bytes)", key, bucket, filePath, written)
+	return written, nil
+}
+
+func (s s3Client) UploadFile(key string, bucket string, filePath string, mimeType string) error {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+
+	// An *os.File is seekable, so the SDK can sign the payload without buffering it
+	input := &s3.PutObjectInput{
+		Bucket:        aws.String(bucket),
+		Key:           aws.String(key),
+		Body:          file,
+		ContentLength: aws.Int64(info.Size()),
+		ContentType:   aws.String(mimeType),
+	}
+
+	_, err = s.client.PutObject(ctx, input)
+	if err != nil {
+		logger.GlobalSugared().Errorf("Failed to upload file %s as %s to bucket %s: %v", filePath, key, bucket, err)
+		return err
+	}
+
+	logger.GlobalSugared().Infof("Successfully uploaded %s to bucket %s", key, bucket)
+	return nil
+}
+
 func (s s3Client) Delete(key string, bucket string) error {
 	ctx := context.Background()
 
@@ -115,13 +219,65 @@ func (s s3Client) Delete(key string, bucket string) error {
 	return nil
 }
 
-func (s s3Client) GenerateUploadPublicUri(key string, bucket string, expirationSeconds uint32) (string, error) {
+// DeletePrefix deletes every object whose key starts with prefix and returns how many were deleted
+func (s s3Client) DeletePrefix(prefix string, bucket string) (int, error) {
 	ctx := context.Background()
 
-	input := &s3.PutObjectInput{
+	paginator := s3.NewListObjectsV2Paginator(s.client, &s3.ListObjectsV2Input{
 		Bucket: aws.String(bucket),
-		Key:    aws.String(key),
-		ACL:    types.ObjectCannedACLPublicRead,
+		Prefix: aws.String(prefix),
+	})
+
+	deleted := 0
+	for paginator.HasMorePages() {
+		// A page holds at most 1000 keys, which is also the DeleteObjects limit
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			logger.GlobalSugared().Errorf("Failed to list objects under %s in bucket %s: %v", prefix, bucket, err)
+			return deleted, err
+		}
+
+		if len(page.Contents) == 0 {
+			continue
+		}
+
+		objects := make([]types.ObjectIdentifier, 0, len(page.Contents))
+		for _, object := range page.Contents {
+			objects = append(objects, types.ObjectIdentifier{Key: object.Key})
+		}
+
+		result, err := s.client.DeleteObjects(ctx, &s3.DeleteObjectsInput{
+			Bucket: aws.String(bucket),
+			Delete: &types.Delete{
+				Objects: objects,
+				Quiet:   aws.Bool(true),
+			},
+		})
+		if err != nil {
+			logger.GlobalSugared().Errorf("Failed to delete objects under %s from bucket %s: %v", prefix, bucket, err)
+			return deleted, err
+		}
+
+		if len(result.Errors) > 0 {
+			failed := result.Errors[0]
+			logger.GlobalSugared().Errorf("Failed to delete %d objects under %s from bucket %s", len(result.Errors), prefix, bucket)
+			return deleted + len(objects) - len(result.Errors), fmt.Errorf("failed to delete %s: %s", aws.ToString(failed.Key), aws.ToString(failed.Message))
+		}
+
+		deleted += len(objects)
+	}
+
+	logger.GlobalSugared().Infof("Successfully deleted %d objects under %s from bucket %s", deleted, prefix, bucket)
+	return deleted, nil
+}
+
+func (s s3Client) GenerateUploadPublicUri(key string, bucket string, expirationSeconds uint32, metadata map[string]string) (string, error) {
+	ctx := context.Background()
+
+	input := &s3.PutObjectInput{
+		Bucket:   aws.String(bucket),
+		Key:      aws.String(key),
+		Metadata: metadata,
 	}
 
 	presignClient := s3.NewPresignClient(s.client)
@@ -159,3 +315,164 @@ func (s s3Client) GenerateDownloadPublicUri(key string, bucket string, expiratio
 	logger.GlobalSugared().Infof("Generated download URL for %s in bucket %s, expires in %d seconds", key, bucket, expirationSeconds)
 	return presignRequest.URL, nil
 }
+
+func (s s3Client) CreateMultipartUpload(key string, bucket string, mimeType string, metadata map[string]string) (string, error) {
+	ctx := context.Background()
+
+	input := &s3.CreateMultipartUploadInput{
+		Bucket:      aws.String(bucket),
+		Key:         aws.String(key),
+		ContentType: aws.String(mimeType),
+		Metadata:    metadata,
+	}
+
+	result, err := s.client.CreateMultipartUpload(ctx, input)
+	if err != nil {
+		logger.GlobalSugared().Errorf("Failed to create multipart upload of %s to bucket %s: %v", key, bucket, err)
+		return "", err
+	}
+
+	logger.GlobalSugared().Infof("Created multipart upload %s of %s to bucket %s", aws.ToString(result.UploadId), key, bucket)
+	return aws.ToString(result.UploadId), nil
+}
+
+func (s s3Client) GenerateUploadPartPublicUri(key string, bucket string, uploadID string, partNumber int32, expirationSeconds uint32) (string, error) {
+	ctx := context.Background()
+
+	input := &s3.UploadPartInput{
+		Bucket:     aws.String(bucket),
+		Key:        aws.String(key),
+		UploadId:   aws.String(uploadID),
+		PartNumber: aws.Int32(partNumber),
+	}
+
+	presignClient := s3.NewPresignClient(s.client)
+	presignRequest, err := presignClient.PresignUploadPart(ctx, input, func(opts *s3.PresignOptions) {
+		opts.Expires = time.Duration(expirationSeconds) * time.Second
+	})
+	if err != nil {
+		logger.GlobalSugared().Errorf("Failed to generate URL for part %d of upload %s in bucket %s: %v", partNumber, uploadID, bucket, err)
+		return "", err
+	}
+
+	return presignRequest.URL, nil
+}
+
+func (s s3Client) ListUploadedParts(key string, bucket string, uploadID string) ([]UploadedPart, error) {
+	ctx := context.Background()
+
+	paginator := s3.NewListPartsPaginator(s.client, &s3.ListPartsInput{
+		Bucket:   aws.String(bucket),
+		Key:      aws.String(key),
+		UploadId: aws.String(uploadID),
+	})
+
+	var parts []UploadedPart
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			if isNoSuchUpload(err) {
+				return nil, fmt.Errorf("%w: %s", ErrUploadNotFound, uploadID)
+			}
+			logger.GlobalSugared().Errorf("Failed to list parts of upload %s in bucket %s: %v", uploadID, bucket, err)
+			return nil, err
+		}
+
+		for _, part := range page.Parts {
+			parts = append(parts, UploadedPart{
+				PartNumber: aws.ToInt32(part.PartNumber),
+				Size:       aws.ToInt64(part.Size),
+				ETag:       aws.ToString(part.ETag),
+			})
+		}
+	}
+
+	return parts, nil
+}
+
+func (s s3Client) CompleteMultipartUpload(key string, bucket string, uploadID string) error {
+	ctx := context.Background()
+
+	parts, err := s.ListUploadedParts(key, bucket, uploadID)
+	if err != nil {
+		return err
+	}
+
+	completed := make([]types.CompletedPart, 0, len(parts))
+	for _, part := range parts {
+		completed = append(completed, types.CompletedPart{
+			PartNumber: aws.Int32(part.PartNumber),
+			ETag:       aws.String(part.ETag),
+		})
+	}
+
+	_, err = s.client.CompleteMultipartUpload(ctx, &s3.CompleteMultipartUploadInput{
+		Bucket:          aws.String(bucket),
+		Key:             aws.String(key),
+		UploadId:        aws.String(uploadID),
+		MultipartUpload: &types.CompletedMultipartUpload{Parts: completed},
+	})
+	if err != nil {
+		logger.GlobalSugared().Errorf("Failed to complete upload %s of %s to bucket %s: %v", uploadID, key, bucket, err)
+		return err
+	}
+
+	logger.GlobalSugared().Infof("Completed upload %s of %s to bucket %s (%d parts)", uploadID, key, bucket, len(parts))
+	return nil
+}
+
+func (s s3Client) AbortMultipartUpload(key string, bucket string, uploadID string) error {
+	ctx := context.Background()
+
+	_, err := s.client.AbortMultipartUpload(ctx, &s3.AbortMultipartUploadInput{
+		Bucket:   aws.String(bucket),
+		Key:      aws.String(key),
+		UploadId: aws.String(uploadID),
+	})
+	if err != nil {
+		if isNoSuchUpload(err) {
+			return nil
+		}
+		logger.GlobalSugared().Errorf("Failed to abort upload %s of %s to bucket %s: %v", uploadID, key, bucket, err)
+		return err
+	}
+
+	logger.GlobalSugared().Infof("Aborted upload %s of %s to bucket %s", uploadID, key, bucket)
+	return nil
+}
+
+func (s s3Client) AbortMultipartUploadsBefore(bucket string, before time.Time) (int, error) {
+	ctx := context.Background()
+
+	paginator := s3.NewListMultipartUploadsPaginator(s.client, &s3.ListMultipartUploadsInput{
+		Bucket: aws.String(bucket),
+	})
+
+	aborted := 0
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			logger.GlobalSugared().Errorf("Failed to list multipart uploads of bucket %s: %v", bucket, err)
+			return aborted, err
+		}
+
+		for _, upload := range page.Uploads {
+			if upload.Initiated == nil || !upload.Initiated.Before(before) {
+				continue
+			}
+			if err := s.AbortMultipartUpload(aws.ToString(upload.Key), bucket, aws.ToString(upload.UploadId)); err != nil {
+				return aborted, err
+			}
+			aborted++
+		}
+	}
+
+	return aborted, nil
+}
+
+// isNoSuchUpload tells whether err reports a multipart upload that does not exist. Only some
+// operations decode it as a types.NoSuchUpload.
+func isNoSuchUpload(err error) bool {
+	var apiErr smithy.APIError
+	return errors.As(err, &apiErr) && apiErr.ErrorCode() == "NoSuchUpload"
+}
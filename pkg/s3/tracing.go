@@ -0,0 +1,56 @@
+package s3
+
+import (
+	"context"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+// metadataHeaderPrefix prefixes the user metadata of an object in S3 requests and notifications
+const metadataHeaderPrefix = "x-amz-meta-"
+
+// TraceMetadata returns object metadata carrying the trace context of ctx, so that whatever
+// the object triggers once uploaded joins the trace. It is nil when ctx is not part of a trace.
+func TraceMetadata(ctx context.Context) map[string]string {
+	carrier := propagation.MapCarrier{}
+	otel.GetTextMapPropagator().Inject(ctx, carrier)
+	if len(carrier) == 0 {
+		return nil
+	}
+	return carrier
+}
+
+// MetadataHeaders returns the headers an upload to a presigned URL must send for the object
+// to be stored with metadata
+func MetadataHeaders(metadata map[string]string) map[string]string {
+	if len(metadata) == 0 {
+		return nil
+	}
+
+	headers := make(map[string]string, len(metadata))
+	for k, v := range metadata {
+		headers[metadataHeaderPrefix+k] = v
+	}
+	return headers
+}
+
+// ContextWithObjectTrace returns ctx continuing the trace recorded in the user metadata of
+// an object notification, as stored by TraceMetadata. ctx is returned unchanged when the
+// metadata carries no trace.
+func ContextWithObjectTrace(ctx context.Context, userMetadata map[string]string) context.Context {
+	// Notifications report the metadata under its header name, e.g. X-Amz-Meta-Traceparent
+	carrier := propagation.MapCarrier{}
+	for k, v := range userMetadata {
+		name := strings.ToLower(k)
+		if strings.HasPrefix(name, metadataHeaderPrefix) {
+			carrier[strings.TrimPrefix(name, metadataHeaderPrefix)] = v
+		}
+	}
+	if len(carrier) == 0 {
+		return ctx
+	}
+
+	return otel.GetTextMapPropagator().Extract(ctx, carrier)
+}
@@ -0,0 +1,48 @@
+package s3
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestObjectTraceRoundTrip(t *testing.T) {
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	spanCtx := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{0x4b, 0xf9, 0x2f, 0x35, 0x77, 0xb3, 0x4d, 0xa6, 0xa3, 0xce, 0x92, 0x9d, 0x0e, 0x0e, 0x47, 0x36},
+		SpanID:     trace.SpanID{0x00, 0xf0, 0x67, 0xaa, 0x0b, 0xa9, 0x02, 0xb7},
+		TraceFlags: trace.FlagsSampled,
+	})
+	ctx := trace.ContextWithSpanContext(context.Background(), spanCtx)
+
+	metadata := TraceMetadata(ctx)
+	require.Equal(t, "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", metadata["traceparent"])
+
+	headers := MetadataHeaders(metadata)
+	require.Equal(t, metadata["traceparent"], headers["x-amz-meta-traceparent"])
+
+	// Notifications report the metadata under its canonical header name
+	userMetadata := map[string]string{
+		"content-type":           "video/mp4",
+		"X-Amz-Meta-Traceparent": metadata["traceparent"],
+	}
+	restored := trace.SpanContextFromContext(ContextWithObjectTrace(context.Background(), userMetadata))
+	require.Equal(t, spanCtx.TraceID(), restored.TraceID())
+	require.Equal(t, spanCtx.SpanID(), restored.SpanID())
+	require.True(t, restored.IsRemote())
+}
+
+func TestObjectTraceWithoutTrace(t *testing.T) {
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	require.Nil(t, TraceMetadata(context.Background()))
+	require.Nil(t, MetadataHeaders(nil))
+
+	ctx := context.Background()
+	require.Equal(t, ctx, ContextWithObjectTrace(ctx, map[string]string{"content-type": "video/mp4"}))
+}
@@ -0,0 +1,422 @@
+package fake
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/IBM/sarama"
+
+	"github.com/sweetloveinyourheart/sweet-reel/pkg/kafka"
+)
+
+// Ensure that KafkaBroker can stand in for the Kafka brokers of a client
+var _ kafka.Transport = (*KafkaBroker)(nil)
+
+var errTransactionsUnsupported = errors.New("fake kafka broker does not support transactions")
+
+// KafkaBroker is an in-process Kafka broker. Every topic is a single partition keeping all
+// of its messages. A consumer group starts at the first message of a topic and commits the
+// offset of every message it marks; within a group a topic is consumed by one member at a
+// time, the others wait until it leaves.
+type KafkaBroker struct {
+	mu      sync.Mutex
+	logs    map[string][]*sarama.ConsumerMessage
+	offsets map[groupTopic]int64
+	owners  map[groupTopic]*brokerClaim
+	// changed is closed and replaced whenever a message is published or a claim released
+	changed chan struct{}
+}
+
+type groupTopic struct {
+	group string
+	topic string
+}
+
+// NewKafkaBroker creates an empty broker
+func NewKafkaBroker() *KafkaBroker {
+	return &KafkaBroker{
+		logs:    make(map[string][]*sarama.ConsumerMessage),
+		offsets: make(map[groupTopic]int64),
+		owners:  make(map[groupTopic]*brokerClaim),
+		changed: make(chan struct{}),
+	}
+}
+
+// Config returns a client configuration exchanging messages through the broker.
+// Failed messages are retried almost immediately.
+func (b *KafkaBroker) Config() *kafka.Config {
+	cfg := kafka.DefaultConfig()
+	cfg.Brokers = nil
+	cfg.ConsumerRetry.InitialBackoff = time.Millisecond
+	cfg.ConsumerRetry.MaxBackoff = 10 * time.Millisecond
+	cfg.Transport = b
+	return cfg
+}
+
+func (b *KafkaBroker) NewSyncProducer(config *kafka.Config) (sarama.SyncProducer, error) {
+	return &brokerProducer{broker: b}, nil
+}
+
+func (b *KafkaBroker) NewConsumerGroup(config *kafka.Config, groupID string) (sarama.ConsumerGroup, error) {
+	return &brokerConsumerGroup{
+		broker: b,
+		group:  groupID,
+		errors: make(chan error),
+		closed: make(chan struct{}),
+	}, nil
+}
+
+// NewestOffsets returns the offset the next message of the single partition of topic gets
+func (b *KafkaBroker) NewestOffsets(config *kafka.Config, topic string) (map[int32]int64, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return map[int32]int64{0: int64(len(b.logs[topic]))}, nil
+}
+
+// Messages returns the messages published on topic so far
+func (b *KafkaBroker) Messages(topic string) []*sarama.ConsumerMessage {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return append([]*sarama.ConsumerMessage(nil), b.logs[topic]...)
+}
+
+// Committed returns the offset of the next message of topic group consumes
+func (b *KafkaBroker) Committed(group string, topic string) int64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.offsets[groupTopic{group: group, topic: topic}]
+}
+
+// publish appends msg to the log of its topic and returns its offset
+func (b *KafkaBroker) publish(msg *sarama.ProducerMessage) (int64, error) {
+	consumed := &sarama.ConsumerMessage{
+		Topic:     msg.Topic,
+		Timestamp: msg.Timestamp,
+	}
+	if consumed.Timestamp.IsZero() {
+		consumed.Timestamp = time.Now()
+	}
+
+	var err error
+	if msg.Key != nil {
+		if consumed.Key, err = msg.Key.Encode(); err != nil {
+			return 0, err
+		}
+	}
+	if msg.Value != nil {
+		if consumed.Value, err = msg.Value.Encode(); err != nil {
+			return 0, err
+		}
+	}
+	for i := range msg.Headers {
+		consumed.Headers = append(consumed.Headers, &sarama.RecordHeader{
+			Key:   msg.Headers[i].Key,
+			Value: msg.Headers[i].Value,
+		})
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	consumed.Offset = int64(len(b.logs[msg.Topic]))
+	b.logs[msg.Topic] = append(b.logs[msg.Topic], consumed)
+	b.notifyLocked()
+
+	return consumed.Offset, nil
+}
+
+// commit moves the offset of group on topic forward to offset
+func (b *KafkaBroker) commit(key groupTopic, offset int64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if offset > b.offsets[key] {
+		b.offsets[key] = offset
+	}
+}
+
+// reset sets the offset of group on topic back to offset
+func (b *KafkaBroker) reset(key groupTopic, offset int64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.offsets[key] = offset
+}
+
+func (b *KafkaBroker) notifyLocked() {
+	close(b.changed)
+	b.changed = make(chan struct{})
+}
+
+// feed delivers the messages of the claimed topic from the committed offset of the group,
+// once no other member of the group consumes it, until ctx is done
+func (b *KafkaBroker) feed(ctx context.Context, claim *brokerClaim) {
+	defer close(claim.messages)
+
+	// Wait for the topic to be free within the group
+	for {
+		b.mu.Lock()
+		if b.owners[claim.key] == nil {
+			b.owners[claim.key] = claim
+			b.mu.Unlock()
+			break
+		}
+		changed := b.changed
+		b.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-changed:
+		}
+	}
+
+	defer func() {
+		b.mu.Lock()
+		delete(b.owners, claim.key)
+		b.notifyLocked()
+		b.mu.Unlock()
+	}()
+
+	b.mu.Lock()
+	next := b.offsets[claim.key]
+	b.mu.Unlock()
+
+	for {
+		b.mu.Lock()
+		log := b.logs[claim.key.topic]
+		changed := b.changed
+		b.mu.Unlock()
+
+		if next < int64(len(log)) {
+			msg := *log[next]
+			select {
+			case claim.messages <- &msg:
+				next++
+			case <-ctx.Done():
+				return
+			}
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-changed:
+		}
+	}
+}
+
+// brokerProducer publishes on the broker
+type brokerProducer struct {
+	broker *KafkaBroker
+}
+
+func (p *brokerProducer) SendMessage(msg *sarama.ProducerMessage) (partition int32, offset int64, err error) {
+	offset, err = p.broker.publish(msg)
+	return 0, offset, err
+}
+
+func (p *brokerProducer) SendMessages(msgs []*sarama.ProducerMessage) error {
+	for _, msg := range msgs {
+		if _, err := p.broker.publish(msg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *brokerProducer) Close() error {
+	return nil
+}
+
+func (p *brokerProducer) TxnStatus() sarama.ProducerTxnStatusFlag {
+	return sarama.ProducerTxnFlagReady
+}
+
+func (p *brokerProducer) IsTransactional() bool {
+	return false
+}
+
+func (p *brokerProducer) BeginTxn() error {
+	return errTransactionsUnsupported
+}
+
+func (p *brokerProducer) CommitTxn() error {
+	return errTransactionsUnsupported
+}
+
+func (p *brokerProducer) AbortTxn() error {
+	return errTransactionsUnsupported
+}
+
+func (p *brokerProducer) AddOffsetsToTxn(offsets map[string][]*sarama.PartitionOffsetMetadata, groupId string) error {
+	return errTransactionsUnsupported
+}
+
+func (p *brokerProducer) AddMessageToTxn(msg *sarama.ConsumerMessage, groupId string, metadata *string) error {
+	return errTransactionsUnsupported
+}
+
+// brokerConsumerGroup is a member of a consumer group of the broker
+type brokerConsumerGroup struct {
+	broker    *KafkaBroker
+	group     string
+	errors    chan error
+	closed    chan struct{}
+	closeOnce sync.Once
+}
+
+// Consume runs a session claiming topics until ctx is done, the group is closed or a claim
+// handler returns
+func (g *brokerConsumerGroup) Consume(ctx context.Context, topics []string, handler sarama.ConsumerGroupHandler) error {
+	select {
+	case <-g.closed:
+		return sarama.ErrClosedConsumerGroup
+	default:
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	go func() {
+		select {
+		case <-g.closed:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	session := &brokerSession{
+		broker: g.broker,
+		group:  g.group,
+		ctx:    ctx,
+		claims: make(map[string][]int32, len(topics)),
+	}
+	for _, topic := range topics {
+		session.claims[topic] = []int32{0}
+	}
+
+	if err := handler.Setup(session); err != nil {
+		return err
+	}
+
+	var wg sync.WaitGroup
+	for _, topic := range topics {
+		claim := &brokerClaim{
+			broker:   g.broker,
+			key:      groupTopic{group: g.group, topic: topic},
+			messages: make(chan *sarama.ConsumerMessage),
+		}
+
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			g.broker.feed(ctx, claim)
+		}()
+		go func() {
+			defer wg.Done()
+			// Like a rebalance, a claim handler returning ends the session
+			defer cancel()
+			_ = handler.ConsumeClaim(session, claim)
+		}()
+	}
+	wg.Wait()
+
+	return handler.Cleanup(session)
+}
+
+// Errors never reports anything, failed claim handlers end their session instead
+func (g *brokerConsumerGroup) Errors() <-chan error {
+	return g.errors
+}
+
+func (g *brokerConsumerGroup) Close() error {
+	g.closeOnce.Do(func() {
+		close(g.closed)
+		close(g.errors)
+	})
+	return nil
+}
+
+func (g *brokerConsumerGroup) Pause(partitions map[string][]int32) {}
+
+func (g *brokerConsumerGroup) Resume(partitions map[string][]int32) {}
+
+func (g *brokerConsumerGroup) PauseAll() {}
+
+func (g *brokerConsumerGroup) ResumeAll() {}
+
+// brokerSession is a consumer group session of the broker
+type brokerSession struct {
+	broker *KafkaBroker
+	group  string
+	ctx    context.Context
+	claims map[string][]int32
+}
+
+func (s *brokerSession) Claims() map[string][]int32 {
+	return s.claims
+}
+
+func (s *brokerSession) MemberID() string {
+	return s.group
+}
+
+func (s *brokerSession) GenerationID() int32 {
+	return 1
+}
+
+func (s *brokerSession) MarkOffset(topic string, partition int32, offset int64, metadata string) {
+	s.broker.commit(groupTopic{group: s.group, topic: topic}, offset)
+}
+
+// Commit does nothing, marked offsets are committed right away
+func (s *brokerSession) Commit() {}
+
+func (s *brokerSession) ResetOffset(topic string, partition int32, offset int64, metadata string) {
+	s.broker.reset(groupTopic{group: s.group, topic: topic}, offset)
+}
+
+func (s *brokerSession) MarkMessage(msg *sarama.ConsumerMessage, metadata string) {
+	s.MarkOffset(msg.Topic, msg.Partition, msg.Offset+1, metadata)
+}
+
+func (s *brokerSession) Context() context.Context {
+	return s.ctx
+}
+
+// brokerClaim is the claim of a session on the partition of a topic
+type brokerClaim struct {
+	broker   *KafkaBroker
+	key      groupTopic
+	messages chan *sarama.ConsumerMessage
+}
+
+func (c *brokerClaim) Topic() string {
+	return c.key.topic
+}
+
+func (c *brokerClaim) Partition() int32 {
+	return 0
+}
+
+func (c *brokerClaim) InitialOffset() int64 {
+	return c.broker.Committed(c.key.group, c.key.topic)
+}
+
+func (c *brokerClaim) HighWaterMarkOffset() int64 {
+	c.broker.mu.Lock()
+	defer c.broker.mu.Unlock()
+
+	return int64(len(c.broker.logs[c.key.topic]))
+}
+
+func (c *brokerClaim) Messages() <-chan *sarama.ConsumerMessage {
+	return c.messages
+}
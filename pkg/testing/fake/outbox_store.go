@@ -0,0 +1,113 @@
+package fake
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/sweetloveinyourheart/sweet-reel/pkg/db"
+	"github.com/sweetloveinyourheart/sweet-reel/pkg/kafka"
+)
+
+// Ensure that OutboxStore implements kafka.OutboxStore
+var _ kafka.OutboxStore = (*OutboxStore)(nil)
+
+// OutboxStore keeps an outbox in memory
+type OutboxStore struct {
+	mu       sync.Mutex
+	messages []*OutboxRecord
+	// relay is held by the relay publishing the outbox, like the relay lock of the database
+	relay sync.Mutex
+}
+
+// OutboxRecord is a message of the outbox and what became of it
+type OutboxRecord struct {
+	Message   db.OutboxMessage
+	SentAt    time.Time
+	LastError string
+	Parked    bool
+}
+
+// NewOutboxStore creates an empty outbox
+func NewOutboxStore() *OutboxStore {
+	return &OutboxStore{}
+}
+
+// Enqueue writes a message to the outbox and returns its id
+func (s *OutboxStore) Enqueue(topic string, key string, payload []byte, headers map[string]string) int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := int64(len(s.messages) + 1)
+	s.messages = append(s.messages, &OutboxRecord{
+		Message: db.OutboxMessage{
+			ID:        id,
+			Topic:     topic,
+			Key:       key,
+			Payload:   payload,
+			Headers:   headers,
+			CreatedAt: time.Now(),
+		},
+	})
+	return id
+}
+
+// Record returns the message with the given id
+func (s *OutboxStore) Record(id int64) OutboxRecord {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return *s.messages[id-1]
+}
+
+// Hold keeps relays from claiming the outbox, as another relay would, until release is called
+func (s *OutboxStore) Hold() (release func()) {
+	s.relay.Lock()
+	return s.relay.Unlock
+}
+
+func (s *OutboxStore) RelayOutbox(ctx context.Context, limit int, publish func(batch []*db.OutboxMessage) (sent []int64, failure *kafka.OutboxFailure)) error {
+	if !s.relay.TryLock() {
+		return nil
+	}
+	defer s.relay.Unlock()
+
+	s.mu.Lock()
+	var batch []*db.OutboxMessage
+	for _, record := range s.messages {
+		if len(batch) == limit {
+			break
+		}
+		if record.SentAt.IsZero() && !record.Parked {
+			message := record.Message
+			batch = append(batch, &message)
+		}
+	}
+	s.mu.Unlock()
+
+	if len(batch) == 0 {
+		return nil
+	}
+
+	sent, failure := publish(batch)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, id := range sent {
+		s.messages[id-1].SentAt = time.Now()
+	}
+	if failure != nil {
+		record := s.messages[failure.ID-1]
+		record.Message.Attempts++
+		record.LastError = failure.Err.Error()
+		record.Parked = failure.Park
+	}
+
+	return nil
+}
+
+// PurgeOutbox keeps every message, so that their records stay available to tests
+func (s *OutboxStore) PurgeOutbox(ctx context.Context, sentBefore time.Time) (int64, error) {
+	return 0, nil
+}
@@ -0,0 +1,46 @@
+package fake
+
+import (
+	"context"
+	"sync"
+
+	"github.com/sweetloveinyourheart/sweet-reel/pkg/kafka"
+)
+
+// Ensure that ProcessedMessageStore implements kafka.ProcessedMessageStore
+var _ kafka.ProcessedMessageStore = (*ProcessedMessageStore)(nil)
+
+// ProcessedMessageStore keeps processed messages in memory
+type ProcessedMessageStore struct {
+	mu        sync.Mutex
+	processed map[string]map[string]struct{}
+}
+
+// NewProcessedMessageStore creates a store without any processed message
+func NewProcessedMessageStore() *ProcessedMessageStore {
+	return &ProcessedMessageStore{
+		processed: make(map[string]map[string]struct{}),
+	}
+}
+
+func (s *ProcessedMessageStore) IsProcessed(ctx context.Context, group string, messageID string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, ok := s.processed[group][messageID]
+	return ok, nil
+}
+
+func (s *ProcessedMessageStore) MarkProcessed(ctx context.Context, group string, messageID string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.processed[group][messageID]; ok {
+		return false, nil
+	}
+	if s.processed[group] == nil {
+		s.processed[group] = make(map[string]struct{})
+	}
+	s.processed[group][messageID] = struct{}{}
+	return true, nil
+}
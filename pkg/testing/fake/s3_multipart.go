@@ -0,0 +1,176 @@
+package fake
+
+import (
+	"bytes"
+	"crypto/md5"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"maps"
+	"net/url"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/sweetloveinyourheart/sweet-reel/pkg/s3"
+)
+
+// s3MultipartUpload is a multipart upload in progress
+type s3MultipartUpload struct {
+	key         string
+	bucket      string
+	contentType string
+	metadata    map[string]string
+	initiated   time.Time
+	parts       map[int32][]byte
+}
+
+// MultipartUploads returns the ids of the multipart uploads to bucket in progress
+func (s *S3Storage) MultipartUploads(bucket string) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var ids []string
+	for id, upload := range s.uploads {
+		if upload.bucket == bucket {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+func (s *S3Storage) CreateMultipartUpload(key string, bucket string, mimeType string, metadata map[string]string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	uploadID := uuid.Must(uuid.NewV4()).String()
+	s.uploads[uploadID] = &s3MultipartUpload{
+		key:         key,
+		bucket:      bucket,
+		contentType: mimeType,
+		metadata:    metadata,
+		initiated:   time.Now(),
+		parts:       make(map[int32][]byte),
+	}
+	return uploadID, nil
+}
+
+func (s *S3Storage) GenerateUploadPartPublicUri(key string, bucket string, uploadID string, partNumber int32, expirationSeconds uint32) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, err := s.upload(key, bucket, uploadID); err != nil {
+		return "", err
+	}
+
+	u, err := url.Parse(presignedURL(key, bucket, expirationSeconds))
+	if err != nil {
+		return "", err
+	}
+	query := u.Query()
+	query.Set("uploadId", uploadID)
+	query.Set("partNumber", strconv.Itoa(int(partNumber)))
+	u.RawQuery = query.Encode()
+
+	return u.String(), nil
+}
+
+func (s *S3Storage) ListUploadedParts(key string, bucket string, uploadID string) ([]s3.UploadedPart, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	upload, err := s.upload(key, bucket, uploadID)
+	if err != nil {
+		return nil, err
+	}
+
+	parts := make([]s3.UploadedPart, 0, len(upload.parts))
+	for _, partNumber := range slices.Sorted(maps.Keys(upload.parts)) {
+		data := upload.parts[partNumber]
+		digest := md5.Sum(data)
+		parts = append(parts, s3.UploadedPart{
+			PartNumber: partNumber,
+			Size:       int64(len(data)),
+			ETag:       fmt.Sprintf("%q", hex.EncodeToString(digest[:])),
+		})
+	}
+	return parts, nil
+}
+
+func (s *S3Storage) CompleteMultipartUpload(key string, bucket string, uploadID string) error {
+	s.mu.Lock()
+	upload, err := s.upload(key, bucket, uploadID)
+	if err != nil {
+		s.mu.Unlock()
+		return err
+	}
+	if len(upload.parts) == 0 {
+		s.mu.Unlock()
+		return fmt.Errorf("multipart upload %s has no part", uploadID)
+	}
+
+	var data bytes.Buffer
+	for _, partNumber := range slices.Sorted(maps.Keys(upload.parts)) {
+		data.Write(upload.parts[partNumber])
+	}
+	delete(s.uploads, uploadID)
+	s.mu.Unlock()
+
+	return s.put(key, bucket, &data, upload.contentType, upload.metadata)
+}
+
+func (s *S3Storage) AbortMultipartUpload(key string, bucket string, uploadID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.uploads, uploadID)
+	return nil
+}
+
+func (s *S3Storage) AbortMultipartUploadsBefore(bucket string, before time.Time) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	aborted := 0
+	for id, upload := range s.uploads {
+		if upload.bucket == bucket && upload.initiated.Before(before) {
+			delete(s.uploads, id)
+			aborted++
+		}
+	}
+	return aborted, nil
+}
+
+// putPart stores a part uploaded to a URL presigned by GenerateUploadPartPublicUri
+func (s *S3Storage) putPart(key string, bucket string, uploadID string, partNumber string, file io.Reader) error {
+	number, err := strconv.ParseInt(partNumber, 10, 32)
+	if err != nil || number < 1 || number > s3.MaxMultipartParts {
+		return fmt.Errorf("invalid part number: %s", partNumber)
+	}
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	upload, err := s.upload(key, bucket, uploadID)
+	if err != nil {
+		return err
+	}
+	upload.parts[int32(number)] = data
+	return nil
+}
+
+// upload returns the multipart upload uploadID of key, s.mu must be held
+func (s *S3Storage) upload(key string, bucket string, uploadID string) (*s3MultipartUpload, error) {
+	upload, ok := s.uploads[uploadID]
+	if !ok || upload.key != key || upload.bucket != bucket {
+		return nil, fmt.Errorf("%w: %s", s3.ErrUploadNotFound, uploadID)
+	}
+	return upload, nil
+}
@@ -0,0 +1,250 @@
+package fake
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/sweetloveinyourheart/sweet-reel/pkg/kafka"
+	"github.com/sweetloveinyourheart/sweet-reel/pkg/messages"
+	"github.com/sweetloveinyourheart/sweet-reel/pkg/s3"
+)
+
+// Ensure that S3Storage implements s3.S3StreamStorage
+var _ s3.S3StreamStorage = (*S3Storage)(nil)
+
+// presignedScheme is the scheme of the URLs presigned by S3Storage
+const presignedScheme = "memory"
+
+// S3Storage is an in-memory S3 storage. Like MinIO with bucket notifications configured,
+// it reports every object uploaded to a watched bucket as an S3EventMessage.
+type S3Storage struct {
+	mu        sync.Mutex
+	objects   map[string]map[string]*s3Object
+	presigned map[string]map[string]map[string]string
+	uploads   map[string]*s3MultipartUpload
+	notify    map[string][]func(messages.S3EventMessage)
+}
+
+type s3Object struct {
+	data        []byte
+	contentType string
+	metadata    map[string]string
+}
+
+// NewS3Storage creates a storage without any object
+func NewS3Storage() *S3Storage {
+	return &S3Storage{
+		objects:   make(map[string]map[string]*s3Object),
+		presigned: make(map[string]map[string]map[string]string),
+		uploads:   make(map[string]*s3MultipartUpload),
+		notify:    make(map[string][]func(messages.S3EventMessage)),
+	}
+}
+
+// OnObjectCreated calls fn with the notification of every object uploaded to bucket
+func (s *S3Storage) OnObjectCreated(bucket string, fn func(messages.S3EventMessage)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.notify[bucket] = append(s.notify[bucket], fn)
+}
+
+// NotifyKafka publishes the notification of every object uploaded to bucket on topic,
+// keyed by "bucket/key" the way MinIO does
+func (s *S3Storage) NotifyKafka(bucket string, client *kafka.Client, topic string) {
+	s.OnObjectCreated(bucket, func(event messages.S3EventMessage) {
+		if _, _, err := client.SendJSON(context.Background(), topic, event.Key, event); err != nil {
+			panic(fmt.Sprintf("failed to publish notification of %s: %v", event.Key, err))
+		}
+	})
+}
+
+// UploadPresigned uploads file to a URL presigned by GenerateUploadPublicUri or
+// GenerateUploadPartPublicUri, the way a browser would. headers must carry the metadata
+// the URL was presigned with.
+func (s *S3Storage) UploadPresigned(uri string, file io.Reader, mimeType string, headers map[string]string) error {
+	u, err := url.Parse(uri)
+	if err != nil {
+		return err
+	}
+	if u.Scheme != presignedScheme {
+		return fmt.Errorf("not a presigned upload url: %s", uri)
+	}
+
+	bucket, key := u.Host, strings.TrimPrefix(u.Path, "/")
+	if uploadID := u.Query().Get("uploadId"); uploadID != "" {
+		return s.putPart(key, bucket, uploadID, u.Query().Get("partNumber"), file)
+	}
+
+	s.mu.Lock()
+	metadata, ok := s.presigned[bucket][key]
+	s.mu.Unlock()
+	if !ok {
+		return fmt.Errorf("no upload of %s presigned in bucket %s", key, bucket)
+	}
+
+	// The signature covers the metadata headers
+	for name, value := range s3.MetadataHeaders(metadata) {
+		if headers[name] != value {
+			return fmt.Errorf("signature mismatch: missing header %s", name)
+		}
+	}
+
+	return s.put(key, bucket, file, mimeType, metadata)
+}
+
+// Object returns the content of an object and whether it exists
+func (s *S3Storage) Object(key string, bucket string) ([]byte, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	object, ok := s.objects[bucket][key]
+	if !ok {
+		return nil, false
+	}
+	return object.data, true
+}
+
+// Keys returns the keys of the objects in bucket starting with prefix
+func (s *S3Storage) Keys(prefix string, bucket string) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var keys []string
+	for key := range s.objects[bucket] {
+		if strings.HasPrefix(key, prefix) {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+func (s *S3Storage) Download(key string, bucket string) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	object, ok := s.objects[bucket][key]
+	if !ok {
+		return nil, fmt.Errorf("object %s not found in bucket %s", key, bucket)
+	}
+	return bytes.Clone(object.data), nil
+}
+
+func (s *S3Storage) DownloadStream(key string, bucket string) (io.ReadCloser, error) {
+	data, err := s.Download(key, bucket)
+	if err != nil {
+		return nil, err
+	}
+	return io.NopCloser(bytes.NewReader(data)), nil
+}
+
+func (s *S3Storage) DownloadToFile(key string, bucket string, filePath string) (int64, error) {
+	data, err := s.Download(key, bucket)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(filePath), 0755); err != nil {
+		return 0, err
+	}
+	if err := os.WriteFile(filePath, data, 0644); err != nil {
+		return 0, err
+	}
+	return int64(len(data)), nil
+}
+
+func (s *S3Storage) Upload(key string, bucket string, file io.Reader, mimeType string) error {
+	return s.put(key, bucket, file, mimeType, nil)
+}
+
+func (s *S3Storage) UploadFile(key string, bucket string, filePath string, mimeType string) error {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	return s.put(key, bucket, file, mimeType, nil)
+}
+
+func (s *S3Storage) Delete(key string, bucket string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.objects[bucket], key)
+	return nil
+}
+
+func (s *S3Storage) DeletePrefix(prefix string, bucket string) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	deleted := 0
+	for key := range s.objects[bucket] {
+		if strings.HasPrefix(key, prefix) {
+			delete(s.objects[bucket], key)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+func (s *S3Storage) GenerateUploadPublicUri(key string, bucket string, expirationSeconds uint32, metadata map[string]string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.presigned[bucket] == nil {
+		s.presigned[bucket] = make(map[string]map[string]string)
+	}
+	s.presigned[bucket][key] = metadata
+
+	return presignedURL(key, bucket, expirationSeconds), nil
+}
+
+func (s *S3Storage) GenerateDownloadPublicUri(key string, bucket string, expirationSeconds uint32) (string, error) {
+	return presignedURL(key, bucket, expirationSeconds), nil
+}
+
+// put stores an object and notifies the watchers of bucket
+func (s *S3Storage) put(key string, bucket string, file io.Reader, mimeType string, metadata map[string]string) error {
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	if s.objects[bucket] == nil {
+		s.objects[bucket] = make(map[string]*s3Object)
+	}
+	s.objects[bucket][key] = &s3Object{data: data, contentType: mimeType, metadata: metadata}
+	watchers := slices.Clone(s.notify[bucket])
+	s.mu.Unlock()
+
+	if len(watchers) == 0 {
+		return nil
+	}
+
+	event := s3.NewObjectCreatedEvent(key, bucket, int64(len(data)), mimeType, metadata)
+	for _, fn := range watchers {
+		fn(event)
+	}
+	return nil
+}
+
+func presignedURL(key string, bucket string, expirationSeconds uint32) string {
+	u := url.URL{
+		Scheme:   presignedScheme,
+		Host:     bucket,
+		Path:     "/" + key,
+		RawQuery: url.Values{"X-Amz-Expires": {fmt.Sprint(expirationSeconds)}}.Encode(),
+	}
+	return u.String()
+}
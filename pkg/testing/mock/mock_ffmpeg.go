@@ -115,6 +115,11 @@ func (m *MockFFmpeg) CreateThumbnail(ctx context.Context, inputPath, outputPath,
 	return args.Error(0)
 }
 
+func (m *MockFFmpeg) CreateStoryboard(ctx context.Context, inputPath, outputDir string, options ffmpeg.StoryboardOptions) error {
+	args := m.Called(ctx, inputPath, outputDir, options)
+	return args.Error(0)
+}
+
 // Segmentation methods
 func (m *MockFFmpeg) SegmentVideo(ctx context.Context, inputPath, outputDir string, options ffmpeg.SegmentationOptions, progressCallback ffmpeg.ProgressCallback) error {
 	args := m.Called(ctx, inputPath, outputDir, options, progressCallback)
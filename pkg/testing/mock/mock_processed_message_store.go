@@ -0,0 +1,27 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+
+	"github.com/sweetloveinyourheart/sweet-reel/pkg/kafka"
+)
+
+// Ensure that MockProcessedMessageStore implements kafka.ProcessedMessageStore
+var _ kafka.ProcessedMessageStore = (*MockProcessedMessageStore)(nil)
+
+// MockProcessedMessageStore is a mock of the kafka.ProcessedMessageStore interface
+type MockProcessedMessageStore struct {
+	mock.Mock
+}
+
+func (m *MockProcessedMessageStore) IsProcessed(ctx context.Context, group string, messageID string) (bool, error) {
+	args := m.Called(ctx, group, messageID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockProcessedMessageStore) MarkProcessed(ctx context.Context, group string, messageID string) (bool, error) {
+	args := m.Called(ctx, group, messageID)
+	return args.Bool(0), args.Error(1)
+}
@@ -2,6 +2,7 @@ package mock
 
 import (
 	"io"
+	"time"
 
 	"github.com/stretchr/testify/mock"
 
@@ -10,6 +11,7 @@ import (
 
 // Ensure that MockS3 implements storage.Storag
 var _ s3.S3Storage = (*MockS3)(nil)
+var _ s3.S3StreamStorage = (*MockS3)(nil)
 
 // MockS3 is a mock of the storage.Storage interface
 type MockS3 struct {
@@ -26,8 +28,31 @@ func (m *MockS3) Download(key string, bucket string) ([]byte, error) {
 	return args.Get(0).([]byte), args.Error(1)
 }
 
-func (m *MockS3) GenerateUploadPublicUri(key string, bucket string, expirationSeconds uint32) (string, error) {
-	args := m.Called(key, bucket, expirationSeconds)
+func (m *MockS3) DownloadStream(key string, bucket string) (io.ReadCloser, error) {
+	args := m.Called(key, bucket)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(io.ReadCloser), args.Error(1)
+}
+
+func (m *MockS3) DownloadToFile(key string, bucket string, filePath string) (int64, error) {
+	args := m.Called(key, bucket, filePath)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockS3) UploadFile(key string, bucket string, filePath string, mimeType string) error {
+	args := m.Called(key, bucket, filePath, mimeType)
+	return args.Error(0)
+}
+
+func (m *MockS3) DeletePrefix(prefix string, bucket string) (int, error) {
+	args := m.Called(prefix, bucket)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockS3) GenerateUploadPublicUri(key string, bucket string, expirationSeconds uint32, metadata map[string]string) (string, error) {
+	args := m.Called(key, bucket, expirationSeconds, metadata)
 	return args.String(0), args.Error(1)
 }
 
@@ -40,3 +65,36 @@ func (m *MockS3) Delete(key string, bucket string) error {
 	args := m.Called(key, bucket)
 	return args.Error(0)
 }
+
+func (m *MockS3) CreateMultipartUpload(key string, bucket string, mimeType string, metadata map[string]string) (string, error) {
+	args := m.Called(key, bucket, mimeType, metadata)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockS3) GenerateUploadPartPublicUri(key string, bucket string, uploadID string, partNumber int32, expirationSeconds uint32) (string, error) {
+	args := m.Called(key, bucket, uploadID, partNumber, expirationSeconds)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockS3) ListUploadedParts(key string, bucket string, uploadID string) ([]s3.UploadedPart, error) {
+	args := m.Called(key, bucket, uploadID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]s3.UploadedPart), args.Error(1)
+}
+
+func (m *MockS3) CompleteMultipartUpload(key string, bucket string, uploadID string) error {
+	args := m.Called(key, bucket, uploadID)
+	return args.Error(0)
+}
+
+func (m *MockS3) AbortMultipartUpload(key string, bucket string, uploadID string) error {
+	args := m.Called(key, bucket, uploadID)
+	return args.Error(0)
+}
+
+func (m *MockS3) AbortMultipartUploadsBefore(bucket string, before time.Time) (int, error) {
+	args := m.Called(bucket, before)
+	return args.Int(0), args.Error(1)
+}
@@ -0,0 +1,190 @@
+package mock
+
+import (
+	"context"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/mock"
+
+	proto "github.com/sweetloveinyourheart/sweet-reel/proto/code/video_management/go"
+	videoManagementConnect "github.com/sweetloveinyourheart/sweet-reel/proto/code/video_management/go/grpcconnect"
+)
+
+// Ensure that MockVideoManagementClient implements videoManagementConnect.VideoManagementClient
+var _ videoManagementConnect.VideoManagementClient = (*MockVideoManagementClient)(nil)
+
+// MockVideoManagementClient is a mock implementation of videoManagementConnect.VideoManagementClient.
+type MockVideoManagementClient struct {
+	mock.Mock
+}
+
+func (m *MockVideoManagementClient) PresignedUrl(
+	ctx context.Context,
+	req *connect.Request[proto.PresignedUrlRequest],
+) (*connect.Response[proto.PresignedUrlResponse], error) {
+	args := m.Called(ctx, req)
+	resp, _ := args.Get(0).(*connect.Response[proto.PresignedUrlResponse])
+	return resp, args.Error(1)
+}
+
+func (m *MockVideoManagementClient) GetChannelVideos(
+	ctx context.Context,
+	req *connect.Request[proto.GetChannelVideosRequest],
+) (*connect.Response[proto.GetChannelVideosResponse], error) {
+	args := m.Called(ctx, req)
+	resp, _ := args.Get(0).(*connect.Response[proto.GetChannelVideosResponse])
+	return resp, args.Error(1)
+}
+
+func (m *MockVideoManagementClient) GetVideoMetadataById(
+	ctx context.Context,
+	req *connect.Request[proto.GetVideoMetadataByIdRequest],
+) (*connect.Response[proto.GetVideoMetadataByIdResponse], error) {
+	args := m.Called(ctx, req)
+	resp, _ := args.Get(0).(*connect.Response[proto.GetVideoMetadataByIdResponse])
+	return resp, args.Error(1)
+}
+
+func (m *MockVideoManagementClient) ServePlaylist(
+	ctx context.Context,
+	req *connect.Request[proto.ServePlaylistRequest],
+) (*connect.Response[proto.ServePlaylistResponse], error) {
+	args := m.Called(ctx, req)
+	resp, _ := args.Get(0).(*connect.Response[proto.ServePlaylistResponse])
+	return resp, args.Error(1)
+}
+
+func (m *MockVideoManagementClient) GetReelFeed(
+	ctx context.Context,
+	req *connect.Request[proto.GetReelFeedRequest],
+) (*connect.Response[proto.GetReelFeedResponse], error) {
+	args := m.Called(ctx, req)
+	resp, _ := args.Get(0).(*connect.Response[proto.GetReelFeedResponse])
+	return resp, args.Error(1)
+}
+
+func (m *MockVideoManagementClient) DeleteVideo(
+	ctx context.Context,
+	req *connect.Request[proto.DeleteVideoRequest],
+) (*connect.Response[proto.DeleteVideoResponse], error) {
+	args := m.Called(ctx, req)
+	resp, _ := args.Get(0).(*connect.Response[proto.DeleteVideoResponse])
+	return resp, args.Error(1)
+}
+
+func (m *MockVideoManagementClient) UpdateVideo(
+	ctx context.Context,
+	req *connect.Request[proto.UpdateVideoRequest],
+) (*connect.Response[proto.UpdateVideoResponse], error) {
+	args := m.Called(ctx, req)
+	resp, _ := args.Get(0).(*connect.Response[proto.UpdateVideoResponse])
+	return resp, args.Error(1)
+}
+
+func (m *MockVideoManagementClient) RecordView(
+	ctx context.Context,
+	req *connect.Request[proto.RecordViewRequest],
+) (*connect.Response[proto.RecordViewResponse], error) {
+	args := m.Called(ctx, req)
+	resp, _ := args.Get(0).(*connect.Response[proto.RecordViewResponse])
+	return resp, args.Error(1)
+}
+
+func (m *MockVideoManagementClient) CreateMultipartUpload(
+	ctx context.Context,
+	req *connect.Request[proto.CreateMultipartUploadRequest],
+) (*connect.Response[proto.CreateMultipartUploadResponse], error) {
+	args := m.Called(ctx, req)
+	resp, _ := args.Get(0).(*connect.Response[proto.CreateMultipartUploadResponse])
+	return resp, args.Error(1)
+}
+
+func (m *MockVideoManagementClient) GetUploadPartUrls(
+	ctx context.Context,
+	req *connect.Request[proto.GetUploadPartUrlsRequest],
+) (*connect.Response[proto.GetUploadPartUrlsResponse], error) {
+	args := m.Called(ctx, req)
+	resp, _ := args.Get(0).(*connect.Response[proto.GetUploadPartUrlsResponse])
+	return resp, args.Error(1)
+}
+
+func (m *MockVideoManagementClient) ListUploadedParts(
+	ctx context.Context,
+	req *connect.Request[proto.ListUploadedPartsRequest],
+) (*connect.Response[proto.ListUploadedPartsResponse], error) {
+	args := m.Called(ctx, req)
+	resp, _ := args.Get(0).(*connect.Response[proto.ListUploadedPartsResponse])
+	return resp, args.Error(1)
+}
+
+func (m *MockVideoManagementClient) CompleteMultipartUpload(
+	ctx context.Context,
+	req *connect.Request[proto.CompleteMultipartUploadRequest],
+) (*connect.Response[proto.CompleteMultipartUploadResponse], error) {
+	args := m.Called(ctx, req)
+	resp, _ := args.Get(0).(*connect.Response[proto.CompleteMultipartUploadResponse])
+	return resp, args.Error(1)
+}
+
+func (m *MockVideoManagementClient) AbortMultipartUpload(
+	ctx context.Context,
+	req *connect.Request[proto.AbortMultipartUploadRequest],
+) (*connect.Response[proto.AbortMultipartUploadResponse], error) {
+	args := m.Called(ctx, req)
+	resp, _ := args.Get(0).(*connect.Response[proto.AbortMultipartUploadResponse])
+	return resp, args.Error(1)
+}
+
+func (m *MockVideoManagementClient) GetVideoStatus(
+	ctx context.Context,
+	req *connect.Request[proto.GetVideoStatusRequest],
+) (*connect.Response[proto.GetVideoStatusResponse], error) {
+	args := m.Called(ctx, req)
+	resp, _ := args.Get(0).(*connect.Response[proto.GetVideoStatusResponse])
+	return resp, args.Error(1)
+}
+
+func (m *MockVideoManagementClient) GetVideoProcessingStatus(
+	ctx context.Context,
+	req *connect.Request[proto.GetVideoProcessingStatusRequest],
+) (*connect.Response[proto.GetVideoProcessingStatusResponse], error) {
+	args := m.Called(ctx, req)
+	resp, _ := args.Get(0).(*connect.Response[proto.GetVideoProcessingStatusResponse])
+	return resp, args.Error(1)
+}
+
+func (m *MockVideoManagementClient) WatchVideoProgress(
+	ctx context.Context,
+	req *connect.Request[proto.WatchVideoProgressRequest],
+) (*connect.ServerStreamForClient[proto.WatchVideoProgressResponse], error) {
+	args := m.Called(ctx, req)
+	stream, _ := args.Get(0).(*connect.ServerStreamForClient[proto.WatchVideoProgressResponse])
+	return stream, args.Error(1)
+}
+
+func (m *MockVideoManagementClient) StoreContentKey(
+	ctx context.Context,
+	req *connect.Request[proto.StoreContentKeyRequest],
+) (*connect.Response[proto.StoreContentKeyResponse], error) {
+	args := m.Called(ctx, req)
+	resp, _ := args.Get(0).(*connect.Response[proto.StoreContentKeyResponse])
+	return resp, args.Error(1)
+}
+
+func (m *MockVideoManagementClient) GetContentKey(
+	ctx context.Context,
+	req *connect.Request[proto.GetContentKeyRequest],
+) (*connect.Response[proto.GetContentKeyResponse], error) {
+	args := m.Called(ctx, req)
+	resp, _ := args.Get(0).(*connect.Response[proto.GetContentKeyResponse])
+	return resp, args.Error(1)
+}
+
+func (m *MockVideoManagementClient) ServeStoryboard(
+	ctx context.Context,
+	req *connect.Request[proto.ServeStoryboardRequest],
+) (*connect.Response[proto.ServeStoryboardResponse], error) {
+	args := m.Called(ctx, req)
+	resp, _ := args.Get(0).(*connect.Response[proto.ServeStoryboardResponse])
+	return resp, args.Error(1)
+}
@@ -45,6 +45,51 @@ const (
 	// VideoManagementServePlaylistProcedure is the fully-qualified name of the VideoManagement's
 	// ServePlaylist RPC.
 	VideoManagementServePlaylistProcedure = "/com.sweetloveinyourheart.srl.videomanagement.dataproviders.VideoManagement/ServePlaylist"
+	// VideoManagementGetReelFeedProcedure is the fully-qualified name of the VideoManagement's
+	// GetReelFeed RPC.
+	VideoManagementGetReelFeedProcedure = "/com.sweetloveinyourheart.srl.videomanagement.dataproviders.VideoManagement/GetReelFeed"
+	// VideoManagementDeleteVideoProcedure is the fully-qualified name of the VideoManagement's
+	// DeleteVideo RPC.
+	VideoManagementDeleteVideoProcedure = "/com.sweetloveinyourheart.srl.videomanagement.dataproviders.VideoManagement/DeleteVideo"
+	// VideoManagementUpdateVideoProcedure is the fully-qualified name of the VideoManagement's
+	// UpdateVideo RPC.
+	VideoManagementUpdateVideoProcedure = "/com.sweetloveinyourheart.srl.videomanagement.dataproviders.VideoManagement/UpdateVideo"
+	// VideoManagementRecordViewProcedure is the fully-qualified name of the VideoManagement's
+	// RecordView RPC.
+	VideoManagementRecordViewProcedure = "/com.sweetloveinyourheart.srl.videomanagement.dataproviders.VideoManagement/RecordView"
+	// VideoManagementCreateMultipartUploadProcedure is the fully-qualified name of the
+	// VideoManagement's CreateMultipartUpload RPC.
+	VideoManagementCreateMultipartUploadProcedure = "/com.sweetloveinyourheart.srl.videomanagement.dataproviders.VideoManagement/CreateMultipartUpload"
+	// VideoManagementGetUploadPartUrlsProcedure is the fully-qualified name of the VideoManagement's
+	// GetUploadPartUrls RPC.
+	VideoManagementGetUploadPartUrlsProcedure = "/com.sweetloveinyourheart.srl.videomanagement.dataproviders.VideoManagement/GetUploadPartUrls"
+	// VideoManagementListUploadedPartsProcedure is the fully-qualified name of the VideoManagement's
+	// ListUploadedParts RPC.
+	VideoManagementListUploadedPartsProcedure = "/com.sweetloveinyourheart.srl.videomanagement.dataproviders.VideoManagement/ListUploadedParts"
+	// VideoManagementCompleteMultipartUploadProcedure is the fully-qualified name of the
+	// VideoManagement's CompleteMultipartUpload RPC.
+	VideoManagementCompleteMultipartUploadProcedure = "/com.sweetloveinyourheart.srl.videomanagement.dataproviders.VideoManagement/CompleteMultipartUpload"
+	// VideoManagementAbortMultipartUploadProcedure is the fully-qualified name of the VideoManagement's
+	// AbortMultipartUpload RPC.
+	VideoManagementAbortMultipartUploadProcedure = "/com.sweetloveinyourheart.srl.videomanagement.dataproviders.VideoManagement/AbortMultipartUpload"
+	// VideoManagementGetVideoStatusProcedure is the fully-qualified name of the VideoManagement's
+	// GetVideoStatus RPC.
+	VideoManagementGetVideoStatusProcedure = "/com.sweetloveinyourheart.srl.videomanagement.dataproviders.VideoManagement/GetVideoStatus"
+	// VideoManagementGetVideoProcessingStatusProcedure is the fully-qualified name of the
+	// VideoManagement's GetVideoProcessingStatus RPC.
+	VideoManagementGetVideoProcessingStatusProcedure = "/com.sweetloveinyourheart.srl.videomanagement.dataproviders.VideoManagement/GetVideoProcessingStatus"
+	// VideoManagementWatchVideoProgressProcedure is the fully-qualified name of the VideoManagement's
+	// WatchVideoProgress RPC.
+	VideoManagementWatchVideoProgressProcedure = "/com.sweetloveinyourheart.srl.videomanagement.dataproviders.VideoManagement/WatchVideoProgress"
+	// VideoManagementStoreContentKeyProcedure is the fully-qualified name of the VideoManagement's
+	// StoreContentKey RPC.
+	VideoManagementStoreContentKeyProcedure = "/com.sweetloveinyourheart.srl.videomanagement.dataproviders.VideoManagement/StoreContentKey"
+	// VideoManagementGetContentKeyProcedure is the fully-qualified name of the VideoManagement's
+	// GetContentKey RPC.
+	VideoManagementGetContentKeyProcedure = "/com.sweetloveinyourheart.srl.videomanagement.dataproviders.VideoManagement/GetContentKey"
+	// VideoManagementServeStoryboardProcedure is the fully-qualified name of the VideoManagement's
+	// ServeStoryboard RPC.
+	VideoManagementServeStoryboardProcedure = "/com.sweetloveinyourheart.srl.videomanagement.dataproviders.VideoManagement/ServeStoryboard"
 )
 
 // VideoManagementClient is a client for the
@@ -54,6 +99,21 @@ type VideoManagementClient interface {
 	GetChannelVideos(context.Context, *connect.Request[_go.GetChannelVideosRequest]) (*connect.Response[_go.GetChannelVideosResponse], error)
 	GetVideoMetadataById(context.Context, *connect.Request[_go.GetVideoMetadataByIdRequest]) (*connect.Response[_go.GetVideoMetadataByIdResponse], error)
 	ServePlaylist(context.Context, *connect.Request[_go.ServePlaylistRequest]) (*connect.Response[_go.ServePlaylistResponse], error)
+	GetReelFeed(context.Context, *connect.Request[_go.GetReelFeedRequest]) (*connect.Response[_go.GetReelFeedResponse], error)
+	DeleteVideo(context.Context, *connect.Request[_go.DeleteVideoRequest]) (*connect.Response[_go.DeleteVideoResponse], error)
+	UpdateVideo(context.Context, *connect.Request[_go.UpdateVideoRequest]) (*connect.Response[_go.UpdateVideoResponse], error)
+	RecordView(context.Context, *connect.Request[_go.RecordViewRequest]) (*connect.Response[_go.RecordViewResponse], error)
+	CreateMultipartUpload(context.Context, *connect.Request[_go.CreateMultipartUploadRequest]) (*connect.Response[_go.CreateMultipartUploadResponse], error)
+	GetUploadPartUrls(context.Context, *connect.Request[_go.GetUploadPartUrlsRequest]) (*connect.Response[_go.GetUploadPartUrlsResponse], error)
+	ListUploadedParts(context.Context, *connect.Request[_go.ListUploadedPartsRequest]) (*connect.Response[_go.ListUploadedPartsResponse], error)
+	CompleteMultipartUpload(context.Context, *connect.Request[_go.CompleteMultipartUploadRequest]) (*connect.Response[_go.CompleteMultipartUploadResponse], error)
+	AbortMultipartUpload(context.Context, *connect.Request[_go.AbortMultipartUploadRequest]) (*connect.Response[_go.AbortMultipartUploadResponse], error)
+	GetVideoStatus(context.Context, *connect.Request[_go.GetVideoStatusRequest]) (*connect.Response[_go.GetVideoStatusResponse], error)
+	GetVideoProcessingStatus(context.Context, *connect.Request[_go.GetVideoProcessingStatusRequest]) (*connect.Response[_go.GetVideoProcessingStatusResponse], error)
+	WatchVideoProgress(context.Context, *connect.Request[_go.WatchVideoProgressRequest]) (*connect.ServerStreamForClient[_go.WatchVideoProgressResponse], error)
+	StoreContentKey(context.Context, *connect.Request[_go.StoreContentKeyRequest]) (*connect.Response[_go.StoreContentKeyResponse], error)
+	GetContentKey(context.Context, *connect.Request[_go.GetContentKeyRequest]) (*connect.Response[_go.GetContentKeyResponse], error)
+	ServeStoryboard(context.Context, *connect.Request[_go.ServeStoryboardRequest]) (*connect.Response[_go.ServeStoryboardResponse], error)
 }
 
 // NewVideoManagementClient constructs a client for the
@@ -92,15 +152,120 @@ func NewVideoManagementClient(httpClient connect.HTTPClient, baseURL string, opt
 			connect.WithSchema(videoManagementMethods.ByName("ServePlaylist")),
 			connect.WithClientOptions(opts...),
 		),
+		getReelFeed: connect.NewClient[_go.GetReelFeedRequest, _go.GetReelFeedResponse](
+			httpClient,
+			baseURL+VideoManagementGetReelFeedProcedure,
+			connect.WithSchema(videoManagementMethods.ByName("GetReelFeed")),
+			connect.WithClientOptions(opts...),
+		),
+		deleteVideo: connect.NewClient[_go.DeleteVideoRequest, _go.DeleteVideoResponse](
+			httpClient,
+			baseURL+VideoManagementDeleteVideoProcedure,
+			connect.WithSchema(videoManagementMethods.ByName("DeleteVideo")),
+			connect.WithClientOptions(opts...),
+		),
+		updateVideo: connect.NewClient[_go.UpdateVideoRequest, _go.UpdateVideoResponse](
+			httpClient,
+			baseURL+VideoManagementUpdateVideoProcedure,
+			connect.WithSchema(videoManagementMethods.ByName("UpdateVideo")),
+			connect.WithClientOptions(opts...),
+		),
+		recordView: connect.NewClient[_go.RecordViewRequest, _go.RecordViewResponse](
+			httpClient,
+			baseURL+VideoManagementRecordViewProcedure,
+			connect.WithSchema(videoManagementMethods.ByName("RecordView")),
+			connect.WithClientOptions(opts...),
+		),
+		createMultipartUpload: connect.NewClient[_go.CreateMultipartUploadRequest, _go.CreateMultipartUploadResponse](
+			httpClient,
+			baseURL+VideoManagementCreateMultipartUploadProcedure,
+			connect.WithSchema(videoManagementMethods.ByName("CreateMultipartUpload")),
+			connect.WithClientOptions(opts...),
+		),
+		getUploadPartUrls: connect.NewClient[_go.GetUploadPartUrlsRequest, _go.GetUploadPartUrlsResponse](
+			httpClient,
+			baseURL+VideoManagementGetUploadPartUrlsProcedure,
+			connect.WithSchema(videoManagementMethods.ByName("GetUploadPartUrls")),
+			connect.WithClientOptions(opts...),
+		),
+		listUploadedParts: connect.NewClient[_go.ListUploadedPartsRequest, _go.ListUploadedPartsResponse](
+			httpClient,
+			baseURL+VideoManagementListUploadedPartsProcedure,
+			connect.WithSchema(videoManagementMethods.ByName("ListUploadedParts")),
+			connect.WithClientOptions(opts...),
+		),
+		completeMultipartUpload: connect.NewClient[_go.CompleteMultipartUploadRequest, _go.CompleteMultipartUploadResponse](
+			httpClient,
+			baseURL+VideoManagementCompleteMultipartUploadProcedure,
+			connect.WithSchema(videoManagementMethods.ByName("CompleteMultipartUpload")),
+			connect.WithClientOptions(opts...),
+		),
+		abortMultipartUpload: connect.NewClient[_go.AbortMultipartUploadRequest, _go.AbortMultipartUploadResponse](
+			httpClient,
+			baseURL+VideoManagementAbortMultipartUploadProcedure,
+			connect.WithSchema(videoManagementMethods.ByName("AbortMultipartUpload")),
+			connect.WithClientOptions(opts...),
+		),
+		getVideoStatus: connect.NewClient[_go.GetVideoStatusRequest, _go.GetVideoStatusResponse](
+			httpClient,
+			baseURL+VideoManagementGetVideoStatusProcedure,
+			connect.WithSchema(videoManagementMethods.ByName("GetVideoStatus")),
+			connect.WithClientOptions(opts...),
+		),
+		getVideoProcessingStatus: connect.NewClient[_go.GetVideoProcessingStatusRequest, _go.GetVideoProcessingStatusResponse](
+			httpClient,
+			baseURL+VideoManagementGetVideoProcessingStatusProcedure,
+			connect.WithSchema(videoManagementMethods.ByName("GetVideoProcessingStatus")),
+			connect.WithClientOptions(opts...),
+		),
+		watchVideoProgress: connect.NewClient[_go.WatchVideoProgressRequest, _go.WatchVideoProgressResponse](
+			httpClient,
+			baseURL+VideoManagementWatchVideoProgressProcedure,
+			connect.WithSchema(videoManagementMethods.ByName("WatchVideoProgress")),
+			connect.WithClientOptions(opts...),
+		),
+		storeContentKey: connect.NewClient[_go.StoreContentKeyRequest, _go.StoreContentKeyResponse](
+			httpClient,
+			baseURL+VideoManagementStoreContentKeyProcedure,
+			connect.WithSchema(videoManagementMethods.ByName("StoreContentKey")),
+			connect.WithClientOptions(opts...),
+		),
+		getContentKey: connect.NewClient[_go.GetContentKeyRequest, _go.GetContentKeyResponse](
+			httpClient,
+			baseURL+VideoManagementGetContentKeyProcedure,
+			connect.WithSchema(videoManagementMethods.ByName("GetContentKey")),
+			connect.WithClientOptions(opts...),
+		),
+		serveStoryboard: connect.NewClient[_go.ServeStoryboardRequest, _go.ServeStoryboardResponse](
+			httpClient,
+			baseURL+VideoManagementServeStoryboardProcedure,
+			connect.WithSchema(videoManagementMethods.ByName("ServeStoryboard")),
+			connect.WithClientOptions(opts...),
+		),
 	}
 }
 
 // videoManagementClient implements VideoManagementClient.
 type videoManagementClient struct {
-	presignedUrl         *connect.Client[_go.PresignedUrlRequest, _go.PresignedUrlResponse]
-	getChannelVideos     *connect.Client[_go.GetChannelVideosRequest, _go.GetChannelVideosResponse]
-	getVideoMetadataById *connect.Client[_go.GetVideoMetadataByIdRequest, _go.GetVideoMetadataByIdResponse]
-	servePlaylist        *connect.Client[_go.ServePlaylistRequest, _go.ServePlaylistResponse]
+	presignedUrl             *connect.Client[_go.PresignedUrlRequest, _go.PresignedUrlResponse]
+	getChannelVideos         *connect.Client[_go.GetChannelVideosRequest, _go.GetChannelVideosResponse]
+	getVideoMetadataById     *connect.Client[_go.GetVideoMetadataByIdRequest, _go.GetVideoMetadataByIdResponse]
+	servePlaylist            *connect.Client[_go.ServePlaylistRequest, _go.ServePlaylistResponse]
+	getReelFeed              *connect.Client[_go.GetReelFeedRequest, _go.GetReelFeedResponse]
+	deleteVideo              *connect.Client[_go.DeleteVideoRequest, _go.DeleteVideoResponse]
+	updateVideo              *connect.Client[_go.UpdateVideoRequest, _go.UpdateVideoResponse]
+	recordView               *connect.Client[_go.RecordViewRequest, _go.RecordViewResponse]
+	createMultipartUpload    *connect.Client[_go.CreateMultipartUploadRequest, _go.CreateMultipartUploadResponse]
+	getUploadPartUrls        *connect.Client[_go.GetUploadPartUrlsRequest, _go.GetUploadPartUrlsResponse]
+	listUploadedParts        *connect.Client[_go.ListUploadedPartsRequest, _go.ListUploadedPartsResponse]
+	completeMultipartUpload  *connect.Client[_go.CompleteMultipartUploadRequest, _go.CompleteMultipartUploadResponse]
+	abortMultipartUpload     *connect.Client[_go.AbortMultipartUploadRequest, _go.AbortMultipartUploadResponse]
+	getVideoStatus           *connect.Client[_go.GetVideoStatusRequest, _go.GetVideoStatusResponse]
+	getVideoProcessingStatus *connect.Client[_go.GetVideoProcessingStatusRequest, _go.GetVideoProcessingStatusResponse]
+	watchVideoProgress       *connect.Client[_go.WatchVideoProgressRequest, _go.WatchVideoProgressResponse]
+	storeContentKey          *connect.Client[_go.StoreContentKeyRequest, _go.StoreContentKeyResponse]
+	getContentKey            *connect.Client[_go.GetContentKeyRequest, _go.GetContentKeyResponse]
+	serveStoryboard          *connect.Client[_go.ServeStoryboardRequest, _go.ServeStoryboardResponse]
 }
 
 // PresignedUrl calls
@@ -127,6 +292,96 @@ func (c *videoManagementClient) ServePlaylist(ctx context.Context, req *connect.
 	return c.servePlaylist.CallUnary(ctx, req)
 }
 
+// GetReelFeed calls
+// com.sweetloveinyourheart.srl.videomanagement.dataproviders.VideoManagement.GetReelFeed.
+func (c *videoManagementClient) GetReelFeed(ctx context.Context, req *connect.Request[_go.GetReelFeedRequest]) (*connect.Response[_go.GetReelFeedResponse], error) {
+	return c.getReelFeed.CallUnary(ctx, req)
+}
+
+// DeleteVideo calls
+// com.sweetloveinyourheart.srl.videomanagement.dataproviders.VideoManagement.DeleteVideo.
+func (c *videoManagementClient) DeleteVideo(ctx context.Context, req *connect.Request[_go.DeleteVideoRequest]) (*connect.Response[_go.DeleteVideoResponse], error) {
+	return c.deleteVideo.CallUnary(ctx, req)
+}
+
+// UpdateVideo calls
+// com.sweetloveinyourheart.srl.videomanagement.dataproviders.VideoManagement.UpdateVideo.
+func (c *videoManagementClient) UpdateVideo(ctx context.Context, req *connect.Request[_go.UpdateVideoRequest]) (*connect.Response[_go.UpdateVideoResponse], error) {
+	return c.updateVideo.CallUnary(ctx, req)
+}
+
+// RecordView calls
+// com.sweetloveinyourheart.srl.videomanagement.dataproviders.VideoManagement.RecordView.
+func (c *videoManagementClient) RecordView(ctx context.Context, req *connect.Request[_go.RecordViewRequest]) (*connect.Response[_go.RecordViewResponse], error) {
+	return c.recordView.CallUnary(ctx, req)
+}
+
+// CreateMultipartUpload calls
+// com.sweetloveinyourheart.srl.videomanagement.dataproviders.VideoManagement.CreateMultipartUpload.
+func (c *videoManagementClient) CreateMultipartUpload(ctx context.Context, req *connect.Request[_go.CreateMultipartUploadRequest]) (*connect.Response[_go.CreateMultipartUploadResponse], error) {
+	return c.createMultipartUpload.CallUnary(ctx, req)
+}
+
+// GetUploadPartUrls calls
+// com.sweetloveinyourheart.srl.videomanagement.dataproviders.VideoManagement.GetUploadPartUrls.
+func (c *videoManagementClient) GetUploadPartUrls(ctx context.Context, req *connect.Request[_go.GetUploadPartUrlsRequest]) (*connect.Response[_go.GetUploadPartUrlsResponse], error) {
+	return c.getUploadPartUrls.CallUnary(ctx, req)
+}
+
+// ListUploadedParts calls
+// com.sweetloveinyourheart.srl.videomanagement.dataproviders.VideoManagement.ListUploadedParts.
+func (c *videoManagementClient) ListUploadedParts(ctx context.Context, req *connect.Request[_go.ListUploadedPartsRequest]) (*connect.Response[_go.ListUploadedPartsResponse], error) {
+	return c.listUploadedParts.CallUnary(ctx, req)
+}
+
+// CompleteMultipartUpload calls
+// com.sweetloveinyourheart.srl.videomanagement.dataproviders.VideoManagement.CompleteMultipartUpload.
+func (c *videoManagementClient) CompleteMultipartUpload(ctx context.Context, req *connect.Request[_go.CompleteMultipartUploadRequest]) (*connect.Response[_go.CompleteMultipartUploadResponse], error) {
+	return c.completeMultipartUpload.CallUnary(ctx, req)
+}
+
+// AbortMultipartUpload calls
+// com.sweetloveinyourheart.srl.videomanagement.dataproviders.VideoManagement.AbortMultipartUpload.
+func (c *videoManagementClient) AbortMultipartUpload(ctx context.Context, req *connect.Request[_go.AbortMultipartUploadRequest]) (*connect.Response[_go.AbortMultipartUploadResponse], error) {
+	return c.abortMultipartUpload.CallUnary(ctx, req)
+}
+
+// GetVideoStatus calls
+// com.sweetloveinyourheart.srl.videomanagement.dataproviders.VideoManagement.GetVideoStatus.
+func (c *videoManagementClient) GetVideoStatus(ctx context.Context, req *connect.Request[_go.GetVideoStatusRequest]) (*connect.Response[_go.GetVideoStatusResponse], error) {
+	return c.getVideoStatus.CallUnary(ctx, req)
+}
+
+// GetVideoProcessingStatus calls
+// com.sweetloveinyourheart.srl.videomanagement.dataproviders.VideoManagement.GetVideoProcessingStatus.
+func (c *videoManagementClient) GetVideoProcessingStatus(ctx context.Context, req *connect.Request[_go.GetVideoProcessingStatusRequest]) (*connect.Response[_go.GetVideoProcessingStatusResponse], error) {
+	return c.getVideoProcessingStatus.CallUnary(ctx, req)
+}
+
+// WatchVideoProgress calls
+// com.sweetloveinyourheart.srl.videomanagement.dataproviders.VideoManagement.WatchVideoProgress.
+func (c *videoManagementClient) WatchVideoProgress(ctx context.Context, req *connect.Request[_go.WatchVideoProgressRequest]) (*connect.ServerStreamForClient[_go.WatchVideoProgressResponse], error) {
+	return c.watchVideoProgress.CallServerStream(ctx, req)
+}
+
+// StoreContentKey calls
+// com.sweetloveinyourheart.srl.videomanagement.dataproviders.VideoManagement.StoreContentKey.
+func (c *videoManagementClient) StoreContentKey(ctx context.Context, req *connect.Request[_go.StoreContentKeyRequest]) (*connect.Response[_go.StoreContentKeyResponse], error) {
+	return c.storeContentKey.CallUnary(ctx, req)
+}
+
+// GetContentKey calls
+// com.sweetloveinyourheart.srl.videomanagement.dataproviders.VideoManagement.GetContentKey.
+func (c *videoManagementClient) GetContentKey(ctx context.Context, req *connect.Request[_go.GetContentKeyRequest]) (*connect.Response[_go.GetContentKeyResponse], error) {
+	return c.getContentKey.CallUnary(ctx, req)
+}
+
+// ServeStoryboard calls
+// com.sweetloveinyourheart.srl.videomanagement.dataproviders.VideoManagement.ServeStoryboard.
+func (c *videoManagementClient) ServeStoryboard(ctx context.Context, req *connect.Request[_go.ServeStoryboardRequest]) (*connect.Response[_go.ServeStoryboardResponse], error) {
+	return c.serveStoryboard.CallUnary(ctx, req)
+}
+
 // VideoManagementHandler is an implementation of the
 // com.sweetloveinyourheart.srl.videomanagement.dataproviders.VideoManagement service.
 type VideoManagementHandler interface {
@@ -134,6 +389,21 @@ type VideoManagementHandler interface {
 	GetChannelVideos(context.Context, *connect.Request[_go.GetChannelVideosRequest]) (*connect.Response[_go.GetChannelVideosResponse], error)
 	GetVideoMetadataById(context.Context, *connect.Request[_go.GetVideoMetadataByIdRequest]) (*connect.Response[_go.GetVideoMetadataByIdResponse], error)
 	ServePlaylist(context.Context, *connect.Request[_go.ServePlaylistRequest]) (*connect.Response[_go.ServePlaylistResponse], error)
+	GetReelFeed(context.Context, *connect.Request[_go.GetReelFeedRequest]) (*connect.Response[_go.GetReelFeedResponse], error)
+	DeleteVideo(context.Context, *connect.Request[_go.DeleteVideoRequest]) (*connect.Response[_go.DeleteVideoResponse], error)
+	UpdateVideo(context.Context, *connect.Request[_go.UpdateVideoRequest]) (*connect.Response[_go.UpdateVideoResponse], error)
+	RecordView(context.Context, *connect.Request[_go.RecordViewRequest]) (*connect.Response[_go.RecordViewResponse], error)
+	CreateMultipartUpload(context.Context, *connect.Request[_go.CreateMultipartUploadRequest]) (*connect.Response[_go.CreateMultipartUploadResponse], error)
+	GetUploadPartUrls(context.Context, *connect.Request[_go.GetUploadPartUrlsRequest]) (*connect.Response[_go.GetUploadPartUrlsResponse], error)
+	ListUploadedParts(context.Context, *connect.Request[_go.ListUploadedPartsRequest]) (*connect.Response[_go.ListUploadedPartsResponse], error)
+	CompleteMultipartUpload(context.Context, *connect.Request[_go.CompleteMultipartUploadRequest]) (*connect.Response[_go.CompleteMultipartUploadResponse], error)
+	AbortMultipartUpload(context.Context, *connect.Request[_go.AbortMultipartUploadRequest]) (*connect.Response[_go.AbortMultipartUploadResponse], error)
+	GetVideoStatus(context.Context, *connect.Request[_go.GetVideoStatusRequest]) (*connect.Response[_go.GetVideoStatusResponse], error)
+	GetVideoProcessingStatus(context.Context, *connect.Request[_go.GetVideoProcessingStatusRequest]) (*connect.Response[_go.GetVideoProcessingStatusResponse], error)
+	WatchVideoProgress(context.Context, *connect.Request[_go.WatchVideoProgressRequest], *connect.ServerStream[_go.WatchVideoProgressResponse]) error
+	StoreContentKey(context.Context, *connect.Request[_go.StoreContentKeyRequest]) (*connect.Response[_go.StoreContentKeyResponse], error)
+	GetContentKey(context.Context, *connect.Request[_go.GetContentKeyRequest]) (*connect.Response[_go.GetContentKeyResponse], error)
+	ServeStoryboard(context.Context, *connect.Request[_go.ServeStoryboardRequest]) (*connect.Response[_go.ServeStoryboardResponse], error)
 }
 
 // NewVideoManagementHandler builds an HTTP handler from the service implementation. It returns the
@@ -167,6 +437,96 @@ func NewVideoManagementHandler(svc VideoManagementHandler, opts ...connect.Handl
 		connect.WithSchema(videoManagementMethods.ByName("ServePlaylist")),
 		connect.WithHandlerOptions(opts...),
 	)
+	videoManagementGetReelFeedHandler := connect.NewUnaryHandler(
+		VideoManagementGetReelFeedProcedure,
+		svc.GetReelFeed,
+		connect.WithSchema(videoManagementMethods.ByName("GetReelFeed")),
+		connect.WithHandlerOptions(opts...),
+	)
+	videoManagementDeleteVideoHandler := connect.NewUnaryHandler(
+		VideoManagementDeleteVideoProcedure,
+		svc.DeleteVideo,
+		connect.WithSchema(videoManagementMethods.ByName("DeleteVideo")),
+		connect.WithHandlerOptions(opts...),
+	)
+	videoManagementUpdateVideoHandler := connect.NewUnaryHandler(
+		VideoManagementUpdateVideoProcedure,
+		svc.UpdateVideo,
+		connect.WithSchema(videoManagementMethods.ByName("UpdateVideo")),
+		connect.WithHandlerOptions(opts...),
+	)
+	videoManagementRecordViewHandler := connect.NewUnaryHandler(
+		VideoManagementRecordViewProcedure,
+		svc.RecordView,
+		connect.WithSchema(videoManagementMethods.ByName("RecordView")),
+		connect.WithHandlerOptions(opts...),
+	)
+	videoManagementCreateMultipartUploadHandler := connect.NewUnaryHandler(
+		VideoManagementCreateMultipartUploadProcedure,
+		svc.CreateMultipartUpload,
+		connect.WithSchema(videoManagementMethods.ByName("CreateMultipartUpload")),
+		connect.WithHandlerOptions(opts...),
+	)
+	videoManagementGetUploadPartUrlsHandler := connect.NewUnaryHandler(
+		VideoManagementGetUploadPartUrlsProcedure,
+		svc.GetUploadPartUrls,
+		connect.WithSchema(videoManagementMethods.ByName("GetUploadPartUrls")),
+		connect.WithHandlerOptions(opts...),
+	)
+	videoManagementListUploadedPartsHandler := connect.NewUnaryHandler(
+		VideoManagementListUploadedPartsProcedure,
+		svc.ListUploadedParts,
+		connect.WithSchema(videoManagementMethods.ByName("ListUploadedParts")),
+		connect.WithHandlerOptions(opts...),
+	)
+	videoManagementCompleteMultipartUploadHandler := connect.NewUnaryHandler(
+		VideoManagementCompleteMultipartUploadProcedure,
+		svc.CompleteMultipartUpload,
+		connect.WithSchema(videoManagementMethods.ByName("CompleteMultipartUpload")),
+		connect.WithHandlerOptions(opts...),
+	)
+	videoManagementAbortMultipartUploadHandler := connect.NewUnaryHandler(
+		VideoManagementAbortMultipartUploadProcedure,
+		svc.AbortMultipartUpload,
+		connect.WithSchema(videoManagementMethods.ByName("AbortMultipartUpload")),
+		connect.WithHandlerOptions(opts...),
+	)
+	videoManagementGetVideoStatusHandler := connect.NewUnaryHandler(
+		VideoManagementGetVideoStatusProcedure,
+		svc.GetVideoStatus,
+		connect.WithSchema(videoManagementMethods.ByName("GetVideoStatus")),
+		connect.WithHandlerOptions(opts...),
+	)
+	videoManagementGetVideoProcessingStatusHandler := connect.NewUnaryHandler(
+		VideoManagementGetVideoProcessingStatusProcedure,
+		svc.GetVideoProcessingStatus,
+		connect.WithSchema(videoManagementMethods.ByName("GetVideoProcessingStatus")),
+		connect.WithHandlerOptions(opts...),
+	)
+	videoManagementWatchVideoProgressHandler := connect.NewServerStreamHandler(
+		VideoManagementWatchVideoProgressProcedure,
+		svc.WatchVideoProgress,
+		connect.WithSchema(videoManagementMethods.ByName("WatchVideoProgress")),
+		connect.WithHandlerOptions(opts...),
+	)
+	videoManagementStoreContentKeyHandler := connect.NewUnaryHandler(
+		VideoManagementStoreContentKeyProcedure,
+		svc.StoreContentKey,
+		connect.WithSchema(videoManagementMethods.ByName("StoreContentKey")),
+		connect.WithHandlerOptions(opts...),
+	)
+	videoManagementGetContentKeyHandler := connect.NewUnaryHandler(
+		VideoManagementGetContentKeyProcedure,
+		svc.GetContentKey,
+		connect.WithSchema(videoManagementMethods.ByName("GetContentKey")),
+		connect.WithHandlerOptions(opts...),
+	)
+	videoManagementServeStoryboardHandler := connect.NewUnaryHandler(
+		VideoManagementServeStoryboardProcedure,
+		svc.ServeStoryboard,
+		connect.WithSchema(videoManagementMethods.ByName("ServeStoryboard")),
+		connect.WithHandlerOptions(opts...),
+	)
 	return "/com.sweetloveinyourheart.srl.videomanagement.dataproviders.VideoManagement/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
 		case VideoManagementPresignedUrlProcedure:
@@ -177,6 +537,36 @@ func NewVideoManagementHandler(svc VideoManagementHandler, opts ...connect.Handl
 			videoManagementGetVideoMetadataByIdHandler.ServeHTTP(w, r)
 		case VideoManagementServePlaylistProcedure:
 			videoManagementServePlaylistHandler.ServeHTTP(w, r)
+		case VideoManagementGetReelFeedProcedure:
+			videoManagementGetReelFeedHandler.ServeHTTP(w, r)
+		case VideoManagementDeleteVideoProcedure:
+			videoManagementDeleteVideoHandler.ServeHTTP(w, r)
+		case VideoManagementUpdateVideoProcedure:
+			videoManagementUpdateVideoHandler.ServeHTTP(w, r)
+		case VideoManagementRecordViewProcedure:
+			videoManagementRecordViewHandler.ServeHTTP(w, r)
+		case VideoManagementCreateMultipartUploadProcedure:
+			videoManagementCreateMultipartUploadHandler.ServeHTTP(w, r)
+		case VideoManagementGetUploadPartUrlsProcedure:
+			videoManagementGetUploadPartUrlsHandler.ServeHTTP(w, r)
+		case VideoManagementListUploadedPartsProcedure:
+			videoManagementListUploadedPartsHandler.ServeHTTP(w, r)
+		case VideoManagementCompleteMultipartUploadProcedure:
+			videoManagementCompleteMultipartUploadHandler.ServeHTTP(w, r)
+		case VideoManagementAbortMultipartUploadProcedure:
+			videoManagementAbortMultipartUploadHandler.ServeHTTP(w, r)
+		case VideoManagementGetVideoStatusProcedure:
+			videoManagementGetVideoStatusHandler.ServeHTTP(w, r)
+		case VideoManagementGetVideoProcessingStatusProcedure:
+			videoManagementGetVideoProcessingStatusHandler.ServeHTTP(w, r)
+		case VideoManagementWatchVideoProgressProcedure:
+			videoManagementWatchVideoProgressHandler.ServeHTTP(w, r)
+		case VideoManagementStoreContentKeyProcedure:
+			videoManagementStoreContentKeyHandler.ServeHTTP(w, r)
+		case VideoManagementGetContentKeyProcedure:
+			videoManagementGetContentKeyHandler.ServeHTTP(w, r)
+		case VideoManagementServeStoryboardProcedure:
+			videoManagementServeStoryboardHandler.ServeHTTP(w, r)
 		default:
 			http.NotFound(w, r)
 		}
@@ -201,3 +591,63 @@ func (UnimplementedVideoManagementHandler) GetVideoMetadataById(context.Context,
 func (UnimplementedVideoManagementHandler) ServePlaylist(context.Context, *connect.Request[_go.ServePlaylistRequest]) (*connect.Response[_go.ServePlaylistResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("com.sweetloveinyourheart.srl.videomanagement.dataproviders.VideoManagement.ServePlaylist is not implemented"))
 }
+
+func (UnimplementedVideoManagementHandler) GetReelFeed(context.Context, *connect.Request[_go.GetReelFeedRequest]) (*connect.Response[_go.GetReelFeedResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("com.sweetloveinyourheart.srl.videomanagement.dataproviders.VideoManagement.GetReelFeed is not implemented"))
+}
+
+func (UnimplementedVideoManagementHandler) DeleteVideo(context.Context, *connect.Request[_go.DeleteVideoRequest]) (*connect.Response[_go.DeleteVideoResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("com.sweetloveinyourheart.srl.videomanagement.dataproviders.VideoManagement.DeleteVideo is not implemented"))
+}
+
+func (UnimplementedVideoManagementHandler) UpdateVideo(context.Context, *connect.Request[_go.UpdateVideoRequest]) (*connect.Response[_go.UpdateVideoResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("com.sweetloveinyourheart.srl.videomanagement.dataproviders.VideoManagement.UpdateVideo is not implemented"))
+}
+
+func (UnimplementedVideoManagementHandler) RecordView(context.Context, *connect.Request[_go.RecordViewRequest]) (*connect.Response[_go.RecordViewResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("com.sweetloveinyourheart.srl.videomanagement.dataproviders.VideoManagement.RecordView is not implemented"))
+}
+
+func (UnimplementedVideoManagementHandler) CreateMultipartUpload(context.Context, *connect.Request[_go.CreateMultipartUploadRequest]) (*connect.Response[_go.CreateMultipartUploadResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("com.sweetloveinyourheart.srl.videomanagement.dataproviders.VideoManagement.CreateMultipartUpload is not implemented"))
+}
+
+func (UnimplementedVideoManagementHandler) GetUploadPartUrls(context.Context, *connect.Request[_go.GetUploadPartUrlsRequest]) (*connect.Response[_go.GetUploadPartUrlsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("com.sweetloveinyourheart.srl.videomanagement.dataproviders.VideoManagement.GetUploadPartUrls is not implemented"))
+}
+
+func (UnimplementedVideoManagementHandler) ListUploadedParts(context.Context, *connect.Request[_go.ListUploadedPartsRequest]) (*connect.Response[_go.ListUploadedPartsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("com.sweetloveinyourheart.srl.videomanagement.dataproviders.VideoManagement.ListUploadedParts is not implemented"))
+}
+
+func (UnimplementedVideoManagementHandler) CompleteMultipartUpload(context.Context, *connect.Request[_go.CompleteMultipartUploadRequest]) (*connect.Response[_go.CompleteMultipartUploadResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("com.sweetloveinyourheart.srl.videomanagement.dataproviders.VideoManagement.CompleteMultipartUpload is not implemented"))
+}
+
+func (UnimplementedVideoManagementHandler) AbortMultipartUpload(context.Context, *connect.Request[_go.AbortMultipartUploadRequest]) (*connect.Response[_go.AbortMultipartUploadResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("com.sweetloveinyourheart.srl.videomanagement.dataproviders.VideoManagement.AbortMultipartUpload is not implemented"))
+}
+
+func (UnimplementedVideoManagementHandler) GetVideoStatus(context.Context, *connect.Request[_go.GetVideoStatusRequest]) (*connect.Response[_go.GetVideoStatusResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("com.sweetloveinyourheart.srl.videomanagement.dataproviders.VideoManagement.GetVideoStatus is not implemented"))
+}
+
+func (UnimplementedVideoManagementHandler) GetVideoProcessingStatus(context.Context, *connect.Request[_go.GetVideoProcessingStatusRequest]) (*connect.Response[_go.GetVideoProcessingStatusResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("com.sweetloveinyourheart.srl.videomanagement.dataproviders.VideoManagement.GetVideoProcessingStatus is not implemented"))
+}
+
+func (UnimplementedVideoManagementHandler) WatchVideoProgress(context.Context, *connect.Request[_go.WatchVideoProgressRequest], *connect.ServerStream[_go.WatchVideoProgressResponse]) error {
+	return connect.NewError(connect.CodeUnimplemented, errors.New("com.sweetloveinyourheart.srl.videomanagement.dataproviders.VideoManagement.WatchVideoProgress is not implemented"))
+}
+
+func (UnimplementedVideoManagementHandler) StoreContentKey(context.Context, *connect.Request[_go.StoreContentKeyRequest]) (*connect.Response[_go.StoreContentKeyResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("com.sweetloveinyourheart.srl.videomanagement.dataproviders.VideoManagement.StoreContentKey is not implemented"))
+}
+
+func (UnimplementedVideoManagementHandler) GetContentKey(context.Context, *connect.Request[_go.GetContentKeyRequest]) (*connect.Response[_go.GetContentKeyResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("com.sweetloveinyourheart.srl.videomanagement.dataproviders.VideoManagement.GetContentKey is not implemented"))
+}
+
+func (UnimplementedVideoManagementHandler) ServeStoryboard(context.Context, *connect.Request[_go.ServeStoryboardRequest]) (*connect.Response[_go.ServeStoryboardResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("com.sweetloveinyourheart.srl.videomanagement.dataproviders.VideoManagement.ServeStoryboard is not implemented"))
+}
@@ -102,6 +102,7 @@ type PresignedUrlResponse struct {
 	VideoId       string                 `protobuf:"bytes,1,opt,name=video_id,json=videoId,proto3" json:"video_id,omitempty"`
 	PresignedUrl  string                 `protobuf:"bytes,2,opt,name=presigned_url,json=presignedUrl,proto3" json:"presigned_url,omitempty"`
 	ExpiresIn     int32                  `protobuf:"varint,3,opt,name=expires_in,json=expiresIn,proto3" json:"expires_in,omitempty"`
+	UploadHeaders map[string]string      `protobuf:"bytes,4,rep,name=upload_headers,json=uploadHeaders,proto3" json:"upload_headers,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // Headers the upload to presigned_url must send
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -157,11 +158,19 @@ func (x *PresignedUrlResponse) GetExpiresIn() int32 {
 	return 0
 }
 
+func (x *PresignedUrlResponse) GetUploadHeaders() map[string]string {
+	if x != nil {
+		return x.UploadHeaders
+	}
+	return nil
+}
+
 type GetChannelVideosRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	ChannelId     string                 `protobuf:"bytes,1,opt,name=channel_id,json=channelId,proto3" json:"channel_id,omitempty"`
 	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
 	Offset        int32                  `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"`
+	ViewerId      string                 `protobuf:"bytes,4,opt,name=viewer_id,json=viewerId,proto3" json:"viewer_id,omitempty"` // Empty for anonymous viewers
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -217,6 +226,13 @@ func (x *GetChannelVideosRequest) GetOffset() int32 {
 	return 0
 }
 
+func (x *GetChannelVideosRequest) GetViewerId() string {
+	if x != nil {
+		return x.ViewerId
+	}
+	return ""
+}
+
 type ChannelVideo struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	VideoId       string                 `protobuf:"bytes,1,opt,name=video_id,json=videoId,proto3" json:"video_id,omitempty"`
@@ -348,6 +364,7 @@ func (x *GetChannelVideosResponse) GetVideos() []*ChannelVideo {
 type GetVideoMetadataByIdRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	VideoId       string                 `protobuf:"bytes,1,opt,name=video_id,json=videoId,proto3" json:"video_id,omitempty"`
+	ViewerId      string                 `protobuf:"bytes,2,opt,name=viewer_id,json=viewerId,proto3" json:"viewer_id,omitempty"` // Empty for anonymous viewers
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -389,6 +406,13 @@ func (x *GetVideoMetadataByIdRequest) GetVideoId() string {
 	return ""
 }
 
+func (x *GetVideoMetadataByIdRequest) GetViewerId() string {
+	if x != nil {
+		return x.ViewerId
+	}
+	return ""
+}
+
 type GetVideoMetadataByIdResponse struct {
 	state              protoimpl.MessageState `protogen:"open.v1"`
 	VideoId            string                 `protobuf:"bytes,1,opt,name=video_id,json=videoId,proto3" json:"video_id,omitempty"`
@@ -398,6 +422,9 @@ type GetVideoMetadataByIdResponse struct {
 	TotalView          int64                  `protobuf:"varint,5,opt,name=total_view,json=totalView,proto3" json:"total_view,omitempty"`
 	AvailableQualities []string               `protobuf:"bytes,6,rep,name=available_qualities,json=availableQualities,proto3" json:"available_qualities,omitempty"`
 	ProcessedAt        int64                  `protobuf:"varint,7,opt,name=processed_at,json=processedAt,proto3" json:"processed_at,omitempty"`
+	Format             string                 `protobuf:"bytes,8,opt,name=format,proto3" json:"format,omitempty"`
+	Visibility         string                 `protobuf:"bytes,9,opt,name=visibility,proto3" json:"visibility,omitempty"`
+	StoryboardUrl      string                 `protobuf:"bytes,10,opt,name=storyboard_url,json=storyboardUrl,proto3" json:"storyboard_url,omitempty"` // WebVTT track of scrubbing previews relative to the video, empty when it has none
 	unknownFields      protoimpl.UnknownFields
 	sizeCache          protoimpl.SizeCache
 }
@@ -481,9 +508,33 @@ func (x *GetVideoMetadataByIdResponse) GetProcessedAt() int64 {
 	return 0
 }
 
+func (x *GetVideoMetadataByIdResponse) GetFormat() string {
+	if x != nil {
+		return x.Format
+	}
+	return ""
+}
+
+func (x *GetVideoMetadataByIdResponse) GetVisibility() string {
+	if x != nil {
+		return x.Visibility
+	}
+	return ""
+}
+
+func (x *GetVideoMetadataByIdResponse) GetStoryboardUrl() string {
+	if x != nil {
+		return x.StoryboardUrl
+	}
+	return ""
+}
+
 type ServePlaylistRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	VideoId       string                 `protobuf:"bytes,1,opt,name=video_id,json=videoId,proto3" json:"video_id,omitempty"`
+	ViewerId      string                 `protobuf:"bytes,2,opt,name=viewer_id,json=viewerId,proto3" json:"viewer_id,omitempty"` // Empty for anonymous viewers
+	Quality       string                 `protobuf:"bytes,3,opt,name=quality,proto3" json:"quality,omitempty"`                   // Empty for the master playlist
+	Format        string                 `protobuf:"bytes,4,opt,name=format,proto3" json:"format,omitempty"`                     // "hls" or "dash", empty for hls. DASH manifests list every quality
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -525,29 +576,50 @@ func (x *ServePlaylistRequest) GetVideoId() string {
 	return ""
 }
 
-type ServePlaylistVariant struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Quality       string                 `protobuf:"bytes,1,opt,name=quality,proto3" json:"quality,omitempty"`
-	PlaylistUrl   string                 `protobuf:"bytes,2,opt,name=playlist_url,json=playlistUrl,proto3" json:"playlist_url,omitempty"`
-	SegmentUrls   []string               `protobuf:"bytes,3,rep,name=segment_urls,json=segmentUrls,proto3" json:"segment_urls,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *ServePlaylistRequest) GetViewerId() string {
+	if x != nil {
+		return x.ViewerId
+	}
+	return ""
+}
+
+func (x *ServePlaylistRequest) GetQuality() string {
+	if x != nil {
+		return x.Quality
+	}
+	return ""
+}
+
+func (x *ServePlaylistRequest) GetFormat() string {
+	if x != nil {
+		return x.Format
+	}
+	return ""
+}
+
+type ServePlaylistResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Playlist        string                 `protobuf:"bytes,3,opt,name=playlist,proto3" json:"playlist,omitempty"`                                        // m3u8 or mpd document with variant and segment URIs rewritten for playback
+	MaxAgeSeconds   int32                  `protobuf:"varint,4,opt,name=max_age_seconds,json=maxAgeSeconds,proto3" json:"max_age_seconds,omitempty"`      // How long the playlist may be cached, its signed URLs outlive it
+	DashManifestUrl string                 `protobuf:"bytes,5,opt,name=dash_manifest_url,json=dashManifestUrl,proto3" json:"dash_manifest_url,omitempty"` // Set on master playlists of videos packaged for DASH too, relative to the master playlist
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
-func (x *ServePlaylistVariant) Reset() {
-	*x = ServePlaylistVariant{}
+func (x *ServePlaylistResponse) Reset() {
+	*x = ServePlaylistResponse{}
 	mi := &file_video_management_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ServePlaylistVariant) String() string {
+func (x *ServePlaylistResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ServePlaylistVariant) ProtoMessage() {}
+func (*ServePlaylistResponse) ProtoMessage() {}
 
-func (x *ServePlaylistVariant) ProtoReflect() protoreflect.Message {
+func (x *ServePlaylistResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_video_management_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -559,54 +631,54 @@ func (x *ServePlaylistVariant) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ServePlaylistVariant.ProtoReflect.Descriptor instead.
-func (*ServePlaylistVariant) Descriptor() ([]byte, []int) {
+// Deprecated: Use ServePlaylistResponse.ProtoReflect.Descriptor instead.
+func (*ServePlaylistResponse) Descriptor() ([]byte, []int) {
 	return file_video_management_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *ServePlaylistVariant) GetQuality() string {
+func (x *ServePlaylistResponse) GetPlaylist() string {
 	if x != nil {
-		return x.Quality
+		return x.Playlist
 	}
 	return ""
 }
 
-func (x *ServePlaylistVariant) GetPlaylistUrl() string {
+func (x *ServePlaylistResponse) GetMaxAgeSeconds() int32 {
 	if x != nil {
-		return x.PlaylistUrl
+		return x.MaxAgeSeconds
 	}
-	return ""
+	return 0
 }
 
-func (x *ServePlaylistVariant) GetSegmentUrls() []string {
+func (x *ServePlaylistResponse) GetDashManifestUrl() string {
 	if x != nil {
-		return x.SegmentUrls
+		return x.DashManifestUrl
 	}
-	return nil
+	return ""
 }
 
-type ServePlaylistResponse struct {
-	state         protoimpl.MessageState  `protogen:"open.v1"`
-	PlaylistUrl   string                  `protobuf:"bytes,1,opt,name=playlist_url,json=playlistUrl,proto3" json:"playlist_url,omitempty"`
-	Variants      []*ServePlaylistVariant `protobuf:"bytes,2,rep,name=variants,proto3" json:"variants,omitempty"`
+type GetReelFeedRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Limit         int32                  `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
+	Offset        int32                  `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ServePlaylistResponse) Reset() {
-	*x = ServePlaylistResponse{}
+func (x *GetReelFeedRequest) Reset() {
+	*x = GetReelFeedRequest{}
 	mi := &file_video_management_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ServePlaylistResponse) String() string {
+func (x *GetReelFeedRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ServePlaylistResponse) ProtoMessage() {}
+func (*GetReelFeedRequest) ProtoMessage() {}
 
-func (x *ServePlaylistResponse) ProtoReflect() protoreflect.Message {
+func (x *GetReelFeedRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_video_management_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -618,86 +690,2400 @@ func (x *ServePlaylistResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ServePlaylistResponse.ProtoReflect.Descriptor instead.
-func (*ServePlaylistResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use GetReelFeedRequest.ProtoReflect.Descriptor instead.
+func (*GetReelFeedRequest) Descriptor() ([]byte, []int) {
 	return file_video_management_proto_rawDescGZIP(), []int{9}
 }
 
-func (x *ServePlaylistResponse) GetPlaylistUrl() string {
+func (x *GetReelFeedRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *GetReelFeedRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+type ReelFeedItem struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	VideoId       string                 `protobuf:"bytes,1,opt,name=video_id,json=videoId,proto3" json:"video_id,omitempty"`
+	ChannelId     string                 `protobuf:"bytes,2,opt,name=channel_id,json=channelId,proto3" json:"channel_id,omitempty"`
+	VideoTitle    string                 `protobuf:"bytes,3,opt,name=video_title,json=videoTitle,proto3" json:"video_title,omitempty"`
+	ThumbnailUrl  string                 `protobuf:"bytes,4,opt,name=thumbnail_url,json=thumbnailUrl,proto3" json:"thumbnail_url,omitempty"`
+	TotalDuration int32                  `protobuf:"varint,5,opt,name=total_duration,json=totalDuration,proto3" json:"total_duration,omitempty"`
+	TotalView     int64                  `protobuf:"varint,6,opt,name=total_view,json=totalView,proto3" json:"total_view,omitempty"`
+	ProcessedAt   int64                  `protobuf:"varint,7,opt,name=processed_at,json=processedAt,proto3" json:"processed_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReelFeedItem) Reset() {
+	*x = ReelFeedItem{}
+	mi := &file_video_management_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReelFeedItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReelFeedItem) ProtoMessage() {}
+
+func (x *ReelFeedItem) ProtoReflect() protoreflect.Message {
+	mi := &file_video_management_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReelFeedItem.ProtoReflect.Descriptor instead.
+func (*ReelFeedItem) Descriptor() ([]byte, []int) {
+	return file_video_management_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ReelFeedItem) GetVideoId() string {
+	if x != nil {
+		return x.VideoId
+	}
+	return ""
+}
+
+func (x *ReelFeedItem) GetChannelId() string {
+	if x != nil {
+		return x.ChannelId
+	}
+	return ""
+}
+
+func (x *ReelFeedItem) GetVideoTitle() string {
+	if x != nil {
+		return x.VideoTitle
+	}
+	return ""
+}
+
+func (x *ReelFeedItem) GetThumbnailUrl() string {
 	if x != nil {
-		return x.PlaylistUrl
+		return x.ThumbnailUrl
 	}
 	return ""
 }
 
-func (x *ServePlaylistResponse) GetVariants() []*ServePlaylistVariant {
+func (x *ReelFeedItem) GetTotalDuration() int32 {
+	if x != nil {
+		return x.TotalDuration
+	}
+	return 0
+}
+
+func (x *ReelFeedItem) GetTotalView() int64 {
+	if x != nil {
+		return x.TotalView
+	}
+	return 0
+}
+
+func (x *ReelFeedItem) GetProcessedAt() int64 {
+	if x != nil {
+		return x.ProcessedAt
+	}
+	return 0
+}
+
+type GetReelFeedResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Reels         []*ReelFeedItem        `protobuf:"bytes,1,rep,name=reels,proto3" json:"reels,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetReelFeedResponse) Reset() {
+	*x = GetReelFeedResponse{}
+	mi := &file_video_management_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetReelFeedResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetReelFeedResponse) ProtoMessage() {}
+
+func (x *GetReelFeedResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_video_management_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetReelFeedResponse.ProtoReflect.Descriptor instead.
+func (*GetReelFeedResponse) Descriptor() ([]byte, []int) {
+	return file_video_management_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *GetReelFeedResponse) GetReels() []*ReelFeedItem {
 	if x != nil {
-		return x.Variants
+		return x.Reels
 	}
 	return nil
 }
 
-var File_video_management_proto protoreflect.FileDescriptor
+type DeleteVideoRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	VideoId       string                 `protobuf:"bytes,1,opt,name=video_id,json=videoId,proto3" json:"video_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
 
-const file_video_management_proto_rawDesc = "" +
-	"\n" +
-	"\x16video_management.proto\x12:com.sweetloveinyourheart.srl.videomanagement.dataproviders\"\xaa\x01\n" +
-	"\x13PresignedUrlRequest\x12\x14\n" +
-	"\x05title\x18\x01 \x01(\tR\x05title\x12 \n" +
-	"\vdescription\x18\x02 \x01(\tR\vdescription\x12\x1b\n" +
-	"\tfile_name\x18\x03 \x01(\tR\bfileName\x12\x1f\n" +
-	"\vuploader_id\x18\x04 \x01(\tR\n" +
-	"uploaderId\x12\x1d\n" +
-	"\n" +
-	"channel_id\x18\x05 \x01(\tR\tchannelId\"u\n" +
-	"\x14PresignedUrlResponse\x12\x19\n" +
-	"\bvideo_id\x18\x01 \x01(\tR\avideoId\x12#\n" +
-	"\rpresigned_url\x18\x02 \x01(\tR\fpresignedUrl\x12\x1d\n" +
-	"\n" +
-	"expires_in\x18\x03 \x01(\x05R\texpiresIn\"f\n" +
-	"\x17GetChannelVideosRequest\x12\x1d\n" +
-	"\n" +
-	"channel_id\x18\x01 \x01(\tR\tchannelId\x12\x14\n" +
-	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x16\n" +
-	"\x06offset\x18\x03 \x01(\x05R\x06offset\"\xd8\x01\n" +
-	"\fChannelVideo\x12\x19\n" +
-	"\bvideo_id\x18\x01 \x01(\tR\avideoId\x12\x1f\n" +
-	"\vvideo_title\x18\x02 \x01(\tR\n" +
-	"videoTitle\x12#\n" +
-	"\rthumbnail_url\x18\x03 \x01(\tR\fthumbnailUrl\x12%\n" +
-	"\x0etotal_duration\x18\x04 \x01(\x05R\rtotalDuration\x12\x1d\n" +
-	"\n" +
-	"total_view\x18\x05 \x01(\x03R\ttotalView\x12!\n" +
-	"\fprocessed_at\x18\x06 \x01(\x03R\vprocessedAt\"|\n" +
-	"\x18GetChannelVideosResponse\x12`\n" +
-	"\x06videos\x18\x01 \x03(\v2H.com.sweetloveinyourheart.srl.videomanagement.dataproviders.ChannelVideoR\x06videos\"8\n" +
-	"\x1bGetVideoMetadataByIdRequest\x12\x19\n" +
-	"\bvideo_id\x18\x01 \x01(\tR\avideoId\"\x99\x02\n" +
-	"\x1cGetVideoMetadataByIdResponse\x12\x19\n" +
-	"\bvideo_id\x18\x01 \x01(\tR\avideoId\x12\x1d\n" +
-	"\n" +
-	"channel_id\x18\x02 \x01(\tR\tchannelId\x12\x1f\n" +
-	"\vvideo_title\x18\x03 \x01(\tR\n" +
-	"videoTitle\x12+\n" +
-	"\x11video_description\x18\x04 \x01(\tR\x10videoDescription\x12\x1d\n" +
-	"\n" +
-	"total_view\x18\x05 \x01(\x03R\ttotalView\x12/\n" +
-	"\x13available_qualities\x18\x06 \x03(\tR\x12availableQualities\x12!\n" +
-	"\fprocessed_at\x18\a \x01(\x03R\vprocessedAt\"1\n" +
-	"\x14ServePlaylistRequest\x12\x19\n" +
-	"\bvideo_id\x18\x01 \x01(\tR\avideoId\"v\n" +
-	"\x14ServePlaylistVariant\x12\x18\n" +
-	"\aquality\x18\x01 \x01(\tR\aquality\x12!\n" +
-	"\fplaylist_url\x18\x02 \x01(\tR\vplaylistUrl\x12!\n" +
-	"\fsegment_urls\x18\x03 \x03(\tR\vsegmentUrls\"\xa8\x01\n" +
-	"\x15ServePlaylistResponse\x12!\n" +
-	"\fplaylist_url\x18\x01 \x01(\tR\vplaylistUrl\x12l\n" +
-	"\bvariants\x18\x02 \x03(\v2P.com.sweetloveinyourheart.srl.videomanagement.dataproviders.ServePlaylistVariantR\bvariants2\x88\x06\n" +
-	"\x0fVideoManagement\x12\xb1\x01\n" +
-	"\fPresignedUrl\x12O.com.sweetloveinyourheart.srl.videomanagement.dataproviders.PresignedUrlRequest\x1aP.com.sweetloveinyourheart.srl.videomanagement.dataproviders.PresignedUrlResponse\x12\xbd\x01\n" +
-	"\x10GetChannelVideos\x12S.com.sweetloveinyourheart.srl.videomanagement.dataproviders.GetChannelVideosRequest\x1aT.com.sweetloveinyourheart.srl.videomanagement.dataproviders.GetChannelVideosResponse\x12\xc9\x01\n" +
-	"\x14GetVideoMetadataById\x12W.com.sweetloveinyourheart.srl.videomanagement.dataproviders.GetVideoMetadataByIdRequest\x1aX.com.sweetloveinyourheart.srl.videomanagement.dataproviders.GetVideoMetadataByIdResponse\x12\xb4\x01\n" +
-	"\rServePlaylist\x12P.com.sweetloveinyourheart.srl.videomanagement.dataproviders.ServePlaylistRequest\x1aQ.com.sweetloveinyourheart.srl.videomanagement.dataproviders.ServePlaylistResponseBPZNgithub.com/sweetloveinyourheart/sweet-reel/proto/code/video_management/go;grpcb\x06proto3"
+func (x *DeleteVideoRequest) Reset() {
+	*x = DeleteVideoRequest{}
+	mi := &file_video_management_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteVideoRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteVideoRequest) ProtoMessage() {}
+
+func (x *DeleteVideoRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_video_management_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteVideoRequest.ProtoReflect.Descriptor instead.
+func (*DeleteVideoRequest) Descriptor() ([]byte, []int) {
+	return file_video_management_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *DeleteVideoRequest) GetVideoId() string {
+	if x != nil {
+		return x.VideoId
+	}
+	return ""
+}
+
+func (x *DeleteVideoRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type DeleteVideoResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	VideoId       string                 `protobuf:"bytes,1,opt,name=video_id,json=videoId,proto3" json:"video_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteVideoResponse) Reset() {
+	*x = DeleteVideoResponse{}
+	mi := &file_video_management_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteVideoResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteVideoResponse) ProtoMessage() {}
+
+func (x *DeleteVideoResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_video_management_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteVideoResponse.ProtoReflect.Descriptor instead.
+func (*DeleteVideoResponse) Descriptor() ([]byte, []int) {
+	return file_video_management_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *DeleteVideoResponse) GetVideoId() string {
+	if x != nil {
+		return x.VideoId
+	}
+	return ""
+}
+
+type UpdateVideoRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	VideoId       string                 `protobuf:"bytes,1,opt,name=video_id,json=videoId,proto3" json:"video_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Title         *string                `protobuf:"bytes,3,opt,name=title,proto3,oneof" json:"title,omitempty"`                           // Left unchanged when not set
+	Description   *string                `protobuf:"bytes,4,opt,name=description,proto3,oneof" json:"description,omitempty"`               // Left unchanged when not set, cleared when empty
+	Visibility    *string                `protobuf:"bytes,5,opt,name=visibility,proto3,oneof" json:"visibility,omitempty"`                 // public, unlisted or private
+	PublishAt     *int64                 `protobuf:"varint,6,opt,name=publish_at,json=publishAt,proto3,oneof" json:"publish_at,omitempty"` // Unix time to make the video public at, zero to unschedule
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateVideoRequest) Reset() {
+	*x = UpdateVideoRequest{}
+	mi := &file_video_management_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateVideoRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateVideoRequest) ProtoMessage() {}
+
+func (x *UpdateVideoRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_video_management_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateVideoRequest.ProtoReflect.Descriptor instead.
+func (*UpdateVideoRequest) Descriptor() ([]byte, []int) {
+	return file_video_management_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *UpdateVideoRequest) GetVideoId() string {
+	if x != nil {
+		return x.VideoId
+	}
+	return ""
+}
+
+func (x *UpdateVideoRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *UpdateVideoRequest) GetTitle() string {
+	if x != nil && x.Title != nil {
+		return *x.Title
+	}
+	return ""
+}
+
+func (x *UpdateVideoRequest) GetDescription() string {
+	if x != nil && x.Description != nil {
+		return *x.Description
+	}
+	return ""
+}
+
+func (x *UpdateVideoRequest) GetVisibility() string {
+	if x != nil && x.Visibility != nil {
+		return *x.Visibility
+	}
+	return ""
+}
+
+func (x *UpdateVideoRequest) GetPublishAt() int64 {
+	if x != nil && x.PublishAt != nil {
+		return *x.PublishAt
+	}
+	return 0
+}
+
+type UpdateVideoResponse struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	VideoId          string                 `protobuf:"bytes,1,opt,name=video_id,json=videoId,proto3" json:"video_id,omitempty"`
+	VideoTitle       string                 `protobuf:"bytes,2,opt,name=video_title,json=videoTitle,proto3" json:"video_title,omitempty"`
+	VideoDescription string                 `protobuf:"bytes,3,opt,name=video_description,json=videoDescription,proto3" json:"video_description,omitempty"`
+	UpdatedAt        int64                  `protobuf:"varint,4,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	Visibility       string                 `protobuf:"bytes,5,opt,name=visibility,proto3" json:"visibility,omitempty"`
+	PublishAt        int64                  `protobuf:"varint,6,opt,name=publish_at,json=publishAt,proto3" json:"publish_at,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *UpdateVideoResponse) Reset() {
+	*x = UpdateVideoResponse{}
+	mi := &file_video_management_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateVideoResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateVideoResponse) ProtoMessage() {}
+
+func (x *UpdateVideoResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_video_management_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateVideoResponse.ProtoReflect.Descriptor instead.
+func (*UpdateVideoResponse) Descriptor() ([]byte, []int) {
+	return file_video_management_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *UpdateVideoResponse) GetVideoId() string {
+	if x != nil {
+		return x.VideoId
+	}
+	return ""
+}
+
+func (x *UpdateVideoResponse) GetVideoTitle() string {
+	if x != nil {
+		return x.VideoTitle
+	}
+	return ""
+}
+
+func (x *UpdateVideoResponse) GetVideoDescription() string {
+	if x != nil {
+		return x.VideoDescription
+	}
+	return ""
+}
+
+func (x *UpdateVideoResponse) GetUpdatedAt() int64 {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return 0
+}
+
+func (x *UpdateVideoResponse) GetVisibility() string {
+	if x != nil {
+		return x.Visibility
+	}
+	return ""
+}
+
+func (x *UpdateVideoResponse) GetPublishAt() int64 {
+	if x != nil {
+		return x.PublishAt
+	}
+	return 0
+}
+
+type RecordViewRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	VideoId       string                 `protobuf:"bytes,1,opt,name=video_id,json=videoId,proto3" json:"video_id,omitempty"`
+	ViewerId      string                 `protobuf:"bytes,2,opt,name=viewer_id,json=viewerId,proto3" json:"viewer_id,omitempty"` // Empty for anonymous viewers
+	IpAddress     string                 `protobuf:"bytes,3,opt,name=ip_address,json=ipAddress,proto3" json:"ip_address,omitempty"`
+	UserAgent     string                 `protobuf:"bytes,4,opt,name=user_agent,json=userAgent,proto3" json:"user_agent,omitempty"`
+	ViewId        string                 `protobuf:"bytes,5,opt,name=view_id,json=viewId,proto3" json:"view_id,omitempty"`                       // Set on watch-duration heartbeats of an already recorded view
+	WatchDuration int32                  `protobuf:"varint,6,opt,name=watch_duration,json=watchDuration,proto3" json:"watch_duration,omitempty"` // Seconds watched so far
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecordViewRequest) Reset() {
+	*x = RecordViewRequest{}
+	mi := &file_video_management_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecordViewRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecordViewRequest) ProtoMessage() {}
+
+func (x *RecordViewRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_video_management_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecordViewRequest.ProtoReflect.Descriptor instead.
+func (*RecordViewRequest) Descriptor() ([]byte, []int) {
+	return file_video_management_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *RecordViewRequest) GetVideoId() string {
+	if x != nil {
+		return x.VideoId
+	}
+	return ""
+}
+
+func (x *RecordViewRequest) GetViewerId() string {
+	if x != nil {
+		return x.ViewerId
+	}
+	return ""
+}
+
+func (x *RecordViewRequest) GetIpAddress() string {
+	if x != nil {
+		return x.IpAddress
+	}
+	return ""
+}
+
+func (x *RecordViewRequest) GetUserAgent() string {
+	if x != nil {
+		return x.UserAgent
+	}
+	return ""
+}
+
+func (x *RecordViewRequest) GetViewId() string {
+	if x != nil {
+		return x.ViewId
+	}
+	return ""
+}
+
+func (x *RecordViewRequest) GetWatchDuration() int32 {
+	if x != nil {
+		return x.WatchDuration
+	}
+	return 0
+}
+
+type RecordViewResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ViewId        string                 `protobuf:"bytes,1,opt,name=view_id,json=viewId,proto3" json:"view_id,omitempty"`
+	Counted       bool                   `protobuf:"varint,2,opt,name=counted,proto3" json:"counted,omitempty"` // False when the view was deduplicated or is a heartbeat
+	TotalView     int64                  `protobuf:"varint,3,opt,name=total_view,json=totalView,proto3" json:"total_view,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecordViewResponse) Reset() {
+	*x = RecordViewResponse{}
+	mi := &file_video_management_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecordViewResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecordViewResponse) ProtoMessage() {}
+
+func (x *RecordViewResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_video_management_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecordViewResponse.ProtoReflect.Descriptor instead.
+func (*RecordViewResponse) Descriptor() ([]byte, []int) {
+	return file_video_management_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *RecordViewResponse) GetViewId() string {
+	if x != nil {
+		return x.ViewId
+	}
+	return ""
+}
+
+func (x *RecordViewResponse) GetCounted() bool {
+	if x != nil {
+		return x.Counted
+	}
+	return false
+}
+
+func (x *RecordViewResponse) GetTotalView() int64 {
+	if x != nil {
+		return x.TotalView
+	}
+	return 0
+}
+
+type CreateMultipartUploadRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Title         string                 `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
+	Description   string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	FileName      string                 `protobuf:"bytes,3,opt,name=file_name,json=fileName,proto3" json:"file_name,omitempty"`
+	UploaderId    string                 `protobuf:"bytes,4,opt,name=uploader_id,json=uploaderId,proto3" json:"uploader_id,omitempty"`
+	ChannelId     string                 `protobuf:"bytes,5,opt,name=channel_id,json=channelId,proto3" json:"channel_id,omitempty"`
+	FileSize      int64                  `protobuf:"varint,6,opt,name=file_size,json=fileSize,proto3" json:"file_size,omitempty"` // Size of the file in bytes
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateMultipartUploadRequest) Reset() {
+	*x = CreateMultipartUploadRequest{}
+	mi := &file_video_management_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateMultipartUploadRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateMultipartUploadRequest) ProtoMessage() {}
+
+func (x *CreateMultipartUploadRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_video_management_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateMultipartUploadRequest.ProtoReflect.Descriptor instead.
+func (*CreateMultipartUploadRequest) Descriptor() ([]byte, []int) {
+	return file_video_management_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *CreateMultipartUploadRequest) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *CreateMultipartUploadRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *CreateMultipartUploadRequest) GetFileName() string {
+	if x != nil {
+		return x.FileName
+	}
+	return ""
+}
+
+func (x *CreateMultipartUploadRequest) GetUploaderId() string {
+	if x != nil {
+		return x.UploaderId
+	}
+	return ""
+}
+
+func (x *CreateMultipartUploadRequest) GetChannelId() string {
+	if x != nil {
+		return x.ChannelId
+	}
+	return ""
+}
+
+func (x *CreateMultipartUploadRequest) GetFileSize() int64 {
+	if x != nil {
+		return x.FileSize
+	}
+	return 0
+}
+
+type CreateMultipartUploadResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	VideoId       string                 `protobuf:"bytes,1,opt,name=video_id,json=videoId,proto3" json:"video_id,omitempty"`
+	PartSize      int64                  `protobuf:"varint,2,opt,name=part_size,json=partSize,proto3" json:"part_size,omitempty"`    // Size of every part but the last one
+	PartCount     int32                  `protobuf:"varint,3,opt,name=part_count,json=partCount,proto3" json:"part_count,omitempty"` // Parts are numbered from 1 to part_count
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateMultipartUploadResponse) Reset() {
+	*x = CreateMultipartUploadResponse{}
+	mi := &file_video_management_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateMultipartUploadResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateMultipartUploadResponse) ProtoMessage() {}
+
+func (x *CreateMultipartUploadResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_video_management_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateMultipartUploadResponse.ProtoReflect.Descriptor instead.
+func (*CreateMultipartUploadResponse) Descriptor() ([]byte, []int) {
+	return file_video_management_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *CreateMultipartUploadResponse) GetVideoId() string {
+	if x != nil {
+		return x.VideoId
+	}
+	return ""
+}
+
+func (x *CreateMultipartUploadResponse) GetPartSize() int64 {
+	if x != nil {
+		return x.PartSize
+	}
+	return 0
+}
+
+func (x *CreateMultipartUploadResponse) GetPartCount() int32 {
+	if x != nil {
+		return x.PartCount
+	}
+	return 0
+}
+
+type GetUploadPartUrlsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	VideoId       string                 `protobuf:"bytes,1,opt,name=video_id,json=videoId,proto3" json:"video_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	PartNumbers   []int32                `protobuf:"varint,3,rep,packed,name=part_numbers,json=partNumbers,proto3" json:"part_numbers,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUploadPartUrlsRequest) Reset() {
+	*x = GetUploadPartUrlsRequest{}
+	mi := &file_video_management_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUploadPartUrlsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUploadPartUrlsRequest) ProtoMessage() {}
+
+func (x *GetUploadPartUrlsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_video_management_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUploadPartUrlsRequest.ProtoReflect.Descriptor instead.
+func (*GetUploadPartUrlsRequest) Descriptor() ([]byte, []int) {
+	return file_video_management_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *GetUploadPartUrlsRequest) GetVideoId() string {
+	if x != nil {
+		return x.VideoId
+	}
+	return ""
+}
+
+func (x *GetUploadPartUrlsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *GetUploadPartUrlsRequest) GetPartNumbers() []int32 {
+	if x != nil {
+		return x.PartNumbers
+	}
+	return nil
+}
+
+type UploadPartUrl struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PartNumber    int32                  `protobuf:"varint,1,opt,name=part_number,json=partNumber,proto3" json:"part_number,omitempty"`
+	Url           string                 `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UploadPartUrl) Reset() {
+	*x = UploadPartUrl{}
+	mi := &file_video_management_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadPartUrl) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadPartUrl) ProtoMessage() {}
+
+func (x *UploadPartUrl) ProtoReflect() protoreflect.Message {
+	mi := &file_video_management_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadPartUrl.ProtoReflect.Descriptor instead.
+func (*UploadPartUrl) Descriptor() ([]byte, []int) {
+	return file_video_management_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *UploadPartUrl) GetPartNumber() int32 {
+	if x != nil {
+		return x.PartNumber
+	}
+	return 0
+}
+
+func (x *UploadPartUrl) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+type GetUploadPartUrlsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Urls          []*UploadPartUrl       `protobuf:"bytes,1,rep,name=urls,proto3" json:"urls,omitempty"`
+	ExpiresIn     int32                  `protobuf:"varint,2,opt,name=expires_in,json=expiresIn,proto3" json:"expires_in,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUploadPartUrlsResponse) Reset() {
+	*x = GetUploadPartUrlsResponse{}
+	mi := &file_video_management_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUploadPartUrlsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUploadPartUrlsResponse) ProtoMessage() {}
+
+func (x *GetUploadPartUrlsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_video_management_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUploadPartUrlsResponse.ProtoReflect.Descriptor instead.
+func (*GetUploadPartUrlsResponse) Descriptor() ([]byte, []int) {
+	return file_video_management_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *GetUploadPartUrlsResponse) GetUrls() []*UploadPartUrl {
+	if x != nil {
+		return x.Urls
+	}
+	return nil
+}
+
+func (x *GetUploadPartUrlsResponse) GetExpiresIn() int32 {
+	if x != nil {
+		return x.ExpiresIn
+	}
+	return 0
+}
+
+type ListUploadedPartsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	VideoId       string                 `protobuf:"bytes,1,opt,name=video_id,json=videoId,proto3" json:"video_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListUploadedPartsRequest) Reset() {
+	*x = ListUploadedPartsRequest{}
+	mi := &file_video_management_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListUploadedPartsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListUploadedPartsRequest) ProtoMessage() {}
+
+func (x *ListUploadedPartsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_video_management_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListUploadedPartsRequest.ProtoReflect.Descriptor instead.
+func (*ListUploadedPartsRequest) Descriptor() ([]byte, []int) {
+	return file_video_management_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *ListUploadedPartsRequest) GetVideoId() string {
+	if x != nil {
+		return x.VideoId
+	}
+	return ""
+}
+
+func (x *ListUploadedPartsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type UploadedPart struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PartNumber    int32                  `protobuf:"varint,1,opt,name=part_number,json=partNumber,proto3" json:"part_number,omitempty"`
+	Size          int64                  `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`
+	Etag          string                 `protobuf:"bytes,3,opt,name=etag,proto3" json:"etag,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UploadedPart) Reset() {
+	*x = UploadedPart{}
+	mi := &file_video_management_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadedPart) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadedPart) ProtoMessage() {}
+
+func (x *UploadedPart) ProtoReflect() protoreflect.Message {
+	mi := &file_video_management_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadedPart.ProtoReflect.Descriptor instead.
+func (*UploadedPart) Descriptor() ([]byte, []int) {
+	return file_video_management_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *UploadedPart) GetPartNumber() int32 {
+	if x != nil {
+		return x.PartNumber
+	}
+	return 0
+}
+
+func (x *UploadedPart) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *UploadedPart) GetEtag() string {
+	if x != nil {
+		return x.Etag
+	}
+	return ""
+}
+
+type ListUploadedPartsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	VideoId       string                 `protobuf:"bytes,1,opt,name=video_id,json=videoId,proto3" json:"video_id,omitempty"`
+	PartSize      int64                  `protobuf:"varint,2,opt,name=part_size,json=partSize,proto3" json:"part_size,omitempty"`
+	PartCount     int32                  `protobuf:"varint,3,opt,name=part_count,json=partCount,proto3" json:"part_count,omitempty"`
+	Parts         []*UploadedPart        `protobuf:"bytes,4,rep,name=parts,proto3" json:"parts,omitempty"` // Parts missing from the list still have to be uploaded
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListUploadedPartsResponse) Reset() {
+	*x = ListUploadedPartsResponse{}
+	mi := &file_video_management_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListUploadedPartsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListUploadedPartsResponse) ProtoMessage() {}
+
+func (x *ListUploadedPartsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_video_management_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListUploadedPartsResponse.ProtoReflect.Descriptor instead.
+func (*ListUploadedPartsResponse) Descriptor() ([]byte, []int) {
+	return file_video_management_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *ListUploadedPartsResponse) GetVideoId() string {
+	if x != nil {
+		return x.VideoId
+	}
+	return ""
+}
+
+func (x *ListUploadedPartsResponse) GetPartSize() int64 {
+	if x != nil {
+		return x.PartSize
+	}
+	return 0
+}
+
+func (x *ListUploadedPartsResponse) GetPartCount() int32 {
+	if x != nil {
+		return x.PartCount
+	}
+	return 0
+}
+
+func (x *ListUploadedPartsResponse) GetParts() []*UploadedPart {
+	if x != nil {
+		return x.Parts
+	}
+	return nil
+}
+
+type CompleteMultipartUploadRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	VideoId       string                 `protobuf:"bytes,1,opt,name=video_id,json=videoId,proto3" json:"video_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CompleteMultipartUploadRequest) Reset() {
+	*x = CompleteMultipartUploadRequest{}
+	mi := &file_video_management_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CompleteMultipartUploadRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompleteMultipartUploadRequest) ProtoMessage() {}
+
+func (x *CompleteMultipartUploadRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_video_management_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CompleteMultipartUploadRequest.ProtoReflect.Descriptor instead.
+func (*CompleteMultipartUploadRequest) Descriptor() ([]byte, []int) {
+	return file_video_management_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *CompleteMultipartUploadRequest) GetVideoId() string {
+	if x != nil {
+		return x.VideoId
+	}
+	return ""
+}
+
+func (x *CompleteMultipartUploadRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type CompleteMultipartUploadResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	VideoId       string                 `protobuf:"bytes,1,opt,name=video_id,json=videoId,proto3" json:"video_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CompleteMultipartUploadResponse) Reset() {
+	*x = CompleteMultipartUploadResponse{}
+	mi := &file_video_management_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CompleteMultipartUploadResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompleteMultipartUploadResponse) ProtoMessage() {}
+
+func (x *CompleteMultipartUploadResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_video_management_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CompleteMultipartUploadResponse.ProtoReflect.Descriptor instead.
+func (*CompleteMultipartUploadResponse) Descriptor() ([]byte, []int) {
+	return file_video_management_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *CompleteMultipartUploadResponse) GetVideoId() string {
+	if x != nil {
+		return x.VideoId
+	}
+	return ""
+}
+
+type AbortMultipartUploadRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	VideoId       string                 `protobuf:"bytes,1,opt,name=video_id,json=videoId,proto3" json:"video_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AbortMultipartUploadRequest) Reset() {
+	*x = AbortMultipartUploadRequest{}
+	mi := &file_video_management_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AbortMultipartUploadRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AbortMultipartUploadRequest) ProtoMessage() {}
+
+func (x *AbortMultipartUploadRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_video_management_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AbortMultipartUploadRequest.ProtoReflect.Descriptor instead.
+func (*AbortMultipartUploadRequest) Descriptor() ([]byte, []int) {
+	return file_video_management_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *AbortMultipartUploadRequest) GetVideoId() string {
+	if x != nil {
+		return x.VideoId
+	}
+	return ""
+}
+
+func (x *AbortMultipartUploadRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type AbortMultipartUploadResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	VideoId       string                 `protobuf:"bytes,1,opt,name=video_id,json=videoId,proto3" json:"video_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AbortMultipartUploadResponse) Reset() {
+	*x = AbortMultipartUploadResponse{}
+	mi := &file_video_management_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AbortMultipartUploadResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AbortMultipartUploadResponse) ProtoMessage() {}
+
+func (x *AbortMultipartUploadResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_video_management_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AbortMultipartUploadResponse.ProtoReflect.Descriptor instead.
+func (*AbortMultipartUploadResponse) Descriptor() ([]byte, []int) {
+	return file_video_management_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *AbortMultipartUploadResponse) GetVideoId() string {
+	if x != nil {
+		return x.VideoId
+	}
+	return ""
+}
+
+type GetVideoStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	VideoId       string                 `protobuf:"bytes,1,opt,name=video_id,json=videoId,proto3" json:"video_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // Empty for internal callers, otherwise only the uploader may read the status
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetVideoStatusRequest) Reset() {
+	*x = GetVideoStatusRequest{}
+	mi := &file_video_management_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetVideoStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetVideoStatusRequest) ProtoMessage() {}
+
+func (x *GetVideoStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_video_management_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetVideoStatusRequest.ProtoReflect.Descriptor instead.
+func (*GetVideoStatusRequest) Descriptor() ([]byte, []int) {
+	return file_video_management_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *GetVideoStatusRequest) GetVideoId() string {
+	if x != nil {
+		return x.VideoId
+	}
+	return ""
+}
+
+func (x *GetVideoStatusRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type GetVideoStatusResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	VideoId       string                 `protobuf:"bytes,1,opt,name=video_id,json=videoId,proto3" json:"video_id,omitempty"`
+	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	FailureCode   string                 `protobuf:"bytes,3,opt,name=failure_code,json=failureCode,proto3" json:"failure_code,omitempty"` // Empty unless status is failed
+	FailureReason string                 `protobuf:"bytes,4,opt,name=failure_reason,json=failureReason,proto3" json:"failure_reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetVideoStatusResponse) Reset() {
+	*x = GetVideoStatusResponse{}
+	mi := &file_video_management_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetVideoStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetVideoStatusResponse) ProtoMessage() {}
+
+func (x *GetVideoStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_video_management_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetVideoStatusResponse.ProtoReflect.Descriptor instead.
+func (*GetVideoStatusResponse) Descriptor() ([]byte, []int) {
+	return file_video_management_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *GetVideoStatusResponse) GetVideoId() string {
+	if x != nil {
+		return x.VideoId
+	}
+	return ""
+}
+
+func (x *GetVideoStatusResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *GetVideoStatusResponse) GetFailureCode() string {
+	if x != nil {
+		return x.FailureCode
+	}
+	return ""
+}
+
+func (x *GetVideoStatusResponse) GetFailureReason() string {
+	if x != nil {
+		return x.FailureReason
+	}
+	return ""
+}
+
+type GetVideoProcessingStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	VideoId       string                 `protobuf:"bytes,1,opt,name=video_id,json=videoId,proto3" json:"video_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // Empty for internal callers, otherwise only the uploader may read the status
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetVideoProcessingStatusRequest) Reset() {
+	*x = GetVideoProcessingStatusRequest{}
+	mi := &file_video_management_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetVideoProcessingStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetVideoProcessingStatusRequest) ProtoMessage() {}
+
+func (x *GetVideoProcessingStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_video_management_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetVideoProcessingStatusRequest.ProtoReflect.Descriptor instead.
+func (*GetVideoProcessingStatusRequest) Descriptor() ([]byte, []int) {
+	return file_video_management_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *GetVideoProcessingStatusRequest) GetVideoId() string {
+	if x != nil {
+		return x.VideoId
+	}
+	return ""
+}
+
+func (x *GetVideoProcessingStatusRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type VideoProcessingStage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Stage         string                 `protobuf:"bytes,1,opt,name=stage,proto3" json:"stage,omitempty"` // download, probe, segment, thumbnail or upload
+	StartedAt     int64                  `protobuf:"varint,2,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	DurationMs    int64                  `protobuf:"varint,3,opt,name=duration_ms,json=durationMs,proto3" json:"duration_ms,omitempty"`
+	ErrorCode     string                 `protobuf:"bytes,4,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"` // Empty when the stage succeeded
+	ErrorMessage  string                 `protobuf:"bytes,5,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	FfmpegStderr  string                 `protobuf:"bytes,6,opt,name=ffmpeg_stderr,json=ffmpegStderr,proto3" json:"ffmpeg_stderr,omitempty"` // Last lines ffmpeg wrote when the stage failed
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VideoProcessingStage) Reset() {
+	*x = VideoProcessingStage{}
+	mi := &file_video_management_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VideoProcessingStage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VideoProcessingStage) ProtoMessage() {}
+
+func (x *VideoProcessingStage) ProtoReflect() protoreflect.Message {
+	mi := &file_video_management_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VideoProcessingStage.ProtoReflect.Descriptor instead.
+func (*VideoProcessingStage) Descriptor() ([]byte, []int) {
+	return file_video_management_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *VideoProcessingStage) GetStage() string {
+	if x != nil {
+		return x.Stage
+	}
+	return ""
+}
+
+func (x *VideoProcessingStage) GetStartedAt() int64 {
+	if x != nil {
+		return x.StartedAt
+	}
+	return 0
+}
+
+func (x *VideoProcessingStage) GetDurationMs() int64 {
+	if x != nil {
+		return x.DurationMs
+	}
+	return 0
+}
+
+func (x *VideoProcessingStage) GetErrorCode() string {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ""
+}
+
+func (x *VideoProcessingStage) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+func (x *VideoProcessingStage) GetFfmpegStderr() string {
+	if x != nil {
+		return x.FfmpegStderr
+	}
+	return ""
+}
+
+type VideoProcessingJob struct {
+	state         protoimpl.MessageState  `protogen:"open.v1"`
+	JobId         string                  `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	Attempt       int32                   `protobuf:"varint,2,opt,name=attempt,proto3" json:"attempt,omitempty"`
+	Status        string                  `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"` // running, succeeded, failed or interrupted
+	StartedAt     int64                   `protobuf:"varint,4,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	FinishedAt    int64                   `protobuf:"varint,5,opt,name=finished_at,json=finishedAt,proto3" json:"finished_at,omitempty"` // Zero while the job runs
+	ErrorCode     string                  `protobuf:"bytes,6,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`     // Empty unless status is failed
+	ErrorMessage  string                  `protobuf:"bytes,7,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	Stages        []*VideoProcessingStage `protobuf:"bytes,8,rep,name=stages,proto3" json:"stages,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VideoProcessingJob) Reset() {
+	*x = VideoProcessingJob{}
+	mi := &file_video_management_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VideoProcessingJob) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VideoProcessingJob) ProtoMessage() {}
+
+func (x *VideoProcessingJob) ProtoReflect() protoreflect.Message {
+	mi := &file_video_management_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VideoProcessingJob.ProtoReflect.Descriptor instead.
+func (*VideoProcessingJob) Descriptor() ([]byte, []int) {
+	return file_video_management_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *VideoProcessingJob) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+func (x *VideoProcessingJob) GetAttempt() int32 {
+	if x != nil {
+		return x.Attempt
+	}
+	return 0
+}
+
+func (x *VideoProcessingJob) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *VideoProcessingJob) GetStartedAt() int64 {
+	if x != nil {
+		return x.StartedAt
+	}
+	return 0
+}
+
+func (x *VideoProcessingJob) GetFinishedAt() int64 {
+	if x != nil {
+		return x.FinishedAt
+	}
+	return 0
+}
+
+func (x *VideoProcessingJob) GetErrorCode() string {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ""
+}
+
+func (x *VideoProcessingJob) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+func (x *VideoProcessingJob) GetStages() []*VideoProcessingStage {
+	if x != nil {
+		return x.Stages
+	}
+	return nil
+}
+
+type GetVideoProcessingStatusResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	VideoId       string                 `protobuf:"bytes,1,opt,name=video_id,json=videoId,proto3" json:"video_id,omitempty"`
+	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	FailureCode   string                 `protobuf:"bytes,3,opt,name=failure_code,json=failureCode,proto3" json:"failure_code,omitempty"` // Empty unless status is failed
+	FailureReason string                 `protobuf:"bytes,4,opt,name=failure_reason,json=failureReason,proto3" json:"failure_reason,omitempty"`
+	Jobs          []*VideoProcessingJob  `protobuf:"bytes,5,rep,name=jobs,proto3" json:"jobs,omitempty"` // Oldest first
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetVideoProcessingStatusResponse) Reset() {
+	*x = GetVideoProcessingStatusResponse{}
+	mi := &file_video_management_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetVideoProcessingStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetVideoProcessingStatusResponse) ProtoMessage() {}
+
+func (x *GetVideoProcessingStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_video_management_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetVideoProcessingStatusResponse.ProtoReflect.Descriptor instead.
+func (*GetVideoProcessingStatusResponse) Descriptor() ([]byte, []int) {
+	return file_video_management_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *GetVideoProcessingStatusResponse) GetVideoId() string {
+	if x != nil {
+		return x.VideoId
+	}
+	return ""
+}
+
+func (x *GetVideoProcessingStatusResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *GetVideoProcessingStatusResponse) GetFailureCode() string {
+	if x != nil {
+		return x.FailureCode
+	}
+	return ""
+}
+
+func (x *GetVideoProcessingStatusResponse) GetFailureReason() string {
+	if x != nil {
+		return x.FailureReason
+	}
+	return ""
+}
+
+func (x *GetVideoProcessingStatusResponse) GetJobs() []*VideoProcessingJob {
+	if x != nil {
+		return x.Jobs
+	}
+	return nil
+}
+
+type WatchVideoProgressRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	VideoId       string                 `protobuf:"bytes,1,opt,name=video_id,json=videoId,proto3" json:"video_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // Empty for internal callers, otherwise only the uploader may watch the progress
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchVideoProgressRequest) Reset() {
+	*x = WatchVideoProgressRequest{}
+	mi := &file_video_management_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchVideoProgressRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchVideoProgressRequest) ProtoMessage() {}
+
+func (x *WatchVideoProgressRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_video_management_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchVideoProgressRequest.ProtoReflect.Descriptor instead.
+func (*WatchVideoProgressRequest) Descriptor() ([]byte, []int) {
+	return file_video_management_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *WatchVideoProgressRequest) GetVideoId() string {
+	if x != nil {
+		return x.VideoId
+	}
+	return ""
+}
+
+func (x *WatchVideoProgressRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+// Sent whenever the progress changes, the stream ends after the video is ready or failed
+type WatchVideoProgressResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	VideoId        string                 `protobuf:"bytes,1,opt,name=video_id,json=videoId,proto3" json:"video_id,omitempty"`
+	Status         string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	Attempt        int32                  `protobuf:"varint,3,opt,name=attempt,proto3" json:"attempt,omitempty"`        // Attempt of the latest processing job, zero before it starts
+	Percentage     float64                `protobuf:"fixed64,4,opt,name=percentage,proto3" json:"percentage,omitempty"` // Transcoding progress of the latest job, 0-100
+	Speed          string                 `protobuf:"bytes,5,opt,name=speed,proto3" json:"speed,omitempty"`             // Relative to playback, e.g. "2.34x"
+	CurrentSeconds float64                `protobuf:"fixed64,6,opt,name=current_seconds,json=currentSeconds,proto3" json:"current_seconds,omitempty"`
+	TotalSeconds   float64                `protobuf:"fixed64,7,opt,name=total_seconds,json=totalSeconds,proto3" json:"total_seconds,omitempty"`
+	FailureCode    string                 `protobuf:"bytes,8,opt,name=failure_code,json=failureCode,proto3" json:"failure_code,omitempty"` // Empty unless status is failed
+	FailureReason  string                 `protobuf:"bytes,9,opt,name=failure_reason,json=failureReason,proto3" json:"failure_reason,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *WatchVideoProgressResponse) Reset() {
+	*x = WatchVideoProgressResponse{}
+	mi := &file_video_management_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchVideoProgressResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchVideoProgressResponse) ProtoMessage() {}
+
+func (x *WatchVideoProgressResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_video_management_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchVideoProgressResponse.ProtoReflect.Descriptor instead.
+func (*WatchVideoProgressResponse) Descriptor() ([]byte, []int) {
+	return file_video_management_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *WatchVideoProgressResponse) GetVideoId() string {
+	if x != nil {
+		return x.VideoId
+	}
+	return ""
+}
+
+func (x *WatchVideoProgressResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *WatchVideoProgressResponse) GetAttempt() int32 {
+	if x != nil {
+		return x.Attempt
+	}
+	return 0
+}
+
+func (x *WatchVideoProgressResponse) GetPercentage() float64 {
+	if x != nil {
+		return x.Percentage
+	}
+	return 0
+}
+
+func (x *WatchVideoProgressResponse) GetSpeed() string {
+	if x != nil {
+		return x.Speed
+	}
+	return ""
+}
+
+func (x *WatchVideoProgressResponse) GetCurrentSeconds() float64 {
+	if x != nil {
+		return x.CurrentSeconds
+	}
+	return 0
+}
+
+func (x *WatchVideoProgressResponse) GetTotalSeconds() float64 {
+	if x != nil {
+		return x.TotalSeconds
+	}
+	return 0
+}
+
+func (x *WatchVideoProgressResponse) GetFailureCode() string {
+	if x != nil {
+		return x.FailureCode
+	}
+	return ""
+}
+
+func (x *WatchVideoProgressResponse) GetFailureReason() string {
+	if x != nil {
+		return x.FailureReason
+	}
+	return ""
+}
+
+// Recorded by video processing before uploading segments encrypted with the key
+type StoreContentKeyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	VideoId       string                 `protobuf:"bytes,1,opt,name=video_id,json=videoId,proto3" json:"video_id,omitempty"`
+	Key           []byte                 `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"` // AES-128 key, 16 bytes
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StoreContentKeyRequest) Reset() {
+	*x = StoreContentKeyRequest{}
+	mi := &file_video_management_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StoreContentKeyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StoreContentKeyRequest) ProtoMessage() {}
+
+func (x *StoreContentKeyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_video_management_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StoreContentKeyRequest.ProtoReflect.Descriptor instead.
+func (*StoreContentKeyRequest) Descriptor() ([]byte, []int) {
+	return file_video_management_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *StoreContentKeyRequest) GetVideoId() string {
+	if x != nil {
+		return x.VideoId
+	}
+	return ""
+}
+
+func (x *StoreContentKeyRequest) GetKey() []byte {
+	if x != nil {
+		return x.Key
+	}
+	return nil
+}
+
+type StoreContentKeyResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StoreContentKeyResponse) Reset() {
+	*x = StoreContentKeyResponse{}
+	mi := &file_video_management_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StoreContentKeyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StoreContentKeyResponse) ProtoMessage() {}
+
+func (x *StoreContentKeyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_video_management_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StoreContentKeyResponse.ProtoReflect.Descriptor instead.
+func (*StoreContentKeyResponse) Descriptor() ([]byte, []int) {
+	return file_video_management_proto_rawDescGZIP(), []int{39}
+}
+
+type GetContentKeyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	VideoId       string                 `protobuf:"bytes,1,opt,name=video_id,json=videoId,proto3" json:"video_id,omitempty"`
+	ViewerId      string                 `protobuf:"bytes,2,opt,name=viewer_id,json=viewerId,proto3" json:"viewer_id,omitempty"` // Only viewers the video is visible to get its key
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetContentKeyRequest) Reset() {
+	*x = GetContentKeyRequest{}
+	mi := &file_video_management_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetContentKeyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetContentKeyRequest) ProtoMessage() {}
+
+func (x *GetContentKeyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_video_management_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetContentKeyRequest.ProtoReflect.Descriptor instead.
+func (*GetContentKeyRequest) Descriptor() ([]byte, []int) {
+	return file_video_management_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *GetContentKeyRequest) GetVideoId() string {
+	if x != nil {
+		return x.VideoId
+	}
+	return ""
+}
+
+func (x *GetContentKeyRequest) GetViewerId() string {
+	if x != nil {
+		return x.ViewerId
+	}
+	return ""
+}
+
+type GetContentKeyResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           []byte                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetContentKeyResponse) Reset() {
+	*x = GetContentKeyResponse{}
+	mi := &file_video_management_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetContentKeyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetContentKeyResponse) ProtoMessage() {}
+
+func (x *GetContentKeyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_video_management_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetContentKeyResponse.ProtoReflect.Descriptor instead.
+func (*GetContentKeyResponse) Descriptor() ([]byte, []int) {
+	return file_video_management_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *GetContentKeyResponse) GetKey() []byte {
+	if x != nil {
+		return x.Key
+	}
+	return nil
+}
+
+type ServeStoryboardRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	VideoId       string                 `protobuf:"bytes,1,opt,name=video_id,json=videoId,proto3" json:"video_id,omitempty"`
+	ViewerId      string                 `protobuf:"bytes,2,opt,name=viewer_id,json=viewerId,proto3" json:"viewer_id,omitempty"` // Empty for anonymous viewers
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ServeStoryboardRequest) Reset() {
+	*x = ServeStoryboardRequest{}
+	mi := &file_video_management_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ServeStoryboardRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ServeStoryboardRequest) ProtoMessage() {}
+
+func (x *ServeStoryboardRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_video_management_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ServeStoryboardRequest.ProtoReflect.Descriptor instead.
+func (*ServeStoryboardRequest) Descriptor() ([]byte, []int) {
+	return file_video_management_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *ServeStoryboardRequest) GetVideoId() string {
+	if x != nil {
+		return x.VideoId
+	}
+	return ""
+}
+
+func (x *ServeStoryboardRequest) GetViewerId() string {
+	if x != nil {
+		return x.ViewerId
+	}
+	return ""
+}
+
+type ServeStoryboardResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Track         string                 `protobuf:"bytes,1,opt,name=track,proto3" json:"track,omitempty"`                                         // WebVTT track with sprite URIs rewritten for playback
+	MaxAgeSeconds int32                  `protobuf:"varint,2,opt,name=max_age_seconds,json=maxAgeSeconds,proto3" json:"max_age_seconds,omitempty"` // How long the track may be cached, its signed URLs outlive it
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ServeStoryboardResponse) Reset() {
+	*x = ServeStoryboardResponse{}
+	mi := &file_video_management_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ServeStoryboardResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ServeStoryboardResponse) ProtoMessage() {}
+
+func (x *ServeStoryboardResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_video_management_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ServeStoryboardResponse.ProtoReflect.Descriptor instead.
+func (*ServeStoryboardResponse) Descriptor() ([]byte, []int) {
+	return file_video_management_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *ServeStoryboardResponse) GetTrack() string {
+	if x != nil {
+		return x.Track
+	}
+	return ""
+}
+
+func (x *ServeStoryboardResponse) GetMaxAgeSeconds() int32 {
+	if x != nil {
+		return x.MaxAgeSeconds
+	}
+	return 0
+}
+
+var File_video_management_proto protoreflect.FileDescriptor
+
+const file_video_management_proto_rawDesc = "" +
+	"\n" +
+	"\x16video_management.proto\x12:com.sweetloveinyourheart.srl.videomanagement.dataproviders\"\xaa\x01\n" +
+	"\x13PresignedUrlRequest\x12\x14\n" +
+	"\x05title\x18\x01 \x01(\tR\x05title\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x12\x1b\n" +
+	"\tfile_name\x18\x03 \x01(\tR\bfileName\x12\x1f\n" +
+	"\vuploader_id\x18\x04 \x01(\tR\n" +
+	"uploaderId\x12\x1d\n" +
+	"\n" +
+	"channel_id\x18\x05 \x01(\tR\tchannelId\"\xc4\x02\n" +
+	"\x14PresignedUrlResponse\x12\x19\n" +
+	"\bvideo_id\x18\x01 \x01(\tR\avideoId\x12#\n" +
+	"\rpresigned_url\x18\x02 \x01(\tR\fpresignedUrl\x12\x1d\n" +
+	"\n" +
+	"expires_in\x18\x03 \x01(\x05R\texpiresIn\x12\x8a\x01\n" +
+	"\x0eupload_headers\x18\x04 \x03(\v2c.com.sweetloveinyourheart.srl.videomanagement.dataproviders.PresignedUrlResponse.UploadHeadersEntryR\ruploadHeaders\x1a@\n" +
+	"\x12UploadHeadersEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x83\x01\n" +
+	"\x17GetChannelVideosRequest\x12\x1d\n" +
+	"\n" +
+	"channel_id\x18\x01 \x01(\tR\tchannelId\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06offset\x18\x03 \x01(\x05R\x06offset\x12\x1b\n" +
+	"\tviewer_id\x18\x04 \x01(\tR\bviewerId\"\xd8\x01\n" +
+	"\fChannelVideo\x12\x19\n" +
+	"\bvideo_id\x18\x01 \x01(\tR\avideoId\x12\x1f\n" +
+	"\vvideo_title\x18\x02 \x01(\tR\n" +
+	"videoTitle\x12#\n" +
+	"\rthumbnail_url\x18\x03 \x01(\tR\fthumbnailUrl\x12%\n" +
+	"\x0etotal_duration\x18\x04 \x01(\x05R\rtotalDuration\x12\x1d\n" +
+	"\n" +
+	"total_view\x18\x05 \x01(\x03R\ttotalView\x12!\n" +
+	"\fprocessed_at\x18\x06 \x01(\x03R\vprocessedAt\"|\n" +
+	"\x18GetChannelVideosResponse\x12`\n" +
+	"\x06videos\x18\x01 \x03(\v2H.com.sweetloveinyourheart.srl.videomanagement.dataproviders.ChannelVideoR\x06videos\"U\n" +
+	"\x1bGetVideoMetadataByIdRequest\x12\x19\n" +
+	"\bvideo_id\x18\x01 \x01(\tR\avideoId\x12\x1b\n" +
+	"\tviewer_id\x18\x02 \x01(\tR\bviewerId\"\xf8\x02\n" +
+	"\x1cGetVideoMetadataByIdResponse\x12\x19\n" +
+	"\bvideo_id\x18\x01 \x01(\tR\avideoId\x12\x1d\n" +
+	"\n" +
+	"channel_id\x18\x02 \x01(\tR\tchannelId\x12\x1f\n" +
+	"\vvideo_title\x18\x03 \x01(\tR\n" +
+	"videoTitle\x12+\n" +
+	"\x11video_description\x18\x04 \x01(\tR\x10videoDescription\x12\x1d\n" +
+	"\n" +
+	"total_view\x18\x05 \x01(\x03R\ttotalView\x12/\n" +
+	"\x13available_qualities\x18\x06 \x03(\tR\x12availableQualities\x12!\n" +
+	"\fprocessed_at\x18\a \x01(\x03R\vprocessedAt\x12\x16\n" +
+	"\x06format\x18\b \x01(\tR\x06format\x12\x1e\n" +
+	"\n" +
+	"visibility\x18\t \x01(\tR\n" +
+	"visibility\x12%\n" +
+	"\x0estoryboard_url\x18\n" +
+	" \x01(\tR\rstoryboardUrl\"\x80\x01\n" +
+	"\x14ServePlaylistRequest\x12\x19\n" +
+	"\bvideo_id\x18\x01 \x01(\tR\avideoId\x12\x1b\n" +
+	"\tviewer_id\x18\x02 \x01(\tR\bviewerId\x12\x18\n" +
+	"\aquality\x18\x03 \x01(\tR\aquality\x12\x16\n" +
+	"\x06format\x18\x04 \x01(\tR\x06format\"\x93\x01\n" +
+	"\x15ServePlaylistResponse\x12\x1a\n" +
+	"\bplaylist\x18\x03 \x01(\tR\bplaylist\x12&\n" +
+	"\x0fmax_age_seconds\x18\x04 \x01(\x05R\rmaxAgeSeconds\x12*\n" +
+	"\x11dash_manifest_url\x18\x05 \x01(\tR\x0fdashManifestUrlJ\x04\b\x01\x10\x02J\x04\b\x02\x10\x03\"B\n" +
+	"\x12GetReelFeedRequest\x12\x14\n" +
+	"\x05limit\x18\x01 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06offset\x18\x02 \x01(\x05R\x06offset\"\xf7\x01\n" +
+	"\fReelFeedItem\x12\x19\n" +
+	"\bvideo_id\x18\x01 \x01(\tR\avideoId\x12\x1d\n" +
+	"\n" +
+	"channel_id\x18\x02 \x01(\tR\tchannelId\x12\x1f\n" +
+	"\vvideo_title\x18\x03 \x01(\tR\n" +
+	"videoTitle\x12#\n" +
+	"\rthumbnail_url\x18\x04 \x01(\tR\fthumbnailUrl\x12%\n" +
+	"\x0etotal_duration\x18\x05 \x01(\x05R\rtotalDuration\x12\x1d\n" +
+	"\n" +
+	"total_view\x18\x06 \x01(\x03R\ttotalView\x12!\n" +
+	"\fprocessed_at\x18\a \x01(\x03R\vprocessedAt\"u\n" +
+	"\x13GetReelFeedResponse\x12^\n" +
+	"\x05reels\x18\x01 \x03(\v2H.com.sweetloveinyourheart.srl.videomanagement.dataproviders.ReelFeedItemR\x05reels\"H\n" +
+	"\x12DeleteVideoRequest\x12\x19\n" +
+	"\bvideo_id\x18\x01 \x01(\tR\avideoId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\"0\n" +
+	"\x13DeleteVideoResponse\x12\x19\n" +
+	"\bvideo_id\x18\x01 \x01(\tR\avideoId\"\x8b\x02\n" +
+	"\x12UpdateVideoRequest\x12\x19\n" +
+	"\bvideo_id\x18\x01 \x01(\tR\avideoId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x19\n" +
+	"\x05title\x18\x03 \x01(\tH\x00R\x05title\x88\x01\x01\x12%\n" +
+	"\vdescription\x18\x04 \x01(\tH\x01R\vdescription\x88\x01\x01\x12#\n" +
+	"\n" +
+	"visibility\x18\x05 \x01(\tH\x02R\n" +
+	"visibility\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"publish_at\x18\x06 \x01(\x03H\x03R\tpublishAt\x88\x01\x01B\b\n" +
+	"\x06_titleB\x0e\n" +
+	"\f_descriptionB\r\n" +
+	"\v_visibilityB\r\n" +
+	"\v_publish_at\"\xdc\x01\n" +
+	"\x13UpdateVideoResponse\x12\x19\n" +
+	"\bvideo_id\x18\x01 \x01(\tR\avideoId\x12\x1f\n" +
+	"\vvideo_title\x18\x02 \x01(\tR\n" +
+	"videoTitle\x12+\n" +
+	"\x11video_description\x18\x03 \x01(\tR\x10videoDescription\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\x04 \x01(\x03R\tupdatedAt\x12\x1e\n" +
+	"\n" +
+	"visibility\x18\x05 \x01(\tR\n" +
+	"visibility\x12\x1d\n" +
+	"\n" +
+	"publish_at\x18\x06 \x01(\x03R\tpublishAt\"\xc9\x01\n" +
+	"\x11RecordViewRequest\x12\x19\n" +
+	"\bvideo_id\x18\x01 \x01(\tR\avideoId\x12\x1b\n" +
+	"\tviewer_id\x18\x02 \x01(\tR\bviewerId\x12\x1d\n" +
+	"\n" +
+	"ip_address\x18\x03 \x01(\tR\tipAddress\x12\x1d\n" +
+	"\n" +
+	"user_agent\x18\x04 \x01(\tR\tuserAgent\x12\x17\n" +
+	"\aview_id\x18\x05 \x01(\tR\x06viewId\x12%\n" +
+	"\x0ewatch_duration\x18\x06 \x01(\x05R\rwatchDuration\"f\n" +
+	"\x12RecordViewResponse\x12\x17\n" +
+	"\aview_id\x18\x01 \x01(\tR\x06viewId\x12\x18\n" +
+	"\acounted\x18\x02 \x01(\bR\acounted\x12\x1d\n" +
+	"\n" +
+	"total_view\x18\x03 \x01(\x03R\ttotalView\"\xd0\x01\n" +
+	"\x1cCreateMultipartUploadRequest\x12\x14\n" +
+	"\x05title\x18\x01 \x01(\tR\x05title\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x12\x1b\n" +
+	"\tfile_name\x18\x03 \x01(\tR\bfileName\x12\x1f\n" +
+	"\vuploader_id\x18\x04 \x01(\tR\n" +
+	"uploaderId\x12\x1d\n" +
+	"\n" +
+	"channel_id\x18\x05 \x01(\tR\tchannelId\x12\x1b\n" +
+	"\tfile_size\x18\x06 \x01(\x03R\bfileSize\"v\n" +
+	"\x1dCreateMultipartUploadResponse\x12\x19\n" +
+	"\bvideo_id\x18\x01 \x01(\tR\avideoId\x12\x1b\n" +
+	"\tpart_size\x18\x02 \x01(\x03R\bpartSize\x12\x1d\n" +
+	"\n" +
+	"part_count\x18\x03 \x01(\x05R\tpartCount\"q\n" +
+	"\x18GetUploadPartUrlsRequest\x12\x19\n" +
+	"\bvideo_id\x18\x01 \x01(\tR\avideoId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12!\n" +
+	"\fpart_numbers\x18\x03 \x03(\x05R\vpartNumbers\"B\n" +
+	"\rUploadPartUrl\x12\x1f\n" +
+	"\vpart_number\x18\x01 \x01(\x05R\n" +
+	"partNumber\x12\x10\n" +
+	"\x03url\x18\x02 \x01(\tR\x03url\"\x99\x01\n" +
+	"\x19GetUploadPartUrlsResponse\x12]\n" +
+	"\x04urls\x18\x01 \x03(\v2I.com.sweetloveinyourheart.srl.videomanagement.dataproviders.UploadPartUrlR\x04urls\x12\x1d\n" +
+	"\n" +
+	"expires_in\x18\x02 \x01(\x05R\texpiresIn\"N\n" +
+	"\x18ListUploadedPartsRequest\x12\x19\n" +
+	"\bvideo_id\x18\x01 \x01(\tR\avideoId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\"W\n" +
+	"\fUploadedPart\x12\x1f\n" +
+	"\vpart_number\x18\x01 \x01(\x05R\n" +
+	"partNumber\x12\x12\n" +
+	"\x04size\x18\x02 \x01(\x03R\x04size\x12\x12\n" +
+	"\x04etag\x18\x03 \x01(\tR\x04etag\"\xd2\x01\n" +
+	"\x19ListUploadedPartsResponse\x12\x19\n" +
+	"\bvideo_id\x18\x01 \x01(\tR\avideoId\x12\x1b\n" +
+	"\tpart_size\x18\x02 \x01(\x03R\bpartSize\x12\x1d\n" +
+	"\n" +
+	"part_count\x18\x03 \x01(\x05R\tpartCount\x12^\n" +
+	"\x05parts\x18\x04 \x03(\v2H.com.sweetloveinyourheart.srl.videomanagement.dataproviders.UploadedPartR\x05parts\"T\n" +
+	"\x1eCompleteMultipartUploadRequest\x12\x19\n" +
+	"\bvideo_id\x18\x01 \x01(\tR\avideoId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\"<\n" +
+	"\x1fCompleteMultipartUploadResponse\x12\x19\n" +
+	"\bvideo_id\x18\x01 \x01(\tR\avideoId\"Q\n" +
+	"\x1bAbortMultipartUploadRequest\x12\x19\n" +
+	"\bvideo_id\x18\x01 \x01(\tR\avideoId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\"9\n" +
+	"\x1cAbortMultipartUploadResponse\x12\x19\n" +
+	"\bvideo_id\x18\x01 \x01(\tR\avideoId\"K\n" +
+	"\x15GetVideoStatusRequest\x12\x19\n" +
+	"\bvideo_id\x18\x01 \x01(\tR\avideoId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\"\x95\x01\n" +
+	"\x16GetVideoStatusResponse\x12\x19\n" +
+	"\bvideo_id\x18\x01 \x01(\tR\avideoId\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12!\n" +
+	"\ffailure_code\x18\x03 \x01(\tR\vfailureCode\x12%\n" +
+	"\x0efailure_reason\x18\x04 \x01(\tR\rfailureReason\"U\n" +
+	"\x1fGetVideoProcessingStatusRequest\x12\x19\n" +
+	"\bvideo_id\x18\x01 \x01(\tR\avideoId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\"\xd5\x01\n" +
+	"\x14VideoProcessingStage\x12\x14\n" +
+	"\x05stage\x18\x01 \x01(\tR\x05stage\x12\x1d\n" +
+	"\n" +
+	"started_at\x18\x02 \x01(\x03R\tstartedAt\x12\x1f\n" +
+	"\vduration_ms\x18\x03 \x01(\x03R\n" +
+	"durationMs\x12\x1d\n" +
+	"\n" +
+	"error_code\x18\x04 \x01(\tR\terrorCode\x12#\n" +
+	"\rerror_message\x18\x05 \x01(\tR\ferrorMessage\x12#\n" +
+	"\rffmpeg_stderr\x18\x06 \x01(\tR\fffmpegStderr\"\xcb\x02\n" +
+	"\x12VideoProcessingJob\x12\x15\n" +
+	"\x06job_id\x18\x01 \x01(\tR\x05jobId\x12\x18\n" +
+	"\aattempt\x18\x02 \x01(\x05R\aattempt\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\x12\x1d\n" +
+	"\n" +
+	"started_at\x18\x04 \x01(\x03R\tstartedAt\x12\x1f\n" +
+	"\vfinished_at\x18\x05 \x01(\x03R\n" +
+	"finishedAt\x12\x1d\n" +
+	"\n" +
+	"error_code\x18\x06 \x01(\tR\terrorCode\x12#\n" +
+	"\rerror_message\x18\a \x01(\tR\ferrorMessage\x12h\n" +
+	"\x06stages\x18\b \x03(\v2P.com.sweetloveinyourheart.srl.videomanagement.dataproviders.VideoProcessingStageR\x06stages\"\x83\x02\n" +
+	" GetVideoProcessingStatusResponse\x12\x19\n" +
+	"\bvideo_id\x18\x01 \x01(\tR\avideoId\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12!\n" +
+	"\ffailure_code\x18\x03 \x01(\tR\vfailureCode\x12%\n" +
+	"\x0efailure_reason\x18\x04 \x01(\tR\rfailureReason\x12b\n" +
+	"\x04jobs\x18\x05 \x03(\v2N.com.sweetloveinyourheart.srl.videomanagement.dataproviders.VideoProcessingJobR\x04jobs\"O\n" +
+	"\x19WatchVideoProgressRequest\x12\x19\n" +
+	"\bvideo_id\x18\x01 \x01(\tR\avideoId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\"\xb7\x02\n" +
+	"\x1aWatchVideoProgressResponse\x12\x19\n" +
+	"\bvideo_id\x18\x01 \x01(\tR\avideoId\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12\x18\n" +
+	"\aattempt\x18\x03 \x01(\x05R\aattempt\x12\x1e\n" +
+	"\n" +
+	"percentage\x18\x04 \x01(\x01R\n" +
+	"percentage\x12\x14\n" +
+	"\x05speed\x18\x05 \x01(\tR\x05speed\x12'\n" +
+	"\x0fcurrent_seconds\x18\x06 \x01(\x01R\x0ecurrentSeconds\x12#\n" +
+	"\rtotal_seconds\x18\a \x01(\x01R\ftotalSeconds\x12!\n" +
+	"\ffailure_code\x18\b \x01(\tR\vfailureCode\x12%\n" +
+	"\x0efailure_reason\x18\t \x01(\tR\rfailureReason\"E\n" +
+	"\x16StoreContentKeyRequest\x12\x19\n" +
+	"\bvideo_id\x18\x01 \x01(\tR\avideoId\x12\x10\n" +
+	"\x03key\x18\x02 \x01(\fR\x03key\"\x19\n" +
+	"\x17StoreContentKeyResponse\"N\n" +
+	"\x14GetContentKeyRequest\x12\x19\n" +
+	"\bvideo_id\x18\x01 \x01(\tR\avideoId\x12\x1b\n" +
+	"\tviewer_id\x18\x02 \x01(\tR\bviewerId\")\n" +
+	"\x15GetContentKeyResponse\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\fR\x03key\"P\n" +
+	"\x16ServeStoryboardRequest\x12\x19\n" +
+	"\bvideo_id\x18\x01 \x01(\tR\avideoId\x12\x1b\n" +
+	"\tviewer_id\x18\x02 \x01(\tR\bviewerId\"W\n" +
+	"\x17ServeStoryboardResponse\x12\x14\n" +
+	"\x05track\x18\x01 \x01(\tR\x05track\x12&\n" +
+	"\x0fmax_age_seconds\x18\x02 \x01(\x05R\rmaxAgeSeconds2\xca\x1c\n" +
+	"\x0fVideoManagement\x12\xb1\x01\n" +
+	"\fPresignedUrl\x12O.com.sweetloveinyourheart.srl.videomanagement.dataproviders.PresignedUrlRequest\x1aP.com.sweetloveinyourheart.srl.videomanagement.dataproviders.PresignedUrlResponse\x12\xbd\x01\n" +
+	"\x10GetChannelVideos\x12S.com.sweetloveinyourheart.srl.videomanagement.dataproviders.GetChannelVideosRequest\x1aT.com.sweetloveinyourheart.srl.videomanagement.dataproviders.GetChannelVideosResponse\x12\xc9\x01\n" +
+	"\x14GetVideoMetadataById\x12W.com.sweetloveinyourheart.srl.videomanagement.dataproviders.GetVideoMetadataByIdRequest\x1aX.com.sweetloveinyourheart.srl.videomanagement.dataproviders.GetVideoMetadataByIdResponse\x12\xb4\x01\n" +
+	"\rServePlaylist\x12P.com.sweetloveinyourheart.srl.videomanagement.dataproviders.ServePlaylistRequest\x1aQ.com.sweetloveinyourheart.srl.videomanagement.dataproviders.ServePlaylistResponse\x12\xae\x01\n" +
+	"\vGetReelFeed\x12N.com.sweetloveinyourheart.srl.videomanagement.dataproviders.GetReelFeedRequest\x1aO.com.sweetloveinyourheart.srl.videomanagement.dataproviders.GetReelFeedResponse\x12\xae\x01\n" +
+	"\vDeleteVideo\x12N.com.sweetloveinyourheart.srl.videomanagement.dataproviders.DeleteVideoRequest\x1aO.com.sweetloveinyourheart.srl.videomanagement.dataproviders.DeleteVideoResponse\x12\xae\x01\n" +
+	"\vUpdateVideo\x12N.com.sweetloveinyourheart.srl.videomanagement.dataproviders.UpdateVideoRequest\x1aO.com.sweetloveinyourheart.srl.videomanagement.dataproviders.UpdateVideoResponse\x12\xab\x01\n" +
+	"\n" +
+	"RecordView\x12M.com.sweetloveinyourheart.srl.videomanagement.dataproviders.RecordViewRequest\x1aN.com.sweetloveinyourheart.srl.videomanagement.dataproviders.RecordViewResponse\x12\xcc\x01\n" +
+	"\x15CreateMultipartUpload\x12X.com.sweetloveinyourheart.srl.videomanagement.dataproviders.CreateMultipartUploadRequest\x1aY.com.sweetloveinyourheart.srl.videomanagement.dataproviders.CreateMultipartUploadResponse\x12\xc0\x01\n" +
+	"\x11GetUploadPartUrls\x12T.com.sweetloveinyourheart.srl.videomanagement.dataproviders.GetUploadPartUrlsRequest\x1aU.com.sweetloveinyourheart.srl.videomanagement.dataproviders.GetUploadPartUrlsResponse\x12\xc0\x01\n" +
+	"\x11ListUploadedParts\x12T.com.sweetloveinyourheart.srl.videomanagement.dataproviders.ListUploadedPartsRequest\x1aU.com.sweetloveinyourheart.srl.videomanagement.dataproviders.ListUploadedPartsResponse\x12\xd2\x01\n" +
+	"\x17CompleteMultipartUpload\x12Z.com.sweetloveinyourheart.srl.videomanagement.dataproviders.CompleteMultipartUploadRequest\x1a[.com.sweetloveinyourheart.srl.videomanagement.dataproviders.CompleteMultipartUploadResponse\x12\xc9\x01\n" +
+	"\x14AbortMultipartUpload\x12W.com.sweetloveinyourheart.srl.videomanagement.dataproviders.AbortMultipartUploadRequest\x1aX.com.sweetloveinyourheart.srl.videomanagement.dataproviders.AbortMultipartUploadResponse\x12\xb7\x01\n" +
+	"\x0eGetVideoStatus\x12Q.com.sweetloveinyourheart.srl.videomanagement.dataproviders.GetVideoStatusRequest\x1aR.com.sweetloveinyourheart.srl.videomanagement.dataproviders.GetVideoStatusResponse\x12\xd5\x01\n" +
+	"\x18GetVideoProcessingStatus\x12[.com.sweetloveinyourheart.srl.videomanagement.dataproviders.GetVideoProcessingStatusRequest\x1a\\.com.sweetloveinyourheart.srl.videomanagement.dataproviders.GetVideoProcessingStatusResponse\x12\xc5\x01\n" +
+	"\x12WatchVideoProgress\x12U.com.sweetloveinyourheart.srl.videomanagement.dataproviders.WatchVideoProgressRequest\x1aV.com.sweetloveinyourheart.srl.videomanagement.dataproviders.WatchVideoProgressResponse0\x01\x12\xba\x01\n" +
+	"\x0fStoreContentKey\x12R.com.sweetloveinyourheart.srl.videomanagement.dataproviders.StoreContentKeyRequest\x1aS.com.sweetloveinyourheart.srl.videomanagement.dataproviders.StoreContentKeyResponse\x12\xb4\x01\n" +
+	"\rGetContentKey\x12P.com.sweetloveinyourheart.srl.videomanagement.dataproviders.GetContentKeyRequest\x1aQ.com.sweetloveinyourheart.srl.videomanagement.dataproviders.GetContentKeyResponse\x12\xba\x01\n" +
+	"\x0fServeStoryboard\x12R.com.sweetloveinyourheart.srl.videomanagement.dataproviders.ServeStoryboardRequest\x1aS.com.sweetloveinyourheart.srl.videomanagement.dataproviders.ServeStoryboardResponseBPZNgithub.com/sweetloveinyourheart/sweet-reel/proto/code/video_management/go;grpcb\x06proto3"
 
 var (
 	file_video_management_proto_rawDescOnce sync.Once
@@ -711,35 +3097,105 @@ func file_video_management_proto_rawDescGZIP() []byte {
 	return file_video_management_proto_rawDescData
 }
 
-var file_video_management_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
+var file_video_management_proto_msgTypes = make([]protoimpl.MessageInfo, 45)
 var file_video_management_proto_goTypes = []any{
-	(*PresignedUrlRequest)(nil),          // 0: com.sweetloveinyourheart.srl.videomanagement.dataproviders.PresignedUrlRequest
-	(*PresignedUrlResponse)(nil),         // 1: com.sweetloveinyourheart.srl.videomanagement.dataproviders.PresignedUrlResponse
-	(*GetChannelVideosRequest)(nil),      // 2: com.sweetloveinyourheart.srl.videomanagement.dataproviders.GetChannelVideosRequest
-	(*ChannelVideo)(nil),                 // 3: com.sweetloveinyourheart.srl.videomanagement.dataproviders.ChannelVideo
-	(*GetChannelVideosResponse)(nil),     // 4: com.sweetloveinyourheart.srl.videomanagement.dataproviders.GetChannelVideosResponse
-	(*GetVideoMetadataByIdRequest)(nil),  // 5: com.sweetloveinyourheart.srl.videomanagement.dataproviders.GetVideoMetadataByIdRequest
-	(*GetVideoMetadataByIdResponse)(nil), // 6: com.sweetloveinyourheart.srl.videomanagement.dataproviders.GetVideoMetadataByIdResponse
-	(*ServePlaylistRequest)(nil),         // 7: com.sweetloveinyourheart.srl.videomanagement.dataproviders.ServePlaylistRequest
-	(*ServePlaylistVariant)(nil),         // 8: com.sweetloveinyourheart.srl.videomanagement.dataproviders.ServePlaylistVariant
-	(*ServePlaylistResponse)(nil),        // 9: com.sweetloveinyourheart.srl.videomanagement.dataproviders.ServePlaylistResponse
+	(*PresignedUrlRequest)(nil),              // 0: com.sweetloveinyourheart.srl.videomanagement.dataproviders.PresignedUrlRequest
+	(*PresignedUrlResponse)(nil),             // 1: com.sweetloveinyourheart.srl.videomanagement.dataproviders.PresignedUrlResponse
+	(*GetChannelVideosRequest)(nil),          // 2: com.sweetloveinyourheart.srl.videomanagement.dataproviders.GetChannelVideosRequest
+	(*ChannelVideo)(nil),                     // 3: com.sweetloveinyourheart.srl.videomanagement.dataproviders.ChannelVideo
+	(*GetChannelVideosResponse)(nil),         // 4: com.sweetloveinyourheart.srl.videomanagement.dataproviders.GetChannelVideosResponse
+	(*GetVideoMetadataByIdRequest)(nil),      // 5: com.sweetloveinyourheart.srl.videomanagement.dataproviders.GetVideoMetadataByIdRequest
+	(*GetVideoMetadataByIdResponse)(nil),     // 6: com.sweetloveinyourheart.srl.videomanagement.dataproviders.GetVideoMetadataByIdResponse
+	(*ServePlaylistRequest)(nil),             // 7: com.sweetloveinyourheart.srl.videomanagement.dataproviders.ServePlaylistRequest
+	(*ServePlaylistResponse)(nil),            // 8: com.sweetloveinyourheart.srl.videomanagement.dataproviders.ServePlaylistResponse
+	(*GetReelFeedRequest)(nil),               // 9: com.sweetloveinyourheart.srl.videomanagement.dataproviders.GetReelFeedRequest
+	(*ReelFeedItem)(nil),                     // 10: com.sweetloveinyourheart.srl.videomanagement.dataproviders.ReelFeedItem
+	(*GetReelFeedResponse)(nil),              // 11: com.sweetloveinyourheart.srl.videomanagement.dataproviders.GetReelFeedResponse
+	(*DeleteVideoRequest)(nil),               // 12: com.sweetloveinyourheart.srl.videomanagement.dataproviders.DeleteVideoRequest
+	(*DeleteVideoResponse)(nil),              // 13: com.sweetloveinyourheart.srl.videomanagement.dataproviders.DeleteVideoResponse
+	(*UpdateVideoRequest)(nil),               // 14: com.sweetloveinyourheart.srl.videomanagement.dataproviders.UpdateVideoRequest
+	(*UpdateVideoResponse)(nil),              // 15: com.sweetloveinyourheart.srl.videomanagement.dataproviders.UpdateVideoResponse
+	(*RecordViewRequest)(nil),                // 16: com.sweetloveinyourheart.srl.videomanagement.dataproviders.RecordViewRequest
+	(*RecordViewResponse)(nil),               // 17: com.sweetloveinyourheart.srl.videomanagement.dataproviders.RecordViewResponse
+	(*CreateMultipartUploadRequest)(nil),     // 18: com.sweetloveinyourheart.srl.videomanagement.dataproviders.CreateMultipartUploadRequest
+	(*CreateMultipartUploadResponse)(nil),    // 19: com.sweetloveinyourheart.srl.videomanagement.dataproviders.CreateMultipartUploadResponse
+	(*GetUploadPartUrlsRequest)(nil),         // 20: com.sweetloveinyourheart.srl.videomanagement.dataproviders.GetUploadPartUrlsRequest
+	(*UploadPartUrl)(nil),                    // 21: com.sweetloveinyourheart.srl.videomanagement.dataproviders.UploadPartUrl
+	(*GetUploadPartUrlsResponse)(nil),        // 22: com.sweetloveinyourheart.srl.videomanagement.dataproviders.GetUploadPartUrlsResponse
+	(*ListUploadedPartsRequest)(nil),         // 23: com.sweetloveinyourheart.srl.videomanagement.dataproviders.ListUploadedPartsRequest
+	(*UploadedPart)(nil),                     // 24: com.sweetloveinyourheart.srl.videomanagement.dataproviders.UploadedPart
+	(*ListUploadedPartsResponse)(nil),        // 25: com.sweetloveinyourheart.srl.videomanagement.dataproviders.ListUploadedPartsResponse
+	(*CompleteMultipartUploadRequest)(nil),   // 26: com.sweetloveinyourheart.srl.videomanagement.dataproviders.CompleteMultipartUploadRequest
+	(*CompleteMultipartUploadResponse)(nil),  // 27: com.sweetloveinyourheart.srl.videomanagement.dataproviders.CompleteMultipartUploadResponse
+	(*AbortMultipartUploadRequest)(nil),      // 28: com.sweetloveinyourheart.srl.videomanagement.dataproviders.AbortMultipartUploadRequest
+	(*AbortMultipartUploadResponse)(nil),     // 29: com.sweetloveinyourheart.srl.videomanagement.dataproviders.AbortMultipartUploadResponse
+	(*GetVideoStatusRequest)(nil),            // 30: com.sweetloveinyourheart.srl.videomanagement.dataproviders.GetVideoStatusRequest
+	(*GetVideoStatusResponse)(nil),           // 31: com.sweetloveinyourheart.srl.videomanagement.dataproviders.GetVideoStatusResponse
+	(*GetVideoProcessingStatusRequest)(nil),  // 32: com.sweetloveinyourheart.srl.videomanagement.dataproviders.GetVideoProcessingStatusRequest
+	(*VideoProcessingStage)(nil),             // 33: com.sweetloveinyourheart.srl.videomanagement.dataproviders.VideoProcessingStage
+	(*VideoProcessingJob)(nil),               // 34: com.sweetloveinyourheart.srl.videomanagement.dataproviders.VideoProcessingJob
+	(*GetVideoProcessingStatusResponse)(nil), // 35: com.sweetloveinyourheart.srl.videomanagement.dataproviders.GetVideoProcessingStatusResponse
+	(*WatchVideoProgressRequest)(nil),        // 36: com.sweetloveinyourheart.srl.videomanagement.dataproviders.WatchVideoProgressRequest
+	(*WatchVideoProgressResponse)(nil),       // 37: com.sweetloveinyourheart.srl.videomanagement.dataproviders.WatchVideoProgressResponse
+	(*StoreContentKeyRequest)(nil),           // 38: com.sweetloveinyourheart.srl.videomanagement.dataproviders.StoreContentKeyRequest
+	(*StoreContentKeyResponse)(nil),          // 39: com.sweetloveinyourheart.srl.videomanagement.dataproviders.StoreContentKeyResponse
+	(*GetContentKeyRequest)(nil),             // 40: com.sweetloveinyourheart.srl.videomanagement.dataproviders.GetContentKeyRequest
+	(*GetContentKeyResponse)(nil),            // 41: com.sweetloveinyourheart.srl.videomanagement.dataproviders.GetContentKeyResponse
+	(*ServeStoryboardRequest)(nil),           // 42: com.sweetloveinyourheart.srl.videomanagement.dataproviders.ServeStoryboardRequest
+	(*ServeStoryboardResponse)(nil),          // 43: com.sweetloveinyourheart.srl.videomanagement.dataproviders.ServeStoryboardResponse
+	nil,                                      // 44: com.sweetloveinyourheart.srl.videomanagement.dataproviders.PresignedUrlResponse.UploadHeadersEntry
 }
 var file_video_management_proto_depIdxs = []int32{
-	3, // 0: com.sweetloveinyourheart.srl.videomanagement.dataproviders.GetChannelVideosResponse.videos:type_name -> com.sweetloveinyourheart.srl.videomanagement.dataproviders.ChannelVideo
-	8, // 1: com.sweetloveinyourheart.srl.videomanagement.dataproviders.ServePlaylistResponse.variants:type_name -> com.sweetloveinyourheart.srl.videomanagement.dataproviders.ServePlaylistVariant
-	0, // 2: com.sweetloveinyourheart.srl.videomanagement.dataproviders.VideoManagement.PresignedUrl:input_type -> com.sweetloveinyourheart.srl.videomanagement.dataproviders.PresignedUrlRequest
-	2, // 3: com.sweetloveinyourheart.srl.videomanagement.dataproviders.VideoManagement.GetChannelVideos:input_type -> com.sweetloveinyourheart.srl.videomanagement.dataproviders.GetChannelVideosRequest
-	5, // 4: com.sweetloveinyourheart.srl.videomanagement.dataproviders.VideoManagement.GetVideoMetadataById:input_type -> com.sweetloveinyourheart.srl.videomanagement.dataproviders.GetVideoMetadataByIdRequest
-	7, // 5: com.sweetloveinyourheart.srl.videomanagement.dataproviders.VideoManagement.ServePlaylist:input_type -> com.sweetloveinyourheart.srl.videomanagement.dataproviders.ServePlaylistRequest
-	1, // 6: com.sweetloveinyourheart.srl.videomanagement.dataproviders.VideoManagement.PresignedUrl:output_type -> com.sweetloveinyourheart.srl.videomanagement.dataproviders.PresignedUrlResponse
-	4, // 7: com.sweetloveinyourheart.srl.videomanagement.dataproviders.VideoManagement.GetChannelVideos:output_type -> com.sweetloveinyourheart.srl.videomanagement.dataproviders.GetChannelVideosResponse
-	6, // 8: com.sweetloveinyourheart.srl.videomanagement.dataproviders.VideoManagement.GetVideoMetadataById:output_type -> com.sweetloveinyourheart.srl.videomanagement.dataproviders.GetVideoMetadataByIdResponse
-	9, // 9: com.sweetloveinyourheart.srl.videomanagement.dataproviders.VideoManagement.ServePlaylist:output_type -> com.sweetloveinyourheart.srl.videomanagement.dataproviders.ServePlaylistResponse
-	6, // [6:10] is the sub-list for method output_type
-	2, // [2:6] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+	44, // 0: com.sweetloveinyourheart.srl.videomanagement.dataproviders.PresignedUrlResponse.upload_headers:type_name -> com.sweetloveinyourheart.srl.videomanagement.dataproviders.PresignedUrlResponse.UploadHeadersEntry
+	3,  // 1: com.sweetloveinyourheart.srl.videomanagement.dataproviders.GetChannelVideosResponse.videos:type_name -> com.sweetloveinyourheart.srl.videomanagement.dataproviders.ChannelVideo
+	10, // 2: com.sweetloveinyourheart.srl.videomanagement.dataproviders.GetReelFeedResponse.reels:type_name -> com.sweetloveinyourheart.srl.videomanagement.dataproviders.ReelFeedItem
+	21, // 3: com.sweetloveinyourheart.srl.videomanagement.dataproviders.GetUploadPartUrlsResponse.urls:type_name -> com.sweetloveinyourheart.srl.videomanagement.dataproviders.UploadPartUrl
+	24, // 4: com.sweetloveinyourheart.srl.videomanagement.dataproviders.ListUploadedPartsResponse.parts:type_name -> com.sweetloveinyourheart.srl.videomanagement.dataproviders.UploadedPart
+	33, // 5: com.sweetloveinyourheart.srl.videomanagement.dataproviders.VideoProcessingJob.stages:type_name -> com.sweetloveinyourheart.srl.videomanagement.dataproviders.VideoProcessingStage
+	34, // 6: com.sweetloveinyourheart.srl.videomanagement.dataproviders.GetVideoProcessingStatusResponse.jobs:type_name -> com.sweetloveinyourheart.srl.videomanagement.dataproviders.VideoProcessingJob
+	0,  // 7: com.sweetloveinyourheart.srl.videomanagement.dataproviders.VideoManagement.PresignedUrl:input_type -> com.sweetloveinyourheart.srl.videomanagement.dataproviders.PresignedUrlRequest
+	2,  // 8: com.sweetloveinyourheart.srl.videomanagement.dataproviders.VideoManagement.GetChannelVideos:input_type -> com.sweetloveinyourheart.srl.videomanagement.dataproviders.GetChannelVideosRequest
+	5,  // 9: com.sweetloveinyourheart.srl.videomanagement.dataproviders.VideoManagement.GetVideoMetadataById:input_type -> com.sweetloveinyourheart.srl.videomanagement.dataproviders.GetVideoMetadataByIdRequest
+	7,  // 10: com.sweetloveinyourheart.srl.videomanagement.dataproviders.VideoManagement.ServePlaylist:input_type -> com.sweetloveinyourheart.srl.videomanagement.dataproviders.ServePlaylistRequest
+	9,  // 11: com.sweetloveinyourheart.srl.videomanagement.dataproviders.VideoManagement.GetReelFeed:input_type -> com.sweetloveinyourheart.srl.videomanagement.dataproviders.GetReelFeedRequest
+	12, // 12: com.sweetloveinyourheart.srl.videomanagement.dataproviders.VideoManagement.DeleteVideo:input_type -> com.sweetloveinyourheart.srl.videomanagement.dataproviders.DeleteVideoRequest
+	14, // 13: com.sweetloveinyourheart.srl.videomanagement.dataproviders.VideoManagement.UpdateVideo:input_type -> com.sweetloveinyourheart.srl.videomanagement.dataproviders.UpdateVideoRequest
+	16, // 14: com.sweetloveinyourheart.srl.videomanagement.dataproviders.VideoManagement.RecordView:input_type -> com.sweetloveinyourheart.srl.videomanagement.dataproviders.RecordViewRequest
+	18, // 15: com.sweetloveinyourheart.srl.videomanagement.dataproviders.VideoManagement.CreateMultipartUpload:input_type -> com.sweetloveinyourheart.srl.videomanagement.dataproviders.CreateMultipartUploadRequest
+	20, // 16: com.sweetloveinyourheart.srl.videomanagement.dataproviders.VideoManagement.GetUploadPartUrls:input_type -> com.sweetloveinyourheart.srl.videomanagement.dataproviders.GetUploadPartUrlsRequest
+	23, // 17: com.sweetloveinyourheart.srl.videomanagement.dataproviders.VideoManagement.ListUploadedParts:input_type -> com.sweetloveinyourheart.srl.videomanagement.dataproviders.ListUploadedPartsRequest
+	26, // 18: com.sweetloveinyourheart.srl.videomanagement.dataproviders.VideoManagement.CompleteMultipartUpload:input_type -> com.sweetloveinyourheart.srl.videomanagement.dataproviders.CompleteMultipartUploadRequest
+	28, // 19: com.sweetloveinyourheart.srl.videomanagement.dataproviders.VideoManagement.AbortMultipartUpload:input_type -> com.sweetloveinyourheart.srl.videomanagement.dataproviders.AbortMultipartUploadRequest
+	30, // 20: com.sweetloveinyourheart.srl.videomanagement.dataproviders.VideoManagement.GetVideoStatus:input_type -> com.sweetloveinyourheart.srl.videomanagement.dataproviders.GetVideoStatusRequest
+	32, // 21: com.sweetloveinyourheart.srl.videomanagement.dataproviders.VideoManagement.GetVideoProcessingStatus:input_type -> com.sweetloveinyourheart.srl.videomanagement.dataproviders.GetVideoProcessingStatusRequest
+	36, // 22: com.sweetloveinyourheart.srl.videomanagement.dataproviders.VideoManagement.WatchVideoProgress:input_type -> com.sweetloveinyourheart.srl.videomanagement.dataproviders.WatchVideoProgressRequest
+	38, // 23: com.sweetloveinyourheart.srl.videomanagement.dataproviders.VideoManagement.StoreContentKey:input_type -> com.sweetloveinyourheart.srl.videomanagement.dataproviders.StoreContentKeyRequest
+	40, // 24: com.sweetloveinyourheart.srl.videomanagement.dataproviders.VideoManagement.GetContentKey:input_type -> com.sweetloveinyourheart.srl.videomanagement.dataproviders.GetContentKeyRequest
+	42, // 25: com.sweetloveinyourheart.srl.videomanagement.dataproviders.VideoManagement.ServeStoryboard:input_type -> com.sweetloveinyourheart.srl.videomanagement.dataproviders.ServeStoryboardRequest
+	1,  // 26: com.sweetloveinyourheart.srl.videomanagement.dataproviders.VideoManagement.PresignedUrl:output_type -> com.sweetloveinyourheart.srl.videomanagement.dataproviders.PresignedUrlResponse
+	4,  // 27: com.sweetloveinyourheart.srl.videomanagement.dataproviders.VideoManagement.GetChannelVideos:output_type -> com.sweetloveinyourheart.srl.videomanagement.dataproviders.GetChannelVideosResponse
+	6,  // 28: com.sweetloveinyourheart.srl.videomanagement.dataproviders.VideoManagement.GetVideoMetadataById:output_type -> com.sweetloveinyourheart.srl.videomanagement.dataproviders.GetVideoMetadataByIdResponse
+	8,  // 29: com.sweetloveinyourheart.srl.videomanagement.dataproviders.VideoManagement.ServePlaylist:output_type -> com.sweetloveinyourheart.srl.videomanagement.dataproviders.ServePlaylistResponse
+	11, // 30: com.sweetloveinyourheart.srl.videomanagement.dataproviders.VideoManagement.GetReelFeed:output_type -> com.sweetloveinyourheart.srl.videomanagement.dataproviders.GetReelFeedResponse
+	13, // 31: com.sweetloveinyourheart.srl.videomanagement.dataproviders.VideoManagement.DeleteVideo:output_type -> com.sweetloveinyourheart.srl.videomanagement.dataproviders.DeleteVideoResponse
+	15, // 32: com.sweetloveinyourheart.srl.videomanagement.dataproviders.VideoManagement.UpdateVideo:output_type -> com.sweetloveinyourheart.srl.videomanagement.dataproviders.UpdateVideoResponse
+	17, // 33: com.sweetloveinyourheart.srl.videomanagement.dataproviders.VideoManagement.RecordView:output_type -> com.sweetloveinyourheart.srl.videomanagement.dataproviders.RecordViewResponse
+	19, // 34: com.sweetloveinyourheart.srl.videomanagement.dataproviders.VideoManagement.CreateMultipartUpload:output_type -> com.sweetloveinyourheart.srl.videomanagement.dataproviders.CreateMultipartUploadResponse
+	22, // 35: com.sweetloveinyourheart.srl.videomanagement.dataproviders.VideoManagement.GetUploadPartUrls:output_type -> com.sweetloveinyourheart.srl.videomanagement.dataproviders.GetUploadPartUrlsResponse
+	25, // 36: com.sweetloveinyourheart.srl.videomanagement.dataproviders.VideoManagement.ListUploadedParts:output_type -> com.sweetloveinyourheart.srl.videomanagement.dataproviders.ListUploadedPartsResponse
+	27, // 37: com.sweetloveinyourheart.srl.videomanagement.dataproviders.VideoManagement.CompleteMultipartUpload:output_type -> com.sweetloveinyourheart.srl.videomanagement.dataproviders.CompleteMultipartUploadResponse
+	29, // 38: com.sweetloveinyourheart.srl.videomanagement.dataproviders.VideoManagement.AbortMultipartUpload:output_type -> com.sweetloveinyourheart.srl.videomanagement.dataproviders.AbortMultipartUploadResponse
+	31, // 39: com.sweetloveinyourheart.srl.videomanagement.dataproviders.VideoManagement.GetVideoStatus:output_type -> com.sweetloveinyourheart.srl.videomanagement.dataproviders.GetVideoStatusResponse
+	35, // 40: com.sweetloveinyourheart.srl.videomanagement.dataproviders.VideoManagement.GetVideoProcessingStatus:output_type -> com.sweetloveinyourheart.srl.videomanagement.dataproviders.GetVideoProcessingStatusResponse
+	37, // 41: com.sweetloveinyourheart.srl.videomanagement.dataproviders.VideoManagement.WatchVideoProgress:output_type -> com.sweetloveinyourheart.srl.videomanagement.dataproviders.WatchVideoProgressResponse
+	39, // 42: com.sweetloveinyourheart.srl.videomanagement.dataproviders.VideoManagement.StoreContentKey:output_type -> com.sweetloveinyourheart.srl.videomanagement.dataproviders.StoreContentKeyResponse
+	41, // 43: com.sweetloveinyourheart.srl.videomanagement.dataproviders.VideoManagement.GetContentKey:output_type -> com.sweetloveinyourheart.srl.videomanagement.dataproviders.GetContentKeyResponse
+	43, // 44: com.sweetloveinyourheart.srl.videomanagement.dataproviders.VideoManagement.ServeStoryboard:output_type -> com.sweetloveinyourheart.srl.videomanagement.dataproviders.ServeStoryboardResponse
+	26, // [26:45] is the sub-list for method output_type
+	7,  // [7:26] is the sub-list for method input_type
+	7,  // [7:7] is the sub-list for extension type_name
+	7,  // [7:7] is the sub-list for extension extendee
+	0,  // [0:7] is the sub-list for field type_name
 }
 
 func init() { file_video_management_proto_init() }
@@ -747,13 +3203,14 @@ func file_video_management_proto_init() {
 	if File_video_management_proto != nil {
 		return
 	}
+	file_video_management_proto_msgTypes[14].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_video_management_proto_rawDesc), len(file_video_management_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   10,
+			NumMessages:   45,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
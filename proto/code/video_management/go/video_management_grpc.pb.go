@@ -19,10 +19,25 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	VideoManagement_PresignedUrl_FullMethodName         = "/com.sweetloveinyourheart.srl.videomanagement.dataproviders.VideoManagement/PresignedUrl"
-	VideoManagement_GetChannelVideos_FullMethodName     = "/com.sweetloveinyourheart.srl.videomanagement.dataproviders.VideoManagement/GetChannelVideos"
-	VideoManagement_GetVideoMetadataById_FullMethodName = "/com.sweetloveinyourheart.srl.videomanagement.dataproviders.VideoManagement/GetVideoMetadataById"
-	VideoManagement_ServePlaylist_FullMethodName        = "/com.sweetloveinyourheart.srl.videomanagement.dataproviders.VideoManagement/ServePlaylist"
+	VideoManagement_PresignedUrl_FullMethodName             = "/com.sweetloveinyourheart.srl.videomanagement.dataproviders.VideoManagement/PresignedUrl"
+	VideoManagement_GetChannelVideos_FullMethodName         = "/com.sweetloveinyourheart.srl.videomanagement.dataproviders.VideoManagement/GetChannelVideos"
+	VideoManagement_GetVideoMetadataById_FullMethodName     = "/com.sweetloveinyourheart.srl.videomanagement.dataproviders.VideoManagement/GetVideoMetadataById"
+	VideoManagement_ServePlaylist_FullMethodName            = "/com.sweetloveinyourheart.srl.videomanagement.dataproviders.VideoManagement/ServePlaylist"
+	VideoManagement_GetReelFeed_FullMethodName              = "/com.sweetloveinyourheart.srl.videomanagement.dataproviders.VideoManagement/GetReelFeed"
+	VideoManagement_DeleteVideo_FullMethodName              = "/com.sweetloveinyourheart.srl.videomanagement.dataproviders.VideoManagement/DeleteVideo"
+	VideoManagement_UpdateVideo_FullMethodName              = "/com.sweetloveinyourheart.srl.videomanagement.dataproviders.VideoManagement/UpdateVideo"
+	VideoManagement_RecordView_FullMethodName               = "/com.sweetloveinyourheart.srl.videomanagement.dataproviders.VideoManagement/RecordView"
+	VideoManagement_CreateMultipartUpload_FullMethodName    = "/com.sweetloveinyourheart.srl.videomanagement.dataproviders.VideoManagement/CreateMultipartUpload"
+	VideoManagement_GetUploadPartUrls_FullMethodName        = "/com.sweetloveinyourheart.srl.videomanagement.dataproviders.VideoManagement/GetUploadPartUrls"
+	VideoManagement_ListUploadedParts_FullMethodName        = "/com.sweetloveinyourheart.srl.videomanagement.dataproviders.VideoManagement/ListUploadedParts"
+	VideoManagement_CompleteMultipartUpload_FullMethodName  = "/com.sweetloveinyourheart.srl.videomanagement.dataproviders.VideoManagement/CompleteMultipartUpload"
+	VideoManagement_AbortMultipartUpload_FullMethodName     = "/com.sweetloveinyourheart.srl.videomanagement.dataproviders.VideoManagement/AbortMultipartUpload"
+	VideoManagement_GetVideoStatus_FullMethodName           = "/com.sweetloveinyourheart.srl.videomanagement.dataproviders.VideoManagement/GetVideoStatus"
+	VideoManagement_GetVideoProcessingStatus_FullMethodName = "/com.sweetloveinyourheart.srl.videomanagement.dataproviders.VideoManagement/GetVideoProcessingStatus"
+	VideoManagement_WatchVideoProgress_FullMethodName       = "/com.sweetloveinyourheart.srl.videomanagement.dataproviders.VideoManagement/WatchVideoProgress"
+	VideoManagement_StoreContentKey_FullMethodName          = "/com.sweetloveinyourheart.srl.videomanagement.dataproviders.VideoManagement/StoreContentKey"
+	VideoManagement_GetContentKey_FullMethodName            = "/com.sweetloveinyourheart.srl.videomanagement.dataproviders.VideoManagement/GetContentKey"
+	VideoManagement_ServeStoryboard_FullMethodName          = "/com.sweetloveinyourheart.srl.videomanagement.dataproviders.VideoManagement/ServeStoryboard"
 )
 
 // VideoManagementClient is the client API for VideoManagement service.
@@ -33,6 +48,21 @@ type VideoManagementClient interface {
 	GetChannelVideos(ctx context.Context, in *GetChannelVideosRequest, opts ...grpc.CallOption) (*GetChannelVideosResponse, error)
 	GetVideoMetadataById(ctx context.Context, in *GetVideoMetadataByIdRequest, opts ...grpc.CallOption) (*GetVideoMetadataByIdResponse, error)
 	ServePlaylist(ctx context.Context, in *ServePlaylistRequest, opts ...grpc.CallOption) (*ServePlaylistResponse, error)
+	GetReelFeed(ctx context.Context, in *GetReelFeedRequest, opts ...grpc.CallOption) (*GetReelFeedResponse, error)
+	DeleteVideo(ctx context.Context, in *DeleteVideoRequest, opts ...grpc.CallOption) (*DeleteVideoResponse, error)
+	UpdateVideo(ctx context.Context, in *UpdateVideoRequest, opts ...grpc.CallOption) (*UpdateVideoResponse, error)
+	RecordView(ctx context.Context, in *RecordViewRequest, opts ...grpc.CallOption) (*RecordViewResponse, error)
+	CreateMultipartUpload(ctx context.Context, in *CreateMultipartUploadRequest, opts ...grpc.CallOption) (*CreateMultipartUploadResponse, error)
+	GetUploadPartUrls(ctx context.Context, in *GetUploadPartUrlsRequest, opts ...grpc.CallOption) (*GetUploadPartUrlsResponse, error)
+	ListUploadedParts(ctx context.Context, in *ListUploadedPartsRequest, opts ...grpc.CallOption) (*ListUploadedPartsResponse, error)
+	CompleteMultipartUpload(ctx context.Context, in *CompleteMultipartUploadRequest, opts ...grpc.CallOption) (*CompleteMultipartUploadResponse, error)
+	AbortMultipartUpload(ctx context.Context, in *AbortMultipartUploadRequest, opts ...grpc.CallOption) (*AbortMultipartUploadResponse, error)
+	GetVideoStatus(ctx context.Context, in *GetVideoStatusRequest, opts ...grpc.CallOption) (*GetVideoStatusResponse, error)
+	GetVideoProcessingStatus(ctx context.Context, in *GetVideoProcessingStatusRequest, opts ...grpc.CallOption) (*GetVideoProcessingStatusResponse, error)
+	WatchVideoProgress(ctx context.Context, in *WatchVideoProgressRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[WatchVideoProgressResponse], error)
+	StoreContentKey(ctx context.Context, in *StoreContentKeyRequest, opts ...grpc.CallOption) (*StoreContentKeyResponse, error)
+	GetContentKey(ctx context.Context, in *GetContentKeyRequest, opts ...grpc.CallOption) (*GetContentKeyResponse, error)
+	ServeStoryboard(ctx context.Context, in *ServeStoryboardRequest, opts ...grpc.CallOption) (*ServeStoryboardResponse, error)
 }
 
 type videoManagementClient struct {
@@ -83,6 +113,165 @@ func (c *videoManagementClient) ServePlaylist(ctx context.Context, in *ServePlay
 	return out, nil
 }
 
+func (c *videoManagementClient) GetReelFeed(ctx context.Context, in *GetReelFeedRequest, opts ...grpc.CallOption) (*GetReelFeedResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetReelFeedResponse)
+	err := c.cc.Invoke(ctx, VideoManagement_GetReelFeed_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *videoManagementClient) DeleteVideo(ctx context.Context, in *DeleteVideoRequest, opts ...grpc.CallOption) (*DeleteVideoResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteVideoResponse)
+	err := c.cc.Invoke(ctx, VideoManagement_DeleteVideo_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *videoManagementClient) UpdateVideo(ctx context.Context, in *UpdateVideoRequest, opts ...grpc.CallOption) (*UpdateVideoResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateVideoResponse)
+	err := c.cc.Invoke(ctx, VideoManagement_UpdateVideo_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *videoManagementClient) RecordView(ctx context.Context, in *RecordViewRequest, opts ...grpc.CallOption) (*RecordViewResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RecordViewResponse)
+	err := c.cc.Invoke(ctx, VideoManagement_RecordView_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *videoManagementClient) CreateMultipartUpload(ctx context.Context, in *CreateMultipartUploadRequest, opts ...grpc.CallOption) (*CreateMultipartUploadResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateMultipartUploadResponse)
+	err := c.cc.Invoke(ctx, VideoManagement_CreateMultipartUpload_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *videoManagementClient) GetUploadPartUrls(ctx context.Context, in *GetUploadPartUrlsRequest, opts ...grpc.CallOption) (*GetUploadPartUrlsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetUploadPartUrlsResponse)
+	err := c.cc.Invoke(ctx, VideoManagement_GetUploadPartUrls_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *videoManagementClient) ListUploadedParts(ctx context.Context, in *ListUploadedPartsRequest, opts ...grpc.CallOption) (*ListUploadedPartsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListUploadedPartsResponse)
+	err := c.cc.Invoke(ctx, VideoManagement_ListUploadedParts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *videoManagementClient) CompleteMultipartUpload(ctx context.Context, in *CompleteMultipartUploadRequest, opts ...grpc.CallOption) (*CompleteMultipartUploadResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CompleteMultipartUploadResponse)
+	err := c.cc.Invoke(ctx, VideoManagement_CompleteMultipartUpload_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *videoManagementClient) AbortMultipartUpload(ctx context.Context, in *AbortMultipartUploadRequest, opts ...grpc.CallOption) (*AbortMultipartUploadResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AbortMultipartUploadResponse)
+	err := c.cc.Invoke(ctx, VideoManagement_AbortMultipartUpload_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *videoManagementClient) GetVideoStatus(ctx context.Context, in *GetVideoStatusRequest, opts ...grpc.CallOption) (*GetVideoStatusResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetVideoStatusResponse)
+	err := c.cc.Invoke(ctx, VideoManagement_GetVideoStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *videoManagementClient) GetVideoProcessingStatus(ctx context.Context, in *GetVideoProcessingStatusRequest, opts ...grpc.CallOption) (*GetVideoProcessingStatusResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetVideoProcessingStatusResponse)
+	err := c.cc.Invoke(ctx, VideoManagement_GetVideoProcessingStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *videoManagementClient) WatchVideoProgress(ctx context.Context, in *WatchVideoProgressRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[WatchVideoProgressResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &VideoManagement_ServiceDesc.Streams[0], VideoManagement_WatchVideoProgress_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchVideoProgressRequest, WatchVideoProgressResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type VideoManagement_WatchVideoProgressClient = grpc.ServerStreamingClient[WatchVideoProgressResponse]
+
+func (c *videoManagementClient) StoreContentKey(ctx context.Context, in *StoreContentKeyRequest, opts ...grpc.CallOption) (*StoreContentKeyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StoreContentKeyResponse)
+	err := c.cc.Invoke(ctx, VideoManagement_StoreContentKey_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *videoManagementClient) GetContentKey(ctx context.Context, in *GetContentKeyRequest, opts ...grpc.CallOption) (*GetContentKeyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetContentKeyResponse)
+	err := c.cc.Invoke(ctx, VideoManagement_GetContentKey_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *videoManagementClient) ServeStoryboard(ctx context.Context, in *ServeStoryboardRequest, opts ...grpc.CallOption) (*ServeStoryboardResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ServeStoryboardResponse)
+	err := c.cc.Invoke(ctx, VideoManagement_ServeStoryboard_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // VideoManagementServer is the server API for VideoManagement service.
 // All implementations should embed UnimplementedVideoManagementServer
 // for forward compatibility.
@@ -91,6 +280,21 @@ type VideoManagementServer interface {
 	GetChannelVideos(context.Context, *GetChannelVideosRequest) (*GetChannelVideosResponse, error)
 	GetVideoMetadataById(context.Context, *GetVideoMetadataByIdRequest) (*GetVideoMetadataByIdResponse, error)
 	ServePlaylist(context.Context, *ServePlaylistRequest) (*ServePlaylistResponse, error)
+	GetReelFeed(context.Context, *GetReelFeedRequest) (*GetReelFeedResponse, error)
+	DeleteVideo(context.Context, *DeleteVideoRequest) (*DeleteVideoResponse, error)
+	UpdateVideo(context.Context, *UpdateVideoRequest) (*UpdateVideoResponse, error)
+	RecordView(context.Context, *RecordViewRequest) (*RecordViewResponse, error)
+	CreateMultipartUpload(context.Context, *CreateMultipartUploadRequest) (*CreateMultipartUploadResponse, error)
+	GetUploadPartUrls(context.Context, *GetUploadPartUrlsRequest) (*GetUploadPartUrlsResponse, error)
+	ListUploadedParts(context.Context, *ListUploadedPartsRequest) (*ListUploadedPartsResponse, error)
+	CompleteMultipartUpload(context.Context, *CompleteMultipartUploadRequest) (*CompleteMultipartUploadResponse, error)
+	AbortMultipartUpload(context.Context, *AbortMultipartUploadRequest) (*AbortMultipartUploadResponse, error)
+	GetVideoStatus(context.Context, *GetVideoStatusRequest) (*GetVideoStatusResponse, error)
+	GetVideoProcessingStatus(context.Context, *GetVideoProcessingStatusRequest) (*GetVideoProcessingStatusResponse, error)
+	WatchVideoProgress(*WatchVideoProgressRequest, grpc.ServerStreamingServer[WatchVideoProgressResponse]) error
+	StoreContentKey(context.Context, *StoreContentKeyRequest) (*StoreContentKeyResponse, error)
+	GetContentKey(context.Context, *GetContentKeyRequest) (*GetContentKeyResponse, error)
+	ServeStoryboard(context.Context, *ServeStoryboardRequest) (*ServeStoryboardResponse, error)
 }
 
 // UnimplementedVideoManagementServer should be embedded to have
@@ -112,6 +316,51 @@ func (UnimplementedVideoManagementServer) GetVideoMetadataById(context.Context,
 func (UnimplementedVideoManagementServer) ServePlaylist(context.Context, *ServePlaylistRequest) (*ServePlaylistResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ServePlaylist not implemented")
 }
+func (UnimplementedVideoManagementServer) GetReelFeed(context.Context, *GetReelFeedRequest) (*GetReelFeedResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetReelFeed not implemented")
+}
+func (UnimplementedVideoManagementServer) DeleteVideo(context.Context, *DeleteVideoRequest) (*DeleteVideoResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteVideo not implemented")
+}
+func (UnimplementedVideoManagementServer) UpdateVideo(context.Context, *UpdateVideoRequest) (*UpdateVideoResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateVideo not implemented")
+}
+func (UnimplementedVideoManagementServer) RecordView(context.Context, *RecordViewRequest) (*RecordViewResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RecordView not implemented")
+}
+func (UnimplementedVideoManagementServer) CreateMultipartUpload(context.Context, *CreateMultipartUploadRequest) (*CreateMultipartUploadResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateMultipartUpload not implemented")
+}
+func (UnimplementedVideoManagementServer) GetUploadPartUrls(context.Context, *GetUploadPartUrlsRequest) (*GetUploadPartUrlsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUploadPartUrls not implemented")
+}
+func (UnimplementedVideoManagementServer) ListUploadedParts(context.Context, *ListUploadedPartsRequest) (*ListUploadedPartsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListUploadedParts not implemented")
+}
+func (UnimplementedVideoManagementServer) CompleteMultipartUpload(context.Context, *CompleteMultipartUploadRequest) (*CompleteMultipartUploadResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CompleteMultipartUpload not implemented")
+}
+func (UnimplementedVideoManagementServer) AbortMultipartUpload(context.Context, *AbortMultipartUploadRequest) (*AbortMultipartUploadResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AbortMultipartUpload not implemented")
+}
+func (UnimplementedVideoManagementServer) GetVideoStatus(context.Context, *GetVideoStatusRequest) (*GetVideoStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetVideoStatus not implemented")
+}
+func (UnimplementedVideoManagementServer) GetVideoProcessingStatus(context.Context, *GetVideoProcessingStatusRequest) (*GetVideoProcessingStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetVideoProcessingStatus not implemented")
+}
+func (UnimplementedVideoManagementServer) WatchVideoProgress(*WatchVideoProgressRequest, grpc.ServerStreamingServer[WatchVideoProgressResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchVideoProgress not implemented")
+}
+func (UnimplementedVideoManagementServer) StoreContentKey(context.Context, *StoreContentKeyRequest) (*StoreContentKeyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StoreContentKey not implemented")
+}
+func (UnimplementedVideoManagementServer) GetContentKey(context.Context, *GetContentKeyRequest) (*GetContentKeyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetContentKey not implemented")
+}
+func (UnimplementedVideoManagementServer) ServeStoryboard(context.Context, *ServeStoryboardRequest) (*ServeStoryboardResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ServeStoryboard not implemented")
+}
 func (UnimplementedVideoManagementServer) testEmbeddedByValue() {}
 
 // UnsafeVideoManagementServer may be embedded to opt out of forward compatibility for this service.
@@ -204,6 +453,269 @@ func _VideoManagement_ServePlaylist_Handler(srv interface{}, ctx context.Context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _VideoManagement_GetReelFeed_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetReelFeedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VideoManagementServer).GetReelFeed(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VideoManagement_GetReelFeed_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VideoManagementServer).GetReelFeed(ctx, req.(*GetReelFeedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VideoManagement_DeleteVideo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteVideoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VideoManagementServer).DeleteVideo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VideoManagement_DeleteVideo_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VideoManagementServer).DeleteVideo(ctx, req.(*DeleteVideoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VideoManagement_UpdateVideo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateVideoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VideoManagementServer).UpdateVideo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VideoManagement_UpdateVideo_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VideoManagementServer).UpdateVideo(ctx, req.(*UpdateVideoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VideoManagement_RecordView_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RecordViewRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VideoManagementServer).RecordView(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VideoManagement_RecordView_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VideoManagementServer).RecordView(ctx, req.(*RecordViewRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VideoManagement_CreateMultipartUpload_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateMultipartUploadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VideoManagementServer).CreateMultipartUpload(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VideoManagement_CreateMultipartUpload_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VideoManagementServer).CreateMultipartUpload(ctx, req.(*CreateMultipartUploadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VideoManagement_GetUploadPartUrls_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUploadPartUrlsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VideoManagementServer).GetUploadPartUrls(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VideoManagement_GetUploadPartUrls_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VideoManagementServer).GetUploadPartUrls(ctx, req.(*GetUploadPartUrlsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VideoManagement_ListUploadedParts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListUploadedPartsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VideoManagementServer).ListUploadedParts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VideoManagement_ListUploadedParts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VideoManagementServer).ListUploadedParts(ctx, req.(*ListUploadedPartsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VideoManagement_CompleteMultipartUpload_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CompleteMultipartUploadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VideoManagementServer).CompleteMultipartUpload(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VideoManagement_CompleteMultipartUpload_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VideoManagementServer).CompleteMultipartUpload(ctx, req.(*CompleteMultipartUploadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VideoManagement_AbortMultipartUpload_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AbortMultipartUploadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VideoManagementServer).AbortMultipartUpload(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VideoManagement_AbortMultipartUpload_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VideoManagementServer).AbortMultipartUpload(ctx, req.(*AbortMultipartUploadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VideoManagement_GetVideoStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetVideoStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VideoManagementServer).GetVideoStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VideoManagement_GetVideoStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VideoManagementServer).GetVideoStatus(ctx, req.(*GetVideoStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VideoManagement_GetVideoProcessingStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetVideoProcessingStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VideoManagementServer).GetVideoProcessingStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VideoManagement_GetVideoProcessingStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VideoManagementServer).GetVideoProcessingStatus(ctx, req.(*GetVideoProcessingStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VideoManagement_WatchVideoProgress_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchVideoProgressRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(VideoManagementServer).WatchVideoProgress(m, &grpc.GenericServerStream[WatchVideoProgressRequest, WatchVideoProgressResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type VideoManagement_WatchVideoProgressServer = grpc.ServerStreamingServer[WatchVideoProgressResponse]
+
+func _VideoManagement_StoreContentKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StoreContentKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VideoManagementServer).StoreContentKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VideoManagement_StoreContentKey_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VideoManagementServer).StoreContentKey(ctx, req.(*StoreContentKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VideoManagement_GetContentKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetContentKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VideoManagementServer).GetContentKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VideoManagement_GetContentKey_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VideoManagementServer).GetContentKey(ctx, req.(*GetContentKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VideoManagement_ServeStoryboard_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ServeStoryboardRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VideoManagementServer).ServeStoryboard(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VideoManagement_ServeStoryboard_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VideoManagementServer).ServeStoryboard(ctx, req.(*ServeStoryboardRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // VideoManagement_ServiceDesc is the grpc.ServiceDesc for VideoManagement service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -227,7 +739,69 @@ var VideoManagement_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ServePlaylist",
 			Handler:    _VideoManagement_ServePlaylist_Handler,
 		},
+		{
+			MethodName: "GetReelFeed",
+			Handler:    _VideoManagement_GetReelFeed_Handler,
+		},
+		{
+			MethodName: "DeleteVideo",
+			Handler:    _VideoManagement_DeleteVideo_Handler,
+		},
+		{
+			MethodName: "UpdateVideo",
+			Handler:    _VideoManagement_UpdateVideo_Handler,
+		},
+		{
+			MethodName: "RecordView",
+			Handler:    _VideoManagement_RecordView_Handler,
+		},
+		{
+			MethodName: "CreateMultipartUpload",
+			Handler:    _VideoManagement_CreateMultipartUpload_Handler,
+		},
+		{
+			MethodName: "GetUploadPartUrls",
+			Handler:    _VideoManagement_GetUploadPartUrls_Handler,
+		},
+		{
+			MethodName: "ListUploadedParts",
+			Handler:    _VideoManagement_ListUploadedParts_Handler,
+		},
+		{
+			MethodName: "CompleteMultipartUpload",
+			Handler:    _VideoManagement_CompleteMultipartUpload_Handler,
+		},
+		{
+			MethodName: "AbortMultipartUpload",
+			Handler:    _VideoManagement_AbortMultipartUpload_Handler,
+		},
+		{
+			MethodName: "GetVideoStatus",
+			Handler:    _VideoManagement_GetVideoStatus_Handler,
+		},
+		{
+			MethodName: "GetVideoProcessingStatus",
+			Handler:    _VideoManagement_GetVideoProcessingStatus_Handler,
+		},
+		{
+			MethodName: "StoreContentKey",
+			Handler:    _VideoManagement_StoreContentKey_Handler,
+		},
+		{
+			MethodName: "GetContentKey",
+			Handler:    _VideoManagement_GetContentKey_Handler,
+		},
+		{
+			MethodName: "ServeStoryboard",
+			Handler:    _VideoManagement_ServeStoryboard_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchVideoProgress",
+			Handler:       _VideoManagement_WatchVideoProgress_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "video_management.proto",
 }
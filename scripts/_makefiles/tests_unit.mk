@@ -63,4 +63,14 @@ ut-auth:
 	@make template-ut package=services/auth packageName=auth
 
 cov-auth:
-	@make template-cov package=services/auth packageName=auth
\ No newline at end of file
+	@make template-cov package=services/auth packageName=auth
+
+###
+### END-TO-END
+###
+
+ut-e2e:
+	@make template-ut package=tests/e2e packageName=e2e
+
+cov-e2e:
+	@make template-cov package=tests/e2e packageName=e2e
@@ -8,8 +8,8 @@ import (
 )
 
 // NewServer creates a new API Gateway server using the new internal structure
-func NewServer(ctx context.Context, port uint64, signingKey string) *server.Server {
-	return server.NewServer(ctx, config.LoadServerConfig(port, signingKey))
+func NewServer(ctx context.Context, port uint64, signingKey string, trustedProxies []string) *server.Server {
+	return server.NewServer(ctx, config.LoadServerConfig(port, signingKey, trustedProxies))
 }
 
 // InitializeRepos initializes any repositories or dependencies specific to API Gateway
@@ -44,6 +44,7 @@ type SecurityConfig struct {
 	JWTSecret       string        `mapstructure:"jwt_secret"`
 	TokenExpiration time.Duration `mapstructure:"token_expiration"`
 	AllowOrigins    []string      `mapstructure:"allow_origins"`
+	TrustedProxies  []string      `mapstructure:"trusted_proxies"`
 }
 
 // LoggingConfig holds logging configuration
@@ -53,7 +54,7 @@ type LoggingConfig struct {
 	RequestLog bool   `mapstructure:"request_log"`
 }
 
-func LoadServerConfig(port uint64, signingKey string) Config {
+func LoadServerConfig(port uint64, signingKey string, trustedProxies []string) Config {
 	return Config{
 		Server: ServerConfig{
 			Port:            port,
@@ -67,6 +68,7 @@ func LoadServerConfig(port uint64, signingKey string) Config {
 			JWTSecret:       signingKey,
 			TokenExpiration: DefaultTokenExpiration,
 			AllowOrigins:    []string{DefaultAllowOrigin},
+			TrustedProxies:  trustedProxies,
 		},
 		Logging: LoggingConfig{
 			Level:      DefaultLogLevel,
@@ -104,6 +104,12 @@ var (
 		Code:       "NOT_FOUND",
 	}
 
+	ErrHTTPConflict = &HTTPError{
+		StatusCode: http.StatusConflict,
+		Message:    "Conflict",
+		Code:       "CONFLICT",
+	}
+
 	ErrHTTPMethodNotAllowed = &HTTPError{
 		StatusCode: http.StatusMethodNotAllowed,
 		Message:    "Method Not Allowed",
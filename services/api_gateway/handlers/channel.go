@@ -148,6 +148,7 @@ func (h *ChannelHandler) GetChannelVideosByHandle(w http.ResponseWriter, r *http
 
 	getVideosReq := connect.NewRequest(&videoManagementProto.GetChannelVideosRequest{
 		ChannelId: resp.Msg.GetChannel().GetId(),
+		ViewerId:  helpers.GetUserID(r),
 		Limit:     int32(limitBy),
 		Offset:    int32(offsetBy),
 	})
@@ -257,6 +258,7 @@ func (h *ChannelHandler) GetChannelVideos(w http.ResponseWriter, r *http.Request
 
 	getVideosReq := connect.NewRequest(&videoManagementProto.GetChannelVideosRequest{
 		ChannelId: resp.Msg.GetChannel().GetId(),
+		ViewerId:  helpers.GetUserID(r),
 		Limit:     int32(limitBy),
 		Offset:    int32(offsetBy),
 	})
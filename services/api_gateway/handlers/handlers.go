@@ -1,16 +1,30 @@
 package handlers
 
+import (
+	"github.com/samber/do"
+
+	"github.com/sweetloveinyourheart/sweet-reel/pkg/s3"
+)
+
 // Handlers holds all the request handlers
 type Handlers struct {
 	AuthHandler    IAuthHandler
 	ChannelHandler IChannelHandler
 	Video          IVideoHandler
+	// Storage is only set when objects are stored by the local storage backend
+	Storage IStorageHandler
 }
 
 func NewHandlers() *Handlers {
-	return &Handlers{
+	handlers := &Handlers{
 		Video:          NewVideoHandler(),
 		ChannelHandler: NewChannelHandler(),
 		AuthHandler:    NewAuthHandler(),
 	}
+
+	if storage, err := do.Invoke[*s3.LocalStorage](nil); err == nil {
+		handlers.Storage = NewStorageHandler(storage)
+	}
+
+	return handlers
 }
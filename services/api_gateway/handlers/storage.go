@@ -0,0 +1,144 @@
+package handlers
+
+import (
+	stdErrors "errors"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/sweetloveinyourheart/sweet-reel/pkg/logger"
+	"github.com/sweetloveinyourheart/sweet-reel/pkg/s3"
+	"github.com/sweetloveinyourheart/sweet-reel/services/api_gateway/errors"
+	"github.com/sweetloveinyourheart/sweet-reel/services/api_gateway/helpers"
+)
+
+// metadataHeaderPrefix prefixes the object metadata sent along with an upload
+const metadataHeaderPrefix = "x-amz-meta-"
+
+type IStorageHandler interface {
+	ServeObject(w http.ResponseWriter, r *http.Request)
+	PutObject(w http.ResponseWriter, r *http.Request)
+}
+
+// StorageHandler serves the presigned URLs of the local storage backend, in place of
+// S3 or MinIO
+type StorageHandler struct {
+	storage *s3.LocalStorage
+}
+
+func NewStorageHandler(storage *s3.LocalStorage) IStorageHandler {
+	return &StorageHandler{
+		storage: storage,
+	}
+}
+
+// ServeObject handles GET and HEAD /storage/{expires}/{signature}/{bucket}/{key...}
+func (h *StorageHandler) ServeObject(w http.ResponseWriter, r *http.Request) {
+	bucket, key := r.PathValue("bucket"), r.PathValue("key")
+
+	err := h.storage.AuthorizeDownload(bucket, key, r.PathValue("expires"), r.PathValue("signature"))
+	if err != nil {
+		helpers.WriteErrorResponse(w, errors.ErrHTTPForbidden)
+		return
+	}
+
+	file, contentType, err := h.storage.OpenObject(key, bucket)
+	if err != nil {
+		writeStorageError(w, err)
+		return
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		logger.Global().Error("failed to stat object", zap.String("bucket", bucket), zap.String("key", key), zap.Error(err))
+		helpers.WriteErrorResponse(w, errors.ErrHTTPInternalServer)
+		return
+	}
+
+	// Large objects outlast the write timeout of the server
+	_ = http.NewResponseController(w).SetWriteDeadline(time.Time{})
+
+	if contentType != "" {
+		w.Header().Set("Content-Type", contentType)
+	}
+	http.ServeContent(w, r, path.Base(key), info.ModTime(), file)
+}
+
+// PutObject handles PUT /storage/{expires}/{signature}/{bucket}/{key...}, the uploads of
+// parts of multipart uploads included
+func (h *StorageHandler) PutObject(w http.ResponseWriter, r *http.Request) {
+	bucket, key := r.PathValue("bucket"), r.PathValue("key")
+
+	if r.URL.Query().Has("uploadId") {
+		h.putPart(w, r, bucket, key)
+		return
+	}
+
+	metadata := make(map[string]string)
+	for name, values := range r.Header {
+		name = strings.ToLower(name)
+		if strings.HasPrefix(name, metadataHeaderPrefix) && len(values) > 0 {
+			metadata[strings.TrimPrefix(name, metadataHeaderPrefix)] = values[0]
+		}
+	}
+
+	err := h.storage.AuthorizeUpload(bucket, key, r.PathValue("expires"), r.PathValue("signature"), metadata)
+	if err != nil {
+		helpers.WriteErrorResponse(w, errors.ErrHTTPForbidden)
+		return
+	}
+
+	// Uploads of large videos outlast the read timeout of the server
+	_ = http.NewResponseController(w).SetReadDeadline(time.Time{})
+
+	err = h.storage.PutObject(key, bucket, r.Body, r.Header.Get("Content-Type"), metadata)
+	if err != nil {
+		writeStorageError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// putPart stores a part of the multipart upload named by the uploadId and partNumber query
+func (h *StorageHandler) putPart(w http.ResponseWriter, r *http.Request, bucket string, key string) {
+	uploadID, partNumber := r.URL.Query().Get("uploadId"), r.URL.Query().Get("partNumber")
+
+	err := h.storage.AuthorizeUploadPart(bucket, key, r.PathValue("expires"), r.PathValue("signature"), uploadID, partNumber)
+	if err != nil {
+		helpers.WriteErrorResponse(w, errors.ErrHTTPForbidden)
+		return
+	}
+
+	number, err := strconv.ParseInt(partNumber, 10, 32)
+	if err != nil {
+		helpers.WriteErrorResponse(w, errors.ErrHTTPBadRequest)
+		return
+	}
+
+	_ = http.NewResponseController(w).SetReadDeadline(time.Time{})
+
+	etag, err := h.storage.PutPart(key, bucket, uploadID, int32(number), r.Body)
+	if err != nil {
+		writeStorageError(w, err)
+		return
+	}
+
+	w.Header().Set("ETag", etag)
+	w.WriteHeader(http.StatusOK)
+}
+
+func writeStorageError(w http.ResponseWriter, err error) {
+	if stdErrors.Is(err, s3.ErrObjectNotFound) || stdErrors.Is(err, s3.ErrUploadNotFound) {
+		helpers.WriteErrorResponse(w, errors.ErrHTTPNotFound)
+		return
+	}
+
+	logger.Global().Error("storage request failed", zap.Error(err))
+	helpers.WriteErrorResponse(w, errors.ErrHTTPInternalServer)
+}
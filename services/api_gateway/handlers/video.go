@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"net/http"
+	"strconv"
 
 	"connectrpc.com/connect"
 	"github.com/samber/do"
@@ -21,8 +22,22 @@ import (
 
 type IVideoHandler interface {
 	GeneratePresignedURL(w http.ResponseWriter, r *http.Request)
+	CreateMultipartUpload(w http.ResponseWriter, r *http.Request)
+	GetUploadPartUrls(w http.ResponseWriter, r *http.Request)
+	ListUploadedParts(w http.ResponseWriter, r *http.Request)
+	CompleteMultipartUpload(w http.ResponseWriter, r *http.Request)
+	AbortMultipartUpload(w http.ResponseWriter, r *http.Request)
 	GetVideoMetadata(w http.ResponseWriter, r *http.Request)
+	GetReelFeed(w http.ResponseWriter, r *http.Request)
+	UpdateVideo(w http.ResponseWriter, r *http.Request)
+	DeleteVideo(w http.ResponseWriter, r *http.Request)
+	GetVideoStatus(w http.ResponseWriter, r *http.Request)
+	WatchVideoProgress(w http.ResponseWriter, r *http.Request)
+	RecordView(w http.ResponseWriter, r *http.Request)
 	ServePlaylist(w http.ResponseWriter, r *http.Request)
+	ServeDASHManifest(w http.ResponseWriter, r *http.Request)
+	ServeContentKey(w http.ResponseWriter, r *http.Request)
+	ServeStoryboard(w http.ResponseWriter, r *http.Request)
 }
 
 type VideoHandler struct {
@@ -76,9 +91,10 @@ func (h *VideoHandler) GeneratePresignedURL(w http.ResponseWriter, r *http.Reque
 
 	// Build response
 	responseData := response.PresignedUrlResponse{
-		VideoId:      presignedUrlRes.Msg.GetVideoId(),
-		PresignedUrl: presignedUrlRes.Msg.GetPresignedUrl(),
-		ExpiresIn:    presignedUrlRes.Msg.GetExpiresIn(),
+		VideoId:       presignedUrlRes.Msg.GetVideoId(),
+		PresignedUrl:  presignedUrlRes.Msg.GetPresignedUrl(),
+		ExpiresIn:     presignedUrlRes.Msg.GetExpiresIn(),
+		UploadHeaders: presignedUrlRes.Msg.GetUploadHeaders(),
 	}
 
 	helpers.WriteJSONSuccess(w, responseData)
@@ -91,7 +107,10 @@ func (h *VideoHandler) GetVideoMetadata(w http.ResponseWriter, r *http.Request)
 	// Get videoID from URL path parameter
 	videoID := r.PathValue("video_id")
 
-	getMetadataReq := connect.NewRequest(&videoManagementProto.GetVideoMetadataByIdRequest{VideoId: videoID})
+	getMetadataReq := connect.NewRequest(&videoManagementProto.GetVideoMetadataByIdRequest{
+		VideoId:  videoID,
+		ViewerId: helpers.GetUserID(r),
+	})
 	getMetadataResp, err := h.videoManagementServiceClient.GetVideoMetadataById(ctx, getMetadataReq)
 	if err != nil {
 		logger.Global().Error("error getting video metadata", zap.Error(err))
@@ -114,6 +133,9 @@ func (h *VideoHandler) GetVideoMetadata(w http.ResponseWriter, r *http.Request)
 		TotalView:          getMetadataResp.Msg.GetTotalView(),
 		AvailableQualities: getMetadataResp.Msg.GetAvailableQualities(),
 		ProcessedAt:        getMetadataResp.Msg.GetProcessedAt(),
+		Format:             getMetadataResp.Msg.GetFormat(),
+		Visibility:         getMetadataResp.Msg.GetVisibility(),
+		StoryboardURL:      videoResourceURL(videoID, getMetadataResp.Msg.GetStoryboardUrl()),
 		Channel: response.ChannelMetadata{
 			Name:   getChannelresp.Msg.GetChannel().GetName(),
 			Handle: getChannelresp.Msg.GetChannel().GetHandle(),
@@ -129,11 +151,479 @@ func (h *VideoHandler) GetVideoMetadata(w http.ResponseWriter, r *http.Request)
 
 }
 
-// Handles both master and variant playlists
-// Example routes:
+// UpdateVideo handles PATCH /api/v1/videos/{video_id}
+func (h *VideoHandler) UpdateVideo(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userID := helpers.GetUserID(r)
+
+	// Get videoID from URL path parameter
+	videoID := r.PathValue("video_id")
+
+	if videoID == "" {
+		helpers.WriteErrorResponse(w, errors.NewHTTPError(
+			http.StatusBadRequest,
+			"video_id is required",
+			"INVALID_VIDEO_ID",
+		))
+		return
+	}
+
+	var body request.UpdateVideoRequestBody
+	err := helpers.ParseJSONBody(r, &body)
+	if err != nil {
+		helpers.WriteErrorResponse(w, err)
+		return
+	}
+
+	updateVideoReq := connect.NewRequest(&videoManagementProto.UpdateVideoRequest{
+		VideoId:     videoID,
+		UserId:      userID,
+		Title:       body.Title,
+		Description: body.Description,
+		Visibility:  body.Visibility,
+		PublishAt:   body.PublishAt,
+	})
+
+	updateVideoRes, err := h.videoManagementServiceClient.UpdateVideo(ctx, updateVideoReq)
+	if err != nil {
+		logger.Global().Error("error performing update video request", zap.Error(err))
+		helpers.WriteErrorResponse(w, videoManagementHTTPError(err))
+		return
+	}
+
+	// Build response
+	responseData := response.UpdateVideoResponse{
+		VideoID:          updateVideoRes.Msg.GetVideoId(),
+		VideoTitle:       updateVideoRes.Msg.GetVideoTitle(),
+		VideoDescription: updateVideoRes.Msg.GetVideoDescription(),
+		Visibility:       updateVideoRes.Msg.GetVisibility(),
+		PublishAt:        updateVideoRes.Msg.GetPublishAt(),
+		UpdatedAt:        updateVideoRes.Msg.GetUpdatedAt(),
+	}
+
+	helpers.WriteJSONSuccess(w, responseData)
+}
+
+// DeleteVideo handles DELETE /api/v1/videos/{video_id}
+func (h *VideoHandler) DeleteVideo(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userID := helpers.GetUserID(r)
+
+	// Get videoID from URL path parameter
+	videoID := r.PathValue("video_id")
+
+	if videoID == "" {
+		helpers.WriteErrorResponse(w, errors.NewHTTPError(
+			http.StatusBadRequest,
+			"video_id is required",
+			"INVALID_VIDEO_ID",
+		))
+		return
+	}
+
+	deleteVideoReq := connect.NewRequest(&videoManagementProto.DeleteVideoRequest{
+		VideoId: videoID,
+		UserId:  userID,
+	})
+
+	deleteVideoRes, err := h.videoManagementServiceClient.DeleteVideo(ctx, deleteVideoReq)
+	if err != nil {
+		logger.Global().Error("error performing delete video request", zap.Error(err))
+		helpers.WriteErrorResponse(w, videoManagementHTTPError(err))
+		return
+	}
+
+	// Build response
+	responseData := response.DeleteVideoResponse{
+		VideoID: deleteVideoRes.Msg.GetVideoId(),
+	}
+
+	helpers.WriteJSONSuccess(w, responseData)
+}
+
+// GetVideoStatus handles GET /api/v1/videos/{video_id}/status
+// Uploaders follow the processing of their video, and learn why it failed if it did.
+func (h *VideoHandler) GetVideoStatus(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userID := helpers.GetUserID(r)
+
+	// Get videoID from URL path parameter
+	videoID := r.PathValue("video_id")
+
+	if videoID == "" {
+		helpers.WriteErrorResponse(w, errors.NewHTTPError(
+			http.StatusBadRequest,
+			"video_id is required",
+			"INVALID_VIDEO_ID",
+		))
+		return
+	}
+
+	getStatusReq := connect.NewRequest(&videoManagementProto.GetVideoStatusRequest{
+		VideoId: videoID,
+		UserId:  userID,
+	})
+
+	getStatusRes, err := h.videoManagementServiceClient.GetVideoStatus(ctx, getStatusReq)
+	if err != nil {
+		logger.Global().Error("error performing get video status request", zap.Error(err))
+		helpers.WriteErrorResponse(w, videoManagementHTTPError(err))
+		return
+	}
+
+	// Build response
+	responseData := response.GetVideoStatusResponse{
+		VideoID: getStatusRes.Msg.GetVideoId(),
+		Status:  getStatusRes.Msg.GetStatus(),
+	}
+	if getStatusRes.Msg.GetFailureCode() != "" {
+		responseData.Failure = &response.VideoFailure{
+			Code:   getStatusRes.Msg.GetFailureCode(),
+			Reason: getStatusRes.Msg.GetFailureReason(),
+		}
+	}
+
+	helpers.WriteJSONSuccess(w, responseData)
+}
+
+// WatchVideoProgress handles GET /api/v1/videos/{video_id}/progress
+// Streams the processing progress of an upload as server-sent "progress" events, until the
+// video is ready or failed. Upload pages show it as a progress bar.
+func (h *VideoHandler) WatchVideoProgress(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userID := helpers.GetUserID(r)
+
+	// Get videoID from URL path parameter
+	videoID := r.PathValue("video_id")
+
+	if videoID == "" {
+		helpers.WriteErrorResponse(w, errors.NewHTTPError(
+			http.StatusBadRequest,
+			"video_id is required",
+			"INVALID_VIDEO_ID",
+		))
+		return
+	}
+
+	stream, err := h.videoManagementServiceClient.WatchVideoProgress(ctx, connect.NewRequest(&videoManagementProto.WatchVideoProgressRequest{
+		VideoId: videoID,
+		UserId:  userID,
+	}))
+	if err != nil {
+		logger.Global().Error("error performing watch video progress request", zap.Error(err))
+		helpers.WriteErrorResponse(w, videoManagementHTTPError(err))
+		return
+	}
+	defer stream.Close()
+
+	// Errors such as an unknown video come with the first update, before any event is sent
+	if !stream.Receive() {
+		logger.Global().Error("error receiving video progress", zap.Error(stream.Err()))
+		helpers.WriteErrorResponse(w, videoManagementHTTPError(stream.Err()))
+		return
+	}
+
+	helpers.StartServerSentEvents(w)
+	for {
+		update := stream.Msg()
+		event := response.VideoProgressEvent{
+			VideoID:        update.GetVideoId(),
+			Status:         update.GetStatus(),
+			Attempt:        update.GetAttempt(),
+			Percentage:     update.GetPercentage(),
+			Speed:          update.GetSpeed(),
+			CurrentSeconds: update.GetCurrentSeconds(),
+			TotalSeconds:   update.GetTotalSeconds(),
+		}
+		if update.GetFailureCode() != "" {
+			event.Failure = &response.VideoFailure{
+				Code:   update.GetFailureCode(),
+				Reason: update.GetFailureReason(),
+			}
+		}
+
+		if err := helpers.WriteServerSentEvent(w, "progress", event); err != nil {
+			// The uploader left the page
+			return
+		}
+
+		if !stream.Receive() {
+			break
+		}
+	}
+
+	if err := stream.Err(); err != nil && ctx.Err() == nil {
+		logger.Global().Error("video progress stream ended", zap.Error(err))
+	}
+}
+
+// RecordView handles POST /api/v1/videos/{video_id}/views
+// The first call of a watch session records the view and returns its id,
+// the player then sends that id back periodically with the seconds watched so far.
+func (h *VideoHandler) RecordView(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// Get videoID from URL path parameter
+	videoID := r.PathValue("video_id")
+
+	if videoID == "" {
+		helpers.WriteErrorResponse(w, errors.NewHTTPError(
+			http.StatusBadRequest,
+			"video_id is required",
+			"INVALID_VIDEO_ID",
+		))
+		return
+	}
+
+	var body request.RecordViewRequestBody
+	err := helpers.ParseJSONBody(r, &body)
+	if err != nil {
+		helpers.WriteErrorResponse(w, err)
+		return
+	}
+
+	recordViewReq := connect.NewRequest(&videoManagementProto.RecordViewRequest{
+		VideoId:       videoID,
+		ViewerId:      helpers.GetUserID(r),
+		IpAddress:     helpers.GetClientIP(r),
+		UserAgent:     r.UserAgent(),
+		ViewId:        body.ViewID,
+		WatchDuration: body.WatchDuration,
+	})
+
+	recordViewRes, err := h.videoManagementServiceClient.RecordView(ctx, recordViewReq)
+	if err != nil {
+		logger.Global().Error("error performing record view request", zap.Error(err))
+		helpers.WriteErrorResponse(w, videoManagementHTTPError(err))
+		return
+	}
+
+	// Build response
+	responseData := response.RecordViewResponse{
+		ViewID:    recordViewRes.Msg.GetViewId(),
+		Counted:   recordViewRes.Msg.GetCounted(),
+		TotalView: recordViewRes.Msg.GetTotalView(),
+	}
+
+	helpers.WriteJSONSuccess(w, responseData)
+}
+
+// GetReelFeed handles GET /api/v1/reels/feed
+func (h *VideoHandler) GetReelFeed(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// Paging is optional, the video management service picks a default page size
+	var limitBy, offsetBy int64
+	if limit := r.URL.Query().Get("limit"); limit != "" {
+		var err error
+		limitBy, err = strconv.ParseInt(limit, 0, 32)
+		if err != nil {
+			helpers.WriteErrorResponse(w, errors.NewHTTPError(http.StatusBadRequest, "limit is not valid", "INVALID_ARGUMENTS"))
+			return
+		}
+	}
+
+	if offset := r.URL.Query().Get("offset"); offset != "" {
+		var err error
+		offsetBy, err = strconv.ParseInt(offset, 0, 32)
+		if err != nil {
+			helpers.WriteErrorResponse(w, errors.NewHTTPError(http.StatusBadRequest, "offset is not valid", "INVALID_ARGUMENTS"))
+			return
+		}
+	}
+
+	getReelFeedReq := connect.NewRequest(&videoManagementProto.GetReelFeedRequest{
+		Limit:  int32(limitBy),
+		Offset: int32(offsetBy),
+	})
+
+	getReelFeedRes, err := h.videoManagementServiceClient.GetReelFeed(ctx, getReelFeedReq)
+	if err != nil {
+		logger.Global().Error("error performing get reel feed request", zap.Error(err))
+		helpers.WriteErrorResponse(w, errors.ErrHTTPInternalServer)
+		return
+	}
+
+	// Build response
+	reels := make([]response.ReelResponse, 0)
+	for _, reel := range getReelFeedRes.Msg.GetReels() {
+		reels = append(reels, response.ReelResponse{
+			VideoID:       reel.GetVideoId(),
+			ChannelID:     reel.GetChannelId(),
+			Title:         reel.GetVideoTitle(),
+			ThumbnailUrl:  reel.GetThumbnailUrl(),
+			TotalDuration: reel.GetTotalDuration(),
+			TotalView:     reel.GetTotalView(),
+			ProcessedAt:   reel.GetProcessedAt(),
+		})
+	}
+
+	responseData := response.GetReelFeedResponse{
+		Reels: reels,
+	}
+
+	helpers.WriteJSONSuccess(w, responseData)
+}
+
+// videoManagementHTTPError maps an error returned by the video management service to the
+// HTTP error sent to the client
+func videoManagementHTTPError(err error) error {
+	switch connect.CodeOf(err) {
+	case connect.CodeInvalidArgument:
+		return errors.ErrHTTPBadRequest
+	case connect.CodeNotFound:
+		return errors.ErrHTTPNotFound
+	case connect.CodePermissionDenied:
+		return errors.ErrHTTPForbidden
+	case connect.CodeFailedPrecondition:
+		return errors.ErrHTTPConflict
+	default:
+		return errors.ErrHTTPInternalServer
+	}
+}
+
+// ServePlaylist handles both master and variant playlists
 //
-//	GET /videos/{video_id}/hls
-//	GET /videos/{video_id}/hls/{quality}
+//	GET /api/v1/videos/{video_id}/playlist.m3u8
+//	GET /api/v1/videos/{video_id}/{quality}/playlist.m3u8
 func (h *VideoHandler) ServePlaylist(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// Get videoID from URL path parameter, quality is empty for the master playlist
+	videoID := r.PathValue("video_id")
+	quality := r.PathValue("quality")
+
+	servePlaylistReq := connect.NewRequest(&videoManagementProto.ServePlaylistRequest{
+		VideoId:  videoID,
+		ViewerId: helpers.GetUserID(r),
+		Quality:  quality,
+	})
+
+	servePlaylistRes, err := h.videoManagementServiceClient.ServePlaylist(ctx, servePlaylistReq)
+	if err != nil {
+		logger.Global().Error("error performing serve playlist request", zap.Error(err))
+		helpers.WriteErrorResponse(w, videoManagementHTTPError(err))
+		return
+	}
+
+	// Playlists hold signed urls and may belong to a private video, so only the viewer caches them
+	w.Header().Set("Content-Type", "application/vnd.apple.mpegurl")
+	w.Header().Set("Cache-Control", "private, max-age="+strconv.Itoa(int(servePlaylistRes.Msg.GetMaxAgeSeconds())))
+
+	// The DASH manifest, when there is one, sits next to the master playlist
+	if dashManifestURL := servePlaylistRes.Msg.GetDashManifestUrl(); dashManifestURL != "" {
+		w.Header().Set("Link", "<"+dashManifestURL+`>; rel="alternate"; type="application/dash+xml"`)
+	}
+	w.WriteHeader(http.StatusOK)
 
+	if _, err := w.Write([]byte(servePlaylistRes.Msg.GetPlaylist())); err != nil {
+		logger.Global().Error("failed to write playlist response", zap.Error(err))
+	}
+}
+
+// ServeDASHManifest handles the DASH manifest of videos packaged for DASH players
+//
+//	GET /api/v1/videos/{video_id}/manifest.mpd
+func (h *VideoHandler) ServeDASHManifest(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// Get videoID from URL path parameter
+	videoID := r.PathValue("video_id")
+
+	serveManifestReq := connect.NewRequest(&videoManagementProto.ServePlaylistRequest{
+		VideoId:  videoID,
+		ViewerId: helpers.GetUserID(r),
+		Format:   "dash",
+	})
+
+	serveManifestRes, err := h.videoManagementServiceClient.ServePlaylist(ctx, serveManifestReq)
+	if err != nil {
+		logger.Global().Error("error performing serve DASH manifest request", zap.Error(err))
+		helpers.WriteErrorResponse(w, videoManagementHTTPError(err))
+		return
+	}
+
+	// Like playlists, the manifest holds signed urls
+	w.Header().Set("Content-Type", "application/dash+xml")
+	w.Header().Set("Cache-Control", "private, max-age="+strconv.Itoa(int(serveManifestRes.Msg.GetMaxAgeSeconds())))
+	w.WriteHeader(http.StatusOK)
+
+	if _, err := w.Write([]byte(serveManifestRes.Msg.GetPlaylist())); err != nil {
+		logger.Global().Error("failed to write DASH manifest response", zap.Error(err))
+	}
+}
+
+// ServeContentKey handles the key decrypting the segments of an encrypted video, referenced by
+// its variant playlists. Like playlists, anyone the video is visible to gets the key, so
+// anonymous viewers and native players can play public videos. Encryption only keeps private
+// and unlisted videos from whoever can't see them, public segments have nothing to hide.
+//
+//	GET /api/v1/videos/{video_id}/key
+func (h *VideoHandler) ServeContentKey(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// Get videoID from URL path parameter
+	videoID := r.PathValue("video_id")
+
+	getKeyReq := connect.NewRequest(&videoManagementProto.GetContentKeyRequest{
+		VideoId:  videoID,
+		ViewerId: helpers.GetUserID(r),
+	})
+
+	getKeyRes, err := h.videoManagementServiceClient.GetContentKey(ctx, getKeyReq)
+	if err != nil {
+		logger.Global().Error("error performing get content key request", zap.Error(err))
+		helpers.WriteErrorResponse(w, videoManagementHTTPError(err))
+		return
+	}
+
+	// Keys are never cached, a viewer who loses access to the video can't fetch it again
+	w.Header().Set("Content-Type", "application/octet-stream")
+	w.Header().Set("Cache-Control", "private, no-store")
+	w.WriteHeader(http.StatusOK)
+
+	if _, err := w.Write(getKeyRes.Msg.GetKey()); err != nil {
+		logger.Global().Error("failed to write content key response", zap.Error(err))
+	}
+}
+
+// ServeStoryboard handles the WebVTT track mapping playback times to sprite regions, used by
+// players for scrubbing previews
+//
+//	GET /api/v1/videos/{video_id}/storyboard.vtt
+func (h *VideoHandler) ServeStoryboard(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// Get videoID from URL path parameter
+	videoID := r.PathValue("video_id")
+
+	serveStoryboardReq := connect.NewRequest(&videoManagementProto.ServeStoryboardRequest{
+		VideoId:  videoID,
+		ViewerId: helpers.GetUserID(r),
+	})
+
+	serveStoryboardRes, err := h.videoManagementServiceClient.ServeStoryboard(ctx, serveStoryboardReq)
+	if err != nil {
+		logger.Global().Error("error performing serve storyboard request", zap.Error(err))
+		helpers.WriteErrorResponse(w, videoManagementHTTPError(err))
+		return
+	}
+
+	// Sprites are signed urls, cached by the viewer only like playlists
+	w.Header().Set("Content-Type", "text/vtt")
+	w.Header().Set("Cache-Control", "private, max-age="+strconv.Itoa(int(serveStoryboardRes.Msg.GetMaxAgeSeconds())))
+	w.WriteHeader(http.StatusOK)
+
+	if _, err := w.Write([]byte(serveStoryboardRes.Msg.GetTrack())); err != nil {
+		logger.Global().Error("failed to write storyboard response", zap.Error(err))
+	}
+}
+
+// videoResourceURL returns the gateway url of a resource of the video given relative to the
+// video, or an empty url when there is no such resource
+func videoResourceURL(videoID, relativeURL string) string {
+	if relativeURL == "" {
+		return ""
+	}
+	return "/api/v1/videos/" + videoID + "/" + relativeURL
 }
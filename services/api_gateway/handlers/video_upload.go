@@ -0,0 +1,221 @@
+package handlers
+
+import (
+	"net/http"
+
+	"connectrpc.com/connect"
+	"go.uber.org/zap"
+
+	"github.com/sweetloveinyourheart/sweet-reel/pkg/logger"
+	videoManagementProto "github.com/sweetloveinyourheart/sweet-reel/proto/code/video_management/go"
+	"github.com/sweetloveinyourheart/sweet-reel/services/api_gateway/errors"
+	"github.com/sweetloveinyourheart/sweet-reel/services/api_gateway/helpers"
+	"github.com/sweetloveinyourheart/sweet-reel/services/api_gateway/types/request"
+	"github.com/sweetloveinyourheart/sweet-reel/services/api_gateway/types/response"
+)
+
+// CreateMultipartUpload handles POST /api/v1/videos/multipart-upload
+// The file is then uploaded in parts, each to its own URL, and the upload completed once they are all sent.
+// After a failure, the parts already uploaded are listed and only the missing ones sent again.
+func (h *VideoHandler) CreateMultipartUpload(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userID := helpers.GetUserID(r)
+
+	var body request.CreateMultipartUploadRequestBody
+	err := helpers.ParseJSONBody(r, &body)
+	if err != nil {
+		helpers.WriteErrorResponse(w, err)
+		return
+	}
+
+	createUploadReq := connect.NewRequest(&videoManagementProto.CreateMultipartUploadRequest{
+		ChannelId:   body.ChannelID,
+		Title:       body.Title,
+		Description: body.Description,
+		FileName:    body.FileName,
+		UploaderId:  userID,
+		FileSize:    body.FileSize,
+	})
+
+	createUploadRes, err := h.videoManagementServiceClient.CreateMultipartUpload(ctx, createUploadReq)
+	if err != nil {
+		logger.Global().Error("error performing create multipart upload request", zap.Error(err))
+		helpers.WriteErrorResponse(w, videoManagementHTTPError(err))
+		return
+	}
+
+	// Build response
+	responseData := response.CreateMultipartUploadResponse{
+		VideoID:   createUploadRes.Msg.GetVideoId(),
+		PartSize:  createUploadRes.Msg.GetPartSize(),
+		PartCount: createUploadRes.Msg.GetPartCount(),
+	}
+
+	helpers.WriteJSONSuccess(w, responseData)
+}
+
+// GetUploadPartUrls handles POST /api/v1/videos/{video_id}/upload/parts
+func (h *VideoHandler) GetUploadPartUrls(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userID := helpers.GetUserID(r)
+
+	videoID, ok := uploadVideoID(w, r)
+	if !ok {
+		return
+	}
+
+	var body request.GetUploadPartUrlsRequestBody
+	err := helpers.ParseJSONBody(r, &body)
+	if err != nil {
+		helpers.WriteErrorResponse(w, err)
+		return
+	}
+
+	partUrlsReq := connect.NewRequest(&videoManagementProto.GetUploadPartUrlsRequest{
+		VideoId:     videoID,
+		UserId:      userID,
+		PartNumbers: body.PartNumbers,
+	})
+
+	partUrlsRes, err := h.videoManagementServiceClient.GetUploadPartUrls(ctx, partUrlsReq)
+	if err != nil {
+		logger.Global().Error("error performing get upload part urls request", zap.Error(err))
+		helpers.WriteErrorResponse(w, videoManagementHTTPError(err))
+		return
+	}
+
+	// Build response
+	urls := make([]response.UploadPartUrl, 0, len(partUrlsRes.Msg.GetUrls()))
+	for _, url := range partUrlsRes.Msg.GetUrls() {
+		urls = append(urls, response.UploadPartUrl{
+			PartNumber: url.GetPartNumber(),
+			Url:        url.GetUrl(),
+		})
+	}
+
+	responseData := response.GetUploadPartUrlsResponse{
+		Urls:      urls,
+		ExpiresIn: partUrlsRes.Msg.GetExpiresIn(),
+	}
+
+	helpers.WriteJSONSuccess(w, responseData)
+}
+
+// ListUploadedParts handles GET /api/v1/videos/{video_id}/upload
+func (h *VideoHandler) ListUploadedParts(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userID := helpers.GetUserID(r)
+
+	videoID, ok := uploadVideoID(w, r)
+	if !ok {
+		return
+	}
+
+	listPartsReq := connect.NewRequest(&videoManagementProto.ListUploadedPartsRequest{
+		VideoId: videoID,
+		UserId:  userID,
+	})
+
+	listPartsRes, err := h.videoManagementServiceClient.ListUploadedParts(ctx, listPartsReq)
+	if err != nil {
+		logger.Global().Error("error performing list uploaded parts request", zap.Error(err))
+		helpers.WriteErrorResponse(w, videoManagementHTTPError(err))
+		return
+	}
+
+	// Build response
+	parts := make([]response.UploadedPart, 0, len(listPartsRes.Msg.GetParts()))
+	for _, part := range listPartsRes.Msg.GetParts() {
+		parts = append(parts, response.UploadedPart{
+			PartNumber: part.GetPartNumber(),
+			Size:       part.GetSize(),
+			ETag:       part.GetEtag(),
+		})
+	}
+
+	responseData := response.ListUploadedPartsResponse{
+		VideoID:   listPartsRes.Msg.GetVideoId(),
+		PartSize:  listPartsRes.Msg.GetPartSize(),
+		PartCount: listPartsRes.Msg.GetPartCount(),
+		Parts:     parts,
+	}
+
+	helpers.WriteJSONSuccess(w, responseData)
+}
+
+// CompleteMultipartUpload handles POST /api/v1/videos/{video_id}/upload/complete
+func (h *VideoHandler) CompleteMultipartUpload(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userID := helpers.GetUserID(r)
+
+	videoID, ok := uploadVideoID(w, r)
+	if !ok {
+		return
+	}
+
+	completeUploadReq := connect.NewRequest(&videoManagementProto.CompleteMultipartUploadRequest{
+		VideoId: videoID,
+		UserId:  userID,
+	})
+
+	completeUploadRes, err := h.videoManagementServiceClient.CompleteMultipartUpload(ctx, completeUploadReq)
+	if err != nil {
+		logger.Global().Error("error performing complete multipart upload request", zap.Error(err))
+		helpers.WriteErrorResponse(w, videoManagementHTTPError(err))
+		return
+	}
+
+	// Build response
+	responseData := response.CompleteMultipartUploadResponse{
+		VideoID: completeUploadRes.Msg.GetVideoId(),
+	}
+
+	helpers.WriteJSONSuccess(w, responseData)
+}
+
+// AbortMultipartUpload handles DELETE /api/v1/videos/{video_id}/upload
+// The video is deleted along with its upload.
+func (h *VideoHandler) AbortMultipartUpload(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userID := helpers.GetUserID(r)
+
+	videoID, ok := uploadVideoID(w, r)
+	if !ok {
+		return
+	}
+
+	abortUploadReq := connect.NewRequest(&videoManagementProto.AbortMultipartUploadRequest{
+		VideoId: videoID,
+		UserId:  userID,
+	})
+
+	abortUploadRes, err := h.videoManagementServiceClient.AbortMultipartUpload(ctx, abortUploadReq)
+	if err != nil {
+		logger.Global().Error("error performing abort multipart upload request", zap.Error(err))
+		helpers.WriteErrorResponse(w, videoManagementHTTPError(err))
+		return
+	}
+
+	// Build response
+	responseData := response.AbortMultipartUploadResponse{
+		VideoID: abortUploadRes.Msg.GetVideoId(),
+	}
+
+	helpers.WriteJSONSuccess(w, responseData)
+}
+
+// uploadVideoID returns the video_id path parameter, writing an error response when it is missing
+func uploadVideoID(w http.ResponseWriter, r *http.Request) (string, bool) {
+	videoID := r.PathValue("video_id")
+
+	if videoID == "" {
+		helpers.WriteErrorResponse(w, errors.NewHTTPError(
+			http.StatusBadRequest,
+			"video_id is required",
+			"INVALID_VIDEO_ID",
+		))
+		return "", false
+	}
+
+	return videoID, true
+}
@@ -31,6 +31,19 @@ func PATCH(handler http.HandlerFunc) http.Handler {
 	return methodHandler(http.MethodPatch, handler)
 }
 
+// Methods creates a handler that dispatches requests to the handler registered for
+// their HTTP method, for routes that serve more than one method on the same path
+func Methods(handlers map[string]http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		handler, ok := handlers[r.Method]
+		if !ok {
+			WriteErrorResponse(w, errors.ErrHTTPMethodNotAllowed)
+			return
+		}
+		handler.ServeHTTP(w, r)
+	})
+}
+
 // methodHandler ensures the request uses the correct HTTP method
 func methodHandler(method string, handler http.HandlerFunc) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
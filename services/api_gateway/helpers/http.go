@@ -2,12 +2,16 @@ package helpers
 
 import (
 	"encoding/json"
+	"fmt"
+	"net"
 	"net/http"
+	"time"
 
 	"go.uber.org/zap"
 
 	"github.com/sweetloveinyourheart/sweet-reel/pkg/logger"
 	"github.com/sweetloveinyourheart/sweet-reel/services/api_gateway/errors"
+	"github.com/sweetloveinyourheart/sweet-reel/services/api_gateway/middleware"
 )
 
 // RequestBody interface for request body validation
@@ -44,6 +48,20 @@ func ParseJSONBody[T RequestBody](r *http.Request, body *T) error {
 	return nil
 }
 
+// GetClientIP returns the address of the client, without port, as resolved by the client IP
+// middleware
+func GetClientIP(r *http.Request) string {
+	if clientIP, ok := r.Context().Value(middleware.ClientIPContextKey).(string); ok {
+		return clientIP
+	}
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
 // WriteJSONResponse writes a successful JSON response
 func WriteJSONResponse(w http.ResponseWriter, statusCode int, data any) {
 	w.Header().Set("Content-Type", "application/json")
@@ -65,6 +83,31 @@ func WriteJSONCreated(w http.ResponseWriter, data any) {
 	WriteJSONResponse(w, http.StatusCreated, data)
 }
 
+// StartServerSentEvents turns the response into a stream of server-sent events, which is
+// kept open past the write timeout of the server
+func StartServerSentEvents(w http.ResponseWriter) {
+	_ = http.NewResponseController(w).SetWriteDeadline(time.Time{})
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no") // Proxies pass events on as they come
+	w.WriteHeader(http.StatusOK)
+}
+
+// WriteServerSentEvent writes data as JSON in an event of the given type and flushes it to
+// the client
+func WriteServerSentEvent(w http.ResponseWriter, event string, data any) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	if _, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event, payload); err != nil {
+		return err
+	}
+	return http.NewResponseController(w).Flush()
+}
+
 // WriteErrorResponse writes an error response in JSON format
 func WriteErrorResponse(w http.ResponseWriter, err error) {
 	var httpErr *errors.HTTPError
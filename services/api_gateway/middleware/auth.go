@@ -27,6 +27,8 @@ type AuthConfig struct {
 	SkipPaths   []string
 	TokenLookup string // "header:Authorization"
 	AuthScheme  string // "Bearer"
+	// Optional lets requests without a token through anonymously, a token that is present must still be valid
+	Optional bool
 }
 
 // NewAuthMiddleware creates a new JWT authentication middleware
@@ -49,6 +51,10 @@ func NewAuthMiddleware(config AuthConfig) func(http.Handler) http.Handler {
 			}
 
 			token, err := extractToken(r, config)
+			if err == errors.ErrTokenNotFound && config.Optional {
+				next.ServeHTTP(w, r)
+				return
+			}
 			if err != nil {
 				writeErrorResponse(w, http.StatusUnauthorized,
 					errors.ErrAuthenticationTokenRequired.Message,
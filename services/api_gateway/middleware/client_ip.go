@@ -0,0 +1,97 @@
+package middleware
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/sweetloveinyourheart/sweet-reel/pkg/logger"
+)
+
+type clientIPKey string
+
+const ClientIPContextKey clientIPKey = "clientIP"
+
+// ClientIPConfig holds configuration for client IP middleware
+type ClientIPConfig struct {
+	TrustedProxies []string // CIDRs of the proxies allowed to report the client address
+}
+
+// ClientIPMiddleware resolves the address of the client and stores it in the context. The
+// X-Forwarded-For and X-Real-IP headers are only honored when the peer is a trusted proxy,
+// anyone else could put any address in them.
+func ClientIPMiddleware(next http.Handler, config ClientIPConfig) http.Handler {
+	trustedProxies := make([]netip.Prefix, 0, len(config.TrustedProxies))
+	for _, cidr := range config.TrustedProxies {
+		prefix, err := netip.ParsePrefix(strings.TrimSpace(cidr))
+		if err != nil {
+			logger.Global().Warn("Ignoring invalid trusted proxy", zap.String("cidr", cidr), zap.Error(err))
+			continue
+		}
+		trustedProxies = append(trustedProxies, prefix.Masked())
+	}
+
+	isTrusted := func(addr string) bool {
+		ip, err := netip.ParseAddr(addr)
+		if err != nil {
+			return false
+		}
+		ip = ip.Unmap()
+		for _, prefix := range trustedProxies {
+			if prefix.Contains(ip) {
+				return true
+			}
+		}
+		return false
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		clientIP := resolveClientIP(r, isTrusted)
+
+		ctx := context.WithValue(r.Context(), ClientIPContextKey, clientIP)
+
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// resolveClientIP walks the forwarding chain from the gateway outwards and returns the
+// first hop that is not a trusted proxy
+func resolveClientIP(r *http.Request, isTrusted func(string) bool) string {
+	clientIP := r.RemoteAddr
+	if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+		clientIP = host
+	}
+	if !isTrusted(clientIP) {
+		return clientIP
+	}
+
+	var hops []string
+	for _, header := range r.Header.Values("X-Forwarded-For") {
+		for hop := range strings.SplitSeq(header, ",") {
+			hops = append(hops, strings.TrimSpace(hop))
+		}
+	}
+	if len(hops) == 0 {
+		if xRealIP := strings.TrimSpace(r.Header.Get("X-Real-IP")); xRealIP != "" {
+			hops = append(hops, xRealIP)
+		}
+	}
+
+	for i := len(hops) - 1; i >= 0; i-- {
+		if _, err := netip.ParseAddr(hops[i]); err != nil {
+			// Garbage in the chain, the last proxy that could be trusted is the best we know
+			return clientIP
+		}
+
+		clientIP = hops[i]
+		if !isTrusted(clientIP) {
+			break
+		}
+	}
+
+	return clientIP
+}
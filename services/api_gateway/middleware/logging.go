@@ -41,6 +41,11 @@ func (rw *responseWriter) WriteHeader(statusCode int) {
 	rw.ResponseWriter.WriteHeader(statusCode)
 }
 
+// Unwrap lets http.ResponseController reach the underlying response writer
+func (rw *responseWriter) Unwrap() http.ResponseWriter {
+	return rw.ResponseWriter
+}
+
 // LoggingMiddleware creates a new logging middleware
 func LoggingMiddleware(next http.Handler, config LoggingConfig) http.Handler {
 	if config.MaxBodySize == 0 {
@@ -159,14 +164,10 @@ func AccessLogMiddleware(next http.Handler) http.Handler {
 
 // getClientIP extracts client IP from request
 func getClientIP(r *http.Request) string {
-	// Check X-Forwarded-For header
-	if xForwardedFor := r.Header.Get("X-Forwarded-For"); xForwardedFor != "" {
-		return xForwardedFor
-	}
-
-	// Check X-Real-IP header
-	if xRealIP := r.Header.Get("X-Real-IP"); xRealIP != "" {
-		return xRealIP
+	// Use the address resolved by the client IP middleware, which only trusts forwarding
+	// headers set by known proxies
+	if clientIP, ok := r.Context().Value(ClientIPContextKey).(string); ok {
+		return clientIP
 	}
 
 	// Fall back to RemoteAddr
@@ -29,19 +29,35 @@ func NewRouter(mux *http.ServeMux, handlers *handlers.Handlers, config config.Co
 func (r *Router) SetupRoutes() {
 	r.setupPublicRoutes()
 	r.setupProtectedRoutes()
+	r.setupStorageRoutes()
 }
 
 // setupPublicRoutes sets up public API routes
 func (r *Router) setupPublicRoutes() {
+	// Public routes still identify the caller when a token is sent, so owners can see their own non-public videos
+	optionalAuthMiddleware := middleware.NewAuthMiddleware(middleware.AuthConfig{
+		SigningKey: r.config.Security.JWTSecret,
+		Optional:   true,
+	})
+
 	r.mux.Handle("/api/v1/oauth/google", helpers.POST(r.handlers.AuthHandler.GoogleOAuth))
 	r.mux.Handle("/api/v1/auth/refresh-token", helpers.GET(r.handlers.AuthHandler.RefreshToken))
 
 	// Channel routes
 	r.mux.Handle("/api/v1/channels/{handle}", helpers.GET(r.handlers.ChannelHandler.GetChannelByHandle))
-	r.mux.Handle("/api/v1/channels/videos/{handle}", helpers.GET(r.handlers.ChannelHandler.GetChannelVideosByHandle))
+	r.mux.Handle("/api/v1/channels/videos/{handle}", optionalAuthMiddleware(helpers.GET(r.handlers.ChannelHandler.GetChannelVideosByHandle)))
 
 	// Video routes
-	r.mux.Handle("/api/v1/videos/{video_id}/metadata", helpers.GET(r.handlers.Video.GetVideoMetadata))
+	r.mux.Handle("/api/v1/videos/{video_id}/metadata", optionalAuthMiddleware(helpers.GET(r.handlers.Video.GetVideoMetadata)))
+	r.mux.Handle("/api/v1/videos/{video_id}/views", optionalAuthMiddleware(helpers.POST(r.handlers.Video.RecordView)))
+	r.mux.Handle("/api/v1/videos/{video_id}/playlist.m3u8", optionalAuthMiddleware(helpers.GET(r.handlers.Video.ServePlaylist)))
+	r.mux.Handle("/api/v1/videos/{video_id}/{quality}/playlist.m3u8", optionalAuthMiddleware(helpers.GET(r.handlers.Video.ServePlaylist)))
+	r.mux.Handle("/api/v1/videos/{video_id}/manifest.mpd", optionalAuthMiddleware(helpers.GET(r.handlers.Video.ServeDASHManifest)))
+	r.mux.Handle("/api/v1/videos/{video_id}/storyboard.vtt", optionalAuthMiddleware(helpers.GET(r.handlers.Video.ServeStoryboard)))
+	r.mux.Handle("/api/v1/videos/{video_id}/key", optionalAuthMiddleware(helpers.GET(r.handlers.Video.ServeContentKey)))
+
+	// Reel routes
+	r.mux.Handle("/api/v1/reels/feed", helpers.GET(r.handlers.Video.GetReelFeed))
 }
 
 // setupProtectedRoutes sets up authenticated API routes
@@ -56,8 +72,35 @@ func (r *Router) setupProtectedRoutes() {
 
 	// Video management routes
 	r.mux.Handle("/api/v1/videos/presigned-url", authMiddleware(helpers.POST(r.handlers.Video.GeneratePresignedURL)))
+	r.mux.Handle("/api/v1/videos/multipart-upload", authMiddleware(helpers.POST(r.handlers.Video.CreateMultipartUpload)))
+	r.mux.Handle("/api/v1/videos/{video_id}/upload", authMiddleware(helpers.Methods(map[string]http.Handler{
+		http.MethodGet:    helpers.GET(r.handlers.Video.ListUploadedParts),
+		http.MethodDelete: helpers.DELETE(r.handlers.Video.AbortMultipartUpload),
+	})))
+	r.mux.Handle("/api/v1/videos/{video_id}/upload/parts", authMiddleware(helpers.POST(r.handlers.Video.GetUploadPartUrls)))
+	r.mux.Handle("/api/v1/videos/{video_id}/upload/complete", authMiddleware(helpers.POST(r.handlers.Video.CompleteMultipartUpload)))
+	r.mux.Handle("/api/v1/videos/{video_id}/status", authMiddleware(helpers.GET(r.handlers.Video.GetVideoStatus)))
+	r.mux.Handle("/api/v1/videos/{video_id}/progress", authMiddleware(helpers.GET(r.handlers.Video.WatchVideoProgress)))
+	r.mux.Handle("/api/v1/videos/{video_id}", authMiddleware(helpers.Methods(map[string]http.Handler{
+		http.MethodPatch:  helpers.PATCH(r.handlers.Video.UpdateVideo),
+		http.MethodDelete: helpers.DELETE(r.handlers.Video.DeleteVideo),
+	})))
 
 	// Channel routes
 	r.mux.Handle("/api/v1/channels", authMiddleware(helpers.GET(r.handlers.ChannelHandler.GetChannel)))
 	r.mux.Handle("/api/v1/channels/videos", authMiddleware(helpers.GET(r.handlers.ChannelHandler.GetChannelVideos)))
 }
+
+// setupStorageRoutes sets up the routes presigned URLs of the local storage backend point to.
+// Requests are authorized by the signature of the URL.
+func (r *Router) setupStorageRoutes() {
+	if r.handlers.Storage == nil {
+		return
+	}
+
+	r.mux.Handle("/storage/{expires}/{signature}/{bucket}/{key...}", helpers.Methods(map[string]http.Handler{
+		http.MethodGet:  http.HandlerFunc(r.handlers.Storage.ServeObject),
+		http.MethodHead: http.HandlerFunc(r.handlers.Storage.ServeObject),
+		http.MethodPut:  helpers.PUT(r.handlers.Storage.PutObject),
+	}))
+}
@@ -67,9 +67,10 @@ func (s *Server) createMiddlewareChain() http.Handler {
 
 	// CORS middleware
 	handler = middleware.CORSMiddleware(handler, middleware.CORSConfig{
-		AllowOrigins: s.config.Security.AllowOrigins,
-		AllowMethods: []string{"GET", "POST", "HEAD", "PUT", "DELETE", "PATCH", "OPTIONS"},
-		AllowHeaders: []string{"Origin", "Content-Type", "Accept", "Authorization", "X-Request-ID"},
+		AllowOrigins:  s.config.Security.AllowOrigins,
+		AllowMethods:  []string{"GET", "POST", "HEAD", "PUT", "DELETE", "PATCH", "OPTIONS"},
+		AllowHeaders:  []string{"Origin", "Content-Type", "Accept", "Authorization", "X-Request-ID", "traceparent", "tracestate", "x-amz-meta-traceparent", "x-amz-meta-tracestate"},
+		ExposeHeaders: []string{"ETag"},
 	})
 
 	// Logging middleware (if enabled)
@@ -85,6 +86,14 @@ func (s *Server) createMiddlewareChain() http.Handler {
 	// Request ID middleware
 	handler = middleware.RequestIDMiddleware(handler)
 
+	// Client IP middleware, ahead of logging so that the resolved address is available to it
+	handler = middleware.ClientIPMiddleware(handler, middleware.ClientIPConfig{
+		TrustedProxies: s.config.Security.TrustedProxies,
+	})
+
+	// Tracing middleware, outermost so that the span covers the whole request
+	handler = middleware.TracingMiddleware(handler)
+
 	return handler
 }
 
@@ -36,3 +36,88 @@ func (r PresignedUrlRequestBody) Validate() error {
 
 	return nil
 }
+
+type CreateMultipartUploadRequestBody struct {
+	ChannelID   string `json:"channel_id"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	FileName    string `json:"file_name"`
+	FileSize    int64  `json:"file_size"`
+}
+
+func (r CreateMultipartUploadRequestBody) Validate() error {
+	if err := (PresignedUrlRequestBody{
+		ChannelID:   r.ChannelID,
+		Title:       r.Title,
+		Description: r.Description,
+		FileName:    r.FileName,
+	}).Validate(); err != nil {
+		return err
+	}
+
+	if r.FileSize <= 0 {
+		return errors.New("file_size should be positive")
+	}
+
+	return nil
+}
+
+type GetUploadPartUrlsRequestBody struct {
+	PartNumbers []int32 `json:"part_numbers"`
+}
+
+func (r GetUploadPartUrlsRequestBody) Validate() error {
+	if len(r.PartNumbers) == 0 {
+		return errors.New("part_numbers should not be empty")
+	}
+
+	for _, partNumber := range r.PartNumbers {
+		if partNumber < 1 {
+			return errors.New("part_numbers should start at 1")
+		}
+	}
+
+	return nil
+}
+
+type UpdateVideoRequestBody struct {
+	Title       *string `json:"title"`
+	Description *string `json:"description"`
+	Visibility  *string `json:"visibility"`
+	PublishAt   *int64  `json:"publish_at"`
+}
+
+func (r UpdateVideoRequestBody) Validate() error {
+	if r.Title == nil && r.Description == nil && r.Visibility == nil && r.PublishAt == nil {
+		return errors.New("title, description, visibility or publish_at should be provided")
+	}
+
+	if r.Title != nil && *r.Title == "" {
+		return errors.New("title should not be empty")
+	}
+
+	if r.PublishAt != nil && *r.PublishAt < 0 {
+		return errors.New("publish_at should be a unix timestamp")
+	}
+
+	return nil
+}
+
+type RecordViewRequestBody struct {
+	ViewID        string `json:"view_id"`
+	WatchDuration int32  `json:"watch_duration"`
+}
+
+func (r RecordViewRequestBody) Validate() error {
+	if r.ViewID != "" {
+		if _, err := uuid.FromString(r.ViewID); err != nil {
+			return errors.New("view_id should be a valid uuid string")
+		}
+	}
+
+	if r.WatchDuration < 0 {
+		return errors.New("watch_duration should not be negative")
+	}
+
+	return nil
+}
@@ -1,9 +1,89 @@
 package response
 
 type PresignedUrlResponse struct {
-	VideoId      string `json:"video_id"`
-	PresignedUrl string `json:"presigned_url"`
-	ExpiresIn    int32  `json:"expires_in"`
+	VideoId       string            `json:"video_id"`
+	PresignedUrl  string            `json:"presigned_url"`
+	ExpiresIn     int32             `json:"expires_in"`
+	UploadHeaders map[string]string `json:"upload_headers,omitempty"`
+}
+
+type CreateMultipartUploadResponse struct {
+	VideoID   string `json:"video_id"`
+	PartSize  int64  `json:"part_size"`
+	PartCount int32  `json:"part_count"`
+}
+
+type UploadPartUrl struct {
+	PartNumber int32  `json:"part_number"`
+	Url        string `json:"url"`
+}
+
+type GetUploadPartUrlsResponse struct {
+	Urls      []UploadPartUrl `json:"urls"`
+	ExpiresIn int32           `json:"expires_in"`
+}
+
+type UploadedPart struct {
+	PartNumber int32  `json:"part_number"`
+	Size       int64  `json:"size"`
+	ETag       string `json:"etag"`
+}
+
+type ListUploadedPartsResponse struct {
+	VideoID   string         `json:"video_id"`
+	PartSize  int64          `json:"part_size"`
+	PartCount int32          `json:"part_count"`
+	Parts     []UploadedPart `json:"parts"`
+}
+
+type CompleteMultipartUploadResponse struct {
+	VideoID string `json:"video_id"`
+}
+
+type AbortMultipartUploadResponse struct {
+	VideoID string `json:"video_id"`
+}
+
+type UpdateVideoResponse struct {
+	VideoID          string `json:"video_id"`
+	VideoTitle       string `json:"video_title"`
+	VideoDescription string `json:"video_description"`
+	Visibility       string `json:"visibility"`
+	PublishAt        int64  `json:"publish_at,omitempty"`
+	UpdatedAt        int64  `json:"updated_at"`
+}
+
+type RecordViewResponse struct {
+	ViewID    string `json:"view_id"`
+	Counted   bool   `json:"counted"`
+	TotalView int64  `json:"total_view"`
+}
+
+type DeleteVideoResponse struct {
+	VideoID string `json:"video_id"`
+}
+
+type VideoFailure struct {
+	Code   string `json:"code"`
+	Reason string `json:"reason"`
+}
+
+type GetVideoStatusResponse struct {
+	VideoID string        `json:"video_id"`
+	Status  string        `json:"status"`
+	Failure *VideoFailure `json:"failure,omitempty"` // Set when the video failed processing
+}
+
+// VideoProgressEvent is sent to uploaders following the processing of their video
+type VideoProgressEvent struct {
+	VideoID        string        `json:"video_id"`
+	Status         string        `json:"status"`
+	Attempt        int32         `json:"attempt"`
+	Percentage     float64       `json:"percentage"`
+	Speed          string        `json:"speed,omitempty"`
+	CurrentSeconds float64       `json:"current_seconds"`
+	TotalSeconds   float64       `json:"total_seconds"`
+	Failure        *VideoFailure `json:"failure,omitempty"` // Set when the video failed processing
 }
 
 type UserVideoResponse struct {
@@ -24,5 +104,22 @@ type GetVideoMetadataResponse struct {
 	TotalView          int64           `json:"total_view,omitempty"`
 	AvailableQualities []string        `json:"available_qualities,omitempty"`
 	ProcessedAt        int64           `json:"processed_at,omitempty"`
+	Format             string          `json:"format,omitempty"`
+	Visibility         string          `json:"visibility,omitempty"`
+	StoryboardURL      string          `json:"storyboard_url,omitempty"` // WebVTT track of scrubbing previews
 	Channel            ChannelMetadata `json:"channel_metadata"`
 }
+
+type ReelResponse struct {
+	VideoID       string `json:"video_id"`
+	ChannelID     string `json:"channel_id"`
+	Title         string `json:"title"`
+	ThumbnailUrl  string `json:"thumbnail_url"`
+	TotalDuration int32  `json:"total_duration"`
+	TotalView     int64  `json:"total_view"`
+	ProcessedAt   int64  `json:"processed_at"`
+}
+
+type GetReelFeedResponse struct {
+	Reels []ReelResponse `json:"reels"`
+}
@@ -0,0 +1,55 @@
+package channelstats_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/samber/do"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/sweetloveinyourheart/sweet-reel/pkg/kafka"
+	testingPkg "github.com/sweetloveinyourheart/sweet-reel/pkg/testing"
+	"github.com/sweetloveinyourheart/sweet-reel/services/user/repos"
+	"github.com/sweetloveinyourheart/sweet-reel/services/user/repos/mocks"
+)
+
+type ChannelStatsSuite struct {
+	*testingPkg.Suite
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	mockChannelRepository *mocks.MockChannelRepository
+}
+
+func (as *ChannelStatsSuite) SetupTest() {
+	as.mockChannelRepository = new(mocks.MockChannelRepository)
+	as.ctx, as.cancel = context.WithTimeout(context.Background(), 10*time.Second)
+}
+
+func (as *ChannelStatsSuite) TearDownTest() {
+	if as.cancel != nil {
+		as.cancel()
+	}
+
+	as.mockChannelRepository = nil
+}
+
+func TestChannelStatsSuite(t *testing.T) {
+	as := &ChannelStatsSuite{
+		Suite: testingPkg.MakeSuite(t),
+	}
+
+	suite.Run(t, as)
+}
+
+func (as *ChannelStatsSuite) setupEnvironment() {
+	do.Override(nil, func(i *do.Injector) (*kafka.Client, error) {
+		client := &kafka.Client{}
+		return client, nil
+	})
+
+	do.Override(nil, func(i *do.Injector) (repos.IChannelRepository, error) {
+		return as.mockChannelRepository, nil
+	})
+}
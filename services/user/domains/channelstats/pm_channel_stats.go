@@ -0,0 +1,116 @@
+package channelstats
+
+import (
+	"context"
+
+	"github.com/cockroachdb/errors"
+	"github.com/samber/do"
+	"go.uber.org/zap"
+
+	"github.com/sweetloveinyourheart/sweet-reel/pkg/kafka"
+	"github.com/sweetloveinyourheart/sweet-reel/pkg/logger"
+	"github.com/sweetloveinyourheart/sweet-reel/pkg/messages"
+	"github.com/sweetloveinyourheart/sweet-reel/services/user/repos"
+)
+
+// ChannelStatsManager keeps the cached view and video totals of channels in line with video_management
+type ChannelStatsManager struct {
+	ctx         context.Context
+	channelRepo repos.IChannelRepository
+}
+
+func NewChannelStatsManager(ctx context.Context) (*ChannelStatsManager, error) {
+	kafkaClient, err := do.Invoke[*kafka.Client](nil)
+	if err != nil {
+		return nil, err
+	}
+
+	channelRepo, err := do.Invoke[repos.IChannelRepository](nil)
+	if err != nil {
+		return nil, err
+	}
+
+	csm := &ChannelStatsManager{
+		ctx:         ctx,
+		channelRepo: channelRepo,
+	}
+
+	go func() {
+		// Totals are applied straight from the handler, so a failed write goes through the consumer retry policy
+		messageHandler := func(ctx context.Context, msg *kafka.ConsumedMessage) error {
+			switch msg.Topic {
+			case kafka.KafkaChannelViewsChangedTopic:
+				return csm.HandleChannelViewsChangedMessage(ctx, msg)
+			case kafka.KafkaChannelVideosChangedTopic:
+				return csm.HandleChannelVideosChangedMessage(ctx, msg)
+			}
+
+			return nil
+		}
+		consumer, err := kafkaClient.CreateConsumer(kafka.KafkaUserChannelStatsGroup,
+			[]string{kafka.KafkaChannelViewsChangedTopic, kafka.KafkaChannelVideosChangedTopic},
+			messageHandler)
+
+		if err != nil {
+			logger.Global().ErrorContext(ctx, "failed to create consumer", zap.Error(err))
+			return
+		}
+
+		err = consumer.Start(ctx)
+		if err != nil {
+			logger.Global().ErrorContext(ctx, "failed to start consumer", zap.Error(err))
+			return
+		}
+
+		<-ctx.Done()
+		if err := consumer.Stop(); err != nil {
+			logger.Global().ErrorContext(ctx, "failed to stop consumer", zap.Error(err))
+		}
+	}()
+
+	return csm, nil
+}
+
+func (csm *ChannelStatsManager) HandleChannelViewsChangedMessage(ctx context.Context, message *kafka.ConsumedMessage) error {
+	if message == nil {
+		return errors.New("message is nil")
+	}
+
+	var msg messages.ChannelViewsChanged
+	if err := message.DecodeEvent(&msg); err != nil {
+		return err
+	}
+
+	applied, err := csm.channelRepo.SyncTotalViews(ctx, msg.ChannelID, msg.TotalViews, msg.ObservedAt)
+	if err != nil {
+		return err
+	}
+
+	logger.Global().Debug("channel total views synced",
+		zap.String("channel_id", msg.ChannelID.String()),
+		zap.Int64("total_views", msg.TotalViews),
+		zap.Bool("applied", applied))
+	return nil
+}
+
+func (csm *ChannelStatsManager) HandleChannelVideosChangedMessage(ctx context.Context, message *kafka.ConsumedMessage) error {
+	if message == nil {
+		return errors.New("message is nil")
+	}
+
+	var msg messages.ChannelVideosChanged
+	if err := message.DecodeEvent(&msg); err != nil {
+		return err
+	}
+
+	applied, err := csm.channelRepo.SyncTotalVideos(ctx, msg.ChannelID, msg.TotalVideos, msg.ObservedAt)
+	if err != nil {
+		return err
+	}
+
+	logger.Global().Debug("channel total videos synced",
+		zap.String("channel_id", msg.ChannelID.String()),
+		zap.Int64("total_videos", msg.TotalVideos),
+		zap.Bool("applied", applied))
+	return nil
+}
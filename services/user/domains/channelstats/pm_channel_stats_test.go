@@ -0,0 +1,98 @@
+package channelstats_test
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/sweetloveinyourheart/sweet-reel/pkg/kafka"
+	"github.com/sweetloveinyourheart/sweet-reel/pkg/messages"
+	"github.com/sweetloveinyourheart/sweet-reel/services/user/domains/channelstats"
+)
+
+func (as *ChannelStatsSuite) consumedMessage(topic string, value any) *kafka.ConsumedMessage {
+	data, err := json.Marshal(value)
+	as.NoError(err)
+
+	return &kafka.ConsumedMessage{
+		Topic: topic,
+		Value: data,
+	}
+}
+
+func (as *ChannelStatsSuite) TestHandleChannelViewsChanged_Applied() {
+	as.setupEnvironment()
+
+	event := messages.ChannelViewsChanged{
+		ChannelID:  uuid.Must(uuid.NewV7()),
+		TotalViews: 1234,
+		ObservedAt: time.Now().UTC().Truncate(time.Microsecond),
+	}
+
+	as.mockChannelRepository.On("SyncTotalViews", mock.Anything, event.ChannelID, event.TotalViews, mock.MatchedBy(func(observedAt time.Time) bool {
+		return observedAt.Equal(event.ObservedAt)
+	})).Return(true, nil)
+
+	manager, err := channelstats.NewChannelStatsManager(as.ctx)
+	as.NoError(err)
+
+	err = manager.HandleChannelViewsChangedMessage(as.ctx, as.consumedMessage(kafka.KafkaChannelViewsChangedTopic, event))
+	as.NoError(err)
+	as.mockChannelRepository.AssertExpectations(as.T())
+}
+
+func (as *ChannelStatsSuite) TestHandleChannelViewsChanged_StaleTotalIgnored() {
+	as.setupEnvironment()
+
+	event := messages.ChannelViewsChanged{
+		ChannelID:  uuid.Must(uuid.NewV7()),
+		TotalViews: 10,
+		ObservedAt: time.Now().UTC().Add(-time.Hour),
+	}
+
+	// A redelivered or older total is not applied, and that is not an error
+	as.mockChannelRepository.On("SyncTotalViews", mock.Anything, event.ChannelID, event.TotalViews, mock.Anything).Return(false, nil)
+
+	manager, err := channelstats.NewChannelStatsManager(as.ctx)
+	as.NoError(err)
+
+	err = manager.HandleChannelViewsChangedMessage(as.ctx, as.consumedMessage(kafka.KafkaChannelViewsChangedTopic, event))
+	as.NoError(err)
+}
+
+func (as *ChannelStatsSuite) TestHandleChannelVideosChanged_RepositoryError() {
+	as.setupEnvironment()
+
+	event := messages.ChannelVideosChanged{
+		ChannelID:   uuid.Must(uuid.NewV7()),
+		TotalVideos: 3,
+		ObservedAt:  time.Now().UTC(),
+	}
+
+	as.mockChannelRepository.On("SyncTotalVideos", mock.Anything, event.ChannelID, event.TotalVideos, mock.Anything).
+		Return(false, errors.New("database unavailable"))
+
+	manager, err := channelstats.NewChannelStatsManager(as.ctx)
+	as.NoError(err)
+
+	// The error is returned so the consumer retries the message
+	err = manager.HandleChannelVideosChangedMessage(as.ctx, as.consumedMessage(kafka.KafkaChannelVideosChangedTopic, event))
+	as.Error(err)
+}
+
+func (as *ChannelStatsSuite) TestHandleChannelVideosChanged_InvalidPayload() {
+	as.setupEnvironment()
+
+	manager, err := channelstats.NewChannelStatsManager(as.ctx)
+	as.NoError(err)
+
+	err = manager.HandleChannelVideosChangedMessage(as.ctx, &kafka.ConsumedMessage{
+		Topic: kafka.KafkaChannelVideosChangedTopic,
+		Value: []byte("not json"),
+	})
+	as.Error(err)
+	as.mockChannelRepository.AssertNotCalled(as.T(), "SyncTotalVideos", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
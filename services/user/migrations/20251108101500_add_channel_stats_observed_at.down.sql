@@ -0,0 +1,3 @@
+ALTER TABLE channels
+DROP COLUMN IF EXISTS total_videos_observed_at,
+DROP COLUMN IF EXISTS total_views_observed_at;
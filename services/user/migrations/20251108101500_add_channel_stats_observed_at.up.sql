@@ -0,0 +1,4 @@
+-- Time the cached totals were counted at in video_management, so older counts never overwrite newer ones
+ALTER TABLE channels
+ADD COLUMN total_views_observed_at TIMESTAMP,
+ADD COLUMN total_videos_observed_at TIMESTAMP;
@@ -2,6 +2,7 @@ package repos
 
 import (
 	"context"
+	"time"
 
 	"github.com/gofrs/uuid"
 
@@ -26,6 +27,8 @@ type IChannelRepository interface {
 	UpdateTotalVideos(ctx context.Context, channelID uuid.UUID, totalVideos int) error
 	IncrementTotalVideos(ctx context.Context, channelID uuid.UUID) error
 	DecrementTotalVideos(ctx context.Context, channelID uuid.UUID) error
+	SyncTotalViews(ctx context.Context, channelID uuid.UUID, totalViews int64, observedAt time.Time) (bool, error)
+	SyncTotalVideos(ctx context.Context, channelID uuid.UUID, totalVideos int64, observedAt time.Time) (bool, error)
 
 	// Channel subscription operations
 	CreateSubscription(ctx context.Context, subscription *models.ChannelSubscription) error
@@ -203,6 +206,37 @@ func (r *ChannelRepository) DecrementTotalVideos(ctx context.Context, channelID
 	return err
 }
 
+// SyncTotalViews sets the cached total views of a channel to a total counted at observedAt.
+// Totals counted before the one already stored are ignored, which makes applying the same
+// or an out of order total harmless. It reports whether the total was applied.
+func (r *ChannelRepository) SyncTotalViews(ctx context.Context, channelID uuid.UUID, totalViews int64, observedAt time.Time) (bool, error) {
+	query := `
+		UPDATE channels
+		SET total_views = $2, total_views_observed_at = $3, updated_at = NOW()
+		WHERE id = $1 AND (total_views_observed_at IS NULL OR total_views_observed_at < $3)`
+
+	result, err := r.Tx.Exec(ctx, query, channelID, totalViews, observedAt)
+	if err != nil {
+		return false, err
+	}
+	return result.RowsAffected() > 0, nil
+}
+
+// SyncTotalVideos sets the cached video count of a channel to a count taken at observedAt,
+// ignoring counts older than the one already stored
+func (r *ChannelRepository) SyncTotalVideos(ctx context.Context, channelID uuid.UUID, totalVideos int64, observedAt time.Time) (bool, error) {
+	query := `
+		UPDATE channels
+		SET total_videos = $2, total_videos_observed_at = $3, updated_at = NOW()
+		WHERE id = $1 AND (total_videos_observed_at IS NULL OR total_videos_observed_at < $3)`
+
+	result, err := r.Tx.Exec(ctx, query, channelID, totalVideos, observedAt)
+	if err != nil {
+		return false, err
+	}
+	return result.RowsAffected() > 0, nil
+}
+
 // Channel subscription operations
 
 func (r *ChannelRepository) CreateSubscription(ctx context.Context, subscription *models.ChannelSubscription) error {
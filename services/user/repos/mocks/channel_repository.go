@@ -2,6 +2,7 @@ package mocks
 
 import (
 	"context"
+	"time"
 
 	"github.com/gofrs/uuid"
 	"github.com/stretchr/testify/mock"
@@ -75,6 +76,16 @@ func (m *MockChannelRepository) DecrementSubscriberCount(ctx context.Context, ch
 	return args.Error(0)
 }
 
+func (m *MockChannelRepository) SyncTotalViews(ctx context.Context, channelID uuid.UUID, totalViews int64, observedAt time.Time) (bool, error) {
+	args := m.Called(ctx, channelID, totalViews, observedAt)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockChannelRepository) SyncTotalVideos(ctx context.Context, channelID uuid.UUID, totalVideos int64, observedAt time.Time) (bool, error) {
+	args := m.Called(ctx, channelID, totalVideos, observedAt)
+	return args.Bool(0), args.Error(1)
+}
+
 func (m *MockChannelRepository) UpdateTotalViews(ctx context.Context, channelID uuid.UUID, totalViews int64) error {
 	args := m.Called(ctx, channelID, totalViews)
 	return args.Error(0)
@@ -3,11 +3,18 @@ package user
 import (
 	"context"
 	"embed"
+
+	"github.com/sweetloveinyourheart/sweet-reel/services/user/domains/channelstats"
 )
 
 //go:embed migrations/*.sql
 var FS embed.FS
 
 func InitializeRepos(ctx context.Context) error {
+	_, err := channelstats.NewChannelStatsManager(ctx)
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
@@ -0,0 +1,48 @@
+package actions
+
+import (
+	"context"
+	"database/sql"
+
+	"connectrpc.com/connect"
+	"github.com/cockroachdb/errors"
+	"go.uber.org/zap"
+
+	"github.com/sweetloveinyourheart/sweet-reel/pkg/grpc"
+	"github.com/sweetloveinyourheart/sweet-reel/pkg/logger"
+	"github.com/sweetloveinyourheart/sweet-reel/pkg/s3"
+	proto "github.com/sweetloveinyourheart/sweet-reel/proto/code/video_management/go"
+	"github.com/sweetloveinyourheart/sweet-reel/services/video_management/domains/uploads"
+	"github.com/sweetloveinyourheart/sweet-reel/services/video_management/repos"
+)
+
+func (a *actions) AbortMultipartUpload(ctx context.Context, request *connect.Request[proto.AbortMultipartUploadRequest]) (*connect.Response[proto.AbortMultipartUploadResponse], error) {
+	video, upload, err := a.getUploadInProgress(ctx, request.Msg.GetVideoId(), request.Msg.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := a.s3Client.AbortMultipartUpload(upload.ObjectKey, s3.S3VideoUploadedBucket, upload.UploadID); err != nil {
+		return nil, grpc.InternalError(errors.Wrap(err, "failed to abort the upload"))
+	}
+
+	// The video only existed to be uploaded, it goes along with its upload
+	err = a.videoAggregateRepo.InTransaction(ctx, "AbortMultipartUpload", func(repo repos.IVideoAggregateRepository) error {
+		return uploads.DiscardVideo(ctx, repo, video)
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errUploadNotInProgress()
+		}
+
+		return nil, grpc.InternalError(err)
+	}
+
+	logger.Global().Info("multipart upload aborted", zap.String("video_id", video.GetID().String()))
+
+	response := &proto.AbortMultipartUploadResponse{
+		VideoId: video.GetID().String(),
+	}
+
+	return connect.NewResponse(response), nil
+}
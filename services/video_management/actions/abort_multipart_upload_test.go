@@ -0,0 +1,75 @@
+package actions_test
+
+import (
+	"context"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/sweetloveinyourheart/sweet-reel/pkg/kafka"
+	"github.com/sweetloveinyourheart/sweet-reel/pkg/messages"
+	"github.com/sweetloveinyourheart/sweet-reel/pkg/s3"
+	proto "github.com/sweetloveinyourheart/sweet-reel/proto/code/video_management/go"
+	"github.com/sweetloveinyourheart/sweet-reel/services/video_management/actions"
+)
+
+func (as *ActionsSuite) TestActions_AbortMultipartUpload_Success() {
+	as.setupEnvironment()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	userID := uuid.Must(uuid.NewV7())
+	video, upload := as.setupUploadInProgress(userID)
+
+	as.mockS3.On("AbortMultipartUpload", upload.ObjectKey, s3.S3VideoUploadedBucket, upload.UploadID).Return(nil)
+	as.mockVideoAggregateRepository.On("DeleteVideoUpload", mock.Anything, video.ID).Return(nil)
+	as.mockVideoAggregateRepository.On("DeleteVideo", mock.Anything, video.ID).Return(nil)
+	as.mockVideoAggregateRepository.On("EnqueueEvent", mock.Anything, kafka.KafkaVideoDeletedTopic, video.ID.String(), mock.MatchedBy(func(event messages.VideoDeleted) bool {
+		return event.VideoID == video.ID && event.ChannelID == video.ChannelID
+	})).Return(nil)
+	as.mockVideoAggregateRepository.On("GetVideoCountByChannelID", mock.Anything, video.ChannelID).Return(int64(0), nil)
+	as.mockVideoAggregateRepository.On("EnqueueEvent", mock.Anything, kafka.KafkaChannelVideosChangedTopic, video.ChannelID.String(), mock.Anything).Return(nil)
+
+	request := &connect.Request[proto.AbortMultipartUploadRequest]{
+		Msg: &proto.AbortMultipartUploadRequest{
+			VideoId: video.ID.String(),
+			UserId:  userID.String(),
+		},
+	}
+
+	actionsInstance := actions.NewActions(ctx, "test-token")
+	response, err := actionsInstance.AbortMultipartUpload(ctx, request)
+
+	as.NoError(err)
+	as.Equal(video.ID.String(), response.Msg.GetVideoId())
+	as.mockS3.AssertExpectations(as.T())
+	as.mockVideoAggregateRepository.AssertExpectations(as.T())
+}
+
+func (as *ActionsSuite) TestActions_AbortMultipartUpload_NotUploader() {
+	as.setupEnvironment()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	video, _ := as.setupUploadInProgress(uuid.Must(uuid.NewV7()))
+
+	request := &connect.Request[proto.AbortMultipartUploadRequest]{
+		Msg: &proto.AbortMultipartUploadRequest{
+			VideoId: video.ID.String(),
+			UserId:  uuid.Must(uuid.NewV7()).String(),
+		},
+	}
+
+	actionsInstance := actions.NewActions(ctx, "test-token")
+	response, err := actionsInstance.AbortMultipartUpload(ctx, request)
+
+	as.Error(err)
+	as.Nil(response)
+	as.Equal(connect.CodePermissionDenied, connect.CodeOf(err))
+	as.mockS3.AssertNotCalled(as.T(), "AbortMultipartUpload", mock.Anything, mock.Anything, mock.Anything)
+	as.mockVideoAggregateRepository.AssertNotCalled(as.T(), "DeleteVideo", mock.Anything, mock.Anything)
+}
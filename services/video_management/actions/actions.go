@@ -8,6 +8,7 @@ import (
 	"github.com/sweetloveinyourheart/sweet-reel/pkg/interceptors"
 	"github.com/sweetloveinyourheart/sweet-reel/pkg/logger"
 	"github.com/sweetloveinyourheart/sweet-reel/pkg/s3"
+	"github.com/sweetloveinyourheart/sweet-reel/services/video_management/domains/contentkeys"
 	"github.com/sweetloveinyourheart/sweet-reel/services/video_management/repos"
 )
 
@@ -16,6 +17,7 @@ type actions struct {
 	defaultAuth        func(context.Context, string) (context.Context, error)
 	s3Client           s3.S3Storage
 	videoAggregateRepo repos.IVideoAggregateRepository
+	contentKeyCipher   *contentkeys.Cipher
 }
 
 func NewActions(ctx context.Context, signingToken string) *actions {
@@ -29,10 +31,16 @@ func NewActions(ctx context.Context, signingToken string) *actions {
 		logger.Global().Fatal("unable to get video aggregate repo")
 	}
 
+	contentKeyCipher, err := do.Invoke[*contentkeys.Cipher](nil)
+	if err != nil {
+		logger.Global().Fatal("unable to get content key cipher")
+	}
+
 	return &actions{
 		context:            ctx,
 		defaultAuth:        interceptors.ConnectServerAuthHandler(signingToken),
 		s3Client:           s3Client,
 		videoAggregateRepo: videoAggregateRepo,
+		contentKeyCipher:   contentKeyCipher,
 	}
 }
@@ -9,10 +9,14 @@ import (
 	"github.com/sweetloveinyourheart/sweet-reel/pkg/s3"
 	testingPkg "github.com/sweetloveinyourheart/sweet-reel/pkg/testing"
 	mockPkg "github.com/sweetloveinyourheart/sweet-reel/pkg/testing/mock"
+	"github.com/sweetloveinyourheart/sweet-reel/services/video_management/domains/contentkeys"
 	"github.com/sweetloveinyourheart/sweet-reel/services/video_management/repos"
 	"github.com/sweetloveinyourheart/sweet-reel/services/video_management/repos/mocks"
 )
 
+// testContentKeySecret seals the content keys of the actions under test
+const testContentKeySecret = "content-key-encryption-key"
+
 type ActionsSuite struct {
 	*testingPkg.Suite
 	mockVideoAggregateRepository *mocks.MockVideoAggregateRepository
@@ -38,6 +42,10 @@ func TestActionsSuite(t *testing.T) {
 }
 
 func (as *ActionsSuite) setupEnvironment() {
+	do.Override(nil, func(i *do.Injector) (*contentkeys.Cipher, error) {
+		return contentkeys.NewCipher(testContentKeySecret)
+	})
+
 	do.Override(nil, func(i *do.Injector) (repos.IVideoAggregateRepository, error) {
 		return as.mockVideoAggregateRepository, nil
 	})
@@ -0,0 +1,87 @@
+package actions
+
+import (
+	"context"
+	"database/sql"
+
+	"connectrpc.com/connect"
+	"github.com/cockroachdb/errors"
+	"go.uber.org/zap"
+
+	"github.com/sweetloveinyourheart/sweet-reel/pkg/grpc"
+	"github.com/sweetloveinyourheart/sweet-reel/pkg/logger"
+	"github.com/sweetloveinyourheart/sweet-reel/pkg/s3"
+	proto "github.com/sweetloveinyourheart/sweet-reel/proto/code/video_management/go"
+	"github.com/sweetloveinyourheart/sweet-reel/services/video_management/models"
+	"github.com/sweetloveinyourheart/sweet-reel/services/video_management/repos"
+)
+
+func (a *actions) CompleteMultipartUpload(ctx context.Context, request *connect.Request[proto.CompleteMultipartUploadRequest]) (*connect.Response[proto.CompleteMultipartUploadResponse], error) {
+	video, upload, err := a.getUploadInProgress(ctx, request.Msg.GetVideoId(), request.Msg.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+
+	parts, err := a.s3Client.ListUploadedParts(upload.ObjectKey, s3.S3VideoUploadedBucket, upload.UploadID)
+	if err != nil {
+		if errors.Is(err, s3.ErrUploadNotFound) {
+			return nil, errUploadNotInProgress()
+		}
+
+		return nil, grpc.InternalError(errors.Wrap(err, "failed to list the uploaded parts"))
+	}
+
+	if err := checkUploadedParts(upload, parts); err != nil {
+		return nil, grpc.PreconditionError(grpc.PreconditionFailure("state", "parts", err.Error()))
+	}
+
+	// Ending the upload in the repository first hands it to a single caller, the one that
+	// completes it or the cleanup aborting it. A failed completion leaves it in progress.
+	err = a.videoAggregateRepo.InTransaction(ctx, "CompleteMultipartUpload", func(repo repos.IVideoAggregateRepository) error {
+		if err := repo.DeleteVideoUpload(ctx, video.GetID()); err != nil {
+			return err
+		}
+		return a.s3Client.CompleteMultipartUpload(upload.ObjectKey, s3.S3VideoUploadedBucket, upload.UploadID)
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) || errors.Is(err, s3.ErrUploadNotFound) {
+			return nil, errUploadNotInProgress()
+		}
+
+		return nil, grpc.InternalError(errors.Wrap(err, "failed to complete the upload"))
+	}
+
+	logger.Global().Info("multipart upload completed",
+		zap.String("video_id", video.GetID().String()),
+		zap.Int32("parts", upload.PartCount))
+
+	response := &proto.CompleteMultipartUploadResponse{
+		VideoId: video.GetID().String(),
+	}
+
+	return connect.NewResponse(response), nil
+}
+
+// checkUploadedParts checks that the parts make up the whole file, every one of them at its expected size
+func checkUploadedParts(upload *models.VideoUpload, parts []s3.UploadedPart) error {
+	if len(parts) != int(upload.PartCount) {
+		return errors.Errorf("%d of %d parts are uploaded", len(parts), upload.PartCount)
+	}
+
+	for i, part := range parts {
+		partNumber := int32(i + 1)
+		if part.PartNumber != partNumber {
+			return errors.Errorf("part %d is missing", partNumber)
+		}
+
+		expectedSize := upload.PartSize
+		if partNumber == upload.PartCount {
+			expectedSize = upload.FileSize - upload.PartSize*int64(upload.PartCount-1)
+		}
+		if part.Size != expectedSize {
+			return errors.Errorf("part %d is %d bytes, expected %d", partNumber, part.Size, expectedSize)
+		}
+	}
+
+	return nil
+}
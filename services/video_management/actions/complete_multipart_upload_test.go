@@ -0,0 +1,118 @@
+package actions_test
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/sweetloveinyourheart/sweet-reel/pkg/s3"
+	proto "github.com/sweetloveinyourheart/sweet-reel/proto/code/video_management/go"
+	"github.com/sweetloveinyourheart/sweet-reel/services/video_management/actions"
+	"github.com/sweetloveinyourheart/sweet-reel/services/video_management/models"
+)
+
+// uploadedParts returns the parts of a fully uploaded file
+func uploadedParts(upload *models.VideoUpload) []s3.UploadedPart {
+	return []s3.UploadedPart{
+		{PartNumber: 1, Size: upload.PartSize},
+		{PartNumber: 2, Size: upload.PartSize},
+		{PartNumber: 3, Size: upload.FileSize - 2*upload.PartSize},
+	}
+}
+
+func (as *ActionsSuite) TestActions_CompleteMultipartUpload_Success() {
+	as.setupEnvironment()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	userID := uuid.Must(uuid.NewV7())
+	video, upload := as.setupUploadInProgress(userID)
+
+	as.mockS3.On("ListUploadedParts", upload.ObjectKey, s3.S3VideoUploadedBucket, upload.UploadID).Return(uploadedParts(upload), nil)
+	as.mockVideoAggregateRepository.On("DeleteVideoUpload", mock.Anything, video.ID).Return(nil)
+	as.mockS3.On("CompleteMultipartUpload", upload.ObjectKey, s3.S3VideoUploadedBucket, upload.UploadID).Return(nil)
+
+	request := &connect.Request[proto.CompleteMultipartUploadRequest]{
+		Msg: &proto.CompleteMultipartUploadRequest{
+			VideoId: video.ID.String(),
+			UserId:  userID.String(),
+		},
+	}
+
+	actionsInstance := actions.NewActions(ctx, "test-token")
+	response, err := actionsInstance.CompleteMultipartUpload(ctx, request)
+
+	as.NoError(err)
+	as.Equal(video.ID.String(), response.Msg.GetVideoId())
+	as.mockS3.AssertExpectations(as.T())
+	as.mockVideoAggregateRepository.AssertExpectations(as.T())
+}
+
+func (as *ActionsSuite) TestActions_CompleteMultipartUpload_MissingParts() {
+	as.setupEnvironment()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	userID := uuid.Must(uuid.NewV7())
+	video, upload := as.setupUploadInProgress(userID)
+
+	parts := uploadedParts(upload)
+	// A part missing, then a part cut short
+	for _, uploaded := range [][]s3.UploadedPart{
+		{parts[0], parts[2]},
+		{parts[0], {PartNumber: 2, Size: upload.PartSize - 1}, parts[2]},
+	} {
+		as.mockS3.On("ListUploadedParts", upload.ObjectKey, s3.S3VideoUploadedBucket, upload.UploadID).Return(uploaded, nil).Once()
+
+		request := &connect.Request[proto.CompleteMultipartUploadRequest]{
+			Msg: &proto.CompleteMultipartUploadRequest{
+				VideoId: video.ID.String(),
+				UserId:  userID.String(),
+			},
+		}
+
+		actionsInstance := actions.NewActions(ctx, "test-token")
+		response, err := actionsInstance.CompleteMultipartUpload(ctx, request)
+
+		as.Error(err)
+		as.Nil(response)
+		as.Equal(connect.CodeFailedPrecondition, connect.CodeOf(err))
+	}
+
+	as.mockS3.AssertNotCalled(as.T(), "CompleteMultipartUpload", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (as *ActionsSuite) TestActions_CompleteMultipartUpload_AlreadyEnded() {
+	as.setupEnvironment()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	userID := uuid.Must(uuid.NewV7())
+	video, upload := as.setupUploadInProgress(userID)
+
+	// Completed or aborted by a concurrent request since it was read
+	as.mockS3.On("ListUploadedParts", upload.ObjectKey, s3.S3VideoUploadedBucket, upload.UploadID).Return(uploadedParts(upload), nil)
+	as.mockVideoAggregateRepository.On("DeleteVideoUpload", mock.Anything, video.ID).Return(sql.ErrNoRows)
+
+	request := &connect.Request[proto.CompleteMultipartUploadRequest]{
+		Msg: &proto.CompleteMultipartUploadRequest{
+			VideoId: video.ID.String(),
+			UserId:  userID.String(),
+		},
+	}
+
+	actionsInstance := actions.NewActions(ctx, "test-token")
+	response, err := actionsInstance.CompleteMultipartUpload(ctx, request)
+
+	as.Error(err)
+	as.Nil(response)
+	as.Equal(connect.CodeFailedPrecondition, connect.CodeOf(err))
+	as.mockS3.AssertNotCalled(as.T(), "CompleteMultipartUpload", mock.Anything, mock.Anything, mock.Anything)
+}
@@ -0,0 +1,82 @@
+package actions
+
+import (
+	"context"
+	"mime"
+	"path"
+
+	"connectrpc.com/connect"
+	"github.com/cockroachdb/errors"
+	"go.uber.org/zap"
+
+	"github.com/sweetloveinyourheart/sweet-reel/pkg/grpc"
+	"github.com/sweetloveinyourheart/sweet-reel/pkg/logger"
+	"github.com/sweetloveinyourheart/sweet-reel/pkg/s3"
+	proto "github.com/sweetloveinyourheart/sweet-reel/proto/code/video_management/go"
+	"github.com/sweetloveinyourheart/sweet-reel/services/video_management/domains/channelstats"
+	"github.com/sweetloveinyourheart/sweet-reel/services/video_management/models"
+	"github.com/sweetloveinyourheart/sweet-reel/services/video_management/repos"
+)
+
+func (a *actions) CreateMultipartUpload(ctx context.Context, request *connect.Request[proto.CreateMultipartUploadRequest]) (*connect.Response[proto.CreateMultipartUploadResponse], error) {
+	fileSize := request.Msg.GetFileSize()
+	if fileSize <= 0 || fileSize > s3.MaxMultipartObjectSize {
+		return nil, grpc.InvalidArgumentError(errors.Errorf("file size must be between 1 and %d bytes", s3.MaxMultipartObjectSize))
+	}
+
+	newVideo, key, err := newUploadVideo(request.Msg.GetTitle(), request.Msg.GetDescription(), request.Msg.GetFileName(), request.Msg.GetUploaderId(), request.Msg.GetChannelId())
+	if err != nil {
+		return nil, err
+	}
+
+	// The trace of the request is stored with the object once the upload completes
+	mimeType := mime.TypeByExtension(path.Ext(key))
+	uploadID, err := a.s3Client.CreateMultipartUpload(key, s3.S3VideoUploadedBucket, mimeType, s3.TraceMetadata(ctx))
+	if err != nil {
+		logger.Global().Error("Failed to create multipart upload",
+			zap.String("key", key),
+			zap.String("videoID", newVideo.GetID().String()),
+			zap.Error(err))
+		return nil, grpc.InternalError(errors.Wrapf(err, "failed to create multipart upload for bucket %s, key %s", s3.S3VideoUploadedBucket, key))
+	}
+
+	partSize := s3.MultipartPartSize(fileSize)
+	upload := models.VideoUpload{
+		VideoID:   newVideo.GetID(),
+		UploadID:  uploadID,
+		ObjectKey: key,
+		FileSize:  fileSize,
+		PartSize:  partSize,
+		PartCount: s3.MultipartPartCount(fileSize, partSize),
+	}
+
+	err = a.videoAggregateRepo.InTransaction(ctx, "CreateVideo", func(repo repos.IVideoAggregateRepository) error {
+		if err := repo.CreateVideo(ctx, newVideo); err != nil {
+			return err
+		}
+		if err := repo.CreateVideoUpload(ctx, &upload); err != nil {
+			return err
+		}
+		return channelstats.EnqueueTotalVideos(ctx, repo, newVideo.GetChannelID())
+	})
+	if err != nil {
+		logger.Global().Error("Failed to create video in database",
+			zap.String("videoID", newVideo.GetID().String()),
+			zap.String("uploaderID", newVideo.GetUploaderID().String()),
+			zap.Error(err))
+
+		// Left behind, the upload would only be aborted by the cleanup of abandoned uploads
+		if abortErr := a.s3Client.AbortMultipartUpload(key, s3.S3VideoUploadedBucket, uploadID); abortErr != nil {
+			logger.Global().Error("Failed to abort multipart upload", zap.String("uploadID", uploadID), zap.Error(abortErr))
+		}
+		return nil, grpc.InternalError(err)
+	}
+
+	response := &proto.CreateMultipartUploadResponse{
+		VideoId:   newVideo.GetID().String(),
+		PartSize:  upload.PartSize,
+		PartCount: upload.PartCount,
+	}
+
+	return connect.NewResponse(response), nil
+}
@@ -0,0 +1,130 @@
+package actions_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/sweetloveinyourheart/sweet-reel/pkg/kafka"
+	"github.com/sweetloveinyourheart/sweet-reel/pkg/s3"
+	proto "github.com/sweetloveinyourheart/sweet-reel/proto/code/video_management/go"
+	"github.com/sweetloveinyourheart/sweet-reel/services/video_management/actions"
+	"github.com/sweetloveinyourheart/sweet-reel/services/video_management/models"
+)
+
+func (as *ActionsSuite) TestActions_CreateMultipartUpload_Success() {
+	as.setupEnvironment()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	userID := uuid.Must(uuid.NewV7())
+	channelID := uuid.Must(uuid.NewV7())
+	fileSize := int64(40 << 20)
+
+	as.mockS3.On("CreateMultipartUpload",
+		mock.MatchedBy(func(key string) bool {
+			return strings.HasSuffix(key, ".mp4")
+		}),
+		s3.S3VideoUploadedBucket,
+		mock.AnythingOfType("string"),
+		map[string]string(nil)).Return("upload-id", nil)
+
+	as.mockVideoAggregateRepository.On("CreateVideo", ctx, mock.MatchedBy(func(video *models.Video) bool {
+		return video.UploaderID == userID && video.ChannelID == channelID
+	})).Return(nil)
+	as.mockVideoAggregateRepository.On("CreateVideoUpload", ctx, mock.MatchedBy(func(upload *models.VideoUpload) bool {
+		return upload.UploadID == "upload-id" &&
+			upload.FileSize == fileSize &&
+			upload.PartSize == s3.DefaultMultipartPartSize &&
+			upload.PartCount == 3
+	})).Return(nil)
+	as.mockVideoAggregateRepository.On("GetVideoCountByChannelID", ctx, channelID).Return(int64(1), nil)
+	as.mockVideoAggregateRepository.On("EnqueueEvent", ctx, kafka.KafkaChannelVideosChangedTopic, channelID.String(), mock.Anything).Return(nil)
+
+	request := &connect.Request[proto.CreateMultipartUploadRequest]{
+		Msg: &proto.CreateMultipartUploadRequest{
+			ChannelId:  channelID.String(),
+			Title:      "Test Video",
+			FileName:   "test-video.mp4",
+			UploaderId: userID.String(),
+			FileSize:   fileSize,
+		},
+	}
+
+	actionsInstance := actions.NewActions(ctx, "test-token")
+	response, err := actionsInstance.CreateMultipartUpload(ctx, request)
+
+	as.NoError(err)
+	as.NotNil(response)
+	as.NotEmpty(response.Msg.GetVideoId())
+	as.Equal(s3.DefaultMultipartPartSize, response.Msg.GetPartSize())
+	as.Equal(int32(3), response.Msg.GetPartCount())
+
+	as.mockS3.AssertExpectations(as.T())
+	as.mockVideoAggregateRepository.AssertExpectations(as.T())
+}
+
+func (as *ActionsSuite) TestActions_CreateMultipartUpload_InvalidFileSize() {
+	as.setupEnvironment()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	for _, fileSize := range []int64{0, -1, s3.MaxMultipartObjectSize + 1} {
+		request := &connect.Request[proto.CreateMultipartUploadRequest]{
+			Msg: &proto.CreateMultipartUploadRequest{
+				ChannelId:  uuid.Must(uuid.NewV7()).String(),
+				Title:      "Test Video",
+				FileName:   "test-video.mp4",
+				UploaderId: uuid.Must(uuid.NewV7()).String(),
+				FileSize:   fileSize,
+			},
+		}
+
+		actionsInstance := actions.NewActions(ctx, "test-token")
+		response, err := actionsInstance.CreateMultipartUpload(ctx, request)
+
+		as.Error(err)
+		as.Nil(response)
+		as.Equal(connect.CodeInvalidArgument, connect.CodeOf(err))
+	}
+
+	as.mockS3.AssertNotCalled(as.T(), "CreateMultipartUpload", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (as *ActionsSuite) TestActions_CreateMultipartUpload_DatabaseError() {
+	as.setupEnvironment()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	as.mockS3.On("CreateMultipartUpload", mock.Anything, s3.S3VideoUploadedBucket, mock.Anything, mock.Anything).Return("upload-id", nil)
+	as.mockS3.On("AbortMultipartUpload", mock.Anything, s3.S3VideoUploadedBucket, "upload-id").Return(nil)
+	as.mockVideoAggregateRepository.On("CreateVideo", ctx, mock.Anything).Return(errors.New("database error"))
+
+	request := &connect.Request[proto.CreateMultipartUploadRequest]{
+		Msg: &proto.CreateMultipartUploadRequest{
+			ChannelId:  uuid.Must(uuid.NewV7()).String(),
+			Title:      "Test Video",
+			FileName:   "test-video.mp4",
+			UploaderId: uuid.Must(uuid.NewV7()).String(),
+			FileSize:   1 << 20,
+		},
+	}
+
+	actionsInstance := actions.NewActions(ctx, "test-token")
+	response, err := actionsInstance.CreateMultipartUpload(ctx, request)
+
+	as.Error(err)
+	as.Nil(response)
+	as.Equal(connect.CodeInternal, connect.CodeOf(err))
+
+	// The upload started in S3 is not left behind
+	as.mockS3.AssertExpectations(as.T())
+}
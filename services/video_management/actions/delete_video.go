@@ -0,0 +1,97 @@
+package actions
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/cockroachdb/errors"
+	"github.com/gofrs/uuid"
+	"go.uber.org/zap"
+
+	"github.com/sweetloveinyourheart/sweet-reel/pkg/grpc"
+	"github.com/sweetloveinyourheart/sweet-reel/pkg/kafka"
+	"github.com/sweetloveinyourheart/sweet-reel/pkg/logger"
+	"github.com/sweetloveinyourheart/sweet-reel/pkg/messages"
+	"github.com/sweetloveinyourheart/sweet-reel/pkg/s3"
+	proto "github.com/sweetloveinyourheart/sweet-reel/proto/code/video_management/go"
+	"github.com/sweetloveinyourheart/sweet-reel/services/video_management/domains/channelstats"
+	"github.com/sweetloveinyourheart/sweet-reel/services/video_management/repos"
+)
+
+func (a *actions) DeleteVideo(ctx context.Context, request *connect.Request[proto.DeleteVideoRequest]) (*connect.Response[proto.DeleteVideoResponse], error) {
+	videoID := uuid.FromStringOrNil(request.Msg.GetVideoId())
+	if videoID == uuid.Nil {
+		return nil, grpc.InvalidArgumentError(errors.Errorf("video id is not recognized, id: %s", request.Msg.GetVideoId()))
+	}
+
+	userID := uuid.FromStringOrNil(request.Msg.GetUserId())
+	if userID == uuid.Nil {
+		return nil, grpc.InvalidArgumentError(errors.Errorf("user id is not recognized, id: %s", request.Msg.GetUserId()))
+	}
+
+	video, err := a.videoAggregateRepo.GetVideoByID(ctx, videoID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, grpc.NotFoundError(errors.New("video not found"))
+		}
+
+		return nil, grpc.InternalError(err)
+	}
+
+	if video.GetUploaderID() != userID {
+		return nil, grpc.PermissionDeniedError(errors.New("only the uploader can delete the video"))
+	}
+
+	// Storage goes first, so a failed cleanup leaves the video in place to be deleted again.
+	// Files uploaded by a job still processing the video are removed by video processing,
+	// which finds the video gone once it is done.
+	if objectKey := video.GetObjectKey(); objectKey != "" {
+		if err := a.s3Client.Delete(objectKey, s3.S3VideoUploadedBucket); err != nil {
+			return nil, grpc.InternalError(errors.Wrap(err, "failed to delete the uploaded video"))
+		}
+	}
+
+	deleted, err := a.s3Client.DeletePrefix(fmt.Sprintf("%s/", videoID.String()), s3.S3VideoProcessedBucket)
+	if err != nil {
+		return nil, grpc.InternalError(errors.Wrap(err, "failed to delete the processed video files"))
+	}
+
+	err = a.videoAggregateRepo.InTransaction(ctx, "DeleteVideo", func(repo repos.IVideoAggregateRepository) error {
+		// Manifests, variants, thumbnails and views are removed by the cascading foreign keys
+		if err := repo.DeleteVideo(ctx, videoID); err != nil {
+			return err
+		}
+
+		event := messages.VideoDeleted{
+			VideoID:    videoID,
+			ChannelID:  video.GetChannelID(),
+			UploaderID: video.GetUploaderID(),
+			DeletedAt:  time.Now(),
+		}
+		if err := repo.EnqueueEvent(ctx, kafka.KafkaVideoDeletedTopic, videoID.String(), event); err != nil {
+			return err
+		}
+
+		// The views of the deleted video no longer count towards its channel
+		if err := channelstats.EnqueueTotalViews(ctx, repo, video.GetChannelID()); err != nil {
+			return err
+		}
+		return channelstats.EnqueueTotalVideos(ctx, repo, video.GetChannelID())
+	})
+	if err != nil {
+		return nil, grpc.InternalError(err)
+	}
+
+	logger.Global().Info("video deleted",
+		zap.String("video_id", videoID.String()),
+		zap.Int("deleted_objects", deleted))
+
+	response := &proto.DeleteVideoResponse{
+		VideoId: videoID.String(),
+	}
+
+	return connect.NewResponse(response), nil
+}
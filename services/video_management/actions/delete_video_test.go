@@ -0,0 +1,121 @@
+package actions_test
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/sweetloveinyourheart/sweet-reel/pkg/kafka"
+	"github.com/sweetloveinyourheart/sweet-reel/pkg/s3"
+	proto "github.com/sweetloveinyourheart/sweet-reel/proto/code/video_management/go"
+	"github.com/sweetloveinyourheart/sweet-reel/services/video_management/actions"
+	"github.com/sweetloveinyourheart/sweet-reel/services/video_management/models"
+)
+
+func (as *ActionsSuite) TestActions_DeleteVideo_Success() {
+	as.setupEnvironment()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// Setup test data
+	userID := uuid.Must(uuid.NewV7())
+	videoID := uuid.Must(uuid.NewV7())
+	channelID := uuid.Must(uuid.NewV7())
+	objectKey := fmt.Sprintf("2025-11-03/%s.mp4", videoID.String())
+
+	// Setup mock expectations
+	as.mockVideoAggregateRepository.On("GetVideoByID", mock.Anything, videoID).Return(&models.Video{
+		ID:         videoID,
+		UploaderID: userID,
+		ChannelID:  channelID,
+		ObjectKey:  &objectKey,
+	}, nil)
+	as.mockS3.On("Delete", objectKey, s3.S3VideoUploadedBucket).Return(nil)
+	as.mockS3.On("DeletePrefix", videoID.String()+"/", s3.S3VideoProcessedBucket).Return(12, nil)
+	as.mockVideoAggregateRepository.On("DeleteVideo", mock.Anything, videoID).Return(nil)
+	as.mockVideoAggregateRepository.On("GetTotalViewsByChannelID", mock.Anything, channelID).Return(int64(40), nil)
+	as.mockVideoAggregateRepository.On("GetVideoCountByChannelID", mock.Anything, channelID).Return(int64(2), nil)
+	as.mockVideoAggregateRepository.On("EnqueueEvent", mock.Anything, kafka.KafkaVideoDeletedTopic, videoID.String(), mock.Anything).Return(nil)
+	as.mockVideoAggregateRepository.On("EnqueueEvent", mock.Anything, kafka.KafkaChannelViewsChangedTopic, channelID.String(), mock.Anything).Return(nil)
+	as.mockVideoAggregateRepository.On("EnqueueEvent", mock.Anything, kafka.KafkaChannelVideosChangedTopic, channelID.String(), mock.Anything).Return(nil)
+
+	// Setup request
+	request := &connect.Request[proto.DeleteVideoRequest]{
+		Msg: &proto.DeleteVideoRequest{
+			VideoId: videoID.String(),
+			UserId:  userID.String(),
+		},
+	}
+
+	// Execute
+	actionsInstance := actions.NewActions(ctx, "test-token")
+	response, err := actionsInstance.DeleteVideo(ctx, request)
+
+	// Assertions
+	as.NoError(err)
+	as.NotNil(response)
+	as.Equal(videoID.String(), response.Msg.GetVideoId())
+	as.mockS3.AssertExpectations(as.T())
+	as.mockVideoAggregateRepository.AssertExpectations(as.T())
+}
+
+func (as *ActionsSuite) TestActions_DeleteVideo_NotOwner() {
+	as.setupEnvironment()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	videoID := uuid.Must(uuid.NewV7())
+
+	as.mockVideoAggregateRepository.On("GetVideoByID", mock.Anything, videoID).Return(&models.Video{
+		ID:         videoID,
+		UploaderID: uuid.Must(uuid.NewV7()),
+	}, nil)
+
+	request := &connect.Request[proto.DeleteVideoRequest]{
+		Msg: &proto.DeleteVideoRequest{
+			VideoId: videoID.String(),
+			UserId:  uuid.Must(uuid.NewV7()).String(),
+		},
+	}
+
+	actionsInstance := actions.NewActions(ctx, "test-token")
+	response, err := actionsInstance.DeleteVideo(ctx, request)
+
+	as.Error(err)
+	as.Nil(response)
+	as.Equal(connect.CodePermissionDenied, connect.CodeOf(err))
+	as.mockS3.AssertNotCalled(as.T(), "DeletePrefix", mock.Anything, mock.Anything)
+	as.mockVideoAggregateRepository.AssertNotCalled(as.T(), "DeleteVideo", mock.Anything, mock.Anything)
+}
+
+func (as *ActionsSuite) TestActions_DeleteVideo_NotFound() {
+	as.setupEnvironment()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	videoID := uuid.Must(uuid.NewV7())
+
+	as.mockVideoAggregateRepository.On("GetVideoByID", mock.Anything, videoID).Return(nil, sql.ErrNoRows)
+
+	request := &connect.Request[proto.DeleteVideoRequest]{
+		Msg: &proto.DeleteVideoRequest{
+			VideoId: videoID.String(),
+			UserId:  uuid.Must(uuid.NewV7()).String(),
+		},
+	}
+
+	actionsInstance := actions.NewActions(ctx, "test-token")
+	response, err := actionsInstance.DeleteVideo(ctx, request)
+
+	as.Error(err)
+	as.Nil(response)
+	as.Equal(connect.CodeNotFound, connect.CodeOf(err))
+}
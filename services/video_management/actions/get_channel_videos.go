@@ -20,7 +20,10 @@ func (a *actions) GetChannelVideos(ctx context.Context, request *connect.Request
 		return nil, grpc.InvalidArgumentError(errors.Errorf("channel id is not recognized, id: ", request.Msg.GetChannelId()))
 	}
 
-	channelVideos, err := a.videoAggregateRepo.GetChannelVideos(ctx, channelID, int(request.Msg.GetLimit()), int(request.Msg.Offset))
+	// Anonymous viewers have no id and only see public videos
+	viewerID := uuid.FromStringOrNil(request.Msg.GetViewerId())
+
+	channelVideos, err := a.videoAggregateRepo.GetChannelVideos(ctx, channelID, viewerID, int(request.Msg.GetLimit()), int(request.Msg.Offset))
 	if err != nil {
 		return nil, grpc.InternalError(err)
 	}
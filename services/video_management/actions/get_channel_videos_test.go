@@ -29,7 +29,7 @@ func (as *ActionsSuite) TestActions_GetChannelVideos_Success() {
 	as.mockS3.On("GenerateDownloadPublicUri", mock.Anything, mock.Anything, mock.Anything).Return(thumbnailUrl, nil)
 
 	as.mockVideoAggregateRepository.On("GetChannelVideos",
-		mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return([]*models.ChannelVideo{
+		mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return([]*models.ChannelVideo{
 		{
 			Video: models.Video{
 				ID:          videoID,
@@ -0,0 +1,56 @@
+package actions
+
+import (
+	"context"
+	"database/sql"
+
+	"connectrpc.com/connect"
+	"github.com/cockroachdb/errors"
+	"github.com/gofrs/uuid"
+
+	"github.com/sweetloveinyourheart/sweet-reel/pkg/grpc"
+	proto "github.com/sweetloveinyourheart/sweet-reel/proto/code/video_management/go"
+)
+
+// GetContentKey returns the key decrypting the segments of a video, to viewers entitled to
+// watch it. Like playlists, videos the viewer may not watch are reported as missing.
+func (a *actions) GetContentKey(ctx context.Context, request *connect.Request[proto.GetContentKeyRequest]) (*connect.Response[proto.GetContentKeyResponse], error) {
+	videoID := uuid.FromStringOrNil(request.Msg.GetVideoId())
+	if videoID == uuid.Nil {
+		return nil, grpc.InvalidArgumentError(errors.Errorf("video id is not recognized, id: %s", request.Msg.GetVideoId()))
+	}
+
+	if a.contentKeyCipher == nil {
+		return nil, errContentKeysNotConfigured()
+	}
+
+	video, err := a.videoAggregateRepo.GetVideoByID(ctx, videoID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, grpc.NotFoundError(errors.New("video not found"))
+		}
+
+		return nil, grpc.InternalError(err)
+	}
+
+	viewerID := uuid.FromStringOrNil(request.Msg.GetViewerId())
+	if !video.CanBeViewedBy(viewerID) {
+		return nil, grpc.NotFoundError(errors.New("video not found"))
+	}
+
+	contentKey, err := a.videoAggregateRepo.GetVideoContentKeyByVideoID(ctx, videoID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, grpc.NotFoundError(errors.New("content key not found, video is not encrypted"))
+		}
+
+		return nil, grpc.InternalError(err)
+	}
+
+	key, err := a.contentKeyCipher.Open(videoID, contentKey.EncryptedKey)
+	if err != nil {
+		return nil, grpc.InternalError(err)
+	}
+
+	return connect.NewResponse(&proto.GetContentKeyResponse{Key: key}), nil
+}
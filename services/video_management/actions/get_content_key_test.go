@@ -0,0 +1,167 @@
+package actions_test
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/gofrs/uuid"
+	"github.com/samber/do"
+	"github.com/stretchr/testify/mock"
+
+	proto "github.com/sweetloveinyourheart/sweet-reel/proto/code/video_management/go"
+	"github.com/sweetloveinyourheart/sweet-reel/services/video_management/actions"
+	"github.com/sweetloveinyourheart/sweet-reel/services/video_management/domains/contentkeys"
+	"github.com/sweetloveinyourheart/sweet-reel/services/video_management/models"
+)
+
+// testContentKey returns a content key of the video sealed like the service stores it
+func (as *ActionsSuite) testContentKey(videoID uuid.UUID, key []byte) *models.VideoContentKey {
+	cipher, err := contentkeys.NewCipher(testContentKeySecret)
+	as.NoError(err)
+
+	encryptedKey, err := cipher.Seal(videoID, key)
+	as.NoError(err)
+
+	return &models.VideoContentKey{
+		VideoID:      videoID,
+		EncryptedKey: encryptedKey,
+		CreatedAt:    time.Now(),
+		UpdatedAt:    time.Now(),
+	}
+}
+
+func (as *ActionsSuite) TestActions_GetContentKey_Success() {
+	as.setupEnvironment()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	videoID := uuid.Must(uuid.NewV7())
+	key := bytes.Repeat([]byte{0x2a}, contentkeys.KeySize)
+
+	as.mockVideoAggregateRepository.On("GetVideoByID", ctx, videoID).Return(publicVideo(videoID), nil)
+	as.mockVideoAggregateRepository.On("GetVideoContentKeyByVideoID", ctx, videoID).Return(as.testContentKey(videoID, key), nil)
+
+	request := &connect.Request[proto.GetContentKeyRequest]{
+		Msg: &proto.GetContentKeyRequest{
+			VideoId:  videoID.String(),
+			ViewerId: uuid.Must(uuid.NewV7()).String(),
+		},
+	}
+
+	actionsInstance := actions.NewActions(ctx, "test-token")
+	response, err := actionsInstance.GetContentKey(ctx, request)
+
+	as.NoError(err)
+	as.Equal(key, response.Msg.GetKey())
+	as.mockVideoAggregateRepository.AssertExpectations(as.T())
+}
+
+func (as *ActionsSuite) TestActions_GetContentKey_PrivateVideo() {
+	as.setupEnvironment()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	videoID := uuid.Must(uuid.NewV7())
+	video := publicVideo(videoID)
+	video.Visibility = models.VideoVisibilityPrivate
+	key := bytes.Repeat([]byte{0x2a}, contentkeys.KeySize)
+
+	as.mockVideoAggregateRepository.On("GetVideoByID", ctx, videoID).Return(video, nil)
+
+	request := &connect.Request[proto.GetContentKeyRequest]{
+		Msg: &proto.GetContentKeyRequest{
+			VideoId:  videoID.String(),
+			ViewerId: uuid.Must(uuid.NewV7()).String(),
+		},
+	}
+
+	actionsInstance := actions.NewActions(ctx, "test-token")
+	response, err := actionsInstance.GetContentKey(ctx, request)
+
+	// Viewers the video is hidden from don't get its key
+	as.Error(err)
+	as.Nil(response)
+	as.Equal(connect.CodeNotFound, connect.CodeOf(err))
+	as.mockVideoAggregateRepository.AssertNotCalled(as.T(), "GetVideoContentKeyByVideoID")
+
+	// The uploader does
+	as.mockVideoAggregateRepository.On("GetVideoContentKeyByVideoID", ctx, videoID).Return(as.testContentKey(videoID, key), nil)
+
+	request.Msg.ViewerId = video.UploaderID.String()
+	response, err = actionsInstance.GetContentKey(ctx, request)
+	as.NoError(err)
+	as.Equal(key, response.Msg.GetKey())
+}
+
+func (as *ActionsSuite) TestActions_GetContentKey_NotEncrypted() {
+	as.setupEnvironment()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	videoID := uuid.Must(uuid.NewV7())
+	as.mockVideoAggregateRepository.On("GetVideoByID", ctx, videoID).Return(publicVideo(videoID), nil)
+	as.mockVideoAggregateRepository.On("GetVideoContentKeyByVideoID", ctx, videoID).Return(nil, sql.ErrNoRows)
+
+	request := &connect.Request[proto.GetContentKeyRequest]{
+		Msg: &proto.GetContentKeyRequest{
+			VideoId: videoID.String(),
+		},
+	}
+
+	actionsInstance := actions.NewActions(ctx, "test-token")
+	response, err := actionsInstance.GetContentKey(ctx, request)
+
+	as.Error(err)
+	as.Nil(response)
+	as.Equal(connect.CodeNotFound, connect.CodeOf(err))
+}
+
+func (as *ActionsSuite) TestActions_GetContentKey_InvalidVideoID() {
+	as.setupEnvironment()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	request := &connect.Request[proto.GetContentKeyRequest]{
+		Msg: &proto.GetContentKeyRequest{
+			VideoId: "not-a-uuid",
+		},
+	}
+
+	actionsInstance := actions.NewActions(ctx, "test-token")
+	response, err := actionsInstance.GetContentKey(ctx, request)
+
+	as.Error(err)
+	as.Nil(response)
+	as.Equal(connect.CodeInvalidArgument, connect.CodeOf(err))
+}
+
+func (as *ActionsSuite) TestActions_GetContentKey_NotConfigured() {
+	as.setupEnvironment()
+	do.Override(nil, func(i *do.Injector) (*contentkeys.Cipher, error) {
+		return nil, nil
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	request := &connect.Request[proto.GetContentKeyRequest]{
+		Msg: &proto.GetContentKeyRequest{
+			VideoId: uuid.Must(uuid.NewV7()).String(),
+		},
+	}
+
+	actionsInstance := actions.NewActions(ctx, "test-token")
+	response, err := actionsInstance.GetContentKey(ctx, request)
+
+	as.Error(err)
+	as.Nil(response)
+	as.Equal(connect.CodeFailedPrecondition, connect.CodeOf(err))
+	as.mockVideoAggregateRepository.AssertNotCalled(as.T(), "GetVideoContentKeyByVideoID", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,68 @@
+package actions
+
+import (
+	"context"
+
+	"connectrpc.com/connect"
+	"go.uber.org/zap"
+
+	"github.com/sweetloveinyourheart/sweet-reel/pkg/grpc"
+	"github.com/sweetloveinyourheart/sweet-reel/pkg/logger"
+	"github.com/sweetloveinyourheart/sweet-reel/pkg/s3"
+	proto "github.com/sweetloveinyourheart/sweet-reel/proto/code/video_management/go"
+)
+
+const (
+	// Page size of the reel feed when none or too many are requested
+	ReelFeedDefaultLimit = 20
+	ReelFeedMaxLimit     = 50
+)
+
+func (a *actions) GetReelFeed(ctx context.Context, request *connect.Request[proto.GetReelFeedRequest]) (*connect.Response[proto.GetReelFeedResponse], error) {
+	limit := int(request.Msg.GetLimit())
+	if limit <= 0 || limit > ReelFeedMaxLimit {
+		limit = ReelFeedDefaultLimit
+	}
+
+	offset := max(int(request.Msg.GetOffset()), 0)
+
+	reels, err := a.videoAggregateRepo.GetReelFeed(ctx, limit, offset)
+	if err != nil {
+		return nil, grpc.InternalError(err)
+	}
+
+	items := make([]*proto.ReelFeedItem, 0, len(reels))
+	for _, reel := range reels {
+		if reel.ThumbnailObjectKey == "" {
+			logger.Global().Warn("no reel thumbnail was found", zap.String("video_id", reel.ID.String()))
+			continue
+		}
+
+		if reel.ProcessedAt == nil {
+			logger.Global().Warn("the reel has not processed yet", zap.String("video_id", reel.ID.String()))
+			continue
+		}
+
+		thumbnailUrl, err := a.s3Client.GenerateDownloadPublicUri(reel.GetThumbnailObjectKey(), s3.S3VideoProcessedBucket, s3.UrlExpirationSeconds)
+		if err != nil {
+			logger.Global().Error("unable to generate download url for reel thumbnail", zap.Error(err))
+			continue
+		}
+
+		items = append(items, &proto.ReelFeedItem{
+			VideoId:       reel.ID.String(),
+			ChannelId:     reel.ChannelID.String(),
+			VideoTitle:    reel.Title,
+			ThumbnailUrl:  thumbnailUrl,
+			TotalDuration: int32(reel.TotalDuration),
+			TotalView:     reel.ViewCount,
+			ProcessedAt:   reel.ProcessedAt.Unix(),
+		})
+	}
+
+	response := &proto.GetReelFeedResponse{
+		Reels: items,
+	}
+
+	return connect.NewResponse(response), nil
+}
@@ -0,0 +1,74 @@
+package actions_test
+
+import (
+	"context"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/mock"
+
+	proto "github.com/sweetloveinyourheart/sweet-reel/proto/code/video_management/go"
+	"github.com/sweetloveinyourheart/sweet-reel/services/video_management/actions"
+	"github.com/sweetloveinyourheart/sweet-reel/services/video_management/models"
+)
+
+func (as *ActionsSuite) TestActions_GetReelFeed_Success() {
+	as.setupEnvironment()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// Setup test data
+	videoID := uuid.Must(uuid.NewV7())
+	channelID := uuid.Must(uuid.NewV7())
+	thumbnailUrl := "https://s3.example.com/download-url"
+	processedAt := time.Now()
+
+	// Setup mock expectations
+	as.mockS3.On("GenerateDownloadPublicUri", mock.Anything, mock.Anything, mock.Anything).Return(thumbnailUrl, nil)
+
+	as.mockVideoAggregateRepository.On("GetReelFeed",
+		mock.Anything, actions.ReelFeedDefaultLimit, 0).Return([]*models.ChannelVideo{
+		{
+			Video: models.Video{
+				ID:          videoID,
+				ChannelID:   channelID,
+				Title:       "Test",
+				Format:      models.VideoFormatReel,
+				ProcessedAt: &processedAt,
+			},
+			ThumbnailObjectKey: "test",
+			TotalDuration:      30,
+		},
+		{
+			// Reels without a thumbnail are left out of the feed
+			Video: models.Video{
+				ID:          uuid.Must(uuid.NewV7()),
+				Title:       "No thumbnail",
+				Format:      models.VideoFormatReel,
+				ProcessedAt: &processedAt,
+			},
+		},
+	}, nil)
+
+	// Setup request, the limit is above the maximum and falls back to the default
+	request := &connect.Request[proto.GetReelFeedRequest]{
+		Msg: &proto.GetReelFeedRequest{
+			Limit: actions.ReelFeedMaxLimit + 1,
+		},
+	}
+
+	// Execute
+	actionsInstance := actions.NewActions(ctx, "test-token")
+	response, err := actionsInstance.GetReelFeed(ctx, request)
+
+	// Assertions
+	as.NoError(err)
+	as.NotNil(response)
+	as.Len(response.Msg.GetReels(), 1)
+	as.Equal(videoID.String(), response.Msg.GetReels()[0].GetVideoId())
+	as.Equal(channelID.String(), response.Msg.GetReels()[0].GetChannelId())
+	as.Equal(thumbnailUrl, response.Msg.GetReels()[0].GetThumbnailUrl())
+	as.Equal(int32(30), response.Msg.GetReels()[0].GetTotalDuration())
+}
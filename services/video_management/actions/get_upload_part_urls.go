@@ -0,0 +1,48 @@
+package actions
+
+import (
+	"context"
+
+	"connectrpc.com/connect"
+	"github.com/cockroachdb/errors"
+
+	"github.com/sweetloveinyourheart/sweet-reel/pkg/grpc"
+	"github.com/sweetloveinyourheart/sweet-reel/pkg/s3"
+	proto "github.com/sweetloveinyourheart/sweet-reel/proto/code/video_management/go"
+)
+
+func (a *actions) GetUploadPartUrls(ctx context.Context, request *connect.Request[proto.GetUploadPartUrlsRequest]) (*connect.Response[proto.GetUploadPartUrlsResponse], error) {
+	partNumbers := request.Msg.GetPartNumbers()
+	if len(partNumbers) == 0 || len(partNumbers) > maxUploadPartUrls {
+		return nil, grpc.InvalidArgumentError(errors.Errorf("between 1 and %d part numbers must be requested", maxUploadPartUrls))
+	}
+
+	_, upload, err := a.getUploadInProgress(ctx, request.Msg.GetVideoId(), request.Msg.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+
+	urls := make([]*proto.UploadPartUrl, 0, len(partNumbers))
+	for _, partNumber := range partNumbers {
+		if partNumber < 1 || partNumber > upload.PartCount {
+			return nil, grpc.InvalidArgumentError(errors.Errorf("part number %d is out of range, the upload has %d parts", partNumber, upload.PartCount))
+		}
+
+		url, err := a.s3Client.GenerateUploadPartPublicUri(upload.ObjectKey, s3.S3VideoUploadedBucket, upload.UploadID, partNumber, s3.UrlExpirationSeconds)
+		if err != nil {
+			return nil, grpc.InternalError(errors.Wrapf(err, "failed to generate presigned URL of part %d", partNumber))
+		}
+
+		urls = append(urls, &proto.UploadPartUrl{
+			PartNumber: partNumber,
+			Url:        url,
+		})
+	}
+
+	response := &proto.GetUploadPartUrlsResponse{
+		Urls:      urls,
+		ExpiresIn: s3.UrlExpirationSeconds,
+	}
+
+	return connect.NewResponse(response), nil
+}
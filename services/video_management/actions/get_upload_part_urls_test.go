@@ -0,0 +1,72 @@
+package actions_test
+
+import (
+	"context"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/sweetloveinyourheart/sweet-reel/pkg/s3"
+	proto "github.com/sweetloveinyourheart/sweet-reel/proto/code/video_management/go"
+	"github.com/sweetloveinyourheart/sweet-reel/services/video_management/actions"
+)
+
+func (as *ActionsSuite) TestActions_GetUploadPartUrls_Success() {
+	as.setupEnvironment()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	userID := uuid.Must(uuid.NewV7())
+	video, upload := as.setupUploadInProgress(userID)
+
+	for _, partNumber := range []int32{1, 3} {
+		as.mockS3.On("GenerateUploadPartPublicUri", upload.ObjectKey, s3.S3VideoUploadedBucket, upload.UploadID, partNumber, uint32(s3.UrlExpirationSeconds)).
+			Return("https://s3.example.com/part", nil)
+	}
+
+	request := &connect.Request[proto.GetUploadPartUrlsRequest]{
+		Msg: &proto.GetUploadPartUrlsRequest{
+			VideoId:     video.ID.String(),
+			UserId:      userID.String(),
+			PartNumbers: []int32{1, 3},
+		},
+	}
+
+	actionsInstance := actions.NewActions(ctx, "test-token")
+	response, err := actionsInstance.GetUploadPartUrls(ctx, request)
+
+	as.NoError(err)
+	as.Len(response.Msg.GetUrls(), 2)
+	as.Equal(int32(3), response.Msg.GetUrls()[1].GetPartNumber())
+	as.Equal(int32(s3.UrlExpirationSeconds), response.Msg.GetExpiresIn())
+	as.mockS3.AssertExpectations(as.T())
+}
+
+func (as *ActionsSuite) TestActions_GetUploadPartUrls_PartOutOfRange() {
+	as.setupEnvironment()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	userID := uuid.Must(uuid.NewV7())
+	video, _ := as.setupUploadInProgress(userID)
+
+	request := &connect.Request[proto.GetUploadPartUrlsRequest]{
+		Msg: &proto.GetUploadPartUrlsRequest{
+			VideoId:     video.ID.String(),
+			UserId:      userID.String(),
+			PartNumbers: []int32{4},
+		},
+	}
+
+	actionsInstance := actions.NewActions(ctx, "test-token")
+	response, err := actionsInstance.GetUploadPartUrls(ctx, request)
+
+	as.Error(err)
+	as.Nil(response)
+	as.Equal(connect.CodeInvalidArgument, connect.CodeOf(err))
+	as.mockS3.AssertNotCalled(as.T(), "GenerateUploadPartPublicUri", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
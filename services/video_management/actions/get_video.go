@@ -27,6 +27,12 @@ func (a *actions) GetVideoMetadataById(ctx context.Context, request *connect.Req
 		return nil, grpc.InternalError(err)
 	}
 
+	// Private videos are reported as missing to anyone but their uploader
+	viewerID := uuid.FromStringOrNil(request.Msg.GetViewerId())
+	if !metadata.CanBeViewedBy(viewerID) {
+		return nil, grpc.NotFoundError(errors.New("video not found"))
+	}
+
 	response := &proto.GetVideoMetadataByIdResponse{
 		VideoId:            metadata.GetID().String(),
 		ChannelId:          metadata.GetChannelID().String(),
@@ -35,6 +41,17 @@ func (a *actions) GetVideoMetadataById(ctx context.Context, request *connect.Req
 		TotalView:          metadata.GetViewCount(),
 		ProcessedAt:        metadata.GetCreatedAt().Unix(),
 		AvailableQualities: metadata.GetAvailableQualities(),
+		Format:             string(metadata.GetFormat()),
+		Visibility:         string(metadata.GetVisibility()),
+	}
+
+	// Players only offer scrubbing previews for videos whose storyboard was processed
+	_, err = a.videoAggregateRepo.GetVideoStoryboardByVideoID(ctx, videoID)
+	switch {
+	case err == nil:
+		response.StoryboardUrl = StoryboardUrl
+	case !errors.Is(err, sql.ErrNoRows):
+		return nil, grpc.InternalError(err)
 	}
 
 	return connect.NewResponse(response), nil
@@ -0,0 +1,71 @@
+package actions
+
+import (
+	"context"
+
+	"connectrpc.com/connect"
+
+	"github.com/sweetloveinyourheart/sweet-reel/pkg/grpc"
+	proto "github.com/sweetloveinyourheart/sweet-reel/proto/code/video_management/go"
+	"github.com/sweetloveinyourheart/sweet-reel/services/video_management/models"
+)
+
+func (a *actions) GetVideoProcessingStatus(ctx context.Context, request *connect.Request[proto.GetVideoProcessingStatusRequest]) (*connect.Response[proto.GetVideoProcessingStatusResponse], error) {
+	video, err := a.getUploadedVideo(ctx, request.Msg.GetVideoId(), request.Msg.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+
+	jobs, err := a.videoAggregateRepo.GetVideoProcessingJobsByVideoID(ctx, video.GetID())
+	if err != nil {
+		return nil, grpc.InternalError(err)
+	}
+
+	response := &proto.GetVideoProcessingStatusResponse{
+		VideoId: video.GetID().String(),
+		Status:  string(video.GetStatus()),
+		Jobs:    make([]*proto.VideoProcessingJob, 0, len(jobs)),
+	}
+	if failure := video.GetFailure(); failure != nil {
+		response.FailureCode = failure.Code
+		response.FailureReason = failure.Reason
+	}
+
+	for _, job := range jobs {
+		response.Jobs = append(response.Jobs, videoProcessingJobToProto(job))
+	}
+
+	return connect.NewResponse(response), nil
+}
+
+func videoProcessingJobToProto(job *models.VideoProcessingJob) *proto.VideoProcessingJob {
+	pb := &proto.VideoProcessingJob{
+		JobId:     job.ID.String(),
+		Attempt:   job.Attempt,
+		Status:    string(job.Status),
+		StartedAt: job.StartedAt.Unix(),
+		Stages:    make([]*proto.VideoProcessingStage, 0, len(job.Stages)),
+	}
+	if job.FinishedAt != nil {
+		pb.FinishedAt = job.FinishedAt.Unix()
+	}
+	if job.ErrorCode != nil {
+		pb.ErrorCode = *job.ErrorCode
+	}
+	if job.ErrorMessage != nil {
+		pb.ErrorMessage = *job.ErrorMessage
+	}
+
+	for _, stage := range job.Stages {
+		pb.Stages = append(pb.Stages, &proto.VideoProcessingStage{
+			Stage:        stage.Stage,
+			StartedAt:    stage.StartedAt.Unix(),
+			DurationMs:   stage.DurationMs,
+			ErrorCode:    stage.ErrorCode,
+			ErrorMessage: stage.ErrorMessage,
+			FfmpegStderr: stage.FFmpegStderr,
+		})
+	}
+
+	return pb
+}
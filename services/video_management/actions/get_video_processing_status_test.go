@@ -0,0 +1,129 @@
+package actions_test
+
+import (
+	"context"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/mock"
+
+	proto "github.com/sweetloveinyourheart/sweet-reel/proto/code/video_management/go"
+	"github.com/sweetloveinyourheart/sweet-reel/services/video_management/actions"
+	"github.com/sweetloveinyourheart/sweet-reel/services/video_management/models"
+)
+
+func (as *ActionsSuite) TestActions_GetVideoProcessingStatus_Jobs() {
+	as.setupEnvironment()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	userID := uuid.Must(uuid.NewV7())
+	videoID := uuid.Must(uuid.NewV7())
+	failureCode := "processing_error"
+	failureReason := "failed to segment video: FFmpeg process failed: exit status 1"
+	startedAt := time.Now().Add(-time.Minute)
+	finishedAt := time.Now()
+
+	as.mockVideoAggregateRepository.On("GetVideoByID", mock.Anything, videoID).Return(&models.Video{
+		ID:            videoID,
+		UploaderID:    userID,
+		Status:        models.VideoStatusFailed,
+		FailureCode:   &failureCode,
+		FailureReason: &failureReason,
+	}, nil)
+
+	failedJob := &models.VideoProcessingJob{
+		ID:           uuid.Must(uuid.NewV7()),
+		VideoID:      videoID,
+		Attempt:      1,
+		Status:       models.VideoProcessingJobFailed,
+		ErrorCode:    &failureCode,
+		ErrorMessage: &failureReason,
+		StartedAt:    startedAt,
+		FinishedAt:   &finishedAt,
+		Stages: []models.VideoProcessingStage{
+			{Stage: "download", StartedAt: startedAt, DurationMs: 800},
+			{Stage: "probe", StartedAt: startedAt, DurationMs: 150},
+			{
+				Stage:        "segment",
+				StartedAt:    startedAt,
+				DurationMs:   52000,
+				ErrorCode:    failureCode,
+				ErrorMessage: failureReason,
+				FFmpegStderr: "Conversion failed!",
+			},
+		},
+	}
+	runningJob := &models.VideoProcessingJob{
+		ID:        uuid.Must(uuid.NewV7()),
+		VideoID:   videoID,
+		Attempt:   2,
+		Status:    models.VideoProcessingJobRunning,
+		StartedAt: finishedAt,
+	}
+	as.mockVideoAggregateRepository.On("GetVideoProcessingJobsByVideoID", mock.Anything, videoID).
+		Return([]*models.VideoProcessingJob{failedJob, runningJob}, nil)
+
+	request := &connect.Request[proto.GetVideoProcessingStatusRequest]{
+		Msg: &proto.GetVideoProcessingStatusRequest{
+			VideoId: videoID.String(),
+			UserId:  userID.String(),
+		},
+	}
+
+	actionsInstance := actions.NewActions(ctx, "test-token")
+	response, err := actionsInstance.GetVideoProcessingStatus(ctx, request)
+
+	as.NoError(err)
+	as.Equal(string(models.VideoStatusFailed), response.Msg.GetStatus())
+	as.Equal(failureCode, response.Msg.GetFailureCode())
+	as.Len(response.Msg.GetJobs(), 2)
+
+	failed := response.Msg.GetJobs()[0]
+	as.Equal(failedJob.ID.String(), failed.GetJobId())
+	as.Equal("failed", failed.GetStatus())
+	as.Equal(finishedAt.Unix(), failed.GetFinishedAt())
+	as.Equal(failureCode, failed.GetErrorCode())
+	as.Len(failed.GetStages(), 3)
+	as.Equal("segment", failed.GetStages()[2].GetStage())
+	as.Equal(int64(52000), failed.GetStages()[2].GetDurationMs())
+	as.Equal("Conversion failed!", failed.GetStages()[2].GetFfmpegStderr())
+
+	running := response.Msg.GetJobs()[1]
+	as.Equal(int32(2), running.GetAttempt())
+	as.Equal("running", running.GetStatus())
+	as.Zero(running.GetFinishedAt())
+	as.Empty(running.GetStages())
+}
+
+func (as *ActionsSuite) TestActions_GetVideoProcessingStatus_NotUploader() {
+	as.setupEnvironment()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	videoID := uuid.Must(uuid.NewV7())
+
+	as.mockVideoAggregateRepository.On("GetVideoByID", mock.Anything, videoID).Return(&models.Video{
+		ID:         videoID,
+		UploaderID: uuid.Must(uuid.NewV7()),
+		Status:     models.VideoStatusProcessing,
+	}, nil)
+
+	request := &connect.Request[proto.GetVideoProcessingStatusRequest]{
+		Msg: &proto.GetVideoProcessingStatusRequest{
+			VideoId: videoID.String(),
+			UserId:  uuid.Must(uuid.NewV7()).String(),
+		},
+	}
+
+	actionsInstance := actions.NewActions(ctx, "test-token")
+	response, err := actionsInstance.GetVideoProcessingStatus(ctx, request)
+
+	as.Error(err)
+	as.Nil(response)
+	as.Equal(connect.CodeNotFound, connect.CodeOf(err))
+	as.mockVideoAggregateRepository.AssertNotCalled(as.T(), "GetVideoProcessingJobsByVideoID", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,56 @@
+package actions
+
+import (
+	"context"
+	"database/sql"
+
+	"connectrpc.com/connect"
+	"github.com/cockroachdb/errors"
+	"github.com/gofrs/uuid"
+
+	"github.com/sweetloveinyourheart/sweet-reel/pkg/grpc"
+	proto "github.com/sweetloveinyourheart/sweet-reel/proto/code/video_management/go"
+	"github.com/sweetloveinyourheart/sweet-reel/services/video_management/models"
+)
+
+func (a *actions) GetVideoStatus(ctx context.Context, request *connect.Request[proto.GetVideoStatusRequest]) (*connect.Response[proto.GetVideoStatusResponse], error) {
+	video, err := a.getUploadedVideo(ctx, request.Msg.GetVideoId(), request.Msg.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+
+	response := &proto.GetVideoStatusResponse{
+		VideoId: video.GetID().String(),
+		Status:  string(video.GetStatus()),
+	}
+	if failure := video.GetFailure(); failure != nil {
+		response.FailureCode = failure.Code
+		response.FailureReason = failure.Reason
+	}
+
+	return connect.NewResponse(response), nil
+}
+
+// getUploadedVideo loads a video for its uploader. Services ask without a user, users only
+// learn about their own uploads.
+func (a *actions) getUploadedVideo(ctx context.Context, id string, userID string) (*models.Video, error) {
+	videoID := uuid.FromStringOrNil(id)
+	if videoID == uuid.Nil {
+		return nil, grpc.InvalidArgumentError(errors.Errorf("video id is not recognized, id: %s", id))
+	}
+
+	video, err := a.videoAggregateRepo.GetVideoByID(ctx, videoID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, grpc.NotFoundError(errors.New("video not found"))
+		}
+
+		return nil, grpc.InternalError(err)
+	}
+
+	if userID != "" && video.GetUploaderID() != uuid.FromStringOrNil(userID) {
+		return nil, grpc.NotFoundError(errors.New("video not found"))
+	}
+
+	return video, nil
+}
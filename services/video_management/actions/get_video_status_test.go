@@ -0,0 +1,131 @@
+package actions_test
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/mock"
+
+	proto "github.com/sweetloveinyourheart/sweet-reel/proto/code/video_management/go"
+	"github.com/sweetloveinyourheart/sweet-reel/services/video_management/actions"
+	"github.com/sweetloveinyourheart/sweet-reel/services/video_management/models"
+)
+
+func (as *ActionsSuite) TestActions_GetVideoStatus_Failed() {
+	as.setupEnvironment()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	userID := uuid.Must(uuid.NewV7())
+	videoID := uuid.Must(uuid.NewV7())
+	failureCode := "duration_too_long"
+	failureReason := "the video is 7300 seconds long, at most 7200 are allowed"
+
+	as.mockVideoAggregateRepository.On("GetVideoByID", mock.Anything, videoID).Return(&models.Video{
+		ID:            videoID,
+		UploaderID:    userID,
+		Status:        models.VideoStatusFailed,
+		FailureCode:   &failureCode,
+		FailureReason: &failureReason,
+	}, nil)
+
+	request := &connect.Request[proto.GetVideoStatusRequest]{
+		Msg: &proto.GetVideoStatusRequest{
+			VideoId: videoID.String(),
+			UserId:  userID.String(),
+		},
+	}
+
+	actionsInstance := actions.NewActions(ctx, "test-token")
+	response, err := actionsInstance.GetVideoStatus(ctx, request)
+
+	as.NoError(err)
+	as.Equal(string(models.VideoStatusFailed), response.Msg.GetStatus())
+	as.Equal(failureCode, response.Msg.GetFailureCode())
+	as.Equal(failureReason, response.Msg.GetFailureReason())
+}
+
+func (as *ActionsSuite) TestActions_GetVideoStatus_InternalCaller() {
+	as.setupEnvironment()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	videoID := uuid.Must(uuid.NewV7())
+
+	as.mockVideoAggregateRepository.On("GetVideoByID", mock.Anything, videoID).Return(&models.Video{
+		ID:         videoID,
+		UploaderID: uuid.Must(uuid.NewV7()),
+		Status:     models.VideoStatusProcessing,
+	}, nil)
+
+	request := &connect.Request[proto.GetVideoStatusRequest]{
+		Msg: &proto.GetVideoStatusRequest{
+			VideoId: videoID.String(),
+		},
+	}
+
+	actionsInstance := actions.NewActions(ctx, "test-token")
+	response, err := actionsInstance.GetVideoStatus(ctx, request)
+
+	as.NoError(err)
+	as.Equal(string(models.VideoStatusProcessing), response.Msg.GetStatus())
+	as.Empty(response.Msg.GetFailureCode())
+}
+
+func (as *ActionsSuite) TestActions_GetVideoStatus_NotUploader() {
+	as.setupEnvironment()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	videoID := uuid.Must(uuid.NewV7())
+
+	as.mockVideoAggregateRepository.On("GetVideoByID", mock.Anything, videoID).Return(&models.Video{
+		ID:         videoID,
+		UploaderID: uuid.Must(uuid.NewV7()),
+		Status:     models.VideoStatusProcessing,
+	}, nil)
+
+	request := &connect.Request[proto.GetVideoStatusRequest]{
+		Msg: &proto.GetVideoStatusRequest{
+			VideoId: videoID.String(),
+			UserId:  uuid.Must(uuid.NewV7()).String(),
+		},
+	}
+
+	actionsInstance := actions.NewActions(ctx, "test-token")
+	response, err := actionsInstance.GetVideoStatus(ctx, request)
+
+	as.Error(err)
+	as.Nil(response)
+	as.Equal(connect.CodeNotFound, connect.CodeOf(err))
+}
+
+func (as *ActionsSuite) TestActions_GetVideoStatus_NotFound() {
+	as.setupEnvironment()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	videoID := uuid.Must(uuid.NewV7())
+
+	as.mockVideoAggregateRepository.On("GetVideoByID", mock.Anything, videoID).Return(nil, sql.ErrNoRows)
+
+	request := &connect.Request[proto.GetVideoStatusRequest]{
+		Msg: &proto.GetVideoStatusRequest{
+			VideoId: videoID.String(),
+		},
+	}
+
+	actionsInstance := actions.NewActions(ctx, "test-token")
+	response, err := actionsInstance.GetVideoStatus(ctx, request)
+
+	as.Error(err)
+	as.Nil(response)
+	as.Equal(connect.CodeNotFound, connect.CodeOf(err))
+}
@@ -0,0 +1,67 @@
+package actions_test
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/gofrs/uuid"
+
+	proto "github.com/sweetloveinyourheart/sweet-reel/proto/code/video_management/go"
+	"github.com/sweetloveinyourheart/sweet-reel/services/video_management/actions"
+	"github.com/sweetloveinyourheart/sweet-reel/services/video_management/models"
+)
+
+func (as *ActionsSuite) TestActions_GetVideoMetadataById_StoryboardUrl() {
+	as.setupEnvironment()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	videoID := uuid.Must(uuid.NewV7())
+	as.mockVideoAggregateRepository.On("GetVideoMetadata", ctx, videoID).Return(&models.VideoMetadata{
+		Video:              *publicVideo(videoID),
+		AvailableQualities: []string{"720p"},
+	}, nil)
+	as.mockVideoAggregateRepository.On("GetVideoStoryboardByVideoID", ctx, videoID).Return(testStoryboard(videoID), nil)
+
+	request := &connect.Request[proto.GetVideoMetadataByIdRequest]{
+		Msg: &proto.GetVideoMetadataByIdRequest{
+			VideoId: videoID.String(),
+		},
+	}
+
+	actionsInstance := actions.NewActions(ctx, "test-token")
+	response, err := actionsInstance.GetVideoMetadataById(ctx, request)
+
+	as.NoError(err)
+	as.Equal(actions.StoryboardUrl, response.Msg.GetStoryboardUrl())
+	as.Equal([]string{"720p"}, response.Msg.GetAvailableQualities())
+}
+
+func (as *ActionsSuite) TestActions_GetVideoMetadataById_WithoutStoryboard() {
+	as.setupEnvironment()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// Videos processed before storyboards existed have none
+	videoID := uuid.Must(uuid.NewV7())
+	as.mockVideoAggregateRepository.On("GetVideoMetadata", ctx, videoID).Return(&models.VideoMetadata{
+		Video: *publicVideo(videoID),
+	}, nil)
+	as.mockVideoAggregateRepository.On("GetVideoStoryboardByVideoID", ctx, videoID).Return(nil, sql.ErrNoRows)
+
+	request := &connect.Request[proto.GetVideoMetadataByIdRequest]{
+		Msg: &proto.GetVideoMetadataByIdRequest{
+			VideoId: videoID.String(),
+		},
+	}
+
+	actionsInstance := actions.NewActions(ctx, "test-token")
+	response, err := actionsInstance.GetVideoMetadataById(ctx, request)
+
+	as.NoError(err)
+	as.Empty(response.Msg.GetStoryboardUrl())
+}
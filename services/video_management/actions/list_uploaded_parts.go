@@ -0,0 +1,46 @@
+package actions
+
+import (
+	"context"
+
+	"connectrpc.com/connect"
+	"github.com/cockroachdb/errors"
+
+	"github.com/sweetloveinyourheart/sweet-reel/pkg/grpc"
+	"github.com/sweetloveinyourheart/sweet-reel/pkg/s3"
+	proto "github.com/sweetloveinyourheart/sweet-reel/proto/code/video_management/go"
+)
+
+func (a *actions) ListUploadedParts(ctx context.Context, request *connect.Request[proto.ListUploadedPartsRequest]) (*connect.Response[proto.ListUploadedPartsResponse], error) {
+	video, upload, err := a.getUploadInProgress(ctx, request.Msg.GetVideoId(), request.Msg.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+
+	uploadedParts, err := a.s3Client.ListUploadedParts(upload.ObjectKey, s3.S3VideoUploadedBucket, upload.UploadID)
+	if err != nil {
+		if errors.Is(err, s3.ErrUploadNotFound) {
+			return nil, errUploadNotInProgress()
+		}
+
+		return nil, grpc.InternalError(errors.Wrap(err, "failed to list the uploaded parts"))
+	}
+
+	parts := make([]*proto.UploadedPart, 0, len(uploadedParts))
+	for _, part := range uploadedParts {
+		parts = append(parts, &proto.UploadedPart{
+			PartNumber: part.PartNumber,
+			Size:       part.Size,
+			Etag:       part.ETag,
+		})
+	}
+
+	response := &proto.ListUploadedPartsResponse{
+		VideoId:   video.GetID().String(),
+		PartSize:  upload.PartSize,
+		PartCount: upload.PartCount,
+		Parts:     parts,
+	}
+
+	return connect.NewResponse(response), nil
+}
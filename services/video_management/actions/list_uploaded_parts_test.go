@@ -0,0 +1,70 @@
+package actions_test
+
+import (
+	"context"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/gofrs/uuid"
+
+	"github.com/sweetloveinyourheart/sweet-reel/pkg/s3"
+	proto "github.com/sweetloveinyourheart/sweet-reel/proto/code/video_management/go"
+	"github.com/sweetloveinyourheart/sweet-reel/services/video_management/actions"
+)
+
+func (as *ActionsSuite) TestActions_ListUploadedParts_Success() {
+	as.setupEnvironment()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	userID := uuid.Must(uuid.NewV7())
+	video, upload := as.setupUploadInProgress(userID)
+
+	as.mockS3.On("ListUploadedParts", upload.ObjectKey, s3.S3VideoUploadedBucket, upload.UploadID).Return([]s3.UploadedPart{
+		{PartNumber: 2, Size: upload.PartSize, ETag: `"etag"`},
+	}, nil)
+
+	request := &connect.Request[proto.ListUploadedPartsRequest]{
+		Msg: &proto.ListUploadedPartsRequest{
+			VideoId: video.ID.String(),
+			UserId:  userID.String(),
+		},
+	}
+
+	actionsInstance := actions.NewActions(ctx, "test-token")
+	response, err := actionsInstance.ListUploadedParts(ctx, request)
+
+	as.NoError(err)
+	as.Equal(upload.PartSize, response.Msg.GetPartSize())
+	as.Equal(upload.PartCount, response.Msg.GetPartCount())
+	as.Len(response.Msg.GetParts(), 1)
+	as.Equal(int32(2), response.Msg.GetParts()[0].GetPartNumber())
+	as.Equal(`"etag"`, response.Msg.GetParts()[0].GetEtag())
+}
+
+func (as *ActionsSuite) TestActions_ListUploadedParts_UploadGone() {
+	as.setupEnvironment()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	userID := uuid.Must(uuid.NewV7())
+	video, upload := as.setupUploadInProgress(userID)
+
+	as.mockS3.On("ListUploadedParts", upload.ObjectKey, s3.S3VideoUploadedBucket, upload.UploadID).Return(nil, s3.ErrUploadNotFound)
+
+	request := &connect.Request[proto.ListUploadedPartsRequest]{
+		Msg: &proto.ListUploadedPartsRequest{
+			VideoId: video.ID.String(),
+			UserId:  userID.String(),
+		},
+	}
+
+	actionsInstance := actions.NewActions(ctx, "test-token")
+	response, err := actionsInstance.ListUploadedParts(ctx, request)
+
+	as.Error(err)
+	as.Nil(response)
+	as.Equal(connect.CodeFailedPrecondition, connect.CodeOf(err))
+}
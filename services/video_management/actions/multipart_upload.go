@@ -0,0 +1,56 @@
+package actions
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/cockroachdb/errors"
+	"github.com/gofrs/uuid"
+
+	"github.com/sweetloveinyourheart/sweet-reel/pkg/grpc"
+	"github.com/sweetloveinyourheart/sweet-reel/services/video_management/models"
+)
+
+// maxUploadPartUrls is the number of part URLs a single request can ask for
+const maxUploadPartUrls = 100
+
+// getUploadInProgress returns a video along with its multipart upload in progress, provided the user uploads it
+func (a *actions) getUploadInProgress(ctx context.Context, videoIDStr string, userIDStr string) (*models.Video, *models.VideoUpload, error) {
+	videoID := uuid.FromStringOrNil(videoIDStr)
+	if videoID == uuid.Nil {
+		return nil, nil, grpc.InvalidArgumentError(errors.Errorf("video id is not recognized, id: %s", videoIDStr))
+	}
+
+	userID := uuid.FromStringOrNil(userIDStr)
+	if userID == uuid.Nil {
+		return nil, nil, grpc.InvalidArgumentError(errors.Errorf("user id is not recognized, id: %s", userIDStr))
+	}
+
+	video, err := a.videoAggregateRepo.GetVideoByID(ctx, videoID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil, grpc.NotFoundError(errors.New("video not found"))
+		}
+
+		return nil, nil, grpc.InternalError(err)
+	}
+
+	if video.GetUploaderID() != userID {
+		return nil, nil, grpc.PermissionDeniedError(errors.New("only the uploader can upload the video"))
+	}
+
+	upload, err := a.videoAggregateRepo.GetVideoUploadByVideoID(ctx, videoID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil, errUploadNotInProgress()
+		}
+
+		return nil, nil, grpc.InternalError(err)
+	}
+
+	return video, upload, nil
+}
+
+func errUploadNotInProgress() error {
+	return grpc.PreconditionError(grpc.PreconditionFailure("state", "upload", "the video has no upload in progress"))
+}
@@ -0,0 +1,96 @@
+package actions_test
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/mock"
+
+	proto "github.com/sweetloveinyourheart/sweet-reel/proto/code/video_management/go"
+	"github.com/sweetloveinyourheart/sweet-reel/services/video_management/actions"
+	"github.com/sweetloveinyourheart/sweet-reel/services/video_management/models"
+)
+
+// setupUploadInProgress sets up a video of userID whose file is being uploaded in parts
+func (as *ActionsSuite) setupUploadInProgress(userID uuid.UUID) (*models.Video, *models.VideoUpload) {
+	videoID := uuid.Must(uuid.NewV7())
+	video := &models.Video{
+		ID:         videoID,
+		UploaderID: userID,
+		ChannelID:  uuid.Must(uuid.NewV7()),
+		Title:      "Test Video",
+		Status:     models.VideoStatusProcessing,
+	}
+	upload := &models.VideoUpload{
+		VideoID:   videoID,
+		UploadID:  "upload-id",
+		ObjectKey: fmt.Sprintf("2025-11-12/%s.mp4", videoID.String()),
+		FileSize:  40 << 20,
+		PartSize:  16 << 20,
+		PartCount: 3,
+	}
+
+	as.mockVideoAggregateRepository.On("GetVideoByID", mock.Anything, videoID).Return(video, nil)
+	as.mockVideoAggregateRepository.On("GetVideoUploadByVideoID", mock.Anything, videoID).Return(upload, nil)
+	return video, upload
+}
+
+func (as *ActionsSuite) TestActions_MultipartUpload_NotUploader() {
+	as.setupEnvironment()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	video, _ := as.setupUploadInProgress(uuid.Must(uuid.NewV7()))
+
+	request := &connect.Request[proto.ListUploadedPartsRequest]{
+		Msg: &proto.ListUploadedPartsRequest{
+			VideoId: video.ID.String(),
+			UserId:  uuid.Must(uuid.NewV7()).String(),
+		},
+	}
+
+	actionsInstance := actions.NewActions(ctx, "test-token")
+	response, err := actionsInstance.ListUploadedParts(ctx, request)
+
+	as.Error(err)
+	as.Nil(response)
+	as.Equal(connect.CodePermissionDenied, connect.CodeOf(err))
+	as.mockS3.AssertNotCalled(as.T(), "ListUploadedParts", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (as *ActionsSuite) TestActions_MultipartUpload_NoUploadInProgress() {
+	as.setupEnvironment()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	userID := uuid.Must(uuid.NewV7())
+	videoID := uuid.Must(uuid.NewV7())
+
+	// Uploaded already, or through a presigned URL
+	as.mockVideoAggregateRepository.On("GetVideoByID", mock.Anything, videoID).Return(&models.Video{
+		ID:         videoID,
+		UploaderID: userID,
+	}, nil)
+	as.mockVideoAggregateRepository.On("GetVideoUploadByVideoID", mock.Anything, videoID).Return(nil, sql.ErrNoRows)
+
+	request := &connect.Request[proto.GetUploadPartUrlsRequest]{
+		Msg: &proto.GetUploadPartUrlsRequest{
+			VideoId:     videoID.String(),
+			UserId:      userID.String(),
+			PartNumbers: []int32{1},
+		},
+	}
+
+	actionsInstance := actions.NewActions(ctx, "test-token")
+	response, err := actionsInstance.GetUploadPartUrls(ctx, request)
+
+	as.Error(err)
+	as.Nil(response)
+	as.Equal(connect.CodeFailedPrecondition, connect.CodeOf(err))
+}
@@ -17,45 +17,20 @@ import (
 	"github.com/sweetloveinyourheart/sweet-reel/pkg/s3"
 	"github.com/sweetloveinyourheart/sweet-reel/pkg/stringsutil"
 	proto "github.com/sweetloveinyourheart/sweet-reel/proto/code/video_management/go"
+	"github.com/sweetloveinyourheart/sweet-reel/services/video_management/domains/channelstats"
 	"github.com/sweetloveinyourheart/sweet-reel/services/video_management/models"
+	"github.com/sweetloveinyourheart/sweet-reel/services/video_management/repos"
 )
 
 func (a *actions) PresignedUrl(ctx context.Context, request *connect.Request[proto.PresignedUrlRequest]) (*connect.Response[proto.PresignedUrlResponse], error) {
-	uploaderID := uuid.FromStringOrNil(request.Msg.GetUploaderId())
-	if uploaderID == uuid.Nil {
-		return nil, grpc.InvalidArgumentError(errors.Errorf("uploader id is not recognized, id: ", request.Msg.GetUploaderId()))
-	}
-
-	channelID := uuid.FromStringOrNil(request.Msg.GetChannelId())
-	if channelID == uuid.Nil {
-		return nil, grpc.InvalidArgumentError(errors.Errorf("channel id is not recognized, id: ", request.Msg.GetChannelId()))
-	}
-
-	filename, ext := s3.ExtractFilenameAndExt(request.Msg.GetFileName())
-	if stringsutil.IsBlank(ext) || stringsutil.IsBlank(filename) {
-		return nil, grpc.InvalidArgumentError(errors.New("cannot extract the filename or extension"))
-	}
-
-	var description *string
-	if !stringsutil.IsBlank(request.Msg.Description) {
-		description = &request.Msg.Description
-	}
-
-	newVideo := models.Video{
-		ID:          uuid.Must(uuid.NewV7()),
-		Title:       request.Msg.GetTitle(),
-		Description: description,
-		UploaderID:  uploaderID,
-		ChannelID:   channelID,
-		Status:      models.VideoStatusProcessing,
-	}
-
-	if err := newVideo.Validate(); err != nil {
-		return nil, grpc.InvalidArgumentError(err)
+	newVideo, key, err := newUploadVideo(request.Msg.GetTitle(), request.Msg.GetDescription(), request.Msg.GetFileName(), request.Msg.GetUploaderId(), request.Msg.GetChannelId())
+	if err != nil {
+		return nil, err
 	}
 
-	key := fmt.Sprintf("%s/%s%s", time.Now().Format("2006-01-02"), newVideo.GetID(), ext)
-	url, err := a.s3Client.GenerateUploadPublicUri(key, s3.S3VideoUploadedBucket, s3.UrlExpirationSeconds)
+	// The trace of the request is stored with the upload, processing the video continues it
+	metadata := s3.TraceMetadata(ctx)
+	url, err := a.s3Client.GenerateUploadPublicUri(key, s3.S3VideoUploadedBucket, s3.UrlExpirationSeconds, metadata)
 	if err != nil {
 		logger.Global().Error("Failed to generate presigned URL",
 			zap.String("key", key),
@@ -65,19 +40,71 @@ func (a *actions) PresignedUrl(ctx context.Context, request *connect.Request[pro
 		return nil, grpc.InternalError(errors.Wrapf(err, "failed to generate presigned URL for bucket %s, key %s", s3.S3VideoUploadedBucket, key))
 	}
 
-	if err := a.videoAggregateRepo.CreateVideo(ctx, &newVideo); err != nil {
+	err = a.videoAggregateRepo.InTransaction(ctx, "CreateVideo", func(repo repos.IVideoAggregateRepository) error {
+		if err := repo.CreateVideo(ctx, newVideo); err != nil {
+			return err
+		}
+		return channelstats.EnqueueTotalVideos(ctx, repo, newVideo.GetChannelID())
+	})
+	if err != nil {
 		logger.Global().Error("Failed to create video in database",
 			zap.String("videoID", newVideo.GetID().String()),
-			zap.String("uploaderID", uploaderID.String()),
+			zap.String("uploaderID", newVideo.GetUploaderID().String()),
 			zap.Error(err))
 		return nil, grpc.InternalError(err)
 	}
 
 	response := &proto.PresignedUrlResponse{
-		VideoId:      newVideo.GetID().String(),
-		PresignedUrl: url,
-		ExpiresIn:    s3.UrlExpirationSeconds,
+		VideoId:       newVideo.GetID().String(),
+		PresignedUrl:  url,
+		ExpiresIn:     s3.UrlExpirationSeconds,
+		UploadHeaders: s3.MetadataHeaders(metadata),
 	}
 
 	return connect.NewResponse(response), nil
 }
+
+// newUploadVideo validates the fields of an upload request and returns the video to create,
+// along with the key its file is uploaded to
+func newUploadVideo(title string, description string, fileName string, uploaderIDStr string, channelIDStr string) (*models.Video, string, error) {
+	uploaderID := uuid.FromStringOrNil(uploaderIDStr)
+	if uploaderID == uuid.Nil {
+		return nil, "", grpc.InvalidArgumentError(errors.Errorf("uploader id is not recognized, id: %s", uploaderIDStr))
+	}
+
+	channelID := uuid.FromStringOrNil(channelIDStr)
+	if channelID == uuid.Nil {
+		return nil, "", grpc.InvalidArgumentError(errors.Errorf("channel id is not recognized, id: %s", channelIDStr))
+	}
+
+	filename, ext := s3.ExtractFilenameAndExt(fileName)
+	if stringsutil.IsBlank(ext) || stringsutil.IsBlank(filename) {
+		return nil, "", grpc.InvalidArgumentError(errors.New("cannot extract the filename or extension"))
+	}
+
+	var videoDescription *string
+	if !stringsutil.IsBlank(description) {
+		videoDescription = &description
+	}
+
+	newVideo := &models.Video{
+		ID:          uuid.Must(uuid.NewV7()),
+		Title:       title,
+		Description: videoDescription,
+		UploaderID:  uploaderID,
+		ChannelID:   channelID,
+		Status:      models.VideoStatusProcessing,
+		Format:      models.VideoFormatLongForm, // Decided once the upload has been probed
+		Visibility:  models.VideoVisibilityPublic,
+	}
+
+	if err := newVideo.Validate(); err != nil {
+		return nil, "", grpc.InvalidArgumentError(err)
+	}
+
+	// The key is recorded right away, so that deleting the video before it is processed still
+	// finds its upload
+	key := fmt.Sprintf("%s/%s%s", time.Now().Format("2006-01-02"), newVideo.GetID(), ext)
+	newVideo.ObjectKey = &key
+	return newVideo, key, nil
+}
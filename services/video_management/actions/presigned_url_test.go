@@ -11,6 +11,7 @@ import (
 	"github.com/gofrs/uuid"
 	"github.com/stretchr/testify/mock"
 
+	"github.com/sweetloveinyourheart/sweet-reel/pkg/kafka"
 	"github.com/sweetloveinyourheart/sweet-reel/pkg/s3"
 	proto "github.com/sweetloveinyourheart/sweet-reel/proto/code/video_management/go"
 	"github.com/sweetloveinyourheart/sweet-reel/services/video_management/actions"
@@ -38,7 +39,8 @@ func (as *ActionsSuite) TestActions_PresignedUrl_Success() {
 			return key != "" && key[len(key)-4:] == ".mp4"
 		}),
 		s3.S3VideoUploadedBucket,
-		uint32(s3.UrlExpirationSeconds)).Return(expectedURL, nil)
+		uint32(s3.UrlExpirationSeconds),
+		map[string]string(nil)).Return(expectedURL, nil)
 
 	as.mockVideoAggregateRepository.On("CreateVideo", ctx, mock.MatchedBy(func(video *models.Video) bool {
 		return video.Title == title &&
@@ -46,6 +48,8 @@ func (as *ActionsSuite) TestActions_PresignedUrl_Success() {
 			video.UploaderID == userID &&
 			video.ID != uuid.Nil
 	})).Return(nil)
+	as.mockVideoAggregateRepository.On("GetVideoCountByChannelID", ctx, channelID).Return(int64(1), nil)
+	as.mockVideoAggregateRepository.On("EnqueueEvent", ctx, kafka.KafkaChannelVideosChangedTopic, channelID.String(), mock.Anything).Return(nil)
 
 	// Setup request
 	request := &connect.Request[proto.PresignedUrlRequest]{
@@ -95,13 +99,16 @@ func (as *ActionsSuite) TestActions_PresignedUrl_Success_WithoutDescription() {
 	as.mockS3.On("GenerateUploadPublicUri",
 		mock.AnythingOfType("string"),
 		s3.S3VideoUploadedBucket,
-		uint32(s3.UrlExpirationSeconds)).Return(expectedURL, nil)
+		uint32(s3.UrlExpirationSeconds),
+		map[string]string(nil)).Return(expectedURL, nil)
 
 	as.mockVideoAggregateRepository.On("CreateVideo", ctx, mock.MatchedBy(func(video *models.Video) bool {
 		return video.Title == title &&
 			video.Description == nil &&
 			video.UploaderID == userID
 	})).Return(nil)
+	as.mockVideoAggregateRepository.On("GetVideoCountByChannelID", ctx, channelID).Return(int64(1), nil)
+	as.mockVideoAggregateRepository.On("EnqueueEvent", ctx, kafka.KafkaChannelVideosChangedTopic, channelID.String(), mock.Anything).Return(nil)
 
 	// Setup request without description
 	request := &connect.Request[proto.PresignedUrlRequest]{
@@ -241,7 +248,8 @@ func (as *ActionsSuite) TestActions_PresignedUrl_S3GenerateUrlError() {
 	as.mockS3.On("GenerateUploadPublicUri",
 		mock.AnythingOfType("string"),
 		s3.S3VideoUploadedBucket,
-		uint32(s3.UrlExpirationSeconds)).Return("", s3Error)
+		uint32(s3.UrlExpirationSeconds),
+		map[string]string(nil)).Return("", s3Error)
 
 	// Setup request
 	request := &connect.Request[proto.PresignedUrlRequest]{
@@ -289,7 +297,8 @@ func (as *ActionsSuite) TestActions_PresignedUrl_DatabaseError() {
 	as.mockS3.On("GenerateUploadPublicUri",
 		mock.AnythingOfType("string"),
 		s3.S3VideoUploadedBucket,
-		uint32(s3.UrlExpirationSeconds)).Return(expectedURL, nil)
+		uint32(s3.UrlExpirationSeconds),
+		map[string]string(nil)).Return(expectedURL, nil)
 
 	as.mockVideoAggregateRepository.On("CreateVideo", ctx, mock.AnythingOfType("*models.Video")).Return(dbError)
 
@@ -342,9 +351,17 @@ func (as *ActionsSuite) TestActions_PresignedUrl_KeyGeneration() {
 			return true
 		}),
 		s3.S3VideoUploadedBucket,
-		uint32(s3.UrlExpirationSeconds)).Return(expectedURL, nil)
+		uint32(s3.UrlExpirationSeconds),
+		map[string]string(nil)).Return(expectedURL, nil)
 
-	as.mockVideoAggregateRepository.On("CreateVideo", ctx, mock.AnythingOfType("*models.Video")).Return(nil)
+	// The video is recorded with the key of its upload before the upload arrives
+	var recordedKey string
+	as.mockVideoAggregateRepository.On("CreateVideo", ctx, mock.MatchedBy(func(video *models.Video) bool {
+		recordedKey = video.GetObjectKey()
+		return true
+	})).Return(nil)
+	as.mockVideoAggregateRepository.On("GetVideoCountByChannelID", ctx, channelID).Return(int64(1), nil)
+	as.mockVideoAggregateRepository.On("EnqueueEvent", ctx, kafka.KafkaChannelVideosChangedTopic, channelID.String(), mock.Anything).Return(nil)
 
 	// Setup request
 	request := &connect.Request[proto.PresignedUrlRequest]{
@@ -367,6 +384,7 @@ func (as *ActionsSuite) TestActions_PresignedUrl_KeyGeneration() {
 	// Validate key format: raw/YYYY/MM/DD/{uuid}.mp4
 	as.Contains(capturedKey, time.Now().Format("2006-01-02"))
 	as.Contains(capturedKey, ".mp4")
+	as.Equal(capturedKey, recordedKey)
 
 	// Verify all mocks were called as expected
 	as.mockS3.AssertExpectations(as.T())
@@ -409,9 +427,12 @@ func (as *ActionsSuite) TestActions_PresignedUrl_DifferentFileExtensions() {
 						return key != "" && key[len(key)-len(tc.expectedExt):] == tc.expectedExt
 					}),
 					s3.S3VideoUploadedBucket,
-					uint32(s3.UrlExpirationSeconds)).Return("https://example.com/url", nil)
+					uint32(s3.UrlExpirationSeconds),
+					map[string]string(nil)).Return("https://example.com/url", nil)
 
 				as.mockVideoAggregateRepository.On("CreateVideo", ctx, mock.AnythingOfType("*models.Video")).Return(nil)
+				as.mockVideoAggregateRepository.On("GetVideoCountByChannelID", ctx, channelID).Return(int64(1), nil)
+				as.mockVideoAggregateRepository.On("EnqueueEvent", ctx, kafka.KafkaChannelVideosChangedTopic, channelID.String(), mock.Anything).Return(nil)
 			}
 
 			request := &connect.Request[proto.PresignedUrlRequest]{
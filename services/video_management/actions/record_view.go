@@ -0,0 +1,162 @@
+package actions
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/cockroachdb/errors"
+	"github.com/gofrs/uuid"
+
+	"github.com/sweetloveinyourheart/sweet-reel/pkg/grpc"
+	proto "github.com/sweetloveinyourheart/sweet-reel/proto/code/video_management/go"
+	"github.com/sweetloveinyourheart/sweet-reel/services/video_management/models"
+	"github.com/sweetloveinyourheart/sweet-reel/services/video_management/repos"
+)
+
+// ViewDedupeWindow is how long repeat views from the same viewer or IP address are not counted again
+const ViewDedupeWindow = 30 * time.Minute
+
+func (a *actions) RecordView(ctx context.Context, request *connect.Request[proto.RecordViewRequest]) (*connect.Response[proto.RecordViewResponse], error) {
+	videoID := uuid.FromStringOrNil(request.Msg.GetVideoId())
+	if videoID == uuid.Nil {
+		return nil, grpc.InvalidArgumentError(errors.Errorf("video id is not recognized, id: %s", request.Msg.GetVideoId()))
+	}
+
+	if request.Msg.GetWatchDuration() < 0 {
+		return nil, grpc.InvalidArgumentError(errors.New("watch duration should not be negative"))
+	}
+
+	var viewerID *uuid.UUID
+	if request.Msg.GetViewerId() != "" {
+		id := uuid.FromStringOrNil(request.Msg.GetViewerId())
+		if id == uuid.Nil {
+			return nil, grpc.InvalidArgumentError(errors.Errorf("viewer id is not recognized, id: %s", request.Msg.GetViewerId()))
+		}
+		viewerID = &id
+	}
+
+	var ipAddress *string
+	if request.Msg.GetIpAddress() != "" {
+		ip := request.Msg.GetIpAddress()
+		ipAddress = &ip
+	}
+
+	if viewerID == nil && ipAddress == nil {
+		return nil, grpc.InvalidArgumentError(errors.New("either viewer id or ip address is required"))
+	}
+
+	// A view id means the view is already recorded and this is a heartbeat
+	if request.Msg.GetViewId() != "" {
+		return a.recordWatchDuration(ctx, videoID, viewerID, ipAddress, request.Msg)
+	}
+
+	video, err := a.videoAggregateRepo.GetVideoByID(ctx, videoID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, grpc.NotFoundError(errors.New("video not found"))
+		}
+
+		return nil, grpc.InternalError(err)
+	}
+
+	if !video.CanBeViewedBy(uuid.FromStringOrNil(request.Msg.GetViewerId())) {
+		return nil, grpc.NotFoundError(errors.New("video not found"))
+	}
+
+	if video.GetStatus() != models.VideoStatusReady {
+		return nil, grpc.PreconditionError(grpc.PreconditionFailure("state", "status", "video is not ready to be watched"))
+	}
+
+	// Every watch session gets its own row for watch time, but only the first one in the window counts as a view
+	view := &models.VideoView{
+		ID:        uuid.Must(uuid.NewV7()),
+		VideoID:   videoID,
+		ViewerID:  viewerID,
+		ViewedAt:  time.Now(),
+		IPAddress: ipAddress,
+	}
+
+	if request.Msg.GetWatchDuration() > 0 {
+		watchDuration := int(request.Msg.GetWatchDuration())
+		view.WatchDuration = &watchDuration
+	}
+
+	if request.Msg.GetUserAgent() != "" {
+		userAgent := request.Msg.GetUserAgent()
+		view.UserAgent = &userAgent
+	}
+
+	var viewedRecently bool
+	err = a.videoAggregateRepo.InTransaction(ctx, "RecordView", func(repo repos.IVideoAggregateRepository) error {
+		// Concurrent views of the same viewer wait on each other, so only one of them is counted
+		if err := repo.LockVideoViewer(ctx, videoID, viewerID, ipAddress); err != nil {
+			return err
+		}
+
+		viewed, err := repo.HasViewedRecently(ctx, videoID, viewerID, ipAddress, ViewDedupeWindow)
+		if err != nil {
+			return err
+		}
+		viewedRecently = viewed
+
+		if err := repo.CreateVideoView(ctx, view); err != nil {
+			return err
+		}
+
+		if viewedRecently {
+			return nil
+		}
+
+		// The view total of the channel is published by the reconciler, which coalesces the views of the channel
+		return repo.IncrementVideoViewCount(ctx, videoID)
+	})
+	if err != nil {
+		return nil, grpc.InternalError(err)
+	}
+
+	totalView, err := a.videoAggregateRepo.GetVideoViewCount(ctx, videoID)
+	if err != nil {
+		return nil, grpc.InternalError(err)
+	}
+
+	response := &proto.RecordViewResponse{
+		ViewId:    view.ID.String(),
+		Counted:   !viewedRecently,
+		TotalView: totalView,
+	}
+
+	return connect.NewResponse(response), nil
+}
+
+// recordWatchDuration updates the watch time of a view recorded earlier by the same viewer,
+// or from the same IP address when the view is anonymous
+func (a *actions) recordWatchDuration(ctx context.Context, videoID uuid.UUID, viewerID *uuid.UUID, ipAddress *string, msg *proto.RecordViewRequest) (*connect.Response[proto.RecordViewResponse], error) {
+	viewID := uuid.FromStringOrNil(msg.GetViewId())
+	if viewID == uuid.Nil {
+		return nil, grpc.InvalidArgumentError(errors.Errorf("view id is not recognized, id: %s", msg.GetViewId()))
+	}
+
+	err := a.videoAggregateRepo.UpdateVideoViewWatchDuration(ctx, viewID, videoID, viewerID, ipAddress, int(msg.GetWatchDuration()))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, grpc.NotFoundError(errors.New("view not found"))
+		}
+
+		return nil, grpc.InternalError(err)
+	}
+
+	totalView, err := a.videoAggregateRepo.GetVideoViewCount(ctx, videoID)
+	if err != nil {
+		return nil, grpc.InternalError(err)
+	}
+
+	response := &proto.RecordViewResponse{
+		ViewId:    viewID.String(),
+		Counted:   false,
+		TotalView: totalView,
+	}
+
+	return connect.NewResponse(response), nil
+}
@@ -0,0 +1,197 @@
+package actions_test
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/mock"
+
+	proto "github.com/sweetloveinyourheart/sweet-reel/proto/code/video_management/go"
+	"github.com/sweetloveinyourheart/sweet-reel/services/video_management/actions"
+	"github.com/sweetloveinyourheart/sweet-reel/services/video_management/models"
+)
+
+func (as *ActionsSuite) TestActions_RecordView_Counted() {
+	as.setupEnvironment()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	videoID := uuid.Must(uuid.NewV7())
+	viewerID := uuid.Must(uuid.NewV7())
+	video := publicVideo(videoID)
+	video.ChannelID = uuid.Must(uuid.NewV7())
+
+	as.mockVideoAggregateRepository.On("GetVideoByID", mock.Anything, videoID).Return(video, nil)
+	as.mockVideoAggregateRepository.On("LockVideoViewer", mock.Anything, videoID, &viewerID, mock.Anything).Return(nil)
+	as.mockVideoAggregateRepository.On("HasViewedRecently", mock.Anything, videoID, &viewerID, mock.Anything, actions.ViewDedupeWindow).Return(false, nil)
+	as.mockVideoAggregateRepository.On("CreateVideoView", mock.Anything, mock.MatchedBy(func(view *models.VideoView) bool {
+		return view.VideoID == videoID && *view.ViewerID == viewerID && *view.UserAgent == "test-agent"
+	})).Return(nil)
+	as.mockVideoAggregateRepository.On("IncrementVideoViewCount", mock.Anything, videoID).Return(nil)
+	as.mockVideoAggregateRepository.On("GetVideoViewCount", mock.Anything, videoID).Return(int64(1), nil)
+
+	request := &connect.Request[proto.RecordViewRequest]{
+		Msg: &proto.RecordViewRequest{
+			VideoId:   videoID.String(),
+			ViewerId:  viewerID.String(),
+			IpAddress: "203.0.113.10",
+			UserAgent: "test-agent",
+		},
+	}
+
+	actionsInstance := actions.NewActions(ctx, "test-token")
+	response, err := actionsInstance.RecordView(ctx, request)
+
+	as.NoError(err)
+	as.NotNil(response)
+	as.True(response.Msg.GetCounted())
+	as.NotEmpty(response.Msg.GetViewId())
+	as.Equal(int64(1), response.Msg.GetTotalView())
+	as.mockVideoAggregateRepository.AssertExpectations(as.T())
+
+	// Channel totals are coalesced by the reconciler rather than counted per view
+	as.mockVideoAggregateRepository.AssertNotCalled(as.T(), "GetTotalViewsByChannelID", mock.Anything, video.ChannelID)
+}
+
+func (as *ActionsSuite) TestActions_RecordView_Deduplicated() {
+	as.setupEnvironment()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	videoID := uuid.Must(uuid.NewV7())
+	ipAddress := "203.0.113.10"
+
+	as.mockVideoAggregateRepository.On("GetVideoByID", mock.Anything, videoID).Return(publicVideo(videoID), nil)
+	as.mockVideoAggregateRepository.On("LockVideoViewer", mock.Anything, videoID, (*uuid.UUID)(nil), &ipAddress).Return(nil)
+	as.mockVideoAggregateRepository.On("HasViewedRecently", mock.Anything, videoID, (*uuid.UUID)(nil), &ipAddress, actions.ViewDedupeWindow).Return(true, nil)
+	as.mockVideoAggregateRepository.On("CreateVideoView", mock.Anything, mock.AnythingOfType("*models.VideoView")).Return(nil)
+	as.mockVideoAggregateRepository.On("GetVideoViewCount", mock.Anything, videoID).Return(int64(5), nil)
+
+	request := &connect.Request[proto.RecordViewRequest]{
+		Msg: &proto.RecordViewRequest{
+			VideoId:   videoID.String(),
+			IpAddress: ipAddress,
+		},
+	}
+
+	actionsInstance := actions.NewActions(ctx, "test-token")
+	response, err := actionsInstance.RecordView(ctx, request)
+
+	as.NoError(err)
+	as.False(response.Msg.GetCounted())
+	as.Equal(int64(5), response.Msg.GetTotalView())
+	as.mockVideoAggregateRepository.AssertNotCalled(as.T(), "IncrementVideoViewCount", mock.Anything, videoID)
+}
+
+func (as *ActionsSuite) TestActions_RecordView_Heartbeat() {
+	as.setupEnvironment()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	videoID := uuid.Must(uuid.NewV7())
+	viewID := uuid.Must(uuid.NewV7())
+	viewerID := uuid.Must(uuid.NewV7())
+	ipAddress := "203.0.113.10"
+
+	as.mockVideoAggregateRepository.On("UpdateVideoViewWatchDuration", mock.Anything, viewID, videoID, &viewerID, &ipAddress, 42).Return(nil)
+	as.mockVideoAggregateRepository.On("GetVideoViewCount", mock.Anything, videoID).Return(int64(3), nil)
+
+	request := &connect.Request[proto.RecordViewRequest]{
+		Msg: &proto.RecordViewRequest{
+			VideoId:       videoID.String(),
+			ViewerId:      viewerID.String(),
+			IpAddress:     ipAddress,
+			ViewId:        viewID.String(),
+			WatchDuration: 42,
+		},
+	}
+
+	actionsInstance := actions.NewActions(ctx, "test-token")
+	response, err := actionsInstance.RecordView(ctx, request)
+
+	as.NoError(err)
+	as.False(response.Msg.GetCounted())
+	as.Equal(viewID.String(), response.Msg.GetViewId())
+	as.mockVideoAggregateRepository.AssertNotCalled(as.T(), "CreateVideoView", mock.Anything, mock.Anything)
+	as.mockVideoAggregateRepository.AssertExpectations(as.T())
+}
+
+func (as *ActionsSuite) TestActions_RecordView_HeartbeatOfAnotherViewer() {
+	as.setupEnvironment()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	videoID := uuid.Must(uuid.NewV7())
+	viewID := uuid.Must(uuid.NewV7())
+	ipAddress := "198.51.100.7"
+
+	// The view belongs to someone else, so no row matches the heartbeat
+	as.mockVideoAggregateRepository.On("UpdateVideoViewWatchDuration", mock.Anything, viewID, videoID, (*uuid.UUID)(nil), &ipAddress, 3600).Return(sql.ErrNoRows)
+
+	request := &connect.Request[proto.RecordViewRequest]{
+		Msg: &proto.RecordViewRequest{
+			VideoId:       videoID.String(),
+			IpAddress:     ipAddress,
+			ViewId:        viewID.String(),
+			WatchDuration: 3600,
+		},
+	}
+
+	actionsInstance := actions.NewActions(ctx, "test-token")
+	response, err := actionsInstance.RecordView(ctx, request)
+
+	as.Error(err)
+	as.Nil(response)
+	as.Equal(connect.CodeNotFound, connect.CodeOf(err))
+	as.mockVideoAggregateRepository.AssertNotCalled(as.T(), "GetVideoViewCount", mock.Anything, videoID)
+}
+
+func (as *ActionsSuite) TestActions_RecordView_HeartbeatMissingViewerIdentity() {
+	as.setupEnvironment()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	request := &connect.Request[proto.RecordViewRequest]{
+		Msg: &proto.RecordViewRequest{
+			VideoId:       uuid.Must(uuid.NewV7()).String(),
+			ViewId:        uuid.Must(uuid.NewV7()).String(),
+			WatchDuration: 42,
+		},
+	}
+
+	actionsInstance := actions.NewActions(ctx, "test-token")
+	response, err := actionsInstance.RecordView(ctx, request)
+
+	as.Error(err)
+	as.Nil(response)
+	as.Equal(connect.CodeInvalidArgument, connect.CodeOf(err))
+	as.mockVideoAggregateRepository.AssertNotCalled(as.T(), "UpdateVideoViewWatchDuration", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (as *ActionsSuite) TestActions_RecordView_MissingViewerIdentity() {
+	as.setupEnvironment()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	request := &connect.Request[proto.RecordViewRequest]{
+		Msg: &proto.RecordViewRequest{
+			VideoId: uuid.Must(uuid.NewV7()).String(),
+		},
+	}
+
+	actionsInstance := actions.NewActions(ctx, "test-token")
+	response, err := actionsInstance.RecordView(ctx, request)
+
+	as.Error(err)
+	as.Nil(response)
+	as.Equal(connect.CodeInvalidArgument, connect.CodeOf(err))
+}
@@ -1,7 +1,14 @@
 package actions
 
 import (
+	"bufio"
 	"context"
+	"database/sql"
+	"math"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
 
 	"connectrpc.com/connect"
 	"github.com/cockroachdb/errors"
@@ -13,85 +20,321 @@ import (
 	"github.com/sweetloveinyourheart/sweet-reel/pkg/logger"
 	"github.com/sweetloveinyourheart/sweet-reel/pkg/s3"
 	proto "github.com/sweetloveinyourheart/sweet-reel/proto/code/video_management/go"
+	"github.com/sweetloveinyourheart/sweet-reel/services/video_management/models"
 )
 
 const (
 	QualityDefault = ffmpeg.QualityDefault
+
+	// PlaylistMaxAgeSeconds is how long a served playlist may be cached by players and proxies
+	PlaylistMaxAgeSeconds = 60
+
+	// SegmentUrlGraceSeconds keeps segment urls valid past the length of the video, so viewers
+	// can pause or seek back without reloading the playlist
+	SegmentUrlGraceSeconds = s3.UrlExpirationSeconds
+
+	// ContentKeyUrl is where players of a variant playlist fetch the key decrypting its segments,
+	// served by the gateway next to the master playlist to viewers entitled to the video
+	ContentKeyUrl = "../key"
 )
 
+// ServePlaylist returns the master playlist of a video, or the variant playlist of one quality,
+// ready to be handed to a player. The master playlist lists the variants that were processed,
+// relative to the master, while segments of a variant playlist are signed urls. Videos packaged
+// for DASH too are served their DASH manifest, listing every quality with signed segment urls,
+// when the dash format is requested.
 func (a *actions) ServePlaylist(ctx context.Context, request *connect.Request[proto.ServePlaylistRequest]) (*connect.Response[proto.ServePlaylistResponse], error) {
 	videoID := uuid.FromStringOrNil(request.Msg.GetVideoId())
 	if videoID == uuid.Nil {
-		return nil, grpc.InvalidArgumentError(errors.Errorf("video id is not recognized, id: ", videoID.String()))
+		return nil, grpc.InvalidArgumentError(errors.Errorf("video id is not recognized, id: %s", request.Msg.GetVideoId()))
+	}
+
+	format := models.ManifestFormat(request.Msg.GetFormat())
+	if format == "" {
+		format = models.ManifestFormatHLS
+	}
+	if format != models.ManifestFormatHLS && format != models.ManifestFormatDASH {
+		return nil, grpc.InvalidArgumentError(errors.Errorf("playlist format is not supported, format: %s", format))
+	}
+
+	video, err := a.videoAggregateRepo.GetVideoByID(ctx, videoID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, grpc.NotFoundError(errors.New("video not found"))
+		}
+
+		return nil, grpc.InternalError(err)
 	}
 
-	masterPlaylist := ""
-	variantPlaylistsMap := make(map[string]*proto.ServePlaylistVariant)
+	// Private videos are reported as missing to anyone but their uploader
+	viewerID := uuid.FromStringOrNil(request.Msg.GetViewerId())
+	if !video.CanBeViewedBy(viewerID) {
+		return nil, grpc.NotFoundError(errors.New("video not found"))
+	}
+
+	quality := request.Msg.GetQuality()
+	if quality == "" {
+		quality = QualityDefault
+	}
 
 	manifests, err := a.videoAggregateRepo.GetVideoManifestsByVideoID(ctx, videoID)
 	if err != nil {
 		return nil, grpc.InternalError(err)
 	}
 
+	// Manifests recorded before DASH was supported have no format, they are HLS playlists
+	var dashManifestKey string
+	qualities := make(map[string]string, len(manifests))
 	for _, manifest := range manifests {
 		if manifest == nil {
 			continue
 		}
 
-		if manifest.Quality == QualityDefault {
-			masterPlaylist, err = a.s3Client.GenerateDownloadPublicUri(manifest.GetObjectKey(), s3.S3VideoUploadedBucket, s3.UrlExpirationSeconds)
-			if err != nil {
-				logger.Global().Error("unable to generate master playlist url", zap.Error(err))
-				return nil, grpc.InternalError(err)
-			}
-		} else {
-			variantPlaylist, err := a.s3Client.GenerateDownloadPublicUri(manifest.GetObjectKey(), s3.S3VideoUploadedBucket, s3.UrlExpirationSeconds)
-			if err != nil {
-				logger.Global().Error("unable to generate variant playlist url", zap.Error(err))
-				return nil, grpc.InternalError(err)
+		if manifest.GetFormat() == models.ManifestFormatDASH {
+			if manifest.Quality == QualityDefault {
+				dashManifestKey = manifest.GetObjectKey()
 			}
+			continue
+		}
+		qualities[manifest.Quality] = manifest.GetObjectKey()
+	}
+
+	if format == models.ManifestFormatDASH {
+		if quality != QualityDefault || dashManifestKey == "" {
+			return nil, grpc.NotFoundError(errors.Errorf("DASH manifest not found, quality: %s", quality))
+		}
+
+		manifest, err := a.signedDASHManifest(dashManifestKey)
+		if err != nil {
+			return nil, grpc.InternalError(err)
+		}
+
+		response := &proto.ServePlaylistResponse{
+			Playlist:      manifest,
+			MaxAgeSeconds: PlaylistMaxAgeSeconds,
+		}
+
+		return connect.NewResponse(response), nil
+	}
+
+	// Players supporting DASH are pointed to the manifest from the master playlist response
+	var dashManifestURL string
+	if quality == QualityDefault && dashManifestKey != "" {
+		dashManifestURL = ffmpeg.DefaultDASHManifestName
+	}
+
+	// The master playlist is built from the recorded variants, unless they were recorded
+	// without stream info
+	if quality == QualityDefault {
+		variants, err := a.videoAggregateRepo.GetVideoVariantsByVideoID(ctx, videoID)
+		if err != nil {
+			return nil, grpc.InternalError(err)
+		}
 
-			if _, exists := variantPlaylistsMap[manifest.Quality]; !exists {
-				variantPlaylistsMap[manifest.Quality] = &proto.ServePlaylistVariant{}
+		if streamVariants, ok := masterPlaylistVariants(variants, qualities); ok {
+			response := &proto.ServePlaylistResponse{
+				Playlist:        ffmpeg.MasterPlaylist(streamVariants),
+				MaxAgeSeconds:   PlaylistMaxAgeSeconds,
+				DashManifestUrl: dashManifestURL,
 			}
 
-			variantPlaylistsMap[manifest.Quality].PlaylistUrl = variantPlaylist
-			variantPlaylistsMap[manifest.Quality].Quality = manifest.Quality
+			return connect.NewResponse(response), nil
 		}
 	}
 
-	variants, err := a.videoAggregateRepo.GetVideoVariantsByVideoID(ctx, videoID)
+	playlistKey, ok := qualities[quality]
+	if !ok {
+		return nil, grpc.NotFoundError(errors.Errorf("playlist not found, quality: %s", quality))
+	}
+
+	content, err := a.s3Client.Download(playlistKey, s3.S3VideoProcessedBucket)
 	if err != nil {
+		logger.Global().Error("unable to download playlist", zap.String("key", playlistKey), zap.Error(err))
 		return nil, grpc.InternalError(err)
 	}
 
+	var playlist string
+	if quality == QualityDefault {
+		playlist = rewriteMasterPlaylist(string(content), qualities)
+	} else {
+		expirationSeconds := uint32(math.Ceil(mediaPlaylistDuration(string(content)))) + SegmentUrlGraceSeconds
+		playlist, err = rewriteMediaPlaylist(string(content), func(uri string) (string, error) {
+			segmentKey := path.Join(path.Dir(playlistKey), uri)
+			return a.s3Client.GenerateDownloadPublicUri(segmentKey, s3.S3VideoProcessedBucket, expirationSeconds)
+		})
+		if err != nil {
+			logger.Global().Error("unable to generate segment url", zap.String("key", playlistKey), zap.Error(err))
+			return nil, grpc.InternalError(err)
+		}
+	}
+
+	response := &proto.ServePlaylistResponse{
+		Playlist:        playlist,
+		MaxAgeSeconds:   PlaylistMaxAgeSeconds,
+		DashManifestUrl: dashManifestURL,
+	}
+
+	return connect.NewResponse(response), nil
+}
+
+// signedDASHManifest downloads the DASH manifest stored at manifestKey and replaces the relative
+// url of every segment with a signed url, valid for the length of the video
+func (a *actions) signedDASHManifest(manifestKey string) (string, error) {
+	content, err := a.s3Client.Download(manifestKey, s3.S3VideoProcessedBucket)
+	if err != nil {
+		logger.Global().Error("unable to download DASH manifest", zap.String("key", manifestKey), zap.Error(err))
+		return "", err
+	}
+
+	mpd, err := ffmpeg.ParseMPD(content)
+	if err != nil {
+		return "", errors.Wrapf(err, "invalid DASH manifest, key: %s", manifestKey)
+	}
+
+	expirationSeconds := uint32(math.Ceil(mpd.DurationSeconds())) + SegmentUrlGraceSeconds
+	err = mpd.RewriteURLs(func(uri string) (string, error) {
+		if strings.Contains(uri, "://") {
+			return uri, nil
+		}
+
+		segmentKey := path.Join(path.Dir(manifestKey), uri)
+		return a.s3Client.GenerateDownloadPublicUri(segmentKey, s3.S3VideoProcessedBucket, expirationSeconds)
+	})
+	if err != nil {
+		logger.Global().Error("unable to generate segment url", zap.String("key", manifestKey), zap.Error(err))
+		return "", err
+	}
+
+	data, err := mpd.Marshal()
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+// masterPlaylistVariants returns the variants to list in the master playlist of a video, lowest
+// bandwidth first, pointing to {quality}/playlist.m3u8 next to the master playlist. Variants
+// without a stored playlist, which failed or were removed, are left out. It reports false when
+// there is no variant to list or one of them was recorded without stream info.
+func masterPlaylistVariants(variants []*models.VideoVariant, qualities map[string]string) ([]ffmpeg.StreamVariant, bool) {
+	streamVariants := make([]ffmpeg.StreamVariant, 0, len(variants))
 	for _, variant := range variants {
-		if variant == nil {
+		if variant == nil || variant.Quality == QualityDefault {
 			continue
 		}
 
-		variantSegment, err := a.s3Client.GenerateDownloadPublicUri(variant.GetObjectKey(), s3.S3VideoUploadedBucket, s3.UrlExpirationSeconds)
-		if err != nil {
-			logger.Global().Error("unable to generate variant segment url", zap.Error(err))
-			return nil, grpc.InternalError(err)
+		if _, ok := qualities[variant.Quality]; !ok {
+			continue
 		}
 
-		if _, exists := variantPlaylistsMap[variant.Quality]; !exists {
-			variantPlaylistsMap[variant.Quality] = &proto.ServePlaylistVariant{}
+		if variant.GetBandwidth() <= 0 {
+			return nil, false
 		}
 
-		variantPlaylistsMap[variant.Quality].SegmentUrls = append(variantPlaylistsMap[variant.Quality].SegmentUrls, variantSegment)
+		streamVariants = append(streamVariants, ffmpeg.StreamVariant{
+			URI:              variant.Quality + "/" + ffmpeg.DefaultPlaylistName,
+			Bandwidth:        variant.GetBandwidth(),
+			AverageBandwidth: variant.GetAverageBandwidth(),
+			Resolution:       variant.GetResolution(),
+			FrameRate:        variant.GetFrameRate(),
+			Codecs:           variant.GetCodecs(),
+		})
+	}
+
+	slices.SortStableFunc(streamVariants, func(a, b ffmpeg.StreamVariant) int {
+		return a.Bandwidth - b.Bandwidth
+	})
+
+	return streamVariants, len(streamVariants) > 0
+}
+
+// rewriteMasterPlaylist points every variant of a stored master playlist to
+// {quality}/playlist.m3u8, next to the master playlist. Variants without a stored playlist are
+// dropped along with their stream info.
+func rewriteMasterPlaylist(content string, qualities map[string]string) string {
+	var lines []string
+	var streamInfo string
+
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case line == "":
+			continue
+		case strings.HasPrefix(line, "#EXT-X-STREAM-INF"):
+			streamInfo = line
+		case strings.HasPrefix(line, "#"):
+			lines = append(lines, line)
+		default:
+			quality := path.Dir(line)
+			if _, ok := qualities[quality]; ok && quality != QualityDefault {
+				if streamInfo != "" {
+					lines = append(lines, streamInfo)
+				}
+				lines = append(lines, quality+"/"+ffmpeg.DefaultPlaylistName)
+			}
+			streamInfo = ""
+		}
 	}
 
-	variantPlaylists := make([]*proto.ServePlaylistVariant, 0)
-	for _, val := range variantPlaylistsMap {
-		variantPlaylists = append(variantPlaylists, val)
+	return strings.Join(lines, "\n") + "\n"
+}
+
+// rewriteMediaPlaylist replaces every relative segment uri of a media playlist, including the
+// initialization segment of fMP4 segments, with the uri returned by sign. Keys of encrypted
+// segments are pointed to ContentKeyUrl.
+func rewriteMediaPlaylist(content string, sign func(uri string) (string, error)) (string, error) {
+	var lines []string
+
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case line == "":
+			continue
+		case strings.HasPrefix(line, "#EXT-X-MAP:"):
+			uri := ffmpeg.PlaylistAttribute(line, "URI")
+			if uri != "" && !strings.Contains(uri, "://") {
+				signed, err := sign(uri)
+				if err != nil {
+					return "", err
+				}
+				line = strings.Replace(line, `URI="`+uri+`"`, `URI="`+signed+`"`, 1)
+			}
+		case strings.HasPrefix(line, "#EXT-X-KEY:"):
+			if uri := ffmpeg.PlaylistAttribute(line, "URI"); uri != "" {
+				line = strings.Replace(line, `URI="`+uri+`"`, `URI="`+ContentKeyUrl+`"`, 1)
+			}
+		case !strings.HasPrefix(line, "#") && !strings.Contains(line, "://"):
+			signed, err := sign(line)
+			if err != nil {
+				return "", err
+			}
+			line = signed
+		}
+		lines = append(lines, line)
 	}
 
-	response := &proto.ServePlaylistResponse{
-		PlaylistUrl: masterPlaylist,
-		Variants:    variantPlaylists,
+	return strings.Join(lines, "\n") + "\n", nil
+}
+
+// mediaPlaylistDuration sums the segment durations of a media playlist, in seconds
+func mediaPlaylistDuration(content string) float64 {
+	var duration float64
+
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	for scanner.Scan() {
+		value, ok := strings.CutPrefix(strings.TrimSpace(scanner.Text()), "#EXTINF:")
+		if !ok {
+			continue
+		}
+
+		value, _, _ = strings.Cut(value, ",")
+		if seconds, err := strconv.ParseFloat(value, 64); err == nil {
+			duration += seconds
+		}
 	}
 
-	return connect.NewResponse(response), nil
+	return duration
 }
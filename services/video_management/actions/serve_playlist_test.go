@@ -15,446 +15,443 @@ import (
 	"github.com/sweetloveinyourheart/sweet-reel/services/video_management/models"
 )
 
-func (as *ActionsSuite) TestActions_ServePlaylist_Success() {
-	as.setupEnvironment()
+const (
+	testMasterPlaylist = `#EXTM3U
+#EXT-X-VERSION:3
+#EXT-X-STREAM-INF:BANDWIDTH=2928000,RESOLUTION=1280x720
+720p/playlist.m3u8
+#EXT-X-STREAM-INF:BANDWIDTH=5192000,RESOLUTION=1920x1080
+1080p/playlist.m3u8`
+
+	testVariantPlaylist = `#EXTM3U
+#EXT-X-VERSION:3
+#EXT-X-TARGETDURATION:4
+#EXT-X-MEDIA-SEQUENCE:0
+#EXTINF:4.000000,
+segment_000.ts
+#EXTINF:4.000000,
+segment_001.ts
+#EXTINF:2.500000,
+segment_002.ts
+#EXT-X-ENDLIST
+`
+)
 
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
+// testVariant returns a variant of the video recorded with stream info
+func testVariant(videoID uuid.UUID, quality string, width, height, bandwidth int) *models.VideoVariant {
+	averageBandwidth := bandwidth * 4 / 5
+	frameRate := 30.0
+	codecs := "avc1.64001f,mp4a.40.2"
+	return &models.VideoVariant{
+		ID:               uuid.Must(uuid.NewV7()),
+		VideoID:          videoID,
+		Quality:          quality,
+		ObjectKey:        videoID.String() + "/hls/" + quality + "/segment_002.ts",
+		Width:            &width,
+		Height:           &height,
+		Bandwidth:        &bandwidth,
+		AverageBandwidth: &averageBandwidth,
+		FrameRate:        &frameRate,
+		Codecs:           &codecs,
+		CreatedAt:        time.Now(),
+	}
+}
 
-	// Setup test data
-	videoID := uuid.Must(uuid.NewV7())
-	masterPlaylistKey := "master/playlist.m3u8"
-	variantPlaylistKey720p := "720p/playlist.m3u8"
-	variantPlaylistKey1080p := "1080p/playlist.m3u8"
-	segment720pKey := "720p/segment-0.ts"
-	segment1080pKey := "1080p/segment-0.ts"
-
-	masterPlaylistURL := "https://s3.example.com/master/playlist.m3u8"
-	variantPlaylistURL720p := "https://s3.example.com/720p/playlist.m3u8"
-	variantPlaylistURL1080p := "https://s3.example.com/1080p/playlist.m3u8"
-	segmentURL720p := "https://s3.example.com/720p/segment-0.ts"
-	segmentURL1080p := "https://s3.example.com/1080p/segment-0.ts"
-
-	// Setup mock expectations for manifests
-	manifests := []*models.VideoManifest{
+// testManifests returns the stored playlists of a video segmented in 720p only
+func testManifests(videoID uuid.UUID) []*models.VideoManifest {
+	return []*models.VideoManifest{
 		{
 			ID:        uuid.Must(uuid.NewV7()),
 			VideoID:   videoID,
-			ObjectKey: masterPlaylistKey,
+			ObjectKey: videoID.String() + "/hls/master.m3u8",
 			Quality:   ffmpeg.QualityDefault,
 			CreatedAt: time.Now(),
 		},
 		{
 			ID:        uuid.Must(uuid.NewV7()),
 			VideoID:   videoID,
-			ObjectKey: variantPlaylistKey720p,
+			ObjectKey: videoID.String() + "/hls/720p/playlist.m3u8",
 			Quality:   "720p",
 			CreatedAt: time.Now(),
 		},
-		{
-			ID:        uuid.Must(uuid.NewV7()),
-			VideoID:   videoID,
-			ObjectKey: variantPlaylistKey1080p,
-			Quality:   "1080p",
-			CreatedAt: time.Now(),
-		},
 	}
+}
 
-	as.mockVideoAggregateRepository.On("GetVideoManifestsByVideoID", ctx, videoID).Return(manifests, nil)
-
-	// Setup mock expectations for variants
-	variants := []*models.VideoVariant{
-		{
-			ID:        uuid.Must(uuid.NewV7()),
-			VideoID:   videoID,
-			Quality:   "720p",
-			ObjectKey: segment720pKey,
-			CreatedAt: time.Now(),
-		},
-		{
-			ID:        uuid.Must(uuid.NewV7()),
-			VideoID:   videoID,
-			Quality:   "1080p",
-			ObjectKey: segment1080pKey,
-			CreatedAt: time.Now(),
-		},
+// testCMAFManifests returns the stored manifests of a video packaged as CMAF in 720p only
+func testCMAFManifests(videoID uuid.UUID) []*models.VideoManifest {
+	manifests := testManifests(videoID)
+	for _, manifest := range manifests {
+		manifest.Format = models.ManifestFormatHLS
 	}
 
-	as.mockVideoAggregateRepository.On("GetVideoVariantsByVideoID", ctx, videoID).Return(variants, nil)
+	return append(manifests, &models.VideoManifest{
+		ID:        uuid.Must(uuid.NewV7()),
+		VideoID:   videoID,
+		ObjectKey: videoID.String() + "/hls/manifest.mpd",
+		Quality:   ffmpeg.QualityDefault,
+		Format:    models.ManifestFormatDASH,
+		CreatedAt: time.Now(),
+	})
+}
+
+func (as *ActionsSuite) TestActions_ServePlaylist_MasterPlaylist() {
+	as.setupEnvironment()
 
-	// Setup S3 mock expectations for master playlist
-	as.mockS3.On("GenerateDownloadPublicUri", masterPlaylistKey, s3.S3VideoUploadedBucket, uint32(s3.UrlExpirationSeconds)).
-		Return(masterPlaylistURL, nil)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
 
-	// Setup S3 mock expectations for variant playlists
-	as.mockS3.On("GenerateDownloadPublicUri", variantPlaylistKey720p, s3.S3VideoUploadedBucket, uint32(s3.UrlExpirationSeconds)).
-		Return(variantPlaylistURL720p, nil)
-	as.mockS3.On("GenerateDownloadPublicUri", variantPlaylistKey1080p, s3.S3VideoUploadedBucket, uint32(s3.UrlExpirationSeconds)).
-		Return(variantPlaylistURL1080p, nil)
+	videoID := uuid.Must(uuid.NewV7())
+	as.mockVideoAggregateRepository.On("GetVideoByID", ctx, videoID).Return(publicVideo(videoID), nil)
+	as.mockVideoAggregateRepository.On("GetVideoManifestsByVideoID", ctx, videoID).Return(testManifests(videoID), nil)
 
-	// Setup S3 mock expectations for segments
-	as.mockS3.On("GenerateDownloadPublicUri", segment720pKey, s3.S3VideoUploadedBucket, uint32(s3.UrlExpirationSeconds)).
-		Return(segmentURL720p, nil)
-	as.mockS3.On("GenerateDownloadPublicUri", segment1080pKey, s3.S3VideoUploadedBucket, uint32(s3.UrlExpirationSeconds)).
-		Return(segmentURL1080p, nil)
+	// The 1080p rendition has no stored playlist, it was removed
+	variants := []*models.VideoVariant{
+		testVariant(videoID, "1080p", 1920, 1080, 5800000),
+		testVariant(videoID, "720p", 1280, 720, 3100000),
+	}
+	as.mockVideoAggregateRepository.On("GetVideoVariantsByVideoID", ctx, videoID).Return(variants, nil)
 
-	// Setup request
 	request := &connect.Request[proto.ServePlaylistRequest]{
 		Msg: &proto.ServePlaylistRequest{
 			VideoId: videoID.String(),
 		},
 	}
 
-	// Execute
 	actionsInstance := actions.NewActions(ctx, "test-token")
 	response, err := actionsInstance.ServePlaylist(ctx, request)
 
-	// Assertions
 	as.NoError(err)
-	as.NotNil(response)
-	as.Equal(masterPlaylistURL, response.Msg.PlaylistUrl)
-	as.Len(response.Msg.Variants, 2)
-
-	// Verify variants contain correct data
-	variantsMap := make(map[string]*proto.ServePlaylistVariant)
-	for _, variant := range response.Msg.Variants {
-		variantsMap[variant.Quality] = variant
-	}
-
-	// Check 720p variant
-	as.Contains(variantsMap, "720p")
-	as.Equal(variantPlaylistURL720p, variantsMap["720p"].PlaylistUrl)
-	as.Equal("720p", variantsMap["720p"].Quality)
-	as.Contains(variantsMap["720p"].SegmentUrls, segmentURL720p)
-
-	// Check 1080p variant
-	as.Contains(variantsMap, "1080p")
-	as.Equal(variantPlaylistURL1080p, variantsMap["1080p"].PlaylistUrl)
-	as.Equal("1080p", variantsMap["1080p"].Quality)
-	as.Contains(variantsMap["1080p"].SegmentUrls, segmentURL1080p)
-
-	// Verify all mocks were called as expected
+	as.Equal(`#EXTM3U
+#EXT-X-VERSION:3
+#EXT-X-STREAM-INF:BANDWIDTH=3100000,AVERAGE-BANDWIDTH=2480000,RESOLUTION=1280x720,FRAME-RATE=30.000,CODECS="avc1.64001f,mp4a.40.2"
+720p/playlist.m3u8
+`, response.Msg.GetPlaylist())
+	as.Equal(int32(actions.PlaylistMaxAgeSeconds), response.Msg.GetMaxAgeSeconds())
+
+	// Nothing is read from storage
 	as.mockVideoAggregateRepository.AssertExpectations(as.T())
-	as.mockS3.AssertExpectations(as.T())
+	as.mockS3.AssertNotCalled(as.T(), "Download")
 }
 
-func (as *ActionsSuite) TestActions_ServePlaylist_InvalidVideoID() {
+func (as *ActionsSuite) TestActions_ServePlaylist_StoredMasterPlaylist() {
 	as.setupEnvironment()
 
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	// Setup request with invalid video ID
+	videoID := uuid.Must(uuid.NewV7())
+	as.mockVideoAggregateRepository.On("GetVideoByID", ctx, videoID).Return(publicVideo(videoID), nil)
+	as.mockVideoAggregateRepository.On("GetVideoManifestsByVideoID", ctx, videoID).Return(testManifests(videoID), nil)
+
+	// Recorded before stream info was published
+	variants := []*models.VideoVariant{
+		{ID: uuid.Must(uuid.NewV7()), VideoID: videoID, Quality: "720p", ObjectKey: videoID.String() + "/hls/720p/segment_002.ts"},
+	}
+	as.mockVideoAggregateRepository.On("GetVideoVariantsByVideoID", ctx, videoID).Return(variants, nil)
+	as.mockS3.On("Download", videoID.String()+"/hls/master.m3u8", s3.S3VideoProcessedBucket).Return([]byte(testMasterPlaylist), nil)
+
 	request := &connect.Request[proto.ServePlaylistRequest]{
 		Msg: &proto.ServePlaylistRequest{
-			VideoId: "invalid-uuid",
+			VideoId: videoID.String(),
 		},
 	}
 
-	// Execute
 	actionsInstance := actions.NewActions(ctx, "test-token")
 	response, err := actionsInstance.ServePlaylist(ctx, request)
 
-	// Assertions
-	as.Error(err)
-	as.Nil(response)
-
-	// Verify error is invalid argument
-	var connectErr *connect.Error
-	as.True(errors.As(err, &connectErr))
-	as.Equal(connect.CodeInvalidArgument, connectErr.Code())
+	// Variants point next to the master playlist, the 1080p one has no stored playlist
+	as.NoError(err)
+	as.Equal(`#EXTM3U
+#EXT-X-VERSION:3
+#EXT-X-STREAM-INF:BANDWIDTH=2928000,RESOLUTION=1280x720
+720p/playlist.m3u8
+`, response.Msg.GetPlaylist())
+	as.Equal(int32(actions.PlaylistMaxAgeSeconds), response.Msg.GetMaxAgeSeconds())
 
-	// Verify no repository or S3 calls were made
-	as.mockVideoAggregateRepository.AssertNotCalled(as.T(), "GetVideoManifestsByVideoID")
-	as.mockVideoAggregateRepository.AssertNotCalled(as.T(), "GetVideoVariantsByVideoID")
+	as.mockVideoAggregateRepository.AssertExpectations(as.T())
+	as.mockS3.AssertExpectations(as.T())
 	as.mockS3.AssertNotCalled(as.T(), "GenerateDownloadPublicUri")
 }
 
-func (as *ActionsSuite) TestActions_ServePlaylist_EmptyVideoID() {
+func (as *ActionsSuite) TestActions_ServePlaylist_VariantPlaylist() {
 	as.setupEnvironment()
 
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	// Setup request with empty video ID
+	videoID := uuid.Must(uuid.NewV7())
+	as.mockVideoAggregateRepository.On("GetVideoByID", ctx, videoID).Return(publicVideo(videoID), nil)
+	as.mockVideoAggregateRepository.On("GetVideoManifestsByVideoID", ctx, videoID).Return(testManifests(videoID), nil)
+	as.mockS3.On("Download", videoID.String()+"/hls/720p/playlist.m3u8", s3.S3VideoProcessedBucket).Return([]byte(testVariantPlaylist), nil)
+
+	// Segment urls stay valid for the 10.5 seconds of video plus the grace period
+	expirationSeconds := uint32(11 + actions.SegmentUrlGraceSeconds)
+	for _, segment := range []string{"segment_000.ts", "segment_001.ts", "segment_002.ts"} {
+		as.mockS3.On("GenerateDownloadPublicUri", videoID.String()+"/hls/720p/"+segment, s3.S3VideoProcessedBucket, expirationSeconds).
+			Return("https://s3.example.com/"+segment+"?signature=abc", nil)
+	}
+
 	request := &connect.Request[proto.ServePlaylistRequest]{
 		Msg: &proto.ServePlaylistRequest{
-			VideoId: "",
+			VideoId: videoID.String(),
+			Quality: "720p",
 		},
 	}
 
-	// Execute
 	actionsInstance := actions.NewActions(ctx, "test-token")
 	response, err := actionsInstance.ServePlaylist(ctx, request)
 
-	// Assertions
-	as.Error(err)
-	as.Nil(response)
-
-	// Verify error is invalid argument
-	var connectErr *connect.Error
-	as.True(errors.As(err, &connectErr))
-	as.Equal(connect.CodeInvalidArgument, connectErr.Code())
+	as.NoError(err)
+	as.Equal(`#EXTM3U
+#EXT-X-VERSION:3
+#EXT-X-TARGETDURATION:4
+#EXT-X-MEDIA-SEQUENCE:0
+#EXTINF:4.000000,
+https://s3.example.com/segment_000.ts?signature=abc
+#EXTINF:4.000000,
+https://s3.example.com/segment_001.ts?signature=abc
+#EXTINF:2.500000,
+https://s3.example.com/segment_002.ts?signature=abc
+#EXT-X-ENDLIST
+`, response.Msg.GetPlaylist())
+	as.Less(response.Msg.GetMaxAgeSeconds(), int32(expirationSeconds))
 
-	// Verify no repository or S3 calls were made
-	as.mockVideoAggregateRepository.AssertNotCalled(as.T(), "GetVideoManifestsByVideoID")
-	as.mockVideoAggregateRepository.AssertNotCalled(as.T(), "GetVideoVariantsByVideoID")
-	as.mockS3.AssertNotCalled(as.T(), "GenerateDownloadPublicUri")
+	as.mockVideoAggregateRepository.AssertExpectations(as.T())
+	as.mockS3.AssertExpectations(as.T())
 }
 
-func (as *ActionsSuite) TestActions_ServePlaylist_GetManifestsError() {
+func (as *ActionsSuite) TestActions_ServePlaylist_FMP4VariantPlaylist() {
 	as.setupEnvironment()
 
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	// Setup test data
 	videoID := uuid.Must(uuid.NewV7())
-	dbError := errors.New("database connection failed")
-
-	// Setup mock expectations - database fails
-	as.mockVideoAggregateRepository.On("GetVideoManifestsByVideoID", ctx, videoID).Return(nil, dbError)
+	as.mockVideoAggregateRepository.On("GetVideoByID", ctx, videoID).Return(publicVideo(videoID), nil)
+	as.mockVideoAggregateRepository.On("GetVideoManifestsByVideoID", ctx, videoID).Return(testManifests(videoID), nil)
+	as.mockS3.On("Download", videoID.String()+"/hls/720p/playlist.m3u8", s3.S3VideoProcessedBucket).Return([]byte(`#EXTM3U
+#EXT-X-VERSION:7
+#EXT-X-TARGETDURATION:6
+#EXT-X-MAP:URI="init.mp4"
+#EXTINF:6.000000,
+segment_000.m4s
+#EXT-X-ENDLIST
+`), nil)
+
+	// The initialization segment is signed like the media segments
+	expirationSeconds := uint32(6 + actions.SegmentUrlGraceSeconds)
+	for _, segment := range []string{"init.mp4", "segment_000.m4s"} {
+		as.mockS3.On("GenerateDownloadPublicUri", videoID.String()+"/hls/720p/"+segment, s3.S3VideoProcessedBucket, expirationSeconds).
+			Return("https://s3.example.com/"+segment+"?signature=abc", nil)
+	}
 
-	// Setup request
 	request := &connect.Request[proto.ServePlaylistRequest]{
 		Msg: &proto.ServePlaylistRequest{
 			VideoId: videoID.String(),
+			Quality: "720p",
 		},
 	}
 
-	// Execute
 	actionsInstance := actions.NewActions(ctx, "test-token")
 	response, err := actionsInstance.ServePlaylist(ctx, request)
 
-	// Assertions
-	as.Error(err)
-	as.Nil(response)
-
-	// Verify error is internal error
-	var connectErr *connect.Error
-	as.True(errors.As(err, &connectErr))
-	as.Equal(connect.CodeInternal, connectErr.Code())
+	as.NoError(err)
+	as.Equal(`#EXTM3U
+#EXT-X-VERSION:7
+#EXT-X-TARGETDURATION:6
+#EXT-X-MAP:URI="https://s3.example.com/init.mp4?signature=abc"
+#EXTINF:6.000000,
+https://s3.example.com/segment_000.m4s?signature=abc
+#EXT-X-ENDLIST
+`, response.Msg.GetPlaylist())
 
-	// Verify manifests call was made but variants was not
-	as.mockVideoAggregateRepository.AssertExpectations(as.T())
-	as.mockVideoAggregateRepository.AssertNotCalled(as.T(), "GetVideoVariantsByVideoID")
-	as.mockS3.AssertNotCalled(as.T(), "GenerateDownloadPublicUri")
+	as.mockS3.AssertExpectations(as.T())
 }
 
-func (as *ActionsSuite) TestActions_ServePlaylist_GetVariantsError() {
+func (as *ActionsSuite) TestActions_ServePlaylist_EncryptedVariantPlaylist() {
 	as.setupEnvironment()
 
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	// Setup test data
 	videoID := uuid.Must(uuid.NewV7())
-	masterPlaylistKey := "master/playlist.m3u8"
-	masterPlaylistURL := "https://s3.example.com/master/playlist.m3u8"
-	dbError := errors.New("database connection failed")
+	as.mockVideoAggregateRepository.On("GetVideoByID", ctx, videoID).Return(publicVideo(videoID), nil)
+	as.mockVideoAggregateRepository.On("GetVideoManifestsByVideoID", ctx, videoID).Return(testManifests(videoID), nil)
+	as.mockS3.On("Download", videoID.String()+"/hls/720p/playlist.m3u8", s3.S3VideoProcessedBucket).Return([]byte(`#EXTM3U
+#EXT-X-VERSION:3
+#EXT-X-TARGETDURATION:6
+#EXT-X-KEY:METHOD=AES-128,URI="../../content.key",IV=0x000102030405060708090a0b0c0d0e0f
+#EXTINF:6.000000,
+segment_000.ts
+#EXT-X-ENDLIST
+`), nil)
+	as.mockS3.On("GenerateDownloadPublicUri", videoID.String()+"/hls/720p/segment_000.ts", s3.S3VideoProcessedBucket, uint32(6+actions.SegmentUrlGraceSeconds)).
+		Return("https://s3.example.com/segment_000.ts?signature=abc", nil)
 
-	// Setup mock expectations for manifests
-	manifests := []*models.VideoManifest{
-		{
-			ID:        uuid.Must(uuid.NewV7()),
-			VideoID:   videoID,
-			ObjectKey: masterPlaylistKey,
-			Quality:   ffmpeg.QualityDefault,
-			CreatedAt: time.Now(),
+	request := &connect.Request[proto.ServePlaylistRequest]{
+		Msg: &proto.ServePlaylistRequest{
+			VideoId: videoID.String(),
+			Quality: "720p",
 		},
 	}
 
-	as.mockVideoAggregateRepository.On("GetVideoManifestsByVideoID", ctx, videoID).Return(manifests, nil)
+	actionsInstance := actions.NewActions(ctx, "test-token")
+	response, err := actionsInstance.ServePlaylist(ctx, request)
+
+	// The key is fetched from the gateway, never from storage
+	as.NoError(err)
+	as.Equal(`#EXTM3U
+#EXT-X-VERSION:3
+#EXT-X-TARGETDURATION:6
+#EXT-X-KEY:METHOD=AES-128,URI="`+actions.ContentKeyUrl+`",IV=0x000102030405060708090a0b0c0d0e0f
+#EXTINF:6.000000,
+https://s3.example.com/segment_000.ts?signature=abc
+#EXT-X-ENDLIST
+`, response.Msg.GetPlaylist())
+
+	as.mockS3.AssertExpectations(as.T())
+}
+
+func (as *ActionsSuite) TestActions_ServePlaylist_MasterPlaylistWithDASHManifest() {
+	as.setupEnvironment()
 
-	// Setup S3 mock for master playlist
-	as.mockS3.On("GenerateDownloadPublicUri", masterPlaylistKey, s3.S3VideoUploadedBucket, uint32(s3.UrlExpirationSeconds)).
-		Return(masterPlaylistURL, nil)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
 
-	// Setup mock expectations for variants - database fails
-	as.mockVideoAggregateRepository.On("GetVideoVariantsByVideoID", ctx, videoID).Return(nil, dbError)
+	videoID := uuid.Must(uuid.NewV7())
+	as.mockVideoAggregateRepository.On("GetVideoByID", ctx, videoID).Return(publicVideo(videoID), nil)
+	as.mockVideoAggregateRepository.On("GetVideoManifestsByVideoID", ctx, videoID).Return(testCMAFManifests(videoID), nil)
+	as.mockVideoAggregateRepository.On("GetVideoVariantsByVideoID", ctx, videoID).Return([]*models.VideoVariant{
+		testVariant(videoID, "720p", 1280, 720, 3100000),
+	}, nil)
 
-	// Setup request
 	request := &connect.Request[proto.ServePlaylistRequest]{
 		Msg: &proto.ServePlaylistRequest{
 			VideoId: videoID.String(),
 		},
 	}
 
-	// Execute
 	actionsInstance := actions.NewActions(ctx, "test-token")
 	response, err := actionsInstance.ServePlaylist(ctx, request)
 
-	// Assertions
-	as.Error(err)
-	as.Nil(response)
-
-	// Verify error is internal error
-	var connectErr *connect.Error
-	as.True(errors.As(err, &connectErr))
-	as.Equal(connect.CodeInternal, connectErr.Code())
-
-	// Verify all repository calls were made
-	as.mockVideoAggregateRepository.AssertExpectations(as.T())
-	as.mockS3.AssertExpectations(as.T())
+	// The DASH manifest is left out of the master playlist, players are pointed to it aside
+	as.NoError(err)
+	as.Contains(response.Msg.GetPlaylist(), "720p/playlist.m3u8")
+	as.NotContains(response.Msg.GetPlaylist(), ffmpeg.DefaultDASHManifestName)
+	as.Equal(ffmpeg.DefaultDASHManifestName, response.Msg.GetDashManifestUrl())
 }
 
-func (as *ActionsSuite) TestActions_ServePlaylist_S3GenerateMasterPlaylistError() {
+func (as *ActionsSuite) TestActions_ServePlaylist_DASHManifest() {
 	as.setupEnvironment()
 
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	// Setup test data
 	videoID := uuid.Must(uuid.NewV7())
-	masterPlaylistKey := "master/playlist.m3u8"
-	s3Error := errors.New("S3 service unavailable")
+	as.mockVideoAggregateRepository.On("GetVideoByID", ctx, videoID).Return(publicVideo(videoID), nil)
+	as.mockVideoAggregateRepository.On("GetVideoManifestsByVideoID", ctx, videoID).Return(testCMAFManifests(videoID), nil)
 
-	// Setup mock expectations for manifests
-	manifests := []*models.VideoManifest{
+	manifest, err := ffmpeg.NewDASHManifest(8.5, []ffmpeg.DASHRepresentation{
 		{
-			ID:        uuid.Must(uuid.NewV7()),
-			VideoID:   videoID,
-			ObjectKey: masterPlaylistKey,
-			Quality:   ffmpeg.QualityDefault,
-			CreatedAt: time.Now(),
+			ID:          "720p",
+			Bandwidth:   3100000,
+			InitSegment: "720p/init.mp4",
+			Segments:    []ffmpeg.MediaSegment{{URI: "720p/segment_000.m4s", Duration: 6}, {URI: "720p/segment_001.m4s", Duration: 2.5}},
 		},
-	}
-
-	as.mockVideoAggregateRepository.On("GetVideoManifestsByVideoID", ctx, videoID).Return(manifests, nil)
+	}).Marshal()
+	as.NoError(err)
+	as.mockS3.On("Download", videoID.String()+"/hls/manifest.mpd", s3.S3VideoProcessedBucket).Return(manifest, nil)
 
-	// Setup S3 mock for master playlist - fails
-	as.mockS3.On("GenerateDownloadPublicUri", masterPlaylistKey, s3.S3VideoUploadedBucket, uint32(s3.UrlExpirationSeconds)).
-		Return("", s3Error)
+	// Segment urls stay valid for the 8.5 seconds of video plus the grace period
+	expirationSeconds := uint32(9 + actions.SegmentUrlGraceSeconds)
+	for _, segment := range []string{"init.mp4", "segment_000.m4s", "segment_001.m4s"} {
+		as.mockS3.On("GenerateDownloadPublicUri", videoID.String()+"/hls/720p/"+segment, s3.S3VideoProcessedBucket, expirationSeconds).
+			Return("https://s3.example.com/"+segment+"?signature=abc", nil)
+	}
 
-	// Setup request
 	request := &connect.Request[proto.ServePlaylistRequest]{
 		Msg: &proto.ServePlaylistRequest{
 			VideoId: videoID.String(),
+			Format:  "dash",
 		},
 	}
 
-	// Execute
 	actionsInstance := actions.NewActions(ctx, "test-token")
 	response, err := actionsInstance.ServePlaylist(ctx, request)
 
-	// Assertions
-	as.Error(err)
-	as.Nil(response)
+	as.NoError(err)
+	served, err := ffmpeg.ParseMPD([]byte(response.Msg.GetPlaylist()))
+	as.NoError(err)
 
-	// Verify error is internal error
-	var connectErr *connect.Error
-	as.True(errors.As(err, &connectErr))
-	as.Equal(connect.CodeInternal, connectErr.Code())
+	segmentList := served.Periods[0].AdaptationSets[0].Representations[0].SegmentList
+	as.Equal("https://s3.example.com/init.mp4?signature=abc", segmentList.Initialization.SourceURL)
+	as.Equal("https://s3.example.com/segment_000.m4s?signature=abc", segmentList.SegmentURLs[0].Media)
+	as.Equal("https://s3.example.com/segment_001.m4s?signature=abc", segmentList.SegmentURLs[1].Media)
+	as.Equal(int32(actions.PlaylistMaxAgeSeconds), response.Msg.GetMaxAgeSeconds())
 
-	// Verify manifests and S3 calls were made
-	as.mockVideoAggregateRepository.AssertExpectations(as.T())
 	as.mockS3.AssertExpectations(as.T())
+	as.mockVideoAggregateRepository.AssertNotCalled(as.T(), "GetVideoVariantsByVideoID")
 }
 
-func (as *ActionsSuite) TestActions_ServePlaylist_S3GenerateVariantPlaylistError() {
+func (as *ActionsSuite) TestActions_ServePlaylist_DASHManifestNotFound() {
 	as.setupEnvironment()
 
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	// Setup test data
+	// Packaged for HLS only
 	videoID := uuid.Must(uuid.NewV7())
-	variantPlaylistKey := "720p/playlist.m3u8"
-	s3Error := errors.New("S3 service unavailable")
-
-	// Setup mock expectations for manifests
-	manifests := []*models.VideoManifest{
-		{
-			ID:        uuid.Must(uuid.NewV7()),
-			VideoID:   videoID,
-			ObjectKey: variantPlaylistKey,
-			Quality:   "720p",
-			CreatedAt: time.Now(),
-		},
-	}
-
-	as.mockVideoAggregateRepository.On("GetVideoManifestsByVideoID", ctx, videoID).Return(manifests, nil)
-
-	// Setup S3 mock for variant playlist - fails
-	as.mockS3.On("GenerateDownloadPublicUri", variantPlaylistKey, s3.S3VideoUploadedBucket, uint32(s3.UrlExpirationSeconds)).
-		Return("", s3Error)
+	as.mockVideoAggregateRepository.On("GetVideoByID", ctx, videoID).Return(publicVideo(videoID), nil)
+	as.mockVideoAggregateRepository.On("GetVideoManifestsByVideoID", ctx, videoID).Return(testManifests(videoID), nil)
 
-	// Setup request
 	request := &connect.Request[proto.ServePlaylistRequest]{
 		Msg: &proto.ServePlaylistRequest{
 			VideoId: videoID.String(),
+			Format:  "dash",
 		},
 	}
 
-	// Execute
 	actionsInstance := actions.NewActions(ctx, "test-token")
 	response, err := actionsInstance.ServePlaylist(ctx, request)
 
-	// Assertions
 	as.Error(err)
 	as.Nil(response)
-
-	// Verify error is internal error
-	var connectErr *connect.Error
-	as.True(errors.As(err, &connectErr))
-	as.Equal(connect.CodeInternal, connectErr.Code())
-
-	// Verify manifests and S3 calls were made
-	as.mockVideoAggregateRepository.AssertExpectations(as.T())
-	as.mockS3.AssertExpectations(as.T())
+	as.Equal(connect.CodeNotFound, connect.CodeOf(err))
+	as.mockS3.AssertNotCalled(as.T(), "Download")
 }
 
-func (as *ActionsSuite) TestActions_ServePlaylist_S3GenerateVariantSegmentError() {
+func (as *ActionsSuite) TestActions_ServePlaylist_UnsupportedFormat() {
 	as.setupEnvironment()
 
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	// Setup test data
-	videoID := uuid.Must(uuid.NewV7())
-	variantPlaylistKey := "720p/playlist.m3u8"
-	segmentKey := "720p/segment-0.ts"
-	variantPlaylistURL := "https://s3.example.com/720p/playlist.m3u8"
-	s3Error := errors.New("S3 service unavailable")
-
-	// Setup mock expectations for manifests
-	manifests := []*models.VideoManifest{
-		{
-			ID:        uuid.Must(uuid.NewV7()),
-			VideoID:   videoID,
-			ObjectKey: variantPlaylistKey,
-			Quality:   "720p",
-			CreatedAt: time.Now(),
+	request := &connect.Request[proto.ServePlaylistRequest]{
+		Msg: &proto.ServePlaylistRequest{
+			VideoId: uuid.Must(uuid.NewV7()).String(),
+			Format:  "smooth",
 		},
 	}
 
-	as.mockVideoAggregateRepository.On("GetVideoManifestsByVideoID", ctx, videoID).Return(manifests, nil)
-
-	// Setup S3 mock for variant playlist - succeeds
-	as.mockS3.On("GenerateDownloadPublicUri", variantPlaylistKey, s3.S3VideoUploadedBucket, uint32(s3.UrlExpirationSeconds)).
-		Return(variantPlaylistURL, nil)
+	actionsInstance := actions.NewActions(ctx, "test-token")
+	response, err := actionsInstance.ServePlaylist(ctx, request)
 
-	// Setup mock expectations for variants
-	variants := []*models.VideoVariant{
-		{
-			ID:        uuid.Must(uuid.NewV7()),
-			VideoID:   videoID,
-			Quality:   "720p",
-			ObjectKey: segmentKey,
-			CreatedAt: time.Now(),
-		},
-	}
+	as.Error(err)
+	as.Nil(response)
+	as.Equal(connect.CodeInvalidArgument, connect.CodeOf(err))
+	as.mockVideoAggregateRepository.AssertNotCalled(as.T(), "GetVideoByID")
+}
 
-	as.mockVideoAggregateRepository.On("GetVideoVariantsByVideoID", ctx, videoID).Return(variants, nil)
+func (as *ActionsSuite) TestActions_ServePlaylist_InvalidVideoID() {
+	as.setupEnvironment()
 
-	// Setup S3 mock for segment - fails
-	as.mockS3.On("GenerateDownloadPublicUri", segmentKey, s3.S3VideoUploadedBucket, uint32(s3.UrlExpirationSeconds)).
-		Return("", s3Error)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
 
-	// Setup request
+	// Setup request with invalid video ID
 	request := &connect.Request[proto.ServePlaylistRequest]{
 		Msg: &proto.ServePlaylistRequest{
-			VideoId: videoID.String(),
+			VideoId: "invalid-uuid",
 		},
 	}
 
@@ -466,51 +463,26 @@ func (as *ActionsSuite) TestActions_ServePlaylist_S3GenerateVariantSegmentError(
 	as.Error(err)
 	as.Nil(response)
 
-	// Verify error is internal error
+	// Verify error is invalid argument
 	var connectErr *connect.Error
 	as.True(errors.As(err, &connectErr))
-	as.Equal(connect.CodeInternal, connectErr.Code())
+	as.Equal(connect.CodeInvalidArgument, connectErr.Code())
 
-	// Verify all repository and S3 calls were made
-	as.mockVideoAggregateRepository.AssertExpectations(as.T())
-	as.mockS3.AssertExpectations(as.T())
+	// Verify no repository or S3 calls were made
+	as.mockVideoAggregateRepository.AssertNotCalled(as.T(), "GetVideoManifestsByVideoID")
+	as.mockS3.AssertNotCalled(as.T(), "Download")
 }
 
-func (as *ActionsSuite) TestActions_ServePlaylist_OnlyMasterPlaylist() {
+func (as *ActionsSuite) TestActions_ServePlaylist_EmptyVideoID() {
 	as.setupEnvironment()
 
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	// Setup test data - only master playlist, no variants
-	videoID := uuid.Must(uuid.NewV7())
-	masterPlaylistKey := "master/playlist.m3u8"
-	masterPlaylistURL := "https://s3.example.com/master/playlist.m3u8"
-
-	// Setup mock expectations for manifests
-	manifests := []*models.VideoManifest{
-		{
-			ID:        uuid.Must(uuid.NewV7()),
-			VideoID:   videoID,
-			ObjectKey: masterPlaylistKey,
-			Quality:   ffmpeg.QualityDefault,
-			CreatedAt: time.Now(),
-		},
-	}
-
-	as.mockVideoAggregateRepository.On("GetVideoManifestsByVideoID", ctx, videoID).Return(manifests, nil)
-
-	// Setup S3 mock for master playlist
-	as.mockS3.On("GenerateDownloadPublicUri", masterPlaylistKey, s3.S3VideoUploadedBucket, uint32(s3.UrlExpirationSeconds)).
-		Return(masterPlaylistURL, nil)
-
-	// Setup mock expectations for variants - empty
-	as.mockVideoAggregateRepository.On("GetVideoVariantsByVideoID", ctx, videoID).Return([]*models.VideoVariant{}, nil)
-
-	// Setup request
+	// Setup request with empty video ID
 	request := &connect.Request[proto.ServePlaylistRequest]{
 		Msg: &proto.ServePlaylistRequest{
-			VideoId: videoID.String(),
+			VideoId: "",
 		},
 	}
 
@@ -519,292 +491,223 @@ func (as *ActionsSuite) TestActions_ServePlaylist_OnlyMasterPlaylist() {
 	response, err := actionsInstance.ServePlaylist(ctx, request)
 
 	// Assertions
-	as.NoError(err)
-	as.NotNil(response)
-	as.Equal(masterPlaylistURL, response.Msg.PlaylistUrl)
-	as.Len(response.Msg.Variants, 0)
+	as.Error(err)
+	as.Nil(response)
 
-	// Verify all mocks were called as expected
-	as.mockVideoAggregateRepository.AssertExpectations(as.T())
-	as.mockS3.AssertExpectations(as.T())
+	// Verify error is invalid argument
+	var connectErr *connect.Error
+	as.True(errors.As(err, &connectErr))
+	as.Equal(connect.CodeInvalidArgument, connectErr.Code())
+
+	// Verify no repository or S3 calls were made
+	as.mockVideoAggregateRepository.AssertNotCalled(as.T(), "GetVideoManifestsByVideoID")
+	as.mockS3.AssertNotCalled(as.T(), "Download")
 }
 
-func (as *ActionsSuite) TestActions_ServePlaylist_NoMasterPlaylist() {
+func (as *ActionsSuite) TestActions_ServePlaylist_GetManifestsError() {
 	as.setupEnvironment()
 
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	// Setup test data - only variant playlists, no master
 	videoID := uuid.Must(uuid.NewV7())
-	variantPlaylistKey := "720p/playlist.m3u8"
-	segmentKey := "720p/segment-0.ts"
-	variantPlaylistURL := "https://s3.example.com/720p/playlist.m3u8"
-	segmentURL := "https://s3.example.com/720p/segment-0.ts"
+	as.mockVideoAggregateRepository.On("GetVideoByID", ctx, videoID).Return(publicVideo(videoID), nil)
+	as.mockVideoAggregateRepository.On("GetVideoManifestsByVideoID", ctx, videoID).Return(nil, errors.New("database connection failed"))
 
-	// Setup mock expectations for manifests - no master
-	manifests := []*models.VideoManifest{
-		{
-			ID:        uuid.Must(uuid.NewV7()),
-			VideoID:   videoID,
-			ObjectKey: variantPlaylistKey,
-			Quality:   "720p",
-			CreatedAt: time.Now(),
+	request := &connect.Request[proto.ServePlaylistRequest]{
+		Msg: &proto.ServePlaylistRequest{
+			VideoId: videoID.String(),
 		},
 	}
 
-	as.mockVideoAggregateRepository.On("GetVideoManifestsByVideoID", ctx, videoID).Return(manifests, nil)
+	actionsInstance := actions.NewActions(ctx, "test-token")
+	response, err := actionsInstance.ServePlaylist(ctx, request)
 
-	// Setup S3 mock for variant playlist
-	as.mockS3.On("GenerateDownloadPublicUri", variantPlaylistKey, s3.S3VideoUploadedBucket, uint32(s3.UrlExpirationSeconds)).
-		Return(variantPlaylistURL, nil)
+	as.Error(err)
+	as.Nil(response)
+	as.Equal(connect.CodeInternal, connect.CodeOf(err))
+	as.mockS3.AssertNotCalled(as.T(), "Download")
+}
 
-	// Setup mock expectations for variants
-	variants := []*models.VideoVariant{
-		{
-			ID:        uuid.Must(uuid.NewV7()),
-			VideoID:   videoID,
-			Quality:   "720p",
-			ObjectKey: segmentKey,
-			CreatedAt: time.Now(),
-		},
-	}
+func (as *ActionsSuite) TestActions_ServePlaylist_GetVariantsError() {
+	as.setupEnvironment()
 
-	as.mockVideoAggregateRepository.On("GetVideoVariantsByVideoID", ctx, videoID).Return(variants, nil)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
 
-	// Setup S3 mock for segment
-	as.mockS3.On("GenerateDownloadPublicUri", segmentKey, s3.S3VideoUploadedBucket, uint32(s3.UrlExpirationSeconds)).
-		Return(segmentURL, nil)
+	videoID := uuid.Must(uuid.NewV7())
+	as.mockVideoAggregateRepository.On("GetVideoByID", ctx, videoID).Return(publicVideo(videoID), nil)
+	as.mockVideoAggregateRepository.On("GetVideoManifestsByVideoID", ctx, videoID).Return(testManifests(videoID), nil)
+	as.mockVideoAggregateRepository.On("GetVideoVariantsByVideoID", ctx, videoID).Return(nil, errors.New("database connection failed"))
 
-	// Setup request
 	request := &connect.Request[proto.ServePlaylistRequest]{
 		Msg: &proto.ServePlaylistRequest{
 			VideoId: videoID.String(),
 		},
 	}
 
-	// Execute
 	actionsInstance := actions.NewActions(ctx, "test-token")
 	response, err := actionsInstance.ServePlaylist(ctx, request)
 
-	// Assertions
-	as.NoError(err)
-	as.NotNil(response)
-	as.Empty(response.Msg.PlaylistUrl) // No master playlist
-	as.Len(response.Msg.Variants, 1)
-	as.Equal("720p", response.Msg.Variants[0].Quality)
-	as.Equal(variantPlaylistURL, response.Msg.Variants[0].PlaylistUrl)
-	as.Contains(response.Msg.Variants[0].SegmentUrls, segmentURL)
-
-	// Verify all mocks were called as expected
-	as.mockVideoAggregateRepository.AssertExpectations(as.T())
-	as.mockS3.AssertExpectations(as.T())
+	as.Error(err)
+	as.Nil(response)
+	as.Equal(connect.CodeInternal, connect.CodeOf(err))
+	as.mockS3.AssertNotCalled(as.T(), "Download")
 }
 
-func (as *ActionsSuite) TestActions_ServePlaylist_MultipleSegmentsPerQuality() {
+func (as *ActionsSuite) TestActions_ServePlaylist_QualityNotFound() {
 	as.setupEnvironment()
 
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	// Setup test data with multiple segments for same quality
 	videoID := uuid.Must(uuid.NewV7())
-	variantPlaylistKey := "720p/playlist.m3u8"
-	segment1Key := "720p/segment-0.ts"
-	segment2Key := "720p/segment-1.ts"
-	segment3Key := "720p/segment-2.ts"
-	variantPlaylistURL := "https://s3.example.com/720p/playlist.m3u8"
-	segment1URL := "https://s3.example.com/720p/segment-0.ts"
-	segment2URL := "https://s3.example.com/720p/segment-1.ts"
-	segment3URL := "https://s3.example.com/720p/segment-2.ts"
-
-	// Setup mock expectations for manifests
-	manifests := []*models.VideoManifest{
-		{
-			ID:        uuid.Must(uuid.NewV7()),
-			VideoID:   videoID,
-			ObjectKey: variantPlaylistKey,
-			Quality:   "720p",
-			CreatedAt: time.Now(),
+	as.mockVideoAggregateRepository.On("GetVideoByID", ctx, videoID).Return(publicVideo(videoID), nil)
+	as.mockVideoAggregateRepository.On("GetVideoManifestsByVideoID", ctx, videoID).Return(testManifests(videoID), nil)
+
+	request := &connect.Request[proto.ServePlaylistRequest]{
+		Msg: &proto.ServePlaylistRequest{
+			VideoId: videoID.String(),
+			Quality: "1080p",
 		},
 	}
 
-	as.mockVideoAggregateRepository.On("GetVideoManifestsByVideoID", ctx, videoID).Return(manifests, nil)
+	actionsInstance := actions.NewActions(ctx, "test-token")
+	response, err := actionsInstance.ServePlaylist(ctx, request)
 
-	// Setup S3 mock for variant playlist
-	as.mockS3.On("GenerateDownloadPublicUri", variantPlaylistKey, s3.S3VideoUploadedBucket, uint32(s3.UrlExpirationSeconds)).
-		Return(variantPlaylistURL, nil)
+	as.Error(err)
+	as.Nil(response)
+	as.Equal(connect.CodeNotFound, connect.CodeOf(err))
+	as.mockS3.AssertNotCalled(as.T(), "Download")
+}
 
-	// Setup mock expectations for variants with multiple segments
-	variants := []*models.VideoVariant{
-		{
-			ID:        uuid.Must(uuid.NewV7()),
-			VideoID:   videoID,
-			Quality:   "720p",
-			ObjectKey: segment1Key,
-			CreatedAt: time.Now(),
-		},
-		{
-			ID:        uuid.Must(uuid.NewV7()),
-			VideoID:   videoID,
-			Quality:   "720p",
-			ObjectKey: segment2Key,
-			CreatedAt: time.Now(),
-		},
-		{
-			ID:        uuid.Must(uuid.NewV7()),
-			VideoID:   videoID,
-			Quality:   "720p",
-			ObjectKey: segment3Key,
-			CreatedAt: time.Now(),
-		},
-	}
+func (as *ActionsSuite) TestActions_ServePlaylist_NotProcessed() {
+	as.setupEnvironment()
 
-	as.mockVideoAggregateRepository.On("GetVideoVariantsByVideoID", ctx, videoID).Return(variants, nil)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
 
-	// Setup S3 mocks for segments
-	as.mockS3.On("GenerateDownloadPublicUri", segment1Key, s3.S3VideoUploadedBucket, uint32(s3.UrlExpirationSeconds)).
-		Return(segment1URL, nil)
-	as.mockS3.On("GenerateDownloadPublicUri", segment2Key, s3.S3VideoUploadedBucket, uint32(s3.UrlExpirationSeconds)).
-		Return(segment2URL, nil)
-	as.mockS3.On("GenerateDownloadPublicUri", segment3Key, s3.S3VideoUploadedBucket, uint32(s3.UrlExpirationSeconds)).
-		Return(segment3URL, nil)
+	videoID := uuid.Must(uuid.NewV7())
+	as.mockVideoAggregateRepository.On("GetVideoByID", ctx, videoID).Return(publicVideo(videoID), nil)
+	as.mockVideoAggregateRepository.On("GetVideoManifestsByVideoID", ctx, videoID).Return([]*models.VideoManifest{}, nil)
+	as.mockVideoAggregateRepository.On("GetVideoVariantsByVideoID", ctx, videoID).Return([]*models.VideoVariant{}, nil)
 
-	// Setup request
 	request := &connect.Request[proto.ServePlaylistRequest]{
 		Msg: &proto.ServePlaylistRequest{
 			VideoId: videoID.String(),
 		},
 	}
 
-	// Execute
 	actionsInstance := actions.NewActions(ctx, "test-token")
 	response, err := actionsInstance.ServePlaylist(ctx, request)
 
-	// Assertions
-	as.NoError(err)
-	as.NotNil(response)
-	as.Len(response.Msg.Variants, 1)
-	as.Equal("720p", response.Msg.Variants[0].Quality)
-	as.Equal(variantPlaylistURL, response.Msg.Variants[0].PlaylistUrl)
-	as.Len(response.Msg.Variants[0].SegmentUrls, 3)
-	as.Contains(response.Msg.Variants[0].SegmentUrls, segment1URL)
-	as.Contains(response.Msg.Variants[0].SegmentUrls, segment2URL)
-	as.Contains(response.Msg.Variants[0].SegmentUrls, segment3URL)
-
-	// Verify all mocks were called as expected
-	as.mockVideoAggregateRepository.AssertExpectations(as.T())
-	as.mockS3.AssertExpectations(as.T())
+	// There is no master playlist before the video is processed
+	as.Error(err)
+	as.Nil(response)
+	as.Equal(connect.CodeNotFound, connect.CodeOf(err))
 }
 
-func (as *ActionsSuite) TestActions_ServePlaylist_NilManifestsAndVariants() {
+func (as *ActionsSuite) TestActions_ServePlaylist_DownloadError() {
 	as.setupEnvironment()
 
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	// Setup test data with nil entries
 	videoID := uuid.Must(uuid.NewV7())
-	variantPlaylistKey := "720p/playlist.m3u8"
-	segmentKey := "720p/segment-0.ts"
-	variantPlaylistURL := "https://s3.example.com/720p/playlist.m3u8"
-	segmentURL := "https://s3.example.com/720p/segment-0.ts"
-
-	// Setup mock expectations for manifests with nil entries
-	manifests := []*models.VideoManifest{
-		nil, // Should be skipped
-		{
-			ID:        uuid.Must(uuid.NewV7()),
-			VideoID:   videoID,
-			ObjectKey: variantPlaylistKey,
-			Quality:   "720p",
-			CreatedAt: time.Now(),
+	as.mockVideoAggregateRepository.On("GetVideoByID", ctx, videoID).Return(publicVideo(videoID), nil)
+	as.mockVideoAggregateRepository.On("GetVideoManifestsByVideoID", ctx, videoID).Return(testManifests(videoID), nil)
+	as.mockVideoAggregateRepository.On("GetVideoVariantsByVideoID", ctx, videoID).Return([]*models.VideoVariant{}, nil)
+	as.mockS3.On("Download", videoID.String()+"/hls/master.m3u8", s3.S3VideoProcessedBucket).Return([]byte(nil), errors.New("S3 service unavailable"))
+
+	request := &connect.Request[proto.ServePlaylistRequest]{
+		Msg: &proto.ServePlaylistRequest{
+			VideoId: videoID.String(),
 		},
-		nil, // Should be skipped
 	}
 
-	as.mockVideoAggregateRepository.On("GetVideoManifestsByVideoID", ctx, videoID).Return(manifests, nil)
+	actionsInstance := actions.NewActions(ctx, "test-token")
+	response, err := actionsInstance.ServePlaylist(ctx, request)
 
-	// Setup S3 mock for variant playlist
-	as.mockS3.On("GenerateDownloadPublicUri", variantPlaylistKey, s3.S3VideoUploadedBucket, uint32(s3.UrlExpirationSeconds)).
-		Return(variantPlaylistURL, nil)
+	as.Error(err)
+	as.Nil(response)
+	as.Equal(connect.CodeInternal, connect.CodeOf(err))
+}
 
-	// Setup mock expectations for variants with nil entries
-	variants := []*models.VideoVariant{
-		nil, // Should be skipped
-		{
-			ID:        uuid.Must(uuid.NewV7()),
-			VideoID:   videoID,
-			Quality:   "720p",
-			ObjectKey: segmentKey,
-			CreatedAt: time.Now(),
-		},
-		nil, // Should be skipped
-	}
+func (as *ActionsSuite) TestActions_ServePlaylist_SignSegmentError() {
+	as.setupEnvironment()
 
-	as.mockVideoAggregateRepository.On("GetVideoVariantsByVideoID", ctx, videoID).Return(variants, nil)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
 
-	// Setup S3 mock for segment
-	as.mockS3.On("GenerateDownloadPublicUri", segmentKey, s3.S3VideoUploadedBucket, uint32(s3.UrlExpirationSeconds)).
-		Return(segmentURL, nil)
+	videoID := uuid.Must(uuid.NewV7())
+	as.mockVideoAggregateRepository.On("GetVideoByID", ctx, videoID).Return(publicVideo(videoID), nil)
+	as.mockVideoAggregateRepository.On("GetVideoManifestsByVideoID", ctx, videoID).Return(testManifests(videoID), nil)
+	as.mockS3.On("Download", videoID.String()+"/hls/720p/playlist.m3u8", s3.S3VideoProcessedBucket).Return([]byte(testVariantPlaylist), nil)
+	as.mockS3.On("GenerateDownloadPublicUri", videoID.String()+"/hls/720p/segment_000.ts", s3.S3VideoProcessedBucket, uint32(11+actions.SegmentUrlGraceSeconds)).
+		Return("", errors.New("S3 service unavailable"))
 
-	// Setup request
 	request := &connect.Request[proto.ServePlaylistRequest]{
 		Msg: &proto.ServePlaylistRequest{
 			VideoId: videoID.String(),
+			Quality: "720p",
 		},
 	}
 
-	// Execute
 	actionsInstance := actions.NewActions(ctx, "test-token")
 	response, err := actionsInstance.ServePlaylist(ctx, request)
 
-	// Assertions
-	as.NoError(err)
-	as.NotNil(response)
-	as.Len(response.Msg.Variants, 1)
-	as.Equal("720p", response.Msg.Variants[0].Quality)
-	as.Equal(variantPlaylistURL, response.Msg.Variants[0].PlaylistUrl)
-	as.Contains(response.Msg.Variants[0].SegmentUrls, segmentURL)
-
-	// Verify all mocks were called as expected
-	as.mockVideoAggregateRepository.AssertExpectations(as.T())
-	as.mockS3.AssertExpectations(as.T())
+	as.Error(err)
+	as.Nil(response)
+	as.Equal(connect.CodeInternal, connect.CodeOf(err))
 }
 
-func (as *ActionsSuite) TestActions_ServePlaylist_EmptyManifestsAndVariants() {
+func (as *ActionsSuite) TestActions_ServePlaylist_PrivateVideo() {
 	as.setupEnvironment()
 
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	// Setup test data
 	videoID := uuid.Must(uuid.NewV7())
+	video := publicVideo(videoID)
+	video.Visibility = models.VideoVisibilityPrivate
 
-	// Setup mock expectations for empty manifests
-	as.mockVideoAggregateRepository.On("GetVideoManifestsByVideoID", ctx, videoID).Return([]*models.VideoManifest{}, nil)
-
-	// Setup mock expectations for empty variants
-	as.mockVideoAggregateRepository.On("GetVideoVariantsByVideoID", ctx, videoID).Return([]*models.VideoVariant{}, nil)
+	as.mockVideoAggregateRepository.On("GetVideoByID", ctx, videoID).Return(video, nil)
 
-	// Setup request
 	request := &connect.Request[proto.ServePlaylistRequest]{
 		Msg: &proto.ServePlaylistRequest{
-			VideoId: videoID.String(),
+			VideoId:  videoID.String(),
+			ViewerId: uuid.Must(uuid.NewV7()).String(),
 		},
 	}
 
-	// Execute
 	actionsInstance := actions.NewActions(ctx, "test-token")
 	response, err := actionsInstance.ServePlaylist(ctx, request)
 
-	// Assertions
+	// Private videos are hidden from everyone but the uploader
+	as.Error(err)
+	as.Nil(response)
+	as.Equal(connect.CodeNotFound, connect.CodeOf(err))
+	as.mockVideoAggregateRepository.AssertNotCalled(as.T(), "GetVideoManifestsByVideoID")
+
+	// The uploader can still watch it
+	as.mockVideoAggregateRepository.On("GetVideoManifestsByVideoID", ctx, videoID).Return(testManifests(videoID), nil)
+	as.mockVideoAggregateRepository.On("GetVideoVariantsByVideoID", ctx, videoID).
+		Return([]*models.VideoVariant{testVariant(videoID, "720p", 1280, 720, 3100000)}, nil)
+
+	request.Msg.ViewerId = video.UploaderID.String()
+	response, err = actionsInstance.ServePlaylist(ctx, request)
 	as.NoError(err)
 	as.NotNil(response)
-	as.Empty(response.Msg.PlaylistUrl)
-	as.Len(response.Msg.Variants, 0)
+}
 
-	// Verify all mocks were called as expected
-	as.mockVideoAggregateRepository.AssertExpectations(as.T())
-	as.mockS3.AssertNotCalled(as.T(), "GenerateDownloadPublicUri")
+// publicVideo returns a ready, public video with the given id
+func publicVideo(videoID uuid.UUID) *models.Video {
+	return &models.Video{
+		ID:         videoID,
+		UploaderID: uuid.Must(uuid.NewV7()),
+		Title:      "Test",
+		Status:     models.VideoStatusReady,
+		Format:     models.VideoFormatLongForm,
+		Visibility: models.VideoVisibilityPublic,
+	}
 }
@@ -0,0 +1,130 @@
+package actions
+
+import (
+	"bufio"
+	"context"
+	"database/sql"
+	"math"
+	"path"
+	"strings"
+
+	"connectrpc.com/connect"
+	"github.com/cockroachdb/errors"
+	"github.com/gofrs/uuid"
+	"go.uber.org/zap"
+
+	"github.com/sweetloveinyourheart/sweet-reel/pkg/ffmpeg"
+	"github.com/sweetloveinyourheart/sweet-reel/pkg/grpc"
+	"github.com/sweetloveinyourheart/sweet-reel/pkg/logger"
+	"github.com/sweetloveinyourheart/sweet-reel/pkg/s3"
+	proto "github.com/sweetloveinyourheart/sweet-reel/proto/code/video_management/go"
+)
+
+// StoryboardUrl is where players fetch the storyboard track of a video, relative to the video
+const StoryboardUrl = ffmpeg.DefaultStoryboardTrackName
+
+// ServeStoryboard returns the WebVTT track of the scrubbing previews of a video, whose sprites
+// are signed urls. Like playlists, videos the viewer may not watch are reported as missing.
+func (a *actions) ServeStoryboard(ctx context.Context, request *connect.Request[proto.ServeStoryboardRequest]) (*connect.Response[proto.ServeStoryboardResponse], error) {
+	videoID := uuid.FromStringOrNil(request.Msg.GetVideoId())
+	if videoID == uuid.Nil {
+		return nil, grpc.InvalidArgumentError(errors.Errorf("video id is not recognized, id: %s", request.Msg.GetVideoId()))
+	}
+
+	video, err := a.videoAggregateRepo.GetVideoByID(ctx, videoID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, grpc.NotFoundError(errors.New("video not found"))
+		}
+
+		return nil, grpc.InternalError(err)
+	}
+
+	viewerID := uuid.FromStringOrNil(request.Msg.GetViewerId())
+	if !video.CanBeViewedBy(viewerID) {
+		return nil, grpc.NotFoundError(errors.New("video not found"))
+	}
+
+	storyboard, err := a.videoAggregateRepo.GetVideoStoryboardByVideoID(ctx, videoID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, grpc.NotFoundError(errors.New("storyboard not found"))
+		}
+
+		return nil, grpc.InternalError(err)
+	}
+
+	content, err := a.s3Client.Download(storyboard.ObjectKey, s3.S3VideoProcessedBucket)
+	if err != nil {
+		logger.Global().Error("unable to download storyboard", zap.String("key", storyboard.ObjectKey), zap.Error(err))
+		return nil, grpc.InternalError(err)
+	}
+
+	expirationSeconds := uint32(math.Ceil(storyboardTrackDuration(string(content)))) + SegmentUrlGraceSeconds
+	track, err := rewriteStoryboardTrack(string(content), func(uri string) (string, error) {
+		spriteKey := path.Join(path.Dir(storyboard.ObjectKey), uri)
+		return a.s3Client.GenerateDownloadPublicUri(spriteKey, s3.S3VideoProcessedBucket, expirationSeconds)
+	})
+	if err != nil {
+		logger.Global().Error("unable to generate sprite url", zap.String("key", storyboard.ObjectKey), zap.Error(err))
+		return nil, grpc.InternalError(err)
+	}
+
+	response := &proto.ServeStoryboardResponse{
+		Track:         track,
+		MaxAgeSeconds: PlaylistMaxAgeSeconds,
+	}
+
+	return connect.NewResponse(response), nil
+}
+
+// rewriteStoryboardTrack replaces the relative sprite uri of every cue of a storyboard track
+// with the uri returned by sign, keeping the "#xywh=" region of the cue. Every sprite is signed
+// once, however many cues point to it.
+func rewriteStoryboardTrack(content string, sign func(uri string) (string, error)) (string, error) {
+	var lines []string
+	signed := make(map[string]string)
+
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		uri, region, isCue := strings.Cut(line, "#xywh=")
+		if isCue && !strings.Contains(uri, "://") {
+			if _, ok := signed[uri]; !ok {
+				signedURI, err := sign(uri)
+				if err != nil {
+					return "", err
+				}
+				signed[uri] = signedURI
+			}
+			line = signed[uri] + "#xywh=" + region
+		}
+		lines = append(lines, line)
+	}
+
+	return strings.Join(lines, "\n") + "\n", nil
+}
+
+// storyboardTrackDuration returns the end of the last cue of a storyboard track, in seconds
+func storyboardTrackDuration(content string) float64 {
+	var duration float64
+
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	for scanner.Scan() {
+		_, timing, ok := strings.Cut(scanner.Text(), "-->")
+		if !ok {
+			continue
+		}
+
+		// Cue settings may follow the end timestamp
+		fields := strings.Fields(timing)
+		if len(fields) == 0 {
+			continue
+		}
+		if seconds, err := ffmpeg.ParseVTTTimestamp(fields[0]); err == nil {
+			duration = max(duration, seconds)
+		}
+	}
+
+	return duration
+}
@@ -0,0 +1,121 @@
+package actions_test
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/gofrs/uuid"
+
+	"github.com/sweetloveinyourheart/sweet-reel/pkg/s3"
+	proto "github.com/sweetloveinyourheart/sweet-reel/proto/code/video_management/go"
+	"github.com/sweetloveinyourheart/sweet-reel/services/video_management/actions"
+	"github.com/sweetloveinyourheart/sweet-reel/services/video_management/models"
+)
+
+func testStoryboard(videoID uuid.UUID) *models.VideoStoryboard {
+	return &models.VideoStoryboard{
+		VideoID:         videoID,
+		ObjectKey:       videoID.String() + "/storyboard/storyboard.vtt",
+		IntervalSeconds: 5,
+		TileWidth:       160,
+		TileHeight:      90,
+		Columns:         10,
+		Rows:            10,
+		Sprites:         1,
+	}
+}
+
+func (as *ActionsSuite) TestActions_ServeStoryboard_Success() {
+	as.setupEnvironment()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	videoID := uuid.Must(uuid.NewV7())
+	as.mockVideoAggregateRepository.On("GetVideoByID", ctx, videoID).Return(publicVideo(videoID), nil)
+	as.mockVideoAggregateRepository.On("GetVideoStoryboardByVideoID", ctx, videoID).Return(testStoryboard(videoID), nil)
+	as.mockS3.On("Download", videoID.String()+"/storyboard/storyboard.vtt", s3.S3VideoProcessedBucket).Return([]byte(`WEBVTT
+
+00:00:00.000 --> 00:00:05.000
+storyboard_000.jpg#xywh=0,0,160,90
+
+00:00:05.000 --> 00:00:07.500
+storyboard_000.jpg#xywh=160,0,160,90
+`), nil)
+	// The sprite is signed once, for the length of the video
+	as.mockS3.On("GenerateDownloadPublicUri", videoID.String()+"/storyboard/storyboard_000.jpg", s3.S3VideoProcessedBucket, uint32(8+actions.SegmentUrlGraceSeconds)).
+		Return("https://s3.example.com/storyboard_000.jpg?signature=abc", nil).Once()
+
+	request := &connect.Request[proto.ServeStoryboardRequest]{
+		Msg: &proto.ServeStoryboardRequest{
+			VideoId: videoID.String(),
+		},
+	}
+
+	actionsInstance := actions.NewActions(ctx, "test-token")
+	response, err := actionsInstance.ServeStoryboard(ctx, request)
+
+	as.NoError(err)
+	as.Equal(`WEBVTT
+
+00:00:00.000 --> 00:00:05.000
+https://s3.example.com/storyboard_000.jpg?signature=abc#xywh=0,0,160,90
+
+00:00:05.000 --> 00:00:07.500
+https://s3.example.com/storyboard_000.jpg?signature=abc#xywh=160,0,160,90
+`, response.Msg.GetTrack())
+	as.Equal(int32(actions.PlaylistMaxAgeSeconds), response.Msg.GetMaxAgeSeconds())
+
+	as.mockS3.AssertExpectations(as.T())
+}
+
+func (as *ActionsSuite) TestActions_ServeStoryboard_NotProcessed() {
+	as.setupEnvironment()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	videoID := uuid.Must(uuid.NewV7())
+	as.mockVideoAggregateRepository.On("GetVideoByID", ctx, videoID).Return(publicVideo(videoID), nil)
+	as.mockVideoAggregateRepository.On("GetVideoStoryboardByVideoID", ctx, videoID).Return(nil, sql.ErrNoRows)
+
+	request := &connect.Request[proto.ServeStoryboardRequest]{
+		Msg: &proto.ServeStoryboardRequest{
+			VideoId: videoID.String(),
+		},
+	}
+
+	actionsInstance := actions.NewActions(ctx, "test-token")
+	response, err := actionsInstance.ServeStoryboard(ctx, request)
+
+	as.Nil(response)
+	as.Equal(connect.CodeNotFound, connect.CodeOf(err))
+}
+
+func (as *ActionsSuite) TestActions_ServeStoryboard_PrivateVideo() {
+	as.setupEnvironment()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	videoID := uuid.Must(uuid.NewV7())
+	video := publicVideo(videoID)
+	video.Visibility = models.VideoVisibilityPrivate
+	as.mockVideoAggregateRepository.On("GetVideoByID", ctx, videoID).Return(video, nil)
+
+	request := &connect.Request[proto.ServeStoryboardRequest]{
+		Msg: &proto.ServeStoryboardRequest{
+			VideoId:  videoID.String(),
+			ViewerId: uuid.Must(uuid.NewV7()).String(),
+		},
+	}
+
+	actionsInstance := actions.NewActions(ctx, "test-token")
+	response, err := actionsInstance.ServeStoryboard(ctx, request)
+
+	as.Nil(response)
+	as.Equal(connect.CodeNotFound, connect.CodeOf(err))
+	as.mockVideoAggregateRepository.AssertNotCalled(as.T(), "GetVideoStoryboardByVideoID", ctx, videoID)
+}
@@ -0,0 +1,59 @@
+package actions
+
+import (
+	"context"
+	"database/sql"
+
+	"connectrpc.com/connect"
+	"github.com/cockroachdb/errors"
+	"github.com/gofrs/uuid"
+
+	"github.com/sweetloveinyourheart/sweet-reel/pkg/grpc"
+	proto "github.com/sweetloveinyourheart/sweet-reel/proto/code/video_management/go"
+	"github.com/sweetloveinyourheart/sweet-reel/services/video_management/domains/contentkeys"
+	"github.com/sweetloveinyourheart/sweet-reel/services/video_management/models"
+)
+
+// StoreContentKey records the key the segments of a video are encrypted with, sealed so only
+// this service can read it back. Processing a video again replaces its key.
+func (a *actions) StoreContentKey(ctx context.Context, request *connect.Request[proto.StoreContentKeyRequest]) (*connect.Response[proto.StoreContentKeyResponse], error) {
+	videoID := uuid.FromStringOrNil(request.Msg.GetVideoId())
+	if videoID == uuid.Nil {
+		return nil, grpc.InvalidArgumentError(errors.Errorf("video id is not recognized, id: %s", request.Msg.GetVideoId()))
+	}
+
+	if a.contentKeyCipher == nil {
+		return nil, errContentKeysNotConfigured()
+	}
+
+	if len(request.Msg.GetKey()) != contentkeys.KeySize {
+		return nil, grpc.InvalidArgumentError(errors.Errorf("content key should be %d bytes, got %d", contentkeys.KeySize, len(request.Msg.GetKey())))
+	}
+
+	if _, err := a.videoAggregateRepo.GetVideoByID(ctx, videoID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, grpc.NotFoundError(errors.New("video not found"))
+		}
+
+		return nil, grpc.InternalError(err)
+	}
+
+	encryptedKey, err := a.contentKeyCipher.Seal(videoID, request.Msg.GetKey())
+	if err != nil {
+		return nil, grpc.InternalError(err)
+	}
+
+	err = a.videoAggregateRepo.UpsertVideoContentKey(ctx, &models.VideoContentKey{
+		VideoID:      videoID,
+		EncryptedKey: encryptedKey,
+	})
+	if err != nil {
+		return nil, grpc.InternalError(err)
+	}
+
+	return connect.NewResponse(&proto.StoreContentKeyResponse{}), nil
+}
+
+func errContentKeysNotConfigured() error {
+	return grpc.PreconditionError(grpc.PreconditionFailure("config", "content_key_encryption_key", "content keys are not configured"))
+}
@@ -0,0 +1,124 @@
+package actions_test
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/gofrs/uuid"
+	"github.com/samber/do"
+	"github.com/stretchr/testify/mock"
+
+	proto "github.com/sweetloveinyourheart/sweet-reel/proto/code/video_management/go"
+	"github.com/sweetloveinyourheart/sweet-reel/services/video_management/actions"
+	"github.com/sweetloveinyourheart/sweet-reel/services/video_management/domains/contentkeys"
+	"github.com/sweetloveinyourheart/sweet-reel/services/video_management/models"
+)
+
+func (as *ActionsSuite) TestActions_StoreContentKey_Success() {
+	as.setupEnvironment()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	videoID := uuid.Must(uuid.NewV7())
+	key := bytes.Repeat([]byte{0x2a}, contentkeys.KeySize)
+
+	// The key is stored sealed, it opens with the cipher of the service
+	cipher, err := contentkeys.NewCipher(testContentKeySecret)
+	as.NoError(err)
+
+	as.mockVideoAggregateRepository.On("GetVideoByID", ctx, videoID).Return(publicVideo(videoID), nil)
+	as.mockVideoAggregateRepository.On("UpsertVideoContentKey", ctx, mock.MatchedBy(func(contentKey *models.VideoContentKey) bool {
+		opened, err := cipher.Open(videoID, contentKey.EncryptedKey)
+		return contentKey.VideoID == videoID && err == nil && bytes.Equal(opened, key)
+	})).Return(nil)
+
+	request := &connect.Request[proto.StoreContentKeyRequest]{
+		Msg: &proto.StoreContentKeyRequest{
+			VideoId: videoID.String(),
+			Key:     key,
+		},
+	}
+
+	actionsInstance := actions.NewActions(ctx, "test-token")
+	response, err := actionsInstance.StoreContentKey(ctx, request)
+
+	as.NoError(err)
+	as.NotNil(response)
+	as.mockVideoAggregateRepository.AssertExpectations(as.T())
+}
+
+func (as *ActionsSuite) TestActions_StoreContentKey_InvalidKey() {
+	as.setupEnvironment()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	request := &connect.Request[proto.StoreContentKeyRequest]{
+		Msg: &proto.StoreContentKeyRequest{
+			VideoId: uuid.Must(uuid.NewV7()).String(),
+			Key:     []byte("short"),
+		},
+	}
+
+	actionsInstance := actions.NewActions(ctx, "test-token")
+	response, err := actionsInstance.StoreContentKey(ctx, request)
+
+	as.Error(err)
+	as.Nil(response)
+	as.Equal(connect.CodeInvalidArgument, connect.CodeOf(err))
+	as.mockVideoAggregateRepository.AssertNotCalled(as.T(), "UpsertVideoContentKey")
+}
+
+func (as *ActionsSuite) TestActions_StoreContentKey_VideoNotFound() {
+	as.setupEnvironment()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	videoID := uuid.Must(uuid.NewV7())
+	as.mockVideoAggregateRepository.On("GetVideoByID", ctx, videoID).Return(nil, sql.ErrNoRows)
+
+	request := &connect.Request[proto.StoreContentKeyRequest]{
+		Msg: &proto.StoreContentKeyRequest{
+			VideoId: videoID.String(),
+			Key:     bytes.Repeat([]byte{0x2a}, contentkeys.KeySize),
+		},
+	}
+
+	actionsInstance := actions.NewActions(ctx, "test-token")
+	response, err := actionsInstance.StoreContentKey(ctx, request)
+
+	as.Error(err)
+	as.Nil(response)
+	as.Equal(connect.CodeNotFound, connect.CodeOf(err))
+	as.mockVideoAggregateRepository.AssertNotCalled(as.T(), "UpsertVideoContentKey")
+}
+
+func (as *ActionsSuite) TestActions_StoreContentKey_NotConfigured() {
+	as.setupEnvironment()
+	do.Override(nil, func(i *do.Injector) (*contentkeys.Cipher, error) {
+		return nil, nil
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	request := &connect.Request[proto.StoreContentKeyRequest]{
+		Msg: &proto.StoreContentKeyRequest{
+			VideoId: uuid.Must(uuid.NewV7()).String(),
+			Key:     bytes.Repeat([]byte{0x2a}, contentkeys.KeySize),
+		},
+	}
+
+	actionsInstance := actions.NewActions(ctx, "test-token")
+	response, err := actionsInstance.StoreContentKey(ctx, request)
+
+	as.Error(err)
+	as.Nil(response)
+	as.Equal(connect.CodeFailedPrecondition, connect.CodeOf(err))
+	as.mockVideoAggregateRepository.AssertNotCalled(as.T(), "UpsertVideoContentKey")
+}
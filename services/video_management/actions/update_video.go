@@ -0,0 +1,100 @@
+package actions
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/cockroachdb/errors"
+	"github.com/gofrs/uuid"
+
+	"github.com/sweetloveinyourheart/sweet-reel/pkg/grpc"
+	"github.com/sweetloveinyourheart/sweet-reel/pkg/stringsutil"
+	proto "github.com/sweetloveinyourheart/sweet-reel/proto/code/video_management/go"
+	"github.com/sweetloveinyourheart/sweet-reel/services/video_management/models"
+)
+
+func (a *actions) UpdateVideo(ctx context.Context, request *connect.Request[proto.UpdateVideoRequest]) (*connect.Response[proto.UpdateVideoResponse], error) {
+	videoID := uuid.FromStringOrNil(request.Msg.GetVideoId())
+	if videoID == uuid.Nil {
+		return nil, grpc.InvalidArgumentError(errors.Errorf("video id is not recognized, id: %s", request.Msg.GetVideoId()))
+	}
+
+	userID := uuid.FromStringOrNil(request.Msg.GetUserId())
+	if userID == uuid.Nil {
+		return nil, grpc.InvalidArgumentError(errors.Errorf("user id is not recognized, id: %s", request.Msg.GetUserId()))
+	}
+
+	video, err := a.videoAggregateRepo.GetVideoByID(ctx, videoID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, grpc.NotFoundError(errors.New("video not found"))
+		}
+
+		return nil, grpc.InternalError(err)
+	}
+
+	if video.GetUploaderID() != userID {
+		return nil, grpc.PermissionDeniedError(errors.New("only the uploader can update the video"))
+	}
+
+	// Only the fields present in the request are changed
+	if request.Msg.Title != nil {
+		video.Title = strings.TrimSpace(request.Msg.GetTitle())
+	}
+
+	if request.Msg.Description != nil {
+		description := request.Msg.GetDescription()
+		if stringsutil.IsBlank(description) {
+			video.Description = nil
+		} else {
+			video.Description = &description
+		}
+	}
+
+	if request.Msg.Visibility != nil {
+		video.Visibility = models.VideoVisibility(request.Msg.GetVisibility())
+	}
+
+	if request.Msg.PublishAt != nil {
+		if request.Msg.GetPublishAt() == 0 {
+			video.PublishAt = nil
+		} else {
+			publishAt := time.Unix(request.Msg.GetPublishAt(), 0).UTC()
+			if !publishAt.After(time.Now()) {
+				return nil, grpc.InvalidArgumentError(errors.New("publish_at should be in the future"))
+			}
+			video.PublishAt = &publishAt
+
+			// A scheduled video stays private until it is published, unless asked otherwise
+			if request.Msg.Visibility == nil && video.Visibility == models.VideoVisibilityPublic {
+				video.Visibility = models.VideoVisibilityPrivate
+			}
+		}
+	}
+
+	if err := video.Validate(); err != nil {
+		return nil, grpc.InvalidArgumentError(err)
+	}
+
+	// Processing may have moved the video on since it was read, only the edited fields are written
+	if err := a.videoAggregateRepo.UpdateVideoDetails(ctx, video); err != nil {
+		return nil, grpc.InternalError(err)
+	}
+
+	response := &proto.UpdateVideoResponse{
+		VideoId:          video.GetID().String(),
+		VideoTitle:       video.GetTitle(),
+		VideoDescription: video.GetDescription(),
+		UpdatedAt:        video.GetUpdatedAt().Unix(),
+		Visibility:       string(video.GetVisibility()),
+	}
+
+	if video.PublishAt != nil {
+		response.PublishAt = video.GetPublishAt().Unix()
+	}
+
+	return connect.NewResponse(response), nil
+}
@@ -0,0 +1,172 @@
+package actions_test
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/mock"
+
+	proto "github.com/sweetloveinyourheart/sweet-reel/proto/code/video_management/go"
+	"github.com/sweetloveinyourheart/sweet-reel/services/video_management/actions"
+	"github.com/sweetloveinyourheart/sweet-reel/services/video_management/models"
+)
+
+func (as *ActionsSuite) TestActions_UpdateVideo_Success() {
+	as.setupEnvironment()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// Setup test data
+	userID := uuid.Must(uuid.NewV7())
+	videoID := uuid.Must(uuid.NewV7())
+	description := "Original description"
+	newTitle := "Fixed title"
+
+	// Setup mock expectations
+	as.mockVideoAggregateRepository.On("GetVideoByID", mock.Anything, videoID).Return(&models.Video{
+		ID:          videoID,
+		UploaderID:  userID,
+		Title:       "Fixd title",
+		Description: &description,
+		Status:      models.VideoStatusReady,
+		Format:      models.VideoFormatLongForm,
+		Visibility:  models.VideoVisibilityPublic,
+	}, nil)
+	as.mockVideoAggregateRepository.On("UpdateVideoDetails", mock.Anything, mock.MatchedBy(func(video *models.Video) bool {
+		// The description was not part of the request and is kept
+		return video.Title == newTitle && video.GetDescription() == description
+	})).Return(nil)
+
+	// Setup request
+	request := &connect.Request[proto.UpdateVideoRequest]{
+		Msg: &proto.UpdateVideoRequest{
+			VideoId: videoID.String(),
+			UserId:  userID.String(),
+			Title:   &newTitle,
+		},
+	}
+
+	// Execute
+	actionsInstance := actions.NewActions(ctx, "test-token")
+	response, err := actionsInstance.UpdateVideo(ctx, request)
+
+	// Assertions
+	as.NoError(err)
+	as.NotNil(response)
+	as.Equal(newTitle, response.Msg.GetVideoTitle())
+	as.Equal(description, response.Msg.GetVideoDescription())
+	as.mockVideoAggregateRepository.AssertExpectations(as.T())
+}
+
+func (as *ActionsSuite) TestActions_UpdateVideo_NotOwner() {
+	as.setupEnvironment()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	videoID := uuid.Must(uuid.NewV7())
+	newTitle := "Not mine"
+
+	as.mockVideoAggregateRepository.On("GetVideoByID", mock.Anything, videoID).Return(&models.Video{
+		ID:         videoID,
+		UploaderID: uuid.Must(uuid.NewV7()),
+		Title:      "Test",
+		Status:     models.VideoStatusReady,
+		Format:     models.VideoFormatLongForm,
+		Visibility: models.VideoVisibilityPublic,
+	}, nil)
+
+	request := &connect.Request[proto.UpdateVideoRequest]{
+		Msg: &proto.UpdateVideoRequest{
+			VideoId: videoID.String(),
+			UserId:  uuid.Must(uuid.NewV7()).String(),
+			Title:   &newTitle,
+		},
+	}
+
+	actionsInstance := actions.NewActions(ctx, "test-token")
+	response, err := actionsInstance.UpdateVideo(ctx, request)
+
+	as.Error(err)
+	as.Nil(response)
+	as.Equal(connect.CodePermissionDenied, connect.CodeOf(err))
+	as.mockVideoAggregateRepository.AssertNotCalled(as.T(), "UpdateVideoDetails", mock.Anything, mock.Anything)
+}
+
+func (as *ActionsSuite) TestActions_UpdateVideo_InvalidTitle() {
+	as.setupEnvironment()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	userID := uuid.Must(uuid.NewV7())
+	videoID := uuid.Must(uuid.NewV7())
+	longTitle := strings.Repeat("a", 256)
+
+	as.mockVideoAggregateRepository.On("GetVideoByID", mock.Anything, videoID).Return(&models.Video{
+		ID:         videoID,
+		UploaderID: userID,
+		Title:      "Test",
+		Status:     models.VideoStatusReady,
+		Format:     models.VideoFormatLongForm,
+		Visibility: models.VideoVisibilityPublic,
+	}, nil)
+
+	request := &connect.Request[proto.UpdateVideoRequest]{
+		Msg: &proto.UpdateVideoRequest{
+			VideoId: videoID.String(),
+			UserId:  userID.String(),
+			Title:   &longTitle,
+		},
+	}
+
+	actionsInstance := actions.NewActions(ctx, "test-token")
+	response, err := actionsInstance.UpdateVideo(ctx, request)
+
+	as.Error(err)
+	as.Nil(response)
+	as.Equal(connect.CodeInvalidArgument, connect.CodeOf(err))
+	as.mockVideoAggregateRepository.AssertNotCalled(as.T(), "UpdateVideoDetails", mock.Anything, mock.Anything)
+}
+
+func (as *ActionsSuite) TestActions_UpdateVideo_SchedulePublish() {
+	as.setupEnvironment()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	userID := uuid.Must(uuid.NewV7())
+	videoID := uuid.Must(uuid.NewV7())
+	publishAt := time.Now().Add(24 * time.Hour).Unix()
+
+	as.mockVideoAggregateRepository.On("GetVideoByID", mock.Anything, videoID).Return(&models.Video{
+		ID:         videoID,
+		UploaderID: userID,
+		Title:      "Test",
+		Status:     models.VideoStatusReady,
+		Format:     models.VideoFormatLongForm,
+		Visibility: models.VideoVisibilityPublic,
+	}, nil)
+	as.mockVideoAggregateRepository.On("UpdateVideoDetails", mock.Anything, mock.Anything).Return(nil)
+
+	request := &connect.Request[proto.UpdateVideoRequest]{
+		Msg: &proto.UpdateVideoRequest{
+			VideoId:   videoID.String(),
+			UserId:    userID.String(),
+			PublishAt: &publishAt,
+		},
+	}
+
+	actionsInstance := actions.NewActions(ctx, "test-token")
+	response, err := actionsInstance.UpdateVideo(ctx, request)
+
+	// A scheduled video is kept private until the scheduler publishes it
+	as.NoError(err)
+	as.NotNil(response)
+	as.Equal(string(models.VideoVisibilityPrivate), response.Msg.GetVisibility())
+	as.Equal(publishAt, response.Msg.GetPublishAt())
+}
@@ -0,0 +1,97 @@
+package actions
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/cockroachdb/errors"
+	protobuf "google.golang.org/protobuf/proto"
+
+	"github.com/sweetloveinyourheart/sweet-reel/pkg/grpc"
+	proto "github.com/sweetloveinyourheart/sweet-reel/proto/code/video_management/go"
+	"github.com/sweetloveinyourheart/sweet-reel/services/video_management/models"
+)
+
+// ProgressPollInterval is how often the progress of a watched video is looked up
+const ProgressPollInterval = time.Second
+
+// ProgressIdleTimeout is how long a stream stays open without any change of the progress, so
+// that watchers of uploads that never arrive are let go. Watchers may reconnect.
+var ProgressIdleTimeout = 10 * time.Minute
+
+// WatchVideoProgress streams the processing progress of a video until it is ready or failed.
+// Progress is stored by whichever node consumed it, so it is polled from the database.
+func (a *actions) WatchVideoProgress(ctx context.Context, request *connect.Request[proto.WatchVideoProgressRequest], stream *connect.ServerStream[proto.WatchVideoProgressResponse]) error {
+	ticker := time.NewTicker(ProgressPollInterval)
+	defer ticker.Stop()
+
+	var sent *proto.WatchVideoProgressResponse
+	changedAt := time.Now()
+	for {
+		video, err := a.getUploadedVideo(ctx, request.Msg.GetVideoId(), request.Msg.GetUserId())
+		if err != nil {
+			return err
+		}
+
+		job, err := a.videoAggregateRepo.GetLatestVideoProcessingJobByVideoID(ctx, video.GetID())
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return grpc.InternalError(err)
+		}
+
+		update := videoProgressToProto(video, job)
+		if sent == nil || !protobuf.Equal(sent, update) {
+			if err := stream.Send(update); err != nil {
+				return err
+			}
+			sent = update
+			changedAt = time.Now()
+		}
+
+		if video.GetStatus() != models.VideoStatusProcessing {
+			return nil
+		}
+
+		if time.Since(changedAt) >= ProgressIdleTimeout {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			// The watcher went away
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// videoProgressToProto describes the progress of a video from its latest processing job, nil
+// when processing has not started
+func videoProgressToProto(video *models.Video, latest *models.VideoProcessingJob) *proto.WatchVideoProgressResponse {
+	update := &proto.WatchVideoProgressResponse{
+		VideoId: video.GetID().String(),
+		Status:  string(video.GetStatus()),
+	}
+	if failure := video.GetFailure(); failure != nil {
+		update.FailureCode = failure.Code
+		update.FailureReason = failure.Reason
+	}
+
+	if latest == nil {
+		return update
+	}
+
+	update.Attempt = latest.Attempt
+	if latest.Progress != nil {
+		update.Percentage = latest.Progress.Percentage
+		update.Speed = latest.Progress.Speed
+		update.CurrentSeconds = latest.Progress.CurrentSeconds
+		update.TotalSeconds = latest.Progress.TotalSeconds
+	}
+	if video.GetStatus() == models.VideoStatusReady {
+		update.Percentage = 100
+	}
+
+	return update
+}
@@ -0,0 +1,178 @@
+package actions_test
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/mock"
+
+	proto "github.com/sweetloveinyourheart/sweet-reel/proto/code/video_management/go"
+	"github.com/sweetloveinyourheart/sweet-reel/proto/code/video_management/go/grpcconnect"
+	"github.com/sweetloveinyourheart/sweet-reel/services/video_management/actions"
+	"github.com/sweetloveinyourheart/sweet-reel/services/video_management/models"
+)
+
+// watchVideoProgress serves the actions and collects what a client watching the video receives
+func (as *ActionsSuite) watchVideoProgress(ctx context.Context, request *proto.WatchVideoProgressRequest) ([]*proto.WatchVideoProgressResponse, error) {
+	mux := http.NewServeMux()
+	mux.Handle(grpcconnect.NewVideoManagementHandler(actions.NewActions(ctx, "test-token")))
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client := grpcconnect.NewVideoManagementClient(server.Client(), server.URL)
+	stream, err := client.WatchVideoProgress(ctx, connect.NewRequest(request))
+	if err != nil {
+		return nil, err
+	}
+	defer stream.Close()
+
+	var updates []*proto.WatchVideoProgressResponse
+	for stream.Receive() {
+		updates = append(updates, stream.Msg())
+	}
+	return updates, stream.Err()
+}
+
+func (as *ActionsSuite) TestActions_WatchVideoProgress_UntilReady() {
+	as.setupEnvironment()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	userID := uuid.Must(uuid.NewV7())
+	videoID := uuid.Must(uuid.NewV7())
+	jobID := uuid.Must(uuid.NewV7())
+
+	as.mockVideoAggregateRepository.On("GetVideoByID", mock.Anything, videoID).Return(&models.Video{
+		ID:         videoID,
+		UploaderID: userID,
+		Status:     models.VideoStatusProcessing,
+	}, nil).Once()
+	as.mockVideoAggregateRepository.On("GetVideoByID", mock.Anything, videoID).Return(&models.Video{
+		ID:         videoID,
+		UploaderID: userID,
+		Status:     models.VideoStatusReady,
+	}, nil)
+
+	as.mockVideoAggregateRepository.On("GetLatestVideoProcessingJobByVideoID", mock.Anything, videoID).Return(&models.VideoProcessingJob{
+		ID:       jobID,
+		VideoID:  videoID,
+		Attempt:  1,
+		Status:   models.VideoProcessingJobRunning,
+		Progress: &models.VideoProcessingProgress{Percentage: 40, Speed: "2.5x", CurrentSeconds: 24, TotalSeconds: 60},
+	}, nil).Once()
+	as.mockVideoAggregateRepository.On("GetLatestVideoProcessingJobByVideoID", mock.Anything, videoID).Return(&models.VideoProcessingJob{
+		ID:       jobID,
+		VideoID:  videoID,
+		Attempt:  1,
+		Status:   models.VideoProcessingJobSucceeded,
+		Progress: &models.VideoProcessingProgress{Percentage: 98, Speed: "2.5x", CurrentSeconds: 58.8, TotalSeconds: 60},
+	}, nil)
+
+	updates, err := as.watchVideoProgress(ctx, &proto.WatchVideoProgressRequest{
+		VideoId: videoID.String(),
+		UserId:  userID.String(),
+	})
+
+	as.NoError(err)
+	as.Len(updates, 2)
+	as.Equal(string(models.VideoStatusProcessing), updates[0].GetStatus())
+	as.Equal(int32(1), updates[0].GetAttempt())
+	as.Equal(40.0, updates[0].GetPercentage())
+	as.Equal("2.5x", updates[0].GetSpeed())
+	as.Equal(string(models.VideoStatusReady), updates[1].GetStatus())
+	as.Equal(100.0, updates[1].GetPercentage())
+}
+
+func (as *ActionsSuite) TestActions_WatchVideoProgress_Failed() {
+	as.setupEnvironment()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	videoID := uuid.Must(uuid.NewV7())
+	failureCode := "unsupported_codec"
+	failureReason := "video codec vp6 is not supported"
+
+	as.mockVideoAggregateRepository.On("GetVideoByID", mock.Anything, videoID).Return(&models.Video{
+		ID:            videoID,
+		UploaderID:    uuid.Must(uuid.NewV7()),
+		Status:        models.VideoStatusFailed,
+		FailureCode:   &failureCode,
+		FailureReason: &failureReason,
+	}, nil)
+	as.mockVideoAggregateRepository.On("GetLatestVideoProcessingJobByVideoID", mock.Anything, videoID).Return(nil, sql.ErrNoRows)
+
+	updates, err := as.watchVideoProgress(ctx, &proto.WatchVideoProgressRequest{
+		VideoId: videoID.String(),
+	})
+
+	// A settled video is reported once and the stream ends
+	as.NoError(err)
+	as.Len(updates, 1)
+	as.Equal(string(models.VideoStatusFailed), updates[0].GetStatus())
+	as.Equal(failureCode, updates[0].GetFailureCode())
+	as.Equal(failureReason, updates[0].GetFailureReason())
+	as.Zero(updates[0].GetAttempt())
+}
+
+func (as *ActionsSuite) TestActions_WatchVideoProgress_IdleTimeout() {
+	as.setupEnvironment()
+
+	idleTimeout := actions.ProgressIdleTimeout
+	actions.ProgressIdleTimeout = 1500 * time.Millisecond
+	defer func() { actions.ProgressIdleTimeout = idleTimeout }()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	userID := uuid.Must(uuid.NewV7())
+	videoID := uuid.Must(uuid.NewV7())
+
+	// The upload never arrives, the video waits for it with no processing job
+	as.mockVideoAggregateRepository.On("GetVideoByID", mock.Anything, videoID).Return(&models.Video{
+		ID:         videoID,
+		UploaderID: userID,
+		Status:     models.VideoStatusProcessing,
+	}, nil)
+	as.mockVideoAggregateRepository.On("GetLatestVideoProcessingJobByVideoID", mock.Anything, videoID).Return(nil, sql.ErrNoRows)
+
+	updates, err := as.watchVideoProgress(ctx, &proto.WatchVideoProgressRequest{
+		VideoId: videoID.String(),
+		UserId:  userID.String(),
+	})
+
+	as.NoError(err)
+	as.Len(updates, 1)
+	as.Equal(string(models.VideoStatusProcessing), updates[0].GetStatus())
+	as.NoError(ctx.Err())
+}
+
+func (as *ActionsSuite) TestActions_WatchVideoProgress_NotUploader() {
+	as.setupEnvironment()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	videoID := uuid.Must(uuid.NewV7())
+
+	as.mockVideoAggregateRepository.On("GetVideoByID", mock.Anything, videoID).Return(&models.Video{
+		ID:         videoID,
+		UploaderID: uuid.Must(uuid.NewV7()),
+		Status:     models.VideoStatusProcessing,
+	}, nil)
+
+	updates, err := as.watchVideoProgress(ctx, &proto.WatchVideoProgressRequest{
+		VideoId: videoID.String(),
+		UserId:  uuid.Must(uuid.NewV7()).String(),
+	})
+
+	as.Error(err)
+	as.Empty(updates)
+	as.Equal(connect.CodeNotFound, connect.CodeOf(err))
+}
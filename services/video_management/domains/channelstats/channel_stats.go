@@ -0,0 +1,48 @@
+package channelstats
+
+import (
+	"context"
+	"time"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/sweetloveinyourheart/sweet-reel/pkg/kafka"
+	"github.com/sweetloveinyourheart/sweet-reel/pkg/messages"
+	"github.com/sweetloveinyourheart/sweet-reel/services/video_management/repos"
+)
+
+// EnqueueTotalViews counts the views of all videos of a channel and enqueues the total for publishing.
+// Called inside the transaction that changed the views, the total includes that change.
+func EnqueueTotalViews(ctx context.Context, videoRepo repos.IVideoRepository, channelID uuid.UUID) error {
+	// Taken before counting, so a later count always carries a later time
+	observedAt := time.Now().UTC()
+
+	totalViews, err := videoRepo.GetTotalViewsByChannelID(ctx, channelID)
+	if err != nil {
+		return err
+	}
+
+	msg := messages.ChannelViewsChanged{
+		ChannelID:  channelID,
+		TotalViews: totalViews,
+		ObservedAt: observedAt,
+	}
+	return videoRepo.EnqueueEvent(ctx, kafka.KafkaChannelViewsChangedTopic, channelID.String(), msg)
+}
+
+// EnqueueTotalVideos counts the videos of a channel and enqueues the total for publishing
+func EnqueueTotalVideos(ctx context.Context, videoRepo repos.IVideoRepository, channelID uuid.UUID) error {
+	observedAt := time.Now().UTC()
+
+	totalVideos, err := videoRepo.GetVideoCountByChannelID(ctx, channelID)
+	if err != nil {
+		return err
+	}
+
+	msg := messages.ChannelVideosChanged{
+		ChannelID:   channelID,
+		TotalVideos: totalVideos,
+		ObservedAt:  observedAt,
+	}
+	return videoRepo.EnqueueEvent(ctx, kafka.KafkaChannelVideosChangedTopic, channelID.String(), msg)
+}
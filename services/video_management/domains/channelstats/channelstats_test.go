@@ -0,0 +1,49 @@
+package channelstats_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/samber/do"
+	"github.com/stretchr/testify/suite"
+
+	testingPkg "github.com/sweetloveinyourheart/sweet-reel/pkg/testing"
+	"github.com/sweetloveinyourheart/sweet-reel/services/video_management/repos"
+	"github.com/sweetloveinyourheart/sweet-reel/services/video_management/repos/mocks"
+)
+
+type ChannelStatsSuite struct {
+	*testingPkg.Suite
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	mockVideoAggregateRepository *mocks.MockVideoAggregateRepository
+}
+
+func (as *ChannelStatsSuite) SetupTest() {
+	as.mockVideoAggregateRepository = new(mocks.MockVideoAggregateRepository)
+	as.ctx, as.cancel = context.WithTimeout(context.Background(), 10*time.Second)
+}
+
+func (as *ChannelStatsSuite) TearDownTest() {
+	if as.cancel != nil {
+		as.cancel()
+	}
+
+	as.mockVideoAggregateRepository = nil
+}
+
+func TestChannelStatsSuite(t *testing.T) {
+	as := &ChannelStatsSuite{
+		Suite: testingPkg.MakeSuite(t),
+	}
+
+	suite.Run(t, as)
+}
+
+func (as *ChannelStatsSuite) setupEnvironment() {
+	do.Override(nil, func(i *do.Injector) (repos.IVideoAggregateRepository, error) {
+		return as.mockVideoAggregateRepository, nil
+	})
+}
@@ -0,0 +1,160 @@
+package channelstats
+
+import (
+	"context"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/samber/do"
+	"go.uber.org/zap"
+
+	"github.com/sweetloveinyourheart/sweet-reel/pkg/logger"
+	"github.com/sweetloveinyourheart/sweet-reel/services/video_management/repos"
+)
+
+const (
+	// DefaultReconcileInterval is how often the totals of every channel are published again
+	DefaultReconcileInterval = time.Hour
+
+	// DefaultViewsFlushInterval is how often the view totals of recently viewed channels are published
+	DefaultViewsFlushInterval = 30 * time.Second
+
+	// ReconcileBatchSize is the number of channels loaded per query while reconciling
+	ReconcileBatchSize = 500
+)
+
+// Reconciler periodically republishes the view and video totals of every channel,
+// which repairs the cached totals of the user service after lost or failed events.
+// View totals of the channels viewed lately are published more often, the views of a
+// channel are coalesced into one total per flush instead of one per view.
+type Reconciler struct {
+	ctx                context.Context
+	interval           time.Duration
+	viewsFlushInterval time.Duration
+	videoAggregateRepo repos.IVideoAggregateRepository
+}
+
+func NewReconciler(ctx context.Context, interval time.Duration, viewsFlushInterval time.Duration) (*Reconciler, error) {
+	if interval <= 0 {
+		interval = DefaultReconcileInterval
+	}
+	if viewsFlushInterval <= 0 {
+		viewsFlushInterval = DefaultViewsFlushInterval
+	}
+
+	videoAggregateRepo, err := do.Invoke[repos.IVideoAggregateRepository](nil)
+	if err != nil {
+		return nil, err
+	}
+
+	rc := &Reconciler{
+		ctx:                ctx,
+		interval:           interval,
+		viewsFlushInterval: viewsFlushInterval,
+		videoAggregateRepo: videoAggregateRepo,
+	}
+
+	go func() {
+		ticker := time.NewTicker(rc.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if _, err := rc.ReconcileAll(ctx); err != nil {
+					logger.Global().ErrorContext(ctx, "failed to reconcile channel stats", zap.Error(err))
+				}
+			}
+		}
+	}()
+
+	go func() {
+		ticker := time.NewTicker(rc.viewsFlushInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				// Every channel is flushed twice after its last view, the second flush counts the
+				// views whose transactions were still open during the first
+				if _, err := rc.ReconcileViewedSince(ctx, time.Now().Add(-2*rc.viewsFlushInterval)); err != nil {
+					logger.Global().ErrorContext(ctx, "failed to flush channel views", zap.Error(err))
+				}
+			}
+		}
+	}()
+
+	return rc, nil
+}
+
+// ReconcileAll enqueues the totals of every channel that has videos and returns how many channels were reconciled.
+// A channel that fails is logged and skipped so one bad channel does not hold back the rest.
+func (rc *Reconciler) ReconcileAll(ctx context.Context) (int, error) {
+	reconciled, err := rc.reconcile(ctx, "ReconcileChannelStats", rc.videoAggregateRepo.ListChannelIDs, func(repo repos.IVideoAggregateRepository, channelID uuid.UUID) error {
+		if err := EnqueueTotalViews(ctx, repo, channelID); err != nil {
+			return err
+		}
+		return EnqueueTotalVideos(ctx, repo, channelID)
+	})
+	if err != nil {
+		return reconciled, err
+	}
+
+	logger.Global().Info("channel stats reconciled", zap.Int("channels", reconciled))
+
+	return reconciled, nil
+}
+
+// ReconcileViewedSince enqueues the view totals of the channels viewed since the given time and returns
+// how many channels were reconciled
+func (rc *Reconciler) ReconcileViewedSince(ctx context.Context, since time.Time) (int, error) {
+	listChannelIDs := func(ctx context.Context, limit, offset int) ([]uuid.UUID, error) {
+		return rc.videoAggregateRepo.ListChannelIDsViewedSince(ctx, since, limit, offset)
+	}
+
+	return rc.reconcile(ctx, "FlushChannelViews", listChannelIDs, func(repo repos.IVideoAggregateRepository, channelID uuid.UUID) error {
+		return EnqueueTotalViews(ctx, repo, channelID)
+	})
+}
+
+// reconcile runs enqueue in a transaction of its own for every channel listed, batch by batch
+func (rc *Reconciler) reconcile(
+	ctx context.Context,
+	scope string,
+	listChannelIDs func(ctx context.Context, limit, offset int) ([]uuid.UUID, error),
+	enqueue func(repo repos.IVideoAggregateRepository, channelID uuid.UUID) error,
+) (int, error) {
+	reconciled := 0
+	for offset := 0; ; offset += ReconcileBatchSize {
+		channelIDs, err := listChannelIDs(ctx, ReconcileBatchSize, offset)
+		if err != nil {
+			return reconciled, err
+		}
+
+		for _, channelID := range channelIDs {
+			if err := ctx.Err(); err != nil {
+				return reconciled, err
+			}
+
+			err := rc.videoAggregateRepo.InTransaction(ctx, scope, func(repo repos.IVideoAggregateRepository) error {
+				return enqueue(repo, channelID)
+			})
+			if err != nil {
+				logger.Global().Error("failed to reconcile channel stats", zap.String("channel_id", channelID.String()), zap.Error(err))
+				continue
+			}
+
+			reconciled++
+		}
+
+		if len(channelIDs) < ReconcileBatchSize {
+			break
+		}
+	}
+
+	return reconciled, nil
+}
@@ -0,0 +1,79 @@
+package channelstats_test
+
+import (
+	"errors"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/sweetloveinyourheart/sweet-reel/pkg/kafka"
+	"github.com/sweetloveinyourheart/sweet-reel/pkg/messages"
+	"github.com/sweetloveinyourheart/sweet-reel/services/video_management/domains/channelstats"
+)
+
+func (as *ChannelStatsSuite) TestReconcileAll_CountsEveryChannel() {
+	as.setupEnvironment()
+
+	channelIDs := []uuid.UUID{uuid.Must(uuid.NewV7()), uuid.Must(uuid.NewV7())}
+
+	as.mockVideoAggregateRepository.On("ListChannelIDs", mock.Anything, channelstats.ReconcileBatchSize, 0).Return(channelIDs, nil)
+	for _, channelID := range channelIDs {
+		as.mockVideoAggregateRepository.On("GetTotalViewsByChannelID", mock.Anything, channelID).Return(int64(10), nil)
+		as.mockVideoAggregateRepository.On("GetVideoCountByChannelID", mock.Anything, channelID).Return(int64(3), nil)
+		as.mockVideoAggregateRepository.On("EnqueueEvent", mock.Anything, kafka.KafkaChannelViewsChangedTopic, channelID.String(), mock.MatchedBy(func(event messages.ChannelViewsChanged) bool {
+			return event.ChannelID == channelID && event.TotalViews == 10
+		})).Return(nil)
+		as.mockVideoAggregateRepository.On("EnqueueEvent", mock.Anything, kafka.KafkaChannelVideosChangedTopic, channelID.String(), mock.MatchedBy(func(event messages.ChannelVideosChanged) bool {
+			return event.ChannelID == channelID && event.TotalVideos == 3
+		})).Return(nil)
+	}
+
+	reconciler, err := channelstats.NewReconciler(as.ctx, time.Hour, time.Hour)
+	as.NoError(err)
+
+	reconciled, err := reconciler.ReconcileAll(as.ctx)
+	as.NoError(err)
+	as.Equal(len(channelIDs), reconciled)
+
+	as.mockVideoAggregateRepository.AssertExpectations(as.T())
+	as.mockVideoAggregateRepository.AssertNumberOfCalls(as.T(), "ListChannelIDs", 1)
+}
+
+func (as *ChannelStatsSuite) TestReconcileAll_ListError() {
+	as.setupEnvironment()
+
+	as.mockVideoAggregateRepository.On("ListChannelIDs", mock.Anything, channelstats.ReconcileBatchSize, 0).
+		Return([]uuid.UUID(nil), errors.New("database unavailable"))
+
+	reconciler, err := channelstats.NewReconciler(as.ctx, time.Hour, time.Hour)
+	as.NoError(err)
+
+	reconciled, err := reconciler.ReconcileAll(as.ctx)
+	as.Error(err)
+	as.Equal(0, reconciled)
+}
+
+func (as *ChannelStatsSuite) TestReconcileViewedSince_CountsViewedChannels() {
+	as.setupEnvironment()
+
+	since := time.Now().Add(-time.Minute)
+	channelID := uuid.Must(uuid.NewV7())
+
+	as.mockVideoAggregateRepository.On("ListChannelIDsViewedSince", mock.Anything, since, channelstats.ReconcileBatchSize, 0).Return([]uuid.UUID{channelID}, nil)
+	as.mockVideoAggregateRepository.On("GetTotalViewsByChannelID", mock.Anything, channelID).Return(int64(42), nil)
+	as.mockVideoAggregateRepository.On("EnqueueEvent", mock.Anything, kafka.KafkaChannelViewsChangedTopic, channelID.String(), mock.MatchedBy(func(event messages.ChannelViewsChanged) bool {
+		return event.ChannelID == channelID && event.TotalViews == 42
+	})).Return(nil)
+
+	reconciler, err := channelstats.NewReconciler(as.ctx, time.Hour, time.Hour)
+	as.NoError(err)
+
+	reconciled, err := reconciler.ReconcileViewedSince(as.ctx, since)
+	as.NoError(err)
+	as.Equal(1, reconciled)
+
+	// Only view totals change with views
+	as.mockVideoAggregateRepository.AssertExpectations(as.T())
+	as.mockVideoAggregateRepository.AssertNotCalled(as.T(), "GetVideoCountByChannelID", mock.Anything, channelID)
+}
@@ -0,0 +1,68 @@
+package contentkeys
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+
+	"github.com/cockroachdb/errors"
+	"github.com/gofrs/uuid"
+
+	"github.com/sweetloveinyourheart/sweet-reel/pkg/stringsutil"
+)
+
+// KeySize is the size in bytes of the AES-128 keys segments are encrypted with
+const KeySize = 16
+
+// Cipher seals the content keys of videos before they are stored, so a leaked database
+// doesn't hand out the keys of every video
+type Cipher struct {
+	aead cipher.AEAD
+}
+
+// NewCipher returns a cipher sealing keys with AES-256-GCM, keyed by a hash of secret
+func NewCipher(secret string) (*Cipher, error) {
+	if stringsutil.IsBlank(secret) {
+		return nil, errors.New("content key encryption key is required")
+	}
+
+	encryptionKey := sha256.Sum256([]byte(secret))
+	block, err := aes.NewCipher(encryptionKey[:])
+	if err != nil {
+		return nil, err
+	}
+
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Cipher{aead: aead}, nil
+}
+
+// Seal encrypts the content key of a video. The sealed key only opens for the same video,
+// a key copied to another video's row is rejected.
+func (c *Cipher) Seal(videoID uuid.UUID, key []byte) ([]byte, error) {
+	nonce := make([]byte, c.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+
+	return c.aead.Seal(nonce, nonce, key, videoID.Bytes()), nil
+}
+
+// Open decrypts a content key sealed for the video
+func (c *Cipher) Open(videoID uuid.UUID, sealed []byte) ([]byte, error) {
+	nonceSize := c.aead.NonceSize()
+	if len(sealed) < nonceSize {
+		return nil, errors.New("sealed content key is too short")
+	}
+
+	key, err := c.aead.Open(nil, sealed[:nonceSize], sealed[nonceSize:], videoID.Bytes())
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to open content key, video id: %s", videoID)
+	}
+
+	return key, nil
+}
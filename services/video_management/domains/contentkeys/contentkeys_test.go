@@ -0,0 +1,45 @@
+package contentkeys_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sweetloveinyourheart/sweet-reel/services/video_management/domains/contentkeys"
+)
+
+func TestCipherSealOpen(t *testing.T) {
+	c, err := contentkeys.NewCipher("content-key-encryption-key")
+	require.NoError(t, err)
+
+	videoID := uuid.Must(uuid.NewV7())
+	key := bytes.Repeat([]byte{0x2a}, contentkeys.KeySize)
+
+	sealed, err := c.Seal(videoID, key)
+	require.NoError(t, err)
+	require.NotContains(t, string(sealed), string(key))
+
+	opened, err := c.Open(videoID, sealed)
+	require.NoError(t, err)
+	require.Equal(t, key, opened)
+
+	// A sealed key belongs to its video
+	_, err = c.Open(uuid.Must(uuid.NewV7()), sealed)
+	require.Error(t, err)
+
+	// and to the secret it was sealed with
+	other, err := contentkeys.NewCipher("another-key")
+	require.NoError(t, err)
+	_, err = other.Open(videoID, sealed)
+	require.Error(t, err)
+
+	_, err = c.Open(videoID, sealed[:4])
+	require.Error(t, err)
+}
+
+func TestNewCipherRequiresSecret(t *testing.T) {
+	_, err := contentkeys.NewCipher(" ")
+	require.Error(t, err)
+}
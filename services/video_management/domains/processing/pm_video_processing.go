@@ -9,7 +9,6 @@ import (
 	"github.com/samber/lo"
 	"go.uber.org/zap"
 
-	"github.com/sweetloveinyourheart/sweet-reel/pkg/jsonsutil"
 	"github.com/sweetloveinyourheart/sweet-reel/pkg/kafka"
 	"github.com/sweetloveinyourheart/sweet-reel/pkg/logger"
 	"github.com/sweetloveinyourheart/sweet-reel/pkg/messages"
@@ -110,94 +109,221 @@ func (vsp *VideoProcessManager) HandleProgressUpdateMessage(ctx context.Context,
 	}
 
 	var msg messages.VideoProcessingProgress
-	if err := message.ValueAsJSON(&msg); err != nil {
+	if err := message.DecodeEvent(&msg); err != nil {
 		return err
 	}
 
-	err = vsp.videoAggregateRepo.UpdateVideoProgress(ctx,
-		msg.VideoID,
-		msg.ObjectKey,
-		models.VideoStatus(msg.Status),
-		msg.ProcessedAt,
-	)
-	if err != nil {
+	var failure *models.VideoFailure
+	if msg.Status == messages.VideoStatusFailed {
+		// Producers that predate failure reasons only send the status
+		failure = &models.VideoFailure{Code: string(messages.VideoFailureProcessingError)}
+		if msg.Failure != nil {
+			failure = &models.VideoFailure{Code: string(msg.Failure.Code), Reason: msg.Failure.Message}
+		}
+	}
+
+	handled, err := vsp.handleOnce(ctx, "HandleProgressUpdate", message, func(repo repos.IVideoAggregateRepository) error {
+		// The video keeps its status while a job runs, only finished jobs settle it
+		if msg.Status != messages.VideoStatusProcessing {
+			err := repo.UpdateVideoProgress(ctx,
+				msg.VideoID,
+				msg.ObjectKey,
+				models.VideoStatus(msg.Status),
+				msg.ProcessedAt,
+				failure,
+			)
+			if err != nil {
+				return err
+			}
+		}
+
+		if msg.Job == nil {
+			return nil
+		}
+		return repo.UpsertVideoProcessingJob(ctx, processingJobFromProgress(msg, failure))
+	})
+	if err != nil || !handled {
 		return err
 	}
 
-	logger.Global().Info("video progress updated",
+	fields := []zap.Field{
 		zap.String("video_id", msg.VideoID.String()),
 		zap.String("object_key", msg.ObjectKey),
-		zap.String("status", string(msg.Status)))
+		zap.String("status", string(msg.Status)),
+	}
+	if failure != nil {
+		fields = append(fields, zap.String("failure_code", failure.Code), zap.String("failure_reason", failure.Reason))
+	}
+	if msg.Progress != nil {
+		// Transcoding progress arrives every few seconds per video
+		logger.Global().Debug("video progress updated", append(fields, zap.Float64("percentage", msg.Progress.Percentage))...)
+		return nil
+	}
+	logger.Global().Info("video progress updated", fields...)
 	return nil
 }
 
+// processingJobFromProgress is the processing job a progress update reports, failing with
+// the failure of the video
+func processingJobFromProgress(msg messages.VideoProcessingProgress, failure *models.VideoFailure) *models.VideoProcessingJob {
+	job := &models.VideoProcessingJob{
+		ID:        msg.Job.ID,
+		VideoID:   msg.VideoID,
+		Attempt:   int32(msg.Job.Attempt),
+		Status:    models.VideoProcessingJobStatus(msg.Job.Status),
+		Stages:    make([]models.VideoProcessingStage, 0, len(msg.Job.Stages)),
+		StartedAt: msg.Job.StartedAt,
+	}
+
+	for _, stage := range msg.Job.Stages {
+		job.Stages = append(job.Stages, models.VideoProcessingStage{
+			Stage:        string(stage.Stage),
+			StartedAt:    stage.StartedAt,
+			DurationMs:   stage.DurationMs,
+			ErrorCode:    string(stage.ErrorCode),
+			ErrorMessage: stage.ErrorMessage,
+			FFmpegStderr: stage.FFmpegStderr,
+		})
+	}
+
+	if msg.Progress != nil {
+		job.Progress = &models.VideoProcessingProgress{
+			Percentage:     msg.Progress.Percentage,
+			Speed:          msg.Progress.Speed,
+			CurrentSeconds: msg.Progress.CurrentSeconds,
+			TotalSeconds:   msg.Progress.TotalSeconds,
+			UpdatedAt:      msg.ProcessedAt,
+		}
+	}
+
+	if job.Status != models.VideoProcessingJobRunning {
+		job.FinishedAt = &msg.ProcessedAt
+	}
+	if job.Status == models.VideoProcessingJobFailed && failure != nil {
+		job.ErrorCode, job.ErrorMessage = &failure.Code, &failure.Reason
+	}
+
+	return job
+}
+
 func (vsp *VideoProcessManager) HandleVideoProcessedMessage(ctx context.Context, message *kafka.ConsumedMessage) (err error) {
 	if message == nil {
 		return errors.New("message is nil")
 	}
 
 	var msg messages.VideoProcessed
-	if err := message.ValueAsJSON(&msg); err != nil {
+	if err := message.DecodeEvent(&msg); err != nil {
 		return err
 	}
 
-	switch msg.Type {
-	case messages.VideoProcessedTypeThumbnail:
-		data, err := jsonsutil.ConvertData[messages.VideoProcessedThumbnailData](msg.Data)
-		if err != nil {
-			return errors.Wrap(err, "invalid thumbnail data")
-		}
+	handled, err := vsp.handleOnce(ctx, "HandleVideoProcessed", message, func(repo repos.IVideoAggregateRepository) error {
+		switch msg.Type {
+		case messages.VideoProcessedTypeSource:
+			var data messages.VideoProcessedSourceData
+			if err := msg.DecodeData(&data); err != nil {
+				return errors.Wrap(err, "invalid source data")
+			}
 
-		newVideoThumbnail := &models.VideoThumbnail{
-			ID:        uuid.Must(uuid.NewV7()),
-			VideoID:   msg.VideoID,
-			ObjectKey: msg.ObjectKey,
-			Width:     &data.Width,
-			Height:    &data.Height,
-		}
-		err = vsp.videoAggregateRepo.CreateVideoThumbnail(ctx, newVideoThumbnail)
-		if err != nil {
-			return err
-		}
+			if err := repo.UpdateVideoFormat(ctx, msg.VideoID, models.VideoFormat(data.Format)); err != nil {
+				return err
+			}
 
-	case messages.VideoProcessedTypeManifest:
-		data, err := jsonsutil.ConvertData[messages.VideoProcessedManifestData](msg.Data)
-		if err != nil {
-			return errors.Wrap(err, "invalid manifest data")
-		}
+		case messages.VideoProcessedTypeThumbnail:
+			var data messages.VideoProcessedThumbnailData
+			if err := msg.DecodeData(&data); err != nil {
+				return errors.Wrap(err, "invalid thumbnail data")
+			}
 
-		newVideoManifest := &models.VideoManifest{
-			ID:        uuid.Must(uuid.NewV7()),
-			VideoID:   msg.VideoID,
-			ObjectKey: msg.ObjectKey,
-			Quality:   data.Quality,
-			SizeBytes: &data.SizeBytes,
-		}
-		err = vsp.videoAggregateRepo.CreateVideoManifest(ctx, newVideoManifest)
-		if err != nil {
-			return err
-		}
+			newVideoThumbnail := &models.VideoThumbnail{
+				ID:        uuid.Must(uuid.NewV7()),
+				VideoID:   msg.VideoID,
+				ObjectKey: msg.ObjectKey,
+				Width:     &data.Width,
+				Height:    &data.Height,
+			}
+			if err := repo.CreateVideoThumbnail(ctx, newVideoThumbnail); err != nil {
+				return err
+			}
 
-	case messages.VideoProcessedTypeVariant:
-		data, err := jsonsutil.ConvertData[messages.VideoProcessedVariantData](msg.Data)
-		if err != nil {
-			return errors.Wrap(err, "invalid video variant data")
-		}
+		case messages.VideoProcessedTypeStoryboard:
+			var data messages.VideoProcessedStoryboardData
+			if err := msg.DecodeData(&data); err != nil {
+				return errors.Wrap(err, "invalid storyboard data")
+			}
 
-		newVideoManifest := &models.VideoVariant{
-			ID:            uuid.Must(uuid.NewV7()),
-			VideoID:       msg.VideoID,
-			ObjectKey:     msg.ObjectKey,
-			Quality:       data.Quality,
-			TotalSegments: &data.TotalSegments,
-			TotalDuration: &data.TotalDuration,
-		}
-		err = vsp.videoAggregateRepo.CreateVideoVariant(ctx, newVideoManifest)
-		if err != nil {
-			return err
+			newVideoStoryboard := &models.VideoStoryboard{
+				VideoID:         msg.VideoID,
+				ObjectKey:       msg.ObjectKey,
+				IntervalSeconds: data.IntervalSeconds,
+				TileWidth:       data.TileWidth,
+				TileHeight:      data.TileHeight,
+				Columns:         data.Columns,
+				Rows:            data.Rows,
+				Sprites:         data.Sprites,
+			}
+			if err := repo.UpsertVideoStoryboard(ctx, newVideoStoryboard); err != nil {
+				return err
+			}
+
+		case messages.VideoProcessedTypeManifest:
+			var data messages.VideoProcessedManifestData
+			if err := msg.DecodeData(&data); err != nil {
+				return errors.Wrap(err, "invalid manifest data")
+			}
+
+			// Processors publishing HLS playlists only leave the format out
+			format := models.ManifestFormatHLS
+			if data.Format == messages.ManifestFormatDASH {
+				format = models.ManifestFormatDASH
+			}
+
+			newVideoManifest := &models.VideoManifest{
+				ID:        uuid.Must(uuid.NewV7()),
+				VideoID:   msg.VideoID,
+				ObjectKey: msg.ObjectKey,
+				Quality:   data.Quality,
+				Format:    format,
+				SizeBytes: &data.SizeBytes,
+			}
+			if err := repo.CreateVideoManifest(ctx, newVideoManifest); err != nil {
+				return err
+			}
+
+		case messages.VideoProcessedTypeVariant:
+			var data messages.VideoProcessedVariantData
+			if err := msg.DecodeData(&data); err != nil {
+				return errors.Wrap(err, "invalid video variant data")
+			}
+
+			newVideoVariant := &models.VideoVariant{
+				ID:            uuid.Must(uuid.NewV7()),
+				VideoID:       msg.VideoID,
+				ObjectKey:     msg.ObjectKey,
+				Quality:       data.Quality,
+				TotalSegments: &data.TotalSegments,
+				TotalDuration: &data.TotalDuration,
+			}
+
+			// Stream info is missing from messages of older processors, which leave it NULL
+			if data.Bandwidth > 0 {
+				newVideoVariant.Width = &data.Width
+				newVideoVariant.Height = &data.Height
+				newVideoVariant.Bandwidth = &data.Bandwidth
+				newVideoVariant.AverageBandwidth = &data.AverageBandwidth
+				newVideoVariant.FrameRate = &data.FrameRate
+				newVideoVariant.Codecs = &data.Codecs
+			}
+			if err := repo.CreateVideoVariant(ctx, newVideoVariant); err != nil {
+				return err
+			}
+		default:
+			return errors.New("invalid message type")
 		}
-	default:
-		return errors.New("invalid message type")
+
+		return nil
+	})
+	if err != nil || !handled {
+		return err
 	}
 
 	logger.Global().Info("processed files stored",
@@ -207,3 +333,31 @@ func (vsp *VideoProcessManager) HandleVideoProcessedMessage(ctx context.Context,
 
 	return nil
 }
+
+// handleOnce runs fn in a transaction that also records message as processed, and skips it
+// when the message was processed before. It reports whether fn ran.
+func (vsp *VideoProcessManager) handleOnce(ctx context.Context, scope string, message *kafka.ConsumedMessage, fn func(repo repos.IVideoAggregateRepository) error) (bool, error) {
+	handled := false
+	err := vsp.videoAggregateRepo.InTransaction(ctx, scope, func(repo repos.IVideoAggregateRepository) error {
+		first, err := repo.MarkMessageProcessed(ctx, kafka.KafkaVideoProcessingGroup, message.MessageID())
+		if err != nil {
+			return err
+		}
+
+		if !first {
+			logger.Global().InfoContext(ctx, "skipping already processed message",
+				zap.String("topic", message.Topic),
+				zap.String("message_id", message.MessageID()))
+			return nil
+		}
+
+		if err := fn(repo); err != nil {
+			return err
+		}
+
+		handled = true
+		return nil
+	})
+
+	return handled, err
+}
@@ -8,8 +8,10 @@ import (
 	"github.com/gofrs/uuid"
 	"github.com/stretchr/testify/mock"
 
+	"github.com/sweetloveinyourheart/sweet-reel/pkg/kafka"
 	"github.com/sweetloveinyourheart/sweet-reel/pkg/messages"
 	"github.com/sweetloveinyourheart/sweet-reel/services/video_management/domains/processing"
+	"github.com/sweetloveinyourheart/sweet-reel/services/video_management/models"
 )
 
 func (as *VideoProcessingSuite) TestNewVideoProcessManager_Success() {
@@ -47,7 +49,8 @@ func (as *VideoProcessingSuite) TestHandleMessage_Success() {
 		eventMessage.VideoID,
 		eventMessage.ObjectKey,
 		eventMessage.Status,
-		eventMessage.ProcessedAt)
+		eventMessage.ProcessedAt,
+		(*models.VideoFailure)(nil))
 
 	// Test getting the manager
 	manager, err := processing.NewVideoProcessManager(as.ctx)
@@ -57,3 +60,356 @@ func (as *VideoProcessingSuite) TestHandleMessage_Success() {
 	// Verify that we have a valid event data structure
 	as.NotEmpty(eventData)
 }
+
+func (as *VideoProcessingSuite) progressMessage(event messages.VideoProcessingProgress) *kafka.ConsumedMessage {
+	eventData, err := json.Marshal(event)
+	as.NoError(err)
+
+	return &kafka.ConsumedMessage{
+		Topic:   kafka.KafkaVideoProgressTopic,
+		Key:     event.VideoID.String(),
+		Value:   eventData,
+		Headers: map[string]string{kafka.HeaderMessageID: uuid.Must(uuid.NewV7()).String()},
+	}
+}
+
+func (as *VideoProcessingSuite) TestHandleProgressUpdateMessage_StoresFailure() {
+	as.setupEnvironment()
+
+	videoID := uuid.Must(uuid.NewV7())
+	message := as.progressMessage(messages.VideoProcessingProgress{
+		VideoID:     videoID,
+		Status:      messages.VideoStatusFailed,
+		ObjectKey:   fmt.Sprintf("%s/source.mp4", videoID),
+		ProcessedAt: time.Now(),
+		Failure: &messages.VideoFailure{
+			Code:    messages.VideoFailureUnsupportedCodec,
+			Message: "video codec vp6 is not supported",
+		},
+	})
+
+	as.mockVideoAggregateRepository.On("MarkMessageProcessed", mock.Anything, kafka.KafkaVideoProcessingGroup, message.MessageID()).Return(true, nil)
+	as.mockVideoAggregateRepository.On("UpdateVideoProgress", mock.Anything, videoID, mock.Anything, models.VideoStatusFailed, mock.Anything,
+		&models.VideoFailure{Code: "unsupported_codec", Reason: "video codec vp6 is not supported"}).Return(nil)
+
+	manager, err := processing.NewVideoProcessManager(as.ctx)
+	as.NoError(err)
+
+	err = manager.HandleProgressUpdateMessage(as.ctx, message)
+	as.NoError(err)
+
+	as.mockVideoAggregateRepository.AssertExpectations(as.T())
+}
+
+func (as *VideoProcessingSuite) TestHandleProgressUpdateMessage_FailureWithoutReason() {
+	as.setupEnvironment()
+
+	videoID := uuid.Must(uuid.NewV7())
+	message := as.progressMessage(messages.VideoProcessingProgress{
+		VideoID:     videoID,
+		Status:      messages.VideoStatusFailed,
+		ObjectKey:   fmt.Sprintf("%s/source.mp4", videoID),
+		ProcessedAt: time.Now(),
+	})
+
+	as.mockVideoAggregateRepository.On("MarkMessageProcessed", mock.Anything, kafka.KafkaVideoProcessingGroup, message.MessageID()).Return(true, nil)
+	as.mockVideoAggregateRepository.On("UpdateVideoProgress", mock.Anything, videoID, mock.Anything, models.VideoStatusFailed, mock.Anything,
+		&models.VideoFailure{Code: "processing_error"}).Return(nil)
+
+	manager, err := processing.NewVideoProcessManager(as.ctx)
+	as.NoError(err)
+
+	err = manager.HandleProgressUpdateMessage(as.ctx, message)
+	as.NoError(err)
+
+	as.mockVideoAggregateRepository.AssertExpectations(as.T())
+}
+
+func (as *VideoProcessingSuite) TestHandleProgressUpdateMessage_StoresFailedJob() {
+	as.setupEnvironment()
+
+	videoID := uuid.Must(uuid.NewV7())
+	job := &messages.VideoProcessingJob{
+		ID:        uuid.Must(uuid.NewV7()),
+		Attempt:   2,
+		Status:    messages.VideoProcessingJobFailed,
+		StartedAt: time.Now().Add(-time.Minute),
+		Stages: []messages.VideoProcessingStageReport{
+			{Stage: messages.VideoProcessingStageDownload, StartedAt: time.Now().Add(-time.Minute), DurationMs: 1200},
+			{Stage: messages.VideoProcessingStageProbe, StartedAt: time.Now().Add(-50 * time.Second), DurationMs: 300},
+			{
+				Stage:        messages.VideoProcessingStageSegment,
+				StartedAt:    time.Now().Add(-45 * time.Second),
+				DurationMs:   40000,
+				ErrorCode:    messages.VideoFailureProcessingError,
+				ErrorMessage: "failed to segment video: FFmpeg process failed: exit status 1",
+				FFmpegStderr: "Error while decoding stream #0:0: Invalid data found when processing input",
+			},
+		},
+	}
+	message := as.progressMessage(messages.VideoProcessingProgress{
+		VideoID:     videoID,
+		Status:      messages.VideoStatusFailed,
+		ObjectKey:   fmt.Sprintf("%s/source.mp4", videoID),
+		ProcessedAt: time.Now(),
+		Failure: &messages.VideoFailure{
+			Code:    messages.VideoFailureProcessingError,
+			Message: "failed to segment video: FFmpeg process failed: exit status 1",
+		},
+		Job: job,
+	})
+
+	as.mockVideoAggregateRepository.On("MarkMessageProcessed", mock.Anything, kafka.KafkaVideoProcessingGroup, message.MessageID()).Return(true, nil)
+	as.mockVideoAggregateRepository.On("UpdateVideoProgress", mock.Anything, videoID, mock.Anything, models.VideoStatusFailed, mock.Anything, mock.Anything).Return(nil)
+	as.mockVideoAggregateRepository.On("UpsertVideoProcessingJob", mock.Anything, mock.MatchedBy(func(stored *models.VideoProcessingJob) bool {
+		return stored.ID == job.ID &&
+			stored.VideoID == videoID &&
+			stored.Attempt == 2 &&
+			stored.Status == models.VideoProcessingJobFailed &&
+			stored.FinishedAt != nil &&
+			stored.ErrorCode != nil && *stored.ErrorCode == "processing_error" &&
+			len(stored.Stages) == 3 &&
+			stored.Stages[2].Stage == "segment" &&
+			stored.Stages[2].FFmpegStderr == job.Stages[2].FFmpegStderr
+	})).Return(nil)
+
+	manager, err := processing.NewVideoProcessManager(as.ctx)
+	as.NoError(err)
+
+	err = manager.HandleProgressUpdateMessage(as.ctx, message)
+	as.NoError(err)
+
+	as.mockVideoAggregateRepository.AssertExpectations(as.T())
+}
+
+func (as *VideoProcessingSuite) TestHandleProgressUpdateMessage_RunningJobKeepsVideo() {
+	as.setupEnvironment()
+
+	videoID := uuid.Must(uuid.NewV7())
+	message := as.progressMessage(messages.VideoProcessingProgress{
+		VideoID:     videoID,
+		Status:      messages.VideoStatusProcessing,
+		ObjectKey:   fmt.Sprintf("%s/source.mp4", videoID),
+		ProcessedAt: time.Now(),
+		Job: &messages.VideoProcessingJob{
+			ID:        uuid.Must(uuid.NewV7()),
+			Attempt:   1,
+			Status:    messages.VideoProcessingJobRunning,
+			StartedAt: time.Now(),
+		},
+		Progress: &messages.VideoTranscodeProgress{
+			Percentage:     42.5,
+			Speed:          "3.1x",
+			CurrentSeconds: 25.5,
+			TotalSeconds:   60,
+		},
+	})
+
+	as.mockVideoAggregateRepository.On("MarkMessageProcessed", mock.Anything, kafka.KafkaVideoProcessingGroup, message.MessageID()).Return(true, nil)
+	as.mockVideoAggregateRepository.On("UpsertVideoProcessingJob", mock.Anything, mock.MatchedBy(func(stored *models.VideoProcessingJob) bool {
+		return stored.Status == models.VideoProcessingJobRunning && stored.FinishedAt == nil && stored.ErrorCode == nil &&
+			stored.Progress != nil && stored.Progress.Percentage == 42.5 && stored.Progress.Speed == "3.1x"
+	})).Return(nil)
+
+	manager, err := processing.NewVideoProcessManager(as.ctx)
+	as.NoError(err)
+
+	err = manager.HandleProgressUpdateMessage(as.ctx, message)
+	as.NoError(err)
+
+	as.mockVideoAggregateRepository.AssertExpectations(as.T())
+	as.mockVideoAggregateRepository.AssertNotCalled(as.T(), "UpdateVideoProgress", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (as *VideoProcessingSuite) variantProcessedMessage(videoID uuid.UUID) *kafka.ConsumedMessage {
+	return as.variantDataProcessedMessage(videoID, messages.VideoProcessedVariantData{
+		Quality:       "720p",
+		TotalSegments: 10,
+		TotalDuration: 60,
+	})
+}
+
+func (as *VideoProcessingSuite) variantDataProcessedMessage(videoID uuid.UUID, data messages.VideoProcessedVariantData) *kafka.ConsumedMessage {
+	eventMessage, err := messages.NewVideoProcessed(
+		videoID,
+		fmt.Sprintf("%s/hls/%s/segment_009.ts", videoID, data.Quality),
+		messages.VideoProcessedTypeVariant,
+		data,
+	)
+	as.NoError(err)
+
+	eventData, err := json.Marshal(eventMessage)
+	as.NoError(err)
+
+	return &kafka.ConsumedMessage{
+		Topic:   kafka.KafkaVideoProcessedTopic,
+		Key:     videoID.String(),
+		Value:   eventData,
+		Headers: map[string]string{kafka.HeaderMessageID: uuid.Must(uuid.NewV7()).String()},
+	}
+}
+
+func (as *VideoProcessingSuite) TestHandleVideoProcessedMessage_StoresVariant() {
+	as.setupEnvironment()
+
+	videoID := uuid.Must(uuid.NewV7())
+	message := as.variantProcessedMessage(videoID)
+
+	as.mockVideoAggregateRepository.On("MarkMessageProcessed", mock.Anything, kafka.KafkaVideoProcessingGroup, message.MessageID()).Return(true, nil)
+	as.mockVideoAggregateRepository.On("CreateVideoVariant", mock.Anything, mock.Anything).Return(nil)
+
+	manager, err := processing.NewVideoProcessManager(as.ctx)
+	as.NoError(err)
+
+	err = manager.HandleVideoProcessedMessage(as.ctx, message)
+	as.NoError(err)
+
+	as.mockVideoAggregateRepository.AssertExpectations(as.T())
+}
+
+func (as *VideoProcessingSuite) TestHandleVideoProcessedMessage_StoresVariantStreamInfo() {
+	as.setupEnvironment()
+
+	videoID := uuid.Must(uuid.NewV7())
+	message := as.variantDataProcessedMessage(videoID, messages.VideoProcessedVariantData{
+		Quality:          "720p",
+		TotalSegments:    10,
+		TotalDuration:    60,
+		Width:            1280,
+		Height:           720,
+		Bandwidth:        3100000,
+		AverageBandwidth: 2500000,
+		FrameRate:        29.97,
+		Codecs:           "avc1.64001f,mp4a.40.2",
+	})
+
+	as.mockVideoAggregateRepository.On("MarkMessageProcessed", mock.Anything, kafka.KafkaVideoProcessingGroup, message.MessageID()).Return(true, nil)
+	as.mockVideoAggregateRepository.On("CreateVideoVariant", mock.Anything, mock.MatchedBy(func(variant *models.VideoVariant) bool {
+		return variant.GetResolution() == "1280x720" &&
+			variant.GetBandwidth() == 3100000 &&
+			variant.GetAverageBandwidth() == 2500000 &&
+			variant.GetFrameRate() == 29.97 &&
+			variant.GetCodecs() == "avc1.64001f,mp4a.40.2"
+	})).Return(nil)
+
+	manager, err := processing.NewVideoProcessManager(as.ctx)
+	as.NoError(err)
+
+	err = manager.HandleVideoProcessedMessage(as.ctx, message)
+	as.NoError(err)
+
+	as.mockVideoAggregateRepository.AssertExpectations(as.T())
+}
+
+func (as *VideoProcessingSuite) manifestProcessedMessage(videoID uuid.UUID, objectKey string, data messages.VideoProcessedManifestData) *kafka.ConsumedMessage {
+	eventMessage, err := messages.NewVideoProcessed(videoID, objectKey, messages.VideoProcessedTypeManifest, data)
+	as.NoError(err)
+
+	eventData, err := json.Marshal(eventMessage)
+	as.NoError(err)
+
+	return &kafka.ConsumedMessage{
+		Topic:   kafka.KafkaVideoProcessedTopic,
+		Key:     videoID.String(),
+		Value:   eventData,
+		Headers: map[string]string{kafka.HeaderMessageID: uuid.Must(uuid.NewV7()).String()},
+	}
+}
+
+func (as *VideoProcessingSuite) TestHandleVideoProcessedMessage_StoresManifestFormat() {
+	as.setupEnvironment()
+
+	videoID := uuid.Must(uuid.NewV7())
+
+	// Published before DASH was supported, without a format
+	playlistMessage := as.manifestProcessedMessage(videoID, fmt.Sprintf("%s/hls/master.m3u8", videoID), messages.VideoProcessedManifestData{
+		Quality:   "default",
+		SizeBytes: 256,
+	})
+	dashMessage := as.manifestProcessedMessage(videoID, fmt.Sprintf("%s/hls/manifest.mpd", videoID), messages.VideoProcessedManifestData{
+		Quality:   "default",
+		SizeBytes: 2048,
+		Format:    messages.ManifestFormatDASH,
+	})
+
+	for _, message := range []*kafka.ConsumedMessage{playlistMessage, dashMessage} {
+		as.mockVideoAggregateRepository.On("MarkMessageProcessed", mock.Anything, kafka.KafkaVideoProcessingGroup, message.MessageID()).Return(true, nil)
+	}
+	as.mockVideoAggregateRepository.On("CreateVideoManifest", mock.Anything, mock.MatchedBy(func(manifest *models.VideoManifest) bool {
+		return manifest.GetFormat() == models.ManifestFormatHLS && manifest.GetSizeBytes() == 256
+	})).Return(nil).Once()
+	as.mockVideoAggregateRepository.On("CreateVideoManifest", mock.Anything, mock.MatchedBy(func(manifest *models.VideoManifest) bool {
+		return manifest.GetFormat() == models.ManifestFormatDASH && manifest.GetSizeBytes() == 2048
+	})).Return(nil).Once()
+
+	manager, err := processing.NewVideoProcessManager(as.ctx)
+	as.NoError(err)
+
+	as.NoError(manager.HandleVideoProcessedMessage(as.ctx, playlistMessage))
+	as.NoError(manager.HandleVideoProcessedMessage(as.ctx, dashMessage))
+
+	as.mockVideoAggregateRepository.AssertExpectations(as.T())
+}
+
+func (as *VideoProcessingSuite) TestHandleVideoProcessedMessage_StoresStoryboard() {
+	as.setupEnvironment()
+
+	videoID := uuid.Must(uuid.NewV7())
+	trackKey := fmt.Sprintf("%s/storyboard/storyboard.vtt", videoID)
+	eventMessage, err := messages.NewVideoProcessed(videoID, trackKey, messages.VideoProcessedTypeStoryboard, messages.VideoProcessedStoryboardData{
+		IntervalSeconds: 5,
+		TileWidth:       160,
+		TileHeight:      90,
+		Columns:         10,
+		Rows:            10,
+		Sprites:         2,
+	})
+	as.NoError(err)
+
+	eventData, err := json.Marshal(eventMessage)
+	as.NoError(err)
+
+	message := &kafka.ConsumedMessage{
+		Topic:   kafka.KafkaVideoProcessedTopic,
+		Key:     videoID.String(),
+		Value:   eventData,
+		Headers: map[string]string{kafka.HeaderMessageID: uuid.Must(uuid.NewV7()).String()},
+	}
+
+	as.mockVideoAggregateRepository.On("MarkMessageProcessed", mock.Anything, kafka.KafkaVideoProcessingGroup, message.MessageID()).Return(true, nil)
+	as.mockVideoAggregateRepository.On("UpsertVideoStoryboard", mock.Anything, &models.VideoStoryboard{
+		VideoID:         videoID,
+		ObjectKey:       trackKey,
+		IntervalSeconds: 5,
+		TileWidth:       160,
+		TileHeight:      90,
+		Columns:         10,
+		Rows:            10,
+		Sprites:         2,
+	}).Return(nil)
+
+	manager, err := processing.NewVideoProcessManager(as.ctx)
+	as.NoError(err)
+
+	as.NoError(manager.HandleVideoProcessedMessage(as.ctx, message))
+
+	as.mockVideoAggregateRepository.AssertExpectations(as.T())
+}
+
+func (as *VideoProcessingSuite) TestHandleVideoProcessedMessage_SkipsRedelivery() {
+	as.setupEnvironment()
+
+	videoID := uuid.Must(uuid.NewV7())
+	message := as.variantProcessedMessage(videoID)
+
+	// The message was recorded by an earlier delivery
+	as.mockVideoAggregateRepository.On("MarkMessageProcessed", mock.Anything, kafka.KafkaVideoProcessingGroup, message.MessageID()).Return(false, nil)
+
+	manager, err := processing.NewVideoProcessManager(as.ctx)
+	as.NoError(err)
+
+	err = manager.HandleVideoProcessedMessage(as.ctx, message)
+	as.NoError(err)
+
+	as.mockVideoAggregateRepository.AssertNotCalled(as.T(), "CreateVideoVariant", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,98 @@
+package publishing
+
+import (
+	"context"
+	"time"
+
+	"github.com/samber/do"
+	"go.uber.org/zap"
+
+	"github.com/sweetloveinyourheart/sweet-reel/pkg/kafka"
+	"github.com/sweetloveinyourheart/sweet-reel/pkg/logger"
+	"github.com/sweetloveinyourheart/sweet-reel/pkg/messages"
+	"github.com/sweetloveinyourheart/sweet-reel/services/video_management/models"
+	"github.com/sweetloveinyourheart/sweet-reel/services/video_management/repos"
+)
+
+// DefaultPublishInterval is how often the scheduler looks for videos that are due to be published
+const DefaultPublishInterval = 30 * time.Second
+
+// PublishScheduler makes scheduled videos public once their publish_at has passed
+type PublishScheduler struct {
+	ctx                context.Context
+	interval           time.Duration
+	videoAggregateRepo repos.IVideoAggregateRepository
+}
+
+func NewPublishScheduler(ctx context.Context, interval time.Duration) (*PublishScheduler, error) {
+	if interval <= 0 {
+		interval = DefaultPublishInterval
+	}
+
+	videoAggregateRepo, err := do.Invoke[repos.IVideoAggregateRepository](nil)
+	if err != nil {
+		return nil, err
+	}
+
+	ps := &PublishScheduler{
+		ctx:                ctx,
+		interval:           interval,
+		videoAggregateRepo: videoAggregateRepo,
+	}
+
+	go func() {
+		ticker := time.NewTicker(ps.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if _, err := ps.PublishDueVideos(ctx); err != nil {
+					logger.Global().ErrorContext(ctx, "failed to publish scheduled videos", zap.Error(err))
+				}
+			}
+		}
+	}()
+
+	return ps, nil
+}
+
+// PublishDueVideos publishes every video whose publish_at has passed and returns how many were published.
+// Several instances can run it at once, the update in the repository hands each video to one of them.
+func (ps *PublishScheduler) PublishDueVideos(ctx context.Context) (int, error) {
+	now := time.Now().UTC()
+
+	var videos []*models.Video
+	err := ps.videoAggregateRepo.InTransaction(ctx, "PublishDueVideos", func(repo repos.IVideoAggregateRepository) error {
+		var err error
+		videos, err = repo.PublishScheduledVideos(ctx, now)
+		if err != nil {
+			return err
+		}
+
+		for _, video := range videos {
+			publishMsg := messages.VideoPublished{
+				VideoID:     video.GetID(),
+				ChannelID:   video.GetChannelID(),
+				UploaderID:  video.GetUploaderID(),
+				PublishedAt: now,
+			}
+			if err := repo.EnqueueEvent(ctx, kafka.KafkaVideoPublishedTopic, video.GetID().String(), publishMsg); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	for _, video := range videos {
+		logger.Global().Info("scheduled video published", zap.String("video_id", video.GetID().String()))
+	}
+
+	return len(videos), nil
+}
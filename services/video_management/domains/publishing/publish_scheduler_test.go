@@ -0,0 +1,70 @@
+package publishing_test
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/sweetloveinyourheart/sweet-reel/pkg/kafka"
+	"github.com/sweetloveinyourheart/sweet-reel/pkg/messages"
+	"github.com/sweetloveinyourheart/sweet-reel/services/video_management/domains/publishing"
+	"github.com/sweetloveinyourheart/sweet-reel/services/video_management/models"
+)
+
+func (as *PublishingSuite) TestPublishDueVideos_NoneDue() {
+	as.setupEnvironment()
+
+	as.mockVideoAggregateRepository.On("PublishScheduledVideos", mock.Anything, mock.AnythingOfType("time.Time")).
+		Return([]*models.Video{}, nil)
+
+	scheduler, err := publishing.NewPublishScheduler(as.ctx, time.Hour)
+	as.NoError(err)
+
+	published, err := scheduler.PublishDueVideos(as.ctx)
+	as.NoError(err)
+	as.Equal(0, published)
+
+	as.mockVideoAggregateRepository.AssertExpectations(as.T())
+}
+
+func (as *PublishingSuite) TestPublishDueVideos_PublishesDueVideos() {
+	as.setupEnvironment()
+
+	videos := []*models.Video{
+		{
+			ID:         uuid.Must(uuid.NewV7()),
+			ChannelID:  uuid.Must(uuid.NewV7()),
+			UploaderID: uuid.Must(uuid.NewV7()),
+			Title:      "Scheduled video",
+			Status:     models.VideoStatusReady,
+			Visibility: models.VideoVisibilityPublic,
+		},
+		{
+			ID:         uuid.Must(uuid.NewV7()),
+			ChannelID:  uuid.Must(uuid.NewV7()),
+			UploaderID: uuid.Must(uuid.NewV7()),
+			Title:      "Another scheduled video",
+			Status:     models.VideoStatusReady,
+			Visibility: models.VideoVisibilityPublic,
+		},
+	}
+
+	as.mockVideoAggregateRepository.On("PublishScheduledVideos", mock.Anything, mock.MatchedBy(func(now time.Time) bool {
+		return now.Location() == time.UTC
+	})).Return(videos, nil)
+	for _, video := range videos {
+		as.mockVideoAggregateRepository.On("EnqueueEvent", mock.Anything, kafka.KafkaVideoPublishedTopic, video.ID.String(), mock.MatchedBy(func(event messages.VideoPublished) bool {
+			return event.VideoID == video.ID && event.ChannelID == video.ChannelID
+		})).Return(nil)
+	}
+
+	scheduler, err := publishing.NewPublishScheduler(as.ctx, time.Hour)
+	as.NoError(err)
+
+	published, err := scheduler.PublishDueVideos(as.ctx)
+	as.NoError(err)
+	as.Equal(len(videos), published)
+
+	as.mockVideoAggregateRepository.AssertExpectations(as.T())
+}
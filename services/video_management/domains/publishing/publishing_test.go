@@ -0,0 +1,49 @@
+package publishing_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/samber/do"
+	"github.com/stretchr/testify/suite"
+
+	testingPkg "github.com/sweetloveinyourheart/sweet-reel/pkg/testing"
+	"github.com/sweetloveinyourheart/sweet-reel/services/video_management/repos"
+	"github.com/sweetloveinyourheart/sweet-reel/services/video_management/repos/mocks"
+)
+
+type PublishingSuite struct {
+	*testingPkg.Suite
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	mockVideoAggregateRepository *mocks.MockVideoAggregateRepository
+}
+
+func (as *PublishingSuite) SetupTest() {
+	as.mockVideoAggregateRepository = new(mocks.MockVideoAggregateRepository)
+	as.ctx, as.cancel = context.WithTimeout(context.Background(), 10*time.Second)
+}
+
+func (as *PublishingSuite) TearDownTest() {
+	if as.cancel != nil {
+		as.cancel()
+	}
+
+	as.mockVideoAggregateRepository = nil
+}
+
+func TestPublishingSuite(t *testing.T) {
+	as := &PublishingSuite{
+		Suite: testingPkg.MakeSuite(t),
+	}
+
+	suite.Run(t, as)
+}
+
+func (as *PublishingSuite) setupEnvironment() {
+	do.Override(nil, func(i *do.Injector) (repos.IVideoAggregateRepository, error) {
+		return as.mockVideoAggregateRepository, nil
+	})
+}
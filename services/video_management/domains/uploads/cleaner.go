@@ -0,0 +1,162 @@
+package uploads
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/samber/do"
+	"go.uber.org/zap"
+
+	"github.com/sweetloveinyourheart/sweet-reel/pkg/logger"
+	"github.com/sweetloveinyourheart/sweet-reel/pkg/s3"
+	"github.com/sweetloveinyourheart/sweet-reel/services/video_management/models"
+	"github.com/sweetloveinyourheart/sweet-reel/services/video_management/repos"
+)
+
+const (
+	// DefaultUploadCleanupInterval is how often the cleaner looks for abandoned uploads
+	DefaultUploadCleanupInterval = 15 * time.Minute
+
+	// DefaultUploadTTL is how long a multipart upload may stay in progress before it is abandoned
+	DefaultUploadTTL = 24 * time.Hour
+
+	// cleanupBatchSize is how many uploads are listed at a time
+	cleanupBatchSize = 100
+)
+
+// UploadCleaner aborts the multipart uploads left in progress for longer than their TTL and deletes
+// their videos, so that the parts stored in S3 do not accumulate
+type UploadCleaner struct {
+	ctx                context.Context
+	interval           time.Duration
+	ttl                time.Duration
+	s3Client           s3.S3Storage
+	videoAggregateRepo repos.IVideoAggregateRepository
+}
+
+func NewUploadCleaner(ctx context.Context, interval time.Duration, ttl time.Duration) (*UploadCleaner, error) {
+	if interval <= 0 {
+		interval = DefaultUploadCleanupInterval
+	}
+	if ttl <= 0 {
+		ttl = DefaultUploadTTL
+	}
+
+	s3Client, err := do.Invoke[s3.S3Storage](nil)
+	if err != nil {
+		return nil, err
+	}
+
+	videoAggregateRepo, err := do.Invoke[repos.IVideoAggregateRepository](nil)
+	if err != nil {
+		return nil, err
+	}
+
+	uc := &UploadCleaner{
+		ctx:                ctx,
+		interval:           interval,
+		ttl:                ttl,
+		s3Client:           s3Client,
+		videoAggregateRepo: videoAggregateRepo,
+	}
+
+	go func() {
+		ticker := time.NewTicker(uc.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if _, err := uc.CleanupAbandonedUploads(ctx); err != nil {
+					logger.Global().ErrorContext(ctx, "failed to clean up abandoned uploads", zap.Error(err))
+				}
+			}
+		}
+	}()
+
+	return uc, nil
+}
+
+// CleanupAbandonedUploads aborts the uploads started more than the TTL ago, deletes their videos and
+// returns how many were discarded. Uploads that complete meanwhile are left alone.
+func (uc *UploadCleaner) CleanupAbandonedUploads(ctx context.Context) (int, error) {
+	before := time.Now().UTC().Add(-uc.ttl)
+
+	// Uploads that fail to be discarded stay listed, so the listing pages past them rather than
+	// starting over, which would have them hold back every upload behind them
+	discarded := 0
+	var after *models.VideoUpload
+	for {
+		uploads, err := uc.videoAggregateRepo.ListVideoUploadsCreatedBefore(ctx, before, after, cleanupBatchSize)
+		if err != nil {
+			return discarded, err
+		}
+
+		n, err := uc.discardUploads(ctx, uploads)
+		discarded += n
+		if err != nil {
+			return discarded, err
+		}
+
+		if len(uploads) < cleanupBatchSize {
+			break
+		}
+		after = uploads[len(uploads)-1]
+	}
+
+	// Uploads started in S3 whose video failed to be created are only known to S3
+	if _, err := uc.s3Client.AbortMultipartUploadsBefore(s3.S3VideoUploadedBucket, before); err != nil {
+		return discarded, err
+	}
+
+	return discarded, nil
+}
+
+// discardUploads aborts the given uploads, deletes their videos and returns how many were discarded
+func (uc *UploadCleaner) discardUploads(ctx context.Context, uploads []*models.VideoUpload) (int, error) {
+	discarded := 0
+	for _, upload := range uploads {
+		// The upload row is claimed before the upload is aborted, so an upload completed meanwhile is
+		// left alone. When the abort fails the row is kept and the next cleanup tries again.
+		var abortErr error
+		err := uc.videoAggregateRepo.InTransaction(ctx, "DiscardAbandonedUpload", func(repo repos.IVideoAggregateRepository) error {
+			video, err := repo.GetVideoByID(ctx, upload.VideoID)
+			if err != nil {
+				return err
+			}
+
+			if err := DiscardVideo(ctx, repo, video); err != nil {
+				return err
+			}
+
+			// A missing upload was aborted by an earlier cleanup that failed to commit
+			abortErr = uc.s3Client.AbortMultipartUpload(upload.ObjectKey, s3.S3VideoUploadedBucket, upload.UploadID)
+			if errors.Is(abortErr, s3.ErrUploadNotFound) {
+				abortErr = nil
+			}
+			return abortErr
+		})
+		if abortErr != nil {
+			logger.Global().Error("failed to abort abandoned upload",
+				zap.String("video_id", upload.VideoID.String()),
+				zap.String("upload_id", upload.UploadID),
+				zap.Error(abortErr))
+			continue
+		}
+		if errors.Is(err, sql.ErrNoRows) {
+			continue
+		}
+		if err != nil {
+			return discarded, err
+		}
+
+		logger.Global().Info("abandoned upload discarded", zap.String("video_id", upload.VideoID.String()))
+		discarded++
+	}
+
+	return discarded, nil
+}
@@ -0,0 +1,199 @@
+package uploads_test
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/sweetloveinyourheart/sweet-reel/pkg/kafka"
+	"github.com/sweetloveinyourheart/sweet-reel/pkg/messages"
+	"github.com/sweetloveinyourheart/sweet-reel/pkg/s3"
+	"github.com/sweetloveinyourheart/sweet-reel/services/video_management/domains/uploads"
+	"github.com/sweetloveinyourheart/sweet-reel/services/video_management/models"
+)
+
+func (as *UploadsSuite) TestCleanupAbandonedUploads_NoneAbandoned() {
+	as.setupEnvironment()
+
+	as.mockVideoAggregateRepository.On("ListVideoUploadsCreatedBefore", mock.Anything, mock.AnythingOfType("time.Time"), (*models.VideoUpload)(nil), mock.Anything).
+		Return([]*models.VideoUpload{}, nil)
+	as.mockS3.On("AbortMultipartUploadsBefore", s3.S3VideoUploadedBucket, mock.AnythingOfType("time.Time")).Return(0, nil)
+
+	cleaner, err := uploads.NewUploadCleaner(as.ctx, time.Hour, time.Hour)
+	as.NoError(err)
+
+	discarded, err := cleaner.CleanupAbandonedUploads(as.ctx)
+	as.NoError(err)
+	as.Equal(0, discarded)
+
+	as.mockVideoAggregateRepository.AssertExpectations(as.T())
+	as.mockS3.AssertExpectations(as.T())
+}
+
+func (as *UploadsSuite) TestCleanupAbandonedUploads_DiscardsAbandonedUploads() {
+	as.setupEnvironment()
+
+	ttl := 24 * time.Hour
+	video := &models.Video{
+		ID:         uuid.Must(uuid.NewV7()),
+		ChannelID:  uuid.Must(uuid.NewV7()),
+		UploaderID: uuid.Must(uuid.NewV7()),
+		Title:      "Abandoned video",
+		Status:     models.VideoStatusProcessing,
+	}
+	abandoned := &models.VideoUpload{VideoID: video.ID, UploadID: "abandoned", ObjectKey: "2025-11-12/abandoned.mp4"}
+	completed := &models.VideoUpload{VideoID: uuid.Must(uuid.NewV7()), UploadID: "completed", ObjectKey: "2025-11-12/completed.mp4"}
+
+	as.mockVideoAggregateRepository.On("ListVideoUploadsCreatedBefore", mock.Anything, mock.MatchedBy(func(before time.Time) bool {
+		return time.Since(before) >= ttl
+	}), (*models.VideoUpload)(nil), mock.Anything).Return([]*models.VideoUpload{abandoned, completed}, nil)
+
+	as.mockS3.On("AbortMultipartUpload", abandoned.ObjectKey, s3.S3VideoUploadedBucket, abandoned.UploadID).Return(nil)
+	as.mockVideoAggregateRepository.On("GetVideoByID", mock.Anything, video.ID).Return(video, nil)
+	as.mockVideoAggregateRepository.On("DeleteVideoUpload", mock.Anything, video.ID).Return(nil)
+	as.mockVideoAggregateRepository.On("DeleteVideo", mock.Anything, video.ID).Return(nil)
+	as.mockVideoAggregateRepository.On("EnqueueEvent", mock.Anything, kafka.KafkaVideoDeletedTopic, video.ID.String(), mock.MatchedBy(func(event messages.VideoDeleted) bool {
+		return event.VideoID == video.ID && event.UploaderID == video.UploaderID
+	})).Return(nil)
+	as.mockVideoAggregateRepository.On("GetVideoCountByChannelID", mock.Anything, video.ChannelID).Return(int64(3), nil)
+	as.mockVideoAggregateRepository.On("EnqueueEvent", mock.Anything, kafka.KafkaChannelVideosChangedTopic, video.ChannelID.String(), mock.Anything).Return(nil)
+
+	// Completed while the cleanup ran, the video and its upload stay
+	as.mockVideoAggregateRepository.On("GetVideoByID", mock.Anything, completed.VideoID).Return(&models.Video{ID: completed.VideoID}, nil)
+	as.mockVideoAggregateRepository.On("DeleteVideoUpload", mock.Anything, completed.VideoID).Return(sql.ErrNoRows)
+
+	as.mockS3.On("AbortMultipartUploadsBefore", s3.S3VideoUploadedBucket, mock.AnythingOfType("time.Time")).Return(1, nil)
+
+	cleaner, err := uploads.NewUploadCleaner(as.ctx, time.Hour, ttl)
+	as.NoError(err)
+
+	discarded, err := cleaner.CleanupAbandonedUploads(as.ctx)
+	as.NoError(err)
+	as.Equal(1, discarded)
+
+	as.mockVideoAggregateRepository.AssertExpectations(as.T())
+	as.mockVideoAggregateRepository.AssertNotCalled(as.T(), "DeleteVideo", mock.Anything, completed.VideoID)
+	as.mockS3.AssertNotCalled(as.T(), "AbortMultipartUpload", completed.ObjectKey, s3.S3VideoUploadedBucket, completed.UploadID)
+	as.mockS3.AssertExpectations(as.T())
+}
+
+func (as *UploadsSuite) TestCleanupAbandonedUploads_UploadAlreadyAborted() {
+	as.setupEnvironment()
+
+	video := &models.Video{
+		ID:         uuid.Must(uuid.NewV7()),
+		ChannelID:  uuid.Must(uuid.NewV7()),
+		UploaderID: uuid.Must(uuid.NewV7()),
+		Status:     models.VideoStatusProcessing,
+	}
+	abandoned := &models.VideoUpload{VideoID: video.ID, UploadID: "abandoned", ObjectKey: "2025-11-12/abandoned.mp4"}
+
+	as.mockVideoAggregateRepository.On("ListVideoUploadsCreatedBefore", mock.Anything, mock.AnythingOfType("time.Time"), (*models.VideoUpload)(nil), mock.Anything).
+		Return([]*models.VideoUpload{abandoned}, nil)
+	as.mockVideoAggregateRepository.On("GetVideoByID", mock.Anything, video.ID).Return(video, nil)
+	as.mockVideoAggregateRepository.On("DeleteVideoUpload", mock.Anything, video.ID).Return(nil)
+	as.mockVideoAggregateRepository.On("DeleteVideo", mock.Anything, video.ID).Return(nil)
+	as.mockVideoAggregateRepository.On("EnqueueEvent", mock.Anything, kafka.KafkaVideoDeletedTopic, video.ID.String(), mock.Anything).Return(nil)
+	as.mockVideoAggregateRepository.On("GetVideoCountByChannelID", mock.Anything, video.ChannelID).Return(int64(0), nil)
+	as.mockVideoAggregateRepository.On("EnqueueEvent", mock.Anything, kafka.KafkaChannelVideosChangedTopic, video.ChannelID.String(), mock.Anything).Return(nil)
+
+	// Aborted by an earlier cleanup that failed to commit
+	as.mockS3.On("AbortMultipartUpload", abandoned.ObjectKey, s3.S3VideoUploadedBucket, abandoned.UploadID).Return(s3.ErrUploadNotFound)
+	as.mockS3.On("AbortMultipartUploadsBefore", s3.S3VideoUploadedBucket, mock.AnythingOfType("time.Time")).Return(0, nil)
+
+	cleaner, err := uploads.NewUploadCleaner(as.ctx, time.Hour, time.Hour)
+	as.NoError(err)
+
+	discarded, err := cleaner.CleanupAbandonedUploads(as.ctx)
+	as.NoError(err)
+	as.Equal(1, discarded)
+
+	as.mockVideoAggregateRepository.AssertExpectations(as.T())
+	as.mockS3.AssertExpectations(as.T())
+}
+
+func (as *UploadsSuite) TestCleanupAbandonedUploads_AbortFails() {
+	as.setupEnvironment()
+
+	video := &models.Video{
+		ID:         uuid.Must(uuid.NewV7()),
+		ChannelID:  uuid.Must(uuid.NewV7()),
+		UploaderID: uuid.Must(uuid.NewV7()),
+		Status:     models.VideoStatusProcessing,
+	}
+	abandoned := &models.VideoUpload{VideoID: video.ID, UploadID: "abandoned", ObjectKey: "2025-11-12/abandoned.mp4"}
+
+	as.mockVideoAggregateRepository.On("ListVideoUploadsCreatedBefore", mock.Anything, mock.AnythingOfType("time.Time"), (*models.VideoUpload)(nil), mock.Anything).
+		Return([]*models.VideoUpload{abandoned}, nil)
+	as.mockVideoAggregateRepository.On("GetVideoByID", mock.Anything, video.ID).Return(video, nil)
+	as.mockVideoAggregateRepository.On("DeleteVideoUpload", mock.Anything, video.ID).Return(nil)
+	as.mockVideoAggregateRepository.On("DeleteVideo", mock.Anything, video.ID).Return(nil)
+	as.mockVideoAggregateRepository.On("EnqueueEvent", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	as.mockVideoAggregateRepository.On("GetVideoCountByChannelID", mock.Anything, video.ChannelID).Return(int64(0), nil)
+
+	// The transaction is rolled back, the upload is tried again by the next cleanup
+	as.mockS3.On("AbortMultipartUpload", abandoned.ObjectKey, s3.S3VideoUploadedBucket, abandoned.UploadID).Return(errors.New("storage unavailable"))
+	as.mockS3.On("AbortMultipartUploadsBefore", s3.S3VideoUploadedBucket, mock.AnythingOfType("time.Time")).Return(0, nil)
+
+	cleaner, err := uploads.NewUploadCleaner(as.ctx, time.Hour, time.Hour)
+	as.NoError(err)
+
+	discarded, err := cleaner.CleanupAbandonedUploads(as.ctx)
+	as.NoError(err)
+	as.Equal(0, discarded)
+
+	as.mockS3.AssertExpectations(as.T())
+}
+
+func (as *UploadsSuite) TestCleanupAbandonedUploads_PagesPastFailedUploads() {
+	as.setupEnvironment()
+
+	// A full page of uploads whose abort keeps failing
+	createdAt := time.Now().Add(-48 * time.Hour)
+	failing := make([]*models.VideoUpload, 100)
+	for i := range failing {
+		failing[i] = &models.VideoUpload{
+			VideoID:   uuid.Must(uuid.NewV7()),
+			UploadID:  "failing",
+			ObjectKey: "2025-11-12/failing.mp4",
+			CreatedAt: createdAt.Add(time.Duration(i) * time.Second),
+		}
+	}
+
+	video := &models.Video{
+		ID:         uuid.Must(uuid.NewV7()),
+		ChannelID:  uuid.Must(uuid.NewV7()),
+		UploaderID: uuid.Must(uuid.NewV7()),
+		Status:     models.VideoStatusProcessing,
+	}
+	abandoned := &models.VideoUpload{VideoID: video.ID, UploadID: "abandoned", ObjectKey: "2025-11-12/abandoned.mp4", CreatedAt: createdAt.Add(time.Hour)}
+
+	as.mockVideoAggregateRepository.On("ListVideoUploadsCreatedBefore", mock.Anything, mock.AnythingOfType("time.Time"), (*models.VideoUpload)(nil), mock.Anything).
+		Return(failing, nil)
+	as.mockVideoAggregateRepository.On("ListVideoUploadsCreatedBefore", mock.Anything, mock.AnythingOfType("time.Time"), failing[len(failing)-1], mock.Anything).
+		Return([]*models.VideoUpload{abandoned}, nil)
+
+	as.mockS3.On("AbortMultipartUpload", abandoned.ObjectKey, s3.S3VideoUploadedBucket, abandoned.UploadID).Return(nil)
+	as.mockS3.On("AbortMultipartUpload", "2025-11-12/failing.mp4", s3.S3VideoUploadedBucket, "failing").Return(errors.New("storage unavailable"))
+	as.mockVideoAggregateRepository.On("GetVideoByID", mock.Anything, video.ID).Return(video, nil)
+	as.mockVideoAggregateRepository.On("GetVideoByID", mock.Anything, mock.Anything).Return(&models.Video{ID: uuid.Must(uuid.NewV7())}, nil)
+	as.mockVideoAggregateRepository.On("DeleteVideoUpload", mock.Anything, mock.Anything).Return(nil)
+	as.mockVideoAggregateRepository.On("DeleteVideo", mock.Anything, mock.Anything).Return(nil)
+	as.mockVideoAggregateRepository.On("EnqueueEvent", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	as.mockVideoAggregateRepository.On("GetVideoCountByChannelID", mock.Anything, mock.Anything).Return(int64(0), nil)
+	as.mockS3.On("AbortMultipartUploadsBefore", s3.S3VideoUploadedBucket, mock.AnythingOfType("time.Time")).Return(0, nil)
+
+	cleaner, err := uploads.NewUploadCleaner(as.ctx, time.Hour, time.Hour)
+	as.NoError(err)
+
+	// The upload behind the failing ones is reached within the same cleanup
+	discarded, err := cleaner.CleanupAbandonedUploads(as.ctx)
+	as.NoError(err)
+	as.Equal(1, discarded)
+
+	as.mockS3.AssertCalled(as.T(), "AbortMultipartUpload", abandoned.ObjectKey, s3.S3VideoUploadedBucket, abandoned.UploadID)
+	as.mockVideoAggregateRepository.AssertExpectations(as.T())
+}
@@ -0,0 +1,37 @@
+package uploads
+
+import (
+	"context"
+	"time"
+
+	"github.com/sweetloveinyourheart/sweet-reel/pkg/kafka"
+	"github.com/sweetloveinyourheart/sweet-reel/pkg/messages"
+	"github.com/sweetloveinyourheart/sweet-reel/services/video_management/domains/channelstats"
+	"github.com/sweetloveinyourheart/sweet-reel/services/video_management/models"
+	"github.com/sweetloveinyourheart/sweet-reel/services/video_management/repos"
+)
+
+// DiscardVideo deletes a video whose multipart upload is abandoned, along with its upload row.
+// It returns sql.ErrNoRows when the upload is no longer in progress, completed or discarded by another caller.
+func DiscardVideo(ctx context.Context, videoRepo repos.IVideoRepository, video *models.Video) error {
+	if err := videoRepo.DeleteVideoUpload(ctx, video.GetID()); err != nil {
+		return err
+	}
+
+	if err := videoRepo.DeleteVideo(ctx, video.GetID()); err != nil {
+		return err
+	}
+
+	event := messages.VideoDeleted{
+		VideoID:    video.GetID(),
+		ChannelID:  video.GetChannelID(),
+		UploaderID: video.GetUploaderID(),
+		DeletedAt:  time.Now(),
+	}
+	if err := videoRepo.EnqueueEvent(ctx, kafka.KafkaVideoDeletedTopic, video.GetID().String(), event); err != nil {
+		return err
+	}
+
+	// A video never uploaded has no views, only the count of videos of its channel changes
+	return channelstats.EnqueueTotalVideos(ctx, videoRepo, video.GetChannelID())
+}
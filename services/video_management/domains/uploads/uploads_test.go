@@ -0,0 +1,58 @@
+package uploads_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/samber/do"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/sweetloveinyourheart/sweet-reel/pkg/s3"
+	testingPkg "github.com/sweetloveinyourheart/sweet-reel/pkg/testing"
+	mockPkg "github.com/sweetloveinyourheart/sweet-reel/pkg/testing/mock"
+	"github.com/sweetloveinyourheart/sweet-reel/services/video_management/repos"
+	"github.com/sweetloveinyourheart/sweet-reel/services/video_management/repos/mocks"
+)
+
+type UploadsSuite struct {
+	*testingPkg.Suite
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	mockVideoAggregateRepository *mocks.MockVideoAggregateRepository
+	mockS3                       *mockPkg.MockS3
+}
+
+func (as *UploadsSuite) SetupTest() {
+	as.mockVideoAggregateRepository = new(mocks.MockVideoAggregateRepository)
+	as.mockS3 = new(mockPkg.MockS3)
+	as.ctx, as.cancel = context.WithTimeout(context.Background(), 10*time.Second)
+}
+
+func (as *UploadsSuite) TearDownTest() {
+	if as.cancel != nil {
+		as.cancel()
+	}
+
+	as.mockVideoAggregateRepository = nil
+	as.mockS3 = nil
+}
+
+func TestUploadsSuite(t *testing.T) {
+	as := &UploadsSuite{
+		Suite: testingPkg.MakeSuite(t),
+	}
+
+	suite.Run(t, as)
+}
+
+func (as *UploadsSuite) setupEnvironment() {
+	do.Override(nil, func(i *do.Injector) (repos.IVideoAggregateRepository, error) {
+		return as.mockVideoAggregateRepository, nil
+	})
+
+	do.Override(nil, func(i *do.Injector) (s3.S3Storage, error) {
+		return as.mockS3, nil
+	})
+}
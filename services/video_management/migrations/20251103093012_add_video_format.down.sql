@@ -0,0 +1,4 @@
+-- Remove format column
+DROP INDEX IF EXISTS idx_videos_format_status_processed_at;
+ALTER TABLE videos
+DROP COLUMN IF EXISTS format;
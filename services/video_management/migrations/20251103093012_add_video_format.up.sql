@@ -0,0 +1,6 @@
+-- Add format column to videos: reel (short vertical) or long_form
+ALTER TABLE videos
+ADD COLUMN format VARCHAR(20) NOT NULL DEFAULT 'long_form';
+
+-- Composite index for the reel feed, newest processed videos first
+CREATE INDEX idx_videos_format_status_processed_at ON videos (format, status, processed_at DESC);
@@ -0,0 +1,7 @@
+-- Remove visibility and publish_at columns
+DROP INDEX IF EXISTS idx_videos_channel_visibility;
+DROP INDEX IF EXISTS idx_videos_publish_at;
+ALTER TABLE videos
+DROP COLUMN IF EXISTS publish_at;
+ALTER TABLE videos
+DROP COLUMN IF EXISTS visibility;
@@ -0,0 +1,13 @@
+-- Add visibility column to videos: public, unlisted or private
+ALTER TABLE videos
+ADD COLUMN visibility VARCHAR(20) NOT NULL DEFAULT 'public';
+
+-- Add publish_at column for videos scheduled to become public
+ALTER TABLE videos
+ADD COLUMN publish_at TIMESTAMP;
+
+-- Partial index for the publish scheduler, only scheduled videos are indexed
+CREATE INDEX idx_videos_publish_at ON videos (publish_at) WHERE publish_at IS NOT NULL;
+
+-- Composite index on videos.channel_id and visibility for channel listings
+CREATE INDEX idx_videos_channel_visibility ON videos (channel_id, visibility);
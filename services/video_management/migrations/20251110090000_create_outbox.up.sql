@@ -0,0 +1,15 @@
+-- Events waiting to be published to Kafka, written in the same transaction as the change they describe
+CREATE TABLE outbox (
+    id                  BIGSERIAL       NOT NULL,
+    topic               VARCHAR(255)    NOT NULL,
+    message_key         VARCHAR(255)    NOT NULL,
+    payload             BYTEA           NOT NULL,
+    headers             JSONB,
+    created_at          TIMESTAMP       DEFAULT NOW(),
+    sent_at             TIMESTAMP,                      -- NULL until the relay has published it
+
+    PRIMARY KEY (id)
+);
+
+CREATE INDEX idx_outbox_unsent ON outbox(id) WHERE sent_at IS NULL;
+CREATE INDEX idx_outbox_sent_at ON outbox(sent_at) WHERE sent_at IS NOT NULL;
@@ -0,0 +1,3 @@
+-- Id published with every outbox message, so consumers can tell a re-published message apart from a new one
+ALTER TABLE outbox
+ADD COLUMN message_id UUID;
@@ -0,0 +1,10 @@
+-- Messages already handled by a consumer group, so that redelivered messages are skipped
+CREATE TABLE processed_messages (
+    consumer_group      VARCHAR(255)    NOT NULL,
+    message_id          VARCHAR(255)    NOT NULL,           -- x-message-id header, or topic/partition/offset
+    processed_at        TIMESTAMP       DEFAULT NOW(),
+
+    PRIMARY KEY (consumer_group, message_id)
+);
+
+CREATE INDEX idx_processed_messages_processed_at ON processed_messages(processed_at);
@@ -0,0 +1,8 @@
+ALTER TABLE video_thumbnails
+DROP CONSTRAINT IF EXISTS uq_video_thumbnails_video_object_key;
+
+ALTER TABLE video_variants
+DROP CONSTRAINT IF EXISTS uq_video_variants_video_quality;
+
+ALTER TABLE video_manifests
+DROP CONSTRAINT IF EXISTS uq_video_manifests_video_quality;
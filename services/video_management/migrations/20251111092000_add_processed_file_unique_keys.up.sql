@@ -0,0 +1,25 @@
+-- Remove the duplicates left by redelivered video processed messages, keeping the latest row
+DELETE FROM video_manifests a
+USING video_manifests b
+WHERE a.video_id = b.video_id AND a.quality = b.quality
+  AND (a.created_at, a.id) < (b.created_at, b.id);
+
+DELETE FROM video_variants a
+USING video_variants b
+WHERE a.video_id = b.video_id AND a.quality = b.quality
+  AND (a.created_at, a.id) < (b.created_at, b.id);
+
+DELETE FROM video_thumbnails a
+USING video_thumbnails b
+WHERE a.video_id = b.video_id AND a.object_key = b.object_key
+  AND (a.created_at, a.id) < (b.created_at, b.id);
+
+-- One manifest and one variant per quality, one row per thumbnail file
+ALTER TABLE video_manifests
+ADD CONSTRAINT uq_video_manifests_video_quality UNIQUE (video_id, quality);
+
+ALTER TABLE video_variants
+ADD CONSTRAINT uq_video_variants_video_quality UNIQUE (video_id, quality);
+
+ALTER TABLE video_thumbnails
+ADD CONSTRAINT uq_video_thumbnails_video_object_key UNIQUE (video_id, object_key);
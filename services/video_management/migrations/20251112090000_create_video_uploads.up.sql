@@ -0,0 +1,12 @@
+-- Multipart uploads in progress, one per video until the upload completes or is aborted
+CREATE TABLE video_uploads (
+    video_id            UUID            PRIMARY KEY REFERENCES videos(id) ON DELETE CASCADE,
+    upload_id           TEXT            NOT NULL,           -- id of the S3 multipart upload
+    object_key          TEXT            NOT NULL,
+    file_size           BIGINT          NOT NULL,
+    part_size           BIGINT          NOT NULL,
+    part_count          INT             NOT NULL,
+    created_at          TIMESTAMP       DEFAULT NOW()
+);
+
+CREATE INDEX idx_video_uploads_created_at ON video_uploads(created_at);
@@ -0,0 +1,5 @@
+-- Remove failure_code and failure_reason columns
+ALTER TABLE videos
+DROP COLUMN IF EXISTS failure_reason;
+ALTER TABLE videos
+DROP COLUMN IF EXISTS failure_code;
@@ -0,0 +1,6 @@
+-- Reason a video failed processing, NULL unless status is failed
+ALTER TABLE videos
+ADD COLUMN failure_code VARCHAR(50);
+
+ALTER TABLE videos
+ADD COLUMN failure_reason TEXT;
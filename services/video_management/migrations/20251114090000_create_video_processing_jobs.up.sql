@@ -0,0 +1,16 @@
+-- Attempts at processing the upload of a video, with the stages each went through
+CREATE TABLE video_processing_jobs (
+    id                  UUID            PRIMARY KEY,        -- id of the job, assigned by video_processing
+    video_id            UUID            NOT NULL REFERENCES videos(id) ON DELETE CASCADE,
+    attempt             INT             NOT NULL,
+    status              VARCHAR(20)     NOT NULL,           -- running, succeeded, failed, interrupted
+    stages              JSONB           NOT NULL DEFAULT '[]',
+    error_code          VARCHAR(50),
+    error_message       TEXT,
+    started_at          TIMESTAMP       NOT NULL,
+    finished_at         TIMESTAMP,
+    created_at          TIMESTAMP       DEFAULT NOW(),
+    updated_at          TIMESTAMP       DEFAULT NOW()
+);
+
+CREATE INDEX idx_video_processing_jobs_video_id ON video_processing_jobs(video_id, started_at);
@@ -0,0 +1,3 @@
+-- Remove progress column
+ALTER TABLE video_processing_jobs
+DROP COLUMN IF EXISTS progress;
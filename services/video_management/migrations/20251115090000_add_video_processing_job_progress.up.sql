@@ -0,0 +1,3 @@
+-- Latest transcoding progress reported for a job, NULL until the first report
+ALTER TABLE video_processing_jobs
+ADD COLUMN progress JSONB;
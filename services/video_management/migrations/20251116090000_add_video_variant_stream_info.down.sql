@@ -0,0 +1,13 @@
+-- Remove stream info columns
+ALTER TABLE video_variants
+DROP COLUMN IF EXISTS codecs;
+ALTER TABLE video_variants
+DROP COLUMN IF EXISTS frame_rate;
+ALTER TABLE video_variants
+DROP COLUMN IF EXISTS average_bandwidth;
+ALTER TABLE video_variants
+DROP COLUMN IF EXISTS bandwidth;
+ALTER TABLE video_variants
+DROP COLUMN IF EXISTS height;
+ALTER TABLE video_variants
+DROP COLUMN IF EXISTS width;
@@ -0,0 +1,19 @@
+-- Stream info of a variant, written to the master playlist. NULL for variants recorded
+-- before it was published, whose master playlist is served as stored.
+ALTER TABLE video_variants
+ADD COLUMN width INT;
+
+ALTER TABLE video_variants
+ADD COLUMN height INT;
+
+ALTER TABLE video_variants
+ADD COLUMN bandwidth INT;                 -- peak segment bitrate in bits per second
+
+ALTER TABLE video_variants
+ADD COLUMN average_bandwidth INT;
+
+ALTER TABLE video_variants
+ADD COLUMN frame_rate DOUBLE PRECISION;
+
+ALTER TABLE video_variants
+ADD COLUMN codecs TEXT;                   -- avc1.64001f,mp4a.40.2
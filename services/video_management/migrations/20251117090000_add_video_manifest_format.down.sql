@@ -0,0 +1,11 @@
+-- Remove the DASH manifests, only HLS manifests fit a single manifest per quality
+DELETE FROM video_manifests WHERE format <> 'hls';
+
+ALTER TABLE video_manifests
+DROP CONSTRAINT IF EXISTS uq_video_manifests_video_format_quality;
+
+ALTER TABLE video_manifests
+ADD CONSTRAINT uq_video_manifests_video_quality UNIQUE (video_id, quality);
+
+ALTER TABLE video_manifests
+DROP COLUMN IF EXISTS format;
@@ -0,0 +1,11 @@
+-- Streaming protocol of a manifest, a video packaged as CMAF has both an HLS master playlist
+-- and a DASH manifest of the default quality
+ALTER TABLE video_manifests
+ADD COLUMN format VARCHAR(20) NOT NULL DEFAULT 'hls';  -- hls, dash
+
+ALTER TABLE video_manifests
+DROP CONSTRAINT IF EXISTS uq_video_manifests_video_quality;
+
+-- One manifest per quality and format
+ALTER TABLE video_manifests
+ADD CONSTRAINT uq_video_manifests_video_format_quality UNIQUE (video_id, format, quality);
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS video_content_keys;
@@ -0,0 +1,8 @@
+-- Content keys of videos whose segments are encrypted, one per video. Keys are stored sealed
+-- with the content key secret of the service, never in the clear.
+CREATE TABLE video_content_keys (
+    video_id            UUID            PRIMARY KEY REFERENCES videos(id) ON DELETE CASCADE,
+    encrypted_key       BYTEA           NOT NULL,
+    created_at          TIMESTAMP       DEFAULT NOW(),
+    updated_at          TIMESTAMP       DEFAULT NOW()
+);
@@ -0,0 +1,14 @@
+-- Storyboards of videos, one per video. The object key points to the WebVTT track, the sprites
+-- it references are stored next to it.
+CREATE TABLE video_storyboards (
+    video_id            UUID            PRIMARY KEY REFERENCES videos(id) ON DELETE CASCADE,
+    object_key          VARCHAR(500)    NOT NULL,
+    interval_seconds    INTEGER         NOT NULL,
+    tile_width          INTEGER         NOT NULL,
+    tile_height         INTEGER         NOT NULL,
+    columns             INTEGER         NOT NULL,
+    rows                INTEGER         NOT NULL,
+    sprites             INTEGER         NOT NULL,
+    created_at          TIMESTAMP       DEFAULT NOW(),
+    updated_at          TIMESTAMP       DEFAULT NOW()
+);
@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS idx_outbox_parked;
+DROP INDEX IF EXISTS idx_outbox_unsent;
+CREATE INDEX idx_outbox_unsent ON outbox(id) WHERE sent_at IS NULL;
+
+ALTER TABLE outbox
+DROP COLUMN IF EXISTS parked_at,
+DROP COLUMN IF EXISTS last_error,
+DROP COLUMN IF EXISTS attempts;
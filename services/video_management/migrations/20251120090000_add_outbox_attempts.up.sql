@@ -0,0 +1,10 @@
+-- Failed publish attempts of each outbox message, so a message that can never be published is parked
+-- instead of holding back every message written after it
+ALTER TABLE outbox
+ADD COLUMN attempts INT NOT NULL DEFAULT 0,
+ADD COLUMN last_error TEXT,
+ADD COLUMN parked_at TIMESTAMP;
+
+DROP INDEX idx_outbox_unsent;
+CREATE INDEX idx_outbox_unsent ON outbox(id) WHERE sent_at IS NULL AND parked_at IS NULL;
+CREATE INDEX idx_outbox_parked ON outbox(parked_at) WHERE parked_at IS NOT NULL;
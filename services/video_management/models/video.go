@@ -17,19 +17,47 @@ const (
 	VideoStatusFailed     VideoStatus = "failed"
 )
 
+// VideoFormat represents how a video is presented to viewers
+type VideoFormat string
+
+const (
+	VideoFormatLongForm VideoFormat = "long_form"
+	VideoFormatReel     VideoFormat = "reel"
+)
+
+// VideoFailure is the reason a video failed processing
+type VideoFailure struct {
+	Code   string
+	Reason string
+}
+
+// VideoVisibility represents who can find and watch a video
+type VideoVisibility string
+
+const (
+	VideoVisibilityPublic   VideoVisibility = "public"   // Listed and watchable by everyone
+	VideoVisibilityUnlisted VideoVisibility = "unlisted" // Watchable by anyone with the link, not listed
+	VideoVisibilityPrivate  VideoVisibility = "private"  // Only the uploader can watch it
+)
+
 // Video represents the main video metadata
 type Video struct {
-	ID          uuid.UUID   `json:"id"`
-	UploaderID  uuid.UUID   `json:"uploader_id"`
-	ChannelID   uuid.UUID   `json:"channel_id"`
-	Title       string      `json:"title"`
-	Description *string     `json:"description"`
-	Status      VideoStatus `json:"status"`
-	ObjectKey   *string     `json:"object_key"`
-	ProcessedAt *time.Time  `json:"processed_at"`
-	ViewCount   int64       `json:"view_count"`
-	CreatedAt   time.Time   `json:"created_at"`
-	UpdatedAt   time.Time   `json:"updated_at"`
+	ID            uuid.UUID       `json:"id"`
+	UploaderID    uuid.UUID       `json:"uploader_id"`
+	ChannelID     uuid.UUID       `json:"channel_id"`
+	Title         string          `json:"title"`
+	Description   *string         `json:"description"`
+	Status        VideoStatus     `json:"status"`
+	Format        VideoFormat     `json:"format"`
+	Visibility    VideoVisibility `json:"visibility"`
+	PublishAt     *time.Time      `json:"publish_at"`
+	ObjectKey     *string         `json:"object_key"`
+	ProcessedAt   *time.Time      `json:"processed_at"`
+	FailureCode   *string         `json:"failure_code"`   // Set when the video failed processing
+	FailureReason *string         `json:"failure_reason"` // Human readable detail of the failure
+	ViewCount     int64           `json:"view_count"`
+	CreatedAt     time.Time       `json:"created_at"`
+	UpdatedAt     time.Time       `json:"updated_at"`
 }
 
 // GetID returns the ID of the video
@@ -65,6 +93,33 @@ func (v Video) GetStatus() VideoStatus {
 	return v.Status
 }
 
+// GetFormat returns the format of the video
+func (v Video) GetFormat() VideoFormat {
+	return v.Format
+}
+
+// GetVisibility returns the visibility of the video
+func (v Video) GetVisibility() VideoVisibility {
+	return v.Visibility
+}
+
+// GetPublishAt returns when the video is scheduled to become public
+func (v Video) GetPublishAt() time.Time {
+	if v.PublishAt == nil {
+		return time.Time{}
+	}
+	return *v.PublishAt
+}
+
+// CanBeViewedBy reports whether viewerID may watch the video, uuid.Nil being an anonymous viewer
+func (v Video) CanBeViewedBy(viewerID uuid.UUID) bool {
+	if viewerID != uuid.Nil && viewerID == v.UploaderID {
+		return true
+	}
+
+	return v.Visibility == VideoVisibilityPublic || v.Visibility == VideoVisibilityUnlisted
+}
+
 // GetObjectKey returns the object key of the video
 func (v Video) GetObjectKey() string {
 	if v.ObjectKey == nil {
@@ -81,6 +136,19 @@ func (v Video) GetProcessedAt() time.Time {
 	return *v.ProcessedAt
 }
 
+// GetFailure returns why the video failed processing, nil when it did not fail
+func (v Video) GetFailure() *VideoFailure {
+	if v.FailureCode == nil {
+		return nil
+	}
+
+	failure := &VideoFailure{Code: *v.FailureCode}
+	if v.FailureReason != nil {
+		failure.Reason = *v.FailureReason
+	}
+	return failure
+}
+
 func (v Video) GetViewCount() int64 {
 	return v.ViewCount
 }
@@ -123,5 +191,28 @@ func (v Video) Validate() error {
 		return errors.New("invalid video status")
 	}
 
+	// Validate format
+	validFormats := map[VideoFormat]bool{
+		VideoFormatLongForm: true,
+		VideoFormatReel:     true,
+	}
+	if !validFormats[v.Format] {
+		return errors.New("invalid video format")
+	}
+
+	// Validate visibility
+	validVisibilities := map[VideoVisibility]bool{
+		VideoVisibilityPublic:   true,
+		VideoVisibilityUnlisted: true,
+		VideoVisibilityPrivate:  true,
+	}
+	if !validVisibilities[v.Visibility] {
+		return errors.New("invalid video visibility")
+	}
+
+	if v.PublishAt != nil && v.Visibility == VideoVisibilityPublic {
+		return errors.New("a scheduled video cannot be public before it is published")
+	}
+
 	return nil
 }
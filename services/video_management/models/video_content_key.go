@@ -0,0 +1,15 @@
+package models
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+// VideoContentKey represents the key the segments of a video are encrypted with, sealed at rest
+type VideoContentKey struct {
+	VideoID      uuid.UUID `db:"video_id" json:"video_id"`
+	EncryptedKey []byte    `db:"encrypted_key" json:"-"`
+	CreatedAt    time.Time `db:"created_at" json:"created_at"`
+	UpdatedAt    time.Time `db:"updated_at" json:"updated_at"`
+}
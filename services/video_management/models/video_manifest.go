@@ -8,14 +8,23 @@ import (
 	"github.com/gofrs/uuid"
 )
 
-// VideoManifest represents a video manifest (HLS master playlist)
+// ManifestFormat represents the streaming protocol a manifest is written for
+type ManifestFormat string
+
+const (
+	ManifestFormatHLS  ManifestFormat = "hls"
+	ManifestFormatDASH ManifestFormat = "dash"
+)
+
+// VideoManifest represents a video manifest (HLS playlist or DASH manifest)
 type VideoManifest struct {
-	ID        uuid.UUID `json:"id"`
-	VideoID   uuid.UUID `json:"video_id"`
-	ObjectKey string    `json:"object_key"`
-	Quality   string    `json:"quality"`
-	SizeBytes *int64    `json:"size_bytes"`
-	CreatedAt time.Time `json:"created_at"`
+	ID        uuid.UUID      `json:"id"`
+	VideoID   uuid.UUID      `json:"video_id"`
+	ObjectKey string         `json:"object_key"`
+	Quality   string         `json:"quality"`
+	Format    ManifestFormat `json:"format"`
+	SizeBytes *int64         `json:"size_bytes"`
+	CreatedAt time.Time      `json:"created_at"`
 }
 
 // GetID returns the ID of the video manifest
@@ -38,6 +47,11 @@ func (vv VideoManifest) GetQuality() string {
 	return vv.Quality
 }
 
+// GetFormat returns the streaming protocol of the video manifest
+func (vm VideoManifest) GetFormat() ManifestFormat {
+	return vm.Format
+}
+
 // GetSizeBytes returns the size in bytes of the video manifest or 0 if nil
 func (vm VideoManifest) GetSizeBytes() int64 {
 	if vm.SizeBytes == nil {
@@ -65,6 +79,14 @@ func (vm VideoManifest) Validate() error {
 		return errors.New("object key is required and cannot be empty")
 	}
 
+	validFormats := map[ManifestFormat]bool{
+		ManifestFormatHLS:  true,
+		ManifestFormatDASH: true,
+	}
+	if !validFormats[vm.Format] {
+		return errors.New("invalid manifest format")
+	}
+
 	if vm.SizeBytes != nil && *vm.SizeBytes < 0 {
 		return errors.New("size bytes cannot be negative")
 	}
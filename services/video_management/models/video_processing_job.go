@@ -0,0 +1,53 @@
+package models
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+// VideoProcessingJobStatus represents how an attempt at processing a video went
+type VideoProcessingJobStatus string
+
+const (
+	VideoProcessingJobRunning     VideoProcessingJobStatus = "running"
+	VideoProcessingJobSucceeded   VideoProcessingJobStatus = "succeeded"
+	VideoProcessingJobFailed      VideoProcessingJobStatus = "failed"
+	VideoProcessingJobInterrupted VideoProcessingJobStatus = "interrupted" // Stopped by shutdown, picked up again by another attempt
+)
+
+// VideoProcessingStage is a step of a processing job, stored as JSON with the job
+type VideoProcessingStage struct {
+	Stage        string    `json:"stage"` // download, probe, segment, thumbnail or upload
+	StartedAt    time.Time `json:"started_at"`
+	DurationMs   int64     `json:"duration_ms"`
+	ErrorCode    string    `json:"error_code,omitempty"`
+	ErrorMessage string    `json:"error_message,omitempty"`
+	FFmpegStderr string    `json:"ffmpeg_stderr,omitempty"` // Last lines ffmpeg wrote when the stage failed
+}
+
+// VideoProcessingProgress is the latest transcoding progress reported for a job, stored as JSON
+// with the job
+type VideoProcessingProgress struct {
+	Percentage     float64   `json:"percentage"`
+	Speed          string    `json:"speed,omitempty"`
+	CurrentSeconds float64   `json:"current_seconds"`
+	TotalSeconds   float64   `json:"total_seconds"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+// VideoProcessingJob represents one attempt at processing the upload of a video
+type VideoProcessingJob struct {
+	ID           uuid.UUID                `json:"id"`
+	VideoID      uuid.UUID                `json:"video_id"`
+	Attempt      int32                    `json:"attempt"`
+	Status       VideoProcessingJobStatus `json:"status"`
+	Stages       []VideoProcessingStage   `json:"stages"`
+	Progress     *VideoProcessingProgress `json:"progress"`      // Kept when later reports carry none
+	ErrorCode    *string                  `json:"error_code"`    // Set when the job failed
+	ErrorMessage *string                  `json:"error_message"` // Human readable detail of the failure
+	StartedAt    time.Time                `json:"started_at"`
+	FinishedAt   *time.Time               `json:"finished_at"`
+	CreatedAt    time.Time                `json:"created_at"`
+	UpdatedAt    time.Time                `json:"updated_at"`
+}
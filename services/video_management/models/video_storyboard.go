@@ -0,0 +1,21 @@
+package models
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+// VideoStoryboard represents the sprite sheets and WebVTT track of the scrubbing previews of a video
+type VideoStoryboard struct {
+	VideoID         uuid.UUID `db:"video_id" json:"video_id"`
+	ObjectKey       string    `db:"object_key" json:"object_key"` // Key of the WebVTT track
+	IntervalSeconds int       `db:"interval_seconds" json:"interval_seconds"`
+	TileWidth       int       `db:"tile_width" json:"tile_width"`
+	TileHeight      int       `db:"tile_height" json:"tile_height"`
+	Columns         int       `db:"columns" json:"columns"`
+	Rows            int       `db:"rows" json:"rows"`
+	Sprites         int       `db:"sprites" json:"sprites"`
+	CreatedAt       time.Time `db:"created_at" json:"created_at"`
+	UpdatedAt       time.Time `db:"updated_at" json:"updated_at"`
+}
@@ -0,0 +1,18 @@
+package models
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+// VideoUpload represents a multipart upload of the file of a video still in progress
+type VideoUpload struct {
+	VideoID   uuid.UUID `db:"video_id" json:"video_id"`
+	UploadID  string    `db:"upload_id" json:"upload_id"` // id of the S3 multipart upload
+	ObjectKey string    `db:"object_key" json:"object_key"`
+	FileSize  int64     `db:"file_size" json:"file_size"`
+	PartSize  int64     `db:"part_size" json:"part_size"`
+	PartCount int32     `db:"part_count" json:"part_count"`
+	CreatedAt time.Time `db:"created_at" json:"created_at"`
+}
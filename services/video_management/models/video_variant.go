@@ -2,6 +2,7 @@ package models
 
 import (
 	"errors"
+	"fmt"
 	"strings"
 	"time"
 
@@ -17,6 +18,14 @@ type VideoVariant struct {
 	TotalSegments *int      `json:"total_segments"`
 	TotalDuration *int      `json:"total_duration"`
 	CreatedAt     time.Time `json:"created_at"`
+
+	// Stream info written to master playlists, nil for variants recorded before it was published
+	Width            *int     `json:"width"`
+	Height           *int     `json:"height"`
+	Bandwidth        *int     `json:"bandwidth"`
+	AverageBandwidth *int     `json:"average_bandwidth"`
+	FrameRate        *float64 `json:"frame_rate"`
+	Codecs           *string  `json:"codecs"`
 }
 
 // GetID returns the ID of the video variant
@@ -55,6 +64,46 @@ func (vv VideoVariant) GetTotalDuration() int {
 	return *vv.TotalDuration
 }
 
+// GetBandwidth returns the peak bitrate of the video variant in bits per second or 0 if nil
+func (vv VideoVariant) GetBandwidth() int {
+	if vv.Bandwidth == nil {
+		return 0
+	}
+	return *vv.Bandwidth
+}
+
+// GetAverageBandwidth returns the average bitrate of the video variant in bits per second or 0 if nil
+func (vv VideoVariant) GetAverageBandwidth() int {
+	if vv.AverageBandwidth == nil {
+		return 0
+	}
+	return *vv.AverageBandwidth
+}
+
+// GetResolution returns the resolution of the video variant as "WxH" or an empty string if unknown
+func (vv VideoVariant) GetResolution() string {
+	if vv.Width == nil || vv.Height == nil || *vv.Width <= 0 || *vv.Height <= 0 {
+		return ""
+	}
+	return fmt.Sprintf("%dx%d", *vv.Width, *vv.Height)
+}
+
+// GetFrameRate returns the frame rate of the video variant or 0 if nil
+func (vv VideoVariant) GetFrameRate() float64 {
+	if vv.FrameRate == nil {
+		return 0
+	}
+	return *vv.FrameRate
+}
+
+// GetCodecs returns the codecs of the video variant or an empty string if nil
+func (vv VideoVariant) GetCodecs() string {
+	if vv.Codecs == nil {
+		return ""
+	}
+	return *vv.Codecs
+}
+
 // GetCreatedAt returns the created timestamp of the video variant
 func (vv VideoVariant) GetCreatedAt() time.Time {
 	return vv.CreatedAt
@@ -90,5 +139,9 @@ func (vv VideoVariant) Validate() error {
 		return errors.New("total duration cannot be negative")
 	}
 
+	if vv.Bandwidth != nil && *vv.Bandwidth < 0 {
+		return errors.New("bandwidth cannot be negative")
+	}
+
 	return nil
 }
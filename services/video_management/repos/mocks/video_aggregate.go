@@ -6,6 +6,7 @@ import (
 	"github.com/gofrs/uuid"
 
 	"github.com/sweetloveinyourheart/sweet-reel/services/video_management/models"
+	"github.com/sweetloveinyourheart/sweet-reel/services/video_management/repos"
 )
 
 // MockVideoAggregateRepository is a mock implementation of IVideoAggregateRepository
@@ -13,8 +14,8 @@ type MockVideoAggregateRepository struct {
 	MockVideoRepository
 }
 
-func (m *MockVideoAggregateRepository) GetChannelVideos(ctx context.Context, uploaderID uuid.UUID, limit, offset int) ([]*models.ChannelVideo, error) {
-	args := m.Called(ctx, uploaderID, limit, offset)
+func (m *MockVideoAggregateRepository) GetChannelVideos(ctx context.Context, channelID uuid.UUID, viewerID uuid.UUID, limit, offset int) ([]*models.ChannelVideo, error) {
+	args := m.Called(ctx, channelID, viewerID, limit, offset)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
@@ -28,3 +29,16 @@ func (m *MockVideoAggregateRepository) GetVideoMetadata(ctx context.Context, vid
 	}
 	return args.Get(0).(*models.VideoMetadata), args.Error(1)
 }
+
+func (m *MockVideoAggregateRepository) GetReelFeed(ctx context.Context, limit, offset int) ([]*models.ChannelVideo, error) {
+	args := m.Called(ctx, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.ChannelVideo), args.Error(1)
+}
+
+// InTransaction runs fn against the mock itself, so expectations set on the mock apply inside the transaction
+func (m *MockVideoAggregateRepository) InTransaction(ctx context.Context, scope string, fn func(repo repos.IVideoAggregateRepository) error) error {
+	return fn(m)
+}
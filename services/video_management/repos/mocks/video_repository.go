@@ -7,6 +7,7 @@ import (
 	"github.com/gofrs/uuid"
 	"github.com/stretchr/testify/mock"
 
+	"github.com/sweetloveinyourheart/sweet-reel/pkg/messages"
 	"github.com/sweetloveinyourheart/sweet-reel/services/video_management/models"
 	"github.com/sweetloveinyourheart/sweet-reel/services/video_management/repos"
 )
@@ -52,11 +53,29 @@ func (m *MockVideoRepository) UpdateVideo(ctx context.Context, video *models.Vid
 	return args.Error(0)
 }
 
-func (m *MockVideoRepository) UpdateVideoProgress(ctx context.Context, id uuid.UUID, objectKey string, status models.VideoStatus, processedAt time.Time) error {
-	args := m.Called(ctx, id, objectKey, status, processedAt)
+func (m *MockVideoRepository) UpdateVideoDetails(ctx context.Context, video *models.Video) error {
+	args := m.Called(ctx, video)
+	return args.Error(0)
+}
+
+func (m *MockVideoRepository) UpdateVideoProgress(ctx context.Context, id uuid.UUID, objectKey string, status models.VideoStatus, processedAt time.Time, failure *models.VideoFailure) error {
+	args := m.Called(ctx, id, objectKey, status, processedAt, failure)
 	return args.Error(0)
 }
 
+func (m *MockVideoRepository) UpdateVideoFormat(ctx context.Context, id uuid.UUID, format models.VideoFormat) error {
+	args := m.Called(ctx, id, format)
+	return args.Error(0)
+}
+
+func (m *MockVideoRepository) PublishScheduledVideos(ctx context.Context, now time.Time) ([]*models.Video, error) {
+	args := m.Called(ctx, now)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Video), args.Error(1)
+}
+
 func (m *MockVideoRepository) DeleteVideo(ctx context.Context, id uuid.UUID) error {
 	args := m.Called(ctx, id)
 	return args.Error(0)
@@ -188,6 +207,16 @@ func (m *MockVideoRepository) GetVideoCountByChannelID(ctx context.Context, chan
 	return args.Get(0).(int64), args.Error(1)
 }
 
+func (m *MockVideoRepository) ListChannelIDs(ctx context.Context, limit, offset int) ([]uuid.UUID, error) {
+	args := m.Called(ctx, limit, offset)
+	return args.Get(0).([]uuid.UUID), args.Error(1)
+}
+
+func (m *MockVideoRepository) ListChannelIDsViewedSince(ctx context.Context, since time.Time, limit, offset int) ([]uuid.UUID, error) {
+	args := m.Called(ctx, since, limit, offset)
+	return args.Get(0).([]uuid.UUID), args.Error(1)
+}
+
 // Video view operations
 
 func (m *MockVideoRepository) CreateVideoView(ctx context.Context, view *models.VideoView) error {
@@ -195,6 +224,11 @@ func (m *MockVideoRepository) CreateVideoView(ctx context.Context, view *models.
 	return args.Error(0)
 }
 
+func (m *MockVideoRepository) UpdateVideoViewWatchDuration(ctx context.Context, viewID uuid.UUID, videoID uuid.UUID, viewerID *uuid.UUID, ipAddress *string, watchDuration int) error {
+	args := m.Called(ctx, viewID, videoID, viewerID, ipAddress, watchDuration)
+	return args.Error(0)
+}
+
 func (m *MockVideoRepository) IncrementVideoViewCount(ctx context.Context, videoID uuid.UUID) error {
 	args := m.Called(ctx, videoID)
 	return args.Error(0)
@@ -220,5 +254,103 @@ func (m *MockVideoRepository) HasViewedRecently(ctx context.Context, videoID uui
 	return args.Get(0).(bool), args.Error(1)
 }
 
+func (m *MockVideoRepository) LockVideoViewer(ctx context.Context, videoID uuid.UUID, viewerID *uuid.UUID, ipAddress *string) error {
+	args := m.Called(ctx, videoID, viewerID, ipAddress)
+	return args.Error(0)
+}
+
+// Video upload operations
+
+func (m *MockVideoRepository) CreateVideoUpload(ctx context.Context, upload *models.VideoUpload) error {
+	args := m.Called(ctx, upload)
+	return args.Error(0)
+}
+
+func (m *MockVideoRepository) GetVideoUploadByVideoID(ctx context.Context, videoID uuid.UUID) (*models.VideoUpload, error) {
+	args := m.Called(ctx, videoID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.VideoUpload), args.Error(1)
+}
+
+func (m *MockVideoRepository) DeleteVideoUpload(ctx context.Context, videoID uuid.UUID) error {
+	args := m.Called(ctx, videoID)
+	return args.Error(0)
+}
+
+func (m *MockVideoRepository) ListVideoUploadsCreatedBefore(ctx context.Context, before time.Time, after *models.VideoUpload, limit int) ([]*models.VideoUpload, error) {
+	args := m.Called(ctx, before, after, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.VideoUpload), args.Error(1)
+}
+
+// Video processing job operations
+
+func (m *MockVideoRepository) UpsertVideoProcessingJob(ctx context.Context, job *models.VideoProcessingJob) error {
+	args := m.Called(ctx, job)
+	return args.Error(0)
+}
+
+func (m *MockVideoRepository) GetVideoProcessingJobsByVideoID(ctx context.Context, videoID uuid.UUID) ([]*models.VideoProcessingJob, error) {
+	args := m.Called(ctx, videoID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.VideoProcessingJob), args.Error(1)
+}
+
+func (m *MockVideoRepository) GetLatestVideoProcessingJobByVideoID(ctx context.Context, videoID uuid.UUID) (*models.VideoProcessingJob, error) {
+	args := m.Called(ctx, videoID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.VideoProcessingJob), args.Error(1)
+}
+
+// Video content key operations
+
+func (m *MockVideoRepository) UpsertVideoContentKey(ctx context.Context, key *models.VideoContentKey) error {
+	args := m.Called(ctx, key)
+	return args.Error(0)
+}
+
+func (m *MockVideoRepository) GetVideoContentKeyByVideoID(ctx context.Context, videoID uuid.UUID) (*models.VideoContentKey, error) {
+	args := m.Called(ctx, videoID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.VideoContentKey), args.Error(1)
+}
+
+// Video storyboard operations
+
+func (m *MockVideoRepository) UpsertVideoStoryboard(ctx context.Context, storyboard *models.VideoStoryboard) error {
+	args := m.Called(ctx, storyboard)
+	return args.Error(0)
+}
+
+func (m *MockVideoRepository) GetVideoStoryboardByVideoID(ctx context.Context, videoID uuid.UUID) (*models.VideoStoryboard, error) {
+	args := m.Called(ctx, videoID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.VideoStoryboard), args.Error(1)
+}
+
+// Event operations
+
+func (m *MockVideoRepository) EnqueueEvent(ctx context.Context, topic string, key string, event messages.Event) error {
+	args := m.Called(ctx, topic, key, event)
+	return args.Error(0)
+}
+
+func (m *MockVideoRepository) MarkMessageProcessed(ctx context.Context, group string, messageID string) (bool, error) {
+	args := m.Called(ctx, group, messageID)
+	return args.Bool(0), args.Error(1)
+}
+
 // Ensure MockVideoRepository implements IVideoRepository
 var _ repos.IVideoRepository = (*MockVideoRepository)(nil)
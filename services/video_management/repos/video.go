@@ -2,11 +2,16 @@ package repos
 
 import (
 	"context"
+	"database/sql"
+	"encoding/json"
 	"time"
 
+	"github.com/cockroachdb/errors"
 	"github.com/gofrs/uuid"
 
 	"github.com/sweetloveinyourheart/sweet-reel/pkg/db"
+	"github.com/sweetloveinyourheart/sweet-reel/pkg/kafka"
+	"github.com/sweetloveinyourheart/sweet-reel/pkg/messages"
 	"github.com/sweetloveinyourheart/sweet-reel/services/video_management/models"
 )
 
@@ -17,7 +22,10 @@ type IVideoRepository interface {
 	GetVideosByUploaderID(ctx context.Context, uploaderID uuid.UUID, limit, offset int) ([]*models.Video, error)
 	GetVideosByChannelID(ctx context.Context, channelID uuid.UUID, limit, offset int) ([]*models.Video, error)
 	UpdateVideo(ctx context.Context, video *models.Video) error
-	UpdateVideoProgress(ctx context.Context, id uuid.UUID, objectKey string, status models.VideoStatus, processedAt time.Time) error
+	UpdateVideoDetails(ctx context.Context, video *models.Video) error
+	UpdateVideoProgress(ctx context.Context, id uuid.UUID, objectKey string, status models.VideoStatus, processedAt time.Time, failure *models.VideoFailure) error
+	UpdateVideoFormat(ctx context.Context, id uuid.UUID, format models.VideoFormat) error
+	PublishScheduledVideos(ctx context.Context, now time.Time) ([]*models.Video, error)
 	DeleteVideo(ctx context.Context, id uuid.UUID) error
 	ListVideos(ctx context.Context, limit, offset int) ([]*models.Video, error)
 
@@ -47,14 +55,41 @@ type IVideoRepository interface {
 	GetVideoCount(ctx context.Context) (int64, error)
 	GetVideoCountByUploaderID(ctx context.Context, uploaderID uuid.UUID) (int64, error)
 	GetVideoCountByChannelID(ctx context.Context, channelID uuid.UUID) (int64, error)
+	ListChannelIDs(ctx context.Context, limit, offset int) ([]uuid.UUID, error)
+	ListChannelIDsViewedSince(ctx context.Context, since time.Time, limit, offset int) ([]uuid.UUID, error)
 
 	// Video view operations
 	CreateVideoView(ctx context.Context, view *models.VideoView) error
+	UpdateVideoViewWatchDuration(ctx context.Context, viewID uuid.UUID, videoID uuid.UUID, viewerID *uuid.UUID, ipAddress *string, watchDuration int) error
 	IncrementVideoViewCount(ctx context.Context, videoID uuid.UUID) error
 	GetVideoViewCount(ctx context.Context, videoID uuid.UUID) (int64, error)
 	GetTotalViewsByUploaderID(ctx context.Context, uploaderID uuid.UUID) (int64, error)
 	GetTotalViewsByChannelID(ctx context.Context, channelID uuid.UUID) (int64, error)
 	HasViewedRecently(ctx context.Context, videoID uuid.UUID, viewerID *uuid.UUID, ipAddress *string, duration time.Duration) (bool, error)
+	LockVideoViewer(ctx context.Context, videoID uuid.UUID, viewerID *uuid.UUID, ipAddress *string) error
+
+	// Video upload operations
+	CreateVideoUpload(ctx context.Context, upload *models.VideoUpload) error
+	GetVideoUploadByVideoID(ctx context.Context, videoID uuid.UUID) (*models.VideoUpload, error)
+	DeleteVideoUpload(ctx context.Context, videoID uuid.UUID) error
+	ListVideoUploadsCreatedBefore(ctx context.Context, before time.Time, after *models.VideoUpload, limit int) ([]*models.VideoUpload, error)
+
+	// Video processing job operations
+	UpsertVideoProcessingJob(ctx context.Context, job *models.VideoProcessingJob) error
+	GetVideoProcessingJobsByVideoID(ctx context.Context, videoID uuid.UUID) ([]*models.VideoProcessingJob, error)
+	GetLatestVideoProcessingJobByVideoID(ctx context.Context, videoID uuid.UUID) (*models.VideoProcessingJob, error)
+
+	// Video content key operations
+	UpsertVideoContentKey(ctx context.Context, key *models.VideoContentKey) error
+	GetVideoContentKeyByVideoID(ctx context.Context, videoID uuid.UUID) (*models.VideoContentKey, error)
+
+	// Video storyboard operations
+	UpsertVideoStoryboard(ctx context.Context, storyboard *models.VideoStoryboard) error
+	GetVideoStoryboardByVideoID(ctx context.Context, videoID uuid.UUID) (*models.VideoStoryboard, error)
+
+	// Event operations
+	EnqueueEvent(ctx context.Context, topic string, key string, event messages.Event) error
+	MarkMessageProcessed(ctx context.Context, group string, messageID string) (bool, error)
 }
 
 type VideoRepository struct {
@@ -71,25 +106,25 @@ func NewVideoRepository(tx db.DbOrTx) IVideoRepository {
 
 func (r *VideoRepository) CreateVideo(ctx context.Context, video *models.Video) error {
 	query := `
-		INSERT INTO videos (id, uploader_id, channel_id, title, description, status, object_key, processed_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
+		INSERT INTO videos (id, uploader_id, channel_id, title, description, status, format, visibility, publish_at, object_key, processed_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)`
 
 	_, err := r.Tx.Exec(ctx, query,
 		video.ID, video.UploaderID, video.ChannelID, video.Title, video.Description, video.Status,
-		video.ObjectKey, video.ProcessedAt)
+		video.Format, video.Visibility, video.PublishAt, video.ObjectKey, video.ProcessedAt)
 	return err
 }
 
 func (r *VideoRepository) GetVideoByID(ctx context.Context, id uuid.UUID) (*models.Video, error) {
 	query := `
-		SELECT id, uploader_id, channel_id, title, description, status, object_key, processed_at, created_at, updated_at
+		SELECT id, uploader_id, channel_id, title, description, status, format, visibility, publish_at, object_key, processed_at, failure_code, failure_reason, created_at, updated_at
 		FROM videos WHERE id = $1`
 
 	video := &models.Video{}
 	err := r.Tx.QueryRow(ctx, query, id).Scan(
 		&video.ID, &video.UploaderID, &video.ChannelID, &video.Title, &video.Description,
-		&video.Status, &video.ObjectKey, &video.ProcessedAt,
-		&video.CreatedAt, &video.UpdatedAt)
+		&video.Status, &video.Format, &video.Visibility, &video.PublishAt, &video.ObjectKey, &video.ProcessedAt,
+		&video.FailureCode, &video.FailureReason, &video.CreatedAt, &video.UpdatedAt)
 
 	if err != nil {
 		return nil, err
@@ -99,7 +134,7 @@ func (r *VideoRepository) GetVideoByID(ctx context.Context, id uuid.UUID) (*mode
 
 func (r *VideoRepository) GetVideosByUploaderID(ctx context.Context, uploaderID uuid.UUID, limit, offset int) ([]*models.Video, error) {
 	query := `
-		SELECT id, uploader_id, channel_id, title, description, status, object_key, processed_at, created_at, updated_at
+		SELECT id, uploader_id, channel_id, title, description, status, format, visibility, publish_at, object_key, processed_at, failure_code, failure_reason, created_at, updated_at
 		FROM videos WHERE uploader_id = $1 ORDER BY created_at DESC LIMIT $2 OFFSET $3`
 
 	rows, err := r.Tx.Query(ctx, query, uploaderID, limit, offset)
@@ -113,8 +148,8 @@ func (r *VideoRepository) GetVideosByUploaderID(ctx context.Context, uploaderID
 		video := &models.Video{}
 		err := rows.Scan(
 			&video.ID, &video.UploaderID, &video.ChannelID, &video.Title, &video.Description,
-			&video.Status, &video.ObjectKey, &video.ProcessedAt,
-			&video.CreatedAt, &video.UpdatedAt)
+			&video.Status, &video.Format, &video.Visibility, &video.PublishAt, &video.ObjectKey, &video.ProcessedAt,
+			&video.FailureCode, &video.FailureReason, &video.CreatedAt, &video.UpdatedAt)
 		if err != nil {
 			return nil, err
 		}
@@ -125,7 +160,7 @@ func (r *VideoRepository) GetVideosByUploaderID(ctx context.Context, uploaderID
 
 func (r *VideoRepository) GetVideosByChannelID(ctx context.Context, channelID uuid.UUID, limit, offset int) ([]*models.Video, error) {
 	query := `
-		SELECT id, uploader_id, channel_id, title, description, status, object_key, processed_at, created_at, updated_at
+		SELECT id, uploader_id, channel_id, title, description, status, format, visibility, publish_at, object_key, processed_at, failure_code, failure_reason, created_at, updated_at
 		FROM videos WHERE channel_id = $1 ORDER BY created_at DESC LIMIT $2 OFFSET $3`
 
 	rows, err := r.Tx.Query(ctx, query, channelID, limit, offset)
@@ -139,8 +174,8 @@ func (r *VideoRepository) GetVideosByChannelID(ctx context.Context, channelID uu
 		video := &models.Video{}
 		err := rows.Scan(
 			&video.ID, &video.UploaderID, &video.ChannelID, &video.Title, &video.Description,
-			&video.Status, &video.ObjectKey, &video.ProcessedAt,
-			&video.CreatedAt, &video.UpdatedAt)
+			&video.Status, &video.Format, &video.Visibility, &video.PublishAt, &video.ObjectKey, &video.ProcessedAt,
+			&video.FailureCode, &video.FailureReason, &video.CreatedAt, &video.UpdatedAt)
 		if err != nil {
 			return nil, err
 		}
@@ -152,21 +187,76 @@ func (r *VideoRepository) GetVideosByChannelID(ctx context.Context, channelID uu
 func (r *VideoRepository) UpdateVideo(ctx context.Context, video *models.Video) error {
 	query := `
 		UPDATE videos SET uploader_id = $2, channel_id = $3, title = $4, description = $5, status = $6, 
-		object_key = $7, processed_at = $8
-		WHERE id = $1`
+		format = $7, visibility = $8, publish_at = $9, object_key = $10, processed_at = $11, updated_at = NOW()
+		WHERE id = $1
+		RETURNING updated_at`
 
-	_, err := r.Tx.Exec(ctx, query,
+	return r.Tx.QueryRow(ctx, query,
 		video.ID, video.UploaderID, video.ChannelID, video.Title, video.Description,
-		video.Status, video.ObjectKey, video.ProcessedAt)
+		video.Status, video.Format, video.Visibility, video.PublishAt, video.ObjectKey, video.ProcessedAt).Scan(&video.UpdatedAt)
+}
+
+// UpdateVideoDetails writes the fields uploaders edit: title, description, visibility and
+// publish date. Status, object key, format and processing date belong to the processing
+// consumer and are left as they are, however stale the given video is.
+func (r *VideoRepository) UpdateVideoDetails(ctx context.Context, video *models.Video) error {
+	query := `
+		UPDATE videos SET title = $2, description = $3, visibility = $4, publish_at = $5, updated_at = NOW()
+		WHERE id = $1
+		RETURNING updated_at`
+
+	return r.Tx.QueryRow(ctx, query,
+		video.ID, video.Title, video.Description, video.Visibility, video.PublishAt).Scan(&video.UpdatedAt)
+}
+
+// UpdateVideoProgress records the processing outcome of a video. The failure is cleared when
+// failure is nil, so a video that is processed again does not keep an old reason.
+func (r *VideoRepository) UpdateVideoProgress(ctx context.Context, id uuid.UUID, objectKey string, status models.VideoStatus, processedAt time.Time, failure *models.VideoFailure) error {
+	var failureCode, failureReason *string
+	if failure != nil {
+		failureCode, failureReason = &failure.Code, &failure.Reason
+	}
+
+	query := `UPDATE videos SET object_key = $2, status = $3, processed_at = $4, failure_code = $5, failure_reason = $6 WHERE id = $1`
+	_, err := r.Tx.Exec(ctx, query, id, objectKey, status, processedAt, failureCode, failureReason)
 	return err
 }
 
-func (r *VideoRepository) UpdateVideoProgress(ctx context.Context, id uuid.UUID, objectKey string, status models.VideoStatus, processedAt time.Time) error {
-	query := `UPDATE videos SET object_key = $2, status = $3, processed_at = $4 WHERE id = $1`
-	_, err := r.Tx.Exec(ctx, query, id, objectKey, status, processedAt)
+func (r *VideoRepository) UpdateVideoFormat(ctx context.Context, id uuid.UUID, format models.VideoFormat) error {
+	query := `UPDATE videos SET format = $2 WHERE id = $1`
+	_, err := r.Tx.Exec(ctx, query, id, format)
 	return err
 }
 
+// PublishScheduledVideos makes every video whose publish_at has passed public and returns them.
+// Each video is returned by exactly one call, even with concurrent callers.
+func (r *VideoRepository) PublishScheduledVideos(ctx context.Context, now time.Time) ([]*models.Video, error) {
+	query := `
+		UPDATE videos SET visibility = $1, publish_at = NULL, updated_at = NOW()
+		WHERE publish_at IS NOT NULL AND publish_at <= $2
+		RETURNING id, uploader_id, channel_id, title, description, status, format, visibility, publish_at, object_key, processed_at, failure_code, failure_reason, created_at, updated_at`
+
+	rows, err := r.Tx.Query(ctx, query, models.VideoVisibilityPublic, now)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var videos []*models.Video
+	for rows.Next() {
+		video := &models.Video{}
+		err := rows.Scan(
+			&video.ID, &video.UploaderID, &video.ChannelID, &video.Title, &video.Description,
+			&video.Status, &video.Format, &video.Visibility, &video.PublishAt, &video.ObjectKey, &video.ProcessedAt,
+			&video.FailureCode, &video.FailureReason, &video.CreatedAt, &video.UpdatedAt)
+		if err != nil {
+			return nil, err
+		}
+		videos = append(videos, video)
+	}
+	return videos, rows.Err()
+}
+
 func (r *VideoRepository) DeleteVideo(ctx context.Context, id uuid.UUID) error {
 	query := `DELETE FROM videos WHERE id = $1`
 	_, err := r.Tx.Exec(ctx, query, id)
@@ -175,7 +265,7 @@ func (r *VideoRepository) DeleteVideo(ctx context.Context, id uuid.UUID) error {
 
 func (r *VideoRepository) ListVideos(ctx context.Context, limit, offset int) ([]*models.Video, error) {
 	query := `
-		SELECT id, uploader_id, channel_id, title, description, status, object_key, processed_at, created_at, updated_at
+		SELECT id, uploader_id, channel_id, title, description, status, format, visibility, publish_at, object_key, processed_at, failure_code, failure_reason, created_at, updated_at
 		FROM videos ORDER BY created_at DESC LIMIT $1 OFFSET $2`
 
 	rows, err := r.Tx.Query(ctx, query, limit, offset)
@@ -189,8 +279,8 @@ func (r *VideoRepository) ListVideos(ctx context.Context, limit, offset int) ([]
 		video := &models.Video{}
 		err := rows.Scan(
 			&video.ID, &video.UploaderID, &video.ChannelID, &video.Title, &video.Description,
-			&video.Status, &video.ObjectKey, &video.ProcessedAt,
-			&video.CreatedAt, &video.UpdatedAt)
+			&video.Status, &video.Format, &video.Visibility, &video.PublishAt, &video.ObjectKey, &video.ProcessedAt,
+			&video.FailureCode, &video.FailureReason, &video.CreatedAt, &video.UpdatedAt)
 		if err != nil {
 			return nil, err
 		}
@@ -203,21 +293,23 @@ func (r *VideoRepository) ListVideos(ctx context.Context, limit, offset int) ([]
 
 func (r *VideoRepository) CreateVideoManifest(ctx context.Context, manifest *models.VideoManifest) error {
 	query := `
-		INSERT INTO video_manifests (id, video_id, object_key, quality, size_bytes)
-		VALUES ($1, $2, $3, $4, $5)`
+		INSERT INTO video_manifests (id, video_id, object_key, quality, format, size_bytes)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (video_id, format, quality) DO UPDATE
+		SET object_key = EXCLUDED.object_key, size_bytes = EXCLUDED.size_bytes`
 
 	_, err := r.Tx.Exec(ctx, query,
 		manifest.ID, manifest.VideoID, manifest.ObjectKey,
-		manifest.Quality, manifest.SizeBytes)
+		manifest.Quality, manifest.Format, manifest.SizeBytes)
 	return err
 }
 
 func (r *VideoRepository) GetVideoManifestsByVideoID(ctx context.Context, videoID uuid.UUID) ([]*models.VideoManifest, error) {
 	query := `
-		SELECT id, video_id, object_key, quality, size_bytes, created_at
+		SELECT id, video_id, object_key, quality, format, size_bytes, created_at
 		FROM video_manifests WHERE video_id = $1`
 
-	rows, err := r.Tx.Query(ctx, query)
+	rows, err := r.Tx.Query(ctx, query, videoID)
 	if err != nil {
 		return nil, err
 	}
@@ -228,7 +320,7 @@ func (r *VideoRepository) GetVideoManifestsByVideoID(ctx context.Context, videoI
 		manifest := &models.VideoManifest{}
 		err := rows.Scan(
 			&manifest.ID, &manifest.VideoID, &manifest.ObjectKey,
-			&manifest.Quality, &manifest.SizeBytes, &manifest.CreatedAt)
+			&manifest.Quality, &manifest.Format, &manifest.SizeBytes, &manifest.CreatedAt)
 		if err != nil {
 			return nil, err
 		}
@@ -239,11 +331,11 @@ func (r *VideoRepository) GetVideoManifestsByVideoID(ctx context.Context, videoI
 
 func (r *VideoRepository) UpdateVideoManifest(ctx context.Context, manifest *models.VideoManifest) error {
 	query := `
-		UPDATE video_manifests SET video_id = $2, object_key = $3, quality = $4, size_bytes = $5
+		UPDATE video_manifests SET video_id = $2, object_key = $3, quality = $4, format = $5, size_bytes = $6
 		WHERE id = $1`
 
 	_, err := r.Tx.Exec(ctx, query,
-		manifest.ID, manifest.VideoID, manifest.ObjectKey, manifest.Quality, manifest.SizeBytes)
+		manifest.ID, manifest.VideoID, manifest.ObjectKey, manifest.Quality, manifest.Format, manifest.SizeBytes)
 	return err
 }
 
@@ -257,18 +349,25 @@ func (r *VideoRepository) DeleteVideoManifest(ctx context.Context, id uuid.UUID)
 
 func (r *VideoRepository) CreateVideoVariant(ctx context.Context, variant *models.VideoVariant) error {
 	query := `
-		INSERT INTO video_variants (id, video_id, quality, object_key, total_segments, total_duration)
-		VALUES ($1, $2, $3, $4, $5, $6)`
+		INSERT INTO video_variants (id, video_id, quality, object_key, total_segments, total_duration,
+			width, height, bandwidth, average_bandwidth, frame_rate, codecs)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+		ON CONFLICT (video_id, quality) DO UPDATE
+		SET object_key = EXCLUDED.object_key, total_segments = EXCLUDED.total_segments, total_duration = EXCLUDED.total_duration,
+			width = EXCLUDED.width, height = EXCLUDED.height, bandwidth = EXCLUDED.bandwidth,
+			average_bandwidth = EXCLUDED.average_bandwidth, frame_rate = EXCLUDED.frame_rate, codecs = EXCLUDED.codecs`
 
 	_, err := r.Tx.Exec(ctx, query,
 		variant.ID, variant.VideoID, variant.Quality, variant.ObjectKey,
-		variant.TotalSegments, variant.TotalDuration)
+		variant.TotalSegments, variant.TotalDuration,
+		variant.Width, variant.Height, variant.Bandwidth, variant.AverageBandwidth, variant.FrameRate, variant.Codecs)
 	return err
 }
 
 func (r *VideoRepository) GetVideoVariantsByVideoID(ctx context.Context, videoID uuid.UUID) ([]*models.VideoVariant, error) {
 	query := `
-		SELECT id, video_id, quality, object_key, total_segments, total_duration, created_at
+		SELECT id, video_id, quality, object_key, total_segments, total_duration, created_at,
+			width, height, bandwidth, average_bandwidth, frame_rate, codecs
 		FROM video_variants WHERE video_id = $1 ORDER BY quality`
 
 	rows, err := r.Tx.Query(ctx, query, videoID)
@@ -282,7 +381,8 @@ func (r *VideoRepository) GetVideoVariantsByVideoID(ctx context.Context, videoID
 		variant := &models.VideoVariant{}
 		err := rows.Scan(
 			&variant.ID, &variant.VideoID, &variant.Quality, &variant.ObjectKey,
-			&variant.TotalSegments, &variant.TotalDuration, &variant.CreatedAt)
+			&variant.TotalSegments, &variant.TotalDuration, &variant.CreatedAt,
+			&variant.Width, &variant.Height, &variant.Bandwidth, &variant.AverageBandwidth, &variant.FrameRate, &variant.Codecs)
 		if err != nil {
 			return nil, err
 		}
@@ -293,13 +393,15 @@ func (r *VideoRepository) GetVideoVariantsByVideoID(ctx context.Context, videoID
 
 func (r *VideoRepository) GetVideoVariantByID(ctx context.Context, id uuid.UUID) (*models.VideoVariant, error) {
 	query := `
-		SELECT id, video_id, quality, object_key, total_segments, total_duration, created_at
+		SELECT id, video_id, quality, object_key, total_segments, total_duration, created_at,
+			width, height, bandwidth, average_bandwidth, frame_rate, codecs
 		FROM video_variants WHERE id = $1`
 
 	variant := &models.VideoVariant{}
 	err := r.Tx.QueryRow(ctx, query, id).Scan(
 		&variant.ID, &variant.VideoID, &variant.Quality, &variant.ObjectKey,
-		&variant.TotalSegments, &variant.TotalDuration, &variant.CreatedAt)
+		&variant.TotalSegments, &variant.TotalDuration, &variant.CreatedAt,
+		&variant.Width, &variant.Height, &variant.Bandwidth, &variant.AverageBandwidth, &variant.FrameRate, &variant.Codecs)
 
 	if err != nil {
 		return nil, err
@@ -310,11 +412,13 @@ func (r *VideoRepository) GetVideoVariantByID(ctx context.Context, id uuid.UUID)
 func (r *VideoRepository) UpdateVideoVariant(ctx context.Context, variant *models.VideoVariant) error {
 	query := `
 		UPDATE video_variants SET video_id = $2, quality = $3, object_key = $4, 
-		total_segments = $5, total_duration = $6 WHERE id = $1`
+		total_segments = $5, total_duration = $6, width = $7, height = $8, bandwidth = $9,
+		average_bandwidth = $10, frame_rate = $11, codecs = $12 WHERE id = $1`
 
 	_, err := r.Tx.Exec(ctx, query,
 		variant.ID, variant.VideoID, variant.Quality, variant.ObjectKey,
-		variant.TotalSegments, variant.TotalDuration)
+		variant.TotalSegments, variant.TotalDuration,
+		variant.Width, variant.Height, variant.Bandwidth, variant.AverageBandwidth, variant.FrameRate, variant.Codecs)
 	return err
 }
 
@@ -335,7 +439,9 @@ func (r *VideoRepository) DeleteVideoVariantsByVideoID(ctx context.Context, vide
 func (r *VideoRepository) CreateVideoThumbnail(ctx context.Context, thumbnail *models.VideoThumbnail) error {
 	query := `
 		INSERT INTO video_thumbnails (id, video_id, object_key, width, height)
-		VALUES ($1, $2, $3, $4, $5)`
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (video_id, object_key) DO UPDATE
+		SET width = EXCLUDED.width, height = EXCLUDED.height`
 
 	_, err := r.Tx.Exec(ctx, query,
 		thumbnail.ID, thumbnail.VideoID, thumbnail.ObjectKey,
@@ -430,6 +536,54 @@ func (r *VideoRepository) GetVideoCountByChannelID(ctx context.Context, channelI
 	return count, err
 }
 
+// ListChannelIDs lists the channels that have at least one video
+func (r *VideoRepository) ListChannelIDs(ctx context.Context, limit, offset int) ([]uuid.UUID, error) {
+	query := `SELECT DISTINCT channel_id FROM videos ORDER BY channel_id LIMIT $1 OFFSET $2`
+
+	rows, err := r.Tx.Query(ctx, query, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var channelIDs []uuid.UUID
+	for rows.Next() {
+		var channelID uuid.UUID
+		if err := rows.Scan(&channelID); err != nil {
+			return nil, err
+		}
+		channelIDs = append(channelIDs, channelID)
+	}
+	return channelIDs, rows.Err()
+}
+
+// ListChannelIDsViewedSince lists the channels with videos viewed since the given time
+func (r *VideoRepository) ListChannelIDsViewedSince(ctx context.Context, since time.Time, limit, offset int) ([]uuid.UUID, error) {
+	query := `
+		SELECT DISTINCT v.channel_id
+		FROM video_views vv
+		JOIN videos v ON v.id = vv.video_id
+		WHERE vv.viewed_at >= $1
+		ORDER BY v.channel_id
+		LIMIT $2 OFFSET $3`
+
+	rows, err := r.Tx.Query(ctx, query, since, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var channelIDs []uuid.UUID
+	for rows.Next() {
+		var channelID uuid.UUID
+		if err := rows.Scan(&channelID); err != nil {
+			return nil, err
+		}
+		channelIDs = append(channelIDs, channelID)
+	}
+	return channelIDs, rows.Err()
+}
+
 // Video view operations
 
 func (r *VideoRepository) CreateVideoView(ctx context.Context, view *models.VideoView) error {
@@ -443,6 +597,38 @@ func (r *VideoRepository) CreateVideoView(ctx context.Context, view *models.Vide
 	return err
 }
 
+// viewWatchDurationSlack is how many seconds a reported watch time may run ahead of the time
+// since the view was recorded, for players that report a little ahead and for clock skew
+const viewWatchDurationSlack = 30
+
+// UpdateVideoViewWatchDuration records how long a view has been watched for.
+// Only the viewer of the view, or its IP address when the view is anonymous, can update it.
+// The duration only ever grows, so heartbeats arriving out of order do not lower it, and is
+// capped at the time elapsed since the view was recorded.
+func (r *VideoRepository) UpdateVideoViewWatchDuration(ctx context.Context, viewID uuid.UUID, videoID uuid.UUID, viewerID *uuid.UUID, ipAddress *string, watchDuration int) error {
+	query := `
+		UPDATE video_views
+		SET watch_duration = GREATEST(
+			COALESCE(watch_duration, 0),
+			LEAST($5, FLOOR(EXTRACT(EPOCH FROM NOW() - viewed_at))::INT + $6)
+		)
+		WHERE id = $1 AND video_id = $2
+			AND CASE
+				WHEN $3::UUID IS NOT NULL THEN viewer_id = $3
+				ELSE viewer_id IS NULL AND ip_address = $4
+			END`
+
+	result, err := r.Tx.Exec(ctx, query, viewID, videoID, viewerID, ipAddress, watchDuration, viewWatchDurationSlack)
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
 func (r *VideoRepository) IncrementVideoViewCount(ctx context.Context, videoID uuid.UUID) error {
 	query := `UPDATE videos SET view_count = view_count + 1, updated_at = NOW() WHERE id = $1`
 	_, err := r.Tx.Exec(ctx, query, videoID)
@@ -511,3 +697,279 @@ func (r *VideoRepository) HasViewedRecently(ctx context.Context, videoID uuid.UU
 	}
 	return exists, nil
 }
+
+// LockVideoViewer serializes the views of one viewer, or IP address for anonymous viewers, on
+// a video until the transaction ends, so that concurrent views see each other when deduplicated
+func (r *VideoRepository) LockVideoViewer(ctx context.Context, videoID uuid.UUID, viewerID *uuid.UUID, ipAddress *string) error {
+	var viewer string
+	if viewerID != nil {
+		viewer = "viewer:" + viewerID.String()
+	} else if ipAddress != nil {
+		viewer = "ip:" + *ipAddress
+	} else {
+		return nil
+	}
+
+	_, err := r.Tx.Exec(ctx, `SELECT pg_advisory_xact_lock(hashtextextended($1, 0))`, "video_views:"+videoID.String()+":"+viewer)
+	return err
+}
+
+// Video upload operations
+
+func (r *VideoRepository) CreateVideoUpload(ctx context.Context, upload *models.VideoUpload) error {
+	query := `
+		INSERT INTO video_uploads (video_id, upload_id, object_key, file_size, part_size, part_count)
+		VALUES ($1, $2, $3, $4, $5, $6)`
+
+	_, err := r.Tx.Exec(ctx, query,
+		upload.VideoID, upload.UploadID, upload.ObjectKey,
+		upload.FileSize, upload.PartSize, upload.PartCount)
+	return err
+}
+
+func (r *VideoRepository) GetVideoUploadByVideoID(ctx context.Context, videoID uuid.UUID) (*models.VideoUpload, error) {
+	query := `
+		SELECT video_id, upload_id, object_key, file_size, part_size, part_count, created_at
+		FROM video_uploads WHERE video_id = $1`
+
+	upload := &models.VideoUpload{}
+	err := r.Tx.QueryRow(ctx, query, videoID).Scan(
+		&upload.VideoID, &upload.UploadID, &upload.ObjectKey,
+		&upload.FileSize, &upload.PartSize, &upload.PartCount, &upload.CreatedAt)
+
+	if err != nil {
+		return nil, err
+	}
+	return upload, nil
+}
+
+// DeleteVideoUpload removes the upload of a video and returns sql.ErrNoRows when there is none,
+// so that a single caller ends the upload even when it is completed and aborted concurrently.
+func (r *VideoRepository) DeleteVideoUpload(ctx context.Context, videoID uuid.UUID) error {
+	query := `DELETE FROM video_uploads WHERE video_id = $1`
+
+	result, err := r.Tx.Exec(ctx, query, videoID)
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// ListVideoUploadsCreatedBefore lists the oldest uploads started before the given time, in the
+// order they were started. Passing the last upload of a page as after lists the next page.
+func (r *VideoRepository) ListVideoUploadsCreatedBefore(ctx context.Context, before time.Time, after *models.VideoUpload, limit int) ([]*models.VideoUpload, error) {
+	query := `
+		SELECT video_id, upload_id, object_key, file_size, part_size, part_count, created_at
+		FROM video_uploads
+		WHERE created_at < $1
+			AND ($2::TIMESTAMP IS NULL OR (created_at, video_id) > ($2, $3))
+		ORDER BY created_at, video_id
+		LIMIT $4`
+
+	var afterCreatedAt *time.Time
+	var afterVideoID *uuid.UUID
+	if after != nil {
+		afterCreatedAt = &after.CreatedAt
+		afterVideoID = &after.VideoID
+	}
+
+	rows, err := r.Tx.Query(ctx, query, before, afterCreatedAt, afterVideoID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var uploads []*models.VideoUpload
+	for rows.Next() {
+		upload := &models.VideoUpload{}
+		err := rows.Scan(
+			&upload.VideoID, &upload.UploadID, &upload.ObjectKey,
+			&upload.FileSize, &upload.PartSize, &upload.PartCount, &upload.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		uploads = append(uploads, upload)
+	}
+	return uploads, rows.Err()
+}
+
+// Video processing job operations
+
+// UpsertVideoProcessingJob records the state of a processing job. Jobs that already finished
+// are left as they are, so a late report of a running job does not reopen them.
+func (r *VideoRepository) UpsertVideoProcessingJob(ctx context.Context, job *models.VideoProcessingJob) error {
+	stages := job.Stages
+	if stages == nil {
+		stages = []models.VideoProcessingStage{}
+	}
+	stagesJSON, err := json.Marshal(stages)
+	if err != nil {
+		return errors.Wrapf(err, "failed to marshal stages of processing job %s", job.ID)
+	}
+
+	var progressJSON []byte
+	if job.Progress != nil {
+		progressJSON, err = json.Marshal(job.Progress)
+		if err != nil {
+			return errors.Wrapf(err, "failed to marshal progress of processing job %s", job.ID)
+		}
+	}
+
+	query := `
+		INSERT INTO video_processing_jobs (id, video_id, attempt, status, stages, progress, error_code, error_message, started_at, finished_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		ON CONFLICT (id) DO UPDATE SET
+			status = EXCLUDED.status, stages = EXCLUDED.stages, progress = COALESCE(EXCLUDED.progress, video_processing_jobs.progress),
+			error_code = EXCLUDED.error_code, error_message = EXCLUDED.error_message, finished_at = EXCLUDED.finished_at, updated_at = NOW()
+		WHERE video_processing_jobs.status = 'running'`
+
+	_, err = r.Tx.Exec(ctx, query,
+		job.ID, job.VideoID, job.Attempt, job.Status, stagesJSON, progressJSON,
+		job.ErrorCode, job.ErrorMessage, job.StartedAt, job.FinishedAt)
+	return err
+}
+
+// GetVideoProcessingJobsByVideoID lists the processing jobs of a video, oldest first
+func (r *VideoRepository) GetVideoProcessingJobsByVideoID(ctx context.Context, videoID uuid.UUID) ([]*models.VideoProcessingJob, error) {
+	query := `
+		SELECT id, video_id, attempt, status, stages, progress, error_code, error_message, started_at, finished_at, created_at, updated_at
+		FROM video_processing_jobs WHERE video_id = $1 ORDER BY started_at`
+
+	rows, err := r.Tx.Query(ctx, query, videoID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var jobs []*models.VideoProcessingJob
+	for rows.Next() {
+		job := &models.VideoProcessingJob{}
+		var stagesJSON, progressJSON []byte
+		err := rows.Scan(
+			&job.ID, &job.VideoID, &job.Attempt, &job.Status, &stagesJSON, &progressJSON,
+			&job.ErrorCode, &job.ErrorMessage, &job.StartedAt, &job.FinishedAt, &job.CreatedAt, &job.UpdatedAt)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(stagesJSON, &job.Stages); err != nil {
+			return nil, errors.Wrapf(err, "failed to unmarshal stages of processing job %s", job.ID)
+		}
+		if len(progressJSON) > 0 {
+			if err := json.Unmarshal(progressJSON, &job.Progress); err != nil {
+				return nil, errors.Wrapf(err, "failed to unmarshal progress of processing job %s", job.ID)
+			}
+		}
+		jobs = append(jobs, job)
+	}
+	return jobs, rows.Err()
+}
+
+// GetLatestVideoProcessingJobByVideoID returns the processing job of a video started last
+func (r *VideoRepository) GetLatestVideoProcessingJobByVideoID(ctx context.Context, videoID uuid.UUID) (*models.VideoProcessingJob, error) {
+	query := `
+		SELECT id, video_id, attempt, status, stages, progress, error_code, error_message, started_at, finished_at, created_at, updated_at
+		FROM video_processing_jobs WHERE video_id = $1 ORDER BY started_at DESC LIMIT 1`
+
+	job := &models.VideoProcessingJob{}
+	var stagesJSON, progressJSON []byte
+	err := r.Tx.QueryRow(ctx, query, videoID).Scan(
+		&job.ID, &job.VideoID, &job.Attempt, &job.Status, &stagesJSON, &progressJSON,
+		&job.ErrorCode, &job.ErrorMessage, &job.StartedAt, &job.FinishedAt, &job.CreatedAt, &job.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(stagesJSON, &job.Stages); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal stages of processing job %s", job.ID)
+	}
+	if len(progressJSON) > 0 {
+		if err := json.Unmarshal(progressJSON, &job.Progress); err != nil {
+			return nil, errors.Wrapf(err, "failed to unmarshal progress of processing job %s", job.ID)
+		}
+	}
+	return job, nil
+}
+
+// Video content key operations
+
+// UpsertVideoContentKey records the content key of a video, replacing the key of an earlier
+// processing of the video whose segments were overwritten
+func (r *VideoRepository) UpsertVideoContentKey(ctx context.Context, key *models.VideoContentKey) error {
+	query := `
+		INSERT INTO video_content_keys (video_id, encrypted_key)
+		VALUES ($1, $2)
+		ON CONFLICT (video_id) DO UPDATE
+		SET encrypted_key = EXCLUDED.encrypted_key, updated_at = NOW()`
+
+	_, err := r.Tx.Exec(ctx, query, key.VideoID, key.EncryptedKey)
+	return err
+}
+
+func (r *VideoRepository) GetVideoContentKeyByVideoID(ctx context.Context, videoID uuid.UUID) (*models.VideoContentKey, error) {
+	query := `
+		SELECT video_id, encrypted_key, created_at, updated_at
+		FROM video_content_keys WHERE video_id = $1`
+
+	key := &models.VideoContentKey{}
+	err := r.Tx.QueryRow(ctx, query, videoID).Scan(
+		&key.VideoID, &key.EncryptedKey, &key.CreatedAt, &key.UpdatedAt)
+
+	if err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// Video storyboard operations
+
+// UpsertVideoStoryboard records the storyboard of a video, replacing the one of an earlier
+// processing of the video
+func (r *VideoRepository) UpsertVideoStoryboard(ctx context.Context, storyboard *models.VideoStoryboard) error {
+	query := `
+		INSERT INTO video_storyboards (video_id, object_key, interval_seconds, tile_width, tile_height, columns, rows, sprites)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (video_id) DO UPDATE
+		SET object_key = EXCLUDED.object_key, interval_seconds = EXCLUDED.interval_seconds,
+			tile_width = EXCLUDED.tile_width, tile_height = EXCLUDED.tile_height,
+			columns = EXCLUDED.columns, rows = EXCLUDED.rows, sprites = EXCLUDED.sprites,
+			updated_at = NOW()`
+
+	_, err := r.Tx.Exec(ctx, query,
+		storyboard.VideoID, storyboard.ObjectKey, storyboard.IntervalSeconds, storyboard.TileWidth,
+		storyboard.TileHeight, storyboard.Columns, storyboard.Rows, storyboard.Sprites)
+	return err
+}
+
+func (r *VideoRepository) GetVideoStoryboardByVideoID(ctx context.Context, videoID uuid.UUID) (*models.VideoStoryboard, error) {
+	query := `
+		SELECT video_id, object_key, interval_seconds, tile_width, tile_height, columns, rows, sprites, created_at, updated_at
+		FROM video_storyboards WHERE video_id = $1`
+
+	storyboard := &models.VideoStoryboard{}
+	err := r.Tx.QueryRow(ctx, query, videoID).Scan(
+		&storyboard.VideoID, &storyboard.ObjectKey, &storyboard.IntervalSeconds, &storyboard.TileWidth,
+		&storyboard.TileHeight, &storyboard.Columns, &storyboard.Rows, &storyboard.Sprites,
+		&storyboard.CreatedAt, &storyboard.UpdatedAt)
+
+	if err != nil {
+		return nil, err
+	}
+	return storyboard, nil
+}
+
+// Event operations
+
+// EnqueueEvent writes an event to the outbox, from where the relay publishes it to Kafka.
+// Within a transaction the event is only published if the transaction commits.
+func (r *VideoRepository) EnqueueEvent(ctx context.Context, topic string, key string, event messages.Event) error {
+	return kafka.EnqueueEvent(ctx, r.Tx, topic, key, event)
+}
+
+// MarkMessageProcessed records a consumed message and returns false when it was already recorded.
+// Within a transaction the record only sticks if the writes the message caused commit too.
+func (r *VideoRepository) MarkMessageProcessed(ctx context.Context, group string, messageID string) (bool, error) {
+	return db.MarkMessageProcessed(ctx, r.Tx, group, messageID)
+}
@@ -6,6 +6,8 @@ import (
 	"time"
 
 	"github.com/gofrs/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
 
 	"github.com/sweetloveinyourheart/sweet-reel/pkg/db"
 	"github.com/sweetloveinyourheart/sweet-reel/services/video_management/models"
@@ -13,8 +15,10 @@ import (
 
 type IVideoAggregateRepository interface {
 	IVideoRepository
-	GetChannelVideos(ctx context.Context, uploaderID uuid.UUID, limit, offset int) ([]*models.ChannelVideo, error)
+	GetChannelVideos(ctx context.Context, channelID uuid.UUID, viewerID uuid.UUID, limit, offset int) ([]*models.ChannelVideo, error)
 	GetVideoMetadata(ctx context.Context, videoID uuid.UUID) (*models.VideoMetadata, error)
+	GetReelFeed(ctx context.Context, limit, offset int) ([]*models.ChannelVideo, error)
+	InTransaction(ctx context.Context, scope string, fn func(repo IVideoAggregateRepository) error) error
 }
 
 type VideoAggregateRepository struct {
@@ -29,7 +33,25 @@ func NewVideoAggregateRepository(tx db.DbOrTx) IVideoAggregateRepository {
 	}
 }
 
-func (r *VideoAggregateRepository) GetChannelVideos(ctx context.Context, channelID uuid.UUID, limit, offset int) ([]*models.ChannelVideo, error) {
+// InTransaction runs fn with a repository whose operations all belong to one transaction.
+// The transaction commits when fn returns nil and rolls back otherwise. A repository that
+// is already bound to a transaction runs fn within it.
+func (r *VideoAggregateRepository) InTransaction(ctx context.Context, scope string, fn func(repo IVideoAggregateRepository) error) error {
+	pool, ok := r.Tx.(db.ConnPool)
+	if !ok {
+		return fn(r)
+	}
+
+	return pool.AcquireFunc(ctx, func(conn *pgxpool.Conn) error {
+		return db.TransactionC(ctx, conn.Conn(), scope, func(tx pgx.Tx) error {
+			return fn(NewVideoAggregateRepository(tx))
+		})
+	})
+}
+
+// GetChannelVideos lists the ready videos of a channel. Only public videos are listed,
+// except for their uploader who also sees unlisted and private ones.
+func (r *VideoAggregateRepository) GetChannelVideos(ctx context.Context, channelID uuid.UUID, viewerID uuid.UUID, limit, offset int) ([]*models.ChannelVideo, error) {
 	query := `
 		SELECT
 			videos.id,
@@ -38,6 +60,9 @@ func (r *VideoAggregateRepository) GetChannelVideos(ctx context.Context, channel
 			title,
 			description,
 			status,
+			format,
+			visibility,
+			publish_at,
 			videos.object_key,
 			processed_at,
 			videos.created_at,
@@ -50,11 +75,11 @@ func (r *VideoAggregateRepository) GetChannelVideos(ctx context.Context, channel
 		FROM videos
 		LEFT JOIN video_thumbnails ON videos.id = video_thumbnails.video_id
 		LEFT JOIN video_variants ON videos.id = video_variants.video_id
-		WHERE channel_id = $1 AND status = 'ready'
+		WHERE channel_id = $1 AND status = 'ready' AND (visibility = 'public' OR uploader_id = $2)
 		ORDER BY videos.created_at DESC, video_thumbnails.created_at ASC
-		LIMIT $2 OFFSET $3`
+		LIMIT $3 OFFSET $4`
 
-	rows, err := r.Tx.Query(ctx, query, channelID, limit, offset)
+	rows, err := r.Tx.Query(ctx, query, channelID, viewerID, limit, offset)
 	if err != nil {
 		return nil, err
 	}
@@ -72,6 +97,9 @@ func (r *VideoAggregateRepository) GetChannelVideos(ctx context.Context, channel
 			title                string
 			description          *string
 			status               models.VideoStatus
+			format               models.VideoFormat
+			visibility           models.VideoVisibility
+			publishAt            *time.Time
 			objectKey            *string
 			processedAt          *time.Time
 			createdAt            time.Time
@@ -85,7 +113,7 @@ func (r *VideoAggregateRepository) GetChannelVideos(ctx context.Context, channel
 
 		err := rows.Scan(
 			&videoID, &uploaderID, &channelID, &title, &description,
-			&status, &objectKey, &processedAt,
+			&status, &format, &visibility, &publishAt, &objectKey, &processedAt,
 			&createdAt, &updatedAt, &viewCount,
 			&thumbnailID, &thumbnailObjectKey,
 			&variantID, &variantTotalDuration)
@@ -105,6 +133,9 @@ func (r *VideoAggregateRepository) GetChannelVideos(ctx context.Context, channel
 					Title:       title,
 					Description: description,
 					Status:      status,
+					Format:      format,
+					Visibility:  visibility,
+					PublishAt:   publishAt,
 					ObjectKey:   objectKey,
 					ProcessedAt: processedAt,
 					ViewCount:   viewCount,
@@ -151,6 +182,9 @@ func (r *VideoAggregateRepository) GetVideoMetadata(ctx context.Context, videoID
 			title,
 			description,
 			status,
+			format,
+			visibility,
+			publish_at,
 			videos.object_key,
 			processed_at,
 			videos.created_at,
@@ -180,6 +214,9 @@ func (r *VideoAggregateRepository) GetVideoMetadata(ctx context.Context, videoID
 			title          string
 			description    *string
 			status         models.VideoStatus
+			format         models.VideoFormat
+			visibility     models.VideoVisibility
+			publishAt      *time.Time
 			objectKey      *string
 			processedAt    *time.Time
 			createdAt      time.Time
@@ -191,7 +228,7 @@ func (r *VideoAggregateRepository) GetVideoMetadata(ctx context.Context, videoID
 
 		err := rows.Scan(
 			&vID, &uploaderID, &channelID, &title, &description,
-			&status, &objectKey, &processedAt,
+			&status, &format, &visibility, &publishAt, &objectKey, &processedAt,
 			&createdAt, &updatedAt, &viewCount,
 			&variantID, &variantQuality)
 		if err != nil {
@@ -207,6 +244,9 @@ func (r *VideoAggregateRepository) GetVideoMetadata(ctx context.Context, videoID
 					Title:       title,
 					Description: description,
 					Status:      status,
+					Format:      format,
+					Visibility:  visibility,
+					PublishAt:   publishAt,
 					ObjectKey:   objectKey,
 					ProcessedAt: processedAt,
 					CreatedAt:   createdAt,
@@ -236,3 +276,72 @@ func (r *VideoAggregateRepository) GetVideoMetadata(ctx context.Context, videoID
 
 	return metadata, nil
 }
+
+func (r *VideoAggregateRepository) GetReelFeed(ctx context.Context, limit, offset int) ([]*models.ChannelVideo, error) {
+	// Thumbnail and duration are picked with subqueries so that LIMIT/OFFSET page over videos
+	query := `
+		SELECT
+			videos.id,
+			uploader_id,
+			channel_id,
+			title,
+			description,
+			status,
+			format,
+			visibility,
+			publish_at,
+			videos.object_key,
+			processed_at,
+			videos.created_at,
+			videos.updated_at,
+			videos.view_count,
+			(SELECT video_thumbnails.object_key FROM video_thumbnails
+				WHERE video_thumbnails.video_id = videos.id
+				ORDER BY video_thumbnails.created_at ASC LIMIT 1),
+			(SELECT MAX(video_variants.total_duration) FROM video_variants
+				WHERE video_variants.video_id = videos.id)
+		FROM videos
+		WHERE format = $1 AND status = 'ready' AND visibility = 'public'
+		ORDER BY processed_at DESC, videos.id DESC
+		LIMIT $2 OFFSET $3`
+
+	rows, err := r.Tx.Query(ctx, query, models.VideoFormatReel, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	videos := make([]*models.ChannelVideo, 0)
+	for rows.Next() {
+		var (
+			thumbnailObjectKey *string
+			totalDuration      *int
+		)
+
+		video := &models.ChannelVideo{}
+		err := rows.Scan(
+			&video.ID, &video.UploaderID, &video.ChannelID, &video.Title, &video.Description,
+			&video.Status, &video.Format, &video.Visibility, &video.PublishAt, &video.ObjectKey, &video.ProcessedAt,
+			&video.CreatedAt, &video.UpdatedAt, &video.ViewCount,
+			&thumbnailObjectKey, &totalDuration)
+		if err != nil {
+			return nil, err
+		}
+
+		if thumbnailObjectKey != nil {
+			video.ThumbnailObjectKey = *thumbnailObjectKey
+		}
+
+		if totalDuration != nil {
+			video.TotalDuration = *totalDuration
+		}
+
+		videos = append(videos, video)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return videos, nil
+}
@@ -3,18 +3,37 @@ package videomanagement
 import (
 	"context"
 	"embed"
+	"time"
 
+	"github.com/sweetloveinyourheart/sweet-reel/services/video_management/domains/channelstats"
 	"github.com/sweetloveinyourheart/sweet-reel/services/video_management/domains/processing"
+	"github.com/sweetloveinyourheart/sweet-reel/services/video_management/domains/publishing"
+	"github.com/sweetloveinyourheart/sweet-reel/services/video_management/domains/uploads"
 )
 
 //go:embed migrations/*.sql
 var FS embed.FS
 
-func InitializeRepos(ctx context.Context) error {
+func InitializeRepos(ctx context.Context, publishInterval time.Duration, reconcileInterval time.Duration, viewsFlushInterval time.Duration, uploadCleanupInterval time.Duration, uploadTTL time.Duration) error {
 	_, err := processing.NewVideoProcessManager(ctx)
 	if err != nil {
 		return err
 	}
 
+	_, err = publishing.NewPublishScheduler(ctx, publishInterval)
+	if err != nil {
+		return err
+	}
+
+	_, err = channelstats.NewReconciler(ctx, reconcileInterval, viewsFlushInterval)
+	if err != nil {
+		return err
+	}
+
+	_, err = uploads.NewUploadCleaner(ctx, uploadCleanupInterval, uploadTTL)
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
@@ -0,0 +1,58 @@
+package processing
+
+import (
+	"os"
+	"path"
+	"path/filepath"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/sweetloveinyourheart/sweet-reel/pkg/ffmpeg"
+)
+
+// writeDASHManifest writes a DASH manifest next to the master playlist in hlsDir, listing the
+// fMP4 segments of every rendition from its HLS playlist. Segment urls are relative to the
+// manifest, as variant playlists are relative to the master playlist.
+func writeDASHManifest(hlsDir string, renditions []ffmpeg.Rendition, variantStreams map[string]variantStream) error {
+	var duration float64
+	representations := make([]ffmpeg.DASHRepresentation, 0, len(renditions))
+	for _, rendition := range renditions {
+		content, err := os.ReadFile(filepath.Join(hlsDir, rendition.QualityName, PlaylistFileName))
+		if err != nil {
+			return errors.Wrapf(err, "failed to read playlist of quality: %s", rendition.QualityName)
+		}
+
+		playlist := ffmpeg.ParseMediaPlaylist(string(content))
+		if playlist.InitSegment == "" || len(playlist.Segments) == 0 {
+			return errors.Errorf("playlist of quality %s does not list fMP4 segments", rendition.QualityName)
+		}
+		duration = max(duration, playlist.Duration())
+
+		segments := make([]ffmpeg.MediaSegment, 0, len(playlist.Segments))
+		for _, segment := range playlist.Segments {
+			segments = append(segments, ffmpeg.MediaSegment{
+				URI:      path.Join(rendition.QualityName, segment.URI),
+				Duration: segment.Duration,
+			})
+		}
+
+		stream := variantStreams[rendition.QualityName]
+		representations = append(representations, ffmpeg.DASHRepresentation{
+			ID:          rendition.QualityName,
+			Bandwidth:   stream.bandwidth,
+			Width:       rendition.Width,
+			Height:      rendition.Height,
+			FrameRate:   stream.frameRate,
+			Codecs:      stream.codecs,
+			InitSegment: path.Join(rendition.QualityName, playlist.InitSegment),
+			Segments:    segments,
+		})
+	}
+
+	data, err := ffmpeg.NewDASHManifest(duration, representations).Marshal()
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(filepath.Join(hlsDir, DASHManifestFileName), data, 0644)
+}
@@ -0,0 +1,66 @@
+package processing
+
+import (
+	"context"
+	"crypto/rand"
+	"os"
+	"path/filepath"
+
+	"connectrpc.com/connect"
+	"github.com/cockroachdb/errors"
+	"github.com/gofrs/uuid"
+
+	"github.com/sweetloveinyourheart/sweet-reel/pkg/ffmpeg"
+	videoManagementProto "github.com/sweetloveinyourheart/sweet-reel/proto/code/video_management/go"
+)
+
+const (
+	ContentKeyFileName = "content.key"
+	KeyInfoFileName    = "content.keyinfo"
+
+	// contentKeyURI is where variant playlists, two levels below the temp directory, find the
+	// key while the video is processed, so they can still be probed. Video management points
+	// players to the key delivery endpoint when serving them.
+	contentKeyURI = "../../" + ContentKeyFileName
+)
+
+// writeContentKey generates the key and IV the segments of a video are encrypted with, and the
+// key info file handing them to FFmpeg. Both files are written to tempDir, out of the HLS
+// directory, so the key is never uploaded along with the segments.
+func writeContentKey(tempDir string) ([]byte, string, error) {
+	key := make([]byte, ffmpeg.AES128KeySize)
+	if _, err := rand.Read(key); err != nil {
+		return nil, "", errors.Wrap(err, "failed to generate content key")
+	}
+
+	iv := make([]byte, ffmpeg.AES128KeySize)
+	if _, err := rand.Read(iv); err != nil {
+		return nil, "", errors.Wrap(err, "failed to generate IV")
+	}
+
+	keyPath := filepath.Join(tempDir, ContentKeyFileName)
+	if err := os.WriteFile(keyPath, key, 0600); err != nil {
+		return nil, "", errors.Wrap(err, "failed to write content key")
+	}
+
+	keyInfoPath := filepath.Join(tempDir, KeyInfoFileName)
+	if err := ffmpeg.WriteKeyInfoFile(keyInfoPath, contentKeyURI, keyPath, iv); err != nil {
+		return nil, "", err
+	}
+
+	return key, keyInfoPath, nil
+}
+
+// storeContentKey hands the content key of a video to video management, which delivers it to
+// viewers. It is stored before the segments it decrypts are uploaded.
+func (vsp *VideoProcessManager) storeContentKey(ctx context.Context, videoID uuid.UUID, key []byte) error {
+	_, err := vsp.videoManagementClient.StoreContentKey(ctx, connect.NewRequest(&videoManagementProto.StoreContentKeyRequest{
+		VideoId: videoID.String(),
+		Key:     key,
+	}))
+	if err != nil {
+		return errors.Wrap(err, "failed to store the content key")
+	}
+
+	return nil
+}
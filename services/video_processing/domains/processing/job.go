@@ -0,0 +1,71 @@
+package processing
+
+import (
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/gofrs/uuid"
+
+	"github.com/sweetloveinyourheart/sweet-reel/pkg/ffmpeg"
+	"github.com/sweetloveinyourheart/sweet-reel/pkg/messages"
+)
+
+// processingJob records the stages of one attempt at processing a video, to be reported
+// with the progress updates of the video
+type processingJob struct {
+	id        uuid.UUID
+	attempt   int
+	startedAt time.Time
+	stages    []messages.VideoProcessingStageReport
+}
+
+func newProcessingJob(attempt int) *processingJob {
+	return &processingJob{
+		id:        uuid.Must(uuid.NewV7()),
+		attempt:   attempt,
+		startedAt: time.Now(),
+	}
+}
+
+// runStage runs fn as the given stage of the job and records how it went
+func (j *processingJob) runStage(stage messages.VideoProcessingStage, fn func() error) error {
+	startedAt := time.Now()
+	err := fn()
+
+	report := messages.VideoProcessingStageReport{
+		Stage:      stage,
+		StartedAt:  startedAt,
+		DurationMs: time.Since(startedAt).Milliseconds(),
+	}
+	if err != nil {
+		failure := failureOf(err)
+		report.ErrorCode = failure.Code
+		report.ErrorMessage = failure.Message
+		report.FFmpegStderr = ffmpeg.StderrExcerpt(err)
+	}
+	j.stages = append(j.stages, report)
+
+	return err
+}
+
+// report describes the job in the given status
+func (j *processingJob) report(status messages.VideoProcessingJobStatus) *messages.VideoProcessingJob {
+	return &messages.VideoProcessingJob{
+		ID:        j.id,
+		Attempt:   j.attempt,
+		Status:    status,
+		StartedAt: j.startedAt,
+		Stages:    j.stages,
+	}
+}
+
+// failureOf returns the reason a video failed with err. Rejected uploads carry their own
+// reason, anything else is a processing error.
+func failureOf(err error) *messages.VideoFailure {
+	var rejection *ValidationError
+	if errors.As(err, &rejection) {
+		return &messages.VideoFailure{Code: rejection.Code, Message: rejection.Message}
+	}
+
+	return &messages.VideoFailure{Code: messages.VideoFailureProcessingError, Message: err.Error()}
+}
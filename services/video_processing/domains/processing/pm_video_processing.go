@@ -1,17 +1,23 @@
 package processing
 
 import (
-	"bytes"
+	"bufio"
 	"context"
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
+	"connectrpc.com/connect"
 	"github.com/cockroachdb/errors"
 	"github.com/gofrs/uuid"
 	"github.com/samber/do"
 	"github.com/samber/lo"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 
 	"github.com/sweetloveinyourheart/sweet-reel/pkg/ffmpeg"
@@ -19,24 +25,31 @@ import (
 	"github.com/sweetloveinyourheart/sweet-reel/pkg/logger"
 	"github.com/sweetloveinyourheart/sweet-reel/pkg/messages"
 	"github.com/sweetloveinyourheart/sweet-reel/pkg/s3"
+	videoManagementProto "github.com/sweetloveinyourheart/sweet-reel/proto/code/video_management/go"
+	videoManagementConnect "github.com/sweetloveinyourheart/sweet-reel/proto/code/video_management/go/grpcconnect"
 )
 
 const (
-	// Batch processing
-	BatchSize = 1024
-
 	// Thumbnail settings
 	ThumbnailWidth      = 320
 	ThumbnailHeight     = 240
 	ThumbnailTimeOffset = "00:00:00"
 	ThumbnailFileName   = "thumbnail.jpg"
 
+	// Reels are vertical videos no longer than this
+	ReelMaxDurationSeconds = 180
+
 	// Directory and file naming
 	TempDirPattern = "/tmp/video_processing_%s"
 	InputFileName  = "input.mp4"
 	HLSDirName     = "hls"
 )
 
+var tracer = otel.Tracer("com.sweetloveinyourheart.srl.video_processing")
+
+// errVideoDeleted ends a job whose video was deleted while it was processed
+var errVideoDeleted = errors.New("video was deleted while it was processed")
+
 // Re-export commonly used ffmpeg constants for convenience
 const (
 	// Quality levels
@@ -44,6 +57,8 @@ const (
 	Quality480p    = ffmpeg.Quality480p
 	Quality720p    = ffmpeg.Quality720p
 	Quality1080p   = ffmpeg.Quality1080p
+	Quality1440p   = ffmpeg.Quality1440p
+	Quality2160p   = ffmpeg.Quality2160p
 	QualityUnknown = ffmpeg.QualityUnknown
 
 	// MIME types
@@ -56,6 +71,11 @@ const (
 	PlaylistType     = ffmpeg.PlaylistTypeVOD
 	SegmentFormat    = ffmpeg.DefaultSegmentFormat
 
+	// CMAF packaging
+	SegmentFormatFMP4    = ffmpeg.SegmentFormatFMP4
+	InitSegmentFileName  = ffmpeg.DefaultInitSegmentName
+	DASHManifestFileName = ffmpeg.DefaultDASHManifestName
+
 	// Codecs
 	CodecH264 = ffmpeg.CodecLibX264
 	CodecAAC  = ffmpeg.CodecAAC
@@ -63,82 +83,114 @@ const (
 	// File extensions
 	ExtM3U8 = ffmpeg.ExtM3U8
 	ExtTS   = ffmpeg.ExtTS
+	ExtM4S  = ffmpeg.ExtM4S
+	ExtMPD  = ffmpeg.ExtMPD
 )
 
 // Video quality configurations
 const (
-	// Resolutions
-	Resolution480p  = ffmpeg.Resolution480p
-	Resolution720p  = ffmpeg.Resolution720p
-	Resolution1080p = ffmpeg.Resolution1080p
+	// Rung heights, widths follow the source aspect ratio
+	Height480p  = 480
+	Height720p  = 720
+	Height1080p = 1080
+	Height1440p = 1440
+	Height2160p = 2160
 
 	// Video bitrates
 	Bitrate480p  = ffmpeg.VideoBitrate480p
 	Bitrate720p  = ffmpeg.VideoBitrate720p
 	Bitrate1080p = ffmpeg.VideoBitrate1080p
+	Bitrate1440p = ffmpeg.VideoBitrate1440p
+	Bitrate2160p = ffmpeg.VideoBitrate2160p
 
 	// Audio bitrates
 	AudioBitrate480p  = ffmpeg.AudioBitrate96k
 	AudioBitrate720p  = ffmpeg.AudioBitrate128k
 	AudioBitrate1080p = ffmpeg.AudioBitrate192k
+	AudioBitrate1440p = ffmpeg.AudioBitrate192k
+	AudioBitrate2160p = ffmpeg.AudioBitrate192k
 )
 
 var (
-	// Define multiple quality levels for adaptive streaming
-	qualities = []ffmpeg.SegmentationOptions{
-		{
-			QualityName:     Quality480p,
-			SegmentDuration: SegmentDuration,
-			PlaylistType:    PlaylistType,
-			PlaylistName:    PlaylistFileName,
-			SegmentPrefix:   SegmentPrefix,
-			SegmentFormat:   SegmentFormat,
-			VideoCodec:      CodecH264,
-			VideoBitrate:    Bitrate480p,
-			AudioCodec:      CodecAAC,
-			AudioBitrate:    AudioBitrate480p,
-			Resolution:      Resolution480p,
-		},
-		{
-			QualityName:     Quality720p,
-			SegmentDuration: SegmentDuration,
-			PlaylistType:    PlaylistType,
-			PlaylistName:    PlaylistFileName,
-			SegmentPrefix:   SegmentPrefix,
-			SegmentFormat:   SegmentFormat,
-			VideoCodec:      CodecH264,
-			VideoBitrate:    Bitrate720p,
-			AudioCodec:      CodecAAC,
-			AudioBitrate:    AudioBitrate720p,
-			Resolution:      Resolution720p,
-		},
-		{
-			QualityName:     Quality1080p,
-			SegmentDuration: SegmentDuration,
-			PlaylistType:    PlaylistType,
-			PlaylistName:    PlaylistFileName,
-			SegmentPrefix:   SegmentPrefix,
-			SegmentFormat:   SegmentFormat,
-			VideoCodec:      CodecH264,
-			VideoBitrate:    Bitrate1080p,
-			AudioCodec:      CodecAAC,
-			AudioBitrate:    AudioBitrate1080p,
-			Resolution:      Resolution1080p,
-		},
+	// Full adaptive streaming ladder, trimmed per video to what the source supports
+	ladder = []ffmpeg.Rendition{
+		{QualityName: Quality480p, Height: Height480p, VideoBitrate: Bitrate480p, AudioBitrate: AudioBitrate480p},
+		{QualityName: Quality720p, Height: Height720p, VideoBitrate: Bitrate720p, AudioBitrate: AudioBitrate720p},
+		{QualityName: Quality1080p, Height: Height1080p, VideoBitrate: Bitrate1080p, AudioBitrate: AudioBitrate1080p},
+		{QualityName: Quality1440p, Height: Height1440p, VideoBitrate: Bitrate1440p, AudioBitrate: AudioBitrate1440p},
+		{QualityName: Quality2160p, Height: Height2160p, VideoBitrate: Bitrate2160p, AudioBitrate: AudioBitrate2160p},
+	}
+
+	// Ladder for vertical videos, rung heights are matched against the source width.
+	// Reels are watched full screen on phones, so nothing above 1080p is produced.
+	portraitLadder = []ffmpeg.Rendition{
+		{QualityName: Quality480p, Height: Height480p, VideoBitrate: Bitrate480p, AudioBitrate: AudioBitrate480p},
+		{QualityName: Quality720p, Height: Height720p, VideoBitrate: Bitrate720p, AudioBitrate: AudioBitrate720p},
+		{QualityName: Quality1080p, Height: Height1080p, VideoBitrate: Bitrate1080p, AudioBitrate: AudioBitrate1080p},
+	}
+
+	// Segmentation settings shared by every rendition
+	baseSegmentationOptions = ffmpeg.SegmentationOptions{
+		SegmentDuration: SegmentDuration,
+		PlaylistType:    PlaylistType,
+		PlaylistName:    PlaylistFileName,
+		SegmentPrefix:   SegmentPrefix,
+		SegmentFormat:   SegmentFormat,
+		VideoCodec:      CodecH264,
+		AudioCodec:      CodecAAC,
 	}
 )
 
 type VideoProcessManager struct {
-	ctx   context.Context
-	queue chan lo.Tuple2[context.Context, *kafka.ConsumedMessage]
-
-	storageClient s3.S3Storage
-	ff            ffmpeg.FFmpegInterface
-	kafkaClient   *kafka.Client
+	ctx          context.Context
+	pool         *WorkerPool
+	drainTimeout time.Duration
+	done         chan struct{}
+
+	validation       *ValidationConfig
+	progressInterval time.Duration
+	packaging        string
+	encryption       bool
+
+	storageClient         s3.S3StreamStorage
+	ff                    ffmpeg.FFmpegInterface
+	kafkaClient           *kafka.Client
+	videoManagementClient videoManagementConnect.VideoManagementClient
+
+	// handleMessage is HandleMessage skipping uploads that were already processed
+	handleMessage kafka.MessageHandler
 }
 
-func NewVideoProcessManager(ctx context.Context) (*VideoProcessManager, error) {
-	storageClient, err := do.Invoke[s3.S3Storage](nil)
+func NewVideoProcessManager(ctx context.Context, cfg *WorkerConfig) (*VideoProcessManager, error) {
+	if cfg == nil {
+		cfg = DefaultWorkerConfig()
+	}
+
+	validation := cfg.Validation
+	if validation == nil {
+		validation = DefaultValidationConfig()
+	}
+
+	progressInterval := cfg.ProgressInterval
+	if progressInterval <= 0 {
+		progressInterval = DefaultProgressInterval
+	}
+
+	packaging := cfg.Packaging
+	switch packaging {
+	case "":
+		packaging = DefaultPackaging
+	case PackagingTS, PackagingCMAF:
+	default:
+		return nil, errors.Errorf("unknown packaging: %s", packaging)
+	}
+
+	// DASH players can't decrypt segments encrypted as a whole with AES-128
+	if cfg.Encryption && packaging != PackagingTS {
+		return nil, errors.Errorf("encryption requires %s packaging, got: %s", PackagingTS, packaging)
+	}
+
+	storageClient, err := do.Invoke[s3.S3StreamStorage](nil)
 	if err != nil {
 		return nil, err
 	}
@@ -148,15 +200,40 @@ func NewVideoProcessManager(ctx context.Context) (*VideoProcessManager, error) {
 		return nil, err
 	}
 
+	processedMessageStore, err := do.Invoke[kafka.ProcessedMessageStore](nil)
+	if err != nil {
+		return nil, err
+	}
+
+	ff, err := do.Invoke[ffmpeg.FFmpegInterface](nil)
+	if err != nil {
+		return nil, err
+	}
+
+	videoManagementClient, err := do.Invoke[videoManagementConnect.VideoManagementClient](nil)
+	if err != nil {
+		return nil, err
+	}
+
 	vsp := &VideoProcessManager{
-		ctx:           ctx,
-		queue:         make(chan lo.Tuple2[context.Context, *kafka.ConsumedMessage], BatchSize*2),
-		storageClient: storageClient,
-		ff:            ffmpeg.New(),
-		kafkaClient:   kafkaClient,
+		ctx:                   ctx,
+		pool:                  NewWorkerPool(cfg.Concurrency),
+		drainTimeout:          cfg.DrainTimeout,
+		done:                  make(chan struct{}),
+		validation:            validation,
+		progressInterval:      progressInterval,
+		packaging:             packaging,
+		encryption:            cfg.Encryption,
+		storageClient:         storageClient,
+		ff:                    ff,
+		kafkaClient:           kafkaClient,
+		videoManagementClient: videoManagementClient,
 	}
+	vsp.handleMessage = kafka.IdempotentHandler(processedMessageStore, kafka.KafkaVideoProcessingGroup, vsp.HandleMessage)
 
 	go func() {
+		defer close(vsp.done)
+
 		messageHandler := func(ctx context.Context, msg *kafka.ConsumedMessage) error {
 			logger.Global().InfoContext(ctx, "received message",
 				zap.String("topic_name", msg.Topic), zap.String("key", msg.Key),
@@ -165,7 +242,14 @@ func NewVideoProcessManager(ctx context.Context) (*VideoProcessManager, error) {
 
 			switch msg.Topic {
 			case kafka.KafkaVideoUploadedTopic:
-				vsp.queue <- lo.T2(ctx, msg)
+				// Blocks while every worker is busy, so the consumer stops pulling
+				// new messages instead of buffering them in memory. An error here
+				// leaves the message unmarked for the next consumer.
+				// The job outlives the handler, it carries on the trace of the message
+				spanCtx := trace.SpanContextFromContext(ctx)
+				return vsp.pool.Submit(ctx, func(jobCtx context.Context) {
+					vsp.runJob(trace.ContextWithSpanContext(jobCtx, spanCtx), msg)
+				})
 			}
 
 			return nil
@@ -189,24 +273,61 @@ func NewVideoProcessManager(ctx context.Context) (*VideoProcessManager, error) {
 		if err := consumer.Stop(); err != nil {
 			logger.Global().ErrorContext(ctx, "failed to stop consumer", zap.Error(err))
 		}
-	}()
 
-	go func() {
-		for {
-			select {
-			case <-ctx.Done():
-				return
-			case tuple := <-vsp.queue:
-				if err := vsp.HandleMessage(tuple.A, tuple.B); err != nil {
-					logger.Global().ErrorContext(tuple.A, "failed to handle event", zap.Error(err))
-				}
-			}
+		logger.Global().Info("Draining video processing workers",
+			zap.Int("running_jobs", vsp.pool.Running()),
+			zap.Duration("drain_timeout", vsp.drainTimeout))
+
+		if !vsp.pool.Drain(vsp.drainTimeout) {
+			logger.Global().Warn("Drain timeout reached, unfinished jobs were handed back")
 		}
 	}()
 
 	return vsp, nil
 }
 
+// Wait blocks until the manager has stopped consuming and every worker has drained
+func (vsp *VideoProcessManager) Wait() {
+	<-vsp.done
+}
+
+// runJob processes a single message on a worker. Failed messages are rejected to the
+// retry policy; when the job is interrupted by shutdown, the message is re-published
+// so another node picks it up.
+func (vsp *VideoProcessManager) runJob(ctx context.Context, msg *kafka.ConsumedMessage) {
+	err := vsp.handleMessage(ctx, msg)
+	if err == nil {
+		return
+	}
+
+	if ctx.Err() == nil {
+		logger.Global().ErrorContext(ctx, "failed to handle event", zap.Error(err))
+
+		// The consumer already moved on, so failed jobs go through the retry policy
+		// from here to be published again for a retry or dead-lettered
+		if err := vsp.kafkaClient.Reject(context.Background(), msg, err); err != nil {
+			logger.Global().Error("Failed to reject message", zap.String("key", msg.Key), zap.Error(err))
+		}
+		return
+	}
+
+	logger.Global().Warn("Video processing interrupted by shutdown, handing message back",
+		zap.String("topic", msg.Topic),
+		zap.String("key", msg.Key),
+		zap.Error(err))
+
+	handBack := &kafka.Message{
+		Topic:     msg.Topic,
+		Key:       msg.Key,
+		Value:     msg.Value,
+		Headers:   msg.Headers,
+		Partition: -1,
+	}
+	if _, _, err := vsp.kafkaClient.SendMessage(context.Background(), handBack); err != nil {
+		logger.Global().Error("Failed to hand back interrupted message", zap.String("key", msg.Key), zap.Error(err))
+	}
+}
+
 func (vsp *VideoProcessManager) HandleMessage(ctx context.Context, message *kafka.ConsumedMessage) (err error) {
 	if message == nil {
 		return errors.Errorf("message is nil")
@@ -218,72 +339,165 @@ func (vsp *VideoProcessManager) HandleMessage(ctx context.Context, message *kafk
 	}
 
 	bucket, key := s3.ExtractBucketAndKeyFromEventMessage(msg.Key)
-	bytes, err := vsp.storageClient.Download(key, bucket)
-	if err != nil {
-		return err
-	}
-
 	fileName, _ := s3.ExtractFilenameAndExt(key)
 	videoID := uuid.FromStringOrNil(fileName)
 	if videoID == uuid.Nil {
 		return errors.Errorf("invalid video id: %s", videoID.String())
 	}
 
-	// Process video using FFmpeg wrapper
-	err = vsp.processVideo(ctx, videoID, bytes)
-	if err != nil {
-		publishMsg := messages.VideoProcessingProgress{
-			VideoID:     videoID,
-			Status:      messages.VideoStatusFailed,
-			ObjectKey:   key,
-			ProcessedAt: time.Now(),
-		}
-		_, _, err := vsp.kafkaClient.SendJSON(ctx, kafka.KafkaVideoProgressTopic, videoID.String(), publishMsg)
+	// Uploads requested within a trace carry it in their metadata, processing joins that trace
+	// and links the notification it was started by
+	notificationCtx := ctx
+	if len(msg.Records) > 0 {
+		ctx = s3.ContextWithObjectTrace(ctx, msg.Records[0].S3.Object.UserMetadata)
+	}
+	ctx, span := tracer.Start(ctx, "ProcessVideo",
+		trace.WithLinks(trace.LinkFromContext(notificationCtx)),
+		trace.WithAttributes(attribute.String("video_id", videoID.String())))
+	defer func() {
 		if err != nil {
-			logger.Global().Error("Failed to publish video progress update message: %v", zap.Error(err))
-			return err
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
 		}
+		span.End()
+	}()
 
-		return errors.Wrap(err, "failed to process video")
-	} else {
-		publishMsg := messages.VideoProcessingProgress{
-			VideoID:     videoID,
-			Status:      messages.VideoStatusReady,
-			ObjectKey:   key,
-			ProcessedAt: time.Now(),
+	// The video may have been deleted, or its upload abandoned, since the object landed
+	process, err := vsp.shouldProcess(ctx, videoID)
+	if err != nil || !process {
+		return err
+	}
+
+	job := newProcessingJob(message.Attempts() + 1)
+	vsp.publishProgress(ctx, videoID, key, messages.VideoStatusProcessing, job.report(messages.VideoProcessingJobRunning), nil)
+
+	tempDir := fmt.Sprintf(TempDirPattern, videoID)
+	defer os.RemoveAll(tempDir)
+
+	inputPath := filepath.Join(tempDir, InputFileName)
+	var written int64
+	err = job.runStage(messages.VideoProcessingStageDownload, func() error {
+		// Uploads above the size limit are rejected before anything is downloaded
+		if len(msg.Records) > 0 && msg.Records[0].S3.Object.Size > 0 {
+			if err := vsp.validation.ValidateFileSize(msg.Records[0].S3.Object.Size); err != nil {
+				return err
+			}
 		}
-		_, _, err = vsp.kafkaClient.SendJSON(ctx, kafka.KafkaVideoProgressTopic, videoID.String(), publishMsg)
+
+		if err := os.MkdirAll(tempDir, 0755); err != nil {
+			return errors.Wrap(err, "failed to create temp directory")
+		}
+
+		// Stream the source object straight to disk instead of holding it in memory
+		var err error
+		written, err = vsp.storageClient.DownloadToFile(key, bucket, inputPath)
 		if err != nil {
-			logger.Global().Error("Failed to publish video progress update message: %v", zap.Error(err))
+			return errors.Wrap(err, "failed to download source video")
 		}
 
-		logger.Global().InfoContext(ctx, "Video processing completed successfully", zap.String("key", msg.Key))
+		logger.Global().InfoContext(ctx, "Source video downloaded",
+			zap.String("video_id", videoID.String()),
+			zap.Int64("size_bytes", written))
+		return nil
+	})
+
+	var probeInfo *ffmpeg.ProbeInfo
+	if err == nil {
+		err = job.runStage(messages.VideoProcessingStageProbe, func() error {
+			var err error
+			probeInfo, err = vsp.validateSource(ctx, inputPath, written)
+			return err
+		})
+	}
+	if err == nil {
+		// Process video using FFmpeg wrapper
+		err = vsp.processVideo(ctx, job, videoID, key, tempDir, probeInfo)
+	}
 
+	if errors.Is(err, errVideoDeleted) {
+		logger.Global().WarnContext(ctx, "Video deleted while it was processed, its files were removed",
+			zap.String("video_id", videoID.String()))
 		return nil
 	}
+
+	if err := vsp.finishJob(ctx, job, videoID, key, err); err != nil {
+		return err
+	}
+
+	logger.Global().InfoContext(ctx, "Video processing completed successfully", zap.String("key", msg.Key))
+
+	return nil
 }
 
-// processVideo handles the actual video processing using FFmpeg
-func (vsp *VideoProcessManager) processVideo(ctx context.Context, videoID uuid.UUID, videoData []byte) error {
-	if err := vsp.ff.IsAvailable(ctx); err != nil {
-		return errors.Wrap(err, "FFmpeg not available")
+// shouldProcess reports whether the video an upload belongs to still waits for it. Videos
+// whose transcoding failed are processed again when the upload is retried, rejected uploads
+// and videos that are gone or ready are skipped.
+func (vsp *VideoProcessManager) shouldProcess(ctx context.Context, videoID uuid.UUID) (bool, error) {
+	response, err := vsp.videoManagementClient.GetVideoStatus(ctx, connect.NewRequest(&videoManagementProto.GetVideoStatusRequest{
+		VideoId: videoID.String(),
+	}))
+	if err != nil {
+		if connect.CodeOf(err) == connect.CodeNotFound {
+			logger.Global().WarnContext(ctx, "No video record for the upload, skipping it", zap.String("video_id", videoID.String()))
+			return false, nil
+		}
+
+		return false, errors.Wrap(err, "failed to get the video status")
 	}
 
-	tempDir := fmt.Sprintf(TempDirPattern, videoID)
-	if err := os.MkdirAll(tempDir, 0755); err != nil {
-		return errors.Wrap(err, "failed to create temp directory")
+	status := messages.VideoStatus(response.Msg.GetStatus())
+	failureCode := messages.VideoFailureCode(response.Msg.GetFailureCode())
+	if status == messages.VideoStatusProcessing || (status == messages.VideoStatusFailed && failureCode == messages.VideoFailureProcessingError) {
+		return true, nil
 	}
-	defer os.RemoveAll(tempDir)
 
-	inputPath := filepath.Join(tempDir, InputFileName)
-	if err := os.WriteFile(inputPath, videoData, 0644); err != nil {
-		return errors.Wrap(err, "failed to write input file")
+	logger.Global().WarnContext(ctx, "Video is not waiting for its upload, skipping it",
+		zap.String("video_id", videoID.String()),
+		zap.String("status", string(status)),
+		zap.String("failure_code", string(failureCode)))
+	return false, nil
+}
+
+// discardDeletedVideo removes the upload and the processed files of a video that was deleted
+// while it was processed, and returns errVideoDeleted. Deleting a video removes the files
+// stored so far, those uploaded by the job afterwards are left to the job.
+func (vsp *VideoProcessManager) discardDeletedVideo(ctx context.Context, videoID uuid.UUID, key string) error {
+	_, err := vsp.videoManagementClient.GetVideoStatus(ctx, connect.NewRequest(&videoManagementProto.GetVideoStatusRequest{
+		VideoId: videoID.String(),
+	}))
+	if err == nil {
+		return nil
+	}
+	if connect.CodeOf(err) != connect.CodeNotFound {
+		return errors.Wrap(err, "failed to get the video status")
+	}
+
+	if _, err := vsp.storageClient.DeletePrefix(videoID.String()+"/", s3.S3VideoProcessedBucket); err != nil {
+		return errors.Wrap(err, "failed to delete the processed files of a deleted video")
+	}
+	if err := vsp.storageClient.Delete(key, s3.S3VideoUploadedBucket); err != nil {
+		return errors.Wrap(err, "failed to delete the upload of a deleted video")
+	}
+
+	return errVideoDeleted
+}
+
+// validateSource probes the downloaded upload and checks it against the validation config
+func (vsp *VideoProcessManager) validateSource(ctx context.Context, inputPath string, size int64) (*ffmpeg.ProbeInfo, error) {
+	if err := vsp.validation.ValidateFileSize(size); err != nil {
+		return nil, err
+	}
+
+	if err := vsp.ff.IsAvailable(ctx); err != nil {
+		return nil, errors.Wrap(err, "FFmpeg not available")
 	}
 
-	// Probe the input file to get information
 	probeInfo, err := vsp.ff.ProbeFile(ctx, inputPath)
 	if err != nil {
-		return errors.Wrap(err, "failed to probe input file")
+		if ctx.Err() != nil {
+			return nil, errors.Wrap(err, "failed to probe input file")
+		}
+		return nil, reject(messages.VideoFailureUnreadableFile, "the uploaded file could not be read as a video")
 	}
 
 	logger.Global().InfoContext(ctx, "Video file information",
@@ -292,59 +506,322 @@ func (vsp *VideoProcessManager) processVideo(ctx context.Context, videoID uuid.U
 		zap.String("size", probeInfo.Format.Size),
 		zap.Int("streams", len(probeInfo.Streams)))
 
-	hlsOutputDir := filepath.Join(tempDir, HLSDirName)
-	if err := os.MkdirAll(hlsOutputDir, 0755); err != nil {
-		return errors.Wrap(err, "failed to create HLS output directory")
+	if err := vsp.validation.ValidateProbe(probeInfo); err != nil {
+		return nil, err
 	}
 
-	progressCallback := func(progress ffmpeg.ProgressInfo) {
-		if int(progress.Percentage)%10 == 0 { // Log every 10%
-			logger.Global().InfoContext(ctx, "Video processing progress",
-				zap.String("video_id", videoID.String()),
-				zap.Float64("percentage", progress.Percentage),
-				zap.String("speed", progress.Speed),
-				zap.Duration("current", progress.Current),
-				zap.Duration("total", progress.Duration))
-		}
+	return probeInfo, nil
+}
+
+// finishJob reports how the job ended. Interrupted jobs leave the video processing, failed
+// jobs mark it as failed with the reason behind err. Rejected uploads are settled here,
+// other failures are returned so that the upload is retried.
+func (vsp *VideoProcessManager) finishJob(ctx context.Context, job *processingJob, videoID uuid.UUID, key string, err error) error {
+	if err == nil {
+		vsp.publishProgress(ctx, videoID, key, messages.VideoStatusReady, job.report(messages.VideoProcessingJobSucceeded), nil)
+		return nil
 	}
 
-	// Start video segmentation
-	startTime := time.Now()
-	logger.Global().InfoContext(ctx, "Starting video segmentation",
-		zap.String("video_id", videoID.String()),
-		zap.Int("quality_levels", len(qualities)))
+	if ctx.Err() != nil {
+		// Interrupted rather than failed, the video should not be marked as failed
+		vsp.publishProgress(context.WithoutCancel(ctx), videoID, key, messages.VideoStatusProcessing, job.report(messages.VideoProcessingJobInterrupted), nil)
+		return errors.Wrap(err, "video processing interrupted")
+	}
+
+	failure := failureOf(err)
+	trace.SpanFromContext(ctx).SetAttributes(attribute.String("failure_code", string(failure.Code)))
 
-	if err := vsp.ff.SegmentVideoMultiQuality(ctx, inputPath, hlsOutputDir, qualities, progressCallback); err != nil {
-		return errors.Wrap(err, "failed to segment video")
+	publishMsg := messages.VideoProcessingProgress{
+		VideoID:     videoID,
+		Status:      messages.VideoStatusFailed,
+		ObjectKey:   key,
+		ProcessedAt: time.Now(),
+		Failure:     failure,
+		Job:         job.report(messages.VideoProcessingJobFailed),
+	}
+	if _, _, err := vsp.kafkaClient.SendEvent(ctx, kafka.KafkaVideoProgressTopic, videoID.String(), publishMsg); err != nil {
+		logger.Global().Error("Failed to publish video progress update message: %v", zap.Error(err))
+		return err
 	}
 
-	processingTime := time.Since(startTime)
-	logger.Global().InfoContext(ctx, "Video segmentation completed",
-		zap.String("video_id", videoID.String()),
-		zap.Duration("processing_time", processingTime))
+	var rejection *ValidationError
+	if errors.As(err, &rejection) {
+		logger.Global().WarnContext(ctx, "Upload rejected",
+			zap.String("video_id", videoID.String()),
+			zap.String("failure_code", string(failure.Code)),
+			zap.String("failure_reason", failure.Message))
+		return nil
+	}
+
+	return errors.Wrap(err, "failed to process video")
+}
+
+// publishProgress publishes a progress update of the video. Updates are best effort, a
+// missed one is superseded by the next.
+func (vsp *VideoProcessManager) publishProgress(ctx context.Context, videoID uuid.UUID, key string, status messages.VideoStatus, job *messages.VideoProcessingJob, progress *messages.VideoTranscodeProgress) {
+	publishMsg := messages.VideoProcessingProgress{
+		VideoID:     videoID,
+		Status:      status,
+		ObjectKey:   key,
+		ProcessedAt: time.Now(),
+		Job:         job,
+		Progress:    progress,
+	}
+	if _, _, err := vsp.kafkaClient.SendEvent(ctx, kafka.KafkaVideoProgressTopic, videoID.String(), publishMsg); err != nil {
+		logger.Global().Error("Failed to publish video progress update message: %v", zap.Error(err))
+	}
+}
+
+// processVideo handles the actual video processing using FFmpeg.
+// The source video is expected at InputFileName inside tempDir, already probed and validated.
+// Each step is recorded as a stage of job.
+func (vsp *VideoProcessManager) processVideo(ctx context.Context, job *processingJob, videoID uuid.UUID, key string, tempDir string, probeInfo *ffmpeg.ProbeInfo) error {
+	inputPath := filepath.Join(tempDir, InputFileName)
+	videoStream := probeInfo.VideoStream()
+
+	// Rotated sources are encoded the way they are displayed
+	width, height := videoStream.DisplaySize()
+	orientation := videoStream.Orientation()
+	format := ClassifyVideoFormat(orientation, probeInfo.DurationSeconds())
+
+	err := vsp.publishSourceInfo(ctx, videoID, messages.VideoProcessedSourceData{
+		Width:           width,
+		Height:          height,
+		Orientation:     orientation,
+		DurationSeconds: probeInfo.DurationSeconds(),
+		Format:          format,
+	})
+	if err != nil {
+		return err
+	}
+
+	// Pick the renditions the source supports without upscaling
+	sourceLadder := ladder
+	if orientation == ffmpeg.OrientationPortrait {
+		sourceLadder = portraitLadder
+	}
+
+	hlsOutputDir := filepath.Join(tempDir, HLSDirName)
+	var renditions []ffmpeg.Rendition
+	var variantStreams map[string]variantStream
+	var contentKey []byte
+	err = job.runStage(messages.VideoProcessingStageSegment, func() error {
+		renditions = ffmpeg.SelectLadder(width, height, sourceLadder)
+		if len(renditions) == 0 {
+			return reject(messages.VideoFailureUnsupportedResolution, "source resolution %dx%d is not supported", width, height)
+		}
+
+		segmentationOptions := vsp.segmentationOptions()
+		if vsp.encryption {
+			key, keyInfoPath, err := writeContentKey(tempDir)
+			if err != nil {
+				return err
+			}
+			contentKey = key
+			segmentationOptions.EnableEncryption = true
+			segmentationOptions.KeyInfoFile = keyInfoPath
+		}
+
+		qualities := make([]ffmpeg.SegmentationOptions, 0, len(renditions))
+		for _, rendition := range renditions {
+			qualities = append(qualities, rendition.SegmentationOptions(segmentationOptions))
+		}
+
+		logger.Global().InfoContext(ctx, "Selected bitrate ladder",
+			zap.String("video_id", videoID.String()),
+			zap.String("source_resolution", fmt.Sprintf("%dx%d", width, height)),
+			zap.String("orientation", orientation),
+			zap.String("format", string(format)),
+			zap.Strings("renditions", lo.Map(renditions, func(r ffmpeg.Rendition, _ int) string {
+				return fmt.Sprintf("%s (%s)", r.QualityName, r.Resolution())
+			})))
+
+		if err := os.MkdirAll(hlsOutputDir, 0755); err != nil {
+			return errors.Wrap(err, "failed to create HLS output directory")
+		}
+
+		// Uploaders follow the transcoding, a throttled share of the updates is published
+		throttle := &progressThrottle{interval: vsp.progressInterval}
+		progressCallback := func(progress ffmpeg.ProgressInfo) {
+			if int(progress.Percentage)%10 == 0 { // Log every 10%
+				logger.Global().InfoContext(ctx, "Video processing progress",
+					zap.String("video_id", videoID.String()),
+					zap.Float64("percentage", progress.Percentage),
+					zap.String("speed", progress.Speed),
+					zap.Duration("current", progress.Current),
+					zap.Duration("total", progress.Duration))
+			}
+
+			if throttle.allow(time.Now()) {
+				vsp.publishProgress(ctx, videoID, key, messages.VideoStatusProcessing, job.report(messages.VideoProcessingJobRunning), &messages.VideoTranscodeProgress{
+					Percentage:     progress.Percentage,
+					Speed:          progress.Speed,
+					CurrentSeconds: progress.Current.Seconds(),
+					TotalSeconds:   progress.Duration.Seconds(),
+				})
+			}
+		}
+
+		// Start video segmentation
+		startTime := time.Now()
+		logger.Global().InfoContext(ctx, "Starting video segmentation",
+			zap.String("video_id", videoID.String()),
+			zap.String("packaging", vsp.packaging),
+			zap.Bool("encryption", vsp.encryption),
+			zap.Int("quality_levels", len(qualities)))
+
+		if err := vsp.ff.SegmentVideoMultiQuality(ctx, inputPath, hlsOutputDir, qualities, progressCallback); err != nil {
+			return errors.Wrap(err, "failed to segment video")
+		}
+
+		processingTime := time.Since(startTime)
+		logger.Global().InfoContext(ctx, "Video segmentation completed",
+			zap.String("video_id", videoID.String()),
+			zap.Duration("processing_time", processingTime))
+
+		// Described once per variant, every segment message of a variant carries the same info
+		variantStreams = make(map[string]variantStream, len(renditions))
+		for _, rendition := range renditions {
+			variantStreams[rendition.QualityName] = vsp.describeVariant(ctx, filepath.Join(hlsOutputDir, rendition.QualityName), rendition)
+		}
+
+		// DASH players are handed the same fMP4 segments as HLS players
+		if vsp.packaging == PackagingCMAF {
+			if err := writeDASHManifest(hlsOutputDir, renditions, variantStreams); err != nil {
+				return errors.Wrap(err, "failed to write DASH manifest")
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// Create thumbnail, portrait videos get a portrait thumbnail
+	thumbnailWidth, thumbnailHeight := ThumbnailWidth, ThumbnailHeight
+	if orientation == ffmpeg.OrientationPortrait {
+		thumbnailWidth, thumbnailHeight = ThumbnailHeight, ThumbnailWidth
+	}
 
-	// Create thumbnail
+	// A missing thumbnail does not fail the video, the stage still records why it is missing
 	thumbnailPath := filepath.Join(tempDir, ThumbnailFileName)
-	if err := vsp.ff.CreateThumbnail(ctx, inputPath, thumbnailPath, ThumbnailTimeOffset, ThumbnailWidth, ThumbnailHeight); err != nil {
+	if err := job.runStage(messages.VideoProcessingStageThumbnail, func() error {
+		return vsp.ff.CreateThumbnail(ctx, inputPath, thumbnailPath, ThumbnailTimeOffset, thumbnailWidth, thumbnailHeight)
+	}); err != nil {
 		logger.Global().WarnContext(ctx, "Failed to create thumbnail", zap.Error(err))
 	} else {
 		logger.Global().InfoContext(ctx, "Thumbnail created", zap.String("path", thumbnailPath))
 	}
 
-	// Upload processed files back to storage
-	if err := vsp.uploadProcessedSegmentFiles(ctx, videoID, hlsOutputDir); err != nil {
-		return errors.Wrap(err, "failed to upload processed segments files")
+	// Scrubbing previews are optional as well, players fall back to the thumbnail
+	storyboardDir := filepath.Join(tempDir, StoryboardDirName)
+	var storyboard *messages.VideoProcessedStoryboardData
+	if err := job.runStage(messages.VideoProcessingStageStoryboard, func() error {
+		data, err := vsp.createStoryboard(ctx, inputPath, storyboardDir, probeInfo.DurationSeconds(), width, height)
+		if err != nil {
+			return err
+		}
+		storyboard = &data
+		return nil
+	}); err != nil {
+		logger.Global().WarnContext(ctx, "Failed to create storyboard", zap.Error(err))
+	}
+
+	// Upload processed files back to storage, unless the video was deleted in the meantime
+	if err := vsp.discardDeletedVideo(ctx, videoID, key); err != nil {
+		return err
+	}
+
+	err = job.runStage(messages.VideoProcessingStageUpload, func() error {
+		if contentKey != nil {
+			if err := vsp.storeContentKey(ctx, videoID, contentKey); err != nil {
+				return err
+			}
+		}
+
+		if err := vsp.uploadProcessedSegmentFiles(ctx, videoID, hlsOutputDir, renditions, variantStreams); err != nil {
+			return errors.Wrap(err, "failed to upload processed segments files")
+		}
+
+		if err := vsp.uploadProcessedThumbnailFiles(ctx, videoID, thumbnailPath, thumbnailWidth, thumbnailHeight); err != nil {
+			return errors.Wrap(err, "failed to upload processed thumbnail files")
+		}
+
+		if storyboard != nil {
+			if err := vsp.uploadProcessedStoryboardFiles(ctx, videoID, storyboardDir, *storyboard); err != nil {
+				return errors.Wrap(err, "failed to upload processed storyboard files")
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
 	}
 
-	if err := vsp.uploadProcessedThumbnailFiles(ctx, videoID, thumbnailPath); err != nil {
-		return errors.Wrap(err, "failed to upload processed thumbnail files")
+	// A video deleted during the upload had its files removed before they were all stored
+	return vsp.discardDeletedVideo(ctx, videoID, key)
+}
+
+// ClassifyVideoFormat decides whether a video is a reel or long-form from its orientation
+// and duration in seconds. A video of unknown duration is treated as long-form.
+func ClassifyVideoFormat(orientation string, durationSeconds float64) messages.VideoFormat {
+	if orientation == ffmpeg.OrientationPortrait && durationSeconds > 0 && durationSeconds <= ReelMaxDurationSeconds {
+		return messages.VideoFormatReel
 	}
 
+	return messages.VideoFormatLongForm
+}
+
+// publishSourceInfo publishes what was detected about the source video when probing it
+func (vsp *VideoProcessManager) publishSourceInfo(ctx context.Context, videoID uuid.UUID, data messages.VideoProcessedSourceData) error {
+	if err := vsp.publishProcessed(ctx, videoID, "", messages.VideoProcessedTypeSource, data); err != nil {
+		return errors.Wrap(err, "failed to publish source info message")
+	}
 	return nil
 }
 
-// uploadProcessedFiles uploads the HLS segments to storage
-func (vsp *VideoProcessManager) uploadProcessedSegmentFiles(ctx context.Context, videoID uuid.UUID, hlsDir string) error {
+// publishProcessed announces a file produced for the video, described by data. Unlike progress
+// updates, a file that is not announced is never served, so failures fail the job, which is
+// retried.
+func (vsp *VideoProcessManager) publishProcessed(ctx context.Context, videoID uuid.UUID, objectKey string, processedType messages.VideoProcessedType, data any) error {
+	publishMsg, err := messages.NewVideoProcessed(videoID, objectKey, processedType, data)
+	if err != nil {
+		return err
+	}
+
+	_, _, err = vsp.kafkaClient.SendEvent(ctx, kafka.KafkaVideoProcessedTopic, videoID.String(), publishMsg)
+	return err
+}
+
+// segmentationOptions returns the segmentation settings shared by every rendition, for the
+// packaging of the manager
+func (vsp *VideoProcessManager) segmentationOptions() ffmpeg.SegmentationOptions {
+	options := baseSegmentationOptions
+	if vsp.packaging == PackagingCMAF {
+		options.SegmentType = ffmpeg.SegmentTypeFMP4
+		options.SegmentFormat = SegmentFormatFMP4
+		options.InitSegmentName = InitSegmentFileName
+	}
+
+	return options
+}
+
+// uploadProcessedFiles uploads the HLS segments, and the DASH manifest listing them, to storage
+func (vsp *VideoProcessManager) uploadProcessedSegmentFiles(ctx context.Context, videoID uuid.UUID, hlsDir string, renditions []ffmpeg.Rendition, variantStreams map[string]variantStream) error {
+	ladderData := make([]messages.VideoRendition, 0, len(renditions))
+	renditionsByQuality := make(map[string]ffmpeg.Rendition, len(renditions))
+	for _, rendition := range renditions {
+		ladderData = append(ladderData, messages.VideoRendition{
+			Quality:      rendition.QualityName,
+			Width:        rendition.Width,
+			Height:       rendition.Height,
+			VideoBitrate: rendition.VideoBitrate,
+			AudioBitrate: rendition.AudioBitrate,
+		})
+		renditionsByQuality[rendition.QualityName] = rendition
+	}
+
 	// Walk through HLS directory and upload all files
 	err := filepath.Walk(hlsDir, func(path string, info os.FileInfo, err error) error {
 		if err != nil {
@@ -355,12 +832,6 @@ func (vsp *VideoProcessManager) uploadProcessedSegmentFiles(ctx context.Context,
 			return nil
 		}
 
-		// Read file content
-		fileData, err := os.ReadFile(path)
-		if err != nil {
-			return errors.Wrapf(err, "failed to read file: %s", path)
-		}
-
 		// Generate storage key based on relative path
 		relPath, err := filepath.Rel(hlsDir, path)
 		if err != nil {
@@ -369,10 +840,9 @@ func (vsp *VideoProcessManager) uploadProcessedSegmentFiles(ctx context.Context,
 
 		storageKey := fmt.Sprintf("%s/hls/%s", videoID.String(), relPath)
 
-		// Upload to storage
-		fileReader := bytes.NewReader(fileData)
+		// Upload to storage, streaming from the file on disk
 		mimeType := ffmpeg.GetMimeType(path)
-		if err := vsp.storageClient.Upload(storageKey, s3.S3VideoProcessedBucket, fileReader, mimeType); err != nil {
+		if err := vsp.storageClient.UploadFile(storageKey, s3.S3VideoProcessedBucket, path, mimeType); err != nil {
 			return errors.Wrapf(err, "failed to upload file: %s", storageKey)
 		}
 
@@ -385,37 +855,45 @@ func (vsp *VideoProcessManager) uploadProcessedSegmentFiles(ctx context.Context,
 			manifestData := messages.VideoProcessedManifestData{
 				Quality:   quality,
 				SizeBytes: info.Size(),
+				Format:    messages.ManifestFormatHLS,
 			}
-			publishManifestMsg := messages.VideoProcessed{
-				VideoID:   videoID,
-				ObjectKey: storageKey,
-				Type:      messages.VideoProcessedTypeManifest,
-				Data:      manifestData,
+			if err := vsp.publishProcessed(ctx, videoID, storageKey, messages.VideoProcessedTypeManifest, manifestData); err != nil {
+				return errors.Wrapf(err, "failed to publish manifest message: %s", storageKey)
 			}
-			_, _, err = vsp.kafkaClient.SendJSON(ctx, kafka.KafkaVideoProcessedTopic, videoID.String(), publishManifestMsg)
-			if err != nil {
-				logger.Global().Error("Failed to publish manifest message", zap.Error(err))
+		case ExtMPD:
+			// A single DASH manifest lists every representation, like the master playlist
+			manifestData := messages.VideoProcessedManifestData{
+				Quality:   QualityDefault,
+				SizeBytes: info.Size(),
+				Format:    messages.ManifestFormatDASH,
 			}
-		case ExtTS:
+			if err := vsp.publishProcessed(ctx, videoID, storageKey, messages.VideoProcessedTypeManifest, manifestData); err != nil {
+				return errors.Wrapf(err, "failed to publish manifest message: %s", storageKey)
+			}
+		case ExtTS, ExtM4S:
 			// For variant segments, extract quality from path and count segments
 			quality := vsp.extractQualityFromPath(relPath)
 			segments := vsp.countSegmentsInDirectory(filepath.Dir(path))
 			duration := vsp.calculateVariantDuration(filepath.Dir(path))
 
+			rendition := renditionsByQuality[quality]
+			stream := variantStreams[quality]
 			variantData := messages.VideoProcessedVariantData{
-				Quality:       quality,
-				TotalSegments: segments,
-				TotalDuration: duration,
-			}
-			publishVariantMsg := messages.VideoProcessed{
-				VideoID:   videoID,
-				ObjectKey: storageKey,
-				Type:      messages.VideoProcessedTypeVariant,
-				Data:      variantData,
+				Quality:          quality,
+				TotalSegments:    segments,
+				TotalDuration:    duration,
+				Width:            rendition.Width,
+				Height:           rendition.Height,
+				VideoBitrate:     rendition.VideoBitrate,
+				AudioBitrate:     rendition.AudioBitrate,
+				Ladder:           ladderData,
+				Bandwidth:        stream.bandwidth,
+				AverageBandwidth: stream.averageBandwidth,
+				FrameRate:        stream.frameRate,
+				Codecs:           stream.codecs,
 			}
-			_, _, err = vsp.kafkaClient.SendJSON(ctx, kafka.KafkaVideoProcessedTopic, videoID.String(), publishVariantMsg)
-			if err != nil {
-				logger.Global().Error("Failed to publish variant message", zap.Error(err))
+			if err := vsp.publishProcessed(ctx, videoID, storageKey, messages.VideoProcessedTypeVariant, variantData); err != nil {
+				return errors.Wrapf(err, "failed to publish variant message: %s", storageKey)
 			}
 		}
 
@@ -433,36 +911,23 @@ func (vsp *VideoProcessManager) uploadProcessedSegmentFiles(ctx context.Context,
 }
 
 // uploadProcessedFiles uploads the thumbnail to storage
-func (vsp *VideoProcessManager) uploadProcessedThumbnailFiles(ctx context.Context, videoID uuid.UUID, thumbnailPath string) error {
+func (vsp *VideoProcessManager) uploadProcessedThumbnailFiles(ctx context.Context, videoID uuid.UUID, thumbnailPath string, width, height int) error {
 	// Upload thumbnail if it exists
 	if thumbnailPath != "" {
 		if _, err := os.Stat(thumbnailPath); err == nil {
-			thumbnailData, err := os.ReadFile(thumbnailPath)
-			if err != nil {
-				logger.Global().WarnContext(ctx, "Failed to read thumbnail", zap.Error(err))
+			thumbnailKey := fmt.Sprintf("%s/%s", videoID.String(), ThumbnailFileName)
+			if err := vsp.storageClient.UploadFile(thumbnailKey, s3.S3VideoProcessedBucket, thumbnailPath, ThumbnailMimeType); err != nil {
+				logger.Global().WarnContext(ctx, "Failed to upload thumbnail", zap.Error(err))
 			} else {
-				thumbnailKey := fmt.Sprintf("%s/%s", videoID.String(), ThumbnailFileName)
-				thumbnailReader := bytes.NewReader(thumbnailData)
-				if err := vsp.storageClient.Upload(thumbnailKey, s3.S3VideoProcessedBucket, thumbnailReader, ThumbnailMimeType); err != nil {
-					logger.Global().WarnContext(ctx, "Failed to upload thumbnail", zap.Error(err))
-				} else {
-					data := messages.VideoProcessedThumbnailData{
-						Width:  ThumbnailWidth,
-						Height: ThumbnailHeight,
-					}
-					publishMsg := messages.VideoProcessed{
-						VideoID:   videoID,
-						ObjectKey: thumbnailKey,
-						Type:      messages.VideoProcessedTypeThumbnail,
-						Data:      data,
-					}
-					_, _, err := vsp.kafkaClient.SendJSON(ctx, kafka.KafkaVideoProcessedTopic, videoID.String(), publishMsg)
-					if err != nil {
-						logger.Global().Error("Failed to publish video progress update message: %v", zap.Error(err))
-					}
-
-					logger.Global().InfoContext(ctx, "Thumbnail uploaded", zap.String("storage_key", thumbnailKey))
+				data := messages.VideoProcessedThumbnailData{
+					Width:  width,
+					Height: height,
+				}
+				if err := vsp.publishProcessed(ctx, videoID, thumbnailKey, messages.VideoProcessedTypeThumbnail, data); err != nil {
+					return errors.Wrap(err, "failed to publish thumbnail message")
 				}
+
+				logger.Global().InfoContext(ctx, "Thumbnail uploaded", zap.String("storage_key", thumbnailKey))
 			}
 		}
 	}
@@ -488,7 +953,7 @@ func (vsp *VideoProcessManager) extractQualityFromPath(relPath string) string {
 	return QualityUnknown
 }
 
-// countSegmentsInDirectory counts the number of .ts segment files in a directory
+// countSegmentsInDirectory counts the number of .ts or .m4s segment files in a directory
 func (vsp *VideoProcessManager) countSegmentsInDirectory(dir string) int {
 	count := 0
 	entries, err := os.ReadDir(dir)
@@ -497,7 +962,7 @@ func (vsp *VideoProcessManager) countSegmentsInDirectory(dir string) int {
 	}
 
 	for _, entry := range entries {
-		if !entry.IsDir() && filepath.Ext(entry.Name()) == ExtTS {
+		if ext := filepath.Ext(entry.Name()); !entry.IsDir() && (ext == ExtTS || ext == ExtM4S) {
 			count++
 		}
 	}
@@ -508,18 +973,19 @@ func (vsp *VideoProcessManager) countSegmentsInDirectory(dir string) int {
 // calculateVariantDuration calculates the total duration of a variant by parsing its playlist
 func (vsp *VideoProcessManager) calculateVariantDuration(dir string) int {
 	playlistPath := filepath.Join(dir, PlaylistFileName)
-	data, err := os.ReadFile(playlistPath)
+	file, err := os.Open(playlistPath)
 	if err != nil {
 		return 0
 	}
+	defer file.Close()
 
-	// Parse m3u8 file to calculate total duration
+	// Parse m3u8 file line by line to calculate total duration
 	// Look for #EXTINF tags which contain segment durations
 	totalDuration := 0.0
-	lines := bytes.SplitSeq(data, []byte("\n"))
-	for line := range lines {
-		lineStr := string(bytes.TrimSpace(line))
-		if bytes.HasPrefix(line, []byte("#EXTINF:")) {
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		lineStr := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(lineStr, "#EXTINF:") {
 			// Extract duration from #EXTINF:6.000000,
 			var segmentDuration float64
 			_, err := fmt.Sscanf(lineStr, "#EXTINF:%f,", &segmentDuration)
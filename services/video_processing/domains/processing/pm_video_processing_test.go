@@ -5,11 +5,16 @@ import (
 	"fmt"
 	"time"
 
+	"connectrpc.com/connect"
+	"github.com/IBM/sarama"
 	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/mock"
 
+	"github.com/sweetloveinyourheart/sweet-reel/pkg/ffmpeg"
 	"github.com/sweetloveinyourheart/sweet-reel/pkg/kafka"
 	"github.com/sweetloveinyourheart/sweet-reel/pkg/messages"
 	"github.com/sweetloveinyourheart/sweet-reel/pkg/s3"
+	videoManagementProto "github.com/sweetloveinyourheart/sweet-reel/proto/code/video_management/go"
 	"github.com/sweetloveinyourheart/sweet-reel/services/video_processing/domains/processing"
 )
 
@@ -18,7 +23,7 @@ func (as *VideoProcessingSuite) TestNewVideoProcessManager_Success() {
 
 	// Since the constructor creates goroutines that are hard to control in tests,
 	// we test that the constructor doesn't return an error and creates a manager
-	manager, err := processing.NewVideoProcessManager(as.ctx)
+	manager, err := processing.NewVideoProcessManager(as.ctx, processing.DefaultWorkerConfig())
 
 	as.NoError(err)
 	as.NotNil(manager)
@@ -27,12 +32,42 @@ func (as *VideoProcessingSuite) TestNewVideoProcessManager_Success() {
 	time.Sleep(100 * time.Millisecond)
 }
 
+func (as *VideoProcessingSuite) TestNewVideoProcessManager_UnknownPackaging() {
+	as.setupEnvironment()
+
+	workerConfig := processing.DefaultWorkerConfig()
+	workerConfig.Packaging = "webm"
+
+	manager, err := processing.NewVideoProcessManager(as.ctx, workerConfig)
+
+	as.Error(err)
+	as.Nil(manager)
+}
+
+func (as *VideoProcessingSuite) TestNewVideoProcessManager_EncryptionRequiresTS() {
+	as.setupEnvironment()
+
+	// DASH players can't decrypt the segments
+	workerConfig := processing.DefaultWorkerConfig()
+	workerConfig.Packaging = processing.PackagingCMAF
+	workerConfig.Encryption = true
+
+	manager, err := processing.NewVideoProcessManager(as.ctx, workerConfig)
+	as.Error(err)
+	as.Nil(manager)
+
+	workerConfig.Packaging = processing.PackagingTS
+	manager, err = processing.NewVideoProcessManager(as.ctx, workerConfig)
+	as.NoError(err)
+	as.NotNil(manager)
+}
+
 func (as *VideoProcessingSuite) TestHandleMessage_Success() {
 	as.setupEnvironment()
 
 	// Create test video ID and data
 	videoID := uuid.Must(uuid.NewV7())
-	videoData := []byte("fake video data")
+	videoSize := int64(len("fake video data"))
 	eventMessage := messages.S3EventMessage{
 		Key: fmt.Sprintf("test/%s.mp4", videoID.String()),
 	}
@@ -43,10 +78,10 @@ func (as *VideoProcessingSuite) TestHandleMessage_Success() {
 
 	// Extract bucket and key from the event message like the actual code does
 	bucket, key := s3.ExtractBucketAndKeyFromEventMessage(videoID.String() + ".mp4")
-	as.mockS3.On("Download", key, bucket).Return(videoData, nil)
+	as.mockS3.On("DownloadToFile", key, bucket, mock.Anything).Return(videoSize, nil)
 
 	// Test getting the manager
-	manager, err := processing.NewVideoProcessManager(as.ctx)
+	manager, err := processing.NewVideoProcessManager(as.ctx, processing.DefaultWorkerConfig())
 	as.NoError(err)
 	as.NotNil(manager)
 
@@ -56,7 +91,9 @@ func (as *VideoProcessingSuite) TestHandleMessage_Success() {
 
 // Test constants
 func (as *VideoProcessingSuite) TestConstants() {
-	as.Equal(1024, processing.BatchSize)
+	as.Equal(2, processing.DefaultWorkerConcurrency)
+	as.Equal(5*time.Minute, processing.DefaultDrainTimeout)
+	as.Equal(processing.PackagingTS, processing.DefaultPackaging)
 	as.Equal("video-processing", kafka.KafkaVideoProcessingGroup)
 	as.Equal("video-uploaded", kafka.KafkaVideoUploadedTopic)
 	as.Equal("video-processed", s3.S3VideoProcessedBucket)
@@ -65,7 +102,7 @@ func (as *VideoProcessingSuite) TestConstants() {
 func (as *VideoProcessingSuite) TestHandleMessage_NilMessage() {
 	as.setupEnvironment()
 
-	manager, err := processing.NewVideoProcessManager(as.ctx)
+	manager, err := processing.NewVideoProcessManager(as.ctx, processing.DefaultWorkerConfig())
 	as.NoError(err)
 	as.NotNil(manager)
 
@@ -78,7 +115,7 @@ func (as *VideoProcessingSuite) TestHandleMessage_NilMessage() {
 func (as *VideoProcessingSuite) TestHandleMessage_InvalidJSON() {
 	as.setupEnvironment()
 
-	manager, err := processing.NewVideoProcessManager(as.ctx)
+	manager, err := processing.NewVideoProcessManager(as.ctx, processing.DefaultWorkerConfig())
 	as.NoError(err)
 	as.NotNil(manager)
 
@@ -117,16 +154,15 @@ func (as *VideoProcessingSuite) TestHandleMessage_InvalidVideoID() {
 		Timestamp: time.Now(),
 	}
 
-	// Extract bucket and key from the event message like the actual code does
-	bucket, key := s3.ExtractBucketAndKeyFromEventMessage(eventMessage.Key)
-	as.mockS3.On("Download", key, bucket).Return([]byte("fake data"), nil)
-
-	manager, err := processing.NewVideoProcessManager(as.ctx)
+	manager, err := processing.NewVideoProcessManager(as.ctx, processing.DefaultWorkerConfig())
 	as.NoError(err)
 
 	err = manager.HandleMessage(as.ctx, consumedMsg)
 	as.Error(err)
 	as.Contains(err.Error(), "invalid video id")
+
+	// The video id is validated before anything is downloaded
+	as.mockS3.AssertNotCalled(as.T(), "DownloadToFile", mock.Anything, mock.Anything, mock.Anything)
 }
 
 func (as *VideoProcessingSuite) TestHandleMessage_S3DownloadError() {
@@ -150,14 +186,157 @@ func (as *VideoProcessingSuite) TestHandleMessage_S3DownloadError() {
 		Timestamp: time.Now(),
 	}
 
+	as.mockVideoStatus(videoID, messages.VideoStatusProcessing, "")
+
 	// Extract bucket and key from the event message like the actual code does
 	bucket, key := s3.ExtractBucketAndKeyFromEventMessage(eventMessage.Key)
-	as.mockS3.On("Download", key, bucket).Return([]byte(nil), fmt.Errorf("test error: download failed"))
+	as.mockS3.On("DownloadToFile", key, bucket, mock.Anything).Return(int64(0), fmt.Errorf("test error: download failed"))
 
-	manager, err := processing.NewVideoProcessManager(as.ctx)
+	manager, err := processing.NewVideoProcessManager(as.ctx, processing.DefaultWorkerConfig())
 	as.NoError(err)
 
 	err = manager.HandleMessage(as.ctx, consumedMsg)
 	as.Error(err)
 	as.Contains(err.Error(), "download failed")
 }
+
+// mockVideoStatus makes video_management report the video in status
+func (as *VideoProcessingSuite) mockVideoStatus(videoID uuid.UUID, status messages.VideoStatus, failureCode messages.VideoFailureCode) {
+	as.mockVideoManagement.On("GetVideoStatus", mock.Anything, mock.MatchedBy(func(req *connect.Request[videoManagementProto.GetVideoStatusRequest]) bool {
+		return req.Msg.GetVideoId() == videoID.String()
+	})).Return(connect.NewResponse(&videoManagementProto.GetVideoStatusResponse{
+		VideoId:     videoID.String(),
+		Status:      string(status),
+		FailureCode: string(failureCode),
+	}), nil)
+}
+
+// uploadedMessage is the notification of an upload of size bytes for the video
+func (as *VideoProcessingSuite) uploadedMessage(videoID uuid.UUID, size int64) *kafka.ConsumedMessage {
+	eventMessage := messages.S3EventMessage{
+		Key: fmt.Sprintf("video-uploaded/%s.mp4", videoID.String()),
+		Records: []messages.S3Record{
+			{S3: messages.S3Details{Object: messages.S3Object{Size: size}}},
+		},
+	}
+
+	eventData, err := json.Marshal(eventMessage)
+	as.NoError(err)
+
+	return &kafka.ConsumedMessage{
+		Topic:     kafka.KafkaVideoUploadedTopic,
+		Key:       videoID.String(),
+		Value:     eventData,
+		Headers:   make(map[string]string),
+		Timestamp: time.Now(),
+	}
+}
+
+// progressEvent decodes a progress update published to the broker
+func (as *VideoProcessingSuite) progressEvent(msg *sarama.ConsumerMessage) messages.VideoProcessingProgress {
+	var contentType string
+	for _, header := range msg.Headers {
+		if string(header.Key) == kafka.HeaderContentType {
+			contentType = string(header.Value)
+		}
+	}
+
+	var event messages.VideoProcessingProgress
+	as.NoError(messages.Unmarshal(msg.Value, contentType, &event))
+	return event
+}
+
+func (as *VideoProcessingSuite) TestHandleMessage_SkipsMissingVideo() {
+	as.setupEnvironment()
+
+	videoID := uuid.Must(uuid.NewV7())
+	as.mockVideoManagement.On("GetVideoStatus", mock.Anything, mock.Anything).
+		Return(nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("video not found")))
+
+	manager, err := processing.NewVideoProcessManager(as.ctx, processing.DefaultWorkerConfig())
+	as.NoError(err)
+
+	err = manager.HandleMessage(as.ctx, as.uploadedMessage(videoID, 1024))
+	as.NoError(err)
+
+	as.mockS3.AssertNotCalled(as.T(), "DownloadToFile", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (as *VideoProcessingSuite) TestHandleMessage_SkipsVideoNotProcessing() {
+	as.setupEnvironment()
+
+	readyID := uuid.Must(uuid.NewV7())
+	rejectedID := uuid.Must(uuid.NewV7())
+	as.mockVideoStatus(readyID, messages.VideoStatusReady, "")
+	as.mockVideoStatus(rejectedID, messages.VideoStatusFailed, messages.VideoFailureUnsupportedCodec)
+
+	manager, err := processing.NewVideoProcessManager(as.ctx, processing.DefaultWorkerConfig())
+	as.NoError(err)
+
+	for _, videoID := range []uuid.UUID{readyID, rejectedID} {
+		err = manager.HandleMessage(as.ctx, as.uploadedMessage(videoID, 1024))
+		as.NoError(err)
+	}
+
+	as.mockS3.AssertNotCalled(as.T(), "DownloadToFile", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (as *VideoProcessingSuite) TestHandleMessage_RetriesFailedTranscode() {
+	as.setupEnvironment()
+
+	// An earlier attempt failed to transcode, the retry processes the upload again
+	videoID := uuid.Must(uuid.NewV7())
+	as.mockVideoStatus(videoID, messages.VideoStatusFailed, messages.VideoFailureProcessingError)
+	as.mockS3.On("DownloadToFile", mock.Anything, mock.Anything, mock.Anything).Return(int64(0), fmt.Errorf("test error: download failed"))
+
+	manager, err := processing.NewVideoProcessManager(as.ctx, processing.DefaultWorkerConfig())
+	as.NoError(err)
+
+	err = manager.HandleMessage(as.ctx, as.uploadedMessage(videoID, 1024))
+	as.Error(err)
+	as.Contains(err.Error(), "download failed")
+
+	// The job is reported when it starts and when it fails, with the stage it failed at
+	progress := as.broker.Messages(kafka.KafkaVideoProgressTopic)
+	as.Len(progress, 2)
+
+	started, failed := as.progressEvent(progress[0]), as.progressEvent(progress[1])
+
+	as.Equal(messages.VideoStatusProcessing, started.Status)
+	as.Equal(messages.VideoProcessingJobRunning, started.Job.Status)
+	as.Equal(1, started.Job.Attempt)
+
+	as.Equal(messages.VideoStatusFailed, failed.Status)
+	as.Equal(started.Job.ID, failed.Job.ID)
+	as.Equal(messages.VideoProcessingJobFailed, failed.Job.Status)
+	as.Len(failed.Job.Stages, 1)
+	as.Equal(messages.VideoProcessingStageDownload, failed.Job.Stages[0].Stage)
+	as.Equal(messages.VideoFailureProcessingError, failed.Job.Stages[0].ErrorCode)
+	as.Contains(failed.Job.Stages[0].ErrorMessage, "download failed")
+}
+
+func (as *VideoProcessingSuite) TestHandleMessage_StatusUnavailable() {
+	as.setupEnvironment()
+
+	videoID := uuid.Must(uuid.NewV7())
+	as.mockVideoManagement.On("GetVideoStatus", mock.Anything, mock.Anything).
+		Return(nil, connect.NewError(connect.CodeUnavailable, fmt.Errorf("connection refused")))
+
+	manager, err := processing.NewVideoProcessManager(as.ctx, processing.DefaultWorkerConfig())
+	as.NoError(err)
+
+	// The upload is retried once video_management is back
+	err = manager.HandleMessage(as.ctx, as.uploadedMessage(videoID, 1024))
+	as.Error(err)
+	as.Contains(err.Error(), "failed to get the video status")
+
+	as.mockS3.AssertNotCalled(as.T(), "DownloadToFile", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (as *VideoProcessingSuite) TestClassifyVideoFormat() {
+	as.Equal(messages.VideoFormatReel, processing.ClassifyVideoFormat(ffmpeg.OrientationPortrait, 45))
+	as.Equal(messages.VideoFormatReel, processing.ClassifyVideoFormat(ffmpeg.OrientationPortrait, processing.ReelMaxDurationSeconds))
+	as.Equal(messages.VideoFormatLongForm, processing.ClassifyVideoFormat(ffmpeg.OrientationPortrait, processing.ReelMaxDurationSeconds+1))
+	as.Equal(messages.VideoFormatLongForm, processing.ClassifyVideoFormat(ffmpeg.OrientationLandscape, 45))
+	as.Equal(messages.VideoFormatLongForm, processing.ClassifyVideoFormat(ffmpeg.OrientationPortrait, 0))
+}
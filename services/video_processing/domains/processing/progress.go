@@ -0,0 +1,20 @@
+package processing
+
+import "time"
+
+// progressThrottle lets progress updates through at most once per interval, the first one
+// right away
+type progressThrottle struct {
+	interval time.Duration
+	last     time.Time
+}
+
+// allow reports whether an update at now may be published
+func (t *progressThrottle) allow(now time.Time) bool {
+	if !t.last.IsZero() && now.Sub(t.last) < t.interval {
+		return false
+	}
+
+	t.last = now
+	return true
+}
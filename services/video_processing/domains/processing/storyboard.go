@@ -0,0 +1,89 @@
+package processing
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/cockroachdb/errors"
+	"github.com/gofrs/uuid"
+	"go.uber.org/zap"
+
+	"github.com/sweetloveinyourheart/sweet-reel/pkg/ffmpeg"
+	"github.com/sweetloveinyourheart/sweet-reel/pkg/logger"
+	"github.com/sweetloveinyourheart/sweet-reel/pkg/messages"
+	"github.com/sweetloveinyourheart/sweet-reel/pkg/s3"
+)
+
+const (
+	// StoryboardDirName holds the sprites and the WebVTT track of a storyboard, both in the
+	// temp directory and in storage, so the sprite urls of the track stay relative
+	StoryboardDirName = "storyboard"
+
+	StoryboardTrackName = ffmpeg.DefaultStoryboardTrackName
+)
+
+// createStoryboard tiles frames of the video sampled at a fixed interval into sprites written to
+// dir, along with the WebVTT track players read them through
+func (vsp *VideoProcessManager) createStoryboard(ctx context.Context, inputPath, dir string, durationSeconds float64, width, height int) (messages.VideoProcessedStoryboardData, error) {
+	options := ffmpeg.DefaultStoryboardOptions(width, height)
+	if err := vsp.ff.CreateStoryboard(ctx, inputPath, dir, options); err != nil {
+		return messages.VideoProcessedStoryboardData{}, errors.Wrap(err, "failed to create storyboard sprites")
+	}
+
+	sprites := 0
+	for {
+		if _, err := os.Stat(filepath.Join(dir, options.SpriteName(sprites))); err != nil {
+			break
+		}
+		sprites++
+	}
+	if sprites == 0 {
+		return messages.VideoProcessedStoryboardData{}, errors.New("no storyboard sprite was created")
+	}
+
+	track := ffmpeg.StoryboardTrack(durationSeconds, sprites, options)
+	if err := os.WriteFile(filepath.Join(dir, StoryboardTrackName), []byte(track), 0644); err != nil {
+		return messages.VideoProcessedStoryboardData{}, errors.Wrap(err, "failed to write storyboard track")
+	}
+
+	return messages.VideoProcessedStoryboardData{
+		IntervalSeconds: options.IntervalSeconds,
+		TileWidth:       options.TileWidth,
+		TileHeight:      options.TileHeight,
+		Columns:         options.Columns,
+		Rows:            options.Rows,
+		Sprites:         sprites,
+	}, nil
+}
+
+// uploadProcessedStoryboardFiles uploads the sprites of the storyboard before its track, which is
+// announced once every sprite it points to is stored. Like the thumbnail, a storyboard that
+// can't be uploaded does not fail the video, one that is uploaded but not announced does.
+func (vsp *VideoProcessManager) uploadProcessedStoryboardFiles(ctx context.Context, videoID uuid.UUID, dir string, data messages.VideoProcessedStoryboardData) error {
+	options := ffmpeg.StoryboardOptions{SpritePrefix: ffmpeg.DefaultStoryboardSpritePrefix}
+	for i := range data.Sprites {
+		spriteKey := fmt.Sprintf("%s/%s/%s", videoID.String(), StoryboardDirName, options.SpriteName(i))
+		if err := vsp.storageClient.UploadFile(spriteKey, s3.S3VideoProcessedBucket, filepath.Join(dir, options.SpriteName(i)), ffmpeg.MimeTypeJPEG); err != nil {
+			logger.Global().WarnContext(ctx, "Failed to upload storyboard sprite", zap.String("storage_key", spriteKey), zap.Error(err))
+			return nil
+		}
+	}
+
+	trackKey := fmt.Sprintf("%s/%s/%s", videoID.String(), StoryboardDirName, StoryboardTrackName)
+	if err := vsp.storageClient.UploadFile(trackKey, s3.S3VideoProcessedBucket, filepath.Join(dir, StoryboardTrackName), ffmpeg.MimeTypeVTT); err != nil {
+		logger.Global().WarnContext(ctx, "Failed to upload storyboard track", zap.String("storage_key", trackKey), zap.Error(err))
+		return nil
+	}
+
+	if err := vsp.publishProcessed(ctx, videoID, trackKey, messages.VideoProcessedTypeStoryboard, data); err != nil {
+		return errors.Wrap(err, "failed to publish storyboard message")
+	}
+
+	logger.Global().InfoContext(ctx, "Storyboard uploaded",
+		zap.String("storage_key", trackKey),
+		zap.Int("sprites", data.Sprites))
+
+	return nil
+}
@@ -0,0 +1,124 @@
+package processing
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/samber/lo"
+
+	"github.com/sweetloveinyourheart/sweet-reel/pkg/ffmpeg"
+	"github.com/sweetloveinyourheart/sweet-reel/pkg/messages"
+)
+
+const (
+	// DefaultMaxFileSize is the largest upload transcoded, in bytes
+	DefaultMaxFileSize = 10 << 30
+
+	// DefaultMinDurationSeconds is the shortest video transcoded
+	DefaultMinDurationSeconds = 1
+
+	// DefaultMaxDurationSeconds is the longest video transcoded
+	DefaultMaxDurationSeconds = 4 * 60 * 60
+)
+
+var (
+	// Container names as reported by ffprobe, which lists every alias of a demuxer
+	// e.g. "mov,mp4,m4a,3gp,3g2,mj2" or "matroska,webm"
+	defaultContainers = []string{"mov", "mp4", "matroska", "webm", "avi", "mpegts"}
+
+	// Codec names as reported by ffprobe
+	defaultVideoCodecs = []string{ffmpeg.CodecH264, "hevc", ffmpeg.CodecVP8, ffmpeg.CodecVP9, ffmpeg.CodecAV1, "mpeg4"}
+	defaultAudioCodecs = []string{ffmpeg.CodecAAC, ffmpeg.CodecMP3, ffmpeg.CodecOpus, ffmpeg.CodecVorbis, "ac3", "eac3", "flac"}
+)
+
+// ValidationConfig bounds the uploads accepted for transcoding. A zero limit and an
+// empty allow-list do not restrict anything.
+type ValidationConfig struct {
+	MaxFileSize        int64
+	MinDurationSeconds float64
+	MaxDurationSeconds float64
+	Containers         []string
+	VideoCodecs        []string
+	AudioCodecs        []string
+}
+
+// DefaultValidationConfig returns the default validation configuration
+func DefaultValidationConfig() *ValidationConfig {
+	return &ValidationConfig{
+		MaxFileSize:        DefaultMaxFileSize,
+		MinDurationSeconds: DefaultMinDurationSeconds,
+		MaxDurationSeconds: DefaultMaxDurationSeconds,
+		Containers:         defaultContainers,
+		VideoCodecs:        defaultVideoCodecs,
+		AudioCodecs:        defaultAudioCodecs,
+	}
+}
+
+// ValidationError rejects an upload. Processing it again would fail the same way,
+// so rejected uploads are not retried.
+type ValidationError struct {
+	Code    messages.VideoFailureCode
+	Message string
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Code, e.Message)
+}
+
+func reject(code messages.VideoFailureCode, format string, args ...any) *ValidationError {
+	return &ValidationError{Code: code, Message: fmt.Sprintf(format, args...)}
+}
+
+// ValidateFileSize checks the size in bytes of an upload
+func (c *ValidationConfig) ValidateFileSize(size int64) error {
+	if size <= 0 {
+		return reject(messages.VideoFailureEmptyFile, "the uploaded file is empty")
+	}
+
+	if c.MaxFileSize > 0 && size > c.MaxFileSize {
+		return reject(messages.VideoFailureFileTooLarge, "the uploaded file is %d bytes, at most %d are allowed", size, c.MaxFileSize)
+	}
+
+	return nil
+}
+
+// ValidateProbe checks the container, codecs and duration ffprobe found in an upload
+func (c *ValidationConfig) ValidateProbe(info *ffmpeg.ProbeInfo) error {
+	if len(c.Containers) > 0 {
+		formatNames := strings.Split(info.Format.FormatName, ",")
+		if len(lo.Intersect(formatNames, c.Containers)) == 0 {
+			return reject(messages.VideoFailureUnsupportedContainer, "container %q is not supported", info.Format.FormatName)
+		}
+	}
+
+	videoStream := info.VideoStream()
+	if videoStream == nil {
+		return reject(messages.VideoFailureNoVideoStream, "the uploaded file has no video stream")
+	}
+
+	if len(c.VideoCodecs) > 0 && !lo.Contains(c.VideoCodecs, videoStream.CodecName) {
+		return reject(messages.VideoFailureUnsupportedCodec, "video codec %q is not supported", videoStream.CodecName)
+	}
+
+	for _, stream := range info.Streams {
+		if stream.CodecType == "audio" && len(c.AudioCodecs) > 0 && !lo.Contains(c.AudioCodecs, stream.CodecName) {
+			return reject(messages.VideoFailureUnsupportedCodec, "audio codec %q is not supported", stream.CodecName)
+		}
+	}
+
+	// Some containers carry no duration, those are only bounded by the file size limit
+	duration := info.DurationSeconds()
+	if duration <= 0 {
+		return nil
+	}
+
+	if c.MinDurationSeconds > 0 && duration < c.MinDurationSeconds {
+		return reject(messages.VideoFailureDurationTooShort, "the video is %.1f seconds long, at least %.0f are required", duration, c.MinDurationSeconds)
+	}
+
+	if c.MaxDurationSeconds > 0 && duration > c.MaxDurationSeconds {
+		return reject(messages.VideoFailureDurationTooLong, "the video is %.0f seconds long, at most %.0f are allowed", duration, c.MaxDurationSeconds)
+	}
+
+	return nil
+}
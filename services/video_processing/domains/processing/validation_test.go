@@ -0,0 +1,67 @@
+package processing_test
+
+import (
+	"github.com/cockroachdb/errors"
+
+	"github.com/sweetloveinyourheart/sweet-reel/pkg/ffmpeg"
+	"github.com/sweetloveinyourheart/sweet-reel/pkg/messages"
+	"github.com/sweetloveinyourheart/sweet-reel/services/video_processing/domains/processing"
+)
+
+// failureCode returns the code of the validation error err, empty when err is nil
+func (as *VideoProcessingSuite) failureCode(err error) messages.VideoFailureCode {
+	if err == nil {
+		return ""
+	}
+
+	var validationErr *processing.ValidationError
+	as.True(errors.As(err, &validationErr), err.Error())
+	return validationErr.Code
+}
+
+func (as *VideoProcessingSuite) TestValidateFileSize() {
+	cfg := processing.DefaultValidationConfig()
+
+	as.NoError(cfg.ValidateFileSize(1024))
+	as.NoError(cfg.ValidateFileSize(processing.DefaultMaxFileSize))
+	as.Equal(messages.VideoFailureEmptyFile, as.failureCode(cfg.ValidateFileSize(0)))
+	as.Equal(messages.VideoFailureFileTooLarge, as.failureCode(cfg.ValidateFileSize(processing.DefaultMaxFileSize+1)))
+
+	// Without a limit any size is accepted
+	cfg.MaxFileSize = 0
+	as.NoError(cfg.ValidateFileSize(processing.DefaultMaxFileSize + 1))
+}
+
+func (as *VideoProcessingSuite) TestValidateProbe() {
+	probe := func(formatName string, duration string, streams ...ffmpeg.StreamInfo) *ffmpeg.ProbeInfo {
+		return &ffmpeg.ProbeInfo{
+			Format:  ffmpeg.FormatInfo{FormatName: formatName, Duration: duration},
+			Streams: streams,
+		}
+	}
+	h264 := ffmpeg.StreamInfo{CodecType: "video", CodecName: "h264", Width: 1280, Height: 720}
+	aac := ffmpeg.StreamInfo{CodecType: "audio", CodecName: "aac"}
+
+	testCases := []struct {
+		name string
+		info *ffmpeg.ProbeInfo
+		code messages.VideoFailureCode
+	}{
+		{"mp4", probe("mov,mp4,m4a,3gp,3g2,mj2", "60.0", h264, aac), ""},
+		{"webm", probe("matroska,webm", "60.0", ffmpeg.StreamInfo{CodecType: "video", CodecName: "vp9"}, ffmpeg.StreamInfo{CodecType: "audio", CodecName: "opus"}), ""},
+		{"no audio", probe("mov,mp4,m4a,3gp,3g2,mj2", "60.0", h264), ""},
+		{"unknown duration", probe("matroska,webm", "N/A", h264), ""},
+		{"unsupported container", probe("gif", "3.0", ffmpeg.StreamInfo{CodecType: "video", CodecName: "gif"}), messages.VideoFailureUnsupportedContainer},
+		{"no video stream", probe("mov,mp4,m4a,3gp,3g2,mj2", "60.0", aac), messages.VideoFailureNoVideoStream},
+		{"flv container", probe("flv", "60.0", ffmpeg.StreamInfo{CodecType: "video", CodecName: "flv1"}), messages.VideoFailureUnsupportedContainer},
+		{"unsupported video codec in mp4", probe("mov,mp4,m4a,3gp,3g2,mj2", "60.0", ffmpeg.StreamInfo{CodecType: "video", CodecName: "prores"}, aac), messages.VideoFailureUnsupportedCodec},
+		{"unsupported audio codec", probe("avi", "60.0", h264, ffmpeg.StreamInfo{CodecType: "audio", CodecName: "wmav2"}), messages.VideoFailureUnsupportedCodec},
+		{"too short", probe("mov,mp4,m4a,3gp,3g2,mj2", "0.5", h264, aac), messages.VideoFailureDurationTooShort},
+		{"too long", probe("mov,mp4,m4a,3gp,3g2,mj2", "14401.0", h264, aac), messages.VideoFailureDurationTooLong},
+	}
+
+	cfg := processing.DefaultValidationConfig()
+	for _, tc := range testCases {
+		as.Equal(tc.code, as.failureCode(cfg.ValidateProbe(tc.info)), tc.name)
+	}
+}
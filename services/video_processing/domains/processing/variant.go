@@ -0,0 +1,98 @@
+package processing
+
+import (
+	"bufio"
+	"context"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/sweetloveinyourheart/sweet-reel/pkg/ffmpeg"
+	"github.com/sweetloveinyourheart/sweet-reel/pkg/logger"
+)
+
+// variantStream is what an HLS master playlist tells players about a variant
+type variantStream struct {
+	bandwidth        int
+	averageBandwidth int
+	frameRate        float64
+	codecs           string
+}
+
+// describeVariant measures the variant of rendition segmented in dir. Bandwidths are measured
+// from the segment files and fall back to the nominal bitrates of the rendition. Frame rate and
+// codecs are probed through the playlist of the variant, which also reads fMP4 segments along
+// with their initialization segment, and left empty when it can't be probed.
+func (vsp *VideoProcessManager) describeVariant(ctx context.Context, dir string, rendition ffmpeg.Rendition) variantStream {
+	var stream variantStream
+
+	stream.bandwidth, stream.averageBandwidth = measureVariantBandwidth(dir)
+	if stream.bandwidth == 0 {
+		stream.bandwidth = ffmpeg.NominalBandwidth(rendition.VideoBitrate, rendition.AudioBitrate)
+	}
+
+	playlistPath := filepath.Join(dir, PlaylistFileName)
+	if _, err := os.Stat(playlistPath); err != nil {
+		return stream
+	}
+
+	probeInfo, err := vsp.ff.ProbeFile(ctx, playlistPath)
+	if err != nil {
+		logger.Global().WarnContext(ctx, "Failed to probe variant playlist",
+			zap.String("quality", rendition.QualityName),
+			zap.Error(err))
+		return stream
+	}
+
+	if videoStream := probeInfo.VideoStream(); videoStream != nil {
+		stream.frameRate = videoStream.FrameRate()
+	}
+	stream.codecs = probeInfo.Codecs()
+
+	return stream
+}
+
+// measureVariantBandwidth returns the peak and average bitrate, in bits per second, of the
+// segments listed in the playlist of the variant in dir. Both are zero when the playlist
+// can't be read.
+func measureVariantBandwidth(dir string) (int, int) {
+	file, err := os.Open(filepath.Join(dir, PlaylistFileName))
+	if err != nil {
+		return 0, 0
+	}
+	defer file.Close()
+
+	var peak, totalBits, totalDuration, segmentDuration float64
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case strings.HasPrefix(line, "#EXTINF:"):
+			// #EXTINF:6.000000, holds the duration of the segment on the next line
+			value, _, _ := strings.Cut(strings.TrimPrefix(line, "#EXTINF:"), ",")
+			segmentDuration, _ = strconv.ParseFloat(value, 64)
+		case line == "" || strings.HasPrefix(line, "#"):
+			continue
+		default:
+			info, err := os.Stat(filepath.Join(dir, line))
+			if err != nil || segmentDuration <= 0 {
+				continue
+			}
+
+			bits := float64(info.Size() * 8)
+			peak = max(peak, bits/segmentDuration)
+			totalBits += bits
+			totalDuration += segmentDuration
+		}
+	}
+
+	if totalDuration == 0 {
+		return 0, 0
+	}
+
+	return int(math.Ceil(peak)), int(math.Ceil(totalBits / totalDuration))
+}
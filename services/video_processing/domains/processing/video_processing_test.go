@@ -8,21 +8,32 @@ import (
 	"github.com/samber/do"
 	"github.com/stretchr/testify/suite"
 
+	"github.com/sweetloveinyourheart/sweet-reel/pkg/ffmpeg"
 	"github.com/sweetloveinyourheart/sweet-reel/pkg/kafka"
 	"github.com/sweetloveinyourheart/sweet-reel/pkg/s3"
 	testingPkg "github.com/sweetloveinyourheart/sweet-reel/pkg/testing"
+	"github.com/sweetloveinyourheart/sweet-reel/pkg/testing/fake"
 	mockPkg "github.com/sweetloveinyourheart/sweet-reel/pkg/testing/mock"
+	videoManagementConnect "github.com/sweetloveinyourheart/sweet-reel/proto/code/video_management/go/grpcconnect"
 )
 
 type VideoProcessingSuite struct {
 	*testingPkg.Suite
-	mockS3 *mockPkg.MockS3
-	ctx    context.Context
-	cancel context.CancelFunc
+	mockS3                    *mockPkg.MockS3
+	mockProcessedMessageStore *mockPkg.MockProcessedMessageStore
+	mockFFmpeg                *mockPkg.MockFFmpeg
+	mockVideoManagement       *mockPkg.MockVideoManagementClient
+	broker                    *fake.KafkaBroker
+	ctx                       context.Context
+	cancel                    context.CancelFunc
 }
 
 func (as *VideoProcessingSuite) SetupTest() {
 	as.mockS3 = new(mockPkg.MockS3)
+	as.mockProcessedMessageStore = new(mockPkg.MockProcessedMessageStore)
+	as.mockFFmpeg = new(mockPkg.MockFFmpeg)
+	as.mockVideoManagement = new(mockPkg.MockVideoManagementClient)
+	as.broker = fake.NewKafkaBroker()
 	as.ctx, as.cancel = context.WithTimeout(context.Background(), 10*time.Second)
 }
 
@@ -31,6 +42,10 @@ func (as *VideoProcessingSuite) TearDownTest() {
 		as.cancel()
 	}
 	as.mockS3 = nil
+	as.mockProcessedMessageStore = nil
+	as.mockFFmpeg = nil
+	as.mockVideoManagement = nil
+	as.broker = nil
 }
 
 func TestVideoProcessingSuite(t *testing.T) {
@@ -42,12 +57,23 @@ func TestVideoProcessingSuite(t *testing.T) {
 }
 
 func (as *VideoProcessingSuite) setupEnvironment() {
-	do.Override(nil, func(i *do.Injector) (s3.S3Storage, error) {
+	do.Override(nil, func(i *do.Injector) (s3.S3StreamStorage, error) {
 		return as.mockS3, nil
 	})
 
 	do.Override(nil, func(i *do.Injector) (*kafka.Client, error) {
-		client := &kafka.Client{}
-		return client, nil
+		return kafka.NewClient(as.broker.Config())
+	})
+
+	do.Override(nil, func(i *do.Injector) (kafka.ProcessedMessageStore, error) {
+		return as.mockProcessedMessageStore, nil
+	})
+
+	do.Override(nil, func(i *do.Injector) (ffmpeg.FFmpegInterface, error) {
+		return as.mockFFmpeg, nil
+	})
+
+	do.Override(nil, func(i *do.Injector) (videoManagementConnect.VideoManagementClient, error) {
+		return as.mockVideoManagement, nil
 	})
 }
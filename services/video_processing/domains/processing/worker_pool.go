@@ -0,0 +1,133 @@
+package processing
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultWorkerConcurrency is the number of transcoding jobs a single node runs at once
+	DefaultWorkerConcurrency = 2
+
+	// DefaultDrainTimeout is how long in-flight jobs may keep running after shutdown starts
+	DefaultDrainTimeout = 5 * time.Minute
+
+	// DefaultProgressInterval is how often the transcoding progress of a video is published
+	DefaultProgressInterval = 2 * time.Second
+
+	// DefaultPackaging keeps segments playable by every HLS player
+	DefaultPackaging = PackagingTS
+)
+
+// Packagings of the processed segments
+const (
+	// PackagingTS writes MPEG-TS segments, listed in HLS playlists only
+	PackagingTS = "ts"
+
+	// PackagingCMAF writes fMP4 segments, listed in HLS playlists and in a DASH manifest
+	PackagingCMAF = "cmaf"
+)
+
+// WorkerConfig controls how many videos a node transcodes concurrently, how it shuts down,
+// which uploads it accepts, how often it reports progress and how segments are packaged
+type WorkerConfig struct {
+	Concurrency      int
+	DrainTimeout     time.Duration
+	Validation       *ValidationConfig // nil uses DefaultValidationConfig
+	ProgressInterval time.Duration     // Zero uses DefaultProgressInterval
+	Packaging        string            // PackagingTS or PackagingCMAF, empty uses DefaultPackaging
+	Encryption       bool              // Encrypts segments with AES-128, PackagingTS only
+}
+
+// DefaultWorkerConfig returns the default worker configuration
+func DefaultWorkerConfig() *WorkerConfig {
+	return &WorkerConfig{
+		Concurrency:      DefaultWorkerConcurrency,
+		DrainTimeout:     DefaultDrainTimeout,
+		Validation:       DefaultValidationConfig(),
+		ProgressInterval: DefaultProgressInterval,
+		Packaging:        DefaultPackaging,
+	}
+}
+
+// WorkerPool runs jobs in their own goroutines with a fixed upper bound on how many run at once.
+// Submit blocks while the pool is full, which pushes back on whoever feeds it.
+type WorkerPool struct {
+	slots      chan struct{}
+	wg         sync.WaitGroup
+	jobCtx     context.Context
+	cancelJobs context.CancelFunc
+}
+
+// NewWorkerPool creates a pool that runs at most concurrency jobs at a time
+func NewWorkerPool(concurrency int) *WorkerPool {
+	if concurrency <= 0 {
+		concurrency = DefaultWorkerConcurrency
+	}
+
+	// Jobs get their own context so that a shutdown signal does not kill them
+	// immediately; they are only cancelled once draining times out.
+	jobCtx, cancel := context.WithCancel(context.Background())
+
+	return &WorkerPool{
+		slots:      make(chan struct{}, concurrency),
+		jobCtx:     jobCtx,
+		cancelJobs: cancel,
+	}
+}
+
+// Submit waits for a free slot and then starts job. If ctx is done before a slot
+// frees up, the job is not started and the context error is returned.
+func (p *WorkerPool) Submit(ctx context.Context, job func(ctx context.Context)) error {
+	select {
+	case p.slots <- struct{}{}:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	p.wg.Add(1)
+	go func() {
+		defer func() {
+			<-p.slots
+			p.wg.Done()
+		}()
+
+		job(p.jobCtx)
+	}()
+
+	return nil
+}
+
+// Capacity returns the maximum number of concurrent jobs
+func (p *WorkerPool) Capacity() int {
+	return cap(p.slots)
+}
+
+// Running returns the number of jobs currently running
+func (p *WorkerPool) Running() int {
+	return len(p.slots)
+}
+
+// Drain waits for running jobs to finish. Jobs still running after timeout have
+// their context cancelled, and Drain waits for them to return.
+// It reports whether every job finished before the timeout.
+// Submit must not be called once Drain has started.
+func (p *WorkerPool) Drain(timeout time.Duration) bool {
+	done := make(chan struct{})
+	go func() {
+		p.wg.Wait()
+		close(done)
+	}()
+
+	defer p.cancelJobs()
+
+	select {
+	case <-done:
+		return true
+	case <-time.After(timeout):
+		p.cancelJobs()
+		<-done
+		return false
+	}
+}
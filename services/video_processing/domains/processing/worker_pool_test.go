@@ -0,0 +1,73 @@
+package processing_test
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/sweetloveinyourheart/sweet-reel/services/video_processing/domains/processing"
+)
+
+func (as *VideoProcessingSuite) TestWorkerPool_LimitsConcurrency() {
+	pool := processing.NewWorkerPool(2)
+	as.Equal(2, pool.Capacity())
+
+	var running, peak atomic.Int32
+	release := make(chan struct{})
+	job := func(ctx context.Context) {
+		current := running.Add(1)
+		for {
+			old := peak.Load()
+			if current <= old || peak.CompareAndSwap(old, current) {
+				break
+			}
+		}
+		<-release
+		running.Add(-1)
+	}
+
+	as.NoError(pool.Submit(as.ctx, job))
+	as.NoError(pool.Submit(as.ctx, job))
+
+	// The pool is full, so a third submit blocks until its context expires
+	ctx, cancel := context.WithTimeout(as.ctx, 50*time.Millisecond)
+	defer cancel()
+	err := pool.Submit(ctx, job)
+	as.ErrorIs(err, context.DeadlineExceeded)
+
+	close(release)
+	as.True(pool.Drain(time.Second))
+	as.Equal(int32(2), peak.Load())
+	as.Equal(0, pool.Running())
+}
+
+func (as *VideoProcessingSuite) TestWorkerPool_DrainWaitsForRunningJobs() {
+	pool := processing.NewWorkerPool(1)
+
+	var finished atomic.Bool
+	as.NoError(pool.Submit(as.ctx, func(ctx context.Context) {
+		time.Sleep(50 * time.Millisecond)
+		finished.Store(true)
+	}))
+
+	as.True(pool.Drain(time.Second))
+	as.True(finished.Load())
+}
+
+func (as *VideoProcessingSuite) TestWorkerPool_DrainTimeoutCancelsJobs() {
+	pool := processing.NewWorkerPool(1)
+
+	var cancelled atomic.Bool
+	as.NoError(pool.Submit(as.ctx, func(ctx context.Context) {
+		<-ctx.Done()
+		cancelled.Store(true)
+	}))
+
+	as.False(pool.Drain(50 * time.Millisecond))
+	as.True(cancelled.Load())
+}
+
+func (as *VideoProcessingSuite) TestWorkerPool_DefaultConcurrency() {
+	pool := processing.NewWorkerPool(0)
+	as.Equal(processing.DefaultWorkerConcurrency, pool.Capacity())
+}
@@ -2,15 +2,19 @@ package videoprocessing
 
 import (
 	"context"
+	"embed"
 
 	"github.com/sweetloveinyourheart/sweet-reel/services/video_processing/domains/processing"
 )
 
-func InitializeRepos(ctx context.Context) error {
-	_, err := processing.NewVideoProcessManager(ctx)
+//go:embed migrations/*.sql
+var FS embed.FS
+
+func InitializeRepos(ctx context.Context, workerConfig *processing.WorkerConfig) (*processing.VideoProcessManager, error) {
+	manager, err := processing.NewVideoProcessManager(ctx, workerConfig)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	return nil
+	return manager, nil
 }
@@ -0,0 +1,115 @@
+package e2e_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/samber/do"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/sweetloveinyourheart/sweet-reel/pkg/ffmpeg"
+	"github.com/sweetloveinyourheart/sweet-reel/pkg/kafka"
+	"github.com/sweetloveinyourheart/sweet-reel/pkg/s3"
+	testingPkg "github.com/sweetloveinyourheart/sweet-reel/pkg/testing"
+	"github.com/sweetloveinyourheart/sweet-reel/pkg/testing/fake"
+	mockPkg "github.com/sweetloveinyourheart/sweet-reel/pkg/testing/mock"
+	"github.com/sweetloveinyourheart/sweet-reel/pkg/tracing"
+	videoManagementConnect "github.com/sweetloveinyourheart/sweet-reel/proto/code/video_management/go/grpcconnect"
+	"github.com/sweetloveinyourheart/sweet-reel/services/video_management/actions"
+	"github.com/sweetloveinyourheart/sweet-reel/services/video_management/domains/contentkeys"
+	"github.com/sweetloveinyourheart/sweet-reel/services/video_management/repos"
+)
+
+// E2ESuite runs services together in process, exchanging messages through an in-memory
+// Kafka broker and storing objects in an in-memory S3 storage
+type E2ESuite struct {
+	*testingPkg.Suite
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	broker      *fake.KafkaBroker
+	kafkaClient *kafka.Client
+	storage     *fake.S3Storage
+	videoRepo   *videoRepository
+	mockFFmpeg  *mockPkg.MockFFmpeg
+}
+
+func (as *E2ESuite) SetupSuite() {
+	// Propagate the trace context without exporting spans
+	_, err := tracing.Init(context.Background(), "e2e", "")
+	as.NoError(err)
+}
+
+func (as *E2ESuite) SetupTest() {
+	as.ctx, as.cancel = context.WithTimeout(context.Background(), 30*time.Second)
+
+	as.broker = fake.NewKafkaBroker()
+	kafkaClient, err := kafka.NewClient(as.broker.Config())
+	as.NoError(err)
+	as.kafkaClient = kafkaClient
+
+	// MinIO notifies the processing service of every upload
+	as.storage = fake.NewS3Storage()
+	as.storage.NotifyKafka(s3.S3VideoUploadedBucket, as.kafkaClient, kafka.KafkaVideoUploadedTopic)
+
+	as.videoRepo = newVideoRepository()
+	as.mockFFmpeg = new(mockPkg.MockFFmpeg)
+}
+
+func (as *E2ESuite) TearDownTest() {
+	if as.cancel != nil {
+		as.cancel()
+	}
+	if as.kafkaClient != nil {
+		as.kafkaClient.Close()
+	}
+
+	as.broker = nil
+	as.kafkaClient = nil
+	as.storage = nil
+	as.videoRepo = nil
+	as.mockFFmpeg = nil
+}
+
+func TestE2ESuite(t *testing.T) {
+	as := &E2ESuite{
+		Suite: testingPkg.MakeSuite(t),
+	}
+
+	suite.Run(t, as)
+}
+
+func (as *E2ESuite) setupEnvironment() {
+	do.Override(nil, func(i *do.Injector) (*kafka.Client, error) {
+		return as.kafkaClient, nil
+	})
+
+	do.Override(nil, func(i *do.Injector) (s3.S3Storage, error) {
+		return as.storage, nil
+	})
+
+	do.Override(nil, func(i *do.Injector) (s3.S3StreamStorage, error) {
+		return as.storage, nil
+	})
+
+	do.Override(nil, func(i *do.Injector) (kafka.ProcessedMessageStore, error) {
+		return fake.NewProcessedMessageStore(), nil
+	})
+
+	do.Override(nil, func(i *do.Injector) (ffmpeg.FFmpegInterface, error) {
+		return as.mockFFmpeg, nil
+	})
+
+	do.Override(nil, func(i *do.Injector) (*contentkeys.Cipher, error) {
+		return contentkeys.NewCipher("content-key-encryption-key")
+	})
+
+	do.Override(nil, func(i *do.Injector) (repos.IVideoAggregateRepository, error) {
+		return as.videoRepo, nil
+	})
+
+	do.Override(nil, func(i *do.Injector) (videoManagementConnect.VideoManagementClient, error) {
+		return &videoManagementClient{handler: actions.NewActions(as.ctx, "signing-token")}, nil
+	})
+}
@@ -0,0 +1,27 @@
+package e2e_test
+
+import (
+	"context"
+
+	"connectrpc.com/connect"
+
+	proto "github.com/sweetloveinyourheart/sweet-reel/proto/code/video_management/go"
+	videoManagementConnect "github.com/sweetloveinyourheart/sweet-reel/proto/code/video_management/go/grpcconnect"
+)
+
+// videoManagementClient serves the calls other services make to video_management by
+// invoking its handler in process. Calls the pipeline does not make panic through the
+// nil embedded interface.
+type videoManagementClient struct {
+	videoManagementConnect.VideoManagementClient
+
+	handler videoManagementConnect.VideoManagementHandler
+}
+
+func (c *videoManagementClient) GetVideoStatus(ctx context.Context, request *connect.Request[proto.GetVideoStatusRequest]) (*connect.Response[proto.GetVideoStatusResponse], error) {
+	return c.handler.GetVideoStatus(ctx, request)
+}
+
+func (c *videoManagementClient) StoreContentKey(ctx context.Context, request *connect.Request[proto.StoreContentKeyRequest]) (*connect.Response[proto.StoreContentKeyResponse], error) {
+	return c.handler.StoreContentKey(ctx, request)
+}
@@ -0,0 +1,528 @@
+package e2e_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/IBM/sarama"
+	"github.com/gofrs/uuid"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/mock"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/sweetloveinyourheart/sweet-reel/pkg/ffmpeg"
+	"github.com/sweetloveinyourheart/sweet-reel/pkg/kafka"
+	"github.com/sweetloveinyourheart/sweet-reel/pkg/s3"
+	proto "github.com/sweetloveinyourheart/sweet-reel/proto/code/video_management/go"
+	"github.com/sweetloveinyourheart/sweet-reel/services/video_management/actions"
+	vmProcessing "github.com/sweetloveinyourheart/sweet-reel/services/video_management/domains/processing"
+	"github.com/sweetloveinyourheart/sweet-reel/services/video_management/models"
+	vpProcessing "github.com/sweetloveinyourheart/sweet-reel/services/video_processing/domains/processing"
+)
+
+const (
+	// The source video is a 12 seconds 720p landscape clip, cut into 6 seconds segments
+	sourceWidth           = 1280
+	sourceHeight          = 720
+	sourceDurationSeconds = 12
+	sourceSegments        = 2
+)
+
+// mockTranscoding makes the ffmpeg mock probe the source video and write the files a
+// transcode of it produces
+func (as *E2ESuite) mockTranscoding() {
+	as.mockFFmpeg.On("IsAvailable", mock.Anything).Return(nil)
+
+	as.mockFFmpeg.On("ProbeFile", mock.Anything, mock.Anything).Return(&ffmpeg.ProbeInfo{
+		Format: ffmpeg.FormatInfo{
+			FormatName: "mov,mp4,m4a,3gp,3g2,mj2",
+			Duration:   fmt.Sprintf("%d.000000", sourceDurationSeconds),
+		},
+		Streams: []ffmpeg.StreamInfo{
+			{CodecType: "video", CodecName: "h264", Profile: "High", Level: 31, AvgFrameRate: "30/1", Width: sourceWidth, Height: sourceHeight},
+			{CodecType: "audio", CodecName: "aac", Profile: "LC"},
+		},
+	}, nil)
+
+	as.mockFFmpeg.On("SegmentVideoMultiQuality", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			outputDir := args.String(2)
+			qualities := args.Get(3).([]ffmpeg.SegmentationOptions)
+
+			// Halfway through, reported twice in a row of which only the first is published
+			progress := args.Get(4).(ffmpeg.ProgressCallback)
+			for range 2 {
+				progress(ffmpeg.ProgressInfo{
+					Percentage: 50,
+					Duration:   time.Duration(len(qualities)*sourceDurationSeconds) * time.Second,
+					Current:    time.Duration(len(qualities)*sourceDurationSeconds) * time.Second / 2,
+					Speed:      "4.2x",
+				})
+			}
+
+			master := []string{"#EXTM3U"}
+			for _, quality := range qualities {
+				dir := filepath.Join(outputDir, quality.QualityName)
+				as.NoError(os.MkdirAll(dir, 0755))
+
+				// fMP4 segments are preceded by their initialization segment
+				playlist := []string{"#EXTM3U", "#EXT-X-TARGETDURATION:6"}
+				if quality.SegmentType == ffmpeg.SegmentTypeFMP4 {
+					as.NoError(os.WriteFile(filepath.Join(dir, quality.InitSegmentName), []byte("init"), 0644))
+					playlist = append(playlist, `#EXT-X-MAP:URI="`+quality.InitSegmentName+`"`)
+				}
+
+				// Encrypted segments point to the key uri of the key info file
+				if quality.EnableEncryption {
+					keyInfo, err := os.ReadFile(quality.KeyInfoFile)
+					as.NoError(err)
+					keyURI, _, _ := strings.Cut(string(keyInfo), "\n")
+					playlist = append(playlist, `#EXT-X-KEY:METHOD=AES-128,URI="`+keyURI+`"`)
+				}
+				for i := range sourceSegments {
+					segment := fmt.Sprintf("%s_%03d.%s", quality.SegmentPrefix, i, quality.SegmentFormat)
+					as.NoError(os.WriteFile(filepath.Join(dir, segment), []byte("segment"), 0644))
+					playlist = append(playlist, "#EXTINF:6.000000,", segment)
+				}
+				playlist = append(playlist, "#EXT-X-ENDLIST")
+				as.NoError(os.WriteFile(filepath.Join(dir, quality.PlaylistName), []byte(strings.Join(playlist, "\n")), 0644))
+
+				master = append(master, "#EXT-X-STREAM-INF:RESOLUTION="+quality.Resolution, quality.QualityName+"/"+quality.PlaylistName)
+			}
+			as.NoError(os.WriteFile(filepath.Join(outputDir, ffmpeg.MasterPlaylistName), []byte(strings.Join(master, "\n")), 0644))
+		}).
+		Return(nil)
+
+	as.mockFFmpeg.On("CreateThumbnail", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			as.NoError(os.WriteFile(args.String(2), []byte("thumbnail"), 0644))
+		}).
+		Return(nil)
+
+	as.mockFFmpeg.On("CreateStoryboard", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			options := args.Get(3).(ffmpeg.StoryboardOptions)
+			as.NoError(os.MkdirAll(args.String(2), 0755))
+			as.NoError(os.WriteFile(filepath.Join(args.String(2), options.SpriteName(0)), []byte("sprite"), 0644))
+		}).
+		Return(nil)
+}
+
+func (as *E2ESuite) TestVideoPipeline_UploadIsProcessed() {
+	as.setupEnvironment()
+	as.mockTranscoding()
+
+	processingManager, err := vpProcessing.NewVideoProcessManager(as.ctx, vpProcessing.DefaultWorkerConfig())
+	as.NoError(err)
+
+	_, err = vmProcessing.NewVideoProcessManager(as.ctx)
+	as.NoError(err)
+
+	// The upload is requested within a trace, which processing continues
+	traceID := trace.TraceID(uuid.Must(uuid.NewV4()))
+	ctx := trace.ContextWithRemoteSpanContext(as.ctx, trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     trace.SpanID{1, 2, 3, 4, 5, 6, 7, 8},
+		TraceFlags: trace.FlagsSampled,
+		Remote:     true,
+	}))
+
+	uploaderID := uuid.Must(uuid.NewV7())
+	channelID := uuid.Must(uuid.NewV7())
+	response, err := actions.NewActions(as.ctx, "signing-token").PresignedUrl(ctx, connect.NewRequest(&proto.PresignedUrlRequest{
+		UploaderId: uploaderID.String(),
+		ChannelId:  channelID.String(),
+		Title:      "Pipeline",
+		FileName:   "pipeline.mp4",
+	}))
+	as.NoError(err)
+
+	videoID := uuid.FromStringOrNil(response.Msg.GetVideoId())
+	as.NotEqual(uuid.Nil, videoID)
+
+	// Upload the source like the browser does
+	err = as.storage.UploadPresigned(response.Msg.GetPresignedUrl(), bytes.NewReader([]byte("source video")), "video/mp4", response.Msg.GetUploadHeaders())
+	as.NoError(err)
+
+	as.Eventually(func() bool {
+		video, err := as.videoRepo.GetVideoByID(context.Background(), videoID)
+		return err == nil && video.Status == models.VideoStatusReady
+	}, 10*time.Second, 20*time.Millisecond)
+
+	video, err := as.videoRepo.GetVideoByID(context.Background(), videoID)
+	as.NoError(err)
+	as.Equal(models.VideoFormatLongForm, video.Format)
+	as.True(strings.HasSuffix(video.GetObjectKey(), videoID.String()+".mp4"))
+	as.False(video.GetProcessedAt().IsZero())
+
+	// The processed files are announced before the video is ready, but handled concurrently
+	var variants []*models.VideoVariant
+	as.Eventually(func() bool {
+		variants, err = as.videoRepo.GetVideoVariantsByVideoID(context.Background(), videoID)
+		return err == nil && len(variants) == 2
+	}, 5*time.Second, 20*time.Millisecond)
+
+	for _, variant := range variants {
+		as.Contains([]string{vpProcessing.Quality480p, vpProcessing.Quality720p}, variant.Quality)
+		as.Equal(sourceSegments, variant.GetTotalSegments())
+		as.Equal(sourceDurationSeconds, variant.GetTotalDuration())
+
+		// Stream info is measured from the segments written for the variant
+		as.Positive(variant.GetBandwidth())
+		as.NotEmpty(variant.GetResolution())
+		as.Equal(30.0, variant.GetFrameRate())
+		as.Equal("avc1.64001f,mp4a.40.2", variant.GetCodecs())
+	}
+
+	as.Eventually(func() bool {
+		manifests, err := as.videoRepo.GetVideoManifestsByVideoID(context.Background(), videoID)
+		thumbnails, terr := as.videoRepo.GetVideoThumbnailsByVideoID(context.Background(), videoID)
+		// One playlist per rendition, plus the master playlist
+		return err == nil && terr == nil && len(manifests) == 3 && len(thumbnails) == 1
+	}, 5*time.Second, 20*time.Millisecond)
+
+	// The master playlist lists both renditions from what was recorded about them
+	playlist, err := actions.NewActions(as.ctx, "signing-token").ServePlaylist(as.ctx, connect.NewRequest(&proto.ServePlaylistRequest{
+		VideoId:  videoID.String(),
+		ViewerId: uploaderID.String(),
+	}))
+	as.NoError(err)
+	as.Equal(2, strings.Count(playlist.Msg.GetPlaylist(), `CODECS="avc1.64001f,mp4a.40.2"`))
+	as.Contains(playlist.Msg.GetPlaylist(), vpProcessing.Quality480p+"/"+ffmpeg.DefaultPlaylistName)
+	as.Contains(playlist.Msg.GetPlaylist(), vpProcessing.Quality720p+"/"+ffmpeg.DefaultPlaylistName)
+
+	thumbnails, err := as.videoRepo.GetVideoThumbnailsByVideoID(context.Background(), videoID)
+	as.NoError(err)
+	as.Equal(vpProcessing.ThumbnailWidth, thumbnails[0].GetWidth())
+	as.Equal(vpProcessing.ThumbnailHeight, thumbnails[0].GetHeight())
+
+	// Every file referenced in the database was stored
+	for _, variant := range variants {
+		_, ok := as.storage.Object(variant.ObjectKey, s3.S3VideoProcessedBucket)
+		as.True(ok, variant.ObjectKey)
+	}
+	_, ok := as.storage.Object(thumbnails[0].ObjectKey, s3.S3VideoProcessedBucket)
+	as.True(ok, thumbnails[0].ObjectKey)
+
+	// The sprite of the storyboard is signed when its track is served
+	var storyboard *models.VideoStoryboard
+	as.Eventually(func() bool {
+		storyboard, err = as.videoRepo.GetVideoStoryboardByVideoID(context.Background(), videoID)
+		return err == nil
+	}, 5*time.Second, 20*time.Millisecond)
+	as.Equal(1, storyboard.Sprites)
+	as.Equal(ffmpeg.DefaultStoryboardTileSize, storyboard.TileWidth)
+	as.Equal(90, storyboard.TileHeight)
+
+	track, err := actions.NewActions(as.ctx, "signing-token").ServeStoryboard(as.ctx, connect.NewRequest(&proto.ServeStoryboardRequest{
+		VideoId:  videoID.String(),
+		ViewerId: uploaderID.String(),
+	}))
+	as.NoError(err)
+	// A frame every 5 seconds of the 12 seconds of the source
+	as.Equal(3, strings.Count(track.Msg.GetTrack(), "-->"))
+	as.Contains(track.Msg.GetTrack(), "#xywh=0,0,160,90")
+	as.NotContains(track.Msg.GetTrack(), "\n"+ffmpeg.DefaultStoryboardSpritePrefix)
+	_, ok = as.storage.Object(videoID.String()+"/storyboard/storyboard_000.jpg", s3.S3VideoProcessedBucket)
+	as.True(ok)
+
+	// Processing joined the trace of the upload request, the job is reported when it starts,
+	// while it transcodes and when it is done
+	progress := as.broker.Messages(kafka.KafkaVideoProgressTopic)
+	as.Len(progress, 3)
+	for _, msg := range progress {
+		as.Contains(headerValue(msg.Headers, "traceparent"), traceID.String())
+	}
+
+	var jobs []*models.VideoProcessingJob
+	as.Eventually(func() bool {
+		jobs, err = as.videoRepo.GetVideoProcessingJobsByVideoID(context.Background(), videoID)
+		return err == nil && len(jobs) == 1 && jobs[0].Status == models.VideoProcessingJobSucceeded
+	}, 5*time.Second, 20*time.Millisecond)
+	as.Equal(int32(1), jobs[0].Attempt)
+	as.NotNil(jobs[0].FinishedAt)
+	as.Equal(50.0, jobs[0].Progress.Percentage)
+	as.Equal("4.2x", jobs[0].Progress.Speed)
+	as.Equal([]string{"download", "probe", "segment", "thumbnail", "storyboard", "upload"}, lo.Map(jobs[0].Stages, func(stage models.VideoProcessingStage, _ int) string {
+		return stage.Stage
+	}))
+
+	// Every message was consumed and committed
+	for _, topic := range []string{kafka.KafkaVideoUploadedTopic, kafka.KafkaVideoProgressTopic, kafka.KafkaVideoProcessedTopic} {
+		published := int64(len(as.broker.Messages(topic)))
+		as.Eventually(func() bool {
+			return as.broker.Committed(kafka.KafkaVideoProcessingGroup, topic) == published
+		}, 5*time.Second, 20*time.Millisecond, topic)
+	}
+
+	// Processing stops consuming and drains its workers on shutdown
+	as.cancel()
+	processingManager.Wait()
+}
+
+func (as *E2ESuite) TestVideoPipeline_RejectedUploadIsFailed() {
+	as.setupEnvironment()
+
+	// The upload is an audio file renamed to .mp4
+	as.mockFFmpeg.On("IsAvailable", mock.Anything).Return(nil)
+	as.mockFFmpeg.On("ProbeFile", mock.Anything, mock.Anything).Return(&ffmpeg.ProbeInfo{
+		Format: ffmpeg.FormatInfo{
+			FormatName: "mov,mp4,m4a,3gp,3g2,mj2",
+			Duration:   fmt.Sprintf("%d.000000", sourceDurationSeconds),
+		},
+		Streams: []ffmpeg.StreamInfo{
+			{CodecType: "audio", CodecName: "aac"},
+		},
+	}, nil)
+
+	processingManager, err := vpProcessing.NewVideoProcessManager(as.ctx, vpProcessing.DefaultWorkerConfig())
+	as.NoError(err)
+
+	_, err = vmProcessing.NewVideoProcessManager(as.ctx)
+	as.NoError(err)
+
+	response, err := actions.NewActions(as.ctx, "signing-token").PresignedUrl(as.ctx, connect.NewRequest(&proto.PresignedUrlRequest{
+		UploaderId: uuid.Must(uuid.NewV7()).String(),
+		ChannelId:  uuid.Must(uuid.NewV7()).String(),
+		Title:      "Not a video",
+		FileName:   "song.mp4",
+	}))
+	as.NoError(err)
+
+	videoID := uuid.FromStringOrNil(response.Msg.GetVideoId())
+	err = as.storage.UploadPresigned(response.Msg.GetPresignedUrl(), bytes.NewReader([]byte("source audio")), "video/mp4", response.Msg.GetUploadHeaders())
+	as.NoError(err)
+
+	as.Eventually(func() bool {
+		video, err := as.videoRepo.GetVideoByID(context.Background(), videoID)
+		return err == nil && video.Status == models.VideoStatusFailed
+	}, 10*time.Second, 20*time.Millisecond)
+
+	// The reason is kept on the video and reported to the uploader
+	video, err := as.videoRepo.GetVideoByID(context.Background(), videoID)
+	as.NoError(err)
+	as.Equal(&models.VideoFailure{Code: "no_video_stream", Reason: "the uploaded file has no video stream"}, video.GetFailure())
+
+	// Rejected uploads are settled at once instead of being retried, and never transcoded
+	as.Eventually(func() bool {
+		return as.broker.Committed(kafka.KafkaVideoProcessingGroup, kafka.KafkaVideoUploadedTopic) == 1
+	}, 5*time.Second, 20*time.Millisecond)
+	as.Empty(as.broker.Messages(kafka.DeadLetterTopic(kafka.KafkaVideoUploadedTopic)))
+	as.Len(as.broker.Messages(kafka.KafkaVideoProgressTopic), 2)
+	as.mockFFmpeg.AssertNotCalled(as.T(), "SegmentVideoMultiQuality", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+
+	// The job stopped at the probe
+	var jobs []*models.VideoProcessingJob
+	as.Eventually(func() bool {
+		jobs, err = as.videoRepo.GetVideoProcessingJobsByVideoID(context.Background(), videoID)
+		return err == nil && len(jobs) == 1 && jobs[0].Status == models.VideoProcessingJobFailed
+	}, 5*time.Second, 20*time.Millisecond)
+	as.Equal("no_video_stream", *jobs[0].ErrorCode)
+	as.Len(jobs[0].Stages, 2)
+	as.Equal("probe", jobs[0].Stages[1].Stage)
+	as.Equal("no_video_stream", jobs[0].Stages[1].ErrorCode)
+
+	as.cancel()
+	processingManager.Wait()
+}
+
+func (as *E2ESuite) TestVideoPipeline_VideoDeletedWhileProcessedIsCleanedUp() {
+	as.setupEnvironment()
+
+	// The uploader deletes the video while it is transcoded, the files stored by then are gone
+	var videoID uuid.UUID
+	as.mockFFmpeg.On("CreateStoryboard", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			as.NoError(as.videoRepo.DeleteVideo(context.Background(), videoID))
+		}).
+		Return(nil)
+	as.mockTranscoding()
+
+	processingManager, err := vpProcessing.NewVideoProcessManager(as.ctx, vpProcessing.DefaultWorkerConfig())
+	as.NoError(err)
+
+	_, err = vmProcessing.NewVideoProcessManager(as.ctx)
+	as.NoError(err)
+
+	response, err := actions.NewActions(as.ctx, "signing-token").PresignedUrl(as.ctx, connect.NewRequest(&proto.PresignedUrlRequest{
+		UploaderId: uuid.Must(uuid.NewV7()).String(),
+		ChannelId:  uuid.Must(uuid.NewV7()).String(),
+		Title:      "Deleted",
+		FileName:   "deleted.mp4",
+	}))
+	as.NoError(err)
+
+	videoID = uuid.FromStringOrNil(response.Msg.GetVideoId())
+	video, err := as.videoRepo.GetVideoByID(context.Background(), videoID)
+	as.NoError(err)
+	sourceKey := video.GetObjectKey()
+	as.NotEmpty(sourceKey)
+
+	err = as.storage.UploadPresigned(response.Msg.GetPresignedUrl(), bytes.NewReader([]byte("source video")), "video/mp4", response.Msg.GetUploadHeaders())
+	as.NoError(err)
+
+	// The job ends without failing, nothing of the video is left in storage
+	as.Eventually(func() bool {
+		return as.broker.Committed(kafka.KafkaVideoProcessingGroup, kafka.KafkaVideoUploadedTopic) == 1
+	}, 10*time.Second, 20*time.Millisecond)
+	as.Empty(as.broker.Messages(kafka.DeadLetterTopic(kafka.KafkaVideoUploadedTopic)))
+	as.Empty(as.storage.Keys(videoID.String()+"/", s3.S3VideoProcessedBucket))
+	_, ok := as.storage.Object(sourceKey, s3.S3VideoUploadedBucket)
+	as.False(ok)
+
+	manifests, err := as.videoRepo.GetVideoManifestsByVideoID(context.Background(), videoID)
+	as.NoError(err)
+	as.Empty(manifests)
+
+	as.cancel()
+	processingManager.Wait()
+}
+
+func (as *E2ESuite) TestVideoPipeline_CMAFUploadIsServedAsDASH() {
+	as.setupEnvironment()
+	as.mockTranscoding()
+
+	workerConfig := vpProcessing.DefaultWorkerConfig()
+	workerConfig.Packaging = vpProcessing.PackagingCMAF
+	processingManager, err := vpProcessing.NewVideoProcessManager(as.ctx, workerConfig)
+	as.NoError(err)
+
+	_, err = vmProcessing.NewVideoProcessManager(as.ctx)
+	as.NoError(err)
+
+	uploaderID := uuid.Must(uuid.NewV7())
+	response, err := actions.NewActions(as.ctx, "signing-token").PresignedUrl(as.ctx, connect.NewRequest(&proto.PresignedUrlRequest{
+		UploaderId: uploaderID.String(),
+		ChannelId:  uuid.Must(uuid.NewV7()).String(),
+		Title:      "CMAF",
+		FileName:   "cmaf.mp4",
+	}))
+	as.NoError(err)
+
+	videoID := uuid.FromStringOrNil(response.Msg.GetVideoId())
+	err = as.storage.UploadPresigned(response.Msg.GetPresignedUrl(), bytes.NewReader([]byte("source video")), "video/mp4", response.Msg.GetUploadHeaders())
+	as.NoError(err)
+
+	// One playlist per rendition and the master playlist, plus the DASH manifest
+	as.Eventually(func() bool {
+		manifests, err := as.videoRepo.GetVideoManifestsByVideoID(context.Background(), videoID)
+		variants, verr := as.videoRepo.GetVideoVariantsByVideoID(context.Background(), videoID)
+		return err == nil && verr == nil && len(manifests) == 4 && len(variants) == 2
+	}, 10*time.Second, 20*time.Millisecond)
+
+	// Segments are counted the same as MPEG-TS ones
+	variants, err := as.videoRepo.GetVideoVariantsByVideoID(context.Background(), videoID)
+	as.NoError(err)
+	for _, variant := range variants {
+		as.Equal(sourceSegments, variant.GetTotalSegments())
+		as.True(strings.HasSuffix(variant.ObjectKey, ffmpeg.ExtM4S), variant.ObjectKey)
+	}
+
+	// HLS players are pointed to the DASH manifest next to the master playlist
+	master, err := actions.NewActions(as.ctx, "signing-token").ServePlaylist(as.ctx, connect.NewRequest(&proto.ServePlaylistRequest{
+		VideoId:  videoID.String(),
+		ViewerId: uploaderID.String(),
+	}))
+	as.NoError(err)
+	as.Equal(ffmpeg.DefaultDASHManifestName, master.Msg.GetDashManifestUrl())
+
+	// The DASH manifest lists the fMP4 segments of both renditions with signed urls
+	dash, err := actions.NewActions(as.ctx, "signing-token").ServePlaylist(as.ctx, connect.NewRequest(&proto.ServePlaylistRequest{
+		VideoId:  videoID.String(),
+		ViewerId: uploaderID.String(),
+		Format:   "dash",
+	}))
+	as.NoError(err)
+
+	mpd, err := ffmpeg.ParseMPD([]byte(dash.Msg.GetPlaylist()))
+	as.NoError(err)
+	as.Equal(float64(sourceDurationSeconds), mpd.DurationSeconds())
+
+	representations := mpd.Periods[0].AdaptationSets[0].Representations
+	as.Len(representations, 2)
+	for _, representation := range representations {
+		as.Equal("avc1.64001f,mp4a.40.2", representation.Codecs)
+		as.Equal("30", representation.FrameRate)
+		as.Positive(representation.Bandwidth)
+		as.Contains(representation.SegmentList.Initialization.SourceURL, videoID.String()+"/hls/"+representation.ID+"/"+ffmpeg.DefaultInitSegmentName)
+		as.Len(representation.SegmentList.SegmentURLs, sourceSegments)
+
+		// Every listed file was stored
+		_, ok := as.storage.Object(videoID.String()+"/hls/"+representation.ID+"/"+ffmpeg.DefaultInitSegmentName, s3.S3VideoProcessedBucket)
+		as.True(ok, representation.ID)
+	}
+
+	as.cancel()
+	processingManager.Wait()
+}
+
+func (as *E2ESuite) TestVideoPipeline_EncryptedUploadServesKeyToViewers() {
+	as.setupEnvironment()
+	as.mockTranscoding()
+
+	workerConfig := vpProcessing.DefaultWorkerConfig()
+	workerConfig.Encryption = true
+	processingManager, err := vpProcessing.NewVideoProcessManager(as.ctx, workerConfig)
+	as.NoError(err)
+
+	_, err = vmProcessing.NewVideoProcessManager(as.ctx)
+	as.NoError(err)
+
+	uploaderID := uuid.Must(uuid.NewV7())
+	response, err := actions.NewActions(as.ctx, "signing-token").PresignedUrl(as.ctx, connect.NewRequest(&proto.PresignedUrlRequest{
+		UploaderId: uploaderID.String(),
+		ChannelId:  uuid.Must(uuid.NewV7()).String(),
+		Title:      "Encrypted",
+		FileName:   "encrypted.mp4",
+	}))
+	as.NoError(err)
+
+	videoID := uuid.FromStringOrNil(response.Msg.GetVideoId())
+	err = as.storage.UploadPresigned(response.Msg.GetPresignedUrl(), bytes.NewReader([]byte("source video")), "video/mp4", response.Msg.GetUploadHeaders())
+	as.NoError(err)
+
+	as.Eventually(func() bool {
+		manifests, err := as.videoRepo.GetVideoManifestsByVideoID(context.Background(), videoID)
+		return err == nil && len(manifests) == 3
+	}, 10*time.Second, 20*time.Millisecond)
+
+	// The key is stored with video management before the segments, and never uploaded
+	key, err := actions.NewActions(as.ctx, "signing-token").GetContentKey(as.ctx, connect.NewRequest(&proto.GetContentKeyRequest{
+		VideoId:  videoID.String(),
+		ViewerId: uploaderID.String(),
+	}))
+	as.NoError(err)
+	as.Len(key.Msg.GetKey(), ffmpeg.AES128KeySize)
+	for _, objectKey := range as.storage.Keys(videoID.String()+"/", s3.S3VideoProcessedBucket) {
+		as.NotContains(objectKey, vpProcessing.ContentKeyFileName)
+		as.NotContains(objectKey, vpProcessing.KeyInfoFileName)
+	}
+
+	// Players fetch the key from the gateway
+	playlist, err := actions.NewActions(as.ctx, "signing-token").ServePlaylist(as.ctx, connect.NewRequest(&proto.ServePlaylistRequest{
+		VideoId:  videoID.String(),
+		ViewerId: uploaderID.String(),
+		Quality:  ffmpeg.Quality720p,
+	}))
+	as.NoError(err)
+	as.Contains(playlist.Msg.GetPlaylist(), `#EXT-X-KEY:METHOD=AES-128,URI="`+actions.ContentKeyUrl+`"`)
+
+	as.cancel()
+	processingManager.Wait()
+}
+
+// headerValue returns the value of the header of a consumed message named key
+func headerValue(headers []*sarama.RecordHeader, key string) string {
+	for _, header := range headers {
+		if string(header.Key) == key {
+			return string(header.Value)
+		}
+	}
+	return ""
+}
@@ -0,0 +1,294 @@
+package e2e_test
+
+import (
+	"context"
+	"database/sql"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/sweetloveinyourheart/sweet-reel/pkg/messages"
+	"github.com/sweetloveinyourheart/sweet-reel/services/video_management/models"
+	"github.com/sweetloveinyourheart/sweet-reel/services/video_management/repos"
+)
+
+// outboxEvent is an event enqueued by video_management for the outbox relay
+type outboxEvent struct {
+	Topic string
+	Key   string
+	Event messages.Event
+}
+
+// videoRepository keeps the tables of video_management that the upload pipeline writes in
+// memory. Upserts follow the unique keys of the tables. Operations the pipeline does not
+// use panic through the nil embedded interface.
+type videoRepository struct {
+	repos.IVideoAggregateRepository
+
+	mu          sync.Mutex
+	videos      map[uuid.UUID]*models.Video
+	manifests   map[uuid.UUID]map[string]*models.VideoManifest
+	variants    map[uuid.UUID]map[string]*models.VideoVariant
+	thumbnails  map[uuid.UUID]map[string]*models.VideoThumbnail
+	jobs        map[uuid.UUID]*models.VideoProcessingJob
+	keys        map[uuid.UUID]*models.VideoContentKey
+	storyboards map[uuid.UUID]*models.VideoStoryboard
+	processed   map[string]struct{}
+	outbox      []outboxEvent
+}
+
+func newVideoRepository() *videoRepository {
+	return &videoRepository{
+		videos:      make(map[uuid.UUID]*models.Video),
+		manifests:   make(map[uuid.UUID]map[string]*models.VideoManifest),
+		variants:    make(map[uuid.UUID]map[string]*models.VideoVariant),
+		thumbnails:  make(map[uuid.UUID]map[string]*models.VideoThumbnail),
+		jobs:        make(map[uuid.UUID]*models.VideoProcessingJob),
+		keys:        make(map[uuid.UUID]*models.VideoContentKey),
+		storyboards: make(map[uuid.UUID]*models.VideoStoryboard),
+		processed:   make(map[string]struct{}),
+	}
+}
+
+// InTransaction runs fn with the repository itself, nothing is rolled back when it fails
+func (r *videoRepository) InTransaction(ctx context.Context, scope string, fn func(repo repos.IVideoAggregateRepository) error) error {
+	return fn(r)
+}
+
+func (r *videoRepository) CreateVideo(ctx context.Context, video *models.Video) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	created := *video
+	created.CreatedAt = time.Now()
+	created.UpdatedAt = created.CreatedAt
+	r.videos[video.ID] = &created
+	return nil
+}
+
+func (r *videoRepository) GetVideoByID(ctx context.Context, id uuid.UUID) (*models.Video, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	video, ok := r.videos[id]
+	if !ok {
+		return nil, sql.ErrNoRows
+	}
+	found := *video
+	return &found, nil
+}
+
+func (r *videoRepository) GetVideoCountByChannelID(ctx context.Context, channelID uuid.UUID) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var count int64
+	for _, video := range r.videos {
+		if video.ChannelID == channelID {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (r *videoRepository) DeleteVideo(ctx context.Context, id uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.videos, id)
+	return nil
+}
+
+func (r *videoRepository) UpdateVideoProgress(ctx context.Context, id uuid.UUID, objectKey string, status models.VideoStatus, processedAt time.Time, failure *models.VideoFailure) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if video, ok := r.videos[id]; ok {
+		video.ObjectKey = &objectKey
+		video.Status = status
+		video.ProcessedAt = &processedAt
+		video.FailureCode, video.FailureReason = nil, nil
+		if failure != nil {
+			video.FailureCode, video.FailureReason = &failure.Code, &failure.Reason
+		}
+	}
+	return nil
+}
+
+func (r *videoRepository) UpdateVideoFormat(ctx context.Context, id uuid.UUID, format models.VideoFormat) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if video, ok := r.videos[id]; ok {
+		video.Format = format
+	}
+	return nil
+}
+
+func (r *videoRepository) CreateVideoManifest(ctx context.Context, manifest *models.VideoManifest) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.manifests[manifest.VideoID] == nil {
+		r.manifests[manifest.VideoID] = make(map[string]*models.VideoManifest)
+	}
+	// One manifest per format and quality, like the unique key of video_manifests
+	created := *manifest
+	r.manifests[manifest.VideoID][string(manifest.Format)+"/"+manifest.Quality] = &created
+	return nil
+}
+
+func (r *videoRepository) GetVideoManifestsByVideoID(ctx context.Context, videoID uuid.UUID) ([]*models.VideoManifest, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var manifests []*models.VideoManifest
+	for _, manifest := range r.manifests[videoID] {
+		found := *manifest
+		manifests = append(manifests, &found)
+	}
+	return manifests, nil
+}
+
+func (r *videoRepository) CreateVideoVariant(ctx context.Context, variant *models.VideoVariant) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.variants[variant.VideoID] == nil {
+		r.variants[variant.VideoID] = make(map[string]*models.VideoVariant)
+	}
+	created := *variant
+	r.variants[variant.VideoID][variant.Quality] = &created
+	return nil
+}
+
+func (r *videoRepository) GetVideoVariantsByVideoID(ctx context.Context, videoID uuid.UUID) ([]*models.VideoVariant, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var variants []*models.VideoVariant
+	for _, variant := range r.variants[videoID] {
+		found := *variant
+		variants = append(variants, &found)
+	}
+	return variants, nil
+}
+
+func (r *videoRepository) CreateVideoThumbnail(ctx context.Context, thumbnail *models.VideoThumbnail) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.thumbnails[thumbnail.VideoID] == nil {
+		r.thumbnails[thumbnail.VideoID] = make(map[string]*models.VideoThumbnail)
+	}
+	created := *thumbnail
+	r.thumbnails[thumbnail.VideoID][thumbnail.ObjectKey] = &created
+	return nil
+}
+
+func (r *videoRepository) GetVideoThumbnailsByVideoID(ctx context.Context, videoID uuid.UUID) ([]*models.VideoThumbnail, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var thumbnails []*models.VideoThumbnail
+	for _, thumbnail := range r.thumbnails[videoID] {
+		found := *thumbnail
+		thumbnails = append(thumbnails, &found)
+	}
+	return thumbnails, nil
+}
+
+func (r *videoRepository) UpsertVideoProcessingJob(ctx context.Context, job *models.VideoProcessingJob) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existing, ok := r.jobs[job.ID]
+	if ok && existing.Status != models.VideoProcessingJobRunning {
+		return nil
+	}
+	if ok && job.Progress == nil {
+		job.Progress = existing.Progress
+	}
+	r.jobs[job.ID] = job
+	return nil
+}
+
+func (r *videoRepository) GetVideoProcessingJobsByVideoID(ctx context.Context, videoID uuid.UUID) ([]*models.VideoProcessingJob, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var jobs []*models.VideoProcessingJob
+	for _, job := range r.jobs {
+		if job.VideoID == videoID {
+			jobs = append(jobs, job)
+		}
+	}
+	slices.SortFunc(jobs, func(a, b *models.VideoProcessingJob) int {
+		return a.StartedAt.Compare(b.StartedAt)
+	})
+	return jobs, nil
+}
+
+func (r *videoRepository) UpsertVideoContentKey(ctx context.Context, key *models.VideoContentKey) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored := *key
+	r.keys[key.VideoID] = &stored
+	return nil
+}
+
+func (r *videoRepository) GetVideoContentKeyByVideoID(ctx context.Context, videoID uuid.UUID) (*models.VideoContentKey, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key, ok := r.keys[videoID]
+	if !ok {
+		return nil, sql.ErrNoRows
+	}
+	found := *key
+	return &found, nil
+}
+
+func (r *videoRepository) UpsertVideoStoryboard(ctx context.Context, storyboard *models.VideoStoryboard) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored := *storyboard
+	r.storyboards[storyboard.VideoID] = &stored
+	return nil
+}
+
+func (r *videoRepository) GetVideoStoryboardByVideoID(ctx context.Context, videoID uuid.UUID) (*models.VideoStoryboard, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	storyboard, ok := r.storyboards[videoID]
+	if !ok {
+		return nil, sql.ErrNoRows
+	}
+	found := *storyboard
+	return &found, nil
+}
+
+func (r *videoRepository) EnqueueEvent(ctx context.Context, topic string, key string, event messages.Event) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.outbox = append(r.outbox, outboxEvent{Topic: topic, Key: key, Event: event})
+	return nil
+}
+
+func (r *videoRepository) MarkMessageProcessed(ctx context.Context, group string, messageID string) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := group + "/" + messageID
+	if _, ok := r.processed[key]; ok {
+		return false, nil
+	}
+	r.processed[key] = struct{}{}
+	return true, nil
+}
@@ -71,6 +71,7 @@ export class S3Client {
     options?: {
       contentType?: string;
       acl?: string;
+      headers?: Record<string, string>;
       onProgress?: (progress: number) => void;
     }
   ): Promise<void> {
@@ -113,6 +114,10 @@ export class S3Client {
         xhr.setRequestHeader('Content-Type', options.contentType);
       }
 
+      for (const [name, value] of Object.entries(options?.headers ?? {})) {
+        xhr.setRequestHeader(name, value);
+      }
+
       xhr.send(file);
     });
   }
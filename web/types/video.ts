@@ -13,6 +13,7 @@ export type PresignedUrlResponse = {
     video_id: string
     presigned_url: string
     expires_in: string
+    upload_headers?: Record<string, string>
 }
 
 export type ChannelVideos = {